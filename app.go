@@ -468,9 +468,18 @@ func NewTrueRepublicApp(logger log.Logger, db dbm.DB, homeDir string, baseAppOpt
 
 // PreBlocker runs x/upgrade before every BeginBlock. At a due height the old
 // binary halts before any module state is changed; a handler-bearing candidate
-// applies its deterministic migration in the same cached FinalizeBlock.
-func (app *TrueRepublicApp) PreBlocker(ctx sdk.Context, _ *abci.RequestFinalizeBlock) (*sdk.ResponsePreBlock, error) {
-	return app.mm.PreBlock(ctx)
+// applies its deterministic migration in the same cached FinalizeBlock. The
+// block's anonymous rating proofs, up to MaxBlockProofBatch of them, are then
+// batch-verified against the migrated state.
+func (app *TrueRepublicApp) PreBlocker(ctx sdk.Context, req *abci.RequestFinalizeBlock) (*sdk.ResponsePreBlock, error) {
+	resp, err := app.mm.PreBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req != nil {
+		app.tdKeeper.PrepareProofBatch(ctx, req.Txs, app.txConfig.TxDecoder())
+	}
+	return resp, nil
 }
 
 // registerUpgradeHandler is the only application-level handler registration
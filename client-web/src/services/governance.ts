@@ -28,7 +28,7 @@ function mapDomain(domain: ChainDomain, denom: string): Domain {
     domainId: domain.name,
     name: domain.name,
     treasury: treasuryAmount(domain.treasury, denom),
-    memberCount: domain.members?.length ?? 0,
+    memberCount: domain.member_count ?? domain.members?.length ?? 0,
     createdAt: '',
   };
 }
@@ -34,6 +34,8 @@ export interface ChainDomain {
   permission_reg: string[] | null;
   identity_commits: string[] | null;
   merkle_root: string;
+  /** Set by the paginated Domains query, which omits the member list. */
+  member_count?: number;
 }
 
 export interface ChainMerkleProof {
@@ -1,8 +1,8 @@
 use cosmwasm_std::{QuerierWrapper, QueryRequest, StdResult};
 
 use crate::query::{
-    DomainMembersResponse, DomainResponse, DomainTreasuryResponse, IssueResponse,
-    NullifierResponse, PurgeScheduleResponse, SuggestionResponse, TrueRepublicQuery,
+    AnonymousSignalsResponse, DomainMembersResponse, DomainResponse, DomainTreasuryResponse,
+    IssueResponse, NullifierResponse, PurgeScheduleResponse, SuggestionResponse, TrueRepublicQuery,
 };
 
 pub fn query_domain(
@@ -75,3 +75,18 @@ pub fn query_domain_treasury(
         domain_name: domain_name.to_string(),
     }))
 }
+
+pub fn query_anonymous_signals(
+    querier: &QuerierWrapper<TrueRepublicQuery>,
+    domain_name: &str,
+    topic: &str,
+    start_after: Option<String>,
+    limit: Option<u32>,
+) -> StdResult<AnonymousSignalsResponse> {
+    querier.query(&QueryRequest::Custom(TrueRepublicQuery::AnonymousSignals {
+        domain_name: domain_name.to_string(),
+        topic: topic.to_string(),
+        start_after,
+        limit,
+    }))
+}
@@ -33,6 +33,15 @@ pub enum TrueRepublicMsg {
         recipient: String,
         amount: String,
     },
+    SignalWithProof {
+        domain_name: String,
+        topic: String,
+        signal: String,
+        proof: String,
+        nullifier_hash: String,
+        #[serde(default, skip_serializing_if = "Option::is_none")]
+        merkle_root: Option<String>,
+    },
 }
 
 impl CustomMsg for TrueRepublicMsg {}
@@ -36,6 +36,14 @@ pub enum TrueRepublicQuery {
     DomainTreasury {
         domain_name: String,
     },
+    AnonymousSignals {
+        domain_name: String,
+        topic: String,
+        #[serde(default, skip_serializing_if = "Option::is_none")]
+        start_after: Option<String>,
+        #[serde(default, skip_serializing_if = "Option::is_none")]
+        limit: Option<u32>,
+    },
 }
 
 impl CustomQuery for TrueRepublicQuery {}
@@ -122,3 +130,15 @@ pub struct DomainTreasuryResponse {
     pub domain_name: String,
     pub amount: String,
 }
+
+#[derive(Serialize, Deserialize, Clone, Debug, PartialEq, JsonSchema)]
+pub struct AnonymousSignal {
+    pub nullifier_hash: String,
+    pub signal: String,
+    pub height: i64,
+}
+
+#[derive(Serialize, Deserialize, Clone, Debug, PartialEq, JsonSchema)]
+pub struct AnonymousSignalsResponse {
+    pub signals: Vec<AnonymousSignal>,
+}
@@ -47,7 +47,7 @@ func ensureConsensusGenesis(cdc codec.Codec, appState map[string]json.RawMessage
 				if matched[i] || !bytes.Equal(appValidator.PubKey, consensusValidator.PubKey.GetEd25519()) {
 					continue
 				}
-				wantPower := appValidator.Stake / rewards.StakeMin
+				wantPower := appValidator.BondedStake() / rewards.StakeMin
 				if consensusValidator.Power != wantPower {
 					return fmt.Errorf("application and consensus power differ for validator %q", appValidator.OperatorAddr)
 				}
@@ -1,6 +1,8 @@
-// Package zkpprover implements the isolated, test-only Groth16 prover used by
-// the maintained-client compatibility harness. It must never be treated as a
-// production ceremony or submission boundary.
+// Package zkpprover implements the isolated Groth16 membership prover. Prove
+// accepts only the pinned synthetic artifacts of the maintained-client
+// compatibility harness and must never be treated as a production ceremony or
+// submission boundary; ProveMember proves real witnesses with ceremony
+// artifacts pinned by the chain's verifying-key fingerprint.
 package zkpprover
 
 import (
@@ -32,7 +34,8 @@ const (
 	VerifyingArtifactSHA256 = "80b92df9562e48d4b25df9e7105e54f6d79250a3f35171250fcfd45c1489e289"
 )
 
-// Request contains one synthetic witness and its frozen public context. All
+// Request contains one witness and its frozen public context.
+// SyntheticAndTestOnly must be true for Prove and false for ProveMember. All
 // field elements use canonical lowercase big-endian hex.
 type Request struct {
 	Schema               string   `json:"schema"`
@@ -89,8 +92,28 @@ func Prove(csBytes, pkBytes, vkBytes []byte, request Request) (Result, error) {
 	if err := verifyArtifact("verifying key", vkBytes, VerifyingKeySize, VerifyingArtifactSHA256); err != nil {
 		return Result{}, err
 	}
+	return prove(csBytes, pkBytes, vkBytes, request, true)
+}
+
+// ProveMember proves a real member's witness with ceremony artifacts. The
+// verifying key must match vkSHA256, the fingerprint the chain reports for
+// its consensus key, and the pinned synthetic key is refused because its
+// setup randomness is public. A proving key from another setup cannot produce
+// a proof that passes the final self-verification.
+func ProveMember(csBytes, pkBytes, vkBytes []byte, vkSHA256 string, request Request) (Result, error) {
+	digest := sha256.Sum256(vkBytes)
+	fingerprint := hex.EncodeToString(digest[:])
+	if fingerprint == VerifyingArtifactSHA256 {
+		return Result{}, fmt.Errorf("verifying key is the synthetic test-only fixture")
+	}
+	if fingerprint != vkSHA256 {
+		return Result{}, fmt.Errorf("verifying key SHA-256 does not match the chain verifying key")
+	}
+	return prove(csBytes, pkBytes, vkBytes, request, false)
+}
 
-	assignment, publicInputs, identitySecret, err := validateRequest(request)
+func prove(csBytes, pkBytes, vkBytes []byte, request Request, synthetic bool) (Result, error) {
+	assignment, publicInputs, identitySecret, err := validateRequest(request, synthetic)
 	if err != nil {
 		return Result{}, err
 	}
@@ -135,7 +158,7 @@ func Prove(csBytes, pkBytes, vkBytes []byte, request Request) (Result, error) {
 	return Result{
 		Schema:               ResultSchema,
 		CircuitID:            zkpcircuit.ID,
-		SyntheticAndTestOnly: true,
+		SyntheticAndTestOnly: synthetic,
 		ProofHex:             hex.EncodeToString(proofBuffer.Bytes()),
 		NullifierHashHex:     hex.EncodeToString(publicInputs[1]),
 		MerkleRootHex:        hex.EncodeToString(publicInputs[0]),
@@ -182,8 +205,8 @@ func verifyArtifact(label string, data []byte, expectedSize int, expectedSHA256
 	return nil
 }
 
-func validateRequest(request Request) (zkpcircuit.MembershipCircuit, [4][]byte, []byte, error) {
-	if request.Schema != RequestSchema || request.CircuitID != zkpcircuit.ID || !request.SyntheticAndTestOnly {
+func validateRequest(request Request, synthetic bool) (zkpcircuit.MembershipCircuit, [4][]byte, []byte, error) {
+	if request.Schema != RequestSchema || request.CircuitID != zkpcircuit.ID || request.SyntheticAndTestOnly != synthetic {
 		return zkpcircuit.MembershipCircuit{}, [4][]byte{}, nil, fmt.Errorf("unsafe or incompatible prover request metadata")
 	}
 	if len(request.SiblingsHex) != zkpcircuit.MerkleDepth || len(request.PathIndices) != zkpcircuit.MerkleDepth {
@@ -99,6 +99,25 @@ func TestProverFailsClosedBeforeProofGeneration(t *testing.T) {
 	})
 }
 
+func TestProveMemberRefusesSyntheticArtifactsAndRequests(t *testing.T) {
+	request, cs, pk, vk := loadFixture(t)
+	production := request
+	production.SyntheticAndTestOnly = false
+	if _, err := zkpprover.ProveMember(cs, pk, vk, pinnedVerifyingKeyFingerprint(t), production); err == nil || !strings.Contains(err.Error(), "synthetic test-only") {
+		t.Fatalf("synthetic verifying key result = %v", err)
+	}
+
+	otherVK := append([]byte(nil), vk...)
+	otherVK[len(otherVK)-1] ^= 1
+	otherFingerprint := truedemocracy.VerifyingKeyFingerprint(otherVK)
+	if _, err := zkpprover.ProveMember(cs, pk, otherVK, pinnedVerifyingKeyFingerprint(t), production); err == nil || !strings.Contains(err.Error(), "does not match the chain") {
+		t.Fatalf("fingerprint mismatch result = %v", err)
+	}
+	if _, err := zkpprover.ProveMember(cs, pk, otherVK, otherFingerprint, request); err == nil || !strings.Contains(err.Error(), "unsafe or incompatible") {
+		t.Fatalf("synthetic-marked request result = %v", err)
+	}
+}
+
 func TestDecodeRequestStrictRejectsAmbiguousJSON(t *testing.T) {
 	tests := []string{
 		`{"schema":"a","schema":"b"}`,
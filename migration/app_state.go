@@ -235,7 +235,7 @@ func validateConsensusGenesis(
 
 func exportedValidatorPower(validator truedemocracy.GenesisValidator) (int64, bool) {
 	if validator.Active == nil {
-		power := validator.Stake / rewards.StakeMin
+		power := validator.BondedStake() / rewards.StakeMin
 		return power, !validator.Jailed && power > 0
 	}
 	return validator.Power, *validator.Active
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 
 	"truerepublic/x/dex"
 	"truerepublic/x/truedemocracy"
@@ -24,6 +25,21 @@ func TestSupportedModuleGRPCQueryRoutesAreRegistered(t *testing.T) {
 		"/truedemocracy.Query/ZKPState",
 		"/truedemocracy.Query/MerkleProof",
 		"/truedemocracy.Query/PayToPut",
+		"/truedemocracy.Query/DomainIssues",
+		"/truedemocracy.Query/IssueSuggestions",
+		"/truedemocracy.Query/DomainMembers",
+		"/truedemocracy.Query/IssueDecision",
+		"/truedemocracy.Query/IssueDecisions",
+		"/truedemocracy.Query/VotingWeight",
+		"/truedemocracy.Query/SubDomains",
+		"/truedemocracy.Query/TreasuryPayouts",
+		"/truedemocracy.Query/DomainOptionsHistory",
+		"/truedemocracy.Query/AnonymousSignals",
+		"/truedemocracy.Query/StakeDelegations",
+		"/truedemocracy.Query/PendingUndelegations",
+		"/truedemocracy.Query/StakingRewards",
+		"/truedemocracy.Query/ValidatorSet",
+		"/truedemocracy.Query/EpochInfo",
 		"/dex.Query/Pool",
 		"/dex.Query/Pools",
 		"/dex.Query/RegisteredAssets",
@@ -78,7 +94,9 @@ func TestSupportedModuleGRPCQueriesExecuteThroughABCI(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	tdRequest, err := app.appCodec.Marshal(&truedemocracy.QueryDomainsRequest{})
+	tdRequest, err := app.appCodec.Marshal(&truedemocracy.QueryDomainsRequest{
+		Pagination: &query.PageRequest{Limit: 10, CountTotal: true},
+	})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -103,6 +121,9 @@ func TestSupportedModuleGRPCQueriesExecuteThroughABCI(t *testing.T) {
 	if len(domains) != 1 || domains[0].Name != "Test" {
 		t.Fatalf("unexpected domain query result: %+v", domains)
 	}
+	if domainsResponse.Pagination == nil || domainsResponse.Pagination.Total != 1 {
+		t.Fatalf("unexpected domain query pagination: %+v", domainsResponse.Pagination)
+	}
 
 	payToPutRequest, err := app.appCodec.Marshal(&truedemocracy.QueryPayToPutRequest{DomainName: "Test"})
 	if err != nil {
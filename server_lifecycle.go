@@ -57,6 +57,8 @@ import (
 	"truerepublic/topologypolicy"
 	"truerepublic/x/dex"
 	"truerepublic/x/truedemocracy"
+	"truerepublic/zkpceremony"
+	"truerepublic/zkpclient"
 )
 
 const envPrefix = "TRUEREPUBLIC"
@@ -259,6 +261,8 @@ func newRootCmd() *cobra.Command {
 		deploymentevidence.NewCommand(),
 		releaseevidence.NewCommand(),
 		healthcheck.NewCommand(),
+		zkpceremony.NewCommand(),
+		zkpclient.NewCommand(),
 	)
 	return rootCmd
 }
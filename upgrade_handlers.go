@@ -34,8 +34,11 @@ func (app *TrueRepublicApp) registerReleaseUpgradeHandlers() {
 }
 
 // v041UpgradeHandler runs registered module migrations and records a
-// deterministic application marker. x/upgrade executes this inside the cached
-// FinalizeBlock, so any error discards both module and marker writes.
+// deterministic application marker. For truedemocracy these are the store
+// migrations registered in its RegisterServices, from the chain's version up
+// to its ConsensusVersion. x/upgrade executes
+// this inside the cached FinalizeBlock, so any error discards both module and
+// marker writes.
 func (app *TrueRepublicApp) v041UpgradeHandler(
 	ctx context.Context,
 	plan upgradetypes.Plan,
@@ -8,6 +8,7 @@ import (
 
 	upgradetypes "cosmossdk.io/x/upgrade/types"
 	"github.com/cometbft/cometbft/proto/tendermint/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"truerepublic/x/truedemocracy"
 )
@@ -27,8 +28,8 @@ func TestV041UpgradeHandlerAppliesMarkerExactlyOnce(t *testing.T) {
 	if err != nil || updated == nil {
 		t.Fatalf("v0.4.1 handler failed: versions=%v err=%v", updated, err)
 	}
-	if got := updated[truedemocracy.ModuleName]; got != 2 {
-		t.Fatalf("truedemocracy module version = %d, want 2", got)
+	if got := updated[truedemocracy.ModuleName]; got != 6 {
+		t.Fatalf("truedemocracy module version = %d, want 6", got)
 	}
 	marker := sdkCtx.KVStore(app.keys[truedemocracy.ModuleName]).Get(governedUpgradeMarkerV041)
 	if !bytes.Equal(marker, []byte{1}) {
@@ -42,6 +43,41 @@ func TestV041UpgradeHandlerAppliesMarkerExactlyOnce(t *testing.T) {
 	}
 }
 
+func TestV041UpgradeHandlerSplitsLegacyDomainBlobs(t *testing.T) {
+	app := newGenesisTestApp(t)
+	if err := initGenesisApp(app, defaultGenesisForApp(app)); err != nil {
+		t.Fatal(err)
+	}
+	ctx := app.NewUncachedContext(false, types.Header{Height: 1})
+	legacy := truedemocracy.Domain{
+		Name:    "LegacyBlob",
+		Admin:   sdk.AccAddress("admin1"),
+		Members: []string{sdk.AccAddress("admin1").String(), "alice"},
+		Issues: []truedemocracy.Issue{{
+			Name:        "Budget",
+			Suggestions: []truedemocracy.Suggestion{{Name: "Plan", Creator: "alice", Ratings: []truedemocracy.Rating{{DomainPubKeyHex: "aa", Value: 3}}}},
+		}},
+	}
+	ctx.KVStore(app.keys[truedemocracy.ModuleName]).Set([]byte("domain:"+legacy.Name), app.cdc.MustMarshalLengthPrefixed(&legacy))
+
+	fromVM := app.mm.GetVersionMap()
+	fromVM[truedemocracy.ModuleName] = 2
+	if _, err := app.v041UpgradeHandler(ctx, upgradetypes.Plan{Name: governedUpgradePlanV041, Height: 1}, fromVM); err != nil {
+		t.Fatal(err)
+	}
+
+	header, found := app.tdKeeper.GetDomainHeader(ctx, legacy.Name)
+	if !found || header.Members != nil || header.Issues != nil {
+		t.Fatalf("legacy blob was not split: found=%v header=%+v", found, header)
+	}
+	if !app.tdKeeper.IsDomainMember(ctx, legacy.Name, "alice") {
+		t.Fatal("migrated member is not indexed")
+	}
+	if ratings := app.tdKeeper.GetSuggestionRatings(ctx, legacy.Name, "Budget", "Plan"); len(ratings) != 1 || ratings[0].Value != 3 {
+		t.Fatalf("migrated ratings = %+v", ratings)
+	}
+}
+
 func TestV041FailingFixtureWritesOnlyToProvidedContext(t *testing.T) {
 	app := newGenesisTestApp(t)
 	if err := initGenesisApp(app, defaultGenesisForApp(app)); err != nil {
@@ -2,9 +2,10 @@ package truedemocracy
 
 import (
 	"encoding/hex"
-	"fmt"
+	"sort"
 
 	errorsmod "cosmossdk.io/errors"
+	storeprefix "cosmossdk.io/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
@@ -18,36 +19,22 @@ func (k Keeper) JoinPermissionRegister(ctx sdk.Context, domainName, memberAddr s
 		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain public key must be 32 bytes (ed25519)")
 	}
 
-	domain, found := k.GetDomain(ctx, domainName)
-	if !found {
+	if _, found := k.GetDomainHeader(ctx, domainName); !found {
 		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
 
 	// Verify the caller is a domain member.
-	isMember := false
-	for _, m := range domain.Members {
-		if m == memberAddr {
-			isMember = true
-			break
-		}
-	}
-	if !isMember {
+	if !k.IsDomainMember(ctx, domainName, memberAddr) {
 		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only domain members can join the permission register")
 	}
 
 	// Check for duplicate key.
 	keyHex := hex.EncodeToString(domainPubKey)
-	for _, existing := range domain.PermissionReg {
-		if existing == keyHex {
-			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain key already registered")
-		}
+	if k.hasPermissionKey(ctx, domainName, keyHex) {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain key already registered")
 	}
 
-	domain.PermissionReg = append(domain.PermissionReg, keyHex)
-
-	store := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	k.addPermissionKey(ctx, domainName, keyHex)
 	return nil
 }
 
@@ -56,7 +43,7 @@ func (k Keeper) JoinPermissionRegister(ctx sdk.Context, domainName, memberAddr s
 // keys before they can vote. Members who have been removed from the domain
 // cannot re-register. Only the domain admin can trigger a purge.
 func (k Keeper) PurgePermissionRegister(ctx sdk.Context, domainName string, caller sdk.AccAddress) error {
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
@@ -65,27 +52,14 @@ func (k Keeper) PurgePermissionRegister(ctx sdk.Context, domainName string, call
 		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only the domain admin can purge the permission register")
 	}
 
-	domain.PermissionReg = []string{}
-
-	store := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	k.clearPermissionRegister(ctx, domainName)
 	return nil
 }
 
 // IsKeyAuthorized checks whether a hex-encoded domain public key is present
 // in the domain's permission register.
 func (k Keeper) IsKeyAuthorized(ctx sdk.Context, domainName string, domainPubKeyHex string) bool {
-	domain, found := k.GetDomain(ctx, domainName)
-	if !found {
-		return false
-	}
-	for _, key := range domain.PermissionReg {
-		if key == domainPubKeyHex {
-			return true
-		}
-	}
-	return false
+	return k.hasPermissionKey(ctx, domainName, domainPubKeyHex)
 }
 
 // HasDomainKeyVoted checks whether a domain key has already voted on a
@@ -186,7 +160,7 @@ func (k Keeper) ApproveOnboardingRequest(ctx sdk.Context, domainName, requesterA
 	}
 
 	// Verify caller is domain admin.
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
@@ -223,7 +197,7 @@ func (k Keeper) RejectOnboardingRequest(ctx sdk.Context, domainName, requesterAd
 	}
 
 	// Verify caller is domain admin.
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
@@ -237,54 +211,192 @@ func (k Keeper) RejectOnboardingRequest(ctx sdk.Context, domainName, requesterAd
 }
 
 // ---------- ZKP Verifying Key Storage (v0.3.0) ----------
+//
+// Every hash family has its own verifying-key slot. The MiMC family keeps the
+// original keys; other families suffix them with "/{family}":
+//
+//   "zkp:verifying-key[/{family}]"          → serialized Groth16 key
+//   "zkp:circuit-id[/{family}]"             → circuit of that key
+//   "zkp:retiring-verifying-key[/{family}]" → RetiringVerifyingKey
+//
+// A domain's proofs verify under the slot of its circuit's family.
+
+func zkpFamilyKey(base, family string) []byte {
+	if family == HashFamilyMiMC {
+		return []byte(base)
+	}
+	return []byte(base + "/" + family)
+}
 
-// GetVerifyingKey retrieves the serialized Groth16 verifying key from the KV store.
+// GetVerifyingKey retrieves the serialized Groth16 verifying key of the MiMC
+// circuit family from the KV store.
 func (k Keeper) GetVerifyingKey(ctx sdk.Context) ([]byte, bool) {
+	return k.GetFamilyVerifyingKey(ctx, HashFamilyMiMC)
+}
+
+// GetFamilyVerifyingKey retrieves the current verifying key of a hash family.
+func (k Keeper) GetFamilyVerifyingKey(ctx sdk.Context, family string) ([]byte, bool) {
 	store := ctx.KVStore(k.StoreKey)
-	bz := store.Get([]byte("zkp:verifying-key"))
+	bz := store.Get(zkpFamilyKey("zkp:verifying-key", family))
 	if bz == nil {
 		return nil, false
 	}
 	return bz, true
 }
 
-// SetVerifyingKey stores the serialized Groth16 verifying key.
+// SetVerifyingKey stores the serialized Groth16 verifying key of the MiMC
+// circuit family.
 func (k Keeper) SetVerifyingKey(ctx sdk.Context, vkBytes []byte) {
+	k.setFamilyVerifyingKey(ctx, HashFamilyMiMC, vkBytes)
+}
+
+func (k Keeper) setFamilyVerifyingKey(ctx sdk.Context, family string, vkBytes []byte) {
 	store := ctx.KVStore(k.StoreKey)
-	store.Set([]byte("zkp:verifying-key"), vkBytes)
+	store.Set(zkpFamilyKey("zkp:verifying-key", family), vkBytes)
 }
 
 // EnsureVerifyingKey returns the consensus-configured Groth16 verifying key.
 // Setup must never run in transaction execution: Groth16 setup is randomized
 // and would let validators derive different consensus state.
 func (k Keeper) EnsureVerifyingKey(ctx sdk.Context) ([]byte, error) {
-	if vkBytes, found := k.GetVerifyingKey(ctx); found {
+	return k.ensureFamilyVerifyingKey(ctx, HashFamilyMiMC)
+}
+
+func (k Keeper) ensureFamilyVerifyingKey(ctx sdk.Context, family string) ([]byte, error) {
+	if vkBytes, found := k.GetFamilyVerifyingKey(ctx, family); found {
 		return vkBytes, nil
 	}
-	return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "ZKP verifying key is not configured in genesis")
+	if family == HashFamilyMiMC {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "ZKP verifying key is not configured in genesis")
+	}
+	return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "ZKP verifying key for hash family %s is not configured", family)
+}
+
+// GetMembershipCircuitID returns the circuit the current MiMC-family
+// verifying key was made for. Chains configured before verifying-key
+// rotation store no ID and run the original circuit.
+func (k Keeper) GetMembershipCircuitID(ctx sdk.Context) string {
+	return k.GetFamilyCircuitID(ctx, HashFamilyMiMC)
+}
+
+// GetFamilyCircuitID returns the circuit the current verifying key of a hash
+// family was made for.
+func (k Keeper) GetFamilyCircuitID(ctx sdk.Context, family string) string {
+	bz := ctx.KVStore(k.StoreKey).Get(zkpFamilyKey("zkp:circuit-id", family))
+	if bz == nil {
+		return hashFamilyCircuits[family]
+	}
+	return string(bz)
+}
+
+// setMembershipCircuitID records circuitID for the slot of its family.
+func (k Keeper) setMembershipCircuitID(ctx sdk.Context, circuitID string) {
+	family := membershipCircuitNullifierFamilies[circuitID]
+	ctx.KVStore(k.StoreKey).Set(zkpFamilyKey("zkp:circuit-id", family), []byte(circuitID))
+}
+
+// GetRetiringVerifyingKey returns the MiMC-family key replaced by the last
+// rotation, if any. It verifies proofs only while its transition window is
+// open.
+func (k Keeper) GetRetiringVerifyingKey(ctx sdk.Context) (RetiringVerifyingKey, bool) {
+	return k.GetFamilyRetiringVerifyingKey(ctx, HashFamilyMiMC)
+}
+
+// GetFamilyRetiringVerifyingKey returns the key of a hash family replaced by
+// its last rotation, if any.
+func (k Keeper) GetFamilyRetiringVerifyingKey(ctx sdk.Context, family string) (RetiringVerifyingKey, bool) {
+	bz := ctx.KVStore(k.StoreKey).Get(zkpFamilyKey("zkp:retiring-verifying-key", family))
+	if bz == nil {
+		return RetiringVerifyingKey{}, false
+	}
+	var retiring RetiringVerifyingKey
+	k.cdc.MustUnmarshalLengthPrefixed(bz, &retiring)
+	return retiring, true
+}
+
+// setRetiringVerifyingKey stores retiring in the slot of its circuit's family.
+func (k Keeper) setRetiringVerifyingKey(ctx sdk.Context, retiring RetiringVerifyingKey) {
+	family := membershipCircuitNullifierFamilies[retiring.CircuitID]
+	ctx.KVStore(k.StoreKey).Set(zkpFamilyKey("zkp:retiring-verifying-key", family), k.cdc.MustMarshalLengthPrefixed(&retiring))
+}
+
+func (k Keeper) deleteRetiringVerifyingKey(ctx sdk.Context, family string) {
+	ctx.KVStore(k.StoreKey).Delete(zkpFamilyKey("zkp:retiring-verifying-key", family))
+}
+
+// activeRetiringVerifyingKey returns a family's retiring key while its
+// transition window is open at the current height.
+func (k Keeper) activeRetiringVerifyingKey(ctx sdk.Context, family string) (RetiringVerifyingKey, bool) {
+	retiring, found := k.GetFamilyRetiringVerifyingKey(ctx, family)
+	if !found || ctx.BlockHeight() >= retiring.EndHeight {
+		return RetiringVerifyingKey{}, false
+	}
+	return retiring, true
+}
+
+// acceptedVerifyingKeys returns the keys a membership proof of a hash family
+// may verify under: the current key first, then the retiring key during its
+// transition window.
+func (k Keeper) acceptedVerifyingKeys(ctx sdk.Context, family string) ([][]byte, error) {
+	vkBytes, err := k.ensureFamilyVerifyingKey(ctx, family)
+	if err != nil {
+		return nil, err
+	}
+	keys := [][]byte{vkBytes}
+	if retiring, ok := k.activeRetiringVerifyingKey(ctx, family); ok {
+		retiringBytes, err := hex.DecodeString(retiring.VerifyingKeyHex)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, retiringBytes)
+	}
+	return keys, nil
+}
+
+// exportCircuitVerifyingKeys returns the configured key slots of the hash
+// families other than MiMC, in family order, each with its retiring key while
+// that is still accepted.
+func (k Keeper) exportCircuitVerifyingKeys(ctx sdk.Context) []CircuitVerifyingKey {
+	families := make([]string, 0, len(hashFamilyCircuits))
+	for family := range hashFamilyCircuits {
+		if family != HashFamilyMiMC {
+			families = append(families, family)
+		}
+	}
+	sort.Strings(families)
+	var slots []CircuitVerifyingKey
+	for _, family := range families {
+		vkBytes, found := k.GetFamilyVerifyingKey(ctx, family)
+		if !found {
+			continue
+		}
+		slot := CircuitVerifyingKey{
+			CircuitID:          k.GetFamilyCircuitID(ctx, family),
+			VerifyingKeyHex:    hex.EncodeToString(vkBytes),
+			VerifyingKeySHA256: VerifyingKeyFingerprint(vkBytes),
+		}
+		if retiring, ok := k.activeRetiringVerifyingKey(ctx, family); ok {
+			slot.Retiring = &retiring
+		}
+		slots = append(slots, slot)
+	}
+	return slots
 }
 
 // ---------- ZKP Identity Commitments (v0.3.0) ----------
 
-// RegisterIdentityCommitment adds a MiMC commitment to the domain's
-// identity commitment set and rebuilds the Merkle root.
-// The caller must be a domain member. The commitment is not linked
+// RegisterIdentityCommitment adds a commitment to the domain's identity
+// commitment set and inserts it into the stored Merkle tree, hashed with the
+// family of the domain's circuit. The caller must be a domain member. The commitment is not linked
 // to the member's identity on-chain (WP S4 ZKP extension).
 func (k Keeper) RegisterIdentityCommitment(ctx sdk.Context, domainName, memberAddr, commitmentHex string) error {
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
 
 	// Verify caller is a domain member.
-	isMember := false
-	for _, m := range domain.Members {
-		if m == memberAddr {
-			isMember = true
-			break
-		}
-	}
-	if !isMember {
+	if !k.IsDomainMember(ctx, domainName, memberAddr) {
 		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only domain members can register identity commitments")
 	}
 
@@ -294,55 +406,48 @@ func (k Keeper) RegisterIdentityCommitment(ctx sdk.Context, domainName, memberAd
 		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "commitment must be 32 bytes hex-encoded (64 hex chars)")
 	}
 	commitmentHex = hex.EncodeToString(commitBytes)
+	if commitmentHex == RevokedIdentityLeaf {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "commitment cannot be zero")
+	}
 
 	// Check for duplicate commitment.
-	for _, existing := range domain.IdentityCommits {
-		if existing == commitmentHex {
-			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "commitment already registered")
-		}
+	if k.hasIdentityCommit(ctx, domainName, commitmentHex) {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "commitment already registered")
+	}
+	if err := k.markAnonymousVoter(ctx, domainName, memberAddr); err != nil {
+		return err
 	}
 
 	// Append commitment.
-	domain.IdentityCommits = append(domain.IdentityCommits, commitmentHex)
+	leafIndex := k.appendIdentityCommit(ctx, domainName, commitmentHex)
+	k.setIdentityLeafOwner(ctx, domainName, memberAddr, leafIndex)
 
 	// Save current root to history before overwriting.
-	if domain.MerkleRoot != "" {
-		domain.MerkleRootHistory = append(domain.MerkleRootHistory, domain.MerkleRoot)
-		if len(domain.MerkleRootHistory) > MerkleRootHistorySize {
-			domain.MerkleRootHistory = domain.MerkleRootHistory[len(domain.MerkleRootHistory)-MerkleRootHistorySize:]
-		}
-	}
+	pushMerkleRootHistory(&domain)
 
-	// Rebuild Merkle root.
-	root, err := k.computeMerkleRoot(domain.IdentityCommits)
+	// Rehash the new leaf's path; O(depth) regardless of domain size.
+	root, err := k.insertMerkleLeaf(ctx, domainIdentityTree(domain), leafIndex)
 	if err != nil {
-		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "failed to compute Merkle root: "+err.Error())
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "failed to update Merkle tree: "+err.Error())
 	}
-	domain.MerkleRoot = root
+	domain.MerkleRoot = hex.EncodeToString(root)
 
 	// Persist domain.
-	store := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	k.SetDomainHeader(ctx, domain)
 	return nil
 }
 
-// computeMerkleRoot builds a Merkle tree from hex-encoded commitments
-// and returns the root as a hex string.
-func (k Keeper) computeMerkleRoot(commitHexes []string) (string, error) {
-	tree := NewMerkleTree(MerkleTreeDepth)
-	leaves := make([][]byte, len(commitHexes))
-	for i, h := range commitHexes {
-		b, err := hex.DecodeString(h)
-		if err != nil {
-			return "", fmt.Errorf("invalid commitment hex at index %d: %w", i, err)
-		}
-		leaves[i] = b
+// pushMerkleRootHistory moves the domain's current root into the history
+// window before the root changes, dropping the oldest entry once the window
+// is full.
+func pushMerkleRootHistory(domain *Domain) {
+	if domain.MerkleRoot == "" {
+		return
 	}
-	if err := tree.BuildFromLeaves(leaves); err != nil {
-		return "", err
+	domain.MerkleRootHistory = append(domain.MerkleRootHistory, domain.MerkleRoot)
+	if len(domain.MerkleRootHistory) > MerkleRootHistorySize {
+		domain.MerkleRootHistory = domain.MerkleRootHistory[len(domain.MerkleRootHistory)-MerkleRootHistorySize:]
 	}
-	return tree.GetRoot(), nil
 }
 
 // isAcceptedMerkleRoot checks if the given root hex matches the domain's current
@@ -359,40 +464,273 @@ func isAcceptedMerkleRoot(domain Domain, rootHex string) bool {
 	return false
 }
 
+// verifyMembershipSignal checks a Groth16 membership proof against the
+// domain's current or a recent Merkle root for the given nullifier scope and
+// signal. It returns the canonical nullifier hex; whether the nullifier may
+// be (re)used is left to the caller.
+func (k Keeper) verifyMembershipSignal(ctx sdk.Context, domain Domain, proofHex, nullifierHashHex, merkleRootHex string, externalNullifier, signalHash []byte) (string, error) {
+	statement, err := membershipStatement(domain, proofHex, nullifierHashHex, merkleRootHex, externalNullifier, signalHash)
+	if err != nil {
+		return "", err
+	}
+
+	// The domain's circuit picks the key slot. During a verifying-key
+	// transition both the current and the retiring key of that family are
+	// accepted; they share its hashing, so the proof's nullifier is the same
+	// whichever key it was made for.
+	vkSet, err := k.acceptedVerifyingKeys(ctx, domain.Options.hashFamily())
+	if err != nil {
+		return "", errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "failed to load verifying key: "+err.Error())
+	}
+	// Statements accepted by this block's batch check need no second pairing.
+	for _, vkBytes := range vkSet {
+		if k.verifiedProofs.contains(ctx.BlockHeight(), verifiedProofKey(vkBytes, statement)) {
+			return hex.EncodeToString(statement.NullifierHash), nil
+		}
+	}
+
+	// Verify the Groth16 membership proof; report the current key's failure.
+	var verifyErr error
+	for i, vkBytes := range vkSet {
+		vk, err := DeserializeVerifyingKey(vkBytes)
+		if err != nil {
+			return "", errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "failed to deserialize verifying key")
+		}
+		err = verifySingle(vk, statement)
+		if err == nil {
+			return hex.EncodeToString(statement.NullifierHash), nil
+		}
+		if i == 0 {
+			verifyErr = err
+		}
+	}
+	return "", errorsmod.Wrap(sdkerrors.ErrUnauthorized, "ZKP membership proof verification failed: "+verifyErr.Error())
+}
+
+// membershipStatement decodes a submitted proof against the domain's current
+// or a recent Merkle root.
+func membershipStatement(domain Domain, proofHex, nullifierHashHex, merkleRootHex string, externalNullifier, signalHash []byte) (MembershipStatement, error) {
+	if domain.MerkleRoot == "" {
+		return MembershipStatement{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "no identity commitments registered in domain")
+	}
+
+	// Determine which Merkle root to verify against.
+	effectiveRoot := domain.MerkleRoot
+	if merkleRootHex != "" {
+		if !isAcceptedMerkleRoot(domain, merkleRootHex) {
+			return MembershipStatement{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "merkle root not recognized (not current and not in history window)")
+		}
+		effectiveRoot = merkleRootHex
+	}
+	merkleRootBytes, err := HexToFieldElement(effectiveRoot)
+	if err != nil {
+		return MembershipStatement{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "invalid or non-canonical Merkle root")
+	}
+
+	// Decode and validate nullifier hash.
+	nullifierBytes, err := HexToFieldElement(nullifierHashHex)
+	if err != nil || len(nullifierHashHex) != 64 {
+		return MembershipStatement{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "nullifier hash must be 32 bytes hex-encoded (64 hex chars)")
+	}
+
+	// Decode proof.
+	proofBytes, err := hex.DecodeString(proofHex)
+	if err != nil {
+		return MembershipStatement{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "invalid proof hex encoding")
+	}
+	return MembershipStatement{
+		Proof:             proofBytes,
+		MerkleRoot:        merkleRootBytes,
+		NullifierHash:     nullifierBytes,
+		ExternalNullifier: externalNullifier,
+		SignalHash:        signalHash,
+	}, nil
+}
+
 // ---------- Nullifier Store (v0.3.0) ----------
+//
+// Used nullifiers live under "nullifier:{domain}:{nullifierHex}". Those spent
+// within one issue, or one suggestion of it, are also indexed under
+// "nullifier-scope:{d}{i}{s}{nullifierHex}" → nullifierHex, where {s} is
+// empty for issue-wide nullifiers and the segments are length-prefixed like
+// the domain_store.go scopes. Deleting the issue or suggestion prunes its
+// nullifiers; unscoped ones stay until the Big Purge.
+
+func nullifierKey(domainName, nullifierHex string) []byte {
+	return []byte("nullifier:" + domainName + ":" + nullifierHex)
+}
+
+// nullifierScopePrefix returns the index prefix of a domain, or of the given
+// issue and suggestion within it.
+func nullifierScopePrefix(domainName string, scope ...string) []byte {
+	prefix := append([]byte("nullifier-scope:"), domainScope(domainName)...)
+	for _, name := range scope {
+		prefix = append(prefix, domainScope(name)...)
+	}
+	return prefix
+}
 
 // IsNullifierUsed checks whether a nullifier has already been consumed
 // in this domain (prevents ZKP double-voting).
 func (k Keeper) IsNullifierUsed(ctx sdk.Context, domainName, nullifierHex string) bool {
 	store := ctx.KVStore(k.StoreKey)
-	return store.Has([]byte("nullifier:" + domainName + ":" + nullifierHex))
+	return store.Has(nullifierKey(domainName, nullifierHex))
 }
 
-// SetNullifierUsed records a nullifier as consumed.
+// SetNullifierUsed records a domain-wide nullifier as consumed.
 func (k Keeper) SetNullifierUsed(ctx sdk.Context, domainName, nullifierHex string, blockHeight int64) {
-	record := NullifierRecord{
+	k.setNullifierRecord(ctx, NullifierRecord{
 		DomainName:    domainName,
 		NullifierHash: nullifierHex,
 		UsedAtHeight:  blockHeight,
+	})
+}
+
+// SetScopedNullifierUsed records a nullifier consumed within an issue, or
+// within one of its suggestions when suggestionName is set, so deleting that
+// issue or suggestion prunes it.
+func (k Keeper) SetScopedNullifierUsed(ctx sdk.Context, domainName, issueName, suggestionName, nullifierHex string, blockHeight int64) {
+	k.setNullifierRecord(ctx, NullifierRecord{
+		DomainName:     domainName,
+		IssueName:      issueName,
+		SuggestionName: suggestionName,
+		NullifierHash:  nullifierHex,
+		UsedAtHeight:   blockHeight,
+	})
+}
+
+func (k Keeper) setNullifierRecord(ctx sdk.Context, record NullifierRecord) {
+	store := ctx.KVStore(k.StoreKey)
+	store.Set(nullifierKey(record.DomainName, record.NullifierHash), k.cdc.MustMarshalLengthPrefixed(&record))
+	if record.IssueName != "" {
+		scope := nullifierScopePrefix(record.DomainName, record.IssueName, record.SuggestionName)
+		store.Set(append(scope, record.NullifierHash...), []byte(record.NullifierHash))
 	}
+}
+
+// pruneScopedNullifiers deletes every nullifier indexed under the scope of an
+// issue, or of one suggestion when suggestionName is given.
+func (k Keeper) pruneScopedNullifiers(ctx sdk.Context, domainName, issueName string, suggestionName ...string) {
 	store := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&record)
-	store.Set([]byte("nullifier:"+domainName+":"+nullifierHex), bz)
+	prefix := nullifierScopePrefix(domainName, append([]string{issueName}, suggestionName...)...)
+	iter := store.Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	var keys [][]byte
+	var nullifiers []string
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, append([]byte{}, iter.Key()...))
+		nullifiers = append(nullifiers, string(iter.Value()))
+	}
+	for i, key := range keys {
+		store.Delete(key)
+		store.Delete(nullifierKey(domainName, nullifiers[i]))
+	}
 }
 
 // PurgeNullifiers clears all nullifiers for a domain.
 // Called during Big Purge when identity commitments are also wiped.
 func (k Keeper) PurgeNullifiers(ctx sdk.Context, domainName string) {
 	store := ctx.KVStore(k.StoreKey)
-	prefix := []byte("nullifier:" + domainName + ":")
-	end := prefixEnd(prefix)
-	iter := store.Iterator(prefix, end)
-	defer iter.Close()
 	var keys [][]byte
-	for ; iter.Valid(); iter.Next() {
-		keys = append(keys, append([]byte{}, iter.Key()...))
+	for _, prefix := range [][]byte{[]byte("nullifier:" + domainName + ":"), nullifierScopePrefix(domainName)} {
+		iter := store.Iterator(prefix, prefixEnd(prefix))
+		for ; iter.Valid(); iter.Next() {
+			keys = append(keys, append([]byte{}, iter.Key()...))
+		}
+		iter.Close()
 	}
 	for _, key := range keys {
 		store.Delete(key)
 	}
 }
+
+// IterateNullifiers iterates over every used nullifier record.
+func (k Keeper) IterateNullifiers(ctx sdk.Context, fn func(NullifierRecord) bool) {
+	iter := storeprefix.NewStore(ctx.KVStore(k.StoreKey), []byte("nullifier:")).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var record NullifierRecord
+		k.cdc.MustUnmarshalLengthPrefixed(iter.Value(), &record)
+		if fn(record) {
+			return
+		}
+	}
+}
+
+// exportNullifierScopes groups the used nullifiers by scope for genesis,
+// ordered by domain, issue and suggestion.
+func (k Keeper) exportNullifierScopes(ctx sdk.Context) []NullifierScopeGenesis {
+	type scopeKey struct{ domain, issue, suggestion string }
+	groups := make(map[scopeKey]*NullifierScopeGenesis)
+	var order []scopeKey
+	k.IterateNullifiers(ctx, func(record NullifierRecord) bool {
+		key := scopeKey{record.DomainName, record.IssueName, record.SuggestionName}
+		group, ok := groups[key]
+		if !ok {
+			group = &NullifierScopeGenesis{DomainName: key.domain, IssueName: key.issue, SuggestionName: key.suggestion}
+			groups[key] = group
+			order = append(order, key)
+		}
+		group.Nullifiers = append(group.Nullifiers, record.NullifierHash)
+		return false
+	})
+	sort.Slice(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if a.domain != b.domain {
+			return a.domain < b.domain
+		}
+		if a.issue != b.issue {
+			return a.issue < b.issue
+		}
+		return a.suggestion < b.suggestion
+	})
+	scopes := make([]NullifierScopeGenesis, 0, len(order))
+	for _, key := range order {
+		group := groups[key]
+		sort.Strings(group.Nullifiers)
+		scopes = append(scopes, *group)
+	}
+	return scopes
+}
+
+// scopeRatingNullifiers indexes the domain-wide nullifiers of a domain that
+// a stored anonymous rating spent, under that rating's suggestion. Rating
+// nullifiers recorded before the scope index existed are domain-wide.
+func (k Keeper) scopeRatingNullifiers(ctx sdk.Context, domainName string) {
+	store := ctx.KVStore(k.StoreKey)
+	k.IterateIssues(ctx, domainName, func(issue Issue) bool {
+		k.IterateSuggestions(ctx, domainName, issue.Name, func(suggestion Suggestion) bool {
+			for _, rating := range k.GetSuggestionRatings(ctx, domainName, issue.Name, suggestion.Name) {
+				if rating.NullifierHex == "" {
+					continue
+				}
+				bz := store.Get(nullifierKey(domainName, rating.NullifierHex))
+				if bz == nil {
+					continue
+				}
+				var record NullifierRecord
+				k.cdc.MustUnmarshalLengthPrefixed(bz, &record)
+				if record.IssueName == "" {
+					k.SetScopedNullifierUsed(ctx, domainName, issue.Name, suggestion.Name, record.NullifierHash, record.UsedAtHeight)
+				}
+			}
+			return false
+		})
+		return false
+	})
+}
+
+// MigrateNullifierScopes indexes the stored rating nullifiers of every domain
+// by suggestion. Version 5 prunes nullifiers with their issue or suggestion;
+// nullifiers no stored rating accounts for stay domain-wide.
+func (k Keeper) MigrateNullifierScopes(ctx sdk.Context) error {
+	var names []string
+	k.IterateDomainHeaders(ctx, func(d Domain) bool {
+		names = append(names, d.Name)
+		return false
+	})
+	for _, name := range names {
+		k.scopeRatingNullifiers(ctx, name)
+	}
+	return nil
+}
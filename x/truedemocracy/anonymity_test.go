@@ -31,9 +31,7 @@ func setupDomainWithIssue(t *testing.T, k Keeper, ctx sdk.Context) {
 			},
 		},
 	}
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:AnonDomain"), bz)
+	k.SetDomain(ctx, domain)
 }
 
 // ---------- JoinPermissionRegister ----------
@@ -289,9 +287,7 @@ func TestVoterUnlinkability(t *testing.T) {
 	domain.Issues[0].Suggestions = append(domain.Issues[0].Suggestions, Suggestion{
 		Name: "CarbonTax", Creator: "bob", Ratings: []Rating{}, Stones: 0,
 	})
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:AnonDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	k.RateProposal(ctx, "AnonDomain", "Climate", "CarbonTax", -1, bobKey)
 
@@ -344,9 +340,7 @@ func TestExcludedMemberCannotReregister(t *testing.T) {
 		}
 	}
 	domain.Members = newMembers
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:AnonDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	// Charlie tries to re-register after being removed — should fail.
 	newCharlieKey := domainKey("charlie-new-key")
@@ -0,0 +1,106 @@
+package truedemocracy
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Anonymous signals are Semaphore-style posts: a domain member proves
+// membership and publishes a bounded signal under an application-defined
+// topic, such as a poll, a comment thread, or an attestation round:
+//   "signal-zk:{d}{t}{nullifier}" → AnonymousSignal
+//
+// {t} is the length-prefixed topic. The topic is the nullifier scope, so
+// each member posts one signal per topic; the record itself marks the
+// nullifier as spent and the one-shot "nullifier:" store is not used. A Big
+// Purge withdraws the signals together with the identity commitments.
+
+// Bounds of an anonymous signal, in bytes.
+const (
+	MaxSignalTopicLength = 128
+	MaxSignalLength      = 256
+)
+
+func anonSignalPrefix(domainName, topic string) []byte {
+	return append(append([]byte("signal-zk:"), domainScope(domainName)...), domainScope(topic)...)
+}
+
+func anonSignalKey(domainName, topic, nullifierHex string) []byte {
+	return append(anonSignalPrefix(domainName, topic), nullifierHex...)
+}
+
+// validateAnonymousSignal checks the topic and signal bounds.
+func validateAnonymousSignal(topic, signal string) error {
+	if topic == "" || len(topic) > MaxSignalTopicLength {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "topic must be 1..%d bytes", MaxSignalTopicLength)
+	}
+	if signal == "" || len(signal) > MaxSignalLength {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "signal must be 1..%d bytes", MaxSignalLength)
+	}
+	return nil
+}
+
+// SignalWithProof records an anonymous signal of a domain member. The proof
+// binds the signal under the topic's nullifier scope, so a member can post
+// one signal per topic and cannot replace it.
+func (k Keeper) SignalWithProof(ctx sdk.Context, domainName, topic, signal, proofHex, nullifierHashHex, merkleRootHex string) error {
+	if err := validateAnonymousSignal(topic, signal); err != nil {
+		return err
+	}
+	domain, found := k.GetDomainHeader(ctx, domainName)
+	if !found {
+		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
+	}
+
+	scope := ComputeSignalNullifierScope(ctx.ChainID(), domainName, topic)
+	signalHash := ComputeSignalHash(ctx.ChainID(), domainName, topic, signal)
+	nullifierHex, err := k.verifyMembershipSignal(ctx, domain, proofHex, nullifierHashHex, merkleRootHex, scope, signalHash)
+	if err != nil {
+		return err
+	}
+	store := ctx.KVStore(k.StoreKey)
+	key := anonSignalKey(domainName, topic, nullifierHex)
+	if store.Has(key) {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "nullifier already used (one signal per member and topic)")
+	}
+	record := AnonymousSignal{
+		DomainName:    domainName,
+		Topic:         topic,
+		NullifierHash: nullifierHex,
+		Signal:        signal,
+		Height:        ctx.BlockHeight(),
+	}
+	store.Set(key, k.cdc.MustMarshalLengthPrefixed(&record))
+	return nil
+}
+
+// GetAnonymousSignal returns the signal posted to a topic under a nullifier.
+func (k Keeper) GetAnonymousSignal(ctx sdk.Context, domainName, topic, nullifierHex string) (AnonymousSignal, bool) {
+	bz := ctx.KVStore(k.StoreKey).Get(anonSignalKey(domainName, topic, nullifierHex))
+	if bz == nil {
+		return AnonymousSignal{}, false
+	}
+	var record AnonymousSignal
+	k.cdc.MustUnmarshalLengthPrefixed(bz, &record)
+	return record, true
+}
+
+// anonymousSignals reads up to limit signals of a topic in nullifier order,
+// starting after the given nullifier.
+func (k Keeper) anonymousSignals(ctx sdk.Context, domainName, topic, startAfter string, limit int) []AnonymousSignal {
+	prefix := anonSignalPrefix(domainName, topic)
+	start := prefix
+	if startAfter != "" {
+		start = append(anonSignalKey(domainName, topic, startAfter), 0)
+	}
+	iter := ctx.KVStore(k.StoreKey).Iterator(start, prefixEnd(prefix))
+	defer iter.Close()
+	signals := []AnonymousSignal{}
+	for ; iter.Valid() && len(signals) < limit; iter.Next() {
+		var record AnonymousSignal
+		k.cdc.MustUnmarshalLengthPrefixed(iter.Value(), &record)
+		signals = append(signals, record)
+	}
+	return signals
+}
@@ -0,0 +1,145 @@
+package truedemocracy
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+)
+
+func anonSignalProof(t *testing.T, k Keeper, ctx sdk.Context, secrets [][]byte, member int, topic, signal string) (string, string) {
+	t.Helper()
+	return generateScopedProof(t, k, ctx, "ZKPDomain", secrets, member,
+		ComputeSignalNullifierScope(ctx.ChainID(), "ZKPDomain", topic),
+		ComputeSignalHash(ctx.ChainID(), "ZKPDomain", topic, signal))
+}
+
+func TestSignalWithProof(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	secrets := setupDomainWithZKPIdentity(t, k, ctx, "ZKPDomain", 2)
+
+	proof, nullifier := anonSignalProof(t, k, ctx, secrets, 0, "poll-1", "yes")
+	if err := k.SignalWithProof(ctx, "ZKPDomain", "poll-1", "no", proof, nullifier, ""); err == nil {
+		t.Fatal("proof for another signal accepted")
+	}
+	if err := k.SignalWithProof(ctx, "ZKPDomain", "poll-2", "yes", proof, nullifier, ""); err == nil {
+		t.Fatal("proof for another topic accepted")
+	}
+	if err := k.SignalWithProof(ctx, "ZKPDomain", "poll-1", "yes", proof, nullifier, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	record, found := k.GetAnonymousSignal(ctx, "ZKPDomain", "poll-1", nullifier)
+	if !found || record.Signal != "yes" || record.Height != ctx.BlockHeight() {
+		t.Fatalf("signal = %+v, found %v", record, found)
+	}
+
+	// A member posts once per topic, even with a fresh proof.
+	proof, again := anonSignalProof(t, k, ctx, secrets, 0, "poll-1", "no")
+	if again != nullifier {
+		t.Fatal("signal nullifier depends on the signal")
+	}
+	if err := k.SignalWithProof(ctx, "ZKPDomain", "poll-1", "no", proof, again, ""); err == nil {
+		t.Fatal("second signal on the same topic accepted")
+	}
+
+	proof, other := anonSignalProof(t, k, ctx, secrets, 1, "poll-1", "no")
+	if err := k.SignalWithProof(ctx, "ZKPDomain", "poll-1", "no", proof, other, ""); err != nil {
+		t.Fatal(err)
+	}
+	proof, nullifier = anonSignalProof(t, k, ctx, secrets, 0, "poll-2", "maybe")
+	if err := k.SignalWithProof(ctx, "ZKPDomain", "poll-2", "maybe", proof, nullifier, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := k.anonymousSignals(ctx, "ZKPDomain", "poll-1", "", 10); len(got) != 2 {
+		t.Fatalf("poll-1 signals = %d, want 2", len(got))
+	}
+	first := k.anonymousSignals(ctx, "ZKPDomain", "poll-1", "", 1)
+	rest := k.anonymousSignals(ctx, "ZKPDomain", "poll-1", first[0].NullifierHash, 10)
+	if len(first) != 1 || len(rest) != 1 || rest[0].NullifierHash == first[0].NullifierHash {
+		t.Fatalf("paging = %+v then %+v", first, rest)
+	}
+
+	resp, err := k.AnonymousSignals(ctx, &QueryAnonymousSignalsRequest{
+		DomainName: "ZKPDomain",
+		Topic:      "poll-1",
+		Pagination: &query.PageRequest{Limit: 1, CountTotal: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var page []AnonymousSignal
+	if err := json.Unmarshal(resp.Result, &page); err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 1 || resp.Pagination.Total != 2 || len(resp.Pagination.NextKey) == 0 {
+		t.Fatalf("query page = %+v, pagination %+v", page, resp.Pagination)
+	}
+	if _, err := k.AnonymousSignals(ctx, &QueryAnonymousSignalsRequest{DomainName: "Nowhere", Topic: "poll-1"}); err == nil {
+		t.Fatal("unknown domain accepted")
+	}
+
+	// Big Purge withdraws every signal of the domain.
+	k.executeBigPurge(ctx, "ZKPDomain")
+	if got := k.anonymousSignals(ctx, "ZKPDomain", "poll-1", "", 10); len(got) != 0 {
+		t.Fatalf("signals after purge = %d", len(got))
+	}
+	if got := k.anonymousSignals(ctx, "ZKPDomain", "poll-2", "", 10); len(got) != 0 {
+		t.Fatalf("signals after purge = %d", len(got))
+	}
+}
+
+func TestSignalWithProofUnknownDomain(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	nullifier := hex.EncodeToString(make([]byte, 32))
+	if err := k.SignalWithProof(ctx, "Nowhere", "poll-1", "yes", "abcd", nullifier, ""); err == nil {
+		t.Fatal("signal to unknown domain accepted")
+	}
+}
+
+func TestMsgSignalWithProofValidationAndEncoding(t *testing.T) {
+	msg := MsgSignalWithProof{
+		Sender:        sdk.AccAddress("relayer"),
+		DomainName:    "ZKPDomain",
+		Topic:         "poll-1",
+		Signal:        "yes",
+		Proof:         "abcd",
+		NullifierHash: hex.EncodeToString(make([]byte, 32)),
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		t.Fatalf("valid signal rejected: %v", err)
+	}
+	if bz, indexes := msg.Descriptor(); len(bz) == 0 || len(indexes) == 0 {
+		t.Fatal("message descriptor missing")
+	}
+	bz, err := gogoproto.Marshal(&msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded MsgSignalWithProof
+	if err := gogoproto.Unmarshal(bz, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Topic != msg.Topic || decoded.Signal != msg.Signal || decoded.NullifierHash != msg.NullifierHash {
+		t.Fatalf("round trip = %+v", decoded)
+	}
+
+	for name, mutate := range map[string]func(*MsgSignalWithProof){
+		"empty topic":     func(m *MsgSignalWithProof) { m.Topic = "" },
+		"long topic":      func(m *MsgSignalWithProof) { m.Topic = strings.Repeat("t", MaxSignalTopicLength+1) },
+		"empty signal":    func(m *MsgSignalWithProof) { m.Signal = "" },
+		"long signal":     func(m *MsgSignalWithProof) { m.Signal = strings.Repeat("s", MaxSignalLength+1) },
+		"short nullifier": func(m *MsgSignalWithProof) { m.NullifierHash = "abcd" },
+		"no domain":       func(m *MsgSignalWithProof) { m.DomainName = "" },
+	} {
+		bad := msg
+		mutate(&bad)
+		if err := bad.ValidateBasic(); err == nil {
+			t.Errorf("%s accepted", name)
+		}
+	}
+}
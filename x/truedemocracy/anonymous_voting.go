@@ -0,0 +1,358 @@
+package truedemocracy
+
+import (
+	"crypto/sha256"
+
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Anonymous ballots and stones are keyed by the nullifier of their
+// membership proof instead of the member address:
+//   "elecvote-zk:{d}{i}{nullifier}" → ballot, encoded like "elecvote:" values
+//   "stone-zk:i:{d}{nullifier}"     → issue name
+//   "stone-zk:s:{d}{i}{nullifier}"  → suggestion name
+//   "stone-zk:m:{d}{nullifier}"     → target member address
+//
+// {d} and {i} are the length-prefixed domain and issue scopes of
+// domain_store.go. The nullifier scope covers one election or stone list but
+// not the choice, so a fresh proof under the same nullifier is a re-vote: it
+// replaces the ballot or moves the stone. These nullifiers never enter the
+// one-shot "nullifier:" store that ratings use.
+//
+// Anonymous stones earn no VoteToEarn reward, since a payout account would
+// link the stone back to its owner.
+//
+// Anonymous suggestions (SubmitProposalWithProof) are one-shot instead: their
+// per-issue nullifier enters the "nullifier:" store scoped to the issue, and
+// the suggestion's creator is the pseudonymous AnonymousCreatorAddress of
+// that nullifier.
+
+// Stone lists accepted by PlaceStoneWithProof.
+const (
+	StoneListIssue      = "issue"
+	StoneListSuggestion = "suggestion"
+	StoneListMember     = "member"
+)
+
+func anonElectionPrefix(domainName, issueName string) []byte {
+	return append(append([]byte("elecvote-zk:"), domainScope(domainName)...), domainScope(issueName)...)
+}
+
+func anonElectionVoteKey(domainName, issueName, nullifierHex string) []byte {
+	return append(anonElectionPrefix(domainName, issueName), nullifierHex...)
+}
+
+func anonStonePrefix(domainName, list, issueName string) []byte {
+	switch list {
+	case StoneListIssue:
+		return append([]byte("stone-zk:i:"), domainScope(domainName)...)
+	case StoneListSuggestion:
+		return append(append([]byte("stone-zk:s:"), domainScope(domainName)...), domainScope(issueName)...)
+	default:
+		return append([]byte("stone-zk:m:"), domainScope(domainName)...)
+	}
+}
+
+func anonStoneKey(domainName, list, issueName, nullifierHex string) []byte {
+	return append(anonStonePrefix(domainName, list, issueName), nullifierHex...)
+}
+
+// CastElectionVoteWithProof records an anonymous ballot in a person election.
+// An empty ranking abstains; plurality and D'Hondt elections take exactly one
+// candidate. The proof's signal binds the ballot, and a later proof under
+// the same election nullifier replaces it.
+func (k Keeper) CastElectionVoteWithProof(ctx sdk.Context, domainName, issueName string, ranking []string, proofHex, nullifierHashHex, merkleRootHex string) error {
+	domain, found := k.GetDomainHeader(ctx, domainName)
+	if !found {
+		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
+	}
+	issue, found := k.GetIssue(ctx, domainName, issueName)
+	if !found {
+		return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue not found")
+	}
+	if err := k.requireIssueOpen(ctx, domainName, issueName); err != nil {
+		return err
+	}
+
+	bz := []byte(abstainSentinel)
+	if len(ranking) == 0 {
+		if !domain.Options.AbstentionAllowed {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "abstention is not allowed in this domain")
+		}
+	} else {
+		if !domain.Options.VotingMode.Ranked() && len(ranking) != 1 {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain voting mode takes a single candidate")
+		}
+		if err := validateRanking(ranking); err != nil {
+			return err
+		}
+		for _, candidate := range ranking {
+			if _, found := k.GetSuggestion(ctx, domainName, issueName, candidate); !found {
+				return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "candidate %s not found in suggestion list", candidate)
+			}
+		}
+		ballot := RankedBallot{Ranking: ranking}
+		bz = append([]byte{rankedBallotMarker}, k.cdc.MustMarshal(&ballot)...)
+	}
+
+	scope := ComputeElectionNullifierScope(ctx.ChainID(), domainName, issueName)
+	signal := ComputeElectionBallotSignal(ctx.ChainID(), domainName, issueName, ranking)
+	nullifierHex, err := k.verifyMembershipSignal(ctx, domain, proofHex, nullifierHashHex, merkleRootHex, scope, signal)
+	if err != nil {
+		return err
+	}
+	ctx.KVStore(k.StoreKey).Set(anonElectionVoteKey(domainName, issueName, nullifierHex), bz)
+
+	issue.LastActivityAt = ctx.BlockTime().Unix()
+	k.SetIssue(ctx, domainName, issue)
+	return nil
+}
+
+// GetAnonymousElectionBallot returns the ballot cast under a nullifier, read
+// like GetElectionBallot.
+func (k Keeper) GetAnonymousElectionBallot(ctx sdk.Context, domainName, issueName, nullifierHex string) (ranking []string, abstained, found bool) {
+	return k.decodeElectionBallot(ctx.KVStore(k.StoreKey).Get(anonElectionVoteKey(domainName, issueName, nullifierHex)))
+}
+
+// anonymousElectionBallots reads the anonymous ballots of an election in
+// nullifier order.
+func (k Keeper) anonymousElectionBallots(ctx sdk.Context, domainName, issueName string) (ballots [][]string, abstained int) {
+	prefix := anonElectionPrefix(domainName, issueName)
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		ranking, abstain, _ := k.decodeElectionBallot(iter.Value())
+		if abstain {
+			abstained++
+			continue
+		}
+		ballots = append(ballots, ranking)
+	}
+	return ballots, abstained
+}
+
+// PlaceStoneWithProof places or moves an anonymous stone on one of the
+// domain's stone lists: an issue, a suggestion of issueName, or a member.
+// The first proof under a list's nullifier places the stone; later proofs
+// move it, adjusting the counts exactly as PlaceStoneOnIssue and
+// PlaceStoneOnSuggestion do. Anonymous member stones cannot be checked
+// against their owner, so the self-vote rule does not apply to them.
+func (k Keeper) PlaceStoneWithProof(ctx sdk.Context, domainName, list, issueName, target, proofHex, nullifierHashHex, merkleRootHex string) error {
+	domain, found := k.GetDomainHeader(ctx, domainName)
+	if !found {
+		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
+	}
+
+	var issue Issue
+	switch list {
+	case StoneListIssue:
+		if issueName != "" {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "issue list stones take no issue name")
+		}
+		if _, found := k.GetIssue(ctx, domainName, target); !found {
+			return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue not found")
+		}
+		if err := k.requireIssueOpen(ctx, domainName, target); err != nil {
+			return err
+		}
+	case StoneListSuggestion:
+		if issue, found = k.GetIssue(ctx, domainName, issueName); !found {
+			return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue not found")
+		}
+		if err := k.requireIssueOpen(ctx, domainName, issueName); err != nil {
+			return err
+		}
+		if _, found := k.GetSuggestion(ctx, domainName, issueName, target); !found {
+			return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "suggestion not found")
+		}
+	case StoneListMember:
+		if issueName != "" {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "member list stones take no issue name")
+		}
+		if !k.IsDomainMember(ctx, domainName, target) {
+			return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "target is not a domain member")
+		}
+	default:
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "unknown stone list %q", list)
+	}
+
+	scope := ComputeStoneNullifierScope(ctx.ChainID(), domainName, list, issueName)
+	signal := ComputeStoneSignal(ctx.ChainID(), domainName, list, issueName, target)
+	nullifierHex, err := k.verifyMembershipSignal(ctx, domain, proofHex, nullifierHashHex, merkleRootHex, scope, signal)
+	if err != nil {
+		return err
+	}
+
+	store := ctx.KVStore(k.StoreKey)
+	key := anonStoneKey(domainName, list, issueName, nullifierHex)
+	previous := store.Get(key)
+	if previous != nil && string(previous) == target {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "stone already placed on this %s", list)
+	}
+	store.Set(key, []byte(target))
+
+	switch list {
+	case StoneListIssue:
+		if previous != nil {
+			k.adjustIssueStones(ctx, domainName, string(previous), -1)
+		}
+		k.adjustIssueStones(ctx, domainName, target, 1)
+	case StoneListSuggestion:
+		if previous != nil {
+			k.adjustSuggestionStones(ctx, domainName, issueName, string(previous), -1)
+		}
+		k.adjustSuggestionStones(ctx, domainName, issueName, target, 1)
+		issue.LastActivityAt = ctx.BlockTime().Unix()
+		k.SetIssue(ctx, domainName, issue)
+		k.checkIssueQuorum(ctx, domainName, issue)
+	}
+	return nil
+}
+
+// GetAnonymousStone returns the target of the stone placed under a nullifier
+// on a stone list.
+func (k Keeper) GetAnonymousStone(ctx sdk.Context, domainName, list, issueName, nullifierHex string) (string, bool) {
+	bz := ctx.KVStore(k.StoreKey).Get(anonStoneKey(domainName, list, issueName, nullifierHex))
+	if bz == nil {
+		return "", false
+	}
+	return string(bz), true
+}
+
+// adjustIssueStones moves an issue's stone count by delta, never below zero.
+// Placing a stone also marks the issue active.
+func (k Keeper) adjustIssueStones(ctx sdk.Context, domainName, issueName string, delta int) {
+	issue, found := k.GetIssue(ctx, domainName, issueName)
+	if !found {
+		return
+	}
+	issue.Stones = max(issue.Stones+delta, 0)
+	if delta > 0 {
+		issue.LastActivityAt = ctx.BlockTime().Unix()
+	}
+	k.SetIssue(ctx, domainName, issue)
+}
+
+// adjustSuggestionStones moves a suggestion's stone count by delta, never
+// below zero.
+func (k Keeper) adjustSuggestionStones(ctx sdk.Context, domainName, issueName, suggestionName string, delta int) {
+	suggestion, found := k.GetSuggestion(ctx, domainName, issueName, suggestionName)
+	if !found {
+		return
+	}
+	suggestion.Stones = max(suggestion.Stones+delta, 0)
+	k.SetSuggestion(ctx, domainName, issueName, suggestion)
+}
+
+// purgeAnonymousVotes withdraws every anonymous stone, ballot and signal of a
+// domain.
+// Big Purge clears the identity commitments their nullifiers were derived
+// from; a member re-registering with a new secret would otherwise hold a
+// second stone or ballot next to the orphaned one.
+func (k Keeper) purgeAnonymousVotes(ctx sdk.Context, domainName string) {
+	store := ctx.KVStore(k.StoreKey)
+	targets := func(prefix []byte) []string {
+		var out []string
+		iter := store.Iterator(prefix, prefixEnd(prefix))
+		defer iter.Close()
+		for ; iter.Valid(); iter.Next() {
+			out = append(out, string(iter.Value()))
+		}
+		return out
+	}
+
+	for _, issueName := range targets(anonStonePrefix(domainName, StoneListIssue, "")) {
+		k.adjustIssueStones(ctx, domainName, issueName, -1)
+	}
+	var issueNames []string
+	k.IterateIssues(ctx, domainName, func(issue Issue) bool {
+		issueNames = append(issueNames, issue.Name)
+		return false
+	})
+	for _, issueName := range issueNames {
+		for _, suggestionName := range targets(anonStonePrefix(domainName, StoneListSuggestion, issueName)) {
+			k.adjustSuggestionStones(ctx, domainName, issueName, suggestionName, -1)
+		}
+	}
+
+	scope := domainScope(domainName)
+	for _, prefix := range [][]byte{
+		append([]byte("stone-zk:i:"), scope...),
+		append([]byte("stone-zk:s:"), scope...),
+		append([]byte("stone-zk:m:"), scope...),
+		append([]byte("elecvote-zk:"), scope...),
+		append([]byte("signal-zk:"), scope...),
+	} {
+		var keys [][]byte
+		iter := store.Iterator(prefix, prefixEnd(prefix))
+		for ; iter.Valid(); iter.Next() {
+			keys = append(keys, append([]byte{}, iter.Key()...))
+		}
+		iter.Close()
+		for _, key := range keys {
+			store.Delete(key)
+		}
+	}
+}
+
+// AnonymousCreatorAddress derives the pseudonymous creator recorded on an
+// anonymous suggestion from its submission nullifier. It is a well-formed
+// account address nobody holds a key for. The nullifier scope is one issue,
+// so a member's pseudonyms in different issues cannot be linked.
+func AnonymousCreatorAddress(nullifierHex string) string {
+	digest := sha256.Sum256(append([]byte("TrueRepublic/proposal-creator/v1"), nullifierHex...))
+	return sdk.AccAddress(digest[:20]).String()
+}
+
+// SubmitProposalWithProof adds a suggestion on behalf of an anonymous domain
+// member. The proof binds the suggestion under the issue's proposal
+// nullifier scope, so each member can submit one anonymous suggestion per
+// issue. The payer funds the put-price fee and is not linked to the member;
+// the suggestion records the nullifier-derived pseudonym as its creator.
+// Domains that only accept issues from their admin reject anonymous
+// submissions, as the pseudonym is never the admin.
+func (k Keeper) SubmitProposalWithProof(
+	ctx sdk.Context,
+	payer sdk.AccAddress,
+	domainName, issueName, suggestionName string,
+	fee sdk.Coins,
+	externalLink, proofHex, nullifierHashHex, merkleRootHex string,
+) (creator string, err error) {
+	if err := requireBankKeeper(k.bankKeeper); err != nil {
+		return "", err
+	}
+	if payer.Empty() {
+		return "", errorsmod.Wrap(sdkerrors.ErrInvalidAddress, "payer address is required")
+	}
+	if err := validatePNYXCoins(fee, "proposal fee"); err != nil {
+		return "", err
+	}
+	domain, found := k.GetDomainHeader(ctx, domainName)
+	if !found {
+		return "", errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
+	}
+
+	scope := ComputeProposalNullifierScope(ctx.ChainID(), domainName, issueName)
+	signal := ComputeProposalSignal(ctx.ChainID(), domainName, issueName, suggestionName, externalLink)
+	nullifierHex, err := k.verifyMembershipSignal(ctx, domain, proofHex, nullifierHashHex, merkleRootHex, scope, signal)
+	if err != nil {
+		return "", err
+	}
+	if k.IsNullifierUsed(ctx, domainName, nullifierHex) {
+		return "", errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "nullifier already used (one anonymous suggestion per issue)")
+	}
+	creator = AnonymousCreatorAddress(nullifierHex)
+
+	cacheCtx, write := ctx.CacheContext()
+	if err := k.SubmitProposal(cacheCtx, domainName, issueName, suggestionName, creator, fee, externalLink); err != nil {
+		return "", err
+	}
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(cacheCtx, payer, ModuleName, fee); err != nil {
+		return "", errorsmod.Wrap(err, "proposal fee escrow transfer failed")
+	}
+	k.SetScopedNullifierUsed(cacheCtx, domainName, issueName, "", nullifierHex, ctx.BlockHeight())
+	write()
+	return creator, nil
+}
@@ -0,0 +1,382 @@
+package truedemocracy
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+)
+
+// generateScopedProof proves membership of the given member for an arbitrary
+// nullifier scope and signal against the domain's current Merkle root.
+func generateScopedProof(t *testing.T, k Keeper, ctx sdk.Context, domainName string, secrets [][]byte, memberIndex int, scope, signal []byte) (string, string) {
+	t.Helper()
+	domain, _ := k.GetDomain(ctx, domainName)
+	commitments := make([][]byte, len(domain.IdentityCommits))
+	for i, h := range domain.IdentityCommits {
+		commitments[i], _ = hex.DecodeString(h)
+	}
+	tree := NewMerkleTree(MerkleTreeDepth)
+	if err := tree.BuildFromLeaves(commitments); err != nil {
+		t.Fatalf("BuildFromLeaves failed: %v", err)
+	}
+	siblings, pathIndices, err := tree.GenerateProof(memberIndex)
+	if err != nil {
+		t.Fatalf("GenerateProof failed: %v", err)
+	}
+	proofBytes, nullifierHash, err := GenerateMembershipProofForSignal(getTestZKPKeys(t), secrets[memberIndex], tree.Root, siblings, pathIndices, scope, signal)
+	if err != nil {
+		t.Fatalf("GenerateMembershipProofForSignal failed: %v", err)
+	}
+	return hex.EncodeToString(proofBytes), hex.EncodeToString(nullifierHash)
+}
+
+func anonBallotProof(t *testing.T, k Keeper, ctx sdk.Context, secrets [][]byte, member int, ranking []string) (string, string) {
+	t.Helper()
+	return generateScopedProof(t, k, ctx, "ZKPDomain", secrets, member,
+		ComputeElectionNullifierScope(ctx.ChainID(), "ZKPDomain", "Chair"),
+		ComputeElectionBallotSignal(ctx.ChainID(), "ZKPDomain", "Chair", ranking))
+}
+
+func anonStoneProof(t *testing.T, k Keeper, ctx sdk.Context, secrets [][]byte, member int, list, issueName, target string) (string, string) {
+	t.Helper()
+	return generateScopedProof(t, k, ctx, "ZKPDomain", secrets, member,
+		ComputeStoneNullifierScope(ctx.ChainID(), "ZKPDomain", list, issueName),
+		ComputeStoneSignal(ctx.ChainID(), "ZKPDomain", list, issueName, target))
+}
+
+func TestCastElectionVoteWithProof(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	secrets := setupDomainWithZKPIdentity(t, k, ctx, "ZKPDomain", 3)
+	addProposal(t, k, ctx, "ZKPDomain", "Chair", "Ann")
+	addProposal(t, k, ctx, "ZKPDomain", "Chair", "Ben")
+
+	proof, nullifier := anonBallotProof(t, k, ctx, secrets, 0, []string{"Ann"})
+	if err := k.CastElectionVoteWithProof(ctx, "ZKPDomain", "Chair", []string{"Ben"}, proof, nullifier, ""); err == nil {
+		t.Fatal("proof for another ballot accepted")
+	}
+	if err := k.CastElectionVoteWithProof(ctx, "ZKPDomain", "Chair", []string{"Ann"}, proof, nullifier, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ranking, _, found := k.GetAnonymousElectionBallot(ctx, "ZKPDomain", "Chair", nullifier); !found || !reflect.DeepEqual(ranking, []string{"Ann"}) {
+		t.Fatalf("stored ballot = %v (found %v)", ranking, found)
+	}
+
+	// Re-voting under the same nullifier replaces the ballot.
+	proof, revote := anonBallotProof(t, k, ctx, secrets, 0, []string{"Ben"})
+	if revote != nullifier {
+		t.Fatal("election nullifier depends on the ballot")
+	}
+	if err := k.CastElectionVoteWithProof(ctx, "ZKPDomain", "Chair", []string{"Ben"}, proof, revote, ""); err != nil {
+		t.Fatal(err)
+	}
+	proof, other := anonBallotProof(t, k, ctx, secrets, 1, []string{"Ben"})
+	if err := k.CastElectionVoteWithProof(ctx, "ZKPDomain", "Chair", []string{"Ben"}, proof, other, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := k.TallyElection(ctx, "ZKPDomain", "Chair")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Elected || result.Candidate != "Ben" || result.Votes != 2 || result.Total != 2 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	// Plurality elections take a single candidate.
+	proof, nullifier = anonBallotProof(t, k, ctx, secrets, 2, []string{"Ann", "Ben"})
+	if err := k.CastElectionVoteWithProof(ctx, "ZKPDomain", "Chair", []string{"Ann", "Ben"}, proof, nullifier, ""); err == nil {
+		t.Fatal("ranking accepted in a simple-majority election")
+	}
+}
+
+func TestPlaceStoneWithProof(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	secrets := setupDomainWithZKPIdentity(t, k, ctx, "ZKPDomain", 2)
+	addProposal(t, k, ctx, "ZKPDomain", "Roads", "Repair")
+	addProposal(t, k, ctx, "ZKPDomain", "Parks", "Plant")
+	addProposal(t, k, ctx, "ZKPDomain", "Parks", "Pave")
+	stones := func(issueName string) int {
+		issue, _ := k.GetIssue(ctx, "ZKPDomain", issueName)
+		return issue.Stones
+	}
+
+	proof, nullifier := anonStoneProof(t, k, ctx, secrets, 0, StoneListIssue, "", "Roads")
+	if err := k.PlaceStoneWithProof(ctx, "ZKPDomain", StoneListIssue, "", "Parks", proof, nullifier, ""); err == nil {
+		t.Fatal("proof for another target accepted")
+	}
+	if err := k.PlaceStoneWithProof(ctx, "ZKPDomain", StoneListIssue, "", "Roads", proof, nullifier, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := k.PlaceStoneWithProof(ctx, "ZKPDomain", StoneListIssue, "", "Roads", proof, nullifier, ""); err == nil {
+		t.Fatal("stone placed twice on the same issue")
+	}
+
+	// A proof for a new target under the same nullifier moves the stone.
+	proof, moved := anonStoneProof(t, k, ctx, secrets, 0, StoneListIssue, "", "Parks")
+	if moved != nullifier {
+		t.Fatal("stone nullifier depends on the target")
+	}
+	if err := k.PlaceStoneWithProof(ctx, "ZKPDomain", StoneListIssue, "", "Parks", proof, moved, ""); err != nil {
+		t.Fatal(err)
+	}
+	if stones("Roads") != 0 || stones("Parks") != 1 {
+		t.Fatalf("stones = Roads %d, Parks %d", stones("Roads"), stones("Parks"))
+	}
+	if target, _ := k.GetAnonymousStone(ctx, "ZKPDomain", StoneListIssue, "", nullifier); target != "Parks" {
+		t.Fatalf("stone on %q", target)
+	}
+
+	proof, nullifier = anonStoneProof(t, k, ctx, secrets, 0, StoneListSuggestion, "Parks", "Plant")
+	if err := k.PlaceStoneWithProof(ctx, "ZKPDomain", StoneListSuggestion, "Parks", "Plant", proof, nullifier, ""); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := k.GetSuggestion(ctx, "ZKPDomain", "Parks", "Plant"); s.Stones != 1 {
+		t.Fatalf("suggestion stones = %d", s.Stones)
+	}
+
+	member := sdk.AccAddress("memberB").String()
+	proof, nullifier = anonStoneProof(t, k, ctx, secrets, 0, StoneListMember, "", member)
+	if err := k.PlaceStoneWithProof(ctx, "ZKPDomain", StoneListMember, "", member, proof, nullifier, ""); err != nil {
+		t.Fatal(err)
+	}
+	domain, _ := k.GetDomain(ctx, "ZKPDomain")
+	if ranks := k.SortMembersByStones(ctx, domain); ranks[0].Address != member || ranks[0].Stones != 1 {
+		t.Fatalf("member ranks = %+v", ranks)
+	}
+
+	// Big Purge withdraws every anonymous stone.
+	k.executeBigPurge(ctx, "ZKPDomain")
+	if stones("Parks") != 0 {
+		t.Fatalf("issue stones after purge = %d", stones("Parks"))
+	}
+	if s, _ := k.GetSuggestion(ctx, "ZKPDomain", "Parks", "Plant"); s.Stones != 0 {
+		t.Fatalf("suggestion stones after purge = %d", s.Stones)
+	}
+	if _, found := k.GetAnonymousStone(ctx, "ZKPDomain", StoneListMember, "", nullifier); found {
+		t.Fatal("member stone survived the purge")
+	}
+}
+
+func TestCredentialHoldersKeepOpenBallotsAndStones(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupDomainWithZKPIdentity(t, k, ctx, "ZKPDomain", 2)
+	addProposal(t, k, ctx, "ZKPDomain", "Chair", "Ann")
+	memberA := sdk.AccAddress("memberA").String()
+	memberB := sdk.AccAddress("memberB").String()
+
+	if err := k.CastElectionVote(ctx, "ZKPDomain", "Chair", "Ann", memberA, VoteChoiceApprove); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.PlaceStoneOnIssue(ctx, "ZKPDomain", "Chair", memberA); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.PlaceStoneOnSuggestion(ctx, "ZKPDomain", "Chair", "Ann", memberA); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.PlaceStoneOnMember(ctx, "ZKPDomain", memberB, memberA); err != nil {
+		t.Fatal(err)
+	}
+	if mode := k.GetVoterMode(ctx, "ZKPDomain", memberA); mode != VoterModeAnonymous {
+		t.Fatalf("voter mode = %q, want anonymous", mode)
+	}
+}
+
+func TestPlaceStoneWithProofRejectsClosedIssue(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	secrets := setupDomainWithZKPIdentity(t, k, ctx, "ZKPDomain", 1)
+	addProposal(t, k, ctx, "ZKPDomain", "Parks", "Plant")
+	issue, _ := k.GetIssue(ctx, "ZKPDomain", "Parks")
+	issue.SubDomain = "ParksBoard"
+	k.SetIssue(ctx, "ZKPDomain", issue)
+
+	proof, nullifier := anonStoneProof(t, k, ctx, secrets, 0, StoneListSuggestion, "Parks", "Plant")
+	if err := k.PlaceStoneWithProof(ctx, "ZKPDomain", StoneListSuggestion, "Parks", "Plant", proof, nullifier, ""); err == nil {
+		t.Fatal("anonymous stone on a closed issue accepted")
+	}
+}
+
+func anonProposalProof(t *testing.T, k Keeper, ctx sdk.Context, secrets [][]byte, member int, issueName, suggestionName, externalLink string) (string, string) {
+	t.Helper()
+	return generateScopedProof(t, k, ctx, "ZKPDomain", secrets, member,
+		ComputeProposalNullifierScope(ctx.ChainID(), "ZKPDomain", issueName),
+		ComputeProposalSignal(ctx.ChainID(), "ZKPDomain", issueName, suggestionName, externalLink))
+}
+
+func TestSubmitProposalWithProof(t *testing.T) {
+	k, ctx, bank := setupKeeperWithBank(t)
+	secrets := setupDomainWithZKPIdentity(t, k, ctx, "ZKPDomain", 2)
+	payer := sdk.AccAddress("fee-payer")
+	fee := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 10_000_000))
+	bank.fundAccount(payer, fee.Add(fee...))
+
+	proof, nullifier := anonProposalProof(t, k, ctx, secrets, 0, "Parks", "Plant", "https://example.org/plant")
+	if _, err := k.SubmitProposalWithProof(ctx, payer, "ZKPDomain", "Parks", "Pave", fee, "https://example.org/plant", proof, nullifier, ""); err == nil {
+		t.Fatal("proof for another suggestion accepted")
+	}
+	if _, err := k.SubmitProposalWithProof(ctx, payer, "ZKPDomain", "Parks", "Plant", fee, "", proof, nullifier, ""); err == nil {
+		t.Fatal("proof for another external link accepted")
+	}
+	creator, err := k.SubmitProposalWithProof(ctx, payer, "ZKPDomain", "Parks", "Plant", fee, "https://example.org/plant", proof, nullifier, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creator != AnonymousCreatorAddress(nullifier) {
+		t.Fatalf("creator = %s", creator)
+	}
+	suggestion, _ := k.GetSuggestion(ctx, "ZKPDomain", "Parks", "Plant")
+	if suggestion.Creator != creator || suggestion.ExternalLink != "https://example.org/plant" {
+		t.Fatalf("stored suggestion = %+v", suggestion)
+	}
+	for i := range secrets {
+		if creator == sdk.AccAddress("member"+string(rune('A'+i))).String() {
+			t.Fatal("suggestion records the member's address")
+		}
+	}
+	if accountBalance(bank, payer) != 10_000_000 || moduleBalance(bank) != 10_000_000 {
+		t.Fatalf("payer %d, module %d after escrow", accountBalance(bank, payer), moduleBalance(bank))
+	}
+
+	// One anonymous suggestion per member and issue.
+	proof, again := anonProposalProof(t, k, ctx, secrets, 0, "Parks", "Pave", "")
+	if again != nullifier {
+		t.Fatal("proposal nullifier depends on the suggestion")
+	}
+	if _, err := k.SubmitProposalWithProof(ctx, payer, "ZKPDomain", "Parks", "Pave", fee, "", proof, again, ""); err == nil {
+		t.Fatal("second anonymous suggestion in the same issue accepted")
+	}
+	if _, found := k.GetSuggestion(ctx, "ZKPDomain", "Parks", "Pave"); found {
+		t.Fatal("rejected suggestion stored")
+	}
+
+	// Another issue takes a fresh, unlinkable pseudonym.
+	proof, other := anonProposalProof(t, k, ctx, secrets, 0, "Roads", "Repair", "")
+	creatorRoads, err := k.SubmitProposalWithProof(ctx, payer, "ZKPDomain", "Roads", "Repair", fee, "", proof, other, "")
+	if err != nil {
+		t.Fatalf("suggestion in another issue rejected: %v", err)
+	}
+	if creatorRoads == creator {
+		t.Fatal("pseudonyms link across issues")
+	}
+
+	// An unfunded payer leaves no suggestion and no spent nullifier.
+	proof, nullifier = anonProposalProof(t, k, ctx, secrets, 1, "Parks", "Pave", "")
+	if _, err := k.SubmitProposalWithProof(ctx, payer, "ZKPDomain", "Parks", "Pave", fee, "", proof, nullifier, ""); err == nil {
+		t.Fatal("unfunded submission accepted")
+	}
+	if k.IsNullifierUsed(ctx, "ZKPDomain", nullifier) {
+		t.Fatal("failed submission spent the nullifier")
+	}
+
+	// Admin-only domains reject anonymous suggestions.
+	domain, _ := k.GetDomain(ctx, "ZKPDomain")
+	domain.Options.OnlyAdminIssues = true
+	k.SetDomain(ctx, domain)
+	bank.fundAccount(payer, fee)
+	if _, err := k.SubmitProposalWithProof(ctx, payer, "ZKPDomain", "Parks", "Pave", fee, "", proof, nullifier, ""); err == nil {
+		t.Fatal("anonymous suggestion accepted in an admin-only domain")
+	}
+}
+
+func TestAnonymousVotingScopesAreDistinct(t *testing.T) {
+	scopes := map[string][]byte{
+		"rating":          ComputeVoteNullifierScope("chain", "D", "I", ""),
+		"election":        ComputeElectionNullifierScope("chain", "D", "I"),
+		"issue list":      ComputeStoneNullifierScope("chain", "D", StoneListIssue, ""),
+		"suggestion list": ComputeStoneNullifierScope("chain", "D", StoneListSuggestion, "I"),
+		"member list":     ComputeStoneNullifierScope("chain", "D", StoneListMember, ""),
+		"proposal":        ComputeProposalNullifierScope("chain", "D", "I"),
+		"signal":          ComputeSignalNullifierScope("chain", "D", "I"),
+	}
+	seen := make(map[string]string)
+	for name, scope := range scopes {
+		if prev, dup := seen[string(scope)]; dup {
+			t.Fatalf("%s and %s share a nullifier scope", name, prev)
+		}
+		seen[string(scope)] = name
+	}
+	if reflect.DeepEqual(ComputeElectionBallotSignal("chain", "D", "I", []string{"AB"}), ComputeElectionBallotSignal("chain", "D", "I", []string{"A", "B"})) {
+		t.Fatal("ballot signal is ambiguous")
+	}
+}
+
+func TestMsgAnonymousVotingValidationAndEncoding(t *testing.T) {
+	nullifier := hex.EncodeToString(make([]byte, 32))
+	vote := MsgCastElectionVoteWithProof{
+		Sender:        sdk.AccAddress("relayer"),
+		DomainName:    "ZKPDomain",
+		IssueName:     "Chair",
+		Ranking:       []string{"Ann", "Ben"},
+		Proof:         "abcd",
+		NullifierHash: nullifier,
+	}
+	if err := vote.ValidateBasic(); err != nil {
+		t.Fatalf("valid vote rejected: %v", err)
+	}
+	bz, err := gogoproto.Marshal(&vote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded MsgCastElectionVoteWithProof
+	if err := gogoproto.Unmarshal(bz, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded.Ranking, vote.Ranking) || decoded.NullifierHash != nullifier {
+		t.Fatalf("decoded vote = %+v", decoded)
+	}
+	vote.Choice = 1
+	if err := vote.ValidateBasic(); err == nil {
+		t.Fatal("abstention with a ranking accepted")
+	}
+	vote.Ranking = nil
+	if err := vote.ValidateBasic(); err != nil {
+		t.Fatalf("abstention rejected: %v", err)
+	}
+	vote.NullifierHash = "00"
+	if err := vote.ValidateBasic(); err == nil {
+		t.Fatal("short nullifier accepted")
+	}
+
+	stone := MsgPlaceStoneWithProof{
+		Sender:        sdk.AccAddress("relayer"),
+		DomainName:    "ZKPDomain",
+		List:          StoneListSuggestion,
+		IssueName:     "Parks",
+		Target:        "Plant",
+		Proof:         "abcd",
+		NullifierHash: nullifier,
+	}
+	if err := stone.ValidateBasic(); err != nil {
+		t.Fatalf("valid stone rejected: %v", err)
+	}
+	if bz, indexes := stone.Descriptor(); len(bz) == 0 || len(indexes) == 0 {
+		t.Fatal("message descriptor missing")
+	}
+	stone.List = StoneListIssue
+	if err := stone.ValidateBasic(); err == nil {
+		t.Fatal("issue list stone with an issue name accepted")
+	}
+	stone.List = "validators"
+	if err := stone.ValidateBasic(); err == nil {
+		t.Fatal("unknown stone list accepted")
+	}
+
+	proposal := MsgSubmitProposalWithProof{
+		Sender:         sdk.AccAddress("relayer"),
+		DomainName:     "ZKPDomain",
+		IssueName:      "Parks",
+		SuggestionName: "Plant",
+		Fee:            sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 1000)),
+		Proof:          "abcd",
+		NullifierHash:  nullifier,
+	}
+	if err := proposal.ValidateBasic(); err != nil {
+		t.Fatalf("valid proposal rejected: %v", err)
+	}
+	proposal.Fee = sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000))
+	if err := proposal.ValidateBasic(); err == nil {
+		t.Fatal("non-PNYX fee accepted")
+	}
+}
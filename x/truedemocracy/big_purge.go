@@ -14,7 +14,7 @@ func (k Keeper) CheckAndExecuteBigPurges(ctx sdk.Context) {
 
 	// Collect domain names first to avoid modifying store during iteration.
 	var domainNames []string
-	k.IterateDomains(ctx, func(d Domain) bool {
+	k.IterateDomainHeaders(ctx, func(d Domain) bool {
 		domainNames = append(domainNames, d.Name)
 		return false
 	})
@@ -60,25 +60,32 @@ func (k Keeper) checkDomainPurge(ctx sdk.Context, domainName string, now int64)
 // executeBigPurge clears a domain's permission register. This is system-initiated
 // from EndBlock so no admin auth is required. Member list stays intact (WP S4).
 func (k Keeper) executeBigPurge(ctx sdk.Context, domainName string) {
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return
 	}
 
-	domain.PermissionReg = []string{}
+	k.clearPermissionRegister(ctx, domainName)
 
 	// v0.3.0: also clear ZKP identity commitments, Merkle root, and root history.
-	domain.IdentityCommits = []string{}
+	k.clearIdentityCommits(ctx, domainName)
+	k.clearIdentityLeafOwners(ctx, domainName)
+	k.clearIdentityRotations(ctx, domainName)
 	domain.MerkleRoot = ""
 	domain.MerkleRootHistory = []string{}
-
-	store := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	k.SetDomainHeader(ctx, domain)
 
 	// v0.3.0: clear all used nullifiers for this domain.
 	k.PurgeNullifiers(ctx, domainName)
 
+	// Anonymous stones, ballots and signals are keyed by nullifiers of the
+	// cleared commitments; withdraw them so re-registered members start fresh.
+	k.purgeAnonymousVotes(ctx, domainName)
+
+	// Members may choose again between open and anonymous voting, so
+	// delegations from former credential holders count again.
+	k.clearVoterModes(ctx, domainName)
+
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
 		"big_purge_executed",
 		sdk.NewAttribute("domain", domainName),
@@ -18,9 +18,7 @@ func TestBigPurgeNotTriggeredBeforeTime(t *testing.T) {
 	// Add member and register a domain key.
 	domain, _ := k.GetDomain(ctx, "PurgeDomain")
 	domain.Members = append(domain.Members, "alice")
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:PurgeDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	aliceKey := domainKey("alice-purge-test")
 	if err := k.JoinPermissionRegister(ctx, "PurgeDomain", "alice", aliceKey.PubKey().Bytes()); err != nil {
@@ -44,9 +42,7 @@ func TestBigPurgeTriggeredAtScheduledTime(t *testing.T) {
 	// Add members and register keys.
 	domain, _ := k.GetDomain(ctx, "PurgeDomain")
 	domain.Members = append(domain.Members, "alice", "bob")
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:PurgeDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	aliceKey := domainKey("alice-purge-trigger")
 	bobKey := domainKey("bob-purge-trigger")
@@ -78,9 +74,7 @@ func TestBigPurgeMembersPreserved(t *testing.T) {
 
 	domain, _ := k.GetDomain(ctx, "PurgeDomain")
 	domain.Members = append(domain.Members, "alice", "bob")
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:PurgeDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	aliceKey := domainKey("alice-member-preserve")
 	k.JoinPermissionRegister(ctx, "PurgeDomain", "alice", aliceKey.PubKey().Bytes())
@@ -233,9 +227,7 @@ func TestMultipleDomainsPurgeIndependently(t *testing.T) {
 	k.CreateDomain(ctx, "Domain1", sdk.AccAddress("admin1"), sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 100_000)))
 	domain1, _ := k.GetDomain(ctx, "Domain1")
 	domain1.Members = append(domain1.Members, "alice")
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain1)
-	st.Set([]byte("domain:Domain1"), bz)
+	k.SetDomain(ctx, domain1)
 
 	aliceKey := domainKey("alice-multi-purge")
 	k.JoinPermissionRegister(ctx, "Domain1", "alice", aliceKey.PubKey().Bytes())
@@ -245,8 +237,7 @@ func TestMultipleDomainsPurgeIndependently(t *testing.T) {
 	k.CreateDomain(ctx2, "Domain2", sdk.AccAddress("admin2"), sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 100_000)))
 	domain2, _ := k.GetDomain(ctx2, "Domain2")
 	domain2.Members = append(domain2.Members, "bob")
-	bz = k.cdc.MustMarshalLengthPrefixed(&domain2)
-	st.Set([]byte("domain:Domain2"), bz)
+	k.SetDomain(ctx, domain2)
 
 	bobKey := domainKey("bob-multi-purge")
 	k.JoinPermissionRegister(ctx2, "Domain2", "bob", bobKey.PubKey().Bytes())
@@ -1,8 +1,10 @@
 package truedemocracy
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"os"
 	"strconv"
 
 	"github.com/spf13/cobra"
@@ -28,6 +30,12 @@ func GetTxCmd() *cobra.Command {
 		CmdSubmitProposal(),
 		CmdRegisterValidator(),
 		CmdWithdrawStake(),
+		CmdDelegateStake(),
+		CmdUndelegateStake(),
+		CmdEditValidator(),
+		CmdClaimStakingRewards(),
+		CmdSetStakingRewardAddress(),
+		CmdVoteValidatorSetParams(),
 		CmdRemoveValidator(),
 		CmdRotateValidatorKey(),
 		CmdUnjail(),
@@ -40,16 +48,29 @@ func GetTxCmd() *cobra.Command {
 		CmdVoteToDelete(),
 		CmdRateProposal(),
 		CmdCastElectionVote(),
+		CmdCastRankedElectionVote(),
 		CmdAddMember(),
 		CmdOnboardToDomain(),
 		CmdApproveOnboarding(),
 		CmdRejectOnboarding(),
 		CmdRegisterIdentity(),
+		CmdRotateIdentity(),
 		CmdRateWithProof(),
+		CmdCastElectionVoteWithProof(),
+		CmdPlaceStoneWithProof(),
+		CmdSubmitProposalWithProof(),
+		CmdSignalWithProof(),
+		CmdRateOpenly(),
+		CmdDelegateVote(),
+		CmdCreateSubDomain(),
+		CmdVoteSubDomainBudget(),
+		CmdDelegateIssueToSubDomain(),
+		CmdProposeDomainOptionsChange(),
 		CmdDepositToDomain(),
 		CmdWithdrawFromDomain(),
 		CmdVoteSoftwareUpgrade(),
 		CmdVoteCancelSoftwareUpgrade(),
+		CmdVoteVerifyingKeyRotation(),
 	)
 	return txCmd
 }
@@ -73,6 +94,21 @@ func GetQueryCmd(cdc *codec.LegacyAmino) *cobra.Command {
 		CmdQueryZKPState(cdc),
 		CmdQueryMerkleProof(cdc),
 		CmdQueryPayToPut(cdc),
+		CmdQueryDomainIssues(cdc),
+		CmdQueryIssueSuggestions(cdc),
+		CmdQueryDomainMembers(cdc),
+		CmdQueryIssueDecision(cdc),
+		CmdQueryIssueDecisions(cdc),
+		CmdQueryVotingWeight(cdc),
+		CmdQuerySubDomains(cdc),
+		CmdQueryTreasuryPayouts(cdc),
+		CmdQueryDomainOptionsHistory(cdc),
+		CmdQueryAnonymousSignals(cdc),
+		CmdQueryStakeDelegations(cdc),
+		CmdQueryPendingUndelegations(cdc),
+		CmdQueryStakingRewards(cdc),
+		CmdQueryValidatorSet(cdc),
+		CmdQueryEpochInfo(cdc),
 	)
 	return queryCmd
 }
@@ -135,12 +171,28 @@ func CmdSubmitProposal() *cobra.Command {
 				Fee:            fee,
 				ExternalLink:   link,
 			}
+			msg.ClosesAt, _ = cmd.Flags().GetInt64("closes-at")
+			msg.RatingQuorum, _ = cmd.Flags().GetInt64("rating-quorum")
+			msg.StoneQuorum, _ = cmd.Flags().GetInt64("stone-quorum")
+			msg.Seats, _ = cmd.Flags().GetInt64("seats")
+			msg.PayoutRecipient, _ = cmd.Flags().GetString("payout-recipient")
+			msg.PayoutAmount, _ = cmd.Flags().GetInt64("payout-amount")
+			msg.PayoutTranches, _ = cmd.Flags().GetInt64("payout-tranches")
+			msg.PayoutInterval, _ = cmd.Flags().GetInt64("payout-interval")
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
 			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 	}
+	cmd.Flags().Int64("closes-at", 0, "Unix time at which a new issue is decided (0 = no deadline)")
+	cmd.Flags().Int64("rating-quorum", 0, "Decide a new issue once it has this many ratings (0 = none)")
+	cmd.Flags().Int64("stone-quorum", 0, "Decide a new issue once its suggestions hold this many stones (0 = none)")
+	cmd.Flags().Int64("seats", 0, "Seats a person election on a new issue fills (0 = single winner)")
+	cmd.Flags().String("payout-recipient", "", "Recipient of a treasury payout carried by the suggestion")
+	cmd.Flags().Int64("payout-amount", 0, "Treasury payout in upnyx, paid when the suggestion stays green past its dwell time or wins")
+	cmd.Flags().Int64("payout-tranches", 0, "Vest the payout in this many equal tranches (0 = single payment)")
+	cmd.Flags().Int64("payout-interval", 0, "Seconds between vesting tranches")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
@@ -166,12 +218,14 @@ func CmdRegisterValidator() *cobra.Command {
 				Stake:        stake,
 				DomainName:   args[2],
 			}
+			msg.CommissionBps, _ = cmd.Flags().GetInt64("commission-bps")
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
 			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 	}
+	cmd.Flags().Int64("commission-bps", 0, "Share of delegator rewards kept by the operator, in basis points")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
@@ -205,6 +259,175 @@ func CmdWithdrawStake() *cobra.Command {
 	return cmd
 }
 
+func CmdDelegateStake() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegate-stake [validator-addr] [amount]",
+		Short: "Delegate PNYX to a Proof of Domain validator",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoinsNormalized(args[1])
+			if err != nil {
+				return err
+			}
+			msg := MsgDelegateStake{
+				Sender:        clientCtx.GetFromAddress(),
+				ValidatorAddr: args[0],
+				Amount:        amount,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdUndelegateStake() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undelegate-stake [validator-addr] [amount]",
+		Short: "Undelegate PNYX; it is released once the evidence window has passed",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			amount, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid amount: %w", err)
+			}
+			msg := MsgUndelegateStake{
+				Sender:        clientCtx.GetFromAddress(),
+				ValidatorAddr: args[0],
+				Amount:        amount,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdEditValidator() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit-validator",
+		Short: "Edit your validator's description and commission",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			msg := MsgEditValidator{Sender: clientCtx.GetFromAddress()}
+			msg.Moniker, _ = cmd.Flags().GetString("moniker")
+			msg.Website, _ = cmd.Flags().GetString("website")
+			msg.SecurityContact, _ = cmd.Flags().GetString("security-contact")
+			msg.Identity, _ = cmd.Flags().GetString("identity")
+			if cmd.Flags().Changed("commission-bps") {
+				msg.CommissionBps, _ = cmd.Flags().GetInt64("commission-bps")
+				msg.UpdateCommission = true
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	cmd.Flags().String("moniker", DoNotModifyDescription, "Validator name")
+	cmd.Flags().String("website", DoNotModifyDescription, "Validator website")
+	cmd.Flags().String("security-contact", DoNotModifyDescription, "Security contact email")
+	cmd.Flags().String("identity", DoNotModifyDescription, "Identity signature, e.g. a Keybase key ID")
+	cmd.Flags().Int64("commission-bps", 0, "New commission in basis points (rate limited)")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdClaimStakingRewards() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim-staking-rewards [validator-addr]",
+		Short: "Claim staking rewards as operator and, optionally, as a delegator of a validator",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			msg := MsgClaimStakingRewards{Sender: clientCtx.GetFromAddress()}
+			if len(args) == 1 {
+				msg.ValidatorAddr = args[0]
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdVoteValidatorSetParams() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote-validator-set-params [max-validators] [epoch-length]",
+		Short: "Vote for a new validator-set cap and epoch length in blocks (governance domain members, 2/3 majority)",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			maxValidators, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid max validators: %w", err)
+			}
+			epochLength, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid epoch length: %w", err)
+			}
+			msg := MsgVoteValidatorSetParams{Sender: clientCtx.GetFromAddress(), MaxValidators: maxValidators, EpochLength: epochLength}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdSetStakingRewardAddress() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-staking-reward-address [withdraw-addr]",
+		Short: "Set where staking reward claims are paid; omit the address to pay yourself",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			msg := MsgSetStakingRewardAddress{Sender: clientCtx.GetFromAddress()}
+			if len(args) == 1 {
+				msg.WithdrawAddr = args[0]
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
 func CmdRemoveValidator() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "remove-validator [operator-addr]",
@@ -527,6 +750,33 @@ func CmdCastElectionVote() *cobra.Command {
 	return cmd
 }
 
+func CmdCastRankedElectionVote() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cast-ranked-election-vote [domain] [issue] [candidate]...",
+		Short: "Cast a ranked ballot in a ranked-choice or Schulze election, most preferred first",
+		Args:  cobra.MinimumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			msg := MsgCastRankedElectionVote{
+				Sender:     clientCtx.GetFromAddress(),
+				DomainName: args[0],
+				IssueName:  args[1],
+				VoterAddr:  clientCtx.GetFromAddress().String(),
+				Ranking:    args[2:],
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
 func CmdAddMember() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "add-member [domain] [new-member]",
@@ -655,6 +905,33 @@ func CmdRegisterIdentity() *cobra.Command {
 	return cmd
 }
 
+func CmdRotateIdentity() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-identity [domain] [old-commitment-hex] [new-commitment-hex]",
+		Short: "Replace one of your ZKP identity commitments with a new one",
+		Long:  "Retire an identity commitment you registered and register a new MiMC commitment in its place. Other members' leaves are unchanged; proofs must then be made against the new Merkle root.",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			msg := MsgRotateIdentityCommitment{
+				Sender:        clientCtx.GetFromAddress(),
+				DomainName:    args[0],
+				OldCommitment: args[1],
+				NewCommitment: args[2],
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
 func CmdRateWithProof() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "rate-with-proof [domain] [issue] [suggestion] [rating] [proof-hex] [nullifier-hex] [reward-recipient] [merkle-root-hex]",
@@ -695,29 +972,30 @@ func CmdRateWithProof() *cobra.Command {
 	return cmd
 }
 
-func CmdVoteSoftwareUpgrade() *cobra.Command {
+func CmdCastElectionVoteWithProof() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "vote-software-upgrade [plan-name] [height] [info]",
-		Short: "Vote to schedule a software-upgrade plan (governance domain members, 2/3 majority)",
-		Args:  cobra.RangeArgs(2, 3),
+		Use:   "cast-election-vote-with-proof [domain] [issue] [proof-hex] [nullifier-hex] [candidate]...",
+		Short: "Cast an anonymous election ballot with a ZKP membership proof",
+		Long:  "Cast an anonymous election ballot, most preferred candidate first, or abstain with --abstain. The proof's public signal must bind the exact ballot under the election's nullifier scope; a later proof with the same nullifier replaces the ballot. Use --merkle-root to prove against a historical root.",
+		Args:  cobra.MinimumNArgs(4),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
-			height, err := strconv.ParseInt(args[1], 10, 64)
-			if err != nil {
-				return fmt.Errorf("invalid height: %w", err)
-			}
-			info := ""
-			if len(args) > 2 {
-				info = args[2]
+			abstain, _ := cmd.Flags().GetBool("abstain")
+			merkleRoot, _ := cmd.Flags().GetString("merkle-root")
+			msg := MsgCastElectionVoteWithProof{
+				Sender:        clientCtx.GetFromAddress(),
+				DomainName:    args[0],
+				IssueName:     args[1],
+				Ranking:       args[4:],
+				Proof:         args[2],
+				NullifierHash: args[3],
+				MerkleRoot:    merkleRoot,
 			}
-			msg := MsgVoteSoftwareUpgrade{
-				Sender:   clientCtx.GetFromAddress(),
-				PlanName: args[0],
-				Height:   height,
-				Info:     info,
+			if abstain {
+				msg.Choice = 1
 			}
 			if err := msg.ValidateBasic(); err != nil {
 				return err
@@ -725,23 +1003,34 @@ func CmdVoteSoftwareUpgrade() *cobra.Command {
 			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 	}
+	cmd.Flags().Bool("abstain", false, "Abstain instead of ranking candidates")
+	cmd.Flags().String("merkle-root", "", "Historical Merkle root the proof was generated against")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
-func CmdVoteCancelSoftwareUpgrade() *cobra.Command {
+func CmdPlaceStoneWithProof() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "vote-cancel-software-upgrade [plan-name]",
-		Short: "Vote to cancel the scheduled software-upgrade plan (2/3 of the original snapshot)",
-		Args:  cobra.ExactArgs(1),
+		Use:   "place-stone-with-proof [domain] [issue|suggestion|member] [target] [proof-hex] [nullifier-hex]",
+		Short: "Place or move an anonymous stone with a ZKP membership proof",
+		Long:  "Place an anonymous stone on an issue, a suggestion (with --issue), or a member. The proof's public signal must bind the target under the stone list's nullifier scope; a later proof with the same nullifier moves the stone. Use --merkle-root to prove against a historical root.",
+		Args:  cobra.ExactArgs(5),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
-			msg := MsgVoteCancelSoftwareUpgrade{
-				Sender:   clientCtx.GetFromAddress(),
-				PlanName: args[0],
+			issueName, _ := cmd.Flags().GetString("issue")
+			merkleRoot, _ := cmd.Flags().GetString("merkle-root")
+			msg := MsgPlaceStoneWithProof{
+				Sender:        clientCtx.GetFromAddress(),
+				DomainName:    args[0],
+				List:          args[1],
+				IssueName:     issueName,
+				Target:        args[2],
+				Proof:         args[3],
+				NullifierHash: args[4],
+				MerkleRoot:    merkleRoot,
 			}
 			if err := msg.ValidateBasic(); err != nil {
 				return err
@@ -749,114 +1038,712 @@ func CmdVoteCancelSoftwareUpgrade() *cobra.Command {
 			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 	}
+	cmd.Flags().String("issue", "", "Issue whose suggestion list holds the stone")
+	cmd.Flags().String("merkle-root", "", "Historical Merkle root the proof was generated against")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
-// --- Query Commands ---
-
-func CmdQueryDomain(cdc *codec.LegacyAmino) *cobra.Command {
+func CmdSubmitProposalWithProof() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "domain [name]",
-		Short: "Query a domain by name",
-		Args:  cobra.ExactArgs(1),
+		Use:   "submit-proposal-with-proof [domain] [issue] [suggestion] [fee] [proof-hex] [nullifier-hex]",
+		Short: "Submit a suggestion anonymously with a ZKP membership proof",
+		Long:  "Submit a suggestion without revealing its author. The proof's public signal must bind the suggestion and external link under the issue's proposal nullifier scope, which allows one anonymous suggestion per member and issue. The signing account pays the fee; the suggestion records a pseudonym derived from the nullifier as its creator. Use --merkle-root to prove against a historical root.",
+		Args:  cobra.ExactArgs(6),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
-			queryClient := NewQueryClient(clientCtx)
-			resp, err := queryClient.Domain(cmd.Context(), &QueryDomainRequest{Name: args[0]})
+			fee, err := sdk.ParseCoinsNormalized(args[3])
 			if err != nil {
 				return err
 			}
-			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+			externalLink, _ := cmd.Flags().GetString("external-link")
+			merkleRoot, _ := cmd.Flags().GetString("merkle-root")
+			msg := MsgSubmitProposalWithProof{
+				Sender:         clientCtx.GetFromAddress(),
+				DomainName:     args[0],
+				IssueName:      args[1],
+				SuggestionName: args[2],
+				Fee:            fee,
+				ExternalLink:   externalLink,
+				Proof:          args[4],
+				NullifierHash:  args[5],
+				MerkleRoot:     merkleRoot,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 	}
-	flags.AddQueryFlagsToCmd(cmd)
+	cmd.Flags().String("external-link", "", "Link to details or arguments, bound into the proof")
+	cmd.Flags().String("merkle-root", "", "Historical Merkle root the proof was generated against")
+	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
-func CmdQueryDomains(cdc *codec.LegacyAmino) *cobra.Command {
+func CmdSignalWithProof() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "domains",
-		Short: "List all domains",
-		Args:  cobra.NoArgs,
+		Use:   "signal-with-proof [domain] [topic] [signal] [proof-hex] [nullifier-hex]",
+		Short: "Post an anonymous signal to a topic with a ZKP membership proof",
+		Long:  "Post a signal, such as a poll choice, a comment hash or an attestation, to an application-defined topic without revealing which member sent it. The proof's public signal must bind the signal under the topic's nullifier scope, which allows one signal per member and topic. Use --merkle-root to prove against a historical root.",
+		Args:  cobra.ExactArgs(5),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
-			queryClient := NewQueryClient(clientCtx)
-			resp, err := queryClient.Domains(cmd.Context(), &QueryDomainsRequest{})
-			if err != nil {
+			merkleRoot, _ := cmd.Flags().GetString("merkle-root")
+			msg := MsgSignalWithProof{
+				Sender:        clientCtx.GetFromAddress(),
+				DomainName:    args[0],
+				Topic:         args[1],
+				Signal:        args[2],
+				Proof:         args[3],
+				NullifierHash: args[4],
+				MerkleRoot:    merkleRoot,
+			}
+			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
-			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 	}
-	flags.AddQueryFlagsToCmd(cmd)
+	cmd.Flags().String("merkle-root", "", "Historical Merkle root the proof was generated against")
+	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
-func CmdQueryValidator(cdc *codec.LegacyAmino) *cobra.Command {
+func CmdRateOpenly() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "validator [operator-addr]",
-		Short: "Query a validator by operator address",
-		Args:  cobra.ExactArgs(1),
+		Use:   "rate-openly [domain] [issue] [suggestion] [rating]",
+		Short: "Rate a suggestion under your own address, carrying delegated votes",
+		Args:  cobra.ExactArgs(4),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
-			queryClient := NewQueryClient(clientCtx)
-			resp, err := queryClient.Validator(cmd.Context(), &QueryValidatorRequest{OperatorAddr: args[0]})
+			rating, err := strconv.ParseInt(args[3], 10, 32)
 			if err != nil {
+				return fmt.Errorf("invalid rating: %w", err)
+			}
+			msg := MsgRateOpenly{
+				Sender:         clientCtx.GetFromAddress(),
+				DomainName:     args[0],
+				IssueName:      args[1],
+				SuggestionName: args[2],
+				Rating:         int32(rating),
+				MemberAddr:     clientCtx.GetFromAddress().String(),
+			}
+			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
-			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 	}
-	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
-func CmdQueryValidators(cdc *codec.LegacyAmino) *cobra.Command {
+func CmdDelegateVote() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "validators",
-		Short: "List all validators",
-		Args:  cobra.NoArgs,
+		Use:   "delegate-vote [domain] [delegate]",
+		Short: "Delegate your vote to another domain member",
+		Long:  "Delegate your vote in a domain, or on one issue with --issue. An issue delegation overrides the domain-wide one on that issue. Omit the delegate to revoke the delegation.",
+		Args:  cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientQueryContext(cmd)
+			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
-			queryClient := NewQueryClient(clientCtx)
-			resp, err := queryClient.Validators(cmd.Context(), &QueryValidatorsRequest{})
-			if err != nil {
-				return err
+			issueName, _ := cmd.Flags().GetString("issue")
+			delegate := ""
+			if len(args) > 1 {
+				delegate = args[1]
 			}
-			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+			msg := MsgDelegateVote{
+				Sender:     clientCtx.GetFromAddress(),
+				DomainName: args[0],
+				IssueName:  issueName,
+				Delegator:  clientCtx.GetFromAddress().String(),
+				Delegate:   delegate,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	cmd.Flags().String("issue", "", "Delegate only on this issue")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdCreateSubDomain() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-sub-domain [parent] [name]",
+		Short: "Create a sub-domain of a domain you administer",
+		Long:  "Create a child domain under a parent you administer. The admin (--admin, default yourself) must be a member of the parent; later members must be too.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			admin, _ := cmd.Flags().GetString("admin")
+			msg := MsgCreateSubDomain{
+				Sender:       clientCtx.GetFromAddress(),
+				ParentDomain: args[0],
+				Name:         args[1],
+				Admin:        admin,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	cmd.Flags().String("admin", "", "Admin of the sub-domain (default: sender)")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdVoteSubDomainBudget() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote-sub-domain-budget [parent] [sub-domain] [amount]",
+		Short: "Vote to move part of a parent treasury to a sub-domain",
+		Long:  "Vote, as a parent member, to allocate an amount of the parent treasury to a sub-domain. The transfer runs once 2/3 of the parent members voted for the same amount. Example: vote-sub-domain-budget Parent Child 100000upnyx",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			coin, err := sdk.ParseCoinNormalized(args[2])
+			if err != nil {
+				return err
+			}
+			msg := MsgVoteSubDomainBudget{
+				Sender:       clientCtx.GetFromAddress(),
+				ParentDomain: args[0],
+				SubDomain:    args[1],
+				Amount:       coin,
+				VoterAddr:    clientCtx.GetFromAddress().String(),
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdDelegateIssueToSubDomain() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegate-issue-to-sub-domain [domain] [issue] [sub-domain]",
+		Short: "Hand an open issue to a sub-domain for decision (admin only)",
+		Long:  "Copy an open issue and its suggestions into a direct sub-domain. The sub-domain votes on it and its decision is recorded on the parent issue.",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			msg := MsgDelegateIssueToSubDomain{
+				Sender:     clientCtx.GetFromAddress(),
+				DomainName: args[0],
+				IssueName:  args[1],
+				SubDomain:  args[2],
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdProposeDomainOptionsChange() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose-domain-options-change [domain]",
+		Short: "Propose or endorse new domain options (member vote)",
+		Long:  "Vote, as a member, for a new options set of a domain. Options not given as flags keep their current value. The change applies once the domain's options-change majority (default 2/3 of members) voted for the same set. Example: propose-domain-options-change MyDomain --approval-threshold 1000 --anyone-can-join=true",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			resp, err := NewQueryClient(clientCtx).Domain(cmd.Context(), &QueryDomainRequest{Name: args[0]})
+			if err != nil {
+				return err
+			}
+			var domain Domain
+			if err := json.Unmarshal(resp.Result, &domain); err != nil {
+				return err
+			}
+			opts := domain.Options
+			fs := cmd.Flags()
+			for name, target := range map[string]*bool{
+				"admin-electable":    &opts.AdminElectable,
+				"anyone-can-join":    &opts.AnyoneCanJoin,
+				"only-admin-issues":  &opts.OnlyAdminIssues,
+				"coin-burn-required": &opts.CoinBurnRequired,
+				"abstention-allowed": &opts.AbstentionAllowed,
+			} {
+				if fs.Changed(name) {
+					if *target, err = fs.GetBool(name); err != nil {
+						return err
+					}
+				}
+			}
+			for name, target := range map[string]*int64{
+				"approval-threshold":      &opts.ApprovalThreshold,
+				"default-dwell-time":      &opts.DefaultDwellTime,
+				"payout-cap-per-epoch":    &opts.PayoutCapPerEpoch,
+				"options-change-majority": &opts.OptionsChangeMajorityBps,
+			} {
+				if fs.Changed(name) {
+					if *target, err = fs.GetInt64(name); err != nil {
+						return err
+					}
+				}
+			}
+			if fs.Changed("voting-mode") {
+				mode, err := fs.GetInt32("voting-mode")
+				if err != nil {
+					return err
+				}
+				opts.VotingMode = VotingMode(mode)
+			}
+			if fs.Changed("zkp-circuit") {
+				if opts.ZKPCircuit, err = fs.GetString("zkp-circuit"); err != nil {
+					return err
+				}
+			}
+			msg := MsgProposeDomainOptionsChange{
+				Sender:                   clientCtx.GetFromAddress(),
+				DomainName:               args[0],
+				VoterAddr:                clientCtx.GetFromAddress().String(),
+				AdminElectable:           opts.AdminElectable,
+				AnyoneCanJoin:            opts.AnyoneCanJoin,
+				OnlyAdminIssues:          opts.OnlyAdminIssues,
+				CoinBurnRequired:         opts.CoinBurnRequired,
+				ApprovalThreshold:        opts.ApprovalThreshold,
+				DefaultDwellTime:         opts.DefaultDwellTime,
+				VotingMode:               int32(opts.VotingMode),
+				AbstentionAllowed:        opts.AbstentionAllowed,
+				PayoutCapPerEpoch:        opts.PayoutCapPerEpoch,
+				OptionsChangeMajorityBps: opts.OptionsChangeMajorityBps,
+				ZKPCircuit:               opts.ZKPCircuit,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	cmd.Flags().Bool("admin-electable", false, "Whether the admin can be replaced by election")
+	cmd.Flags().Bool("anyone-can-join", false, "Whether anyone can join without approval")
+	cmd.Flags().Bool("only-admin-issues", false, "Whether only the admin can open issues")
+	cmd.Flags().Bool("coin-burn-required", false, "Whether proposals must burn the domain cost")
+	cmd.Flags().Bool("abstention-allowed", false, "Whether explicit abstention is allowed in elections")
+	cmd.Flags().Int64("approval-threshold", 0, "Green-zone approval threshold in basis points (0 = default)")
+	cmd.Flags().Int64("default-dwell-time", 0, "Green-zone dwell time in seconds (0 = default)")
+	cmd.Flags().Int64("payout-cap-per-epoch", 0, "Treasury payout cap per epoch in upnyx (0 = voted payouts uncapped, admin withdrawals capped at 10% of the treasury)")
+	cmd.Flags().Int64("options-change-majority", 0, "Majority for later options changes in basis points, 5001..10000 (0 = 2/3)")
+	cmd.Flags().Int32("voting-mode", 0, "Election voting mode (0 simple, 1 absolute, 2 consensing, 3 ranked, 4 Schulze, 5 STV, 6 D'Hondt)")
+	cmd.Flags().String("zkp-circuit", "", "Membership circuit of anonymous proofs ("+MembershipCircuitID+" or "+Poseidon2MembershipCircuitID+"); the hash family can change only while no identity commitment is registered")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdVoteSoftwareUpgrade() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote-software-upgrade [plan-name] [height] [info]",
+		Short: "Vote to schedule a software-upgrade plan (governance domain members, 2/3 majority)",
+		Args:  cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			height, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid height: %w", err)
+			}
+			info := ""
+			if len(args) > 2 {
+				info = args[2]
+			}
+			msg := MsgVoteSoftwareUpgrade{
+				Sender:   clientCtx.GetFromAddress(),
+				PlanName: args[0],
+				Height:   height,
+				Info:     info,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdVoteCancelSoftwareUpgrade() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote-cancel-software-upgrade [plan-name]",
+		Short: "Vote to cancel the scheduled software-upgrade plan (2/3 of the original snapshot)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			msg := MsgVoteCancelSoftwareUpgrade{
+				Sender:   clientCtx.GetFromAddress(),
+				PlanName: args[0],
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdVoteVerifyingKeyRotation() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote-verifying-key-rotation [circuit-id] [verifying-key-file] [sha256] [transition-blocks]",
+		Short: "Vote to replace the ZKP membership verifying key (governance domain members, 2/3 majority)",
+		Long: `Vote to replace the ZKP membership verifying key with the ceremony output in
+verifying-key-file. The SHA-256 fingerprint must be the one published with the
+ceremony transcript. After the rotation, proofs for the replaced key are still
+accepted for transition-blocks blocks.`,
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			vkBytes, err := os.ReadFile(args[1])
+			if err != nil {
+				return fmt.Errorf("read verifying key: %w", err)
+			}
+			transition, err := strconv.ParseInt(args[3], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid transition blocks: %w", err)
+			}
+			msg := MsgVoteVerifyingKeyRotation{
+				Sender:             clientCtx.GetFromAddress(),
+				CircuitID:          args[0],
+				VerifyingKeyHex:    hex.EncodeToString(vkBytes),
+				VerifyingKeySHA256: args[2],
+				TransitionBlocks:   transition,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// --- Query Commands ---
+
+func CmdQueryDomain(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "domain [name]",
+		Short: "Query a domain by name",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.Domain(cmd.Context(), &QueryDomainRequest{Name: args[0]})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryDomains(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "domains",
+		Short: "List domains with member and issue counts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.Domains(cmd.Context(), &QueryDomainsRequest{Pagination: pageReq})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "domains")
+	return cmd
+}
+
+func CmdQueryValidator(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator [operator-addr]",
+		Short: "Query a validator by operator address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.Validator(cmd.Context(), &QueryValidatorRequest{OperatorAddr: args[0]})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryValidators(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validators",
+		Short: "List validators",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.Validators(cmd.Context(), &QueryValidatorsRequest{Pagination: pageReq})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "validators")
+	return cmd
+}
+
+func CmdQueryNullifier(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "nullifier [domain] [nullifier-hex]",
+		Short: "Check if a nullifier has been used in a domain",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.Nullifier(cmd.Context(), &QueryNullifierRequest{
+				DomainName:    args[0],
+				NullifierHash: args[1],
+			})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryPurgeSchedule(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "purge-schedule [domain]",
+		Short: "Query the Big Purge schedule for a domain",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.PurgeSchedule(cmd.Context(), &QueryPurgeScheduleRequest{
+				DomainName: args[0],
+			})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryZKPState(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "zkp-state [domain]",
+		Short: "Query lightweight ZKP state for a domain",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.ZKPState(cmd.Context(), &QueryZKPStateRequest{
+				DomainName: args[0],
+			})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryMerkleProof(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "merkle-proof [domain] [commitment-hex]",
+		Short: "Query a Merkle membership proof for an identity commitment",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.MerkleProof(cmd.Context(), &QueryMerkleProofRequest{
+				DomainName: args[0],
+				Commitment: args[1],
+			})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryPayToPut(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pay-to-put [domain]",
+		Short: "Query the current PayToPut proposal fee for a domain (eq.3)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.PayToPut(cmd.Context(), &QueryPayToPutRequest{
+				DomainName: args[0],
+			})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
 		},
 	}
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
 
-func CmdQueryNullifier(cdc *codec.LegacyAmino) *cobra.Command {
+func CmdQueryDomainIssues(cdc *codec.LegacyAmino) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "nullifier [domain] [nullifier-hex]",
-		Short: "Check if a nullifier has been used in a domain",
+		Use:   "domain-issues [domain]",
+		Short: "List the issues of a domain, optionally sorted by stones or activity",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			sortBy, _ := cmd.Flags().GetString("sort-by")
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.DomainIssues(cmd.Context(), &QueryDomainIssuesRequest{
+				DomainName: args[0],
+				SortBy:     sortBy,
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	cmd.Flags().String("sort-by", "", "Sort order: stones or activity (default creation order)")
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "domain-issues")
+	return cmd
+}
+
+func CmdQueryIssueSuggestions(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "issue-suggestions [domain] [issue]",
+		Short: "List the suggestions of an issue, filtered by color and score",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			color, _ := cmd.Flags().GetString("color")
+			minScore, _ := cmd.Flags().GetString("min-score")
+			maxScore, _ := cmd.Flags().GetString("max-score")
+			sortBy, _ := cmd.Flags().GetString("sort-by")
 			queryClient := NewQueryClient(clientCtx)
-			resp, err := queryClient.Nullifier(cmd.Context(), &QueryNullifierRequest{
-				DomainName:    args[0],
-				NullifierHash: args[1],
+			resp, err := queryClient.IssueSuggestions(cmd.Context(), &QueryIssueSuggestionsRequest{
+				DomainName: args[0],
+				IssueName:  args[1],
+				Color:      color,
+				MinScore:   minScore,
+				MaxScore:   maxScore,
+				SortBy:     sortBy,
+				Pagination: pageReq,
 			})
 			if err != nil {
 				return err
@@ -864,23 +1751,33 @@ func CmdQueryNullifier(cdc *codec.LegacyAmino) *cobra.Command {
 			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
 		},
 	}
+	cmd.Flags().String("color", "", "Only suggestions in this zone (green, yellow or red)")
+	cmd.Flags().String("min-score", "", "Only suggestions with at least this score")
+	cmd.Flags().String("max-score", "", "Only suggestions with at most this score")
+	cmd.Flags().String("sort-by", "", "Sort order: score (default submission order)")
 	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "issue-suggestions")
 	return cmd
 }
 
-func CmdQueryPurgeSchedule(cdc *codec.LegacyAmino) *cobra.Command {
+func CmdQueryDomainMembers(cdc *codec.LegacyAmino) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "purge-schedule [domain]",
-		Short: "Query the Big Purge schedule for a domain",
+		Use:   "domain-members [domain]",
+		Short: "List the members of a domain in join order",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
 			queryClient := NewQueryClient(clientCtx)
-			resp, err := queryClient.PurgeSchedule(cmd.Context(), &QueryPurgeScheduleRequest{
+			resp, err := queryClient.DomainMembers(cmd.Context(), &QueryDomainMembersRequest{
 				DomainName: args[0],
+				Pagination: pageReq,
 			})
 			if err != nil {
 				return err
@@ -889,22 +1786,53 @@ func CmdQueryPurgeSchedule(cdc *codec.LegacyAmino) *cobra.Command {
 		},
 	}
 	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "domain-members")
 	return cmd
 }
 
-func CmdQueryZKPState(cdc *codec.LegacyAmino) *cobra.Command {
+func CmdQueryIssueDecision(cdc *codec.LegacyAmino) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "zkp-state [domain]",
-		Short: "Query lightweight ZKP state for a domain",
+		Use:   "issue-decision [domain] [issue]",
+		Short: "Query the recorded decision of a closed issue",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.IssueDecision(cmd.Context(), &QueryIssueDecisionRequest{
+				DomainName: args[0],
+				IssueName:  args[1],
+			})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryIssueDecisions(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "issue-decisions [domain]",
+		Short: "List the recorded issue decisions of a domain",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
 			queryClient := NewQueryClient(clientCtx)
-			resp, err := queryClient.ZKPState(cmd.Context(), &QueryZKPStateRequest{
+			resp, err := queryClient.IssueDecisions(cmd.Context(), &QueryIssueDecisionsRequest{
 				DomainName: args[0],
+				Pagination: pageReq,
 			})
 			if err != nil {
 				return err
@@ -913,23 +1841,27 @@ func CmdQueryZKPState(cdc *codec.LegacyAmino) *cobra.Command {
 		},
 	}
 	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "issue-decisions")
 	return cmd
 }
 
-func CmdQueryMerkleProof(cdc *codec.LegacyAmino) *cobra.Command {
+func CmdQueryVotingWeight(cdc *codec.LegacyAmino) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "merkle-proof [domain] [commitment-hex]",
-		Short: "Query a Merkle membership proof for an identity commitment",
+		Use:   "voting-weight [domain] [member]",
+		Short: "Query a member's effective voting weight including delegated votes",
+		Long:  "Query the votes a member would cast in a domain, or on one issue with --issue, counting every member whose delegation chain reaches them.",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
+			issueName, _ := cmd.Flags().GetString("issue")
 			queryClient := NewQueryClient(clientCtx)
-			resp, err := queryClient.MerkleProof(cmd.Context(), &QueryMerkleProofRequest{
+			resp, err := queryClient.VotingWeight(cmd.Context(), &QueryVotingWeightRequest{
 				DomainName: args[0],
-				Commitment: args[1],
+				IssueName:  issueName,
+				Member:     args[1],
 			})
 			if err != nil {
 				return err
@@ -937,23 +1869,180 @@ func CmdQueryMerkleProof(cdc *codec.LegacyAmino) *cobra.Command {
 			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
 		},
 	}
+	cmd.Flags().String("issue", "", "Issue to evaluate; empty for the issue and member lists")
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
 
-func CmdQueryPayToPut(cdc *codec.LegacyAmino) *cobra.Command {
+func CmdQuerySubDomains(cdc *codec.LegacyAmino) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "pay-to-put [domain]",
-		Short: "Query the current PayToPut proposal fee for a domain (eq.3)",
+		Use:   "sub-domains [domain]",
+		Short: "List the direct sub-domains of a domain",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
 			queryClient := NewQueryClient(clientCtx)
-			resp, err := queryClient.PayToPut(cmd.Context(), &QueryPayToPutRequest{
+			resp, err := queryClient.SubDomains(cmd.Context(), &QuerySubDomainsRequest{
+				DomainName: args[0],
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "sub-domains")
+	return cmd
+}
+
+func CmdQueryTreasuryPayouts(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "treasury-payouts [domain]",
+		Short: "List the approved treasury payouts of a domain and their progress",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.TreasuryPayouts(cmd.Context(), &QueryTreasuryPayoutsRequest{
+				DomainName: args[0],
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "treasury-payouts")
+	return cmd
+}
+
+func CmdQueryDomainOptionsHistory(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "domain-options-history [domain]",
+		Short: "List the applied options changes of a domain, oldest first",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.DomainOptionsHistory(cmd.Context(), &QueryDomainOptionsHistoryRequest{
+				DomainName: args[0],
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "domain-options-history")
+	return cmd
+}
+
+func CmdQueryAnonymousSignals(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "anonymous-signals [domain] [topic]",
+		Short: "List the anonymous signals posted to a topic of a domain",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.AnonymousSignals(cmd.Context(), &QueryAnonymousSignalsRequest{
 				DomainName: args[0],
+				Topic:      args[1],
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "anonymous-signals")
+	return cmd
+}
+
+func CmdQueryStakeDelegations(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stake-delegations [validator-addr]",
+		Short: "List the delegations to a validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.StakeDelegations(cmd.Context(), &QueryStakeDelegationsRequest{
+				ValidatorAddr: args[0],
+				Pagination:    pageReq,
+			})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "stake-delegations")
+	return cmd
+}
+
+func CmdQueryPendingUndelegations(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pending-undelegations [validator-addr]",
+		Short: "List the undelegation holds of a validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.PendingUndelegations(cmd.Context(), &QueryPendingUndelegationsRequest{
+				ValidatorAddr: args[0],
+				Pagination:    pageReq,
 			})
 			if err != nil {
 				return err
@@ -962,6 +2051,82 @@ func CmdQueryPayToPut(cdc *codec.LegacyAmino) *cobra.Command {
 		},
 	}
 	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "pending-undelegations")
+	return cmd
+}
+
+func CmdQueryStakingRewards(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "staking-rewards [address] [validator-addr]",
+		Short: "Show an address's unclaimed and claimable staking rewards",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			req := &QueryStakingRewardsRequest{Address: args[0]}
+			if len(args) == 2 {
+				req.ValidatorAddr = args[1]
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.StakingRewards(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdQueryValidatorSet(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator-set",
+		Short: "Show the validator-set cap, the next re-ranking height and the ranked validators",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.ValidatorSet(cmd.Context(), &QueryValidatorSetRequest{Pagination: pageReq})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "validator-set")
+	return cmd
+}
+
+func CmdQueryEpochInfo(cdc *codec.LegacyAmino) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "epoch-info",
+		Short: "Show the validator-set epoch and the power changes applied at its end",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := NewQueryClient(clientCtx)
+			resp, err := queryClient.EpochInfo(cmd.Context(), &QueryEpochInfoRequest{})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintObjectLegacy(json.RawMessage(resp.Result))
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
 
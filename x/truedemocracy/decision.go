@@ -0,0 +1,311 @@
+package truedemocracy
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Issue decisions use KV keys:
+//   "decision:{d}{issueName}"                    → IssueDecision
+//   "decision-deadline:{closesAt}{d}{issueName}" → empty (deadline schedule)
+//   "decision-due:{d}{issueName}"                → empty (quorum reached)
+//
+// {d} is the length-prefixed domain scope of domain_store.go and closesAt is
+// an 8-byte big-endian unix time, so EndBlock walks only the deadlines that
+// have passed. Quorums are checked when participation changes and queue the
+// issue for the next EndBlock, keeping per-block work proportional to the
+// issues that actually close. A decision lives as long as its issue; deleting
+// the issue drops it with the schedule entries.
+
+const (
+	DecisionReasonDeadline = "deadline"
+	DecisionReasonQuorum   = "quorum"
+)
+
+func issueDecisionKey(domainName, issueName string) []byte {
+	return append(append([]byte("decision:"), domainScope(domainName)...), issueName...)
+}
+
+func issueDeadlineKey(closesAt int64, domainName, issueName string) []byte {
+	key := binary.BigEndian.AppendUint64([]byte("decision-deadline:"), uint64(closesAt))
+	return append(append(key, domainScope(domainName)...), issueName...)
+}
+
+func issueDueKey(domainName, issueName string) []byte {
+	return append(append([]byte("decision-due:"), domainScope(domainName)...), issueName...)
+}
+
+// splitDecisionScope reverses domainScope(d)+issueName.
+func splitDecisionScope(bz []byte) (string, string, error) {
+	n, width := binary.Uvarint(bz)
+	if width <= 0 || uint64(len(bz)-width) < n {
+		return "", "", fmt.Errorf("malformed decision key")
+	}
+	return string(bz[width : width+int(n)]), string(bz[width+int(n):]), nil
+}
+
+// GetIssueDecision returns the recorded decision of an issue.
+func (k Keeper) GetIssueDecision(ctx sdk.Context, domainName, issueName string) (IssueDecision, bool) {
+	bz := ctx.KVStore(k.StoreKey).Get(issueDecisionKey(domainName, issueName))
+	if bz == nil {
+		return IssueDecision{}, false
+	}
+	var decision IssueDecision
+	k.cdc.MustUnmarshalLengthPrefixed(bz, &decision)
+	return decision, true
+}
+
+// IsIssueDecided reports whether an issue has been finalized.
+func (k Keeper) IsIssueDecided(ctx sdk.Context, domainName, issueName string) bool {
+	return ctx.KVStore(k.StoreKey).Has(issueDecisionKey(domainName, issueName))
+}
+
+func (k Keeper) setIssueDecision(ctx sdk.Context, decision IssueDecision) {
+	ctx.KVStore(k.StoreKey).Set(
+		issueDecisionKey(decision.DomainName, decision.IssueName),
+		k.cdc.MustMarshalLengthPrefixed(&decision),
+	)
+}
+
+// IterateIssueDecisions iterates over all decisions, grouped by domain.
+func (k Keeper) IterateIssueDecisions(ctx sdk.Context, fn func(IssueDecision) bool) {
+	k.iterateIssueDecisionPrefix(ctx, []byte("decision:"), fn)
+}
+
+// IterateDomainIssueDecisions iterates over one domain's decisions in issue
+// name order.
+func (k Keeper) IterateDomainIssueDecisions(ctx sdk.Context, domainName string, fn func(IssueDecision) bool) {
+	k.iterateIssueDecisionPrefix(ctx, issueDecisionKey(domainName, ""), fn)
+}
+
+func (k Keeper) iterateIssueDecisionPrefix(ctx sdk.Context, prefix []byte, fn func(IssueDecision) bool) {
+	store := ctx.KVStore(k.StoreKey)
+	iter := store.Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var decision IssueDecision
+		k.cdc.MustUnmarshalLengthPrefixed(iter.Value(), &decision)
+		if fn(decision) {
+			break
+		}
+	}
+}
+
+// requireIssueOpen rejects writes that would change the outcome of an issue
+// that has already been decided or was handed to a sub-domain.
+func (k Keeper) requireIssueOpen(ctx sdk.Context, domainName, issueName string) error {
+	if k.IsIssueDecided(ctx, domainName, issueName) {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "issue %s has been decided", issueName)
+	}
+	if issue, found := k.GetIssue(ctx, domainName, issueName); found && issue.SubDomain != "" {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "issue %s is decided by sub-domain %s", issueName, issue.SubDomain)
+	}
+	return nil
+}
+
+// SetIssueClosingRule attaches a closing rule to an issue that has none yet.
+// The rule is immutable afterwards so participants know up front when and
+// how the issue will be decided.
+func (k Keeper) SetIssueClosingRule(ctx sdk.Context, domainName, issueName string, rule IssueClosingRule) error {
+	if rule.ClosesAt < 0 || rule.RatingQuorum < 0 || rule.StoneQuorum < 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "closing rule values cannot be negative")
+	}
+	if rule.IsZero() {
+		return nil
+	}
+	if rule.ClosesAt != 0 && rule.ClosesAt <= ctx.BlockTime().Unix() {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "closing deadline must be in the future")
+	}
+	issue, found := k.GetIssue(ctx, domainName, issueName)
+	if !found {
+		return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue not found")
+	}
+	if err := k.requireIssueOpen(ctx, domainName, issueName); err != nil {
+		return err
+	}
+	if !issue.Closing.IsZero() {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "issue already has a closing rule")
+	}
+	issue.Closing = rule
+	k.SetIssue(ctx, domainName, issue)
+	k.scheduleIssueDecision(ctx, domainName, issue)
+	return nil
+}
+
+// scheduleIssueDecision indexes the issue's deadline and queues it right away
+// if its quorum is already met.
+func (k Keeper) scheduleIssueDecision(ctx sdk.Context, domainName string, issue Issue) {
+	if issue.Closing.ClosesAt > 0 {
+		ctx.KVStore(k.StoreKey).Set(issueDeadlineKey(issue.Closing.ClosesAt, domainName, issue.Name), []byte{})
+	}
+	k.checkIssueQuorum(ctx, domainName, issue)
+}
+
+// unscheduleIssueDecision removes an issue from both closing indexes.
+func (k Keeper) unscheduleIssueDecision(ctx sdk.Context, domainName string, issue Issue) {
+	store := ctx.KVStore(k.StoreKey)
+	if issue.Closing.ClosesAt > 0 {
+		store.Delete(issueDeadlineKey(issue.Closing.ClosesAt, domainName, issue.Name))
+	}
+	store.Delete(issueDueKey(domainName, issue.Name))
+}
+
+// deleteIssueDecision drops a deleted issue's decision and schedule entries,
+// so a new issue can take its name.
+func (k Keeper) deleteIssueDecision(ctx sdk.Context, domainName string, issue Issue) {
+	k.unscheduleIssueDecision(ctx, domainName, issue)
+	ctx.KVStore(k.StoreKey).Delete(issueDecisionKey(domainName, issue.Name))
+}
+
+// issueParticipation counts the ratings and suggestion stones of an issue.
+func (k Keeper) issueParticipation(ctx sdk.Context, domainName, issueName string) (ratings, stones int64) {
+	scope, ok := k.issueScope(ctx, domainName, issueName)
+	if !ok {
+		return 0, 0
+	}
+	store := ctx.KVStore(k.StoreKey)
+	domainSuggestions.iterate(store, scope, func(seq uint64, value []byte) bool {
+		var suggestion Suggestion
+		k.cdc.MustUnmarshalLengthPrefixed(value, &suggestion)
+		stones += int64(suggestion.Stones)
+		ratings += int64(domainRatings.count(store, appendSeq(scope, seq)))
+		return false
+	})
+	return ratings, stones
+}
+
+// checkIssueQuorum queues an open issue for finalization once its rating or
+// stone quorum is met. Issues without a quorum pay no extra reads.
+func (k Keeper) checkIssueQuorum(ctx sdk.Context, domainName string, issue Issue) {
+	if (issue.Closing.RatingQuorum == 0 && issue.Closing.StoneQuorum == 0) || issue.SubDomain != "" {
+		return
+	}
+	if k.IsIssueDecided(ctx, domainName, issue.Name) {
+		return
+	}
+	ratings, stones := k.issueParticipation(ctx, domainName, issue.Name)
+	if (issue.Closing.RatingQuorum > 0 && ratings >= issue.Closing.RatingQuorum) ||
+		(issue.Closing.StoneQuorum > 0 && stones >= issue.Closing.StoneQuorum) {
+		ctx.KVStore(k.StoreKey).Set(issueDueKey(domainName, issue.Name), []byte{})
+	}
+}
+
+// ProcessIssueDecisions finalizes every issue whose deadline has passed or
+// whose quorum was reached since the last block. Called from EndBlock.
+func (k Keeper) ProcessIssueDecisions(ctx sdk.Context) error {
+	store := ctx.KVStore(k.StoreKey)
+	now := ctx.BlockTime().Unix()
+
+	type closing struct {
+		domainName, issueName, reason string
+		closesAt                      int64
+	}
+	var due []closing
+
+	deadlinePrefix := []byte("decision-deadline:")
+	end := binary.BigEndian.AppendUint64(append([]byte{}, deadlinePrefix...), uint64(now)+1)
+	iter := store.Iterator(deadlinePrefix, end)
+	for ; iter.Valid(); iter.Next() {
+		rest := iter.Key()[len(deadlinePrefix):]
+		domainName, issueName, err := splitDecisionScope(rest[8:])
+		if err != nil {
+			iter.Close()
+			return err
+		}
+		due = append(due, closing{domainName, issueName, DecisionReasonDeadline, int64(binary.BigEndian.Uint64(rest[:8]))})
+	}
+	iter.Close()
+
+	duePrefix := []byte("decision-due:")
+	iter = store.Iterator(duePrefix, prefixEnd(duePrefix))
+	for ; iter.Valid(); iter.Next() {
+		domainName, issueName, err := splitDecisionScope(iter.Key()[len(duePrefix):])
+		if err != nil {
+			iter.Close()
+			return err
+		}
+		due = append(due, closing{domainName: domainName, issueName: issueName, reason: DecisionReasonQuorum})
+	}
+	iter.Close()
+
+	for _, c := range due {
+		if c.closesAt > 0 {
+			store.Delete(issueDeadlineKey(c.closesAt, c.domainName, c.issueName))
+		} else {
+			store.Delete(issueDueKey(c.domainName, c.issueName))
+		}
+		issue, found := k.GetIssue(ctx, c.domainName, c.issueName)
+		if !found || issue.SubDomain != "" || k.IsIssueDecided(ctx, c.domainName, c.issueName) {
+			continue
+		}
+		if c.closesAt > 0 && issue.Closing.ClosesAt != c.closesAt {
+			continue // stale entry of a deleted and re-created issue
+		}
+		k.finalizeIssue(ctx, c.domainName, issue, c.reason)
+	}
+	return nil
+}
+
+// finalizeIssue records the issue's outcome and clears its schedule entries.
+func (k Keeper) finalizeIssue(ctx sdk.Context, domainName string, issue Issue, reason string) IssueDecision {
+	var suggestions []Suggestion
+	k.IterateSuggestions(ctx, domainName, issue.Name, func(s Suggestion) bool {
+		s.Ratings = k.GetSuggestionRatings(ctx, domainName, issue.Name, s.Name)
+		suggestions = append(suggestions, s)
+		return false
+	})
+	ranking := RankSuggestionsByScore(suggestions)
+	winner, score := FindConsensusWinner(suggestions)
+
+	decision := IssueDecision{
+		DomainName:      domainName,
+		IssueName:       issue.Name,
+		Winner:          winner,
+		WinnerScore:     score,
+		Ranking:         ranking,
+		Reason:          reason,
+		MemberCount:     int64(k.DomainMemberCount(ctx, domainName)),
+		DecidedAtHeight: ctx.BlockHeight(),
+		DecidedAt:       ctx.BlockTime().Unix(),
+	}
+	for _, entry := range ranking {
+		decision.RatingCount += int64(entry.Count)
+		decision.StoneCount += int64(entry.Stones)
+	}
+	k.setIssueDecision(ctx, decision)
+	k.unscheduleIssueDecision(ctx, domainName, issue)
+	for _, s := range suggestions {
+		if s.Name == winner {
+			k.approvePayout(ctx, domainName, issue.Name, s, PayoutTriggerDecision)
+		}
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"issue_decided",
+		sdk.NewAttribute("domain", domainName),
+		sdk.NewAttribute("issue", issue.Name),
+		sdk.NewAttribute("winner", winner),
+		sdk.NewAttribute("reason", reason),
+	))
+	k.adoptSubDomainDecision(ctx, decision)
+	return decision
+}
+
+// restoreIssueSchedules rebuilds the closing indexes of undecided issues
+// after genesis import.
+func (k Keeper) restoreIssueSchedules(ctx sdk.Context, domainName string) {
+	var open []Issue
+	k.IterateIssues(ctx, domainName, func(issue Issue) bool {
+		if !issue.Closing.IsZero() && issue.SubDomain == "" && !k.IsIssueDecided(ctx, domainName, issue.Name) {
+			open = append(open, issue)
+		}
+		return false
+	})
+	for _, issue := range open {
+		k.scheduleIssueDecision(ctx, domainName, issue)
+	}
+}
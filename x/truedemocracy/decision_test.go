@@ -0,0 +1,318 @@
+package truedemocracy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+var (
+	decisionAlice = sdk.AccAddress("alice").String()
+	decisionBob   = sdk.AccAddress("bob").String()
+)
+
+// setupDecisionDomain stores a domain with one open issue "Budget" carrying
+// the suggestions "A" and "B".
+func setupDecisionDomain(t *testing.T, k Keeper, ctx sdk.Context) {
+	t.Helper()
+	k.SetDomain(ctx, Domain{
+		Name:    "Vote",
+		Admin:   sdk.AccAddress("admin1"),
+		Members: []string{sdk.AccAddress("admin1").String(), decisionAlice, decisionBob},
+		Issues: []Issue{{Name: "Budget", Suggestions: []Suggestion{
+			{Name: "A", Creator: decisionAlice},
+			{Name: "B", Creator: decisionBob},
+		}}},
+	})
+}
+
+func rateDecision(t *testing.T, k Keeper, ctx sdk.Context, suggestion string, voter, value int) {
+	t.Helper()
+	if !k.recordRating(ctx, "Vote", "Budget", suggestion, Rating{DomainPubKeyHex: fmt.Sprintf("%064x", voter), Value: value}) {
+		t.Fatalf("rating %d on %s not recorded", voter, suggestion)
+	}
+}
+
+func TestIssueDecidedAtDeadline(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupDecisionDomain(t, k, ctx)
+	closesAt := ctx.BlockTime().Unix() + 3600
+	if err := k.SetIssueClosingRule(ctx, "Vote", "Budget", IssueClosingRule{ClosesAt: closesAt}); err != nil {
+		t.Fatal(err)
+	}
+	rateDecision(t, k, ctx, "A", 1, 2)
+	rateDecision(t, k, ctx, "B", 2, 5)
+	rateDecision(t, k, ctx, "B", 3, -1)
+
+	before := ctx.WithBlockTime(time.Unix(closesAt-1, 0)).WithBlockHeight(9)
+	if err := k.ProcessIssueDecisions(before); err != nil {
+		t.Fatal(err)
+	}
+	if k.IsIssueDecided(before, "Vote", "Budget") {
+		t.Fatal("issue decided before its deadline")
+	}
+
+	at := ctx.WithBlockTime(time.Unix(closesAt, 0)).WithBlockHeight(10)
+	if err := k.ProcessIssueDecisions(at); err != nil {
+		t.Fatal(err)
+	}
+	decision, found := k.GetIssueDecision(at, "Vote", "Budget")
+	if !found {
+		t.Fatal("issue not decided at its deadline")
+	}
+	if decision.Winner != "B" || decision.WinnerScore != 4 || decision.Reason != DecisionReasonDeadline {
+		t.Fatalf("decision = %+v", decision)
+	}
+	if decision.RatingCount != 3 || decision.MemberCount != 3 || decision.DecidedAtHeight != 10 || decision.DecidedAt != closesAt {
+		t.Fatalf("decision tallies = %+v", decision)
+	}
+	if len(decision.Ranking) != 2 || decision.Ranking[0].Name != "B" || decision.Ranking[1].Name != "A" {
+		t.Fatalf("ranking = %+v", decision.Ranking)
+	}
+
+	// A decided issue is frozen.
+	if err := k.SubmitProposal(at, "Vote", "Budget", "C", decisionAlice, sdk.NewCoins(), ""); !errors.Is(err, sdkerrors.ErrInvalidRequest) {
+		t.Fatalf("suggestion on decided issue: got %v", err)
+	}
+	k.JoinPermissionRegister(at, "Vote", decisionAlice, domainKey("alice-vote").PubKey().Bytes())
+	if _, _, err := k.RateProposal(at, "Vote", "Budget", "A", 3, domainKey("alice-vote")); !errors.Is(err, sdkerrors.ErrInvalidRequest) {
+		t.Fatalf("rating on decided issue: got %v", err)
+	}
+
+	// Later blocks must not re-decide the issue.
+	later := at.WithBlockTime(time.Unix(closesAt+60, 0)).WithBlockHeight(11)
+	if err := k.ProcessIssueDecisions(later); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := k.GetIssueDecision(later, "Vote", "Budget"); again.DecidedAtHeight != 10 {
+		t.Fatalf("issue re-decided at height %d", again.DecidedAtHeight)
+	}
+}
+
+func TestIssueDecidedOnRatingQuorum(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupDecisionDomain(t, k, ctx)
+	if err := k.SetIssueClosingRule(ctx, "Vote", "Budget", IssueClosingRule{RatingQuorum: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	rateDecision(t, k, ctx, "A", 1, 3)
+	if err := k.ProcessIssueDecisions(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if k.IsIssueDecided(ctx, "Vote", "Budget") {
+		t.Fatal("issue decided below its quorum")
+	}
+
+	rateDecision(t, k, ctx, "B", 2, 1)
+	if err := k.ProcessIssueDecisions(ctx); err != nil {
+		t.Fatal(err)
+	}
+	decision, found := k.GetIssueDecision(ctx, "Vote", "Budget")
+	if !found || decision.Winner != "A" || decision.Reason != DecisionReasonQuorum {
+		t.Fatalf("decision = %+v, found %v", decision, found)
+	}
+}
+
+func TestIssueDecidedOnStoneQuorum(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupDecisionDomain(t, k, ctx)
+	if err := k.SetIssueClosingRule(ctx, "Vote", "Budget", IssueClosingRule{StoneQuorum: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, member := range []string{decisionAlice, decisionBob} {
+		if _, err := k.PlaceStoneOnSuggestion(ctx, "Vote", "Budget", "B", member); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := k.ProcessIssueDecisions(ctx); err != nil {
+		t.Fatal(err)
+	}
+	decision, found := k.GetIssueDecision(ctx, "Vote", "Budget")
+	if !found || decision.StoneCount != 2 || decision.Reason != DecisionReasonQuorum {
+		t.Fatalf("decision = %+v, found %v", decision, found)
+	}
+}
+
+func TestClosedIssuesRejectOpenStones(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupDecisionDomain(t, k, ctx)
+	k.finalizeIssue(ctx, "Vote", Issue{Name: "Budget"}, DecisionReasonQuorum)
+	treasury := func() string {
+		domain, _ := k.GetDomainHeader(ctx, "Vote")
+		return domain.Treasury.String()
+	}
+	before := treasury()
+
+	if _, err := k.PlaceStoneOnIssue(ctx, "Vote", "Budget", decisionAlice); !errors.Is(err, sdkerrors.ErrInvalidRequest) {
+		t.Fatalf("issue stone on a decided issue: got %v", err)
+	}
+	if _, err := k.PlaceStoneOnSuggestion(ctx, "Vote", "Budget", "A", decisionAlice); !errors.Is(err, sdkerrors.ErrInvalidRequest) {
+		t.Fatalf("suggestion stone on a decided issue: got %v", err)
+	}
+	if issue, _ := k.GetIssue(ctx, "Vote", "Budget"); issue.Stones != 0 || treasury() != before {
+		t.Fatalf("rejected stones changed state: stones %d, treasury %s", issue.Stones, treasury())
+	}
+
+	// Issues handed to a sub-domain are closed the same way.
+	k, ctx = setupKeeper(t)
+	setupDecisionDomain(t, k, ctx)
+	issue, _ := k.GetIssue(ctx, "Vote", "Budget")
+	issue.SubDomain = "BudgetBoard"
+	k.SetIssue(ctx, "Vote", issue)
+	if _, err := k.PlaceStoneOnSuggestion(ctx, "Vote", "Budget", "A", decisionAlice); !errors.Is(err, sdkerrors.ErrInvalidRequest) {
+		t.Fatalf("suggestion stone on a delegated issue: got %v", err)
+	}
+}
+
+func TestSetIssueClosingRuleValidation(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupDecisionDomain(t, k, ctx)
+	now := ctx.BlockTime().Unix()
+
+	cases := []struct {
+		name  string
+		issue string
+		rule  IssueClosingRule
+	}{
+		{"negative quorum", "Budget", IssueClosingRule{RatingQuorum: -1}},
+		{"past deadline", "Budget", IssueClosingRule{ClosesAt: now}},
+		{"unknown issue", "Missing", IssueClosingRule{StoneQuorum: 1}},
+	}
+	for _, tc := range cases {
+		if err := k.SetIssueClosingRule(ctx, "Vote", tc.issue, tc.rule); err == nil {
+			t.Fatalf("%s: rule accepted", tc.name)
+		}
+	}
+
+	if err := k.SetIssueClosingRule(ctx, "Vote", "Budget", IssueClosingRule{}); err != nil {
+		t.Fatalf("zero rule: %v", err)
+	}
+	if err := k.SetIssueClosingRule(ctx, "Vote", "Budget", IssueClosingRule{ClosesAt: now + 10}); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.SetIssueClosingRule(ctx, "Vote", "Budget", IssueClosingRule{ClosesAt: now + 20}); !errors.Is(err, sdkerrors.ErrInvalidRequest) {
+		t.Fatalf("second rule: got %v", err)
+	}
+
+	// The msg server only accepts a rule from the proposal that opens the issue.
+	_, err := NewMsgServer(k).SubmitProposal(ctx, &MsgSubmitProposal{
+		Sender: sdk.AccAddress("alice"), DomainName: "Vote", IssueName: "Budget",
+		SuggestionName: "C", Creator: decisionAlice, RatingQuorum: 5,
+	})
+	if !errors.Is(err, sdkerrors.ErrInvalidRequest) {
+		t.Fatalf("rule on existing issue: got %v", err)
+	}
+}
+
+func TestDeletedIssueIsNotDecided(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupDecisionDomain(t, k, ctx)
+	closesAt := ctx.BlockTime().Unix() + 60
+	if err := k.SetIssueClosingRule(ctx, "Vote", "Budget", IssueClosingRule{ClosesAt: closesAt}); err != nil {
+		t.Fatal(err)
+	}
+	domain, _ := k.GetDomain(ctx, "Vote")
+	domain.Issues = nil
+	k.SetDomain(ctx, domain)
+
+	at := ctx.WithBlockTime(time.Unix(closesAt, 0))
+	if err := k.ProcessIssueDecisions(at); err != nil {
+		t.Fatal(err)
+	}
+	if k.IsIssueDecided(at, "Vote", "Budget") {
+		t.Fatal("deleted issue was decided")
+	}
+}
+
+func TestDeletedDecidedIssueNameIsReusable(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupDecisionDomain(t, k, ctx)
+	issue, _ := k.GetIssue(ctx, "Vote", "Budget")
+	k.finalizeIssue(ctx, "Vote", issue, DecisionReasonQuorum)
+	k.deleteIssue(ctx, "Vote", "Budget")
+
+	if _, found := k.GetIssueDecision(ctx, "Vote", "Budget"); found {
+		t.Fatal("decision of a deleted issue survived")
+	}
+	addProposal(t, k, ctx, "Vote", "Budget", "C")
+	if _, err := k.PlaceStoneOnSuggestion(ctx, "Vote", "Budget", "C", decisionAlice); err != nil {
+		t.Fatalf("re-created issue is closed: %v", err)
+	}
+}
+
+func TestQueryIssueDecisions(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupDecisionDomain(t, k, ctx)
+	k.SetIssue(ctx, "Vote", Issue{Name: "Aardvark"})
+	for _, issue := range []string{"Aardvark", "Budget"} {
+		k.finalizeIssue(ctx, "Vote", Issue{Name: issue}, DecisionReasonDeadline)
+	}
+
+	resp, err := k.IssueDecision(ctx, &QueryIssueDecisionRequest{DomainName: "Vote", IssueName: "Budget"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := decodeResult[IssueDecision](t, resp.Result); got.IssueName != "Budget" {
+		t.Fatalf("decision = %+v", got)
+	}
+	if _, err := k.IssueDecision(ctx, &QueryIssueDecisionRequest{DomainName: "Vote", IssueName: "Open"}); !errors.Is(err, sdkerrors.ErrKeyNotFound) {
+		t.Fatalf("undecided issue: got %v", err)
+	}
+
+	list, err := k.IssueDecisions(ctx, &QueryIssueDecisionsRequest{
+		DomainName: "Vote",
+		Pagination: &query.PageRequest{Limit: 1, CountTotal: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	decisions := decodeResult[[]IssueDecision](t, list.Result)
+	if len(decisions) != 1 || decisions[0].IssueName != "Aardvark" || list.Pagination.Total != 2 {
+		t.Fatalf("page = %+v, pagination %+v", decisions, list.Pagination)
+	}
+}
+
+func TestIssueDecisionsSurviveGenesisRoundTrip(t *testing.T) {
+	am, k, ctx := setupModuleForGenesis(t)
+	setupDecisionDomain(t, k, ctx)
+	k.SetIssue(ctx, "Vote", Issue{Name: "Later"})
+	closesAt := ctx.BlockTime().Unix() + 3600
+	if err := k.SetIssueClosingRule(ctx, "Vote", "Later", IssueClosingRule{ClosesAt: closesAt}); err != nil {
+		t.Fatal(err)
+	}
+	k.finalizeIssue(ctx, "Vote", Issue{Name: "Budget"}, DecisionReasonQuorum)
+
+	exported := am.ExportGenesis(ctx, nil)
+	var genesis GenesisState
+	if err := json.Unmarshal(exported, &genesis); err != nil {
+		t.Fatal(err)
+	}
+	if len(genesis.IssueDecisions) != 1 || genesis.IssueDecisions[0].IssueName != "Budget" {
+		t.Fatalf("exported decisions = %+v", genesis.IssueDecisions)
+	}
+
+	am2, k2, ctx2 := setupModuleForGenesis(t)
+	am2.InitGenesis(ctx2, nil, exported)
+	want, _ := k.GetIssueDecision(ctx, "Vote", "Budget")
+	if got, _ := k2.GetIssueDecision(ctx2, "Vote", "Budget"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("imported decision = %+v, want %+v", got, want)
+	}
+
+	// The deadline of the open issue is rescheduled on import.
+	at := ctx2.WithBlockTime(time.Unix(closesAt, 0))
+	if err := k2.ProcessIssueDecisions(at); err != nil {
+		t.Fatal(err)
+	}
+	if !k2.IsIssueDecided(at, "Vote", "Later") {
+		t.Fatal("imported deadline was not rescheduled")
+	}
+}
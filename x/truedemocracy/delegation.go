@@ -0,0 +1,609 @@
+package truedemocracy
+
+import (
+	"sort"
+
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Liquid delegation lets a member hand their vote to another member of the
+// domain, either for one issue or domain-wide:
+//   "delegation:{d}{i}{delegator}"           → VoteDelegation
+//   "delegators:{d}{i}{delegate}{delegator}" → []byte{1}, delegations by delegate
+//   "votermode:{d}{member}"                  → VoterModeOpen or VoterModeAnonymous
+//
+// {i} is empty for a domain-wide delegation. On an issue's suggestion list,
+// ratings and election an issue delegation overrides the domain-wide one;
+// the issue list and the member list follow domain-wide delegations only.
+//
+// A member who has not voted lends their weight to the first member along
+// their chain who has. The weight is dropped when the chain loops, runs
+// longer than MaxDelegationDepth hops, or reaches a member who holds an
+// anonymous credential and has not voted openly. Delegated weight counts in
+// election tallies, in the stone counters (tracked as DelegatedStones) and in
+// the Weight of open ratings; anonymous votes cannot carry it. The counters
+// and weights are kept up to date incrementally: when a member votes,
+// delegates or changes voter mode, only the members whose chains pass through
+// them, found through the delegate index, move their weight.
+//
+// Ratings are otherwise anonymous, so delegation needs a voter mode to stay
+// one-person-one-vote: registering an identity commitment makes a member an
+// anonymous voter whose own weight is never delegated, and an
+// open rating makes them an open voter who cannot register a commitment. The
+// Big Purge resets both modes.
+
+// MaxDelegationDepth is the longest delegation chain, in hops, that carries
+// weight.
+const MaxDelegationDepth = 5
+
+// Voter modes, fixed by a member's first open rating or identity commitment
+// until the next Big Purge. Domain keys leave the mode unset.
+const (
+	VoterModeOpen      = "open"
+	VoterModeAnonymous = "anonymous"
+)
+
+// VotingWeight is a member's effective voting weight on an issue, or on the
+// domain's issue and member lists when IssueName is empty.
+type VotingWeight struct {
+	DomainName string   `json:"domain_name"`
+	IssueName  string   `json:"issue_name,omitempty"`
+	Member     string   `json:"member"`
+	Delegate   string   `json:"delegate,omitempty"` // where the member's vote goes when they do not vote
+	Delegators []string `json:"delegators"`         // members whose votes the member carries
+	Weight     int      `json:"weight"`             // one plus len(Delegators)
+}
+
+func delegationPrefix(domainName string) []byte {
+	return append([]byte("delegation:"), domainScope(domainName)...)
+}
+
+func delegationKey(domainName, issueName, delegator string) []byte {
+	return append(append(delegationPrefix(domainName), domainScope(issueName)...), delegator...)
+}
+
+func delegatorIndexPrefix(domainName, issueName, delegate string) []byte {
+	return append(append(append([]byte("delegators:"), domainScope(domainName)...), domainScope(issueName)...), domainScope(delegate)...)
+}
+
+func delegatorIndexKey(domainName, issueName, delegate, delegator string) []byte {
+	return append(delegatorIndexPrefix(domainName, issueName, delegate), delegator...)
+}
+
+func voterModePrefix(domainName string) []byte {
+	return append([]byte("votermode:"), domainScope(domainName)...)
+}
+
+func voterModeKey(domainName, member string) []byte {
+	return append(voterModePrefix(domainName), member...)
+}
+
+// DelegateVote sets or replaces the delegator's delegation for an issue, or
+// domain-wide when issueName is empty. An empty delegate revokes it. Chains
+// that would loop back to the delegator or exceed MaxDelegationDepth are
+// rejected.
+func (k Keeper) DelegateVote(ctx sdk.Context, domainName, issueName, delegator, delegate string) error {
+	if _, found := k.GetDomainHeader(ctx, domainName); !found {
+		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
+	}
+	if !k.IsDomainMember(ctx, domainName, delegator) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only domain members can delegate their vote")
+	}
+	if issueName != "" {
+		if _, found := k.GetIssue(ctx, domainName, issueName); !found {
+			return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue not found")
+		}
+		if err := k.requireIssueOpen(ctx, domainName, issueName); err != nil {
+			return err
+		}
+	}
+
+	existing, found := k.GetVoteDelegation(ctx, domainName, issueName, delegator)
+	change := func() { k.deleteVoteDelegation(ctx, existing) }
+	if delegate == "" {
+		if !found {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "no delegation to revoke")
+		}
+	} else {
+		if delegate == delegator {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "cannot delegate to yourself")
+		}
+		if !k.IsDomainMember(ctx, domainName, delegate) {
+			return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "delegate is not a domain member")
+		}
+		if k.GetVoterMode(ctx, domainName, delegator) == VoterModeAnonymous {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "members with an anonymous voting credential cannot delegate")
+		}
+		if err := k.checkDelegationChain(ctx, domainName, issueName, delegator, delegate); err != nil {
+			return err
+		}
+		change = func() {
+			k.setVoteDelegation(ctx, VoteDelegation{DomainName: domainName, IssueName: issueName, Delegator: delegator, Delegate: delegate})
+		}
+	}
+
+	tallies := k.issueTallies(ctx, domainName, issueName)
+	if issueName == "" {
+		tallies = k.domainTallies(ctx, domainName)
+	}
+	k.trackDelegatedWeight(ctx, domainName, []string{delegator}, tallies, change)
+	return nil
+}
+
+// checkDelegationChain walks the chain that starts at the new delegate and
+// rejects it if it returns to the delegator or grows past MaxDelegationDepth.
+func (k Keeper) checkDelegationChain(ctx sdk.Context, domainName, issueName, delegator, delegate string) error {
+	current := delegate
+	for hops := 1; ; hops++ {
+		if hops > MaxDelegationDepth {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "delegation chain exceeds %d hops", MaxDelegationDepth)
+		}
+		next, found := k.effectiveDelegate(ctx, domainName, issueName, current)
+		if !found {
+			return nil
+		}
+		if next == delegator {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "delegation would create a cycle")
+		}
+		current = next
+	}
+}
+
+// GetVoteDelegation returns the delegation stored for exactly this scope;
+// issueName is empty for the domain-wide delegation.
+func (k Keeper) GetVoteDelegation(ctx sdk.Context, domainName, issueName, delegator string) (VoteDelegation, bool) {
+	bz := ctx.KVStore(k.StoreKey).Get(delegationKey(domainName, issueName, delegator))
+	if bz == nil {
+		return VoteDelegation{}, false
+	}
+	var delegation VoteDelegation
+	k.cdc.MustUnmarshal(bz, &delegation)
+	return delegation, true
+}
+
+// setVoteDelegation stores the delegation, replacing the delegator's previous
+// one for the same scope, and indexes it by delegate.
+func (k Keeper) setVoteDelegation(ctx sdk.Context, delegation VoteDelegation) {
+	if old, found := k.GetVoteDelegation(ctx, delegation.DomainName, delegation.IssueName, delegation.Delegator); found {
+		k.deleteVoteDelegation(ctx, old)
+	}
+	store := ctx.KVStore(k.StoreKey)
+	store.Set(delegationKey(delegation.DomainName, delegation.IssueName, delegation.Delegator), k.cdc.MustMarshal(&delegation))
+	store.Set(delegatorIndexKey(delegation.DomainName, delegation.IssueName, delegation.Delegate, delegation.Delegator), []byte{1})
+}
+
+func (k Keeper) deleteVoteDelegation(ctx sdk.Context, delegation VoteDelegation) {
+	store := ctx.KVStore(k.StoreKey)
+	store.Delete(delegationKey(delegation.DomainName, delegation.IssueName, delegation.Delegator))
+	store.Delete(delegatorIndexKey(delegation.DomainName, delegation.IssueName, delegation.Delegate, delegation.Delegator))
+}
+
+// directDelegators returns the members whose delegation for exactly this
+// scope names the delegate, in member key order.
+func (k Keeper) directDelegators(ctx sdk.Context, domainName, issueName, delegate string) []string {
+	prefix := delegatorIndexPrefix(domainName, issueName, delegate)
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	var delegators []string
+	for ; iter.Valid(); iter.Next() {
+		delegators = append(delegators, string(iter.Key()[len(prefix):]))
+	}
+	return delegators
+}
+
+// delegatorsOf returns the members whose effective delegate on the issue is
+// the member: issue delegations to them, and domain-wide ones from members
+// without an issue delegation of their own.
+func (k Keeper) delegatorsOf(ctx sdk.Context, domainName, issueName, member string) []string {
+	delegators := k.directDelegators(ctx, domainName, "", member)
+	if issueName == "" {
+		return delegators
+	}
+	out := k.directDelegators(ctx, domainName, issueName, member)
+	for _, delegator := range delegators {
+		if _, found := k.GetVoteDelegation(ctx, domainName, issueName, delegator); !found {
+			out = append(out, delegator)
+		}
+	}
+	return out
+}
+
+// delegationUpstream returns the members followed by everyone whose chain on
+// the issue reaches one of them within MaxDelegationDepth hops.
+func (k Keeper) delegationUpstream(ctx sdk.Context, domainName, issueName string, members []string) []string {
+	seen := make(map[string]bool)
+	var upstream []string
+	level := members
+	for hops := 0; len(level) > 0; hops++ {
+		var next []string
+		for _, member := range level {
+			if seen[member] {
+				continue
+			}
+			seen[member] = true
+			upstream = append(upstream, member)
+			if hops < MaxDelegationDepth {
+				next = append(next, k.delegatorsOf(ctx, domainName, issueName, member)...)
+			}
+		}
+		level = next
+	}
+	return upstream
+}
+
+// IterateVoteDelegations iterates over a domain's delegations in key order.
+func (k Keeper) IterateVoteDelegations(ctx sdk.Context, domainName string, fn func(VoteDelegation) bool) {
+	prefix := delegationPrefix(domainName)
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var delegation VoteDelegation
+		k.cdc.MustUnmarshal(iter.Value(), &delegation)
+		if fn(delegation) {
+			return
+		}
+	}
+}
+
+func (k Keeper) hasVoteDelegations(ctx sdk.Context, domainName string) bool {
+	prefix := delegationPrefix(domainName)
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	return iter.Valid()
+}
+
+// effectiveDelegate returns the member's delegate for the issue, falling back
+// to the domain-wide delegation.
+func (k Keeper) effectiveDelegate(ctx sdk.Context, domainName, issueName, member string) (string, bool) {
+	if issueName != "" {
+		if delegation, found := k.GetVoteDelegation(ctx, domainName, issueName, member); found {
+			return delegation.Delegate, true
+		}
+	}
+	delegation, found := k.GetVoteDelegation(ctx, domainName, "", member)
+	return delegation.Delegate, found
+}
+
+// resolveDelegate follows the member's chain to the first delegate for whom
+// voted reports true.
+func (k Keeper) resolveDelegate(ctx sdk.Context, domainName, issueName, member string, voted func(string) bool) (string, bool) {
+	seen := map[string]bool{member: true}
+	current := member
+	for hops := 0; hops < MaxDelegationDepth; hops++ {
+		next, found := k.effectiveDelegate(ctx, domainName, issueName, current)
+		if !found || seen[next] || !k.IsDomainMember(ctx, domainName, next) {
+			return "", false
+		}
+		if voted(next) {
+			return next, true
+		}
+		if k.GetVoterMode(ctx, domainName, next) == VoterModeAnonymous {
+			return "", false
+		}
+		seen[next] = true
+		current = next
+	}
+	return "", false
+}
+
+// forEachDelegatedVote calls fn, in member order, for every member who has
+// not voted but whose delegation chain reaches a member who has.
+func (k Keeper) forEachDelegatedVote(ctx sdk.Context, domainName, issueName string, voted func(string) bool, fn func(delegator, holder string)) {
+	if !k.hasVoteDelegations(ctx, domainName) {
+		return
+	}
+	for _, member := range k.GetDomainMembers(ctx, domainName) {
+		if voted(member) || k.GetVoterMode(ctx, domainName, member) == VoterModeAnonymous {
+			continue
+		}
+		if holder, found := k.resolveDelegate(ctx, domainName, issueName, member, voted); found {
+			fn(member, holder)
+		}
+	}
+}
+
+// GetVotingWeight reports the weight the member would cast on the issue, or
+// on the domain's issue and member lists when issueName is empty, if they
+// voted and every member who delegates to them did not.
+func (k Keeper) GetVotingWeight(ctx sdk.Context, domainName, issueName, member string) (VotingWeight, error) {
+	if _, found := k.GetDomainHeader(ctx, domainName); !found {
+		return VotingWeight{}, errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
+	}
+	if !k.IsDomainMember(ctx, domainName, member) {
+		return VotingWeight{}, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "not a domain member")
+	}
+	if issueName != "" {
+		if _, found := k.GetIssue(ctx, domainName, issueName); !found {
+			return VotingWeight{}, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue not found")
+		}
+	}
+	weight := VotingWeight{DomainName: domainName, IssueName: issueName, Member: member, Delegators: []string{}}
+	weight.Delegate, _ = k.effectiveDelegate(ctx, domainName, issueName, member)
+	k.forEachDelegatedVote(ctx, domainName, issueName, func(m string) bool { return m == member }, func(delegator, _ string) {
+		weight.Delegators = append(weight.Delegators, delegator)
+	})
+	weight.Weight = 1 + len(weight.Delegators)
+	return weight, nil
+}
+
+// ---------- Voter modes ----------
+
+// GetVoterMode returns VoterModeOpen, VoterModeAnonymous, or "" for a member
+// who has done neither since the last Big Purge.
+func (k Keeper) GetVoterMode(ctx sdk.Context, domainName, member string) string {
+	return string(ctx.KVStore(k.StoreKey).Get(voterModeKey(domainName, member)))
+}
+
+func (k Keeper) setVoterMode(ctx sdk.Context, domainName, member, mode string) {
+	ctx.KVStore(k.StoreKey).Set(voterModeKey(domainName, member), []byte(mode))
+}
+
+// markAnonymousVoter records that the member holds an anonymous credential.
+// Open voters are refused one, since they could then vote twice.
+func (k Keeper) markAnonymousVoter(ctx sdk.Context, domainName, member string) error {
+	switch k.GetVoterMode(ctx, domainName, member) {
+	case VoterModeAnonymous:
+		return nil
+	case VoterModeOpen:
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "members who rated openly cannot register an anonymous credential before the next Big Purge")
+	}
+	k.setAnonymousVoter(ctx, domainName, member)
+	return nil
+}
+
+// setAnonymousVoter sets the member's voter mode to anonymous, which stops
+// the delegation chains through them.
+func (k Keeper) setAnonymousVoter(ctx sdk.Context, domainName, member string) {
+	change := func() { k.setVoterMode(ctx, domainName, member, VoterModeAnonymous) }
+	if !k.hasVoteDelegations(ctx, domainName) {
+		change()
+		return
+	}
+	k.trackDelegatedWeight(ctx, domainName, []string{member}, k.domainTallies(ctx, domainName), change)
+}
+
+// clearVoterModes resets every member of the domain to no mode, so chains
+// through former credential holders carry weight again.
+func (k Keeper) clearVoterModes(ctx sdk.Context, domainName string) {
+	store := ctx.KVStore(k.StoreKey)
+	prefix := voterModePrefix(domainName)
+	iter := store.Iterator(prefix, prefixEnd(prefix))
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, append([]byte{}, iter.Key()...))
+	}
+	iter.Close()
+	change := func() {
+		for _, key := range keys {
+			store.Delete(key)
+		}
+	}
+	anonymous := k.anonymousVoters(ctx, domainName)
+	if len(anonymous) == 0 || !k.hasVoteDelegations(ctx, domainName) {
+		change()
+		return
+	}
+	k.trackDelegatedWeight(ctx, domainName, anonymous, k.domainTallies(ctx, domainName), change)
+}
+
+// anonymousVoters returns the members of the domain that hold an anonymous
+// credential.
+func (k Keeper) anonymousVoters(ctx sdk.Context, domainName string) []string {
+	prefix := voterModePrefix(domainName)
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	var members []string
+	for ; iter.Valid(); iter.Next() {
+		if string(iter.Value()) == VoterModeAnonymous {
+			members = append(members, string(iter.Key()[len(prefix):]))
+		}
+	}
+	return members
+}
+
+// removeMemberDelegations drops the delegations a leaving member gave or
+// received, together with their voter mode.
+func (k Keeper) removeMemberDelegations(ctx sdk.Context, domainName, member string) {
+	var stale []VoteDelegation
+	k.IterateVoteDelegations(ctx, domainName, func(delegation VoteDelegation) bool {
+		if delegation.Delegator == member || delegation.Delegate == member {
+			stale = append(stale, delegation)
+		}
+		return false
+	})
+	for _, delegation := range stale {
+		k.deleteVoteDelegation(ctx, delegation)
+	}
+	ctx.KVStore(k.StoreKey).Delete(voterModeKey(domainName, member))
+}
+
+// ---------- Delegated weight ----------
+
+// delegatedTally is one list that counts delegated weight: the issue list,
+// an issue's suggestion list, or the open ratings of one suggestion.
+type delegatedTally struct {
+	issueName string                             // scope of the delegations that feed it
+	vote      func(member string) (string, bool) // the entry a member voted for, if any
+	apply     func(deltas map[string]int)        // moves delegated weight between entries
+}
+
+// trackDelegatedWeight runs change, which may alter the votes, delegations or
+// voter modes of members, and moves the weight of every member whose chain
+// passes through one of them on each tally. change must not add delegations
+// to the members.
+func (k Keeper) trackDelegatedWeight(ctx sdk.Context, domainName string, members []string, tallies []delegatedTally, change func()) {
+	upstream := make(map[string][]string)
+	before := make([]map[string]int, len(tallies))
+	for i, tally := range tallies {
+		if _, done := upstream[tally.issueName]; !done {
+			upstream[tally.issueName] = k.delegationUpstream(ctx, domainName, tally.issueName, members)
+		}
+		before[i] = k.delegatedTargets(ctx, domainName, tally, upstream[tally.issueName])
+	}
+	change()
+	for i, tally := range tallies {
+		deltas := k.delegatedTargets(ctx, domainName, tally, upstream[tally.issueName])
+		for target, count := range before[i] {
+			deltas[target] -= count
+			if deltas[target] == 0 {
+				delete(deltas, target)
+			}
+		}
+		if len(deltas) > 0 {
+			tally.apply(deltas)
+		}
+	}
+}
+
+// delegatedTargets counts, per entry of the tally, the delegated votes of
+// the given members that reach it.
+func (k Keeper) delegatedTargets(ctx sdk.Context, domainName string, tally delegatedTally, members []string) map[string]int {
+	voted := func(m string) bool {
+		_, found := tally.vote(m)
+		return found
+	}
+	counts := make(map[string]int)
+	for _, member := range members {
+		if voted(member) || !k.IsDomainMember(ctx, domainName, member) || k.GetVoterMode(ctx, domainName, member) == VoterModeAnonymous {
+			continue
+		}
+		if holder, found := k.resolveDelegate(ctx, domainName, tally.issueName, member, voted); found {
+			target, _ := tally.vote(holder)
+			counts[target]++
+		}
+	}
+	return counts
+}
+
+// domainTallies returns every tally a domain-wide delegation feeds.
+func (k Keeper) domainTallies(ctx sdk.Context, domainName string) []delegatedTally {
+	tallies := []delegatedTally{k.issueListTally(ctx, domainName)}
+	var issueNames []string
+	k.IterateIssues(ctx, domainName, func(issue Issue) bool {
+		issueNames = append(issueNames, issue.Name)
+		return false
+	})
+	for _, issueName := range issueNames {
+		tallies = append(tallies, k.issueTallies(ctx, domainName, issueName)...)
+	}
+	return tallies
+}
+
+// issueTallies returns the suggestion list and open ratings of an undecided
+// issue; decided issues keep the weight they were decided with.
+func (k Keeper) issueTallies(ctx sdk.Context, domainName, issueName string) []delegatedTally {
+	if k.IsIssueDecided(ctx, domainName, issueName) {
+		return nil
+	}
+	tallies := []delegatedTally{k.suggestionListTally(ctx, domainName, issueName)}
+	k.IterateSuggestions(ctx, domainName, issueName, func(s Suggestion) bool {
+		tallies = append(tallies, k.ratingTally(ctx, domainName, issueName, s.Name))
+		return false
+	})
+	return tallies
+}
+
+// issueListTally folds delegated stones on the issue list into the issues'
+// stone counters.
+func (k Keeper) issueListTally(ctx sdk.Context, domainName string) delegatedTally {
+	return delegatedTally{
+		vote: func(m string) (string, bool) { return k.GetMemberIssueStone(ctx, domainName, m) },
+		apply: func(deltas map[string]int) {
+			for _, name := range sortedDeltaKeys(deltas) {
+				if issue, found := k.GetIssue(ctx, domainName, name); found {
+					issue.Stones += deltas[name]
+					issue.DelegatedStones += deltas[name]
+					k.SetIssue(ctx, domainName, issue)
+				}
+			}
+		},
+	}
+}
+
+// suggestionListTally folds delegated stones on an issue's suggestion list
+// into the suggestions' stone counters and re-checks the issue's stone
+// quorum.
+func (k Keeper) suggestionListTally(ctx sdk.Context, domainName, issueName string) delegatedTally {
+	return delegatedTally{
+		issueName: issueName,
+		vote: func(m string) (string, bool) {
+			return k.GetMemberSuggestionStone(ctx, domainName, issueName, m)
+		},
+		apply: func(deltas map[string]int) {
+			for _, name := range sortedDeltaKeys(deltas) {
+				if s, found := k.GetSuggestion(ctx, domainName, issueName, name); found {
+					s.Stones += deltas[name]
+					s.DelegatedStones += deltas[name]
+					k.SetSuggestion(ctx, domainName, issueName, s)
+				}
+			}
+			if issue, found := k.GetIssue(ctx, domainName, issueName); found {
+				k.checkIssueQuorum(ctx, domainName, issue)
+			}
+		},
+	}
+}
+
+// ratingTally adds delegated weight to the Weight of a suggestion's open
+// ratings: one plus the number of members whose delegation they carry.
+func (k Keeper) ratingTally(ctx sdk.Context, domainName, issueName, suggestionName string) delegatedTally {
+	return delegatedTally{
+		issueName: issueName,
+		vote: func(m string) (string, bool) {
+			return m, k.hasMemberRated(ctx, domainName, issueName, suggestionName, m)
+		},
+		apply: func(deltas map[string]int) {
+			scope, ok := k.suggestionScope(ctx, domainName, issueName, suggestionName)
+			if !ok {
+				return
+			}
+			store := ctx.KVStore(k.StoreKey)
+			for _, member := range sortedDeltaKeys(deltas) {
+				_, bz, found := domainRatings.get(store, scope, ratingVoterID(Rating{MemberAddr: member}))
+				if !found {
+					continue
+				}
+				var rating Rating
+				k.cdc.MustUnmarshalLengthPrefixed(bz, &rating)
+				rating.Weight += deltas[member]
+				k.appendRating(ctx, domainName, issueName, suggestionName, rating) // replaces the record in place
+			}
+		},
+	}
+}
+
+func sortedDeltaKeys(deltas map[string]int) []string {
+	keys := make([]string, 0, len(deltas))
+	for key := range deltas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ---------- Genesis ----------
+
+// exportVoteDelegations returns every delegation and voter mode on chain.
+func (k Keeper) exportVoteDelegations(ctx sdk.Context) ([]VoteDelegation, []VoterModeRecord) {
+	var domainNames []string
+	k.IterateDomainHeaders(ctx, func(domain Domain) bool {
+		domainNames = append(domainNames, domain.Name)
+		return false
+	})
+	var delegations []VoteDelegation
+	var modes []VoterModeRecord
+	for _, domainName := range domainNames {
+		k.IterateVoteDelegations(ctx, domainName, func(delegation VoteDelegation) bool {
+			delegations = append(delegations, delegation)
+			return false
+		})
+		for _, member := range k.GetDomainMembers(ctx, domainName) {
+			if mode := k.GetVoterMode(ctx, domainName, member); mode != "" {
+				modes = append(modes, VoterModeRecord{DomainName: domainName, Member: member, Mode: mode})
+			}
+		}
+	}
+	return delegations, modes
+}
@@ -0,0 +1,507 @@
+package truedemocracy
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+)
+
+func mustDelegate(t *testing.T, k Keeper, ctx sdk.Context, domainName, issueName, delegator, delegate string) {
+	t.Helper()
+	if err := k.DelegateVote(ctx, domainName, issueName, delegator, delegate); err != nil {
+		t.Fatalf("DelegateVote(%s -> %s): %v", delegator, delegate, err)
+	}
+}
+
+func TestDelegatedVotesCountInElections(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupElectionDomain(t, k, ctx, VotingModeSimpleMajority, true)
+
+	mustDelegate(t, k, ctx, "ElecDomain", "", "alice", "bob")
+	mustDelegate(t, k, ctx, "ElecDomain", "", "charlie", "bob")
+	mustDelegate(t, k, ctx, "ElecDomain", "", "dave", "bob")
+	// An issue delegation overrides the domain-wide one.
+	mustDelegate(t, k, ctx, "ElecDomain", "BoardChair", "dave", "eve")
+
+	if err := k.CastElectionVote(ctx, "ElecDomain", "BoardChair", "Alice", "bob", VoteChoiceApprove); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.CastElectionVote(ctx, "ElecDomain", "BoardChair", "Charlie", "eve", VoteChoiceApprove); err != nil {
+		t.Fatal(err)
+	}
+	result, err := k.TallyElection(ctx, "ElecDomain", "BoardChair")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Candidate != "Alice" || result.Votes != 3 || result.Total != 5 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	// A direct vote overrides the member's delegation.
+	if err := k.CastElectionVote(ctx, "ElecDomain", "BoardChair", "Charlie", "alice", VoteChoiceApprove); err != nil {
+		t.Fatal(err)
+	}
+	result, _ = k.TallyElection(ctx, "ElecDomain", "BoardChair")
+	if result.Candidate != "Charlie" || result.Votes != 3 || result.Total != 5 {
+		t.Fatalf("result after direct vote = %+v", result)
+	}
+
+	// Revoking returns the vote to its owner.
+	mustDelegate(t, k, ctx, "ElecDomain", "", "charlie", "")
+	result, _ = k.TallyElection(ctx, "ElecDomain", "BoardChair")
+	if result.Total != 4 {
+		t.Fatalf("total after revoke = %d, want 4", result.Total)
+	}
+	if err := k.DelegateVote(ctx, "ElecDomain", "", "charlie", ""); err == nil {
+		t.Fatal("revoked a missing delegation")
+	}
+}
+
+func TestDelegateVoteRejectsInvalidChains(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupCouncilDomain(t, k, ctx, VotingModeSimpleMajority, 8, 1)
+
+	if err := k.DelegateVote(ctx, "CouncilDomain", "", "v0", "v0"); err == nil {
+		t.Fatal("self-delegation accepted")
+	}
+	if err := k.DelegateVote(ctx, "CouncilDomain", "", "v0", "outsider"); err == nil {
+		t.Fatal("delegation to a non-member accepted")
+	}
+	if err := k.DelegateVote(ctx, "CouncilDomain", "", "outsider", "v0"); err == nil {
+		t.Fatal("delegation by a non-member accepted")
+	}
+	if err := k.DelegateVote(ctx, "CouncilDomain", "Missing", "v0", "v1"); err == nil {
+		t.Fatal("delegation on a missing issue accepted")
+	}
+
+	for _, pair := range [][2]string{{"v0", "v1"}, {"v1", "v2"}, {"v2", "v3"}, {"v3", "v4"}, {"v4", "v5"}} {
+		mustDelegate(t, k, ctx, "CouncilDomain", "", pair[0], pair[1])
+	}
+	if err := k.DelegateVote(ctx, "CouncilDomain", "", "v5", "v2"); err == nil {
+		t.Fatal("cycle accepted")
+	}
+	if err := k.DelegateVote(ctx, "CouncilDomain", "", "v6", "v0"); err == nil {
+		t.Fatalf("chain longer than %d hops accepted", MaxDelegationDepth)
+	}
+	// An issue delegation cannot close a loop through domain-wide ones.
+	if err := k.DelegateVote(ctx, "CouncilDomain", "Council", "v5", "v0"); err == nil {
+		t.Fatal("cycle through an issue delegation accepted")
+	}
+
+	// The longest allowed chain still carries the weight to its end.
+	weight, err := k.GetVotingWeight(ctx, "CouncilDomain", "Council", "v5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weight.Weight != 6 || !reflect.DeepEqual(weight.Delegators, []string{"v0", "v1", "v2", "v3", "v4"}) {
+		t.Fatalf("weight = %+v", weight)
+	}
+	if weight, _ := k.GetVotingWeight(ctx, "CouncilDomain", "", "v0"); weight.Delegate != "v1" || weight.Weight != 1 {
+		t.Fatalf("delegator weight = %+v", weight)
+	}
+}
+
+func TestDelegatedStones(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupElectionDomain(t, k, ctx, VotingModeSimpleMajority, true)
+	addProposal(t, k, ctx, "ElecDomain", "Roads", "Repair")
+	addProposal(t, k, ctx, "ElecDomain", "Roads", "Widen")
+	issueStones := func(name string) (int, int) {
+		issue, _ := k.GetIssue(ctx, "ElecDomain", name)
+		return issue.Stones, issue.DelegatedStones
+	}
+	suggestionStones := func(name string) (int, int) {
+		s, _ := k.GetSuggestion(ctx, "ElecDomain", "Roads", name)
+		return s.Stones, s.DelegatedStones
+	}
+
+	mustDelegate(t, k, ctx, "ElecDomain", "", "alice", "bob")
+	mustDelegate(t, k, ctx, "ElecDomain", "Roads", "charlie", "dave")
+	if _, err := k.PlaceStoneOnIssue(ctx, "ElecDomain", "Roads", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if stones, delegated := issueStones("Roads"); stones != 2 || delegated != 1 {
+		t.Fatalf("issue stones = %d (%d delegated), want 2 (1)", stones, delegated)
+	}
+	// The issue list follows domain-wide delegations only.
+	if _, err := k.PlaceStoneOnIssue(ctx, "ElecDomain", "Roads", "dave"); err != nil {
+		t.Fatal(err)
+	}
+	if stones, _ := issueStones("Roads"); stones != 3 {
+		t.Fatalf("issue stones = %d, want 3", stones)
+	}
+
+	if _, err := k.PlaceStoneOnSuggestion(ctx, "ElecDomain", "Roads", "Repair", "dave"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.PlaceStoneOnSuggestion(ctx, "ElecDomain", "Roads", "Widen", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if stones, delegated := suggestionStones("Repair"); stones != 2 || delegated != 1 {
+		t.Fatalf("Repair stones = %d (%d delegated), want 2 (1)", stones, delegated)
+	}
+	if stones, delegated := suggestionStones("Widen"); stones != 2 || delegated != 1 {
+		t.Fatalf("Widen stones = %d (%d delegated), want 2 (1)", stones, delegated)
+	}
+
+	// A direct stone withdraws the delegated one.
+	if _, err := k.PlaceStoneOnSuggestion(ctx, "ElecDomain", "Roads", "Repair", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if stones, delegated := suggestionStones("Widen"); stones != 1 || delegated != 0 {
+		t.Fatalf("Widen stones = %d (%d delegated), want 1 (0)", stones, delegated)
+	}
+	if stones, _ := suggestionStones("Repair"); stones != 3 {
+		t.Fatalf("Repair stones = %d, want 3", stones)
+	}
+
+	// Revoking a delegation removes its stones.
+	mustDelegate(t, k, ctx, "ElecDomain", "Roads", "charlie", "")
+	if stones, delegated := suggestionStones("Repair"); stones != 2 || delegated != 0 {
+		t.Fatalf("Repair stones after revoke = %d (%d delegated), want 2 (0)", stones, delegated)
+	}
+}
+
+func TestDelegatedMemberStones(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupElectionDomain(t, k, ctx, VotingModeSimpleMajority, true)
+
+	mustDelegate(t, k, ctx, "ElecDomain", "", "alice", "bob")
+	mustDelegate(t, k, ctx, "ElecDomain", "", "eve", "bob")
+	if err := k.PlaceStoneOnMember(ctx, "ElecDomain", "charlie", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.PlaceStoneOnMember(ctx, "ElecDomain", "eve", "dave"); err != nil {
+		t.Fatal(err)
+	}
+	domain, _ := k.GetDomain(ctx, "ElecDomain")
+	counts := k.countMemberStones(ctx, domain)
+	if counts["charlie"] != 3 || counts["eve"] != 1 {
+		t.Fatalf("member stones = %v", counts)
+	}
+
+	// A delegated stone never lands on its own delegator.
+	if err := k.PlaceStoneOnMember(ctx, "ElecDomain", "eve", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	counts = k.countMemberStones(ctx, domain)
+	if counts["eve"] != 3 || counts["charlie"] != 0 {
+		t.Fatalf("member stones after move = %v", counts)
+	}
+}
+
+func TestOpenRatingsCarryDelegatedWeight(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupElectionDomain(t, k, ctx, VotingModeSimpleMajority, true)
+	addProposal(t, k, ctx, "ElecDomain", "Parks", "Plant")
+	score := func() int {
+		return ComputeSuggestionScore(Suggestion{Ratings: k.GetSuggestionRatings(ctx, "ElecDomain", "Parks", "Plant")})
+	}
+
+	mustDelegate(t, k, ctx, "ElecDomain", "", "alice", "bob")
+	mustDelegate(t, k, ctx, "ElecDomain", "Parks", "charlie", "bob")
+	if _, err := k.RateProposalOpenly(ctx, "ElecDomain", "Parks", "Plant", 4, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if got := score(); got != 12 {
+		t.Fatalf("score = %d, want 12", got)
+	}
+	if _, err := k.RateProposalOpenly(ctx, "ElecDomain", "Parks", "Plant", 4, "bob"); err == nil {
+		t.Fatal("second open rating accepted")
+	}
+	if _, err := k.RateProposalOpenly(ctx, "ElecDomain", "Parks", "Plant", 9, "dave"); err == nil {
+		t.Fatal("out-of-range rating accepted")
+	}
+	if _, err := k.RateProposalOpenly(ctx, "ElecDomain", "Parks", "Plant", 1, "outsider"); err == nil {
+		t.Fatal("non-member rating accepted")
+	}
+
+	// Once a delegator rates for themselves their weight leaves the delegate.
+	if _, err := k.RateProposalOpenly(ctx, "ElecDomain", "Parks", "Plant", -5, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if got := score(); got != 3 {
+		t.Fatalf("score = %d, want 3", got)
+	}
+
+	// Open voters cannot take an identity commitment until the Big Purge.
+	if err := k.RegisterIdentityCommitment(ctx, "ElecDomain", "bob", commitmentHex(t, big.NewInt(951).Bytes())); err == nil {
+		t.Fatal("open voter registered an identity commitment")
+	}
+	if mode := k.GetVoterMode(ctx, "ElecDomain", "bob"); mode != VoterModeOpen {
+		t.Fatalf("voter mode = %q", mode)
+	}
+}
+
+func TestAnonymousVotersCannotLendWeight(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupElectionDomain(t, k, ctx, VotingModeSimpleMajority, true)
+
+	mustDelegate(t, k, ctx, "ElecDomain", "", "alice", "bob")
+	mustDelegate(t, k, ctx, "ElecDomain", "", "charlie", "alice")
+	if weight, _ := k.GetVotingWeight(ctx, "ElecDomain", "", "bob"); weight.Weight != 3 {
+		t.Fatalf("weight = %+v, want 3", weight)
+	}
+
+	// Alice now holds an identity commitment: her own vote stays hers and
+	// the chain through her stops.
+	if err := k.RegisterIdentityCommitment(ctx, "ElecDomain", "alice", commitmentHex(t, big.NewInt(952).Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if weight, _ := k.GetVotingWeight(ctx, "ElecDomain", "", "bob"); weight.Weight != 1 {
+		t.Fatalf("weight = %+v, want 1", weight)
+	}
+	if err := k.DelegateVote(ctx, "ElecDomain", "", "alice", "dave"); err == nil {
+		t.Fatal("anonymous voter delegated")
+	}
+	if _, err := k.RateProposalOpenly(ctx, "ElecDomain", "BoardChair", "Alice", 3, "alice"); err == nil {
+		t.Fatal("anonymous voter rated openly")
+	}
+
+	// The Big Purge clears voter modes, so the old delegation counts again.
+	k.executeBigPurge(ctx, "ElecDomain")
+	if mode := k.GetVoterMode(ctx, "ElecDomain", "alice"); mode != "" {
+		t.Fatalf("voter mode after purge = %q", mode)
+	}
+	if weight, _ := k.GetVotingWeight(ctx, "ElecDomain", "", "bob"); weight.Weight != 3 {
+		t.Fatalf("weight after purge = %+v, want 3", weight)
+	}
+}
+
+func TestDomainKeysLeaveVoterModeUnset(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupElectionDomain(t, k, ctx, VotingModeSimpleMajority, true)
+	addProposal(t, k, ctx, "ElecDomain", "Parks", "Plant")
+	pubKey := make([]byte, 32)
+	pubKey[0] = 1
+
+	mustDelegate(t, k, ctx, "ElecDomain", "", "bob", "alice")
+	if err := k.JoinPermissionRegister(ctx, "ElecDomain", "alice", pubKey); err != nil {
+		t.Fatal(err)
+	}
+	if mode := k.GetVoterMode(ctx, "ElecDomain", "alice"); mode != "" {
+		t.Fatalf("voter mode = %q, want unset", mode)
+	}
+	// A key holder keeps the open paths and the weight delegated to them.
+	if _, err := k.PlaceStoneOnIssue(ctx, "ElecDomain", "Parks", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if issue, _ := k.GetIssue(ctx, "ElecDomain", "Parks"); issue.Stones != 2 || issue.DelegatedStones != 1 {
+		t.Fatalf("issue stones = %d (%d delegated), want 2 (1)", issue.Stones, issue.DelegatedStones)
+	}
+	if _, err := k.RateProposalOpenly(ctx, "ElecDomain", "Parks", "Plant", 3, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.DelegateVote(ctx, "ElecDomain", "BoardChair", "alice", "dave"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRemoveMemberDropsDelegations(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupElectionDomain(t, k, ctx, VotingModeSimpleMajority, true)
+
+	mustDelegate(t, k, ctx, "ElecDomain", "", "alice", "bob")
+	mustDelegate(t, k, ctx, "ElecDomain", "BoardChair", "bob", "charlie")
+	if _, err := k.PlaceStoneOnIssue(ctx, "ElecDomain", "BoardChair", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	k.removeMember(ctx, "ElecDomain", "bob")
+
+	if _, found := k.GetVoteDelegation(ctx, "ElecDomain", "", "alice"); found {
+		t.Fatal("delegation to a removed member survived")
+	}
+	if _, found := k.GetVoteDelegation(ctx, "ElecDomain", "BoardChair", "bob"); found {
+		t.Fatal("delegation by a removed member survived")
+	}
+	if issue, _ := k.GetIssue(ctx, "ElecDomain", "BoardChair"); issue.DelegatedStones != 0 {
+		t.Fatalf("delegated stones = %d after removal", issue.DelegatedStones)
+	}
+}
+
+func TestDelegatedWeightFollowsChains(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupElectionDomain(t, k, ctx, VotingModeSimpleMajority, true)
+	addProposal(t, k, ctx, "ElecDomain", "Roads", "Repair")
+	delegated := func(issueName string) int {
+		issue, _ := k.GetIssue(ctx, "ElecDomain", issueName)
+		return issue.DelegatedStones
+	}
+
+	mustDelegate(t, k, ctx, "ElecDomain", "", "alice", "bob")
+	mustDelegate(t, k, ctx, "ElecDomain", "", "bob", "charlie")
+	mustDelegate(t, k, ctx, "ElecDomain", "", "eve", "alice")
+	if got := k.delegationUpstream(ctx, "ElecDomain", "", []string{"charlie"}); !reflect.DeepEqual(got, []string{"charlie", "bob", "alice", "eve"}) {
+		t.Fatalf("upstream = %v", got)
+	}
+	if _, err := k.PlaceStoneOnIssue(ctx, "ElecDomain", "BoardChair", "charlie"); err != nil {
+		t.Fatal(err)
+	}
+	if got := delegated("BoardChair"); got != 3 {
+		t.Fatalf("delegated stones = %d, want 3", got)
+	}
+
+	// A stone in the middle of the chain takes the weight behind it along.
+	if _, err := k.PlaceStoneOnIssue(ctx, "ElecDomain", "Roads", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if a, b := delegated("BoardChair"), delegated("Roads"); a != 0 || b != 2 {
+		t.Fatalf("delegated stones = %d/%d, want 0/2", a, b)
+	}
+
+	// Redirecting a delegation moves only the delegator's subtree.
+	mustDelegate(t, k, ctx, "ElecDomain", "", "alice", "charlie")
+	if a, b := delegated("BoardChair"), delegated("Roads"); a != 2 || b != 0 {
+		t.Fatalf("delegated stones after redirect = %d/%d, want 2/0", a, b)
+	}
+	if got := k.directDelegators(ctx, "ElecDomain", "", "bob"); len(got) != 0 {
+		t.Fatalf("stale delegate index = %v", got)
+	}
+
+	// Removing a member drops the weight routed through them.
+	if err := k.removeMember(ctx, "ElecDomain", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if a, b := delegated("BoardChair"), delegated("Roads"); a != 0 || b != 0 {
+		t.Fatalf("delegated stones after removal = %d/%d, want 0/0", a, b)
+	}
+	if issue, _ := k.GetIssue(ctx, "ElecDomain", "BoardChair"); issue.Stones != 1 {
+		t.Fatalf("issue stones = %d, want 1", issue.Stones)
+	}
+}
+
+func TestQueryVotingWeight(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupElectionDomain(t, k, ctx, VotingModeSimpleMajority, true)
+	mustDelegate(t, k, ctx, "ElecDomain", "BoardChair", "alice", "bob")
+
+	resp, err := k.VotingWeight(ctx, &QueryVotingWeightRequest{DomainName: "ElecDomain", IssueName: "BoardChair", Member: "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var weight VotingWeight
+	if err := json.Unmarshal(resp.Result, &weight); err != nil {
+		t.Fatal(err)
+	}
+	if weight.Weight != 2 || !reflect.DeepEqual(weight.Delegators, []string{"alice"}) {
+		t.Fatalf("weight = %+v", weight)
+	}
+	if _, err := k.VotingWeight(ctx, &QueryVotingWeightRequest{DomainName: "ElecDomain", Member: "outsider"}); err == nil {
+		t.Fatal("weight reported for a non-member")
+	}
+}
+
+func TestMsgDelegationValidationAndEncoding(t *testing.T) {
+	delegator := sdk.AccAddress("delegator")
+	msg := MsgDelegateVote{
+		Sender:     delegator,
+		DomainName: "ElecDomain",
+		IssueName:  "BoardChair",
+		Delegator:  delegator.String(),
+		Delegate:   sdk.AccAddress("delegate").String(),
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		t.Fatalf("valid delegation rejected: %v", err)
+	}
+	bz, err := gogoproto.Marshal(&msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded MsgDelegateVote
+	if err := gogoproto.Unmarshal(bz, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, msg) {
+		t.Fatalf("decoded = %+v", decoded)
+	}
+	msg.Delegate = msg.Delegator
+	if err := msg.ValidateBasic(); err == nil {
+		t.Fatal("self-delegation accepted")
+	}
+	msg.Delegate = ""
+	msg.Delegator = sdk.AccAddress("someone-else").String()
+	if err := msg.ValidateBasic(); err == nil {
+		t.Fatal("delegation for another member accepted")
+	}
+
+	rate := MsgRateOpenly{
+		Sender:         delegator,
+		DomainName:     "ElecDomain",
+		IssueName:      "Parks",
+		SuggestionName: "Plant",
+		Rating:         -5,
+		MemberAddr:     delegator.String(),
+	}
+	if err := rate.ValidateBasic(); err != nil {
+		t.Fatalf("valid rating rejected: %v", err)
+	}
+	if bz, indexes := rate.Descriptor(); len(bz) == 0 || len(indexes) == 0 {
+		t.Fatal("message descriptor missing")
+	}
+	rate.Rating = 6
+	if err := rate.ValidateBasic(); err == nil {
+		t.Fatal("out-of-range rating accepted")
+	}
+}
+
+func TestGenesisVoteDelegations(t *testing.T) {
+	genesis := validDemocracyGenesis()
+	admin := genesis.Domains[0].Admin.String()
+	member := sdk.AccAddress("genesis-member").String()
+	genesis.Domains[0].Members = append(genesis.Domains[0].Members, member)
+	genesis.VoteDelegations = []VoteDelegation{{DomainName: "Test", Delegator: member, Delegate: admin}}
+	genesis.VoterModes = []VoterModeRecord{{DomainName: "Test", Member: admin, Mode: VoterModeOpen}}
+	if err := ValidateGenesisState(genesis); err != nil {
+		t.Fatalf("valid delegations rejected: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*GenesisState)
+	}{
+		{"self delegation", func(g *GenesisState) { g.VoteDelegations[0].Delegate = member }},
+		{"non-member delegate", func(g *GenesisState) { g.VoteDelegations[0].Delegate = sdk.AccAddress("outsider").String() }},
+		{"missing issue", func(g *GenesisState) { g.VoteDelegations[0].IssueName = "missing" }},
+		{"duplicate delegation", func(g *GenesisState) { g.VoteDelegations = append(g.VoteDelegations, g.VoteDelegations[0]) }},
+		{"unknown mode", func(g *GenesisState) { g.VoterModes[0].Mode = "secret" }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := genesis
+			g.VoteDelegations = append([]VoteDelegation(nil), genesis.VoteDelegations...)
+			g.VoterModes = append([]VoterModeRecord(nil), genesis.VoterModes...)
+			tc.mutate(&g)
+			if err := ValidateGenesisState(g); err == nil {
+				t.Fatal("invalid genesis accepted")
+			}
+		})
+	}
+}
+
+func TestGenesisRoundTripPreservesDelegations(t *testing.T) {
+	am1, k1, ctx1 := setupModuleForGenesis(t)
+	admin := sdk.AccAddress("admin1")
+	k1.CreateDomain(ctx1, "DelegDomain", admin, sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 500_000*PNYXUnit)))
+	member := sdk.AccAddress("member1").String()
+	k1.AddMember(ctx1, "DelegDomain", member, admin)
+	mustDelegate(t, k1, ctx1, "DelegDomain", "", member, admin.String())
+	k1.setVoterMode(ctx1, "DelegDomain", admin.String(), VoterModeOpen)
+
+	exported := am1.ExportGenesis(ctx1, nil)
+	am2, k2, ctx2 := setupModuleForGenesis(t)
+	am2.InitGenesis(ctx2, nil, exported)
+
+	if delegation, found := k2.GetVoteDelegation(ctx2, "DelegDomain", "", member); !found || delegation.Delegate != admin.String() {
+		t.Fatalf("delegation = %+v (found %v)", delegation, found)
+	}
+	if mode := k2.GetVoterMode(ctx2, "DelegDomain", admin.String()); mode != VoterModeOpen {
+		t.Fatalf("voter mode = %q", mode)
+	}
+	if got := k2.directDelegators(ctx2, "DelegDomain", "", admin.String()); !reflect.DeepEqual(got, []string{member}) {
+		t.Fatalf("delegate index = %v", got)
+	}
+}
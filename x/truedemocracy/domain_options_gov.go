@@ -0,0 +1,267 @@
+package truedemocracy
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Domain options are changed by member vote rather than by the admin:
+//   "optionsvote:{d}{options hash}{voter}" → []byte{1}
+//   "optionshistory:{d}{seq}"              → DomainOptionsChange
+//
+// A member proposes a complete new options set; other members endorse it by
+// proposing the identical set. Votes count per exact options set. When the
+// share of current members required by the domain's own
+// OptionsChangeMajorityBps agrees, the new options replace the old ones, every
+// open options vote of the domain is cleared (they were cast against the old
+// options), the change is appended to the domain's history and suggestion
+// zones are re-evaluated under the new thresholds.
+
+// DefaultOptionsChangeMajorityBps is the supermajority used when a domain
+// does not configure OptionsChangeMajorityBps.
+const DefaultOptionsChangeMajorityBps int64 = 6667 // 2/3 ≈ 66.67%
+
+// DomainOptionsChange is one applied options change, kept for audit.
+type DomainOptionsChange struct {
+	DomainName      string        `json:"domain_name"`
+	Sequence        uint64        `json:"sequence"`
+	Previous        DomainOptions `json:"previous"`
+	Options         DomainOptions `json:"options"`
+	Voters          []string      `json:"voters"`
+	MemberCount     int64         `json:"member_count"`
+	ChangedAtHeight int64         `json:"changed_at_height"`
+	ChangedAt       int64         `json:"changed_at"`
+}
+
+func optionsVotePrefix(domainName string) []byte {
+	return append([]byte("optionsvote:"), domainScope(domainName)...)
+}
+
+func optionsVoteKey(domainName string, options DomainOptions, voter string) []byte {
+	hash := domainOptionsHash(options)
+	return append(append(optionsVotePrefix(domainName), hash[:]...), voter...)
+}
+
+func optionsHistoryPrefix(domainName string) []byte {
+	return append([]byte("optionshistory:"), domainScope(domainName)...)
+}
+
+func optionsHistoryKey(domainName string, seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(optionsHistoryPrefix(domainName), seq)
+}
+
+// domainOptionsHash identifies an options set in vote keys. Every field is
+// encoded explicitly so the hash does not depend on JSON field order. The
+// circuit is appended only when it is not the MiMC circuit, so the hash of
+// every options set that predates it is unchanged.
+func domainOptionsHash(o DomainOptions) [32]byte {
+	flags := func(b bool) string {
+		if b {
+			return "1"
+		}
+		return "0"
+	}
+	encoded := fmt.Sprintf("%s%s%s%s%s|%d|%d|%d|%d|%d",
+		flags(o.AdminElectable), flags(o.AnyoneCanJoin), flags(o.OnlyAdminIssues),
+		flags(o.CoinBurnRequired), flags(o.AbstentionAllowed),
+		o.ApprovalThreshold, o.DefaultDwellTime, o.VotingMode, o.PayoutCapPerEpoch,
+		o.OptionsChangeMajorityBps)
+	if circuit := o.zkpCircuit(); circuit != MembershipCircuitID {
+		encoded += fmt.Sprintf("|%d:%s", len(circuit), circuit)
+	}
+	return sha256.Sum256([]byte(encoded))
+}
+
+// optionsChangeMajority returns the supermajority, in basis points, that a
+// change of these options requires.
+func (o DomainOptions) optionsChangeMajority() int64 {
+	if o.OptionsChangeMajorityBps == 0 {
+		return DefaultOptionsChangeMajorityBps
+	}
+	return o.OptionsChangeMajorityBps
+}
+
+// zkpCircuit returns the membership circuit of the domain's anonymous proofs.
+func (o DomainOptions) zkpCircuit() string {
+	if o.ZKPCircuit == "" {
+		return MembershipCircuitID
+	}
+	return o.ZKPCircuit
+}
+
+// hashFamily returns the hash family of the domain's membership circuit.
+func (o DomainOptions) hashFamily() string {
+	return membershipCircuitNullifierFamilies[o.zkpCircuit()]
+}
+
+// validateDomainOptions checks the bounds of every numeric option and that
+// the membership circuit is a supported one.
+func validateDomainOptions(o DomainOptions) error {
+	if o.ApprovalThreshold < 0 || o.ApprovalThreshold > 10_000 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "approval threshold must be 0..10000 basis points")
+	}
+	if o.DefaultDwellTime < 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "default dwell time cannot be negative")
+	}
+	if o.VotingMode < VotingModeSimpleMajority || o.VotingMode > VotingModeDHondt {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "unknown voting mode %d", o.VotingMode)
+	}
+	if o.PayoutCapPerEpoch < 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "payout cap per epoch cannot be negative")
+	}
+	if o.OptionsChangeMajorityBps != 0 && (o.OptionsChangeMajorityBps <= 5_000 || o.OptionsChangeMajorityBps > 10_000) {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "options change majority must be 5001..10000 basis points (0 = default)")
+	}
+	if _, supported := membershipCircuitNullifierFamilies[o.zkpCircuit()]; !supported {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "unsupported ZKP circuit id %q", o.ZKPCircuit)
+	}
+	return nil
+}
+
+// ProposeDomainOptionsChange records a member's vote for replacing the
+// domain's options with options. When the domain's options-change
+// supermajority of current members has voted for the identical set, the
+// change is applied atomically. Returns the number of votes for the set,
+// the member count, and whether the change was applied.
+func (k Keeper) ProposeDomainOptionsChange(ctx sdk.Context, domainName string, options DomainOptions, voterAddr string) (int, int, bool, error) {
+	if domainName == ReservedGovernanceDomain {
+		return 0, 0, false, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "domain %s options cannot be changed", ReservedGovernanceDomain)
+	}
+	domain, found := k.GetDomainHeader(ctx, domainName)
+	if !found {
+		return 0, 0, false, errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
+	}
+	if !k.IsDomainMember(ctx, domainName, voterAddr) {
+		return 0, 0, false, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only domain members can vote on options changes")
+	}
+	if err := validateDomainOptions(options); err != nil {
+		return 0, 0, false, err
+	}
+	if domainOptionsHash(options) == domainOptionsHash(domain.Options) {
+		return 0, 0, false, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "options are unchanged")
+	}
+	if options.zkpCircuit() == MembershipCircuitID {
+		options.ZKPCircuit = ""
+	}
+	if err := k.validateZKPCircuitChange(ctx, domain, options); err != nil {
+		return 0, 0, false, err
+	}
+
+	store := ctx.KVStore(k.StoreKey)
+	voteKey := optionsVoteKey(domainName, options, voterAddr)
+	if store.Has(voteKey) {
+		return 0, 0, false, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "already voted for these options")
+	}
+	store.Set(voteKey, []byte{1})
+
+	members := k.GetDomainMembers(ctx, domainName)
+	var voters []string
+	for _, member := range members {
+		if store.Has(optionsVoteKey(domainName, options, member)) {
+			voters = append(voters, member)
+		}
+	}
+	if int64(len(voters))*10000 < int64(len(members))*domain.Options.optionsChangeMajority() {
+		return len(voters), len(members), false, nil
+	}
+
+	// Apply on a cache so a failing zone re-evaluation leaves neither the
+	// new options nor a history entry behind.
+	cacheCtx, write := ctx.CacheContext()
+	change := DomainOptionsChange{
+		DomainName:      domainName,
+		Sequence:        k.nextOptionsHistorySeq(cacheCtx, domainName),
+		Previous:        domain.Options,
+		Options:         options,
+		Voters:          voters,
+		MemberCount:     int64(len(members)),
+		ChangedAtHeight: ctx.BlockHeight(),
+		ChangedAt:       ctx.BlockTime().Unix(),
+	}
+	domain.Options = options
+	k.SetDomainHeader(cacheCtx, domain)
+	k.setOptionsHistory(cacheCtx, change)
+	k.clearOptionsVotes(cacheCtx, domainName)
+	if err := k.EvaluateSuggestionZones(cacheCtx, domainName); err != nil {
+		return len(voters), len(members), false, err
+	}
+	write()
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"domain_options_changed",
+		sdk.NewAttribute("domain", domainName),
+		sdk.NewAttribute("sequence", fmt.Sprintf("%d", change.Sequence)),
+		sdk.NewAttribute("votes", fmt.Sprintf("%d", len(voters))),
+		sdk.NewAttribute("members", fmt.Sprintf("%d", len(members))),
+	))
+	return len(voters), len(members), true, nil
+}
+
+// validateZKPCircuitChange admits a new membership circuit for the domain.
+// Moving to another hash family changes how commitments and the identity
+// tree are hashed, so it is allowed only while no commitment is registered
+// (before the first or after a Big Purge), and only to a family whose
+// verifying key is configured.
+func (k Keeper) validateZKPCircuitChange(ctx sdk.Context, domain Domain, options DomainOptions) error {
+	family := options.hashFamily()
+	if family == domain.Options.hashFamily() {
+		return nil
+	}
+	if domain.MerkleRoot != "" {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "the ZKP circuit hash family cannot change while identity commitments are registered")
+	}
+	if _, found := k.GetFamilyVerifyingKey(ctx, family); !found {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "no verifying key is configured for circuit %s", options.zkpCircuit())
+	}
+	return nil
+}
+
+func (k Keeper) clearOptionsVotes(ctx sdk.Context, domainName string) {
+	store := ctx.KVStore(k.StoreKey)
+	prefix := optionsVotePrefix(domainName)
+	iter := store.Iterator(prefix, prefixEnd(prefix))
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, append([]byte{}, iter.Key()...))
+	}
+	iter.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+func (k Keeper) nextOptionsHistorySeq(ctx sdk.Context, domainName string) uint64 {
+	prefix := optionsHistoryPrefix(domainName)
+	iter := ctx.KVStore(k.StoreKey).ReverseIterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	if !iter.Valid() {
+		return 1
+	}
+	return binary.BigEndian.Uint64(iter.Key()[len(prefix):]) + 1
+}
+
+func (k Keeper) setOptionsHistory(ctx sdk.Context, change DomainOptionsChange) {
+	store := ctx.KVStore(k.StoreKey)
+	store.Set(optionsHistoryKey(change.DomainName, change.Sequence), k.cdc.MustMarshalLengthPrefixed(&change))
+}
+
+// GetDomainOptionsHistory returns a domain's applied options changes, oldest
+// first.
+func (k Keeper) GetDomainOptionsHistory(ctx sdk.Context, domainName string) []DomainOptionsChange {
+	store := ctx.KVStore(k.StoreKey)
+	prefix := optionsHistoryPrefix(domainName)
+	iter := store.Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	var changes []DomainOptionsChange
+	for ; iter.Valid(); iter.Next() {
+		var change DomainOptionsChange
+		k.cdc.MustUnmarshalLengthPrefixed(iter.Value(), &change)
+		changes = append(changes, change)
+	}
+	return changes
+}
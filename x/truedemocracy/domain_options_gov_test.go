@@ -0,0 +1,285 @@
+package truedemocracy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// proposeOptions has members m{from}..m{to} of LifeDomain vote for options
+// and reports whether the last vote applied the change.
+func proposeOptions(t *testing.T, k Keeper, ctx sdk.Context, options DomainOptions, from, to int) bool {
+	t.Helper()
+	applied := false
+	for i := from; i <= to; i++ {
+		_, _, ok, err := k.ProposeDomainOptionsChange(ctx, "LifeDomain", options, fmt.Sprintf("m%d", i))
+		if err != nil {
+			t.Fatalf("vote of m%d: %v", i, err)
+		}
+		applied = ok
+	}
+	return applied
+}
+
+func TestDomainOptionsChangeAppliesAtSupermajority(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupLifecycleDomain(t, k, ctx)
+	setSuggestionStones(t, k, ctx, "LifeDomain", 0, 0, 1)
+	if err := k.EvaluateSuggestionZones(ctx, "LifeDomain"); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := k.GetSuggestion(ctx, "LifeDomain", "PolicyA", "S1"); s.Color != "green" {
+		t.Fatalf("S1 color = %q before the change", s.Color)
+	}
+
+	previous := lifeDomainOptions(t, k, ctx)
+	options := previous
+	options.ApprovalThreshold = 2_000 // 2 of 10 members
+
+	// 6 of 10 members are below the default 2/3.
+	if proposeOptions(t, k, ctx, options, 1, 6) {
+		t.Fatal("change applied below the supermajority")
+	}
+	if got := lifeDomainOptions(t, k, ctx); got != previous {
+		t.Fatalf("options changed early: %+v", got)
+	}
+	if !proposeOptions(t, k, ctx, options, 7, 7) {
+		t.Fatal("change not applied at 7 of 10 members")
+	}
+
+	if got := lifeDomainOptions(t, k, ctx); got != options {
+		t.Fatalf("options = %+v, want %+v", got, options)
+	}
+	if s, _ := k.GetSuggestion(ctx, "LifeDomain", "PolicyA", "S1"); s.Color != "yellow" {
+		t.Fatalf("S1 color = %q, want zones re-evaluated under the new threshold", s.Color)
+	}
+	history := k.GetDomainOptionsHistory(ctx, "LifeDomain")
+	if len(history) != 1 {
+		t.Fatalf("history = %+v", history)
+	}
+	change := history[0]
+	if change.Sequence != 1 || change.Previous != previous || change.Options != options ||
+		len(change.Voters) != 7 || change.MemberCount != 10 || change.ChangedAtHeight != ctx.BlockHeight() {
+		t.Fatalf("history entry = %+v", change)
+	}
+
+	// Votes were cleared, so the same member can vote on the next change.
+	next := options
+	next.AnyoneCanJoin = true
+	if _, _, _, err := k.ProposeDomainOptionsChange(ctx, "LifeDomain", next, "m1"); err != nil {
+		t.Fatalf("vote after applied change: %v", err)
+	}
+}
+
+func TestDomainOptionsChangeCountsIdenticalSetsOnly(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupLifecycleDomain(t, k, ctx)
+
+	lower := lifeDomainOptions(t, k, ctx)
+	lower.ApprovalThreshold = 1_000
+	higher := lower
+	higher.ApprovalThreshold = 1_500
+
+	proposeOptions(t, k, ctx, lower, 1, 5)
+	if votes, members, applied, err := k.ProposeDomainOptionsChange(ctx, "LifeDomain", higher, "m6"); err != nil || votes != 1 || members != 10 || applied {
+		t.Fatalf("competing set: votes=%d members=%d applied=%t err=%v", votes, members, applied, err)
+	}
+	if !proposeOptions(t, k, ctx, lower, 7, 8) {
+		t.Fatal("identical set did not reach 7 votes")
+	}
+
+	// The competing vote was cast against the old options and is cleared.
+	if votes, _, _, err := k.ProposeDomainOptionsChange(ctx, "LifeDomain", higher, "m6"); err != nil || votes != 1 {
+		t.Fatalf("revote on competing set: votes=%d err=%v", votes, err)
+	}
+}
+
+func TestDomainOptionsChangeUsesConfiguredMajority(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupLifecycleDomain(t, k, ctx)
+
+	unanimous := lifeDomainOptions(t, k, ctx)
+	unanimous.OptionsChangeMajorityBps = 10_000
+	if !proposeOptions(t, k, ctx, unanimous, 1, 7) {
+		t.Fatal("majority change not applied under the default 2/3")
+	}
+
+	// The new majority governs the next change.
+	next := unanimous
+	next.DefaultDwellTime = 3_600
+	if proposeOptions(t, k, ctx, next, 1, 9) {
+		t.Fatal("change applied with 9 of 10 under a unanimous requirement")
+	}
+	if !proposeOptions(t, k, ctx, next, 10, 10) {
+		t.Fatal("change not applied with every member")
+	}
+	if history := k.GetDomainOptionsHistory(ctx, "LifeDomain"); len(history) != 2 || history[1].Sequence != 2 {
+		t.Fatalf("history = %+v", history)
+	}
+}
+
+func TestDomainOptionsChangeRejections(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupLifecycleDomain(t, k, ctx)
+	current := lifeDomainOptions(t, k, ctx)
+	options := current
+	options.OnlyAdminIssues = true
+
+	tests := []struct {
+		name    string
+		domain  string
+		options DomainOptions
+		voter   string
+		want    error
+	}{
+		{"missing domain", "Missing", options, "m1", sdkerrors.ErrUnknownRequest},
+		{"governance domain", ReservedGovernanceDomain, options, "m1", sdkerrors.ErrInvalidRequest},
+		{"non-member", "LifeDomain", options, "outsider", sdkerrors.ErrUnauthorized},
+		{"unchanged", "LifeDomain", current, "m1", sdkerrors.ErrInvalidRequest},
+		{"threshold out of range", "LifeDomain", DomainOptions{ApprovalThreshold: 10_001}, "m1", sdkerrors.ErrInvalidRequest},
+		{"simple majority", "LifeDomain", DomainOptions{OptionsChangeMajorityBps: 5_000}, "m1", sdkerrors.ErrInvalidRequest},
+		{"unknown voting mode", "LifeDomain", DomainOptions{VotingMode: VotingModeDHondt + 1}, "m1", sdkerrors.ErrInvalidRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, _, err := k.ProposeDomainOptionsChange(ctx, tc.domain, tc.options, tc.voter); !errors.Is(err, tc.want) {
+				t.Fatalf("got %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	if _, _, _, err := k.ProposeDomainOptionsChange(ctx, "LifeDomain", options, "m1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := k.ProposeDomainOptionsChange(ctx, "LifeDomain", options, "m1"); !errors.Is(err, sdkerrors.ErrInvalidRequest) {
+		t.Fatalf("duplicate vote: got %v", err)
+	}
+}
+
+func TestMsgProposeDomainOptionsChange(t *testing.T) {
+	sender := sdk.AccAddress("member1")
+	msg := MsgProposeDomainOptionsChange{
+		Sender: sender, DomainName: "D", VoterAddr: sender.String(),
+		AnyoneCanJoin: true, ApprovalThreshold: 1_000, VotingMode: int32(VotingModeSchulze), OptionsChangeMajorityBps: 7_500,
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		t.Fatalf("valid message rejected: %v", err)
+	}
+	want := DomainOptions{AnyoneCanJoin: true, ApprovalThreshold: 1_000, VotingMode: VotingModeSchulze, OptionsChangeMajorityBps: 7_500}
+	if got := msg.Options(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("options = %+v", got)
+	}
+
+	bad := msg
+	bad.VoterAddr = sdk.AccAddress("member2").String()
+	if err := bad.ValidateBasic(); err == nil {
+		t.Fatal("voter other than the signer accepted")
+	}
+	bad = msg
+	bad.DefaultDwellTime = -1
+	if err := bad.ValidateBasic(); err == nil {
+		t.Fatal("negative dwell time accepted")
+	}
+}
+
+func TestQueryDomainOptionsHistory(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupLifecycleDomain(t, k, ctx)
+	options := lifeDomainOptions(t, k, ctx)
+	options.CoinBurnRequired = true
+	proposeOptions(t, k, ctx, options, 1, 7)
+
+	resp, err := k.DomainOptionsHistory(ctx, &QueryDomainOptionsHistoryRequest{DomainName: "LifeDomain"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var changes []DomainOptionsChange
+	if err := json.Unmarshal(resp.Result, &changes); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || !changes[0].Options.CoinBurnRequired {
+		t.Fatalf("changes = %+v", changes)
+	}
+	if _, err := k.DomainOptionsHistory(ctx, &QueryDomainOptionsHistoryRequest{DomainName: "Missing"}); err == nil {
+		t.Fatal("history of a missing domain returned")
+	}
+}
+
+func TestGenesisDomainOptionsHistory(t *testing.T) {
+	genesis := validDemocracyGenesis()
+	genesis.DomainOptionsHistory = []DomainOptionsChange{{
+		DomainName: "Test", Sequence: 1, Options: DomainOptions{AnyoneCanJoin: true},
+		Voters: []string{sdk.AccAddress("genesis-admin").String()}, MemberCount: 1,
+	}}
+	if err := ValidateGenesisState(genesis); err != nil {
+		t.Fatalf("valid options history rejected: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*GenesisState)
+	}{
+		{"missing domain", func(g *GenesisState) { g.DomainOptionsHistory[0].DomainName = "Missing" }},
+		{"zero sequence", func(g *GenesisState) { g.DomainOptionsHistory[0].Sequence = 0 }},
+		{"invalid options", func(g *GenesisState) { g.DomainOptionsHistory[0].Options.OptionsChangeMajorityBps = 4_000 }},
+		{"more voters than members", func(g *GenesisState) { g.DomainOptionsHistory[0].MemberCount = 0 }},
+		{"duplicate sequence", func(g *GenesisState) {
+			g.DomainOptionsHistory = append(g.DomainOptionsHistory, g.DomainOptionsHistory[0])
+		}},
+		{"invalid domain majority", func(g *GenesisState) { g.Domains[0].Options.OptionsChangeMajorityBps = 10_001 }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := genesis
+			g.DomainOptionsHistory = append([]DomainOptionsChange(nil), genesis.DomainOptionsHistory...)
+			g.Domains = append([]Domain(nil), genesis.Domains...)
+			tc.mutate(&g)
+			if err := ValidateGenesisState(g); err == nil {
+				t.Fatal("invalid genesis accepted")
+			}
+		})
+	}
+}
+
+func TestGenesisRoundTripKeepsOptionsHistory(t *testing.T) {
+	am1, k1, ctx1 := setupModuleForGenesis(t)
+	admin := sdk.AccAddress("admin1")
+	k1.CreateDomain(ctx1, "Solo", admin, sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 1_000)))
+	solo, _ := k1.GetDomainHeader(ctx1, "Solo")
+	options := solo.Options
+	options.AnyoneCanJoin = true
+	if _, _, applied, err := k1.ProposeDomainOptionsChange(ctx1, "Solo", options, admin.String()); err != nil || !applied {
+		t.Fatalf("sole member change: applied=%t err=%v", applied, err)
+	}
+
+	exported := am1.ExportGenesis(ctx1, nil)
+	am2, k2, ctx2 := setupModuleForGenesis(t)
+	am2.InitGenesis(ctx2, nil, exported)
+
+	if got, want := k2.GetDomainOptionsHistory(ctx2, "Solo"), k1.GetDomainOptionsHistory(ctx1, "Solo"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("history after import = %+v, want %+v", got, want)
+	}
+	// The sequence continues after import.
+	options.AnyoneCanJoin = false
+	if _, _, applied, err := k2.ProposeDomainOptionsChange(ctx2, "Solo", options, admin.String()); err != nil || !applied {
+		t.Fatalf("change after import: applied=%t err=%v", applied, err)
+	}
+	if history := k2.GetDomainOptionsHistory(ctx2, "Solo"); len(history) != 2 || history[1].Sequence != 2 {
+		t.Fatalf("history = %+v", history)
+	}
+}
+
+// lifeDomainOptions returns the current options of LifeDomain.
+func lifeDomainOptions(t *testing.T, k Keeper, ctx sdk.Context) DomainOptions {
+	t.Helper()
+	domain, found := k.GetDomainHeader(ctx, "LifeDomain")
+	if !found {
+		t.Fatal("LifeDomain not found")
+	}
+	return domain.Options
+}
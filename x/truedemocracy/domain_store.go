@@ -0,0 +1,587 @@
+package truedemocracy
+
+import (
+	"encoding/binary"
+
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Domain state is split into per-entity records so that a stone, rating or
+// membership change reads and writes only the records it touches:
+//
+//   "domain:{name}"                              → Domain header (no lists)
+//   "dmember:{d}{seq}"       / "dmember-idx:{d}{addr}"       → member address
+//   "dpermreg:{d}{seq}"      / "dpermreg-idx:{d}{key}"       → domain key hex
+//   "dcommit:{d}{seq}"       / "dcommit-idx:{d}{commit}"     → commitment hex
+//   "dissue:{d}{seq}"        / "dissue-idx:{d}{name}"        → Issue (no suggestions)
+//   "dsugg:{d}{i}{seq}"      / "dsugg-idx:{d}{i}{name}"      → Suggestion (no ratings)
+//   "drating:{d}{i}{s}{seq}" / "drating-idx:{d}{i}{s}{voter}" → Rating
+//
+// {d} is the uvarint length-prefixed domain name and {i}, {s} and {seq} are
+// 8-byte big-endian sequence numbers, so names containing ':' cannot collide
+// and prefix iteration returns entities in insertion order. Each scope keeps
+// its next sequence and live count under "{prefix}-meta:{scope}".
+
+var (
+	domainMembers     = entityIndex{prefix: "dmember"}
+	domainPermReg     = entityIndex{prefix: "dpermreg"}
+	domainCommits     = entityIndex{prefix: "dcommit"}
+	domainIssues      = entityIndex{prefix: "dissue"}
+	domainSuggestions = entityIndex{prefix: "dsugg"}
+	domainRatings     = entityIndex{prefix: "drating"}
+)
+
+func domainHeaderKey(name string) []byte {
+	return []byte("domain:" + name)
+}
+
+// domainScope encodes a domain name as a prefix-free key segment.
+func domainScope(name string) []byte {
+	bz := binary.AppendUvarint(nil, uint64(len(name)))
+	return append(bz, name...)
+}
+
+func appendSeq(scope []byte, seq uint64) []byte {
+	out := make([]byte, len(scope), len(scope)+8)
+	copy(out, scope)
+	return binary.BigEndian.AppendUint64(out, seq)
+}
+
+// ratingVoterID is the double-vote index key of a rating: the legacy domain
+// key, the ZKP nullifier or the open rater's address, whichever the rating
+// carries.
+func ratingVoterID(r Rating) string {
+	if r.DomainPubKeyHex != "" {
+		return "k:" + r.DomainPubKeyHex
+	}
+	if r.NullifierHex != "" {
+		return "n:" + r.NullifierHex
+	}
+	if r.MemberAddr != "" {
+		return "m:" + r.MemberAddr
+	}
+	return ""
+}
+
+// entityIndex is an insertion-ordered, name-indexed record set stored under
+// one key prefix. Records live under "{prefix}:{scope}{seq}", the name index
+// under "{prefix}-idx:{scope}{name}" and the scope counters under
+// "{prefix}-meta:{scope}".
+type entityIndex struct {
+	prefix string
+}
+
+func (e entityIndex) recordKey(scope []byte, seq uint64) []byte {
+	return appendSeq(append([]byte(e.prefix+":"), scope...), seq)
+}
+
+func (e entityIndex) indexKey(scope []byte, name string) []byte {
+	return append(append([]byte(e.prefix+"-idx:"), scope...), name...)
+}
+
+func (e entityIndex) metaKey(scope []byte) []byte {
+	return append([]byte(e.prefix+"-meta:"), scope...)
+}
+
+// meta returns the next sequence number and the live record count of a scope.
+func (e entityIndex) meta(store storetypes.KVStore, scope []byte) (uint64, uint64) {
+	bz := store.Get(e.metaKey(scope))
+	if len(bz) != 16 {
+		return 0, 0
+	}
+	return binary.BigEndian.Uint64(bz[:8]), binary.BigEndian.Uint64(bz[8:])
+}
+
+func (e entityIndex) setMeta(store storetypes.KVStore, scope []byte, next, count uint64) {
+	bz := binary.BigEndian.AppendUint64(nil, next)
+	store.Set(e.metaKey(scope), binary.BigEndian.AppendUint64(bz, count))
+}
+
+func (e entityIndex) lookup(store storetypes.KVStore, scope []byte, name string) (uint64, bool) {
+	bz := store.Get(e.indexKey(scope, name))
+	if len(bz) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(bz), true
+}
+
+func (e entityIndex) get(store storetypes.KVStore, scope []byte, name string) (uint64, []byte, bool) {
+	seq, ok := e.lookup(store, scope, name)
+	if !ok {
+		return 0, nil, false
+	}
+	return seq, store.Get(e.recordKey(scope, seq)), true
+}
+
+// put stores value under name, appending a new record when name is unknown
+// and overwriting the existing record in place otherwise. An empty name
+// appends an unindexed record. It returns the record's sequence number.
+func (e entityIndex) put(store storetypes.KVStore, scope []byte, name string, value []byte) uint64 {
+	if name != "" {
+		if seq, ok := e.lookup(store, scope, name); ok {
+			store.Set(e.recordKey(scope, seq), value)
+			return seq
+		}
+	}
+	next, count := e.meta(store, scope)
+	seq := next
+	store.Set(e.recordKey(scope, seq), value)
+	if name != "" {
+		store.Set(e.indexKey(scope, name), binary.BigEndian.AppendUint64(nil, seq))
+	}
+	e.setMeta(store, scope, next+1, count+1)
+	return seq
+}
+
+func (e entityIndex) remove(store storetypes.KVStore, scope []byte, name string) (uint64, bool) {
+	seq, ok := e.lookup(store, scope, name)
+	if !ok {
+		return 0, false
+	}
+	store.Delete(e.recordKey(scope, seq))
+	store.Delete(e.indexKey(scope, name))
+	next, count := e.meta(store, scope)
+	if count > 0 {
+		count--
+	}
+	e.setMeta(store, scope, next, count)
+	return seq, true
+}
+
+func (e entityIndex) count(store storetypes.KVStore, scope []byte) int {
+	_, count := e.meta(store, scope)
+	return int(count)
+}
+
+func (e entityIndex) iterate(store storetypes.KVStore, scope []byte, fn func(seq uint64, value []byte) bool) {
+	prefix := append([]byte(e.prefix+":"), scope...)
+	iter := store.Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if len(key) != len(prefix)+8 {
+			continue // record of a nested scope sharing this prefix
+		}
+		if fn(binary.BigEndian.Uint64(key[len(prefix):]), iter.Value()) {
+			break
+		}
+	}
+}
+
+func (e entityIndex) strings(store storetypes.KVStore, scope []byte) []string {
+	var out []string
+	e.iterate(store, scope, func(_ uint64, value []byte) bool {
+		out = append(out, string(value))
+		return false
+	})
+	return out
+}
+
+// putStrings appends values in order. A repeated value is kept as an
+// unindexed record so that the stored list round-trips exactly, letting
+// readers that validate state still detect the duplicate.
+func (e entityIndex) putStrings(store storetypes.KVStore, scope []byte, values []string) {
+	for _, value := range values {
+		name := value
+		if _, exists := e.lookup(store, scope, value); exists {
+			name = ""
+		}
+		e.put(store, scope, name, []byte(value))
+	}
+}
+
+// clear deletes every record, index entry and counter whose scope starts with
+// scopePrefix, including nested scopes (all ratings of an issue, say).
+func (e entityIndex) clear(store storetypes.KVStore, scopePrefix []byte) {
+	for _, space := range []string{e.prefix + ":", e.prefix + "-idx:", e.prefix + "-meta:"} {
+		prefix := append([]byte(space), scopePrefix...)
+		iter := store.Iterator(prefix, prefixEnd(prefix))
+		var keys [][]byte
+		for ; iter.Valid(); iter.Next() {
+			keys = append(keys, append([]byte{}, iter.Key()...))
+		}
+		iter.Close()
+		for _, key := range keys {
+			store.Delete(key)
+		}
+	}
+}
+
+// ---------- Domain header ----------
+
+// GetDomainHeader loads a domain without its members, issues, permission
+// register or identity commitments. Hot paths use it together with the
+// per-entity accessors below so gas does not grow with domain size.
+func (k Keeper) GetDomainHeader(ctx sdk.Context, name string) (Domain, bool) {
+	bz := ctx.KVStore(k.StoreKey).Get(domainHeaderKey(name))
+	if bz == nil {
+		return Domain{}, false
+	}
+	var domain Domain
+	k.cdc.MustUnmarshalLengthPrefixed(bz, &domain)
+	return domain, true
+}
+
+// SetDomainHeader persists the scalar domain fields. Any list fields on the
+// argument are ignored; they are owned by their entity records.
+func (k Keeper) SetDomainHeader(ctx sdk.Context, domain Domain) {
+	header := domain
+	header.Members = nil
+	header.Issues = nil
+	header.PermissionReg = nil
+	header.IdentityCommits = nil
+	ctx.KVStore(k.StoreKey).Set(domainHeaderKey(domain.Name), k.cdc.MustMarshalLengthPrefixed(&header))
+}
+
+// IterateDomainHeaders iterates over all domain headers in name order.
+func (k Keeper) IterateDomainHeaders(ctx sdk.Context, fn func(Domain) bool) {
+	store := ctx.KVStore(k.StoreKey)
+	prefix := []byte("domain:")
+	iter := store.Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var domain Domain
+		k.cdc.MustUnmarshalLengthPrefixed(iter.Value(), &domain)
+		if fn(domain) {
+			break
+		}
+	}
+}
+
+// ---------- Full domain assembly ----------
+
+// GetDomain loads a domain with every member, issue, suggestion and rating
+// assembled from their entity records. Its cost grows with the domain, so it
+// is meant for queries, genesis export and infrequent governance paths.
+func (k Keeper) GetDomain(ctx sdk.Context, name string) (Domain, bool) {
+	domain, found := k.GetDomainHeader(ctx, name)
+	if !found {
+		return Domain{}, false
+	}
+	store := ctx.KVStore(k.StoreKey)
+	scope := domainScope(name)
+	domain.Members = domainMembers.strings(store, scope)
+	domain.PermissionReg = domainPermReg.strings(store, scope)
+	domain.IdentityCommits = domainCommits.strings(store, scope)
+	domainIssues.iterate(store, scope, func(issueSeq uint64, value []byte) bool {
+		var issue Issue
+		k.cdc.MustUnmarshalLengthPrefixed(value, &issue)
+		issueScope := appendSeq(scope, issueSeq)
+		domainSuggestions.iterate(store, issueScope, func(suggSeq uint64, value []byte) bool {
+			var suggestion Suggestion
+			k.cdc.MustUnmarshalLengthPrefixed(value, &suggestion)
+			domainRatings.iterate(store, appendSeq(issueScope, suggSeq), func(_ uint64, value []byte) bool {
+				var rating Rating
+				k.cdc.MustUnmarshalLengthPrefixed(value, &rating)
+				suggestion.Ratings = append(suggestion.Ratings, rating)
+				return false
+			})
+			issue.Suggestions = append(issue.Suggestions, suggestion)
+			return false
+		})
+		domain.Issues = append(domain.Issues, issue)
+		return false
+	})
+	return domain, true
+}
+
+// SetDomain replaces a domain and all of its entity records with the given
+// value, preserving list order. Like GetDomain its cost grows with the
+// domain; it serves genesis import, the store migration and bulk rewrites.
+func (k Keeper) SetDomain(ctx sdk.Context, domain Domain) {
+	store := ctx.KVStore(k.StoreKey)
+	scope := domainScope(domain.Name)
+	for _, index := range []entityIndex{domainMembers, domainPermReg, domainCommits, domainIssues, domainSuggestions, domainRatings} {
+		index.clear(store, scope)
+	}
+	k.SetDomainHeader(ctx, domain)
+	if domain.Parent != "" {
+		store.Set(subDomainKey(domain.Parent, domain.Name), []byte(domain.Name))
+	}
+	domainMembers.putStrings(store, scope, domain.Members)
+	domainPermReg.putStrings(store, scope, domain.PermissionReg)
+	domainCommits.putStrings(store, scope, domain.IdentityCommits)
+	// Malformed commitments leave the identity tree empty, so its root no
+	// longer matches MerkleRoot and proof queries fail closed.
+	_ = k.rebuildMerkleNodes(ctx, domainIdentityTree(domain))
+	for _, issue := range domain.Issues {
+		k.SetIssue(ctx, domain.Name, issue)
+		for _, suggestion := range issue.Suggestions {
+			k.SetSuggestion(ctx, domain.Name, issue.Name, suggestion)
+			for _, rating := range suggestion.Ratings {
+				k.appendRating(ctx, domain.Name, issue.Name, suggestion.Name, rating)
+			}
+		}
+	}
+}
+
+// IterateDomains iterates over all fully assembled domains in name order.
+func (k Keeper) IterateDomains(ctx sdk.Context, fn func(Domain) bool) {
+	var names []string
+	k.IterateDomainHeaders(ctx, func(d Domain) bool {
+		names = append(names, d.Name)
+		return false
+	})
+	for _, name := range names {
+		domain, _ := k.GetDomain(ctx, name)
+		if fn(domain) {
+			break
+		}
+	}
+}
+
+// ---------- Members ----------
+
+// IsDomainMember reports membership with a single indexed read.
+func (k Keeper) IsDomainMember(ctx sdk.Context, domainName, addr string) bool {
+	_, ok := domainMembers.lookup(ctx.KVStore(k.StoreKey), domainScope(domainName), addr)
+	return ok
+}
+
+// DomainMemberCount returns the number of members without loading them.
+func (k Keeper) DomainMemberCount(ctx sdk.Context, domainName string) int {
+	return domainMembers.count(ctx.KVStore(k.StoreKey), domainScope(domainName))
+}
+
+// GetDomainMembers returns the members of a domain in join order.
+func (k Keeper) GetDomainMembers(ctx sdk.Context, domainName string) []string {
+	return domainMembers.strings(ctx.KVStore(k.StoreKey), domainScope(domainName))
+}
+
+func (k Keeper) addDomainMember(ctx sdk.Context, domainName, addr string) {
+	domainMembers.put(ctx.KVStore(k.StoreKey), domainScope(domainName), addr, []byte(addr))
+}
+
+func (k Keeper) removeDomainMember(ctx sdk.Context, domainName, addr string) {
+	domainMembers.remove(ctx.KVStore(k.StoreKey), domainScope(domainName), addr)
+}
+
+// ---------- Permission register and identity commitments ----------
+
+func (k Keeper) hasPermissionKey(ctx sdk.Context, domainName, keyHex string) bool {
+	_, ok := domainPermReg.lookup(ctx.KVStore(k.StoreKey), domainScope(domainName), keyHex)
+	return ok
+}
+
+func (k Keeper) addPermissionKey(ctx sdk.Context, domainName, keyHex string) {
+	domainPermReg.put(ctx.KVStore(k.StoreKey), domainScope(domainName), keyHex, []byte(keyHex))
+}
+
+func (k Keeper) clearPermissionRegister(ctx sdk.Context, domainName string) {
+	domainPermReg.clear(ctx.KVStore(k.StoreKey), domainScope(domainName))
+}
+
+func (k Keeper) hasIdentityCommit(ctx sdk.Context, domainName, commitmentHex string) bool {
+	_, ok := domainCommits.lookup(ctx.KVStore(k.StoreKey), domainScope(domainName), commitmentHex)
+	return ok
+}
+
+// appendIdentityCommit stores a commitment and returns its leaf index.
+func (k Keeper) appendIdentityCommit(ctx sdk.Context, domainName, commitmentHex string) uint64 {
+	return domainCommits.put(ctx.KVStore(k.StoreKey), domainScope(domainName), commitmentHex, []byte(commitmentHex))
+}
+
+// GetIdentityCommits returns the domain's identity commitments in leaf order.
+func (k Keeper) GetIdentityCommits(ctx sdk.Context, domainName string) []string {
+	return domainCommits.strings(ctx.KVStore(k.StoreKey), domainScope(domainName))
+}
+
+func (k Keeper) clearIdentityCommits(ctx sdk.Context, domainName string) {
+	domainCommits.clear(ctx.KVStore(k.StoreKey), domainScope(domainName))
+	k.clearMerkleNodes(ctx, domainName)
+}
+
+// ---------- Issues ----------
+
+func (k Keeper) issueScope(ctx sdk.Context, domainName, issueName string) ([]byte, bool) {
+	scope := domainScope(domainName)
+	seq, ok := domainIssues.lookup(ctx.KVStore(k.StoreKey), scope, issueName)
+	if !ok {
+		return nil, false
+	}
+	return appendSeq(scope, seq), true
+}
+
+// GetIssue loads one issue without its suggestions.
+func (k Keeper) GetIssue(ctx sdk.Context, domainName, issueName string) (Issue, bool) {
+	_, bz, ok := domainIssues.get(ctx.KVStore(k.StoreKey), domainScope(domainName), issueName)
+	if !ok {
+		return Issue{}, false
+	}
+	var issue Issue
+	k.cdc.MustUnmarshalLengthPrefixed(bz, &issue)
+	return issue, true
+}
+
+// SetIssue creates or updates an issue record. Suggestions on the argument
+// are ignored; they are owned by their own records.
+func (k Keeper) SetIssue(ctx sdk.Context, domainName string, issue Issue) {
+	issue.Suggestions = nil
+	domainIssues.put(ctx.KVStore(k.StoreKey), domainScope(domainName), issue.Name, k.cdc.MustMarshalLengthPrefixed(&issue))
+}
+
+// IterateIssues iterates over a domain's issues, without suggestions, in
+// creation order.
+func (k Keeper) IterateIssues(ctx sdk.Context, domainName string, fn func(Issue) bool) {
+	domainIssues.iterate(ctx.KVStore(k.StoreKey), domainScope(domainName), func(_ uint64, value []byte) bool {
+		var issue Issue
+		k.cdc.MustUnmarshalLengthPrefixed(value, &issue)
+		return fn(issue)
+	})
+}
+
+// deleteIssue removes an issue together with its suggestions, ratings and
+// the nullifiers spent within it.
+// A recorded decision outlives the issue; pending closing schedules do not.
+func (k Keeper) deleteIssue(ctx sdk.Context, domainName, issueName string) {
+	store := ctx.KVStore(k.StoreKey)
+	scope, ok := k.issueScope(ctx, domainName, issueName)
+	if !ok {
+		return
+	}
+	if issue, found := k.GetIssue(ctx, domainName, issueName); found {
+		k.deleteIssueDecision(ctx, domainName, issue)
+	}
+	domainRatings.clear(store, scope)
+	domainSuggestions.clear(store, scope)
+	domainIssues.remove(store, domainScope(domainName), issueName)
+	k.pruneScopedNullifiers(ctx, domainName, issueName)
+	k.releaseSubDomainIssue(ctx, domainName, issueName)
+}
+
+// ---------- Suggestions ----------
+
+// GetSuggestion loads one suggestion without its ratings.
+func (k Keeper) GetSuggestion(ctx sdk.Context, domainName, issueName, suggestionName string) (Suggestion, bool) {
+	scope, ok := k.issueScope(ctx, domainName, issueName)
+	if !ok {
+		return Suggestion{}, false
+	}
+	_, bz, ok := domainSuggestions.get(ctx.KVStore(k.StoreKey), scope, suggestionName)
+	if !ok {
+		return Suggestion{}, false
+	}
+	var suggestion Suggestion
+	k.cdc.MustUnmarshalLengthPrefixed(bz, &suggestion)
+	return suggestion, true
+}
+
+// SetSuggestion creates or updates a suggestion record within an existing
+// issue. Ratings on the argument are ignored; they are append-only records.
+func (k Keeper) SetSuggestion(ctx sdk.Context, domainName, issueName string, suggestion Suggestion) {
+	scope, ok := k.issueScope(ctx, domainName, issueName)
+	if !ok {
+		panic("truedemocracy: suggestion written to unknown issue " + issueName)
+	}
+	suggestion.Ratings = nil
+	domainSuggestions.put(ctx.KVStore(k.StoreKey), scope, suggestion.Name, k.cdc.MustMarshalLengthPrefixed(&suggestion))
+}
+
+// IterateSuggestions iterates over an issue's suggestions, without ratings,
+// in submission order.
+func (k Keeper) IterateSuggestions(ctx sdk.Context, domainName, issueName string, fn func(Suggestion) bool) {
+	scope, ok := k.issueScope(ctx, domainName, issueName)
+	if !ok {
+		return
+	}
+	domainSuggestions.iterate(ctx.KVStore(k.StoreKey), scope, func(_ uint64, value []byte) bool {
+		var suggestion Suggestion
+		k.cdc.MustUnmarshalLengthPrefixed(value, &suggestion)
+		return fn(suggestion)
+	})
+}
+
+// deleteSuggestion removes a suggestion together with its ratings and their
+// nullifiers.
+func (k Keeper) deleteSuggestion(ctx sdk.Context, domainName, issueName, suggestionName string) {
+	store := ctx.KVStore(k.StoreKey)
+	scope, ok := k.issueScope(ctx, domainName, issueName)
+	if !ok {
+		return
+	}
+	seq, ok := domainSuggestions.remove(store, scope, suggestionName)
+	if !ok {
+		return
+	}
+	domainRatings.clear(store, appendSeq(scope, seq))
+	k.pruneScopedNullifiers(ctx, domainName, issueName, suggestionName)
+}
+
+// ---------- Ratings ----------
+
+func (k Keeper) suggestionScope(ctx sdk.Context, domainName, issueName, suggestionName string) ([]byte, bool) {
+	scope, ok := k.issueScope(ctx, domainName, issueName)
+	if !ok {
+		return nil, false
+	}
+	seq, ok := domainSuggestions.lookup(ctx.KVStore(k.StoreKey), scope, suggestionName)
+	if !ok {
+		return nil, false
+	}
+	return appendSeq(scope, seq), true
+}
+
+// GetSuggestionRatings returns a suggestion's ratings in submission order.
+func (k Keeper) GetSuggestionRatings(ctx sdk.Context, domainName, issueName, suggestionName string) []Rating {
+	scope, ok := k.suggestionScope(ctx, domainName, issueName, suggestionName)
+	if !ok {
+		return nil
+	}
+	var ratings []Rating
+	domainRatings.iterate(ctx.KVStore(k.StoreKey), scope, func(_ uint64, value []byte) bool {
+		var rating Rating
+		k.cdc.MustUnmarshalLengthPrefixed(value, &rating)
+		ratings = append(ratings, rating)
+		return false
+	})
+	return ratings
+}
+
+// hasDomainKeyRated is the indexed form of HasDomainKeyVoted.
+func (k Keeper) hasDomainKeyRated(ctx sdk.Context, domainName, issueName, suggestionName, domainPubKeyHex string) bool {
+	scope, ok := k.suggestionScope(ctx, domainName, issueName, suggestionName)
+	if !ok {
+		return false
+	}
+	_, found := domainRatings.lookup(ctx.KVStore(k.StoreKey), scope, ratingVoterID(Rating{DomainPubKeyHex: domainPubKeyHex}))
+	return found
+}
+
+// hasMemberRated reports whether the member has rated the suggestion openly.
+func (k Keeper) hasMemberRated(ctx sdk.Context, domainName, issueName, suggestionName, memberAddr string) bool {
+	scope, ok := k.suggestionScope(ctx, domainName, issueName, suggestionName)
+	if !ok {
+		return false
+	}
+	_, found := domainRatings.lookup(ctx.KVStore(k.StoreKey), scope, ratingVoterID(Rating{MemberAddr: memberAddr}))
+	return found
+}
+
+func (k Keeper) appendRating(ctx sdk.Context, domainName, issueName, suggestionName string, rating Rating) bool {
+	scope, ok := k.suggestionScope(ctx, domainName, issueName, suggestionName)
+	if !ok {
+		return false
+	}
+	domainRatings.put(ctx.KVStore(k.StoreKey), scope, ratingVoterID(rating), k.cdc.MustMarshalLengthPrefixed(&rating))
+	return true
+}
+
+// ---------- Store migration ----------
+
+// MigrateDomainEntities splits every legacy monolithic "domain:" blob into a
+// header and per-entity records. Headers that carry no inline lists are left
+// alone, so the migration is idempotent.
+func (k Keeper) MigrateDomainEntities(ctx sdk.Context) error {
+	var legacy []Domain
+	k.IterateDomainHeaders(ctx, func(d Domain) bool {
+		if len(d.Members) > 0 || len(d.Issues) > 0 || len(d.PermissionReg) > 0 || len(d.IdentityCommits) > 0 {
+			legacy = append(legacy, d)
+		}
+		return false
+	})
+	for _, domain := range legacy {
+		k.SetDomain(ctx, domain)
+	}
+	return nil
+}
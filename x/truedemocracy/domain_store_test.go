@@ -0,0 +1,145 @@
+package truedemocracy
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// buildLargeDomain returns a domain whose size is dominated by n issues with n
+// rated suggestions each, plus n extra members.
+func buildLargeDomain(name string, n int) Domain {
+	domain := Domain{
+		Name:     name,
+		Admin:    sdk.AccAddress("admin1"),
+		Members:  []string{sdk.AccAddress("admin1").String(), "alice"},
+		Treasury: sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 1_000_000)),
+	}
+	for i := 0; i < n; i++ {
+		domain.Members = append(domain.Members, fmt.Sprintf("member-%03d", i))
+		issue := Issue{Name: fmt.Sprintf("issue-%03d", i), CreationDate: 1}
+		for j := 0; j < n; j++ {
+			suggestion := Suggestion{Name: fmt.Sprintf("sugg-%03d", j), Creator: "alice", CreationDate: 1}
+			for r := 0; r < n; r++ {
+				suggestion.Ratings = append(suggestion.Ratings, Rating{DomainPubKeyHex: fmt.Sprintf("%064x", r), Value: 1})
+			}
+			issue.Suggestions = append(issue.Suggestions, suggestion)
+		}
+		domain.Issues = append(domain.Issues, issue)
+	}
+	return domain
+}
+
+func gasUsed(ctx sdk.Context, fn func(sdk.Context)) storetypes.Gas {
+	metered := ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	fn(metered)
+	return metered.GasMeter().GasConsumed()
+}
+
+func TestSetDomainRoundTripsEntityRecords(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	want := buildLargeDomain("Round:Trip", 3)
+	want.PermissionReg = []string{"aa", "bb"}
+	want.IdentityCommits = []string{"01", "02"}
+	want.MerkleRootHistory = []string{"ff"}
+	k.SetDomain(ctx, want)
+
+	got, found := k.GetDomain(ctx, "Round:Trip")
+	if !found {
+		t.Fatal("domain not found")
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, want)
+	}
+
+	header, _ := k.GetDomainHeader(ctx, "Round:Trip")
+	if header.Members != nil || header.Issues != nil || header.PermissionReg != nil || header.IdentityCommits != nil {
+		t.Fatalf("header carries inline lists: %+v", header)
+	}
+	if n := k.DomainMemberCount(ctx, "Round:Trip"); n != len(want.Members) {
+		t.Fatalf("member count = %d, want %d", n, len(want.Members))
+	}
+
+	// A shorter rewrite must drop stale entity records.
+	want.Issues = want.Issues[:1]
+	want.Members = want.Members[:2]
+	k.SetDomain(ctx, want)
+	got, _ = k.GetDomain(ctx, "Round:Trip")
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("rewrite mismatch:\n got %+v\nwant %+v", got, want)
+	}
+	if k.IsDomainMember(ctx, "Round:Trip", "member-002") {
+		t.Fatal("stale member index survived rewrite")
+	}
+}
+
+func TestDomainScopesDoNotCollide(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	k.CreateDomain(ctx, "a", sdk.AccAddress("admin1"), sdk.NewCoins())
+	k.CreateDomain(ctx, "a:b", sdk.AccAddress("admin2"), sdk.NewCoins())
+
+	if k.IsDomainMember(ctx, "a", sdk.AccAddress("admin2").String()) {
+		t.Fatal("member of a:b leaked into a")
+	}
+	if members := k.GetDomainMembers(ctx, "a"); len(members) != 1 {
+		t.Fatalf("domain a members = %v", members)
+	}
+}
+
+func TestHotPathGasIndependentOfDomainSize(t *testing.T) {
+	measure := func(n int) (stone, rating storetypes.Gas) {
+		k, ctx := setupKeeper(t)
+		k.SetDomain(ctx, buildLargeDomain("Gas", n))
+		k.addPermissionKey(ctx, "Gas", fmt.Sprintf("%064x", 999))
+
+		stone = gasUsed(ctx, func(ctx sdk.Context) {
+			if _, err := k.PlaceStoneOnSuggestion(ctx, "Gas", "issue-000", "sugg-000", "alice"); err != nil {
+				t.Fatal(err)
+			}
+		})
+		rating = gasUsed(ctx, func(ctx sdk.Context) {
+			if !k.recordRating(ctx, "Gas", "issue-000", "sugg-001", Rating{DomainPubKeyHex: fmt.Sprintf("%064x", 999), Value: 2}) {
+				t.Fatal("rating not recorded")
+			}
+		})
+		return stone, rating
+	}
+
+	smallStone, smallRating := measure(2)
+	largeStone, largeRating := measure(12)
+	if smallStone != largeStone {
+		t.Fatalf("stone gas grew with domain size: %d -> %d", smallStone, largeStone)
+	}
+	if smallRating != largeRating {
+		t.Fatalf("rating gas grew with domain size: %d -> %d", smallRating, largeRating)
+	}
+}
+
+func TestMigrateDomainEntitiesSplitsLegacyBlob(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	legacy := buildLargeDomain("Legacy", 2)
+	ctx.KVStore(k.StoreKey).Set(domainHeaderKey(legacy.Name), k.cdc.MustMarshalLengthPrefixed(&legacy))
+
+	if err := k.MigrateDomainEntities(ctx); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := k.GetDomain(ctx, "Legacy")
+	if !reflect.DeepEqual(got, legacy) {
+		t.Fatalf("migrated domain mismatch:\n got %+v\nwant %+v", got, legacy)
+	}
+	if !k.hasDomainKeyRated(ctx, "Legacy", "issue-001", "sugg-001", fmt.Sprintf("%064x", 1)) {
+		t.Fatal("migrated rating is not indexed")
+	}
+
+	// Re-running must not wipe the already split records.
+	if err := k.MigrateDomainEntities(ctx); err != nil {
+		t.Fatal(err)
+	}
+	again, _ := k.GetDomain(ctx, "Legacy")
+	if !reflect.DeepEqual(again, legacy) {
+		t.Fatal("second migration changed state")
+	}
+}
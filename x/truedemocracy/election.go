@@ -8,6 +8,7 @@ import (
 
 // Person election voting uses KV keys:
 //   "elecvote:{domainName}:{issueName}:{voterAddr}" → candidateName (or "ABSTAIN")
+//                                                    or 0x00 + RankedBallot
 //
 // This implements Whitepaper §3.7: voting modes for person elections.
 
@@ -20,17 +21,17 @@ func electionVoteKey(domainName, issueName, voterAddr string) []byte {
 // CastElectionVote records a vote (approve a candidate or abstain) in a person
 // election. Each member can vote for exactly one candidate per issue, or abstain.
 func (k Keeper) CastElectionVote(ctx sdk.Context, domainName, issueName, candidateName, voterAddr string, choice VoteChoice) error {
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
 
-	if !isMember(domain, voterAddr) {
+	if !k.IsDomainMember(ctx, domainName, voterAddr) {
 		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only domain members can vote")
 	}
 
-	issueIdx := findIssueIndex(domain, issueName)
-	if issueIdx == -1 {
+	issue, found := k.GetIssue(ctx, domainName, issueName)
+	if !found {
 		return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue not found")
 	}
 
@@ -49,7 +50,7 @@ func (k Keeper) CastElectionVote(ctx sdk.Context, domainName, issueName, candida
 			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "candidate name required for approve vote")
 		}
 		// Candidate must be a suggestion in the issue's suggestion list.
-		if findSuggestionIndex(domain.Issues[issueIdx], candidateName) == -1 {
+		if _, found := k.GetSuggestion(ctx, domainName, issueName, candidateName); !found {
 			return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "candidate not found in suggestion list")
 		}
 		store.Set(key, []byte(candidateName))
@@ -59,54 +60,60 @@ func (k Keeper) CastElectionVote(ctx sdk.Context, domainName, issueName, candida
 	}
 
 	// Update issue activity timestamp.
-	domain.Issues[issueIdx].LastActivityAt = ctx.BlockTime().Unix()
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	issue.LastActivityAt = ctx.BlockTime().Unix()
+	k.SetIssue(ctx, domainName, issue)
 	return nil
 }
 
 // ElectionResult holds the outcome of a person election tally.
 type ElectionResult struct {
 	Candidate string // winning candidate (empty if no winner)
-	Votes     int    // votes received by winner
+	Votes     int    // votes received by winner (final round for ranked choice, first preferences for multi-seat)
 	Total     int    // total votes cast (excl. abstentions for simple majority)
 	Abstained int    // number of explicit abstentions
 	Elected   bool   // whether a winner meets the threshold
+
+	// Ranked and multi-seat modes only. Candidates fixes the order of Rounds
+	// tallies and of both matrix axes.
+	Candidates []string      // issue suggestions in creation order
+	Rounds     []RunoffRound // instant-runoff / STV rounds; D'Hondt's single count
+	Pairwise   [][]int       // Schulze: Pairwise[i][j] ballots rank i above j
+	Strongest  [][]int       // Schulze: strongest path strength from i to j
+	Seats      []string      // multi-seat modes: elected seats in order, reported only
+	Quota      int           // STV: Droop quota in STVVoteUnit
 }
 
 // TallyElection evaluates an election for the given issue according to the
 // domain's VotingMode (WP §3.7). For VotingModeSystemicConsensing, the
 // standard rating-based scoring in §3.2 applies and this function is not used.
+// Ranked and multi-seat modes are tallied by tallyPreferenceElection;
+// plurality modes count the first preference of ranked ballots.
 func (k Keeper) TallyElection(ctx sdk.Context, domainName, issueName string) (ElectionResult, error) {
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return ElectionResult{}, errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
 
-	issueIdx := findIssueIndex(domain, issueName)
-	if issueIdx == -1 {
+	issue, found := k.GetIssue(ctx, domainName, issueName)
+	if !found {
 		return ElectionResult{}, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue not found")
 	}
+	mode := domain.Options.VotingMode
+	if issue.Seats > 1 && !mode.MultiSeat() {
+		return ElectionResult{}, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "issue elects %d seats but voting mode %d elects a single winner", issue.Seats, mode)
+	}
 
-	store := ctx.KVStore(k.StoreKey)
+	members := k.GetDomainMembers(ctx, domainName)
+	ballots, abstained := k.electionBallots(ctx, domainName, issueName, members)
+	if mode.Ranked() || mode.MultiSeat() {
+		return k.tallyPreferenceElection(ctx, mode, int(max(issue.Seats, 1)), domainName, issueName, ballots, abstained), nil
+	}
 
-	// Count votes per candidate and abstentions.
+	// Count first preferences per candidate.
 	candidateVotes := make(map[string]int)
-	abstained := 0
-	totalVoters := 0
-
-	for _, member := range domain.Members {
-		bz := store.Get(electionVoteKey(domainName, issueName, member))
-		if bz == nil {
-			continue // did not vote
-		}
-		totalVoters++
-		vote := string(bz)
-		if vote == abstainSentinel {
-			abstained++
-		} else {
-			candidateVotes[vote]++
-		}
+	totalVoters := len(ballots) + abstained
+	for _, ballot := range ballots {
+		candidateVotes[ballot[0]]++
 	}
 
 	// Find candidate with most votes.
@@ -136,7 +143,7 @@ func (k Keeper) TallyElection(ctx sdk.Context, domainName, issueName string) (El
 
 	case VotingModeAbsoluteMajority:
 		// >50% of all eligible members.
-		totalMembers := len(domain.Members)
+		totalMembers := len(members)
 		result.Total = totalMembers
 		if totalMembers > 0 && bestVotes*2 > totalMembers {
 			result.Elected = true
@@ -0,0 +1,192 @@
+package truedemocracy
+
+import (
+	"sort"
+
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Multi-seat elections only count: like a single-winner TallyElection, the
+// ordered Seats are reported to clients and nothing on chain acts on them.
+// Domain governance stays with Domain.Admin (ElectAdmin); seating a council
+// or co-admins from a result is not implemented.
+
+// STVVoteUnit is the weight of one full ballot in an STV count. Surplus
+// transfers scale ballot weights down in these units, truncating toward
+// zero, so every validator reaches the same count without fractions.
+const STVVoteUnit = 1_000_000
+
+// SetElectionSeats flags an open issue as an N-seat election. Like the
+// closing rule it is fixed once set, so the count cannot change mid-vote.
+func (k Keeper) SetElectionSeats(ctx sdk.Context, domainName, issueName string, seats int64) error {
+	if seats < 0 || seats > MaxElectionSeats {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "seats must be between 0 and %d", MaxElectionSeats)
+	}
+	if seats == 0 {
+		return nil
+	}
+	domain, found := k.GetDomainHeader(ctx, domainName)
+	if !found {
+		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
+	}
+	if seats > 1 && !domain.Options.VotingMode.MultiSeat() {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain voting mode elects a single winner")
+	}
+	issue, found := k.GetIssue(ctx, domainName, issueName)
+	if !found {
+		return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue not found")
+	}
+	if err := k.requireIssueOpen(ctx, domainName, issueName); err != nil {
+		return err
+	}
+	if issue.Seats != 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "issue already has a seat count")
+	}
+	issue.Seats = seats
+	k.SetIssue(ctx, domainName, issue)
+	return nil
+}
+
+// tallySTV fills seats by single transferable vote with a Droop quota. A
+// candidate reaching the quota is seated and the ballots counted for them
+// carry on to their next preference at weight × surplus / tally; otherwise
+// the weakest candidate is eliminated (ties drop the later candidate). Once
+// the continuing candidates just fill the open seats they are all seated in
+// tally order.
+func tallySTV(result *ElectionResult, prefs [][]int, seats int) {
+	n := len(result.Candidates)
+	if len(prefs) == 0 {
+		return
+	}
+	const (
+		continuing = iota
+		seated
+		eliminated
+	)
+	state := make([]int, n)
+	weights := make([]int, len(prefs))
+	for b := range weights {
+		weights[b] = STVVoteUnit
+	}
+	result.Quota = len(prefs)*STVVoteUnit/(seats+1) + 1
+
+	for len(result.Seats) < seats {
+		round := RunoffRound{Tallies: make([]int, n)}
+		assigned := make([]int, len(prefs))
+		for b, pref := range prefs {
+			assigned[b] = -1
+			for _, c := range pref {
+				if state[c] == continuing {
+					round.Tallies[c] += weights[b]
+					assigned[b] = c
+					break
+				}
+			}
+			if assigned[b] < 0 {
+				round.Exhausted += weights[b]
+			}
+		}
+
+		var open []int
+		for c := 0; c < n; c++ {
+			if state[c] == continuing {
+				open = append(open, c)
+			}
+		}
+		if len(open) == 0 {
+			break
+		}
+		sort.SliceStable(open, func(i, j int) bool { return round.Tallies[open[i]] > round.Tallies[open[j]] })
+
+		var elect []int
+		if len(open) <= seats-len(result.Seats) {
+			elect = open
+		} else {
+			for _, c := range open {
+				if round.Tallies[c] >= result.Quota && len(result.Seats)+len(elect) < seats {
+					elect = append(elect, c)
+				}
+			}
+		}
+		if len(elect) > 0 {
+			for _, c := range elect {
+				state[c] = seated
+				result.Seats = append(result.Seats, result.Candidates[c])
+				round.Elected = append(round.Elected, result.Candidates[c])
+				tally, surplus := round.Tallies[c], max(round.Tallies[c]-result.Quota, 0)
+				for b := range prefs {
+					if assigned[b] == c && tally > 0 {
+						weights[b] = weights[b] * surplus / tally
+					}
+				}
+			}
+			result.Rounds = append(result.Rounds, round)
+			continue
+		}
+
+		weakest := open[len(open)-1] // the stable sort leaves the later of tied candidates last
+		state[weakest] = eliminated
+		round.Eliminated = result.Candidates[weakest]
+		result.Rounds = append(result.Rounds, round)
+	}
+	finishMultiSeat(result, prefs, seats)
+}
+
+// tallyDHondt treats each suggestion as a list and allocates seats by
+// highest averages: the next seat goes to the list with the largest
+// votes / (seats won + 1). A list can win several seats, so Seats may repeat
+// a name. Ties favour more votes, then the earlier list.
+func tallyDHondt(result *ElectionResult, prefs [][]int, seats int) {
+	n := len(result.Candidates)
+	round := RunoffRound{Tallies: make([]int, n)}
+	for _, pref := range prefs {
+		if len(pref) == 0 {
+			round.Exhausted++
+			continue
+		}
+		round.Tallies[pref[0]]++
+	}
+	result.Rounds = []RunoffRound{round}
+
+	votes := round.Tallies
+	won := make([]int, n)
+	for len(result.Seats) < seats {
+		best := -1
+		for c := 0; c < n; c++ {
+			if votes[c] == 0 {
+				continue
+			}
+			if best < 0 {
+				best = c
+				continue
+			}
+			lhs, rhs := votes[c]*(won[best]+1), votes[best]*(won[c]+1)
+			if lhs > rhs || (lhs == rhs && votes[c] > votes[best]) {
+				best = c
+			}
+		}
+		if best < 0 {
+			break
+		}
+		won[best]++
+		result.Seats = append(result.Seats, result.Candidates[best])
+	}
+	finishMultiSeat(result, prefs, seats)
+}
+
+// finishMultiSeat reports the first seat as the headline winner with its
+// first-preference votes.
+func finishMultiSeat(result *ElectionResult, prefs [][]int, seats int) {
+	if len(result.Seats) == 0 {
+		return
+	}
+	result.Candidate = result.Seats[0]
+	for _, pref := range prefs {
+		if len(pref) > 0 && result.Candidates[pref[0]] == result.Candidate {
+			result.Votes++
+		}
+	}
+	result.Elected = len(result.Seats) == seats
+}
@@ -0,0 +1,157 @@
+package truedemocracy
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// setupCouncilDomain creates a domain with the given number of voters
+// (v0, v1, ...) and an issue "Council" with the candidates Ann, Ben, Cat and
+// Dan, flagged as a seats-seat election.
+func setupCouncilDomain(t *testing.T, k Keeper, ctx sdk.Context, mode VotingMode, voters int, seats int64) {
+	t.Helper()
+	domain := Domain{Name: "CouncilDomain", Admin: sdk.AccAddress("admin1"), Options: DomainOptions{VotingMode: mode}}
+	for i := 0; i < voters; i++ {
+		domain.Members = append(domain.Members, fmt.Sprintf("v%d", i))
+	}
+	issue := Issue{Name: "Council"}
+	for _, name := range []string{"Ann", "Ben", "Cat", "Dan"} {
+		issue.Suggestions = append(issue.Suggestions, Suggestion{Name: name, Creator: "v0"})
+	}
+	domain.Issues = []Issue{issue}
+	k.SetDomain(ctx, domain)
+	if err := k.SetElectionSeats(ctx, "CouncilDomain", "Council", seats); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetElectionSeats(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupCouncilDomain(t, k, ctx, VotingModeSTV, 3, 2)
+
+	issue, _ := k.GetIssue(ctx, "CouncilDomain", "Council")
+	if issue.Seats != 2 {
+		t.Fatalf("seats = %d, want 2", issue.Seats)
+	}
+	if err := k.SetElectionSeats(ctx, "CouncilDomain", "Council", 3); err == nil {
+		t.Fatal("seat count changed after it was set")
+	}
+	if err := k.SetElectionSeats(ctx, "CouncilDomain", "Council", MaxElectionSeats+1); err == nil {
+		t.Fatal("seat count above the maximum accepted")
+	}
+
+	k2, ctx2 := setupKeeper(t)
+	setupElectionDomain(t, k2, ctx2, VotingModeRankedChoice, true)
+	if err := k2.SetElectionSeats(ctx2, "ElecDomain", "BoardChair", 2); err == nil {
+		t.Fatal("multi-seat issue accepted in a single-winner domain")
+	}
+
+	// An issue flagged outside the keeper still refuses a single-winner tally.
+	issue, _ = k2.GetIssue(ctx2, "ElecDomain", "BoardChair")
+	issue.Seats = 2
+	k2.SetIssue(ctx2, "ElecDomain", issue)
+	if _, err := k2.TallyElection(ctx2, "ElecDomain", "BoardChair"); err == nil {
+		t.Fatal("multi-seat issue tallied by a single-winner mode")
+	}
+
+	// The msg server only accepts a seat count from the proposal that opens the issue.
+	msg := &MsgSubmitProposal{
+		Sender: sdk.AccAddress("v0"), DomainName: "CouncilDomain", IssueName: "Council",
+		SuggestionName: "Eve", Creator: "v0", Seats: 2,
+	}
+	if _, err := NewMsgServer(k).SubmitProposal(ctx, msg); err == nil {
+		t.Fatal("seat count accepted on an existing issue")
+	}
+}
+
+func TestTallySTV(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupCouncilDomain(t, k, ctx, VotingModeSTV, 9, 2)
+	ballots := map[string][]string{}
+	for i := 0; i < 5; i++ {
+		ballots[fmt.Sprintf("v%d", i)] = []string{"Ann", "Ben"}
+	}
+	ballots["v5"], ballots["v6"] = []string{"Cat", "Dan"}, []string{"Cat", "Dan"}
+	ballots["v7"], ballots["v8"] = []string{"Dan", "Cat"}, []string{"Dan", "Cat"}
+	for voter, ranking := range ballots {
+		if err := k.CastRankedElectionVote(ctx, "CouncilDomain", "Council", voter, ranking); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := k.TallyElection(ctx, "CouncilDomain", "Council")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Elected || !reflect.DeepEqual(result.Seats, []string{"Ann", "Cat"}) {
+		t.Fatalf("seats = %v (elected %v)", result.Seats, result.Elected)
+	}
+	if result.Candidate != "Ann" || result.Votes != 5 || result.Quota != 3*STVVoteUnit+1 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	// Ann's surplus of 1,999,999 units moves to Ben at 399,999 units a ballot;
+	// the truncated remainder is not carried.
+	const transferred = 5 * 399_999
+	want := []RunoffRound{
+		{Tallies: []int{5 * STVVoteUnit, 0, 2 * STVVoteUnit, 2 * STVVoteUnit}, Elected: []string{"Ann"}},
+		{Tallies: []int{0, transferred, 2 * STVVoteUnit, 2 * STVVoteUnit}, Eliminated: "Ben"},
+		{Tallies: []int{0, 0, 2 * STVVoteUnit, 2 * STVVoteUnit}, Exhausted: transferred, Eliminated: "Dan"},
+		{Tallies: []int{0, 0, 4 * STVVoteUnit, 0}, Exhausted: transferred, Elected: []string{"Cat"}},
+	}
+	if !reflect.DeepEqual(result.Rounds, want) {
+		t.Fatalf("rounds = %+v\nwant %+v", result.Rounds, want)
+	}
+}
+
+func TestTallyDHondt(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupCouncilDomain(t, k, ctx, VotingModeDHondt, 11, 3)
+	votes := []string{"Ann", "Ann", "Ann", "Ann", "Ann", "Ann", "Ben", "Ben", "Ben", "Cat", "Cat"}
+	for i, candidate := range votes {
+		if err := k.CastElectionVote(ctx, "CouncilDomain", "Council", candidate, fmt.Sprintf("v%d", i), VoteChoiceApprove); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := k.CastRankedElectionVote(ctx, "CouncilDomain", "Council", "v0", []string{"Ann"}); err == nil {
+		t.Fatal("ranked ballot accepted in a D'Hondt domain")
+	}
+
+	result, err := k.TallyElection(ctx, "CouncilDomain", "Council")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Quotients: Ann 6, 3, 2; Ben 3, 1.5; Cat 2. The 3–3 tie goes to more votes.
+	if !result.Elected || !reflect.DeepEqual(result.Seats, []string{"Ann", "Ann", "Ben"}) {
+		t.Fatalf("seats = %v (elected %v)", result.Seats, result.Elected)
+	}
+	if !reflect.DeepEqual(result.Rounds[0].Tallies, []int{6, 3, 2, 0}) || result.Votes != 6 {
+		t.Fatalf("result = %+v", result)
+	}
+}
+
+func TestTallyMultiSeatUnfilled(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupCouncilDomain(t, k, ctx, VotingModeDHondt, 3, 2)
+
+	result, err := k.TallyElection(ctx, "CouncilDomain", "Council")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Elected || len(result.Seats) != 0 {
+		t.Fatalf("empty election result = %+v", result)
+	}
+
+	k2, ctx2 := setupKeeper(t)
+	setupCouncilDomain(t, k2, ctx2, VotingModeSTV, 3, 2)
+	result, err = k2.TallyElection(ctx2, "CouncilDomain", "Council")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Elected || len(result.Seats) != 0 {
+		t.Fatalf("empty STV result = %+v", result)
+	}
+}
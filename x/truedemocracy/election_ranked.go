@@ -0,0 +1,313 @@
+package truedemocracy
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Ranked ballots share the "elecvote:" key of single-candidate votes, so a
+// member holds exactly one ballot per election whichever message cast it.
+// The value is rankedBallotMarker followed by the amino-encoded RankedBallot;
+// legacy values remain a bare candidate name or the abstain sentinel.
+
+const rankedBallotMarker = 0x00
+
+// RankedBallot is a member's full preference order, most preferred first.
+// Candidates left off the ballot rank below every listed candidate.
+type RankedBallot struct {
+	Ranking []string `json:"ranking"`
+}
+
+// RunoffRound is one instant-runoff or STV counting round. STV counts in
+// STVVoteUnit so surplus transfers stay integral.
+type RunoffRound struct {
+	Tallies    []int    // continuing votes per ElectionResult.Candidates entry
+	Exhausted  int      // ballots without a continuing preference
+	Eliminated string   // candidate dropped after this round; empty in the final round
+	Elected    []string // STV: candidates seated after this round
+}
+
+// CastRankedElectionVote records a member's preference order in a
+// ranked-choice, Schulze or STV election, replacing any earlier ballot.
+func (k Keeper) CastRankedElectionVote(ctx sdk.Context, domainName, issueName, voterAddr string, ranking []string) error {
+	domain, found := k.GetDomainHeader(ctx, domainName)
+	if !found {
+		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
+	}
+	if !domain.Options.VotingMode.Ranked() {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain does not use a ranked voting mode")
+	}
+	if !k.IsDomainMember(ctx, domainName, voterAddr) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only domain members can vote")
+	}
+	issue, found := k.GetIssue(ctx, domainName, issueName)
+	if !found {
+		return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue not found")
+	}
+	if err := validateRanking(ranking); err != nil {
+		return err
+	}
+	for _, candidate := range ranking {
+		if _, found := k.GetSuggestion(ctx, domainName, issueName, candidate); !found {
+			return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "candidate %s not found in suggestion list", candidate)
+		}
+	}
+
+	ballot := RankedBallot{Ranking: ranking}
+	bz := append([]byte{rankedBallotMarker}, k.cdc.MustMarshal(&ballot)...)
+	ctx.KVStore(k.StoreKey).Set(electionVoteKey(domainName, issueName, voterAddr), bz)
+
+	issue.LastActivityAt = ctx.BlockTime().Unix()
+	k.SetIssue(ctx, domainName, issue)
+	return nil
+}
+
+// validateRanking rejects empty ballots and blank or repeated candidates.
+func validateRanking(ranking []string) error {
+	if len(ranking) == 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "ranking must list at least one candidate")
+	}
+	seen := make(map[string]bool, len(ranking))
+	for _, candidate := range ranking {
+		if candidate == "" {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "ranking contains an empty candidate")
+		}
+		if seen[candidate] {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "candidate %s ranked twice", candidate)
+		}
+		seen[candidate] = true
+	}
+	return nil
+}
+
+// GetElectionBallot returns a member's ballot as a preference order. A
+// single-candidate vote reads as a one-entry ranking; abstentions report
+// abstained with a nil ranking.
+func (k Keeper) GetElectionBallot(ctx sdk.Context, domainName, issueName, voterAddr string) (ranking []string, abstained, found bool) {
+	return k.decodeElectionBallot(ctx.KVStore(k.StoreKey).Get(electionVoteKey(domainName, issueName, voterAddr)))
+}
+
+// decodeElectionBallot reads a stored ballot value of either encoding.
+func (k Keeper) decodeElectionBallot(bz []byte) (ranking []string, abstained, found bool) {
+	switch {
+	case bz == nil:
+		return nil, false, false
+	case string(bz) == abstainSentinel:
+		return nil, true, true
+	case bz[0] == rankedBallotMarker:
+		var ballot RankedBallot
+		k.cdc.MustUnmarshal(bz[1:], &ballot)
+		return ballot.Ranking, false, true
+	default:
+		return []string{string(bz)}, false, true
+	}
+}
+
+// electionBallots reads the ballots of the given members in member order,
+// then a copy of the delegate's ballot (or abstention) for every member whose
+// delegation reaches a voter, then the anonymous ballots of the election.
+func (k Keeper) electionBallots(ctx sdk.Context, domainName, issueName string, members []string) (ballots [][]string, abstained int) {
+	type ballot struct {
+		ranking []string
+		abstain bool
+	}
+	cast := make(map[string]ballot)
+	count := func(b ballot) {
+		if b.abstain {
+			abstained++
+		} else {
+			ballots = append(ballots, b.ranking)
+		}
+	}
+	for _, member := range members {
+		if ranking, abstain, found := k.GetElectionBallot(ctx, domainName, issueName, member); found {
+			cast[member] = ballot{ranking: ranking, abstain: abstain}
+			count(cast[member])
+		}
+	}
+	voted := func(m string) bool {
+		_, found := cast[m]
+		return found
+	}
+	k.forEachDelegatedVote(ctx, domainName, issueName, voted, func(_, holder string) {
+		count(cast[holder])
+	})
+	anonymous, anonAbstained := k.anonymousElectionBallots(ctx, domainName, issueName)
+	return append(ballots, anonymous...), abstained + anonAbstained
+}
+
+// tallyPreferenceElection evaluates ballots against the issue's current
+// suggestions. Preferences for candidates that no longer exist are skipped.
+func (k Keeper) tallyPreferenceElection(ctx sdk.Context, mode VotingMode, seats int, domainName, issueName string, ballots [][]string, abstained int) ElectionResult {
+	var candidates []string
+	k.IterateSuggestions(ctx, domainName, issueName, func(s Suggestion) bool {
+		candidates = append(candidates, s.Name)
+		return false
+	})
+	index := make(map[string]int, len(candidates))
+	for i, name := range candidates {
+		index[name] = i
+	}
+	var prefs [][]int
+	for _, ballot := range ballots {
+		var pref []int
+		for _, name := range ballot {
+			if i, ok := index[name]; ok {
+				pref = append(pref, i)
+			}
+		}
+		prefs = append(prefs, pref)
+	}
+
+	result := ElectionResult{
+		Total:      len(ballots),
+		Abstained:  abstained,
+		Candidates: candidates,
+	}
+	switch mode {
+	case VotingModeSchulze:
+		tallySchulze(&result, prefs)
+	case VotingModeSTV:
+		tallySTV(&result, prefs, seats)
+	case VotingModeDHondt:
+		tallyDHondt(&result, prefs, seats)
+	default:
+		tallyInstantRunoff(&result, prefs)
+	}
+	return result
+}
+
+// tallyInstantRunoff counts each ballot for its highest continuing
+// preference and drops the weakest candidate until one holds a majority of
+// the continuing ballots. Ties for elimination drop the later candidate,
+// ties for the lead favour the earlier one, so the result is deterministic.
+func tallyInstantRunoff(result *ElectionResult, prefs [][]int) {
+	n := len(result.Candidates)
+	eliminated := make([]bool, n)
+	for remaining := n; remaining > 0; remaining-- {
+		round := RunoffRound{Tallies: make([]int, n)}
+		for _, pref := range prefs {
+			counted := false
+			for _, c := range pref {
+				if !eliminated[c] {
+					round.Tallies[c]++
+					counted = true
+					break
+				}
+			}
+			if !counted {
+				round.Exhausted++
+			}
+		}
+
+		leader, weakest := -1, -1
+		for c := 0; c < n; c++ {
+			if eliminated[c] {
+				continue
+			}
+			if leader < 0 || round.Tallies[c] > round.Tallies[leader] {
+				leader = c
+			}
+			if weakest < 0 || round.Tallies[c] <= round.Tallies[weakest] {
+				weakest = c
+			}
+		}
+		active := len(prefs) - round.Exhausted
+		if active == 0 {
+			result.Rounds = append(result.Rounds, round)
+			return
+		}
+		if round.Tallies[leader]*2 > active {
+			result.Rounds = append(result.Rounds, round)
+			result.Candidate = result.Candidates[leader]
+			result.Votes = round.Tallies[leader]
+			result.Elected = true
+			return
+		}
+		round.Eliminated = result.Candidates[weakest]
+		eliminated[weakest] = true
+		result.Rounds = append(result.Rounds, round)
+	}
+}
+
+// tallySchulze builds the pairwise preference matrix, computes strongest
+// paths with the widest-path variant of Floyd–Warshall, and elects the
+// candidate no other candidate beats. Several unbeaten candidates are a tie:
+// the earliest is reported but not elected.
+func tallySchulze(result *ElectionResult, prefs [][]int) {
+	n := len(result.Candidates)
+	d := make([][]int, n)
+	p := make([][]int, n)
+	for i := range d {
+		d[i] = make([]int, n)
+		p[i] = make([]int, n)
+	}
+
+	rank := make([]int, n)
+	for _, pref := range prefs {
+		for c := range rank {
+			rank[c] = n // unranked candidates share the last place
+		}
+		for pos, c := range pref {
+			rank[c] = pos
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if rank[i] < rank[j] {
+					d[i][j]++
+				}
+			}
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j && d[i][j] > d[j][i] {
+				p[i][j] = d[i][j]
+			}
+		}
+	}
+	for m := 0; m < n; m++ {
+		for i := 0; i < n; i++ {
+			if i == m {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if j == i || j == m {
+					continue
+				}
+				if via := min(p[i][m], p[m][j]); via > p[i][j] {
+					p[i][j] = via
+				}
+			}
+		}
+	}
+	result.Pairwise = d
+	result.Strongest = p
+
+	var winners []int
+	for i := 0; i < n; i++ {
+		unbeaten := true
+		for j := 0; j < n; j++ {
+			if i != j && p[j][i] > p[i][j] {
+				unbeaten = false
+				break
+			}
+		}
+		if unbeaten {
+			winners = append(winners, i)
+		}
+	}
+	if len(prefs) == 0 || len(winners) == 0 {
+		return
+	}
+	winner := winners[0]
+	result.Candidate = result.Candidates[winner]
+	for _, pref := range prefs {
+		if len(pref) > 0 && pref[0] == winner {
+			result.Votes++
+		}
+	}
+	result.Elected = len(winners) == 1
+}
@@ -0,0 +1,242 @@
+package truedemocracy
+
+import (
+	"reflect"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+)
+
+// castRankedBallots records one ranked ballot per member.
+func castRankedBallots(t *testing.T, k Keeper, ctx sdk.Context, ballots map[string][]string) {
+	t.Helper()
+	for voter, ranking := range ballots {
+		if err := k.CastRankedElectionVote(ctx, "ElecDomain", "BoardChair", voter, ranking); err != nil {
+			t.Fatalf("ballot of %s: %v", voter, err)
+		}
+	}
+}
+
+// condorcetBallots make Bob the Condorcet winner although he has the fewest
+// first preferences, so instant-runoff eliminates him first.
+var condorcetBallots = map[string][]string{
+	"alice":   {"Alice", "Bob", "Charlie"},
+	"bob":     {"Alice", "Bob", "Charlie"},
+	"charlie": {"Charlie", "Bob", "Alice"},
+	"dave":    {"Charlie", "Bob", "Alice"},
+	"eve":     {"Bob", "Charlie", "Alice"},
+}
+
+// ---------- CastRankedElectionVote ----------
+
+func TestCastRankedElectionVote(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupElectionDomain(t, k, ctx, VotingModeRankedChoice, true)
+
+	if err := k.CastRankedElectionVote(ctx, "ElecDomain", "BoardChair", "alice", []string{"Bob", "Alice"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ranking, abstained, found := k.GetElectionBallot(ctx, "ElecDomain", "BoardChair", "alice")
+	if !found || abstained || !reflect.DeepEqual(ranking, []string{"Bob", "Alice"}) {
+		t.Fatalf("stored ballot = %v (abstained %v, found %v)", ranking, abstained, found)
+	}
+
+	// A single-candidate vote replaces the ballot and reads as a one-entry ranking.
+	if err := k.CastElectionVote(ctx, "ElecDomain", "BoardChair", "Charlie", "alice", VoteChoiceApprove); err != nil {
+		t.Fatal(err)
+	}
+	if ranking, _, _ := k.GetElectionBallot(ctx, "ElecDomain", "BoardChair", "alice"); !reflect.DeepEqual(ranking, []string{"Charlie"}) {
+		t.Fatalf("single vote ballot = %v", ranking)
+	}
+
+	rejected := map[string]struct {
+		voter   string
+		ranking []string
+	}{
+		"empty ballot":      {"bob", nil},
+		"duplicate":         {"bob", []string{"Alice", "Alice"}},
+		"unknown candidate": {"bob", []string{"Alice", "Zed"}},
+		"non-member":        {"outsider", []string{"Alice"}},
+	}
+	for name, tc := range rejected {
+		if err := k.CastRankedElectionVote(ctx, "ElecDomain", "BoardChair", tc.voter, tc.ranking); err == nil {
+			t.Errorf("%s: ballot accepted", name)
+		}
+	}
+
+	k2, ctx2 := setupKeeper(t)
+	setupElectionDomain(t, k2, ctx2, VotingModeSimpleMajority, true)
+	if err := k2.CastRankedElectionVote(ctx2, "ElecDomain", "BoardChair", "alice", []string{"Alice"}); err == nil {
+		t.Fatal("ranked ballot accepted in a simple-majority domain")
+	}
+}
+
+func TestMsgCastRankedElectionVoteValidationAndEncoding(t *testing.T) {
+	voter := sdk.AccAddress("ranked-voter")
+	msg := MsgCastRankedElectionVote{
+		Sender:     voter,
+		DomainName: "ElecDomain",
+		IssueName:  "BoardChair",
+		VoterAddr:  voter.String(),
+		Ranking:    []string{"Bob", "Alice"},
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		t.Fatalf("valid message rejected: %v", err)
+	}
+	if bz, indexes := msg.Descriptor(); len(bz) == 0 || len(indexes) == 0 {
+		t.Fatal("message descriptor missing")
+	}
+	bz, err := gogoproto.Marshal(&msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded MsgCastRankedElectionVote
+	if err := gogoproto.Unmarshal(bz, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded.Ranking, msg.Ranking) {
+		t.Fatalf("decoded ranking = %v", decoded.Ranking)
+	}
+
+	msg.Ranking = []string{"Bob", "Bob"}
+	if err := msg.ValidateBasic(); err == nil {
+		t.Fatal("duplicate ranking accepted")
+	}
+	msg.Ranking = []string{"Bob"}
+	msg.VoterAddr = sdk.AccAddress("someone-else").String()
+	if err := msg.ValidateBasic(); err == nil {
+		t.Fatal("spoofed voter accepted")
+	}
+}
+
+// ---------- TallyElection: Ranked Choice ----------
+
+func TestTallyRankedChoice(t *testing.T) {
+	t.Run("transfers eliminated preferences", func(t *testing.T) {
+		k, ctx := setupKeeper(t)
+		setupElectionDomain(t, k, ctx, VotingModeRankedChoice, true)
+		castRankedBallots(t, k, ctx, condorcetBallots)
+
+		result, err := k.TallyElection(ctx, "ElecDomain", "BoardChair")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.Elected || result.Candidate != "Charlie" || result.Votes != 3 || result.Total != 5 {
+			t.Fatalf("result = %+v", result)
+		}
+		want := []RunoffRound{
+			{Tallies: []int{2, 1, 2}, Eliminated: "Bob"},
+			{Tallies: []int{2, 0, 3}},
+		}
+		if !reflect.DeepEqual(result.Rounds, want) {
+			t.Fatalf("rounds = %+v, want %+v", result.Rounds, want)
+		}
+	})
+
+	t.Run("exhausted ballots leave the count", func(t *testing.T) {
+		k, ctx := setupKeeper(t)
+		setupElectionDomain(t, k, ctx, VotingModeRankedChoice, true)
+		castRankedBallots(t, k, ctx, map[string][]string{
+			"alice":   {"Bob"},
+			"bob":     {"Alice"},
+			"charlie": {"Alice"},
+			"dave":    {"Charlie"},
+			"eve":     {"Charlie", "Alice"},
+		})
+		if err := k.CastElectionVote(ctx, "ElecDomain", "BoardChair", "", "eve", VoteChoiceAbstain); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := k.TallyElection(ctx, "ElecDomain", "BoardChair")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Round 1: Alice 2, Bob 1, Charlie 1 of 4 → Charlie (later tie) out.
+		// Round 2: dave is exhausted; Alice 2 of 3 continuing ballots wins.
+		if !result.Elected || result.Candidate != "Alice" || result.Abstained != 1 || len(result.Rounds) != 2 {
+			t.Fatalf("result = %+v", result)
+		}
+		if last := result.Rounds[1]; last.Exhausted != 1 || last.Tallies[0] != 2 {
+			t.Fatalf("final round = %+v", last)
+		}
+	})
+
+	t.Run("no ballots", func(t *testing.T) {
+		k, ctx := setupKeeper(t)
+		setupElectionDomain(t, k, ctx, VotingModeRankedChoice, true)
+		result, err := k.TallyElection(ctx, "ElecDomain", "BoardChair")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Elected || result.Candidate != "" {
+			t.Fatalf("result = %+v", result)
+		}
+	})
+}
+
+// ---------- TallyElection: Schulze ----------
+
+func TestTallySchulze(t *testing.T) {
+	t.Run("elects the Condorcet winner", func(t *testing.T) {
+		k, ctx := setupKeeper(t)
+		setupElectionDomain(t, k, ctx, VotingModeSchulze, true)
+		castRankedBallots(t, k, ctx, condorcetBallots)
+
+		result, err := k.TallyElection(ctx, "ElecDomain", "BoardChair")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.Elected || result.Candidate != "Bob" || result.Votes != 1 {
+			t.Fatalf("result = %+v", result)
+		}
+		wantPairwise := [][]int{
+			{0, 2, 2},
+			{3, 0, 3},
+			{3, 2, 0},
+		}
+		if !reflect.DeepEqual(result.Candidates, []string{"Alice", "Bob", "Charlie"}) ||
+			!reflect.DeepEqual(result.Pairwise, wantPairwise) {
+			t.Fatalf("pairwise = %v over %v", result.Pairwise, result.Candidates)
+		}
+		wantStrongest := [][]int{
+			{0, 0, 0},
+			{3, 0, 3},
+			{3, 0, 0},
+		}
+		if !reflect.DeepEqual(result.Strongest, wantStrongest) {
+			t.Fatalf("strongest = %v", result.Strongest)
+		}
+	})
+
+	t.Run("unranked candidates rank last", func(t *testing.T) {
+		k, ctx := setupKeeper(t)
+		setupElectionDomain(t, k, ctx, VotingModeSchulze, true)
+		castRankedBallots(t, k, ctx, map[string][]string{"alice": {"Charlie"}})
+
+		result, err := k.TallyElection(ctx, "ElecDomain", "BoardChair")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.Elected || result.Candidate != "Charlie" || result.Pairwise[0][1] != 0 {
+			t.Fatalf("result = %+v", result)
+		}
+	})
+
+	t.Run("tie is not elected", func(t *testing.T) {
+		k, ctx := setupKeeper(t)
+		setupElectionDomain(t, k, ctx, VotingModeSchulze, true)
+		castRankedBallots(t, k, ctx, map[string][]string{
+			"alice": {"Alice"},
+			"bob":   {"Bob"},
+		})
+
+		result, err := k.TallyElection(ctx, "ElecDomain", "BoardChair")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Elected || result.Candidate != "Alice" {
+			t.Fatalf("result = %+v", result)
+		}
+	})
+}
@@ -29,9 +29,7 @@ func setupElectionDomain(t *testing.T, k Keeper, ctx sdk.Context, mode VotingMod
 		},
 	}
 
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:ElecDomain"), bz)
+	k.SetDomain(ctx, domain)
 }
 
 // ---------- CastElectionVote ----------
@@ -55,7 +55,7 @@ func (k Keeper) CreateDomainWithEscrow(ctx sdk.Context, name string, admin sdk.A
 	if err := validatePNYXCoins(initialCoins, "initial coins"); err != nil {
 		return err
 	}
-	if _, found := k.GetDomain(ctx, name); found {
+	if _, found := k.GetDomainHeader(ctx, name); found {
 		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "domain %s already exists", name)
 	}
 
@@ -196,6 +196,8 @@ func (k Keeper) RemoveValidatorWithEscrow(ctx sdk.Context, sender sdk.AccAddress
 		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "validator stake is invalid")
 	}
 
+	// Delegations enter holds of their own when the validator is removed.
+	validator.DelegatedStake = 0
 	removal, err := newPendingValidatorRemoval(ctx, validator, sender.String())
 	if err != nil {
 		return err
@@ -260,6 +262,23 @@ func (k Keeper) PlaceStoneOnSuggestionWithPayout(
 	})
 }
 
+// RateProposalOpenlyWithPayout records an open rating and atomically pays the
+// RateToEarn reward to the rating member, who must be the signer.
+func (k Keeper) RateProposalOpenlyWithPayout(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	domainName, issueName, suggestionName string,
+	rating int,
+	memberAddr string,
+) (sdk.Coins, error) {
+	if err := requireSignerClaim(sender, memberAddr, "member address"); err != nil {
+		return nil, err
+	}
+	return k.executeRewardPayout(ctx, sender, func(cacheCtx sdk.Context) (sdk.Coins, error) {
+		return k.RateProposalOpenly(cacheCtx, domainName, issueName, suggestionName, rating, memberAddr)
+	})
+}
+
 // executeDeferredAnonymousReward was the GH-13/GH-7 stopgap: it recorded the
 // anonymous rating but restored the reward to treasury because neither path
 // bound a bank recipient. GH-209 replaces it with an atomic treasury-funded
@@ -368,23 +387,27 @@ func (k Keeper) RateProposalWithZKPPayout(
 }
 
 // EscrowClaims returns the aggregate upnyx claims held in domain treasuries,
-// active validator stake records, and evidence-window exit holds. Reward
-// issuance must fund this same escrow.
+// active validator stake records and their delegations, evidence-window
+// exit and undelegation holds, and outstanding staking rewards. Reward issuance must fund this same escrow.
 func (k Keeper) EscrowClaims(ctx sdk.Context) math.Int {
 	claims := math.ZeroInt()
-	k.IterateDomains(ctx, func(domain Domain) bool {
+	k.IterateDomainHeaders(ctx, func(domain Domain) bool {
 		claims = claims.Add(domain.Treasury.AmountOf(PNYXDenom))
 		return false
 	})
 	k.IterateValidators(ctx, func(validator Validator) bool {
-		claims = claims.Add(validator.Stake.AmountOf(PNYXDenom))
+		claims = claims.Add(validatorBondedStake(validator))
 		return false
 	})
 	k.IteratePendingValidatorRemovals(ctx, func(removal PendingValidatorRemoval) bool {
 		claims = claims.Add(removal.Validator.Stake.AmountOf(PNYXDenom))
 		return false
 	})
-	return claims
+	k.IteratePendingUndelegations(ctx, func(hold PendingUndelegation) bool {
+		claims = claims.AddRaw(hold.Amount)
+		return false
+	})
+	return claims.Add(k.getStakingRewardPool(ctx).Outstanding)
 }
 
 func (k Keeper) ValidateEscrowParity(ctx sdk.Context) error {
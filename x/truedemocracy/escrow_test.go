@@ -31,8 +31,7 @@ func withEvidenceWindow(ctx sdk.Context, maxAgeBlocks int64, maxAgeDuration time
 
 func saveDomain(t *testing.T, keeper Keeper, ctx sdk.Context, domain Domain) {
 	t.Helper()
-	store := ctx.KVStore(keeper.StoreKey)
-	store.Set([]byte("domain:"+domain.Name), keeper.cdc.MustMarshalLengthPrefixed(&domain))
+	keeper.SetDomain(ctx, domain)
 }
 
 func backExistingEscrow(keeper *Keeper, ctx sdk.Context) *mockBankKeeper {
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"slices"
 
 	"cosmossdk.io/core/comet"
 	"cosmossdk.io/math"
@@ -45,6 +46,9 @@ func ValidateGenesisState(genesis GenesisState) error {
 		}
 		domains[domain.Name] = domain
 	}
+	if err := validateGenesisSubDomains(domains); err != nil {
+		return err
+	}
 
 	operators := make(map[string]struct{}, len(genesis.Validators))
 	activeValidators := make(map[string]GenesisValidator, len(genesis.Validators))
@@ -78,6 +82,12 @@ func ValidateGenesisState(genesis GenesisState) error {
 			(!validator.Jailed && validator.JailedUntil != 0) {
 			return fmt.Errorf("validator %q jail or liveness state is invalid", validator.OperatorAddr)
 		}
+		if validator.DelegatedStake < 0 || validator.BondedStake() < validator.Stake {
+			return fmt.Errorf("validator %q delegated stake %d is invalid", validator.OperatorAddr, validator.DelegatedStake)
+		}
+		if err := validateCommissionBps(validator.CommissionBps); err != nil {
+			return fmt.Errorf("validator %q: %w", validator.OperatorAddr, err)
+		}
 		validatorDomains, power, active, err := resolveGenesisValidator(validator)
 		if err != nil {
 			return err
@@ -107,13 +117,16 @@ func ValidateGenesisState(genesis GenesisState) error {
 			}
 		} else if active {
 			// An explicit active record retains the strict rules: positive
-			// stake-derived power, no jail state, and membership in every
-			// listed domain.
+			// power derived from the bonded stake, an own stake meeting the
+			// minimum, no jail state, and membership in every listed domain.
 			if validator.Jailed || power <= 0 {
 				return fmt.Errorf("validator %q active flag contradicts its jail or power state", validator.OperatorAddr)
 			}
-			if power != validator.Stake/rewards.StakeMin {
-				return fmt.Errorf("validator %q power %d is inconsistent with stake %d", validator.OperatorAddr, power, validator.Stake)
+			if power != validator.BondedStake()/rewards.StakeMin {
+				return fmt.Errorf("validator %q power %d is inconsistent with stake %d", validator.OperatorAddr, power, validator.BondedStake())
+			}
+			if validator.Stake < rewards.StakeMin {
+				return fmt.Errorf("validator %q stake %d is below minimum %d", validator.OperatorAddr, validator.Stake, rewards.StakeMin)
 			}
 			if len(validatorDomains) == 0 {
 				return fmt.Errorf("validator %q active flag requires at least one domain", validator.OperatorAddr)
@@ -129,8 +142,8 @@ func ValidateGenesisState(genesis GenesisState) error {
 			// and its stored power must be either zero or exactly
 			// stake-derived. Unjailed positive power would contradict the
 			// explicit inactive classification.
-			if power < 0 || (power != 0 && power != validator.Stake/rewards.StakeMin) {
-				return fmt.Errorf("validator %q power %d is inconsistent with stake %d", validator.OperatorAddr, power, validator.Stake)
+			if power < 0 || (power != 0 && power != validator.BondedStake()/rewards.StakeMin) {
+				return fmt.Errorf("validator %q power %d is inconsistent with stake %d", validator.OperatorAddr, power, validator.BondedStake())
 			}
 			if !validator.Jailed && power > 0 {
 				return fmt.Errorf("validator %q inactive flag contradicts its active state", validator.OperatorAddr)
@@ -239,6 +252,9 @@ func ValidateGenesisState(genesis GenesisState) error {
 		if !removal.Validator.Stake.AmountOf(PNYXDenom).IsInt64() {
 			return fmt.Errorf("pending removal stake for %q exceeds supported range", operator)
 		}
+		if removal.Validator.DelegatedStake != 0 {
+			return fmt.Errorf("pending removal for %q must not retain delegations", operator)
+		}
 		if len(removal.Validator.Domains) != 1 {
 			return fmt.Errorf("pending removal for %q must reference exactly one accounting domain", operator)
 		}
@@ -417,22 +433,70 @@ func ValidateGenesisState(genesis GenesisState) error {
 		return fmt.Errorf("last commit cursor is malformed")
 	}
 
-	usedNullifiers := make(map[string]struct{}, len(genesis.UsedNullifiers))
-	for _, record := range genesis.UsedNullifiers {
-		if _, exists := domains[record.DomainName]; !exists {
-			return fmt.Errorf("used nullifier references missing domain %q", record.DomainName)
+	if err := validateGenesisNullifiers(genesis, domains); err != nil {
+		return err
+	}
+
+	decisions := make(map[string]struct{}, len(genesis.IssueDecisions))
+	for _, decision := range genesis.IssueDecisions {
+		if _, exists := domains[decision.DomainName]; !exists {
+			return fmt.Errorf("issue decision references missing domain %q", decision.DomainName)
 		}
-		if err := validateCanonicalFieldHex(record.NullifierHash, "used nullifier", true); err != nil {
-			return fmt.Errorf("domain %q: %w", record.DomainName, err)
+		if decision.IssueName == "" {
+			return fmt.Errorf("domain %q issue decision has no issue name", decision.DomainName)
 		}
-		if record.UsedAtHeight < 0 {
-			return fmt.Errorf("domain %q used nullifier height cannot be negative", record.DomainName)
+		if decision.Reason != DecisionReasonDeadline && decision.Reason != DecisionReasonQuorum {
+			return fmt.Errorf("domain %q issue %q decision has unknown reason %q", decision.DomainName, decision.IssueName, decision.Reason)
 		}
-		key := record.DomainName + "\x00" + record.NullifierHash
-		if _, exists := usedNullifiers[key]; exists {
-			return fmt.Errorf("duplicate used nullifier for domain %q", record.DomainName)
+		if decision.RatingCount < 0 || decision.MemberCount < 0 || decision.DecidedAtHeight < 0 || decision.DecidedAt < 0 {
+			return fmt.Errorf("domain %q issue %q decision is malformed", decision.DomainName, decision.IssueName)
 		}
-		usedNullifiers[key] = struct{}{}
+		key := decision.DomainName + "\x00" + decision.IssueName
+		if _, exists := decisions[key]; exists {
+			return fmt.Errorf("duplicate decision for domain %q issue %q", decision.DomainName, decision.IssueName)
+		}
+		decisions[key] = struct{}{}
+	}
+
+	if err := validateGenesisDelegations(genesis, domains); err != nil {
+		return err
+	}
+	descriptions := make(map[string]struct{}, len(genesis.ValidatorDescriptions))
+	for _, description := range genesis.ValidatorDescriptions {
+		if _, found := activeValidators[description.OperatorAddr]; !found {
+			return fmt.Errorf("validator description references missing validator %q", description.OperatorAddr)
+		}
+		if _, exists := descriptions[description.OperatorAddr]; exists {
+			return fmt.Errorf("duplicate validator description for %q", description.OperatorAddr)
+		}
+		descriptions[description.OperatorAddr] = struct{}{}
+		if err := validateValidatorDescription(description); err != nil {
+			return fmt.Errorf("validator %q description is invalid: %w", description.OperatorAddr, err)
+		}
+		if description.CommissionUpdatedAt < 0 {
+			return fmt.Errorf("validator %q commission update time cannot be negative", description.OperatorAddr)
+		}
+	}
+	if err := validateGenesisStakeDelegations(genesis, activeValidators); err != nil {
+		return err
+	}
+	if err := validateGenesisStakingRewards(genesis, activeValidators); err != nil {
+		return err
+	}
+	if err := validateGenesisValidatorSet(genesis, domains); err != nil {
+		return err
+	}
+	if err := validateGenesisTreasuryPayouts(genesis, domains); err != nil {
+		return err
+	}
+	if err := validateGenesisOptionsHistory(genesis, domains); err != nil {
+		return err
+	}
+	if err := validateGenesisIdentityLeafOwners(genesis, domains); err != nil {
+		return err
+	}
+	if err := validateGenesisIdentityRotations(genesis, domains); err != nil {
+		return err
 	}
 
 	if genesis.VerifyingKeyHex == "" {
@@ -450,6 +514,15 @@ func ValidateGenesisState(genesis GenesisState) error {
 		if _, err := ValidateMembershipVerifyingKey(verifyingKey, genesis.ZKPCircuitID, genesis.VerifyingKeySHA256); err != nil {
 			return fmt.Errorf("invalid verifying key: %w", err)
 		}
+		if membershipCircuitNullifierFamilies[genesis.ZKPCircuitID] != HashFamilyMiMC {
+			return fmt.Errorf("verifying key hex holds the MiMC circuit key; circuit %s belongs in circuit_verifying_keys", genesis.ZKPCircuitID)
+		}
+	}
+	if err := validateRetiringVerifyingKeyGenesis(genesis); err != nil {
+		return err
+	}
+	if err := validateGenesisCircuitVerifyingKeys(genesis); err != nil {
+		return err
 	}
 	if err := validateSoftwareUpgradeGenesis(genesis, domains); err != nil {
 		return err
@@ -457,6 +530,508 @@ func ValidateGenesisState(genesis GenesisState) error {
 	return nil
 }
 
+// validateGenesisDelegations checks that delegations and voter modes name
+// members and issues of existing domains. Chain length and cycles are not
+// checked here; tallies drop chains that loop or run too deep.
+// validateGenesisNullifiers checks the legacy per-record and the compact
+// per-scope nullifier lists together: every nullifier is canonical, spent at
+// most once per domain, and scoped to an issue or suggestion that exists.
+func validateGenesisNullifiers(genesis GenesisState, domains map[string]Domain) error {
+	used := make(map[string]struct{}, len(genesis.UsedNullifiers))
+	add := func(domainName, issueName, suggestionName, nullifier string) error {
+		domain, exists := domains[domainName]
+		if !exists {
+			return fmt.Errorf("used nullifier references missing domain %q", domainName)
+		}
+		if err := validateCanonicalFieldHex(nullifier, "used nullifier", true); err != nil {
+			return fmt.Errorf("domain %q: %w", domainName, err)
+		}
+		if suggestionName != "" && issueName == "" {
+			return fmt.Errorf("domain %q used nullifier scopes a suggestion without its issue", domainName)
+		}
+		if issueName != "" {
+			index := slices.IndexFunc(domain.Issues, func(issue Issue) bool { return issue.Name == issueName })
+			if index < 0 {
+				return fmt.Errorf("domain %q used nullifier references missing issue %q", domainName, issueName)
+			}
+			if suggestionName != "" && !slices.ContainsFunc(domain.Issues[index].Suggestions, func(s Suggestion) bool { return s.Name == suggestionName }) {
+				return fmt.Errorf("domain %q used nullifier references missing suggestion %q", domainName, suggestionName)
+			}
+		}
+		key := domainName + "\x00" + nullifier
+		if _, exists := used[key]; exists {
+			return fmt.Errorf("duplicate used nullifier for domain %q", domainName)
+		}
+		used[key] = struct{}{}
+		return nil
+	}
+	for _, record := range genesis.UsedNullifiers {
+		if err := add(record.DomainName, record.IssueName, record.SuggestionName, record.NullifierHash); err != nil {
+			return err
+		}
+		if record.UsedAtHeight < 0 {
+			return fmt.Errorf("domain %q used nullifier height cannot be negative", record.DomainName)
+		}
+	}
+	for _, scope := range genesis.NullifierScopes {
+		if len(scope.Nullifiers) == 0 {
+			return fmt.Errorf("domain %q nullifier scope is empty", scope.DomainName)
+		}
+		for _, nullifier := range scope.Nullifiers {
+			if err := add(scope.DomainName, scope.IssueName, scope.SuggestionName, nullifier); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateGenesisDelegations(genesis GenesisState, domains map[string]Domain) error {
+	seen := make(map[string]struct{}, len(genesis.VoteDelegations))
+	for _, delegation := range genesis.VoteDelegations {
+		domain, exists := domains[delegation.DomainName]
+		if !exists {
+			return fmt.Errorf("vote delegation references missing domain %q", delegation.DomainName)
+		}
+		if !containsString(domain.Members, delegation.Delegator) || !containsString(domain.Members, delegation.Delegate) {
+			return fmt.Errorf("domain %q vote delegation between non-members", delegation.DomainName)
+		}
+		if delegation.Delegator == delegation.Delegate {
+			return fmt.Errorf("domain %q member %q delegates to themselves", delegation.DomainName, delegation.Delegator)
+		}
+		if delegation.IssueName != "" && !slices.ContainsFunc(domain.Issues, func(issue Issue) bool { return issue.Name == delegation.IssueName }) {
+			return fmt.Errorf("domain %q vote delegation references missing issue %q", delegation.DomainName, delegation.IssueName)
+		}
+		key := delegation.DomainName + "\x00" + delegation.IssueName + "\x00" + delegation.Delegator
+		if _, exists := seen[key]; exists {
+			return fmt.Errorf("duplicate vote delegation by %q in domain %q", delegation.Delegator, delegation.DomainName)
+		}
+		seen[key] = struct{}{}
+	}
+
+	modes := make(map[string]struct{}, len(genesis.VoterModes))
+	for _, record := range genesis.VoterModes {
+		domain, exists := domains[record.DomainName]
+		if !exists {
+			return fmt.Errorf("voter mode references missing domain %q", record.DomainName)
+		}
+		if !containsString(domain.Members, record.Member) {
+			return fmt.Errorf("domain %q voter mode for non-member %q", record.DomainName, record.Member)
+		}
+		if record.Mode != VoterModeOpen && record.Mode != VoterModeAnonymous {
+			return fmt.Errorf("domain %q member %q has unknown voter mode %q", record.DomainName, record.Member, record.Mode)
+		}
+		key := record.DomainName + "\x00" + record.Member
+		if _, exists := modes[key]; exists {
+			return fmt.Errorf("duplicate voter mode for %q in domain %q", record.Member, record.DomainName)
+		}
+		modes[key] = struct{}{}
+	}
+	return nil
+}
+
+// validateGenesisStakeDelegations checks that every delegation backs a
+// genesis validator and sums to its DelegatedStake, and that every
+// undelegation hold is well formed.
+func validateGenesisStakeDelegations(genesis GenesisState, validators map[string]GenesisValidator) error {
+	delegated := make(map[string]int64, len(validators))
+	seen := make(map[string]struct{}, len(genesis.StakeDelegations))
+	for _, delegation := range genesis.StakeDelegations {
+		if _, err := sdk.AccAddressFromBech32(delegation.DelegatorAddr); err != nil {
+			return fmt.Errorf("stake delegator address %q is invalid: %w", delegation.DelegatorAddr, err)
+		}
+		if _, found := validators[delegation.ValidatorAddr]; !found {
+			return fmt.Errorf("stake delegation references missing validator %q", delegation.ValidatorAddr)
+		}
+		if delegation.DelegatorAddr == delegation.ValidatorAddr {
+			return fmt.Errorf("validator %q delegates to itself", delegation.ValidatorAddr)
+		}
+		if delegation.Amount <= 0 {
+			return fmt.Errorf("stake delegation from %q to %q must be positive", delegation.DelegatorAddr, delegation.ValidatorAddr)
+		}
+		key := delegation.ValidatorAddr + "\x00" + delegation.DelegatorAddr
+		if _, exists := seen[key]; exists {
+			return fmt.Errorf("duplicate stake delegation from %q to %q", delegation.DelegatorAddr, delegation.ValidatorAddr)
+		}
+		seen[key] = struct{}{}
+		total := delegated[delegation.ValidatorAddr] + delegation.Amount
+		if total < delegation.Amount {
+			return fmt.Errorf("validator %q delegations exceed supported range", delegation.ValidatorAddr)
+		}
+		delegated[delegation.ValidatorAddr] = total
+	}
+	for operator, validator := range validators {
+		if delegated[operator] != validator.DelegatedStake {
+			return fmt.Errorf("validator %q delegated stake %d does not match its delegations %d", operator, validator.DelegatedStake, delegated[operator])
+		}
+	}
+
+	holds := make(map[string]struct{}, len(genesis.PendingUndelegations))
+	for _, hold := range genesis.PendingUndelegations {
+		if _, err := sdk.AccAddressFromBech32(hold.DelegatorAddr); err != nil {
+			return fmt.Errorf("pending undelegation delegator address %q is invalid: %w", hold.DelegatorAddr, err)
+		}
+		if _, err := sdk.AccAddressFromBech32(hold.ValidatorAddr); err != nil {
+			return fmt.Errorf("pending undelegation validator address %q is invalid: %w", hold.ValidatorAddr, err)
+		}
+		if hold.Amount <= 0 {
+			return fmt.Errorf("pending undelegation from %q to %q must be positive", hold.DelegatorAddr, hold.ValidatorAddr)
+		}
+		if hold.CreatedHeight < 0 ||
+			hold.ConsensusRetiredHeight <= hold.CreatedHeight ||
+			hold.ReleaseAfterHeight < hold.ConsensusRetiredHeight {
+			return fmt.Errorf("pending undelegation heights for %q are invalid", hold.DelegatorAddr)
+		}
+		if (hold.ConsensusRetiredAtNanos == 0) != (hold.ReleaseAfterTimeNanos == 0) ||
+			hold.ConsensusRetiredAtNanos < 0 ||
+			hold.ReleaseAfterTimeNanos < hold.ConsensusRetiredAtNanos {
+			return fmt.Errorf("pending undelegation times for %q are invalid", hold.DelegatorAddr)
+		}
+		key := fmt.Sprintf("%s\x00%s\x00%d", hold.ValidatorAddr, hold.DelegatorAddr, hold.CreatedHeight)
+		if _, exists := holds[key]; exists {
+			return fmt.Errorf("duplicate pending undelegation from %q to %q at height %d", hold.DelegatorAddr, hold.ValidatorAddr, hold.CreatedHeight)
+		}
+		holds[key] = struct{}{}
+	}
+	return nil
+}
+
+// validateGenesisValidatorSet checks the validator-set parameters and that
+// every open vote comes from a governance domain member, once per parameter
+// set, without having reached the threshold that would have applied it.
+func validateGenesisValidatorSet(genesis GenesisState, domains map[string]Domain) error {
+	params := DefaultValidatorSetParams()
+	if genesis.ValidatorSetParams != nil {
+		params = *genesis.ValidatorSetParams
+		if err := validateValidatorSetParams(params); err != nil {
+			return fmt.Errorf("validator-set params are invalid: %w", err)
+		}
+	}
+	if len(genesis.ValidatorSetVotes) == 0 {
+		return nil
+	}
+	governance, found := domains[ReservedGovernanceDomain]
+	if !found {
+		return fmt.Errorf("validator-set votes require the %s domain", ReservedGovernanceDomain)
+	}
+	members := snapshotEligibleMembers(governance)
+	votes := make(map[[32]byte]map[string]struct{})
+	for _, vote := range genesis.ValidatorSetVotes {
+		if !isEligibleSnapshotMember(members, vote.Voter) {
+			return fmt.Errorf("validator-set voter %q is not a %s member", vote.Voter, ReservedGovernanceDomain)
+		}
+		if err := validateValidatorSetParams(vote.Params); err != nil {
+			return fmt.Errorf("validator-set vote of %q is invalid: %w", vote.Voter, err)
+		}
+		hash := validatorSetParamsHash(vote.Params)
+		if hash == validatorSetParamsHash(params) {
+			return fmt.Errorf("validator-set vote of %q repeats the current params", vote.Voter)
+		}
+		if votes[hash] == nil {
+			votes[hash] = make(map[string]struct{})
+		}
+		if _, exists := votes[hash][vote.Voter]; exists {
+			return fmt.Errorf("duplicate validator-set vote of %q", vote.Voter)
+		}
+		votes[hash][vote.Voter] = struct{}{}
+		if upgradeThresholdReached(len(votes[hash]), len(members)) {
+			return fmt.Errorf("validator-set votes reach the threshold without being applied")
+		}
+	}
+	return nil
+}
+
+// validateGenesisStakingRewards bounds the reward pool by the bonded stake
+// of unjailed validators, checks that reward records trail the indexes they
+// are settled against, and that outstanding rewards cover every account.
+func validateGenesisStakingRewards(genesis GenesisState, validators map[string]GenesisValidator) error {
+	pool := StakingRewardPool{Index: math.ZeroInt(), BondedStake: math.ZeroInt(), Outstanding: math.ZeroInt()}
+	if genesis.StakingRewardPool != nil {
+		pool = StakingRewardPool{
+			Index:       intOrZero(genesis.StakingRewardPool.Index),
+			BondedStake: intOrZero(genesis.StakingRewardPool.BondedStake),
+			Outstanding: intOrZero(genesis.StakingRewardPool.Outstanding),
+		}
+		if pool.Index.IsNegative() || pool.Outstanding.IsNegative() {
+			return fmt.Errorf("staking reward pool cannot be negative")
+		}
+		bonded := math.ZeroInt()
+		for _, validator := range genesis.Validators {
+			if !validator.Jailed {
+				bonded = bonded.AddRaw(validator.BondedStake())
+			}
+		}
+		// Only set members earn, and InitGenesis seats the set afresh, so the
+		// exported stake is bounded by the unjailed validators rather than
+		// matched against them.
+		if pool.BondedStake.IsNegative() || pool.BondedStake.GT(bonded) {
+			return fmt.Errorf("staking reward pool bonded stake %s exceeds unjailed validators %s", pool.BondedStake, bonded)
+		}
+	}
+
+	delegatorIndexes := make(map[string]math.Int, len(genesis.ValidatorRewards))
+	for _, rs := range genesis.ValidatorRewards {
+		if _, found := validators[rs.OperatorAddr]; !found {
+			return fmt.Errorf("validator rewards reference missing validator %q", rs.OperatorAddr)
+		}
+		if _, exists := delegatorIndexes[rs.OperatorAddr]; exists {
+			return fmt.Errorf("duplicate validator rewards for %q", rs.OperatorAddr)
+		}
+		poolIndex, delegatorIndex := intOrZero(rs.PoolIndex), intOrZero(rs.DelegatorIndex)
+		if poolIndex.IsNegative() || poolIndex.GT(pool.Index) || delegatorIndex.IsNegative() {
+			return fmt.Errorf("validator %q reward indexes are invalid", rs.OperatorAddr)
+		}
+		delegatorIndexes[rs.OperatorAddr] = delegatorIndex
+	}
+	for _, delegation := range genesis.StakeDelegations {
+		rewardIndex := intOrZero(delegation.RewardIndex)
+		delegatorIndex, found := delegatorIndexes[delegation.ValidatorAddr]
+		if !found {
+			delegatorIndex = math.ZeroInt()
+		}
+		if rewardIndex.IsNegative() || rewardIndex.GT(delegatorIndex) {
+			return fmt.Errorf("stake delegation from %q to %q reward index is invalid", delegation.DelegatorAddr, delegation.ValidatorAddr)
+		}
+	}
+
+	unclaimed := math.ZeroInt()
+	accounts := make(map[string]struct{}, len(genesis.StakingRewardAccounts))
+	for _, acct := range genesis.StakingRewardAccounts {
+		if _, err := sdk.AccAddressFromBech32(acct.Address); err != nil {
+			return fmt.Errorf("staking reward account address %q is invalid: %w", acct.Address, err)
+		}
+		if _, exists := accounts[acct.Address]; exists {
+			return fmt.Errorf("duplicate staking reward account %q", acct.Address)
+		}
+		accounts[acct.Address] = struct{}{}
+		if acct.WithdrawAddr != "" {
+			if _, err := sdk.AccAddressFromBech32(acct.WithdrawAddr); err != nil {
+				return fmt.Errorf("staking reward withdraw address %q is invalid: %w", acct.WithdrawAddr, err)
+			}
+		}
+		if intOrZero(acct.Unclaimed).IsNegative() {
+			return fmt.Errorf("staking reward account %q cannot be negative", acct.Address)
+		}
+		unclaimed = unclaimed.Add(intOrZero(acct.Unclaimed))
+	}
+	if unclaimed.GT(pool.Outstanding) {
+		return fmt.Errorf("unclaimed staking rewards %s exceed outstanding %s", unclaimed, pool.Outstanding)
+	}
+	return nil
+}
+
+// validateGenesisSubDomains checks the domain tree: parents exist, the
+// governance domain stays outside it, chains do not loop, child members are
+// parent members, and delegated issues point at a child holding the issue.
+func validateGenesisSubDomains(domains map[string]Domain) error {
+	for _, domain := range domains {
+		if domain.Parent != "" {
+			parent, exists := domains[domain.Parent]
+			if !exists {
+				return fmt.Errorf("domain %q references missing parent %q", domain.Name, domain.Parent)
+			}
+			if domain.Name == ReservedGovernanceDomain || parent.Name == ReservedGovernanceDomain {
+				return fmt.Errorf("domain %q cannot be part of a sub-domain tree", ReservedGovernanceDomain)
+			}
+			for _, member := range domain.Members {
+				if !containsString(parent.Members, member) {
+					return fmt.Errorf("sub-domain %q member %q is not a member of parent %q", domain.Name, member, parent.Name)
+				}
+			}
+			visited := map[string]struct{}{domain.Name: {}}
+			for ancestor := parent; ancestor.Parent != ""; ancestor = domains[ancestor.Parent] {
+				if _, looped := visited[ancestor.Name]; looped {
+					return fmt.Errorf("domain %q has a cyclic parent chain", domain.Name)
+				}
+				visited[ancestor.Name] = struct{}{}
+			}
+		}
+		for _, issue := range domain.Issues {
+			if issue.SubDomain == "" {
+				continue
+			}
+			child, exists := domains[issue.SubDomain]
+			if !exists || child.Parent != domain.Name {
+				return fmt.Errorf("domain %q issue %q is delegated to %q, which is not a sub-domain", domain.Name, issue.Name, issue.SubDomain)
+			}
+			if !slices.ContainsFunc(child.Issues, func(childIssue Issue) bool { return childIssue.Name == issue.Name }) {
+				return fmt.Errorf("domain %q issue %q is missing from sub-domain %q", domain.Name, issue.Name, issue.SubDomain)
+			}
+		}
+	}
+	return nil
+}
+
+// validateGenesisTreasuryPayouts checks that payout records belong to
+// existing domains and that their progress fits their schedule. The
+// suggestion may since have been deleted, so it is not looked up.
+func validateGenesisTreasuryPayouts(genesis GenesisState, domains map[string]Domain) error {
+	seen := make(map[string]struct{}, len(genesis.TreasuryPayouts))
+	for _, record := range genesis.TreasuryPayouts {
+		if _, exists := domains[record.DomainName]; !exists {
+			return fmt.Errorf("treasury payout references missing domain %q", record.DomainName)
+		}
+		if record.IssueName == "" || record.SuggestionName == "" {
+			return fmt.Errorf("domain %q treasury payout needs an issue and suggestion", record.DomainName)
+		}
+		if err := validateTreasuryPayout(record.Payout); err != nil {
+			return fmt.Errorf("domain %q treasury payout %q: %w", record.DomainName, record.SuggestionName, err)
+		}
+		if record.Trigger != PayoutTriggerGreen && record.Trigger != PayoutTriggerDecision {
+			return fmt.Errorf("domain %q treasury payout %q has unknown trigger %q", record.DomainName, record.SuggestionName, record.Trigger)
+		}
+		tranches := record.Payout.trancheCount()
+		if record.ApprovedAtHeight < 0 || record.ApprovedAt < 0 ||
+			record.TranchesPaid < 0 || record.TranchesPaid > tranches ||
+			record.Paid < 0 || record.Paid > record.Payout.Amount ||
+			(record.TranchesPaid == tranches) != (record.NextPaymentAt == 0) ||
+			(record.NextPaymentAt != 0 && record.NextPaymentAt < record.ApprovedAt) {
+			return fmt.Errorf("domain %q treasury payout %q progress is invalid", record.DomainName, record.SuggestionName)
+		}
+		key := record.DomainName + "\x00" + record.IssueName + "\x00" + record.SuggestionName
+		if _, exists := seen[key]; exists {
+			return fmt.Errorf("duplicate treasury payout for %q in domain %q", record.SuggestionName, record.DomainName)
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+// validateGenesisOptionsHistory checks that options history entries belong to
+// existing domains, carry valid options and have unique sequence numbers.
+func validateGenesisOptionsHistory(genesis GenesisState, domains map[string]Domain) error {
+	seen := make(map[string]struct{}, len(genesis.DomainOptionsHistory))
+	for _, change := range genesis.DomainOptionsHistory {
+		if _, exists := domains[change.DomainName]; !exists {
+			return fmt.Errorf("options history references missing domain %q", change.DomainName)
+		}
+		if change.Sequence == 0 || change.ChangedAtHeight < 0 || change.ChangedAt < 0 || change.MemberCount < int64(len(change.Voters)) {
+			return fmt.Errorf("domain %q options history entry %d is invalid", change.DomainName, change.Sequence)
+		}
+		if err := validateDomainOptions(change.Previous); err != nil {
+			return fmt.Errorf("domain %q options history entry %d: %w", change.DomainName, change.Sequence, err)
+		}
+		if err := validateDomainOptions(change.Options); err != nil {
+			return fmt.Errorf("domain %q options history entry %d: %w", change.DomainName, change.Sequence, err)
+		}
+		key := fmt.Sprintf("%s\x00%d", change.DomainName, change.Sequence)
+		if _, exists := seen[key]; exists {
+			return fmt.Errorf("duplicate options history entry %d in domain %q", change.Sequence, change.DomainName)
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+// validateGenesisIdentityLeafOwners checks that every owner record names a
+// member of an existing domain and a live, distinct identity leaf.
+func validateGenesisIdentityLeafOwners(genesis GenesisState, domains map[string]Domain) error {
+	seen := make(map[string]struct{}, len(genesis.IdentityLeafOwners))
+	for _, owner := range genesis.IdentityLeafOwners {
+		domain, exists := domains[owner.DomainName]
+		if !exists {
+			return fmt.Errorf("identity leaf owner references missing domain %q", owner.DomainName)
+		}
+		if !slices.Contains(domain.Members, owner.Member) {
+			return fmt.Errorf("identity leaf %d owner %q is not a member of domain %q", owner.Leaf, owner.Member, owner.DomainName)
+		}
+		if owner.Leaf >= uint64(len(domain.IdentityCommits)) || domain.IdentityCommits[owner.Leaf] == RevokedIdentityLeaf {
+			return fmt.Errorf("identity leaf owner references missing leaf %d in domain %q", owner.Leaf, owner.DomainName)
+		}
+		key := fmt.Sprintf("%s\x00%d", owner.DomainName, owner.Leaf)
+		if _, exists := seen[key]; exists {
+			return fmt.Errorf("duplicate owner of identity leaf %d in domain %q", owner.Leaf, owner.DomainName)
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+// validateGenesisIdentityRotations checks that every rotation record names a
+// member of an existing domain at most once.
+func validateGenesisIdentityRotations(genesis GenesisState, domains map[string]Domain) error {
+	seen := make(map[string]struct{}, len(genesis.IdentityRotations))
+	for _, rotation := range genesis.IdentityRotations {
+		domain, exists := domains[rotation.DomainName]
+		if !exists {
+			return fmt.Errorf("identity rotation references missing domain %q", rotation.DomainName)
+		}
+		if !slices.Contains(domain.Members, rotation.Member) {
+			return fmt.Errorf("identity rotation member %q is not a member of domain %q", rotation.Member, rotation.DomainName)
+		}
+		key := rotation.DomainName + "\x00" + rotation.Member
+		if _, exists := seen[key]; exists {
+			return fmt.Errorf("duplicate identity rotation of %q in domain %q", rotation.Member, rotation.DomainName)
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+// validateRetiringVerifyingKeyGenesis checks a key still in its rotation
+// transition window: it needs a current key to transition to, must itself be
+// a valid key distinct from it, and must share its nullifier family.
+func validateRetiringVerifyingKeyGenesis(genesis GenesisState) error {
+	retiring := genesis.RetiringVerifyingKey
+	if retiring == nil {
+		return nil
+	}
+	if genesis.VerifyingKeyHex == "" {
+		return fmt.Errorf("retiring verifying key requires a current verifying key")
+	}
+	return validateRetiringVerifyingKey(*retiring, genesis.ZKPCircuitID, genesis.VerifyingKeySHA256)
+}
+
+func validateRetiringVerifyingKey(retiring RetiringVerifyingKey, currentCircuitID, currentSHA256 string) error {
+	verifyingKey, err := hex.DecodeString(retiring.VerifyingKeyHex)
+	if err != nil || retiring.VerifyingKeyHex != hex.EncodeToString(verifyingKey) {
+		return fmt.Errorf("retiring verifying key hex must use canonical lowercase encoding")
+	}
+	if _, err := ValidateMembershipVerifyingKey(verifyingKey, retiring.CircuitID, retiring.VerifyingKeySHA256); err != nil {
+		return fmt.Errorf("invalid retiring verifying key: %w", err)
+	}
+	if retiring.VerifyingKeySHA256 == currentSHA256 {
+		return fmt.Errorf("retiring verifying key must differ from the current key")
+	}
+	if membershipCircuitNullifierFamilies[retiring.CircuitID] != membershipCircuitNullifierFamilies[currentCircuitID] {
+		return fmt.Errorf("retiring verifying key derives nullifiers differently from the current key")
+	}
+	if retiring.EndHeight <= 0 {
+		return fmt.Errorf("retiring verifying key end height must be positive")
+	}
+	return nil
+}
+
+// validateGenesisCircuitVerifyingKeys checks the key slots of the hash
+// families other than MiMC: one valid, canonical key per family, each with
+// an optional retiring key of the same family.
+func validateGenesisCircuitVerifyingKeys(genesis GenesisState) error {
+	seen := make(map[string]struct{}, len(genesis.CircuitVerifyingKeys))
+	for _, slot := range genesis.CircuitVerifyingKeys {
+		verifyingKey, err := hex.DecodeString(slot.VerifyingKeyHex)
+		if err != nil || slot.VerifyingKeyHex != hex.EncodeToString(verifyingKey) {
+			return fmt.Errorf("circuit %s verifying key hex must use canonical lowercase encoding", slot.CircuitID)
+		}
+		if _, err := ValidateMembershipVerifyingKey(verifyingKey, slot.CircuitID, slot.VerifyingKeySHA256); err != nil {
+			return fmt.Errorf("invalid circuit %s verifying key: %w", slot.CircuitID, err)
+		}
+		family := membershipCircuitNullifierFamilies[slot.CircuitID]
+		if family == HashFamilyMiMC {
+			return fmt.Errorf("circuit %s key belongs in verifying_key_hex", slot.CircuitID)
+		}
+		if _, exists := seen[family]; exists {
+			return fmt.Errorf("duplicate verifying key for hash family %s", family)
+		}
+		seen[family] = struct{}{}
+		if slot.Retiring != nil {
+			if err := validateRetiringVerifyingKey(*slot.Retiring, slot.CircuitID, slot.VerifyingKeySHA256); err != nil {
+				return fmt.Errorf("circuit %s: %w", slot.CircuitID, err)
+			}
+		}
+	}
+	return nil
+}
+
 func validateSoftwareUpgradeGenesis(genesis GenesisState, domains map[string]Domain) error {
 	proposal := genesis.SoftwareUpgradeProposal
 	if proposal == nil {
@@ -545,7 +1120,7 @@ func resolveGenesisValidator(validator GenesisValidator) (domains []string, powe
 		if validator.Domain != "" {
 			domains = []string{validator.Domain}
 		}
-		power = validator.Stake / rewards.StakeMin
+		power = validator.BondedStake() / rewards.StakeMin
 		return domains, power, !validator.Jailed && power > 0, nil
 	}
 	if (len(validator.Domains) == 0) != (validator.Domain == "") {
@@ -558,7 +1133,8 @@ func resolveGenesisValidator(validator GenesisValidator) (domains []string, powe
 	return domains, validator.Power, *validator.Active, nil
 }
 
-// GenesisEscrowClaims returns all PNYX treasury and validator stake claims.
+// GenesisEscrowClaims returns all PNYX treasury, validator stake, and
+// delegation claims.
 func GenesisEscrowClaims(genesis GenesisState) (math.Int, error) {
 	if err := ValidateGenesisState(genesis); err != nil {
 		return math.Int{}, err
@@ -568,11 +1144,17 @@ func GenesisEscrowClaims(genesis GenesisState) (math.Int, error) {
 		claims = claims.Add(domain.Treasury.AmountOf(PNYXDenom))
 	}
 	for _, validator := range genesis.Validators {
-		claims = claims.Add(math.NewInt(validator.Stake))
+		claims = claims.Add(math.NewInt(validator.Stake)).AddRaw(validator.DelegatedStake)
 	}
 	for _, removal := range genesis.PendingValidatorRemovals {
 		claims = claims.Add(removal.Validator.Stake.AmountOf(PNYXDenom))
 	}
+	for _, hold := range genesis.PendingUndelegations {
+		claims = claims.AddRaw(hold.Amount)
+	}
+	if genesis.StakingRewardPool != nil {
+		claims = claims.Add(intOrZero(genesis.StakingRewardPool.Outstanding))
+	}
 	return claims, nil
 }
 
@@ -589,16 +1171,14 @@ func validateGenesisDomain(domain Domain) error {
 	if !domain.Treasury.Empty() && (len(domain.Treasury) != 1 || domain.Treasury[0].Denom != PNYXDenom || !domain.Treasury[0].Amount.IsPositive()) {
 		return fmt.Errorf("domain %q treasury must contain only positive %s", domain.Name, PNYXDenom)
 	}
-	if domain.TotalPayouts < 0 || domain.TransferredStake < 0 {
+	if domain.TotalPayouts < 0 || domain.TransferredStake < 0 || domain.PayoutEpoch < 0 || domain.PayoutEpochSpent < 0 {
 		return fmt.Errorf("domain %q payout counters cannot be negative", domain.Name)
 	}
 	if math.NewInt(domain.TransferredStake).MulRaw(10_000).GT(math.NewInt(domain.TotalPayouts).MulRaw(StakeTransferLimitBps)) {
 		return fmt.Errorf("domain %q transferred stake exceeds its payout-backed limit", domain.Name)
 	}
-	if domain.Options.ApprovalThreshold < 0 || domain.Options.ApprovalThreshold > 10_000 ||
-		domain.Options.DefaultDwellTime < 0 ||
-		domain.Options.VotingMode < VotingModeSimpleMajority || domain.Options.VotingMode > VotingModeSystemicConsensing {
-		return fmt.Errorf("domain %q options are invalid", domain.Name)
+	if err := validateDomainOptions(domain.Options); err != nil {
+		return fmt.Errorf("domain %q options are invalid: %w", domain.Name, err)
 	}
 	if err := validateUniqueStrings(domain.Name, "member", domain.Members); err != nil {
 		return err
@@ -624,7 +1204,10 @@ func validateGenesisDomain(domain Domain) error {
 	}
 	issues := make(map[string]struct{}, len(domain.Issues))
 	for _, issue := range domain.Issues {
-		if issue.Name == "" || issue.Stones < 0 || issue.CreationDate < 0 || issue.LastActivityAt < 0 {
+		if issue.Name == "" || issue.Stones < 0 || issue.DelegatedStones < 0 || issue.DelegatedStones > issue.Stones ||
+			issue.CreationDate < 0 || issue.LastActivityAt < 0 ||
+			issue.Closing.ClosesAt < 0 || issue.Closing.RatingQuorum < 0 || issue.Closing.StoneQuorum < 0 ||
+			issue.Seats < 0 || issue.Seats > MaxElectionSeats {
 			return fmt.Errorf("domain %q contains malformed issue %q", domain.Name, issue.Name)
 		}
 		if _, exists := issues[issue.Name]; exists {
@@ -634,7 +1217,8 @@ func validateGenesisDomain(domain Domain) error {
 		suggestions := make(map[string]struct{}, len(issue.Suggestions))
 		for _, suggestion := range issue.Suggestions {
 			if suggestion.Name == "" || suggestion.Creator == "" || suggestion.Stones < 0 || suggestion.DwellTime < 0 ||
-				suggestion.CreationDate < 0 || suggestion.EnteredYellowAt < 0 || suggestion.EnteredRedAt < 0 || suggestion.DeleteVotes < 0 {
+				suggestion.CreationDate < 0 || suggestion.EnteredGreenAt < 0 || suggestion.EnteredYellowAt < 0 || suggestion.EnteredRedAt < 0 || suggestion.DeleteVotes < 0 ||
+				suggestion.DelegatedStones < 0 || suggestion.DelegatedStones > suggestion.Stones {
 				return fmt.Errorf("domain %q issue %q contains malformed suggestion %q", domain.Name, issue.Name, suggestion.Name)
 			}
 			if _, err := sdk.AccAddressFromBech32(suggestion.Creator); err != nil {
@@ -643,12 +1227,27 @@ func validateGenesisDomain(domain Domain) error {
 			if _, exists := suggestions[suggestion.Name]; exists {
 				return fmt.Errorf("domain %q issue %q contains duplicate suggestion %q", domain.Name, issue.Name, suggestion.Name)
 			}
+			if suggestion.Payout != nil {
+				if err := validateTreasuryPayout(*suggestion.Payout); err != nil {
+					return fmt.Errorf("domain %q suggestion %q payout: %w", domain.Name, suggestion.Name, err)
+				}
+			}
 			suggestions[suggestion.Name] = struct{}{}
 			for _, rating := range suggestion.Ratings {
 				if rating.Value < -5 || rating.Value > 5 {
 					return fmt.Errorf("domain %q suggestion %q contains rating outside -5..5", domain.Name, suggestion.Name)
 				}
-				if rating.NullifierHex != "" {
+				if rating.Weight < 0 || (rating.Weight > 1 && rating.MemberAddr == "") {
+					return fmt.Errorf("domain %q suggestion %q contains a malformed rating weight", domain.Name, suggestion.Name)
+				}
+				if rating.MemberAddr != "" {
+					if rating.DomainPubKeyHex != "" || rating.NullifierHex != "" {
+						return fmt.Errorf("domain %q suggestion %q rating mixes open and anonymous identity", domain.Name, suggestion.Name)
+					}
+					if !containsString(domain.Members, rating.MemberAddr) {
+						return fmt.Errorf("domain %q suggestion %q has an open rating by non-member %q", domain.Name, suggestion.Name, rating.MemberAddr)
+					}
+				} else if rating.NullifierHex != "" {
 					if rating.DomainPubKeyHex != "" {
 						return fmt.Errorf("domain %q suggestion %q rating mixes ZKP and domain-key identity", domain.Name, suggestion.Name)
 					}
@@ -676,7 +1275,7 @@ func validateGenesisZKPState(domain Domain) error {
 		if err := validateCanonicalFieldHex(commitment, "identity commitment", true); err != nil {
 			return fmt.Errorf("domain %q: %w", domain.Name, err)
 		}
-		if _, exists := seenCommitments[commitment]; exists {
+		if _, exists := seenCommitments[commitment]; exists && commitment != RevokedIdentityLeaf {
 			return fmt.Errorf("domain %q contains duplicate identity commitment %q", domain.Name, commitment)
 		}
 		seenCommitments[commitment] = struct{}{}
@@ -691,7 +1290,10 @@ func validateGenesisZKPState(domain Domain) error {
 	if err := validateCanonicalFieldHex(domain.MerkleRoot, "Merkle root", true); err != nil {
 		return fmt.Errorf("domain %q: %w", domain.Name, err)
 	}
-	tree := NewMerkleTree(MerkleTreeDepth)
+	tree, err := NewMerkleTreeFor(domain.Options.hashFamily(), MerkleTreeDepth)
+	if err != nil {
+		return fmt.Errorf("domain %q identity tree: %w", domain.Name, err)
+	}
 	if err := tree.BuildFromLeaves(leaves); err != nil {
 		return fmt.Errorf("domain %q identity tree: %w", domain.Name, err)
 	}
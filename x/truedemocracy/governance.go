@@ -29,16 +29,15 @@ func excludeVoteKey(domainName, targetMember, voterAddr string) []byte {
 // target automatically moves it from the old one. Members cannot vote for
 // themselves.
 func (k Keeper) PlaceStoneOnMember(ctx sdk.Context, domainName, targetMember, voterAddr string) error {
-	domain, found := k.GetDomain(ctx, domainName)
-	if !found {
+	if _, found := k.GetDomainHeader(ctx, domainName); !found {
 		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
 
-	if !isMember(domain, voterAddr) {
+	if !k.IsDomainMember(ctx, domainName, voterAddr) {
 		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only domain members can place stones")
 	}
 
-	if !isMember(domain, targetMember) {
+	if !k.IsDomainMember(ctx, domainName, targetMember) {
 		return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "target is not a domain member")
 	}
 
@@ -77,7 +76,8 @@ type MemberRank struct {
 }
 
 // countMemberStones builds a map of member → stone count by checking each
-// member's vote in the KV store. O(members) KV reads.
+// member's vote in the KV store, then adds the delegated and anonymous
+// member stones. A delegated stone never lands on its own delegator.
 func (k Keeper) countMemberStones(ctx sdk.Context, domain Domain) map[string]int {
 	counts := make(map[string]int)
 	for _, member := range domain.Members {
@@ -86,6 +86,21 @@ func (k Keeper) countMemberStones(ctx sdk.Context, domain Domain) map[string]int
 			counts[target]++
 		}
 	}
+	voted := func(m string) bool {
+		_, found := k.GetMemberStone(ctx, domain.Name, m)
+		return found
+	}
+	k.forEachDelegatedVote(ctx, domain.Name, "", voted, func(delegator, holder string) {
+		if target, _ := k.GetMemberStone(ctx, domain.Name, holder); target != delegator {
+			counts[target]++
+		}
+	})
+	prefix := anonStonePrefix(domain.Name, StoneListMember, "")
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		counts[string(iter.Value())]++
+	}
 	return counts
 }
 
@@ -108,7 +123,7 @@ func (k Keeper) SortMembersByStones(ctx sdk.Context, domain Domain) []MemberRank
 // ElectAdmin sets the domain admin to the member with the most stones when
 // AdminElectable is true. If no member has stones, admin remains unchanged.
 func (k Keeper) ElectAdmin(ctx sdk.Context, domainName string) error {
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return nil
 	}
@@ -116,6 +131,7 @@ func (k Keeper) ElectAdmin(ctx sdk.Context, domainName string) error {
 	if !domain.Options.AdminElectable {
 		return nil
 	}
+	domain.Members = k.GetDomainMembers(ctx, domainName)
 
 	counts := k.countMemberStones(ctx, domain)
 	if len(counts) == 0 {
@@ -137,9 +153,7 @@ func (k Keeper) ElectAdmin(ctx sdk.Context, domainName string) error {
 	}
 
 	domain.Admin = sdk.AccAddress(bestAddr)
-	store := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	k.SetDomainHeader(ctx, domain)
 	return nil
 }
 
@@ -152,16 +166,15 @@ func (k Keeper) VoteToExclude(ctx sdk.Context, domainName, targetMember, voterAd
 	if domainName == ReservedGovernanceDomain {
 		return false, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "governance electorate is immutable after genesis")
 	}
-	domain, found := k.GetDomain(ctx, domainName)
-	if !found {
+	if _, found := k.GetDomainHeader(ctx, domainName); !found {
 		return false, errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
 
-	if !isMember(domain, voterAddr) {
+	if !k.IsDomainMember(ctx, domainName, voterAddr) {
 		return false, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only domain members can vote to exclude")
 	}
 
-	if !isMember(domain, targetMember) {
+	if !k.IsDomainMember(ctx, domainName, targetMember) {
 		return false, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "target is not a domain member")
 	}
 
@@ -179,8 +192,9 @@ func (k Keeper) VoteToExclude(ctx sdk.Context, domainName, targetMember, voterAd
 	store.Set(voteKey, []byte{1})
 
 	// Count total votes for this exclusion.
+	members := k.GetDomainMembers(ctx, domainName)
 	votes := 0
-	for _, member := range domain.Members {
+	for _, member := range members {
 		if member == targetMember {
 			continue // target doesn't count
 		}
@@ -189,71 +203,82 @@ func (k Keeper) VoteToExclude(ctx sdk.Context, domainName, targetMember, voterAd
 		}
 	}
 
-	totalVoters := len(domain.Members) - 1 // exclude the target from the denominator
+	totalVoters := len(members) - 1 // exclude the target from the denominator
 	excluded := int64(votes)*10000 >= int64(totalVoters)*ExcludeMajorityBps
 
 	if excluded {
-		k.removeMember(ctx, &domain, targetMember)
-
-		bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-		store.Set([]byte("domain:"+domainName), bz)
+		cacheCtx, write := ctx.CacheContext()
+		if err := k.removeMember(cacheCtx, domainName, targetMember); err != nil {
+			return false, err
+		}
+		write()
 	}
 
 	return excluded, nil
 }
 
-// removeMember removes a member from the domain and cleans up their stones.
-func (k Keeper) removeMember(ctx sdk.Context, domain *Domain, memberAddr string) {
-	// Remove from member list.
-	for i, m := range domain.Members {
-		if m == memberAddr {
-			domain.Members = append(domain.Members[:i], domain.Members[i+1:]...)
-			break
-		}
+// removeMember removes a member from the domain and its sub-domains and
+// cleans up their stones, delegations and identity leaves. An error leaves
+// partial writes behind, so callers run it in a cache context.
+func (k Keeper) removeMember(ctx sdk.Context, domainName, memberAddr string) error {
+	// Drop the member, their votes and their delegations, and move the weight
+	// that flowed through them.
+	leave := func() { k.dropMemberVotes(ctx, domainName, memberAddr) }
+	if k.hasVoteDelegations(ctx, domainName) {
+		k.trackDelegatedWeight(ctx, domainName, []string{memberAddr}, k.domainTallies(ctx, domainName), leave)
+	} else {
+		leave()
+	}
+
+	// Retire their identity leaves so they can no longer prove membership.
+	if err := k.RevokeMemberCommitments(ctx, domainName, memberAddr); err != nil {
+		return err
 	}
+	ctx.KVStore(k.StoreKey).Delete(identityRotationKey(domainName, memberAddr))
+
+	// Sub-domain membership is a subset of this domain's.
+	return k.removeFromSubDomains(ctx, domainName, memberAddr)
+}
+
+// dropMemberVotes removes the member from the member list together with their
+// stones, delegations and voter mode.
+func (k Keeper) dropMemberVotes(ctx sdk.Context, domainName, memberAddr string) {
+	// Remove from member list.
+	k.removeDomainMember(ctx, domainName, memberAddr)
 
 	store := ctx.KVStore(k.StoreKey)
 
 	// Clean up issue stone.
-	issueKey := issueStoneKey(domain.Name, memberAddr)
+	issueKey := issueStoneKey(domainName, memberAddr)
 	if oldIssue := store.Get(issueKey); oldIssue != nil {
-		issueName := string(oldIssue)
-		for i, issue := range domain.Issues {
-			if issue.Name == issueName && domain.Issues[i].Stones > 0 {
-				domain.Issues[i].Stones--
-				break
-			}
+		if issue, found := k.GetIssue(ctx, domainName, string(oldIssue)); found && issue.Stones > 0 {
+			issue.Stones--
+			k.SetIssue(ctx, domainName, issue)
 		}
 		store.Delete(issueKey)
 	}
 
 	// Clean up suggestion stones (one per issue).
-	for _, issue := range domain.Issues {
-		suggKey := suggestionStoneKey(domain.Name, issue.Name, memberAddr)
+	var issueNames []string
+	k.IterateIssues(ctx, domainName, func(issue Issue) bool {
+		issueNames = append(issueNames, issue.Name)
+		return false
+	})
+	for _, issueName := range issueNames {
+		suggKey := suggestionStoneKey(domainName, issueName, memberAddr)
 		if oldSugg := store.Get(suggKey); oldSugg != nil {
-			suggName := string(oldSugg)
-			for i, s := range issue.Suggestions {
-				if s.Name == suggName {
-					// Find the issue index to modify the domain's data.
-					for ii, di := range domain.Issues {
-						if di.Name == issue.Name && ii < len(domain.Issues) {
-							for jj, ds := range domain.Issues[ii].Suggestions {
-								if ds.Name == suggName && domain.Issues[ii].Suggestions[jj].Stones > 0 {
-									domain.Issues[ii].Suggestions[jj].Stones--
-								}
-							}
-						}
-					}
-					_ = i
-					break
-				}
+			if s, found := k.GetSuggestion(ctx, domainName, issueName, string(oldSugg)); found && s.Stones > 0 {
+				s.Stones--
+				k.SetSuggestion(ctx, domainName, issueName, s)
 			}
 			store.Delete(suggKey)
 		}
 	}
 
 	// Clean up member stone (who they voted for).
-	store.Delete(memberStoneKey(domain.Name, memberAddr))
+	store.Delete(memberStoneKey(domainName, memberAddr))
+
+	k.removeMemberDelegations(ctx, domainName, memberAddr)
 }
 
 // --- Inactivity Cleanup (WP §3.1) ---
@@ -262,32 +287,21 @@ func (k Keeper) removeMember(ctx sdk.Context, domain *Domain, memberAddr string)
 // no activity for InactivityTimeoutSecs (360 days). Issues with
 // LastActivityAt == 0 use CreationDate as fallback.
 func (k Keeper) CleanupInactiveIssues(ctx sdk.Context, domainName string) error {
-	domain, found := k.GetDomain(ctx, domainName)
-	if !found {
-		return nil
-	}
-
 	now := ctx.BlockTime().Unix()
-	var kept []Issue
-	modified := false
-
-	for _, issue := range domain.Issues {
+	var expired []string
+	k.IterateIssues(ctx, domainName, func(issue Issue) bool {
 		lastActivity := issue.LastActivityAt
 		if lastActivity == 0 {
 			lastActivity = issue.CreationDate
 		}
 		if lastActivity > 0 && now-lastActivity > InactivityTimeoutSecs {
-			modified = true
-			continue // drop this issue
+			expired = append(expired, issue.Name)
 		}
-		kept = append(kept, issue)
-	}
+		return false
+	})
 
-	if modified {
-		domain.Issues = kept
-		store := ctx.KVStore(k.StoreKey)
-		bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-		store.Set([]byte("domain:"+domainName), bz)
+	for _, issueName := range expired {
+		k.deleteIssue(ctx, domainName, issueName)
 	}
 	return nil
 }
@@ -297,14 +311,12 @@ func (k Keeper) CleanupInactiveIssues(ctx sdk.Context, domainName string) error
 // TrackPayout increments a domain's cumulative TotalPayouts. This is used to
 // calculate the 10% stake transfer limit for validators.
 func (k Keeper) TrackPayout(ctx sdk.Context, domainName string, amount int64) error {
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
 	domain.TotalPayouts += amount
-	store := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	k.SetDomainHeader(ctx, domain)
 	return nil
 }
 
@@ -313,7 +325,7 @@ func (k Keeper) TrackPayout(ctx sdk.Context, domainName string, amount int64) er
 // (WP §7). The limit is: cumulative transferred stake ≤ 10% of domain's
 // total payouts. If domain has zero payouts, transfers are blocked.
 func (k Keeper) ValidateStakeTransfer(ctx sdk.Context, domainName, operatorAddr string, amount int64) error {
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
@@ -336,7 +348,7 @@ func (k Keeper) ValidateStakeTransfer(ctx sdk.Context, domainName, operatorAddr
 // Called from EndBlock.
 func (k Keeper) ProcessGovernance(ctx sdk.Context) {
 	var domainNames []string
-	k.IterateDomains(ctx, func(d Domain) bool {
+	k.IterateDomainHeaders(ctx, func(d Domain) bool {
 		domainNames = append(domainNames, d.Name)
 		return false
 	})
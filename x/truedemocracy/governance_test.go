@@ -32,9 +32,7 @@ func setupGovernanceDomain(t *testing.T, k Keeper, ctx sdk.Context) {
 		},
 	}
 
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:GovDomain"), bz)
+	k.SetDomain(ctx, domain)
 }
 
 // ---------- PlaceStoneOnMember ----------
@@ -154,9 +152,7 @@ func TestElectAdmin(t *testing.T) {
 		domain, _ := k.GetDomain(ctx, "EmptyDomain")
 		domain.Options.AdminElectable = true
 		domain.Members = []string{"a", "b", "c"}
-		st := ctx.KVStore(k.StoreKey)
-		bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-		st.Set([]byte("domain:EmptyDomain"), bz)
+		k.SetDomain(ctx, domain)
 
 		k.ElectAdmin(ctx, "EmptyDomain")
 
@@ -171,9 +167,7 @@ func TestElectAdmin(t *testing.T) {
 		domain, _ := k.GetDomain(ctx, "FixedAdmin")
 		domain.Options.AdminElectable = false
 		domain.Members = []string{"boss", "worker"}
-		st := ctx.KVStore(k.StoreKey)
-		bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-		st.Set([]byte("domain:FixedAdmin"), bz)
+		k.SetDomain(ctx, domain)
 
 		k.PlaceStoneOnMember(ctx, "FixedAdmin", "worker", "boss")
 		k.ElectAdmin(ctx, "FixedAdmin")
@@ -340,9 +334,7 @@ func TestInactivityPartialCleanup(t *testing.T) {
 	// Set Climate activity to now, but Education to 400 days ago.
 	domain, _ := k.GetDomain(ctx, "GovDomain")
 	domain.Issues[1].LastActivityAt = ctx.BlockTime().Unix() - 400*86400
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:GovDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	err := k.CleanupInactiveIssues(ctx, "GovDomain")
 	if err != nil {
@@ -365,9 +357,7 @@ func TestActivityResetsInactivityTimer(t *testing.T) {
 	// Set Climate activity to 350 days ago (almost expired).
 	domain, _ := k.GetDomain(ctx, "GovDomain")
 	domain.Issues[0].LastActivityAt = ctx.BlockTime().Unix() - 350*86400
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:GovDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	// Place a stone — this should update LastActivityAt to now.
 	k.PlaceStoneOnIssue(ctx, "GovDomain", "Climate", "alice")
@@ -396,9 +386,7 @@ func TestExternalLink(t *testing.T) {
 
 	domain, _ := k.GetDomain(ctx, "LinkDomain")
 	domain.Members = append(domain.Members, "alice")
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:LinkDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	// Submit with external link.
 	err := k.SubmitProposal(ctx, "LinkDomain", "Policy", "Plan", "alice",
@@ -419,9 +407,7 @@ func TestExternalLinkEmpty(t *testing.T) {
 
 	domain, _ := k.GetDomain(ctx, "NoLinkDomain")
 	domain.Members = append(domain.Members, "alice")
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:NoLinkDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	// Submit without external link.
 	err := k.SubmitProposal(ctx, "NoLinkDomain", "Policy", "Plan", "alice",
@@ -0,0 +1,279 @@
+package truedemocracy
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Identity commitments can be retired one leaf at a time instead of by a
+// Big Purge. Registration records which member owns which leaf:
+//
+//   "idleaf:{d}{member}{leaf}" → []byte{1}
+//
+// {member} is length-prefixed like {d} and {leaf} is the 8-byte big-endian
+// leaf index. The member ↔ commitment link is already public, since
+// MsgRegisterIdentity is signed by the member; ZKP ratings reveal only a
+// root and a nullifier, so this index does not link members to votes.
+//
+// A retired leaf is overwritten in place with RevokedIdentityLeaf and its
+// path rehashed, leaving every other leaf (and so every other member's
+// anonymity set) where it was. The previous root joins the root history like
+// on registration, so proofs other members are generating stay valid. The
+// retired commitment stays provable against those older roots until they
+// leave the MerkleRootHistorySize window. Commitments registered before leaf
+// owners were recorded have no owner and are only retired by the Big Purge.
+//
+// A rotation gives the member a second secret, and so fresh nullifiers in
+// every scope. To bound that, each member may rotate once per Big Purge
+// cycle:
+//
+//   "idrot:{d}{member}" → []byte{1}
+//
+// and a rotation withdraws the domain's anonymous stones, ballots and signals
+// like the Big Purge does, since the chain cannot tell which of them the old
+// secret cast. Their owners place them again with the same nullifiers.
+
+// RevokedIdentityLeaf is the value of a retired leaf. It is the all-zero
+// field element, which no registered commitment may take: nobody knows a
+// secret whose MiMC commitment is zero, so the leaf cannot be proven.
+var RevokedIdentityLeaf = strings.Repeat("0", 64)
+
+// IdentityRotation records that a member rotated a commitment in the current
+// Big Purge cycle.
+type IdentityRotation struct {
+	DomainName string `json:"domain_name"`
+	Member     string `json:"member"`
+}
+
+// IdentityLeafOwner records the member that registered a leaf.
+type IdentityLeafOwner struct {
+	DomainName string `json:"domain_name"`
+	Member     string `json:"member"`
+	Leaf       uint64 `json:"leaf"`
+}
+
+func identityLeafDomainPrefix(domainName string) []byte {
+	return append([]byte("idleaf:"), domainScope(domainName)...)
+}
+
+func identityLeafMemberPrefix(domainName, member string) []byte {
+	return append(identityLeafDomainPrefix(domainName), domainScope(member)...)
+}
+
+func identityLeafKey(domainName, member string, leaf uint64) []byte {
+	return binary.BigEndian.AppendUint64(identityLeafMemberPrefix(domainName, member), leaf)
+}
+
+func (k Keeper) setIdentityLeafOwner(ctx sdk.Context, domainName, member string, leaf uint64) {
+	ctx.KVStore(k.StoreKey).Set(identityLeafKey(domainName, member, leaf), []byte{1})
+}
+
+func identityRotationPrefix(domainName string) []byte {
+	return append([]byte("idrot:"), domainScope(domainName)...)
+}
+
+func identityRotationKey(domainName, member string) []byte {
+	return append(identityRotationPrefix(domainName), domainScope(member)...)
+}
+
+func (k Keeper) setIdentityRotated(ctx sdk.Context, domainName, member string) {
+	ctx.KVStore(k.StoreKey).Set(identityRotationKey(domainName, member), []byte{1})
+}
+
+// HasRotatedIdentity reports whether the member already rotated a commitment
+// in the domain's current Big Purge cycle.
+func (k Keeper) HasRotatedIdentity(ctx sdk.Context, domainName, member string) bool {
+	return ctx.KVStore(k.StoreKey).Has(identityRotationKey(domainName, member))
+}
+
+// GetIdentityRotations returns the members of a domain that rotated in the
+// current Big Purge cycle, in member key order.
+func (k Keeper) GetIdentityRotations(ctx sdk.Context, domainName string) []IdentityRotation {
+	prefix := identityRotationPrefix(domainName)
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	var rotations []IdentityRotation
+	for ; iter.Valid(); iter.Next() {
+		rest := iter.Key()[len(prefix):]
+		length, n := binary.Uvarint(rest)
+		if n <= 0 || uint64(len(rest)) != uint64(n)+length {
+			continue
+		}
+		rotations = append(rotations, IdentityRotation{DomainName: domainName, Member: string(rest[n:])})
+	}
+	return rotations
+}
+
+func (k Keeper) clearIdentityRotations(ctx sdk.Context, domainName string) {
+	store := ctx.KVStore(k.StoreKey)
+	prefix := identityRotationPrefix(domainName)
+	iter := store.Iterator(prefix, prefixEnd(prefix))
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, append([]byte{}, iter.Key()...))
+	}
+	iter.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// memberIdentityLeaves returns the live leaves registered by member.
+func (k Keeper) memberIdentityLeaves(ctx sdk.Context, domainName, member string) []uint64 {
+	prefix := identityLeafMemberPrefix(domainName, member)
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	var leaves []uint64
+	for ; iter.Valid(); iter.Next() {
+		leaves = append(leaves, binary.BigEndian.Uint64(iter.Key()[len(prefix):]))
+	}
+	return leaves
+}
+
+func (k Keeper) clearIdentityLeafOwners(ctx sdk.Context, domainName string) {
+	store := ctx.KVStore(k.StoreKey)
+	prefix := identityLeafDomainPrefix(domainName)
+	iter := store.Iterator(prefix, prefixEnd(prefix))
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, append([]byte{}, iter.Key()...))
+	}
+	iter.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// GetIdentityLeafOwners returns the recorded leaf owners of a domain, ordered
+// by member and leaf.
+func (k Keeper) GetIdentityLeafOwners(ctx sdk.Context, domainName string) []IdentityLeafOwner {
+	prefix := identityLeafDomainPrefix(domainName)
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	var owners []IdentityLeafOwner
+	for ; iter.Valid(); iter.Next() {
+		rest := iter.Key()[len(prefix):]
+		length, n := binary.Uvarint(rest)
+		if n <= 0 || uint64(len(rest)) != uint64(n)+length+8 {
+			continue
+		}
+		owners = append(owners, IdentityLeafOwner{
+			DomainName: domainName,
+			Member:     string(rest[n : uint64(n)+length]),
+			Leaf:       binary.BigEndian.Uint64(rest[uint64(n)+length:]),
+		})
+	}
+	return owners
+}
+
+// retireIdentityLeaf overwrites the leaf with RevokedIdentityLeaf, drops the
+// commitment from the duplicate index and the owner record, and returns the
+// rehashed root.
+func (k Keeper) retireIdentityLeaf(ctx sdk.Context, tree identityTree, member string, leaf uint64) ([]byte, error) {
+	store := ctx.KVStore(k.StoreKey)
+	scope := domainScope(tree.domainName)
+	if bz := store.Get(domainCommits.recordKey(scope, leaf)); bz != nil {
+		if idx, ok := domainCommits.lookup(store, scope, string(bz)); ok && idx == leaf {
+			store.Delete(domainCommits.indexKey(scope, string(bz)))
+		}
+	}
+	store.Set(domainCommits.recordKey(scope, leaf), []byte(RevokedIdentityLeaf))
+	store.Delete(identityLeafKey(tree.domainName, member, leaf))
+	return k.insertMerkleLeaf(ctx, tree, leaf)
+}
+
+// RevokeMemberCommitments retires every leaf the member registered in the
+// domain. It runs when a member is removed; the other leaves are untouched.
+func (k Keeper) RevokeMemberCommitments(ctx sdk.Context, domainName, member string) error {
+	leaves := k.memberIdentityLeaves(ctx, domainName, member)
+	if len(leaves) == 0 {
+		return nil
+	}
+	domain, found := k.GetDomainHeader(ctx, domainName)
+	if !found {
+		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
+	}
+	tree := domainIdentityTree(domain)
+	var root []byte
+	for _, leaf := range leaves {
+		var err error
+		if root, err = k.retireIdentityLeaf(ctx, tree, member, leaf); err != nil {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "failed to update Merkle tree: "+err.Error())
+		}
+	}
+	pushMerkleRootHistory(&domain)
+	domain.MerkleRoot = hex.EncodeToString(root)
+	k.SetDomainHeader(ctx, domain)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"identity_commitment_revoked",
+		sdk.NewAttribute("domain", domainName),
+		sdk.NewAttribute("member", member),
+		sdk.NewAttribute("leaves", fmt.Sprintf("%d", len(leaves))),
+	))
+	return nil
+}
+
+// RotateIdentityCommitment replaces one of the member's commitments with a
+// new one: the old leaf is retired and the new commitment appended. Ratings
+// already cast keep their nullifiers; the new secret derives fresh ones, so
+// a member rotates at most once per Big Purge cycle and the domain's
+// anonymous stones, ballots and signals are withdrawn.
+func (k Keeper) RotateIdentityCommitment(ctx sdk.Context, domainName, memberAddr, oldCommitmentHex, newCommitmentHex string) error {
+	domain, found := k.GetDomainHeader(ctx, domainName)
+	if !found {
+		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
+	}
+	if !k.IsDomainMember(ctx, domainName, memberAddr) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only domain members can rotate identity commitments")
+	}
+	oldBytes, err := HexToFieldElement(oldCommitmentHex)
+	if err != nil || len(oldCommitmentHex) != 64 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "old commitment must be 32 bytes hex-encoded (64 hex chars)")
+	}
+	newBytes, err := HexToFieldElement(newCommitmentHex)
+	if err != nil || len(newCommitmentHex) != 64 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "new commitment must be 32 bytes hex-encoded (64 hex chars)")
+	}
+	oldCommitmentHex = hex.EncodeToString(oldBytes)
+	newCommitmentHex = hex.EncodeToString(newBytes)
+	if newCommitmentHex == RevokedIdentityLeaf {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "commitment cannot be zero")
+	}
+
+	store := ctx.KVStore(k.StoreKey)
+	leaf, ok := domainCommits.lookup(store, domainScope(domainName), oldCommitmentHex)
+	if !ok || !store.Has(identityLeafKey(domainName, memberAddr, leaf)) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "old commitment is not registered by this member")
+	}
+	if k.hasIdentityCommit(ctx, domainName, newCommitmentHex) {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "commitment already registered")
+	}
+	if k.HasRotatedIdentity(ctx, domainName, memberAddr) {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "identity commitment already rotated in this Big Purge cycle")
+	}
+
+	tree := domainIdentityTree(domain)
+	if _, err := k.retireIdentityLeaf(ctx, tree, memberAddr, leaf); err != nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "failed to update Merkle tree: "+err.Error())
+	}
+	newLeaf := k.appendIdentityCommit(ctx, domainName, newCommitmentHex)
+	root, err := k.insertMerkleLeaf(ctx, tree, newLeaf)
+	if err != nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "failed to update Merkle tree: "+err.Error())
+	}
+	k.setIdentityLeafOwner(ctx, domainName, memberAddr, newLeaf)
+	k.setIdentityRotated(ctx, domainName, memberAddr)
+	k.purgeAnonymousVotes(ctx, domainName)
+
+	pushMerkleRootHistory(&domain)
+	domain.MerkleRoot = hex.EncodeToString(root)
+	k.SetDomainHeader(ctx, domain)
+	return nil
+}
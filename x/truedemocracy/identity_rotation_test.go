@@ -0,0 +1,253 @@
+package truedemocracy
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func commitmentHex(t *testing.T, secret []byte) string {
+	t.Helper()
+	commitment, err := ComputeCommitment(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(commitment)
+}
+
+func TestRotateIdentityCommitment(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	secrets := setupDomainWithZKPIdentity(t, k, ctx, "RotDomain", 3)
+	addProposal(t, k, ctx, "RotDomain", "Climate", "GreenDeal")
+	before := k.GetIdentityCommits(ctx, "RotDomain")
+	header, _ := k.GetDomainHeader(ctx, "RotDomain")
+	oldRoot := header.MerkleRoot
+	// Another member is proving against the current root while memberA rotates.
+	inFlightProof, inFlightNullifier := generateZKPRating(t, k, ctx, "RotDomain", secrets, 1, "Climate", "GreenDeal", 3)
+	stoneProof, stoneNullifier := generateScopedProof(t, k, ctx, "RotDomain", secrets, 2,
+		ComputeStoneNullifierScope(ctx.ChainID(), "RotDomain", StoneListIssue, ""),
+		ComputeStoneSignal(ctx.ChainID(), "RotDomain", StoneListIssue, "", "Climate"))
+	if err := k.PlaceStoneWithProof(ctx, "RotDomain", StoneListIssue, "", "Climate", stoneProof, stoneNullifier, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	memberA := sdk.AccAddress("memberA").String()
+	newSecret := big.NewInt(900).Bytes()
+	if err := k.RotateIdentityCommitment(ctx, "RotDomain", memberA, before[0], commitmentHex(t, newSecret)); err != nil {
+		t.Fatal(err)
+	}
+
+	commits := k.GetIdentityCommits(ctx, "RotDomain")
+	if len(commits) != 4 || commits[0] != RevokedIdentityLeaf || commits[1] != before[1] || commits[2] != before[2] {
+		t.Fatalf("commitments after rotation = %v", commits)
+	}
+	header, _ = k.GetDomainHeader(ctx, "RotDomain")
+	if header.MerkleRoot != fullTreeFor(t, commits).GetRoot() {
+		t.Fatal("stored root differs from full rebuild")
+	}
+	if history := header.MerkleRootHistory; len(history) == 0 || history[len(history)-1] != oldRoot {
+		t.Fatalf("previous root not kept in history: %v", history)
+	}
+	if owners := k.memberIdentityLeaves(ctx, "RotDomain", memberA); !reflect.DeepEqual(owners, []uint64{3}) {
+		t.Fatalf("memberA leaves = %v, want [3]", owners)
+	}
+
+	if _, err := k.MerkleProof(ctx, &QueryMerkleProofRequest{DomainName: "RotDomain", Commitment: before[0]}); err == nil {
+		t.Fatal("proof served for the retired commitment")
+	}
+	// Proofs generated against the previous root stay valid.
+	if _, err := k.RateProposalWithZKP(ctx, "RotDomain", "Climate", "GreenDeal", 3, inFlightProof, inFlightNullifier, oldRoot, testRewardRecipient()); err != nil {
+		t.Fatalf("in-flight proof rejected: %v", err)
+	}
+	// Anonymous stones are withdrawn; their owner places them again.
+	if issue, _ := k.GetIssue(ctx, "RotDomain", "Climate"); issue.Stones != 0 {
+		t.Fatalf("issue stones after rotation = %d, want 0", issue.Stones)
+	}
+	if err := k.PlaceStoneWithProof(ctx, "RotDomain", StoneListIssue, "", "Climate", stoneProof, stoneNullifier, oldRoot); err != nil {
+		t.Fatalf("withdrawn stone not placed again: %v", err)
+	}
+	// One rotation per Big Purge cycle.
+	if err := k.RotateIdentityCommitment(ctx, "RotDomain", memberA, commitmentHex(t, newSecret), commitmentHex(t, big.NewInt(903).Bytes())); err == nil {
+		t.Fatal("second rotation in the cycle accepted")
+	}
+
+	// The new commitment proves membership, as do the untouched ones.
+	secrets = append(secrets, newSecret)
+	for _, leaf := range []int{3, 2} {
+		proof, nullifier := generateZKPRating(t, k, ctx, "RotDomain", secrets, leaf, "Climate", "GreenDeal", 4)
+		if _, err := k.RateProposalWithZKP(ctx, "RotDomain", "Climate", "GreenDeal", 4, proof, nullifier, "", testRewardRecipient()); err != nil {
+			t.Fatalf("leaf %d rating: %v", leaf, err)
+		}
+	}
+}
+
+func TestRotateIdentityCommitmentRejects(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupDomainWithZKPIdentity(t, k, ctx, "RotDomain", 2)
+	commits := k.GetIdentityCommits(ctx, "RotDomain")
+	memberA := sdk.AccAddress("memberA").String()
+	fresh := commitmentHex(t, big.NewInt(901).Bytes())
+
+	tests := []struct {
+		name, member, oldHex, newHex string
+	}{
+		{"non-member", sdk.AccAddress("outsider").String(), commits[0], fresh},
+		{"another member's commitment", memberA, commits[1], fresh},
+		{"unknown commitment", memberA, fresh, commitmentHex(t, big.NewInt(902).Bytes())},
+		{"zero commitment", memberA, commits[0], RevokedIdentityLeaf},
+		{"registered commitment", memberA, commits[0], commits[1]},
+		{"revoked leaf", memberA, RevokedIdentityLeaf, fresh},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := k.RotateIdentityCommitment(ctx, "RotDomain", tc.member, tc.oldHex, tc.newHex); err == nil {
+				t.Fatal("rotation accepted")
+			}
+		})
+	}
+	if err := k.RegisterIdentityCommitment(ctx, "RotDomain", memberA, RevokedIdentityLeaf); err == nil {
+		t.Fatal("zero commitment registered")
+	}
+	if got := k.GetIdentityCommits(ctx, "RotDomain"); !reflect.DeepEqual(got, commits) {
+		t.Fatalf("rejected rotations changed commitments: %v", got)
+	}
+}
+
+func TestRemovedMemberLeafRevokedOthersStillProve(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	secrets := setupDomainWithZKPIdentity(t, k, ctx, "RevDomain", 3)
+	addProposal(t, k, ctx, "RevDomain", "Climate", "GreenDeal")
+	before := k.GetIdentityCommits(ctx, "RevDomain")
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	if err := k.removeMember(ctx, "RevDomain", sdk.AccAddress("memberB").String()); err != nil {
+		t.Fatal(err)
+	}
+
+	commits := k.GetIdentityCommits(ctx, "RevDomain")
+	if !reflect.DeepEqual(commits, []string{before[0], RevokedIdentityLeaf, before[2]}) {
+		t.Fatalf("commitments after removal = %v", commits)
+	}
+	header, _ := k.GetDomainHeader(ctx, "RevDomain")
+	if header.MerkleRoot != fullTreeFor(t, commits).GetRoot() || len(header.MerkleRootHistory) == 0 {
+		t.Fatal("root not rehashed or previous root not kept")
+	}
+	revoked := false
+	for _, event := range ctx.EventManager().Events() {
+		revoked = revoked || event.Type == "identity_commitment_revoked"
+	}
+	if !revoked {
+		t.Fatal("identity_commitment_revoked event missing")
+	}
+
+	// The removed member's commitment is no longer in the tree.
+	if _, err := k.MerkleProof(ctx, &QueryMerkleProofRequest{DomainName: "RevDomain", Commitment: before[1]}); err == nil {
+		t.Fatal("proof served for the removed member's commitment")
+	}
+	// The others keep their leaves and prove against the current root.
+	for _, leaf := range []int{0, 2} {
+		proof, nullifier := generateZKPRating(t, k, ctx, "RevDomain", secrets, leaf, "Climate", "GreenDeal", 3)
+		if _, err := k.RateProposalWithZKP(ctx, "RevDomain", "Climate", "GreenDeal", 3, proof, nullifier, "", testRewardRecipient()); err != nil {
+			t.Fatalf("leaf %d rating: %v", leaf, err)
+		}
+	}
+}
+
+func TestExclusionFailsWholeWhenRevocationFails(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	commits := setupDomainWithCommitments(t, k, ctx, "Broken", 2)
+	target := sdk.AccAddress("Broken-member-0").String()
+	// A malformed sibling leaf makes rehashing the target's path fail.
+	ctx.KVStore(k.StoreKey).Set(domainCommits.recordKey(domainScope("Broken"), 1), []byte("zz"))
+
+	if _, err := k.VoteToExclude(ctx, "Broken", target, sdk.AccAddress("Broken-admin").String()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.VoteToExclude(ctx, "Broken", target, sdk.AccAddress("Broken-member-1").String()); err == nil {
+		t.Fatal("exclusion succeeded although revocation failed")
+	}
+	if !k.IsDomainMember(ctx, "Broken", target) {
+		t.Fatal("member removed although revocation failed")
+	}
+	if got := k.GetIdentityCommits(ctx, "Broken")[0]; got != commits[0] {
+		t.Fatalf("leaf 0 = %s, want the untouched commitment", got)
+	}
+}
+
+func TestGenesisRoundTripKeepsIdentityLeafOwners(t *testing.T) {
+	am1, k1, ctx1 := setupModuleForGenesis(t)
+	admin := sdk.AccAddress("admin1")
+	k1.CreateDomain(ctx1, "Solo", admin, sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 1_000)))
+	first, second := commitmentHex(t, big.NewInt(910).Bytes()), commitmentHex(t, big.NewInt(911).Bytes())
+	for _, commitment := range []string{first, second} {
+		if err := k1.RegisterIdentityCommitment(ctx1, "Solo", admin.String(), commitment); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := k1.RotateIdentityCommitment(ctx1, "Solo", admin.String(), first, commitmentHex(t, big.NewInt(912).Bytes())); err != nil {
+		t.Fatal(err)
+	}
+
+	exported := am1.ExportGenesis(ctx1, nil)
+	am2, k2, ctx2 := setupModuleForGenesis(t)
+	am2.InitGenesis(ctx2, nil, exported)
+
+	if got, want := k2.GetIdentityLeafOwners(ctx2, "Solo"), k1.GetIdentityLeafOwners(ctx1, "Solo"); !reflect.DeepEqual(got, want) || len(got) != 2 {
+		t.Fatalf("owners after import = %+v, want %+v", got, want)
+	}
+	if got := k2.GetIdentityCommits(ctx2, "Solo"); got[0] != RevokedIdentityLeaf {
+		t.Fatalf("retired leaf not kept in place: %v", got)
+	}
+	// The rotation of this cycle carries over.
+	if err := k2.RotateIdentityCommitment(ctx2, "Solo", admin.String(), second, commitmentHex(t, big.NewInt(913).Bytes())); err == nil {
+		t.Fatal("second rotation after import accepted")
+	}
+	// Ownership carries over: after the Big Purge the member rotates again.
+	k2.clearIdentityRotations(ctx2, "Solo")
+	if err := k2.RotateIdentityCommitment(ctx2, "Solo", admin.String(), second, commitmentHex(t, big.NewInt(913).Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	header, _ := k2.GetDomainHeader(ctx2, "Solo")
+	if header.MerkleRoot != fullTreeFor(t, k2.GetIdentityCommits(ctx2, "Solo")).GetRoot() {
+		t.Fatal("root after import and rotation differs from full rebuild")
+	}
+}
+
+func TestGenesisIdentityLeafOwners(t *testing.T) {
+	member := sdk.AccAddress("genesis-admin").String()
+	live := commitmentHex(t, big.NewInt(920).Bytes())
+	genesis := validDemocracyGenesis()
+	commits := []string{RevokedIdentityLeaf, live, RevokedIdentityLeaf}
+	genesis.Domains[0].IdentityCommits = commits
+	genesis.Domains[0].MerkleRoot = fullTreeFor(t, commits).GetRoot()
+	genesis.IdentityLeafOwners = []IdentityLeafOwner{{DomainName: "Test", Member: member, Leaf: 1}}
+	if err := ValidateGenesisState(genesis); err != nil {
+		t.Fatalf("valid leaf owners rejected: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*GenesisState)
+	}{
+		{"missing domain", func(g *GenesisState) { g.IdentityLeafOwners[0].DomainName = "Missing" }},
+		{"non-member", func(g *GenesisState) { g.IdentityLeafOwners[0].Member = sdk.AccAddress("outsider").String() }},
+		{"leaf out of range", func(g *GenesisState) { g.IdentityLeafOwners[0].Leaf = 3 }},
+		{"revoked leaf", func(g *GenesisState) { g.IdentityLeafOwners[0].Leaf = 0 }},
+		{"duplicate owner", func(g *GenesisState) {
+			g.IdentityLeafOwners = append(g.IdentityLeafOwners, g.IdentityLeafOwners[0])
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := genesis
+			g.IdentityLeafOwners = append([]IdentityLeafOwner(nil), genesis.IdentityLeafOwners...)
+			tc.mutate(&g)
+			if err := ValidateGenesisState(g); err == nil {
+				t.Fatal("invalid genesis accepted")
+			}
+		})
+	}
+}
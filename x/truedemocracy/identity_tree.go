@@ -0,0 +1,209 @@
+package truedemocracy
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// The identity commitments of a domain form an incremental Merkle tree of
+// depth MerkleTreeDepth whose interior nodes are persisted:
+//
+//   "dcommit:{d}{seq}"              → leaf seq (commitment hex, domain_store.go)
+//   "mnode:{d}{level}{index}"      → 32-byte node hash, levels 1..depth
+//
+// {level} is one byte and {index} an 8-byte big-endian position within the
+// level. Absent nodes are empty subtrees and hash to the zero of their level.
+// Nodes are hashed with the hash family of the domain's membership circuit.
+// Commitments are only appended (and cleared wholesale by the Big Purge), so
+// a leaf's commitment sequence is its leaf index and an insertion rehashes
+// just the depth nodes on its path, reading each sibling from the store. The
+// left siblings on that path are the tree's frontier.
+
+// merkleZeros holds the empty-subtree hash of every level, per hash family.
+var merkleZeros = func() map[string][][]byte {
+	zeros := make(map[string][][]byte, len(familyHashes))
+	for family, hashFn := range familyHashes {
+		zeros[family] = zeroValues(hashFn, MerkleTreeDepth)
+	}
+	return zeros
+}()
+
+// identityTree is the hashing of one domain's identity tree.
+type identityTree struct {
+	domainName string
+	hash       func(...*big.Int) []byte
+	zeros      [][]byte
+}
+
+// domainIdentityTree returns the hashing of the domain's identity tree, which
+// follows the hash family of the domain's membership circuit. Callers pass
+// the header they already hold, so tree updates cost the same gas however
+// large the domain grows.
+func domainIdentityTree(domain Domain) identityTree {
+	family := domain.Options.hashFamily()
+	return identityTree{domainName: domain.Name, hash: familyHashes[family], zeros: merkleZeros[family]}
+}
+
+func merkleNodePrefix(domainName string) []byte {
+	return append([]byte("mnode:"), domainScope(domainName)...)
+}
+
+func merkleNodeKey(domainName string, level int, index uint64) []byte {
+	key := append(merkleNodePrefix(domainName), byte(level))
+	return binary.BigEndian.AppendUint64(key, index)
+}
+
+// merkleNode returns the hash of the node at level and index. Level 0 nodes
+// are the commitments themselves.
+func (k Keeper) merkleNode(ctx sdk.Context, tree identityTree, level int, index uint64) ([]byte, error) {
+	store := ctx.KVStore(k.StoreKey)
+	if level == 0 {
+		bz := store.Get(domainCommits.recordKey(domainScope(tree.domainName), index))
+		if bz == nil {
+			return tree.zeros[0], nil
+		}
+		leaf, err := hex.DecodeString(string(bz))
+		if err != nil || len(leaf) != 32 {
+			return nil, fmt.Errorf("malformed identity commitment at leaf %d", index)
+		}
+		return leaf, nil
+	}
+	if bz := store.Get(merkleNodeKey(tree.domainName, level, index)); bz != nil {
+		return bz, nil
+	}
+	return tree.zeros[level], nil
+}
+
+// insertMerkleLeaf rehashes the path from the leaf at index to the root and
+// returns the new root. The leaf must already be stored as a commitment.
+func (k Keeper) insertMerkleLeaf(ctx sdk.Context, tree identityTree, index uint64) ([]byte, error) {
+	if index >= 1<<MerkleTreeDepth {
+		return nil, fmt.Errorf("identity tree is full (%d leaves)", uint64(1)<<MerkleTreeDepth)
+	}
+	store := ctx.KVStore(k.StoreKey)
+	node, err := k.merkleNode(ctx, tree, 0, index)
+	if err != nil {
+		return nil, err
+	}
+	for level := 0; level < MerkleTreeDepth; level++ {
+		sibling, err := k.merkleNode(ctx, tree, level, index^1)
+		if err != nil {
+			return nil, err
+		}
+		left, right := node, sibling
+		if index%2 == 1 {
+			left, right = sibling, node
+		}
+		node = tree.hash(new(big.Int).SetBytes(left), new(big.Int).SetBytes(right))
+		index /= 2
+		store.Set(merkleNodeKey(tree.domainName, level+1, index), node)
+	}
+	return node, nil
+}
+
+// merkleProof reads the sibling path of the leaf at index from the stored
+// nodes and returns it with the current root. pathIndices marks 0 = the
+// path node is a left child, 1 = a right child.
+func (k Keeper) merkleProof(ctx sdk.Context, tree identityTree, index uint64) ([][]byte, []int, []byte, error) {
+	siblings := make([][]byte, MerkleTreeDepth)
+	pathIndices := make([]int, MerkleTreeDepth)
+	for level := 0; level < MerkleTreeDepth; level++ {
+		sibling, err := k.merkleNode(ctx, tree, level, index^1)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		siblings[level] = sibling
+		pathIndices[level] = int(index % 2)
+		index /= 2
+	}
+	root, err := k.merkleNode(ctx, tree, MerkleTreeDepth, 0)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return siblings, pathIndices, root, nil
+}
+
+func (k Keeper) clearMerkleNodes(ctx sdk.Context, domainName string) {
+	store := ctx.KVStore(k.StoreKey)
+	prefix := merkleNodePrefix(domainName)
+	iter := store.Iterator(prefix, prefixEnd(prefix))
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, append([]byte{}, iter.Key()...))
+	}
+	iter.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// rebuildMerkleNodes recomputes every stored node of a domain's identity tree
+// from its commitments, one level at a time. It serves genesis import and the
+// store migration; transactions use insertMerkleLeaf.
+func (k Keeper) rebuildMerkleNodes(ctx sdk.Context, tree identityTree) error {
+	domainName := tree.domainName
+	k.clearMerkleNodes(ctx, domainName)
+	commits := k.GetIdentityCommits(ctx, domainName)
+	if len(commits) > 1<<MerkleTreeDepth {
+		return fmt.Errorf("domain %s has too many identity commitments", domainName)
+	}
+	level := make([][]byte, len(commits))
+	for i, commit := range commits {
+		leaf, err := hex.DecodeString(commit)
+		if err != nil || len(leaf) != 32 {
+			return fmt.Errorf("domain %s has a malformed identity commitment at leaf %d", domainName, i)
+		}
+		level[i] = leaf
+	}
+	if len(level) == 0 {
+		return nil
+	}
+	store := ctx.KVStore(k.StoreKey)
+	for depth := 0; depth < MerkleTreeDepth; depth++ {
+		next := make([][]byte, (len(level)+1)/2)
+		for i := range next {
+			right := tree.zeros[depth]
+			if 2*i+1 < len(level) {
+				right = level[2*i+1]
+			}
+			next[i] = tree.hash(new(big.Int).SetBytes(level[2*i]), new(big.Int).SetBytes(right))
+			store.Set(merkleNodeKey(domainName, depth+1, uint64(i)), next[i])
+		}
+		level = next
+	}
+	return nil
+}
+
+// MigrateIdentityTrees builds the stored identity tree of every domain that
+// has identity commitments. Version 4 stores the tree nodes; earlier versions
+// recomputed the tree from the commitments on every registration and query.
+// The rebuilt root must match the domain's MerkleRoot, or proofs against the
+// stored root would stop verifying, so a mismatch fails the migration.
+func (k Keeper) MigrateIdentityTrees(ctx sdk.Context) error {
+	var domains []Domain
+	k.IterateDomainHeaders(ctx, func(d Domain) bool {
+		domains = append(domains, d)
+		return false
+	})
+	for _, domain := range domains {
+		tree := domainIdentityTree(domain)
+		if err := k.rebuildMerkleNodes(ctx, tree); err != nil {
+			return err
+		}
+		if domain.MerkleRoot == "" && domainCommits.count(ctx.KVStore(k.StoreKey), domainScope(domain.Name)) == 0 {
+			continue
+		}
+		root, err := k.merkleNode(ctx, tree, MerkleTreeDepth, 0)
+		if err != nil {
+			return err
+		}
+		if got := hex.EncodeToString(root); got != domain.MerkleRoot {
+			return fmt.Errorf("domain %s: rebuilt identity root %s does not match stored root %q", domain.Name, got, domain.MerkleRoot)
+		}
+	}
+	return nil
+}
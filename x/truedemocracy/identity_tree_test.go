@@ -0,0 +1,133 @@
+package truedemocracy
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// fullTreeFor rebuilds the reference in-memory tree over commits.
+func fullTreeFor(t *testing.T, commits []string) *MerkleTree {
+	t.Helper()
+	leaves := make([][]byte, len(commits))
+	for i, commit := range commits {
+		leaves[i], _ = hex.DecodeString(commit)
+	}
+	tree := NewMerkleTree(MerkleTreeDepth)
+	if err := tree.BuildFromLeaves(leaves); err != nil {
+		t.Fatal(err)
+	}
+	return tree
+}
+
+func TestIncrementalTreeMatchesFullRebuild(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	commits := setupDomainWithCommitments(t, k, ctx, "TreeDomain", 7)
+
+	tree := fullTreeFor(t, commits)
+	header, _ := k.GetDomainHeader(ctx, "TreeDomain")
+	if header.MerkleRoot != tree.GetRoot() {
+		t.Fatalf("incremental root %s, full rebuild %s", header.MerkleRoot, tree.GetRoot())
+	}
+	// Every intermediate root entered the history in order.
+	for i, root := range header.MerkleRootHistory {
+		if want := fullTreeFor(t, commits[:i+1]).GetRoot(); root != want {
+			t.Fatalf("history[%d] = %s, want %s", i, root, want)
+		}
+	}
+
+	for leaf := range commits {
+		siblings, pathIndices, root, err := k.merkleProof(ctx, domainIdentityTree(header), uint64(leaf))
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantSiblings, wantIndices, _ := tree.GenerateProof(leaf)
+		for level := range siblings {
+			if !bytes.Equal(siblings[level], wantSiblings[level]) || pathIndices[level] != wantIndices[level] {
+				t.Fatalf("leaf %d level %d: stored path differs from full rebuild", leaf, level)
+			}
+		}
+		if hex.EncodeToString(root) != header.MerkleRoot {
+			t.Fatalf("leaf %d: stored root differs", leaf)
+		}
+	}
+}
+
+func TestMerkleInsertionGasIndependentOfTreeSize(t *testing.T) {
+	// Leaves 4 and 64 are each the first leaf of a fresh subtree whose only
+	// non-empty sibling sits on one level, so their paths read and write the
+	// same number of stored nodes; gas must not depend on the leaves before.
+	measure := func(leaves int) storetypes.Gas {
+		k, ctx := setupKeeper(t)
+		setupDomainWithCommitments(t, k, ctx, "GasTree", leaves)
+		next := fmt.Sprintf("%064x", 0xfeed)
+		index := k.appendIdentityCommit(ctx, "GasTree", next)
+		header, _ := k.GetDomainHeader(ctx, "GasTree")
+		return gasUsed(ctx, func(ctx sdk.Context) {
+			if _, err := k.insertMerkleLeaf(ctx, domainIdentityTree(header), index); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+	if small, large := measure(4), measure(64); small != large {
+		t.Fatalf("insertion gas grew with tree size: %d -> %d", small, large)
+	}
+}
+
+func TestBigPurgeClearsStoredTree(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	commits := setupDomainWithCommitments(t, k, ctx, "PurgeTree", 3)
+	k.executeBigPurge(ctx, "PurgeTree")
+
+	prefix := merkleNodePrefix("PurgeTree")
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	stale := iter.Valid()
+	iter.Close()
+	if stale {
+		t.Fatal("tree nodes survived the Big Purge")
+	}
+
+	// The next registration starts a fresh tree at leaf 0.
+	member := sdk.AccAddress("PurgeTree-member-0").String()
+	if err := k.RegisterIdentityCommitment(ctx, "PurgeTree", member, commits[0]); err != nil {
+		t.Fatal(err)
+	}
+	header, _ := k.GetDomainHeader(ctx, "PurgeTree")
+	if want := fullTreeFor(t, commits[:1]).GetRoot(); header.MerkleRoot != want {
+		t.Fatalf("root after purge = %s, want %s", header.MerkleRoot, want)
+	}
+}
+
+func TestMigrateIdentityTreesBuildsStoredNodes(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	commits := setupDomainWithCommitments(t, k, ctx, "LegacyTree", 5)
+	// Version 3 state: commitments and root without stored nodes.
+	k.clearMerkleNodes(ctx, "LegacyTree")
+	if _, err := k.MerkleProof(ctx, &QueryMerkleProofRequest{DomainName: "LegacyTree", Commitment: commits[2]}); err == nil {
+		t.Fatal("proof served without stored nodes")
+	}
+
+	if err := k.MigrateIdentityTrees(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.MerkleProof(ctx, &QueryMerkleProofRequest{DomainName: "LegacyTree", Commitment: commits[2]}); err != nil {
+		t.Fatalf("proof after migration: %v", err)
+	}
+	header, _ := k.GetDomainHeader(ctx, "LegacyTree")
+	_, _, root, _ := k.merkleProof(ctx, domainIdentityTree(header), 0)
+	if hex.EncodeToString(root) != fullTreeFor(t, commits).GetRoot() {
+		t.Fatal("migrated root differs from full rebuild")
+	}
+
+	// A stored root the commitments do not hash to fails the migration.
+	header.MerkleRoot = strings.Repeat("0", 63) + "1"
+	k.SetDomainHeader(ctx, header)
+	if err := k.MigrateIdentityTrees(ctx); err == nil || !strings.Contains(err.Error(), "LegacyTree") {
+		t.Fatalf("root mismatch accepted: %v", err)
+	}
+}
@@ -30,6 +30,7 @@ func TestEndBlockAggregateIssuanceStopsExactlyAtCap(t *testing.T) {
 
 	keeper, ctx := setupKeeper(t)
 	setupDomainWithValidator(t, keeper, ctx)
+	seatValidator(keeper, ctx, "oper1")
 	initializeRewardTimers(keeper, ctx)
 	domain, _ := keeper.GetDomain(ctx, "TestDomain")
 	domain.TotalPayouts = 100_000 * PNYXUnit
@@ -52,9 +53,8 @@ func TestEndBlockAggregateIssuanceStopsExactlyAtCap(t *testing.T) {
 	if supply := bank.GetSupply(ctx, PNYXDenom).Amount; !supply.Equal(token.MaxSupply()) {
 		t.Fatalf("supply after aggregate rewards = %s, want %s", supply, token.MaxSupply())
 	}
-	validator, _ := keeper.GetValidator(ctx, "oper1")
-	if got := validator.Stake.AmountOf(PNYXDenom); !got.Equal(math.NewInt(rewards.StakeMin + 1)) {
-		t.Fatalf("validator received %s stake, want final cap unit", got)
+	if got := keeper.ClaimableStakingRewards(ctx, "oper1", ""); !got.Equal(math.OneInt()) {
+		t.Fatalf("validator accrued %s, want final cap unit", got)
 	}
 	domain, _ = keeper.GetDomain(ctx, "TestDomain")
 	if got := domain.Treasury.AmountOf(PNYXDenom); !got.Equal(math.NewInt(500_000 * PNYXUnit)) {
@@ -68,6 +68,7 @@ func TestEndBlockAggregateIssuanceStopsExactlyAtCap(t *testing.T) {
 func TestStakingMintFailureRollsBackClaimsAndTimer(t *testing.T) {
 	keeper, ctx := setupKeeper(t)
 	setupDomainWithValidator(t, keeper, ctx)
+	seatValidator(keeper, ctx, "oper1")
 	initializeRewardTimers(keeper, ctx)
 	bank := backExistingEscrow(&keeper, ctx)
 	bank.failMint = true
@@ -99,6 +100,7 @@ func TestStakingMintFailureRollsBackClaimsAndTimer(t *testing.T) {
 func TestEndBlockDomainMintFailureRollsBackRewardClaimsAndTimers(t *testing.T) {
 	keeper, ctx := setupKeeper(t)
 	setupDomainWithValidator(t, keeper, ctx)
+	seatValidator(keeper, ctx, "oper1")
 	initializeRewardTimers(keeper, ctx)
 	domain, _ := keeper.GetDomain(ctx, "TestDomain")
 	domain.TotalPayouts = 100_000 * PNYXUnit
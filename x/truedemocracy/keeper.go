@@ -43,6 +43,7 @@ type Keeper struct {
 	bankKeeper       BankKeeper // nil until x/bank is wired (bridge functions check)
 	issuer           token.IssuanceService
 	upgradeScheduler UpgradeScheduler // nil fails closed for software-upgrade governance
+	verifiedProofs   *verifiedProofCache
 }
 
 func NewKeeper(cdc *codec.LegacyAmino, storeKey storetypes.StoreKey, nodes []*Node, bankKeeper BankKeeper, upgradeScheduler UpgradeScheduler) Keeper {
@@ -53,23 +54,11 @@ func NewKeeper(cdc *codec.LegacyAmino, storeKey storetypes.StoreKey, nodes []*No
 		bankKeeper:       bankKeeper,
 		issuer:           token.NewIssuanceService(bankKeeper, ModuleName),
 		upgradeScheduler: upgradeScheduler,
+		verifiedProofs:   newVerifiedProofCache(),
 	}
 }
 
-// GetDomain loads a domain from the KV store by name.
-func (k Keeper) GetDomain(ctx sdk.Context, name string) (Domain, bool) {
-	store := ctx.KVStore(k.StoreKey)
-	bz := store.Get([]byte("domain:" + name))
-	if bz == nil {
-		return Domain{}, false
-	}
-	var domain Domain
-	k.cdc.MustUnmarshalLengthPrefixed(bz, &domain)
-	return domain, true
-}
-
 func (k Keeper) CreateDomain(ctx sdk.Context, name string, admin sdk.AccAddress, initialCoins sdk.Coins) {
-	store := ctx.KVStore(k.StoreKey)
 	domain := Domain{
 		Name:          name,
 		Admin:         admin,
@@ -79,8 +68,7 @@ func (k Keeper) CreateDomain(ctx sdk.Context, name string, admin sdk.AccAddress,
 		Options:       DomainOptions{AdminElectable: true, AnyoneCanJoin: false},
 		PermissionReg: []string{},
 	}
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+name), bz)
+	k.SetDomain(ctx, domain)
 
 	// Initialize automated Big Purge schedule (WP S4).
 	k.InitializeBigPurgeSchedule(ctx, name)
@@ -92,7 +80,7 @@ func (k Keeper) AddMember(ctx sdk.Context, domainName, newMember string, caller
 	if domainName == ReservedGovernanceDomain {
 		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "governance electorate is immutable after genesis")
 	}
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
@@ -101,28 +89,22 @@ func (k Keeper) AddMember(ctx sdk.Context, domainName, newMember string, caller
 		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only domain admin can add members")
 	}
 
-	for _, m := range domain.Members {
-		if m == newMember {
-			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "member already exists in domain")
-		}
+	if k.IsDomainMember(ctx, domainName, newMember) {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "member already exists in domain")
+	}
+	if err := k.requireParentMembership(ctx, domain, newMember); err != nil {
+		return err
 	}
 
-	domain.Members = append(domain.Members, newMember)
-
-	store := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	k.addDomainMember(ctx, domainName, newMember)
 	return nil
 }
 
 func (k Keeper) SubmitProposal(ctx sdk.Context, domainName, issueName, suggestionName, creator string, fee sdk.Coins, externalLink string) error {
-	store := ctx.KVStore(k.StoreKey)
-	domainBz := store.Get([]byte("domain:" + domainName))
-	if domainBz == nil {
+	domain, found := k.GetDomainHeader(ctx, domainName)
+	if !found {
 		return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "Domain not found")
 	}
-	var domain Domain
-	k.cdc.MustUnmarshalLengthPrefixed(domainBz, &domain)
 
 	if domain.Options.OnlyAdminIssues && creator != domain.Admin.String() {
 		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "Only admin can submit issues")
@@ -130,54 +112,41 @@ func (k Keeper) SubmitProposal(ctx sdk.Context, domainName, issueName, suggestio
 	if domain.Options.CoinBurnRequired && fee.AmountOf(PNYXDenom).LT(rewards.CalcDomainCost(fee.AmountOf(PNYXDenom))) {
 		return errorsmod.Wrap(sdkerrors.ErrInsufficientFunds, "Coin burn requirement not met")
 	}
-	putPrice := rewards.CalcPutPrice(domain.Treasury.AmountOf(PNYXDenom), int64(len(domain.Members)))
+	putPrice := rewards.CalcPutPrice(domain.Treasury.AmountOf(PNYXDenom), int64(k.DomainMemberCount(ctx, domainName)))
 	if putPrice.IsPositive() && fee.AmountOf(PNYXDenom).LT(putPrice) {
 		return errorsmod.Wrap(sdkerrors.ErrInsufficientFunds, "Fee below put price (eq.3)")
 	}
-	for _, issue := range domain.Issues {
-		if issue.Name != issueName {
-			continue
-		}
-		for _, suggestion := range issue.Suggestions {
-			if suggestion.Name == suggestionName {
-				return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "suggestion already exists")
-			}
-		}
+	if _, exists := k.GetSuggestion(ctx, domainName, issueName, suggestionName); exists {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "suggestion already exists")
+	}
+	if err := k.requireIssueOpen(ctx, domainName, issueName); err != nil {
+		return err
 	}
 	domain.Treasury = domain.Treasury.Add(fee...)
 
 	now := ctx.BlockTime().Unix()
 
-	found := false
-	for i, issue := range domain.Issues {
-		if issue.Name == issueName {
-			domain.Issues[i].Suggestions = append(domain.Issues[i].Suggestions, Suggestion{
-				Name:         suggestionName,
-				Creator:      creator,
-				Ratings:      []Rating{},
-				Stones:       0,
-				Color:        "",
-				DwellTime:    0,
-				CreationDate: now,
-				ExternalLink: externalLink,
-			})
-			domain.Issues[i].LastActivityAt = now
-			found = true
-			break
-		}
-	}
+	issue, found := k.GetIssue(ctx, domainName, issueName)
 	if !found {
-		domain.Issues = append(domain.Issues, Issue{
-			Name:           issueName,
-			Suggestions:    []Suggestion{{Name: suggestionName, Creator: creator, Ratings: []Rating{}, Stones: 0, Color: "", DwellTime: 0, CreationDate: now, ExternalLink: externalLink}},
-			Stones:         0,
-			CreationDate:   now,
-			LastActivityAt: now,
-		})
+		issue = Issue{
+			Name:         issueName,
+			Stones:       0,
+			CreationDate: now,
+		}
 	}
-
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	issue.LastActivityAt = now
+	k.SetIssue(ctx, domainName, issue)
+	k.SetSuggestion(ctx, domainName, issueName, Suggestion{
+		Name:         suggestionName,
+		Creator:      creator,
+		Stones:       0,
+		Color:        "",
+		DwellTime:    0,
+		CreationDate: now,
+		ExternalLink: externalLink,
+	})
+
+	k.SetDomainHeader(ctx, domain)
 	return nil
 }
 
@@ -193,13 +162,13 @@ func (k Keeper) RateProposal(ctx sdk.Context, domainName, issueName, suggestionN
 	// Derive domain public key (anonymous identity).
 	domainPubKeyHex := hex.EncodeToString(domainPrivKey.PubKey().Bytes())
 
-	store := ctx.KVStore(k.StoreKey)
-	domainBz := store.Get([]byte("domain:" + domainName))
-	if domainBz == nil {
+	domain, found := k.GetDomainHeader(ctx, domainName)
+	if !found {
 		return sdk.Coins{}, nil, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "Domain not found")
 	}
-	var domain Domain
-	k.cdc.MustUnmarshalLengthPrefixed(domainBz, &domain)
+	if err := k.requireIssueOpen(ctx, domainName, issueName); err != nil {
+		return sdk.Coins{}, nil, err
+	}
 
 	// Verify domain key is in the permission register.
 	if !k.IsKeyAuthorized(ctx, domainName, domainPubKeyHex) {
@@ -218,44 +187,19 @@ func (k Keeper) RateProposal(ctx sdk.Context, domainName, issueName, suggestionN
 	}
 
 	// Prevent double-voting with the same domain key.
-	if HasDomainKeyVoted(domain, issueName, suggestionName, domainPubKeyHex) {
+	if k.hasDomainKeyRated(ctx, domainName, issueName, suggestionName, domainPubKeyHex) {
 		return sdk.Coins{}, nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain key has already voted on this suggestion")
 	}
 
-	now := ctx.BlockTime().Unix()
-	foundIssue := false
-	foundSuggestion := false
-	for i, issue := range domain.Issues {
-		if issue.Name == issueName {
-			foundIssue = true
-			for j, suggestion := range issue.Suggestions {
-				if suggestion.Name == suggestionName {
-					domain.Issues[i].Suggestions[j].Ratings = append(domain.Issues[i].Suggestions[j].Ratings, Rating{
-						DomainPubKeyHex: domainPubKeyHex,
-						Value:           rating,
-					})
-					domain.Issues[i].LastActivityAt = now
-					foundSuggestion = true
-					break
-				}
-			}
-			break
-		}
-	}
-	if !foundIssue || !foundSuggestion {
+	if !k.recordRating(ctx, domainName, issueName, suggestionName, Rating{DomainPubKeyHex: domainPubKeyHex, Value: rating}) {
 		return sdk.Coins{}, nil, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "Issue or suggestion not found")
 	}
 
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
-
 	rewardAmt := rewards.CalcReward(domain.Treasury.AmountOf(PNYXDenom))
 	reward := sdk.NewCoins(sdk.NewCoin(PNYXDenom, rewardAmt))
 	domain.Treasury = domain.Treasury.Sub(reward...)
 	domain.TotalPayouts += rewardAmt.Int64()
-
-	bz = k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	k.SetDomainHeader(ctx, domain)
 
 	cache := map[string]interface{}{
 		"avg_rating": rating,
@@ -301,39 +245,21 @@ func (k Keeper) RateProposalWithSignature(ctx sdk.Context, domainName, issueName
 		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "domain key not in permission register")
 	}
 
-	store := ctx.KVStore(k.StoreKey)
-	domainBz := store.Get([]byte("domain:" + domainName))
-	if domainBz == nil {
+	domain, found := k.GetDomainHeader(ctx, domainName)
+	if !found {
 		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "domain not found")
 	}
-	var domain Domain
-	k.cdc.MustUnmarshalLengthPrefixed(domainBz, &domain)
+	if err := k.requireIssueOpen(ctx, domainName, issueName); err != nil {
+		return sdk.Coins{}, err
+	}
 
 	// Prevent double-voting.
-	if HasDomainKeyVoted(domain, issueName, suggestionName, domainPubKeyHex) {
+	if k.hasDomainKeyRated(ctx, domainName, issueName, suggestionName, domainPubKeyHex) {
 		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain key has already voted on this suggestion")
 	}
 
 	// Find and rate the suggestion.
-	now := ctx.BlockTime().Unix()
-	found := false
-	for i, issue := range domain.Issues {
-		if issue.Name == issueName {
-			for j, suggestion := range issue.Suggestions {
-				if suggestion.Name == suggestionName {
-					domain.Issues[i].Suggestions[j].Ratings = append(domain.Issues[i].Suggestions[j].Ratings, Rating{
-						DomainPubKeyHex: domainPubKeyHex,
-						Value:           rating,
-					})
-					domain.Issues[i].LastActivityAt = now
-					found = true
-					break
-				}
-			}
-			break
-		}
-	}
-	if !found {
+	if !k.recordRating(ctx, domainName, issueName, suggestionName, Rating{DomainPubKeyHex: domainPubKeyHex, Value: rating}) {
 		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue or suggestion not found")
 	}
 
@@ -342,9 +268,7 @@ func (k Keeper) RateProposalWithSignature(ctx sdk.Context, domainName, issueName
 	reward := sdk.NewCoins(sdk.NewCoin(PNYXDenom, rewardAmt))
 	domain.Treasury = domain.Treasury.Sub(reward...)
 	domain.TotalPayouts += rewardAmt.Int64()
-
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	k.SetDomainHeader(ctx, domain)
 	return reward, nil
 }
 
@@ -363,58 +287,20 @@ func (k Keeper) RateProposalWithZKP(ctx sdk.Context, domainName, issueName, sugg
 	}
 
 	// Get domain and verify identity commitments exist.
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return sdk.Coins{}, errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
-	if domain.MerkleRoot == "" {
-		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "no identity commitments registered in domain")
-	}
-
-	// Determine which Merkle root to verify against.
-	effectiveRoot := domain.MerkleRoot
-	if merkleRootHex != "" {
-		if !isAcceptedMerkleRoot(domain, merkleRootHex) {
-			return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "merkle root not recognized (not current and not in history window)")
-		}
-		effectiveRoot = merkleRootHex
-	}
-	merkleRootBytes, err := HexToFieldElement(effectiveRoot)
-	if err != nil {
-		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "invalid or non-canonical Merkle root")
-	}
-
-	// Decode and validate nullifier hash.
-	nullifierBytes, err := HexToFieldElement(nullifierHashHex)
-	if err != nil || len(nullifierHashHex) != 64 {
-		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "nullifier hash must be 32 bytes hex-encoded (64 hex chars)")
+	if err := k.requireIssueOpen(ctx, domainName, issueName); err != nil {
+		return sdk.Coins{}, err
 	}
-	nullifierHashHex = hex.EncodeToString(nullifierBytes)
-
 	// Compute external nullifier from voting context. The scope deliberately
 	// stays recipient- and rating-independent (GH-209).
 	externalNullifier := ComputeVoteNullifierScope(ctx.ChainID(), domainName, issueName, suggestionName)
 	signalHash := ComputeVoteSignalV2(ctx.ChainID(), domainName, issueName, suggestionName, rating, rewardRecipient)
-
-	// Decode proof.
-	proofBytes, err := hex.DecodeString(proofHex)
-	if err != nil {
-		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "invalid proof hex encoding")
-	}
-
-	// Get or initialize the verifying key.
-	vkBytes, err := k.EnsureVerifyingKey(ctx)
-	if err != nil {
-		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "failed to load verifying key: "+err.Error())
-	}
-	vk, err := DeserializeVerifyingKey(vkBytes)
+	nullifierHashHex, err := k.verifyMembershipSignal(ctx, domain, proofHex, nullifierHashHex, merkleRootHex, externalNullifier, signalHash)
 	if err != nil {
-		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "failed to deserialize verifying key")
-	}
-
-	// Verify the Groth16 membership proof.
-	if err := VerifyMembershipProofForSignal(vk, proofBytes, merkleRootBytes, nullifierBytes, externalNullifier, signalHash); err != nil {
-		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "ZKP membership proof verification failed: "+err.Error())
+		return sdk.Coins{}, err
 	}
 
 	// Check nullifier has not been used (prevents double-voting).
@@ -423,39 +309,82 @@ func (k Keeper) RateProposalWithZKP(ctx sdk.Context, domainName, issueName, sugg
 	}
 
 	// Find and rate the suggestion.
-	now := ctx.BlockTime().Unix()
-	suggestionFound := false
-	for i, issue := range domain.Issues {
-		if issue.Name == issueName {
-			for j, suggestion := range issue.Suggestions {
-				if suggestion.Name == suggestionName {
-					domain.Issues[i].Suggestions[j].Ratings = append(domain.Issues[i].Suggestions[j].Ratings, Rating{
-						NullifierHex: nullifierHashHex,
-						Value:        rating,
-					})
-					domain.Issues[i].LastActivityAt = now
-					suggestionFound = true
-					break
-				}
-			}
-			break
-		}
-	}
-	if !suggestionFound {
+	if !k.recordRating(ctx, domainName, issueName, suggestionName, Rating{NullifierHex: nullifierHashHex, Value: rating}) {
 		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue or suggestion not found")
 	}
 
 	// Mark nullifier as used.
-	k.SetNullifierUsed(ctx, domainName, nullifierHashHex, ctx.BlockHeight())
+	k.SetScopedNullifierUsed(ctx, domainName, issueName, suggestionName, nullifierHashHex, ctx.BlockHeight())
 
 	// RateToEarn reward (eq.2).
 	rewardAmt := rewards.CalcReward(domain.Treasury.AmountOf(PNYXDenom))
 	reward := sdk.NewCoins(sdk.NewCoin(PNYXDenom, rewardAmt))
 	domain.Treasury = domain.Treasury.Sub(reward...)
 	domain.TotalPayouts += rewardAmt.Int64()
+	k.SetDomainHeader(ctx, domain)
+	return reward, nil
+}
+
+// RateProposalOpenly records a rating under the member's own address. Open
+// ratings carry the votes delegated to the member (see delegation.go), which
+// anonymous ratings cannot. Members holding an anonymous credential cannot
+// rate openly, and an open rating keeps the member from registering one
+// until the next Big Purge.
+func (k Keeper) RateProposalOpenly(ctx sdk.Context, domainName, issueName, suggestionName string, rating int, memberAddr string) (sdk.Coins, error) {
+	if rating < -5 || rating > 5 {
+		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "rating must be between -5 and +5")
+	}
+	domain, found := k.GetDomainHeader(ctx, domainName)
+	if !found {
+		return sdk.Coins{}, errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
+	}
+	if !k.IsDomainMember(ctx, domainName, memberAddr) {
+		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only domain members can rate openly")
+	}
+	if err := k.requireIssueOpen(ctx, domainName, issueName); err != nil {
+		return sdk.Coins{}, err
+	}
+	if k.GetVoterMode(ctx, domainName, memberAddr) == VoterModeAnonymous {
+		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "members with an anonymous voting credential cannot rate openly")
+	}
+	if k.hasMemberRated(ctx, domainName, issueName, suggestionName, memberAddr) {
+		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "member has already rated this suggestion")
+	}
 
-	store := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	recorded := false
+	rate := func() {
+		recorded = k.recordRating(ctx, domainName, issueName, suggestionName, Rating{MemberAddr: memberAddr, Value: rating, Weight: 1})
+	}
+	if k.hasVoteDelegations(ctx, domainName) {
+		// The rating carries the weight delegated to the member.
+		k.trackDelegatedWeight(ctx, domainName, []string{memberAddr}, []delegatedTally{k.ratingTally(ctx, domainName, issueName, suggestionName)}, rate)
+	} else {
+		rate()
+	}
+	if !recorded {
+		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue or suggestion not found")
+	}
+	k.setVoterMode(ctx, domainName, memberAddr, VoterModeOpen)
+
+	// RateToEarn reward (eq.2).
+	rewardAmt := rewards.CalcReward(domain.Treasury.AmountOf(PNYXDenom))
+	reward := sdk.NewCoins(sdk.NewCoin(PNYXDenom, rewardAmt))
+	domain.Treasury = domain.Treasury.Sub(reward...)
+	domain.TotalPayouts += rewardAmt.Int64()
+	k.SetDomainHeader(ctx, domain)
 	return reward, nil
 }
+
+// recordRating appends a rating to a suggestion, marks its issue active and
+// queues the issue for decision once its rating quorum is met. It returns
+// false when the issue or suggestion does not exist.
+func (k Keeper) recordRating(ctx sdk.Context, domainName, issueName, suggestionName string, rating Rating) bool {
+	issue, found := k.GetIssue(ctx, domainName, issueName)
+	if !found || !k.appendRating(ctx, domainName, issueName, suggestionName, rating) {
+		return false
+	}
+	issue.LastActivityAt = ctx.BlockTime().Unix()
+	k.SetIssue(ctx, domainName, issue)
+	k.checkIssueQuorum(ctx, domainName, issue)
+	return true
+}
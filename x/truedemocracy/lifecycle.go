@@ -13,7 +13,9 @@ import (
 //   RED    (still < threshold)      →  dwell time, then auto-deleted
 //
 // Transitions are evaluated every EndBlock. A suggestion can recover
-// to green from yellow or red at any time by gaining enough stones.
+// to green from yellow or red at any time by gaining enough stones. A
+// suggestion carrying a treasury payout has it approved once it has stayed
+// green for its dwell time (see treasury_payout.go).
 
 // MeetsApprovalThreshold checks whether a suggestion's stone count meets
 // the domain's approval threshold. Uses integer math to avoid floats:
@@ -50,76 +52,78 @@ func effectiveDwellTime(s Suggestion, opts DomainOptions) int64 {
 // a domain. Called from EndBlock. Suggestions that expire in the red zone
 // are automatically deleted.
 func (k Keeper) EvaluateSuggestionZones(ctx sdk.Context, domainName string) error {
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return nil // domain may have been removed
 	}
 
 	now := ctx.BlockTime().Unix()
-	totalMembers := len(domain.Members)
+	totalMembers := k.DomainMemberCount(ctx, domainName)
 	threshold := effectiveThreshold(domain.Options)
-	modified := false
 
-	for i := range domain.Issues {
-		var kept []Suggestion
-		for j := range domain.Issues[i].Suggestions {
-			s := &domain.Issues[i].Suggestions[j]
+	var issueNames []string
+	k.IterateIssues(ctx, domainName, func(issue Issue) bool {
+		issueNames = append(issueNames, issue.Name)
+		return false
+	})
+
+	for _, issueName := range issueNames {
+		var suggestions []Suggestion
+		k.IterateSuggestions(ctx, domainName, issueName, func(s Suggestion) bool {
+			suggestions = append(suggestions, s)
+			return false
+		})
+
+		for _, s := range suggestions {
+			modified := false
+			deleted := false
 
 			if MeetsApprovalThreshold(s.Stones, totalMembers, threshold) {
 				// Approved → green. Clear any zone timestamps.
-				if s.Color != "green" {
+				if s.Color != "green" || (s.Payout != nil && s.EnteredGreenAt == 0) {
 					s.Color = "green"
+					s.EnteredGreenAt = now
 					s.EnteredYellowAt = 0
 					s.EnteredRedAt = 0
 					modified = true
 				}
-				kept = append(kept, *s)
-				continue
-			}
-
-			// Below threshold.
-			dwellTime := effectiveDwellTime(*s, domain.Options)
-
-			switch s.Color {
-			case "", "green":
-				// First drop below threshold → enter yellow.
-				s.Color = "yellow"
-				s.EnteredYellowAt = now
-				s.EnteredRedAt = 0
-				modified = true
-				kept = append(kept, *s)
-
-			case "yellow":
-				if now >= s.EnteredYellowAt+dwellTime {
-					// Yellow expired → enter red.
-					s.Color = "red"
-					s.EnteredRedAt = now
+				k.approveGreenPayout(ctx, domain, issueName, s)
+			} else {
+				// Below threshold.
+				dwellTime := effectiveDwellTime(s, domain.Options)
+
+				switch s.Color {
+				case "", "green":
+					// First drop below threshold → enter yellow.
+					s.Color = "yellow"
+					s.EnteredGreenAt = 0
+					s.EnteredYellowAt = now
+					s.EnteredRedAt = 0
 					modified = true
-				}
-				kept = append(kept, *s)
 
-			case "red":
-				if now >= s.EnteredRedAt+dwellTime {
-					// Red expired → auto-delete.
-					modified = true
-					// Don't append → suggestion is removed.
-				} else {
-					kept = append(kept, *s)
+				case "yellow":
+					if now >= s.EnteredYellowAt+dwellTime {
+						// Yellow expired → enter red.
+						s.Color = "red"
+						s.EnteredRedAt = now
+						modified = true
+					}
+
+				case "red":
+					if now >= s.EnteredRedAt+dwellTime {
+						// Red expired → auto-delete.
+						deleted = true
+					}
 				}
-			default:
-				kept = append(kept, *s)
 			}
-		}
-		if len(kept) != len(domain.Issues[i].Suggestions) {
-			modified = true
-		}
-		domain.Issues[i].Suggestions = kept
-	}
 
-	if modified {
-		store := ctx.KVStore(k.StoreKey)
-		bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-		store.Set([]byte("domain:"+domainName), bz)
+			switch {
+			case deleted:
+				k.deleteSuggestion(ctx, domainName, issueName, s.Name)
+			case modified:
+				k.SetSuggestion(ctx, domainName, issueName, s)
+			}
+		}
 	}
 	return nil
 }
@@ -128,7 +132,7 @@ func (k Keeper) EvaluateSuggestionZones(ctx sdk.Context, domainName string) erro
 // Called from EndBlock.
 func (k Keeper) ProcessAllLifecycles(ctx sdk.Context) {
 	var domainNames []string
-	k.IterateDomains(ctx, func(d Domain) bool {
+	k.IterateDomainHeaders(ctx, func(d Domain) bool {
 		domainNames = append(domainNames, d.Name)
 		return false
 	})
@@ -137,22 +141,6 @@ func (k Keeper) ProcessAllLifecycles(ctx sdk.Context) {
 	}
 }
 
-// IterateDomains iterates over all domains in the KV store.
-func (k Keeper) IterateDomains(ctx sdk.Context, fn func(Domain) bool) {
-	store := ctx.KVStore(k.StoreKey)
-	prefix := []byte("domain:")
-	end := prefixEnd(prefix)
-	iter := store.Iterator(prefix, end)
-	defer iter.Close()
-	for ; iter.Valid(); iter.Next() {
-		var domain Domain
-		k.cdc.MustUnmarshalLengthPrefixed(iter.Value(), &domain)
-		if fn(domain) {
-			break
-		}
-	}
-}
-
 // --- Fast Delete (2/3 majority) ---
 
 // deleteVoteKey returns the KV key for tracking an individual member's
@@ -165,21 +153,19 @@ func deleteVoteKey(domainName, issueName, suggestionName, memberAddr string) []b
 // count reaches 2/3 of domain members, the suggestion is immediately removed.
 // Returns (deleted bool, error).
 func (k Keeper) VoteToDelete(ctx sdk.Context, domainName, issueName, suggestionName, memberAddr string) (bool, error) {
-	domain, found := k.GetDomain(ctx, domainName)
-	if !found {
+	if _, found := k.GetDomainHeader(ctx, domainName); !found {
 		return false, errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
 
-	if !isMember(domain, memberAddr) {
+	if !k.IsDomainMember(ctx, domainName, memberAddr) {
 		return false, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only domain members can vote to delete")
 	}
 
-	issueIdx := findIssueIndex(domain, issueName)
-	if issueIdx == -1 {
+	if _, found := k.GetIssue(ctx, domainName, issueName); !found {
 		return false, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue not found")
 	}
-	suggIdx := findSuggestionIndex(domain.Issues[issueIdx], suggestionName)
-	if suggIdx == -1 {
+	suggestion, found := k.GetSuggestion(ctx, domainName, issueName, suggestionName)
+	if !found {
 		return false, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "suggestion not found")
 	}
 
@@ -193,20 +179,18 @@ func (k Keeper) VoteToDelete(ctx sdk.Context, domainName, issueName, suggestionN
 
 	// Record vote.
 	store.Set(voteKey, []byte{1})
-	domain.Issues[issueIdx].Suggestions[suggIdx].DeleteVotes++
+	suggestion.DeleteVotes++
 
-	totalMembers := len(domain.Members)
-	votes := domain.Issues[issueIdx].Suggestions[suggIdx].DeleteVotes
+	totalMembers := k.DomainMemberCount(ctx, domainName)
+	votes := suggestion.DeleteVotes
 
 	// Check if 2/3 majority reached.
 	deleted := int64(votes)*10000 >= int64(totalMembers)*DeleteMajorityBps
 	if deleted {
 		// Remove the suggestion.
-		suggs := domain.Issues[issueIdx].Suggestions
-		domain.Issues[issueIdx].Suggestions = append(suggs[:suggIdx], suggs[suggIdx+1:]...)
+		k.deleteSuggestion(ctx, domainName, issueName, suggestionName)
+	} else {
+		k.SetSuggestion(ctx, domainName, issueName, suggestion)
 	}
-
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
 	return deleted, nil
 }
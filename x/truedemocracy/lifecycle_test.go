@@ -27,9 +27,7 @@ func setupLifecycleDomain(t *testing.T, k Keeper, ctx sdk.Context) {
 		},
 	}
 
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:LifeDomain"), bz)
+	k.SetDomain(ctx, domain)
 }
 
 // setSuggestionStones directly sets the stone count on a suggestion.
@@ -37,9 +35,7 @@ func setSuggestionStones(t *testing.T, k Keeper, ctx sdk.Context, domainName str
 	t.Helper()
 	domain, _ := k.GetDomain(ctx, domainName)
 	domain.Issues[issueIdx].Suggestions[suggIdx].Stones = stones
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:"+domainName), bz)
+	k.SetDomain(ctx, domain)
 }
 
 // setSuggestionColor directly sets the color/zone state on a suggestion.
@@ -49,9 +45,7 @@ func setSuggestionColor(t *testing.T, k Keeper, ctx sdk.Context, domainName stri
 	domain.Issues[issueIdx].Suggestions[suggIdx].Color = color
 	domain.Issues[issueIdx].Suggestions[suggIdx].EnteredYellowAt = yellowAt
 	domain.Issues[issueIdx].Suggestions[suggIdx].EnteredRedAt = redAt
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:"+domainName), bz)
+	k.SetDomain(ctx, domain)
 }
 
 // ---------- MeetsApprovalThreshold ----------
@@ -314,9 +308,7 @@ func TestCustomApprovalThreshold(t *testing.T) {
 	// Set a high threshold: 50% (5000 bps). Now need 5 stones out of 10.
 	domain, _ := k.GetDomain(ctx, "LifeDomain")
 	domain.Options.ApprovalThreshold = 5000
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:LifeDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	// Give S1 only 1 stone — not enough at 50%.
 	setSuggestionStones(t, k, ctx, "LifeDomain", 0, 0, 1)
@@ -351,9 +343,7 @@ func TestCustomDwellTime(t *testing.T) {
 	// Set domain dwell time to 1 hour.
 	domain, _ := k.GetDomain(ctx, "LifeDomain")
 	domain.Options.DefaultDwellTime = 3600
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:LifeDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	now := ctx.BlockTime().Unix()
 
@@ -384,9 +374,7 @@ func TestSuggestionSpecificDwellTime(t *testing.T) {
 	domain.Issues[0].Suggestions[0].DwellTime = 1800
 	domain.Issues[0].Suggestions[0].Color = "yellow"
 	domain.Issues[0].Suggestions[0].EnteredYellowAt = now - 2000 // 33 min ago
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:LifeDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	err := k.EvaluateSuggestionZones(ctx, "LifeDomain")
 	if err != nil {
@@ -417,9 +405,7 @@ func TestProcessAllLifecycles(t *testing.T) {
 			},
 		},
 	}
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain2)
-	st.Set([]byte("domain:Domain2"), bz)
+	k.SetDomain(ctx, domain2)
 
 	// Process all — both domains should be evaluated.
 	k.ProcessAllLifecycles(ctx)
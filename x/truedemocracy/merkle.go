@@ -6,11 +6,13 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"hash"
 	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/poseidon2"
 
 	"truerepublic/x/truedemocracy/zkpcircuit"
 )
@@ -19,11 +21,43 @@ import (
 // Supports up to 2^20 = 1,048,576 leaves per domain (Semaphore standard).
 const MerkleTreeDepth = zkpcircuit.MerkleDepth
 
+// Hash families of the membership circuits. Circuits of one family hash
+// commitments, tree nodes and nullifiers identically.
+const (
+	HashFamilyMiMC      = "mimc-bn254"
+	HashFamilyPoseidon2 = "poseidon2-bn254"
+)
+
+// familyHashes holds the native hash of every family, matching the hasher
+// its circuits use in-circuit.
+var familyHashes = map[string]func(...*big.Int) []byte{
+	HashFamilyMiMC:      MiMCHash,
+	HashFamilyPoseidon2: Poseidon2Hash,
+}
+
+func familyHash(family string) (func(...*big.Int) []byte, error) {
+	hashFn, ok := familyHashes[family]
+	if !ok {
+		return nil, fmt.Errorf("unknown hash family %q", family)
+	}
+	return hashFn, nil
+}
+
 // MiMCHash computes MiMC(data...) using the BN254 native hasher.
 // Each element is a big.Int that must be < BN254 field modulus.
 // Returns the hash as a 32-byte big-endian slice.
 func MiMCHash(data ...*big.Int) []byte {
-	hasher := mimc.NewMiMC()
+	return sumFieldElements(mimc.NewMiMC(), data)
+}
+
+// Poseidon2Hash computes the Poseidon2 Merkle-Damgård hash of data with the
+// default BN254 parameters, the native counterpart of the Poseidon2 circuit's
+// hasher. Elements must be < BN254 field modulus.
+func Poseidon2Hash(data ...*big.Int) []byte {
+	return sumFieldElements(poseidon2.NewMerkleDamgardHasher(), data)
+}
+
+func sumFieldElements(hasher hash.Hash, data []*big.Int) []byte {
 	for _, d := range data {
 		var buf [32]byte
 		b := d.Bytes()
@@ -36,6 +70,15 @@ func MiMCHash(data ...*big.Int) []byte {
 // MiMCHashBytes is a convenience wrapper that hashes raw 32-byte
 // big-endian field elements.
 func MiMCHashBytes(data ...[]byte) ([]byte, error) {
+	return hashFieldBytes(MiMCHash, data)
+}
+
+// Poseidon2HashBytes is MiMCHashBytes with Poseidon2.
+func Poseidon2HashBytes(data ...[]byte) ([]byte, error) {
+	return hashFieldBytes(Poseidon2Hash, data)
+}
+
+func hashFieldBytes(hashFn func(...*big.Int) []byte, data [][]byte) ([]byte, error) {
 	vals := make([]*big.Int, len(data))
 	for i, d := range data {
 		if len(d) > 32 {
@@ -46,55 +89,92 @@ func MiMCHashBytes(data ...[]byte) ([]byte, error) {
 			return nil, fmt.Errorf("element %d is not a canonical BN254 field element", i)
 		}
 	}
-	return MiMCHash(vals...), nil
+	return hashFn(vals...), nil
 }
 
 // ComputeCommitment computes commitment = MiMC(identitySecret).
 func ComputeCommitment(identitySecret []byte) ([]byte, error) {
+	return ComputeCommitmentFor(HashFamilyMiMC, identitySecret)
+}
+
+// ComputeCommitmentFor computes the identity commitment of a domain whose
+// circuit belongs to family.
+func ComputeCommitmentFor(family string, identitySecret []byte) ([]byte, error) {
+	hashFn, err := familyHash(family)
+	if err != nil {
+		return nil, err
+	}
 	if len(identitySecret) == 0 || len(identitySecret) > 32 {
 		return nil, fmt.Errorf("identitySecret must be 1-32 bytes")
 	}
-	return MiMCHashBytes(identitySecret)
+	return hashFieldBytes(hashFn, [][]byte{identitySecret})
 }
 
 // ComputeNullifier computes nullifier = MiMC(identitySecret, externalNullifier).
 func ComputeNullifier(identitySecret, externalNullifier []byte) ([]byte, error) {
+	return ComputeNullifierFor(HashFamilyMiMC, identitySecret, externalNullifier)
+}
+
+// ComputeNullifierFor computes the nullifier family's circuits reveal for
+// identitySecret under externalNullifier.
+func ComputeNullifierFor(family string, identitySecret, externalNullifier []byte) ([]byte, error) {
+	hashFn, err := familyHash(family)
+	if err != nil {
+		return nil, err
+	}
 	if len(identitySecret) == 0 || len(identitySecret) > 32 {
 		return nil, fmt.Errorf("identitySecret must be 1-32 bytes")
 	}
 	if len(externalNullifier) == 0 || len(externalNullifier) > 32 {
 		return nil, fmt.Errorf("externalNullifier must be 1-32 bytes")
 	}
-	return MiMCHashBytes(identitySecret, externalNullifier)
+	return hashFieldBytes(hashFn, [][]byte{identitySecret, externalNullifier})
 }
 
 // zeroValues returns precomputed zero hashes for each tree level.
-// Level 0 = MiMC(0) (empty leaf hash).
-// Level i = MiMC(zeroValues[i-1], zeroValues[i-1]) (empty subtree).
-func zeroValues(depth int) [][]byte {
+// Level 0 = H(0) (empty leaf hash).
+// Level i = H(zeroValues[i-1], zeroValues[i-1]) (empty subtree).
+func zeroValues(hashFn func(...*big.Int) []byte, depth int) [][]byte {
 	zeros := make([][]byte, depth+1)
-	zeros[0] = MiMCHash(big.NewInt(0))
+	zeros[0] = hashFn(big.NewInt(0))
 	for i := 1; i <= depth; i++ {
 		prev := new(big.Int).SetBytes(zeros[i-1])
-		zeros[i] = MiMCHash(prev, prev)
+		zeros[i] = hashFn(prev, prev)
 	}
 	return zeros
 }
 
-// MerkleTree is a fixed-depth binary Merkle tree using MiMC hash.
+// MerkleTree is a fixed-depth binary Merkle tree using the hash of one
+// circuit family, MiMC unless built with NewMerkleTreeFor.
 type MerkleTree struct {
 	Depth  int
 	Leaves [][]byte // actual leaf values (commitments)
 	Root   []byte   // current root hash
 	zeros  [][]byte // precomputed zero hashes per level
+	hash   func(...*big.Int) []byte
 }
 
-// NewMerkleTree creates a new empty Merkle tree of the given depth.
+// NewMerkleTree creates a new empty MiMC Merkle tree of the given depth.
 func NewMerkleTree(depth int) *MerkleTree {
+	return newMerkleTree(MiMCHash, depth)
+}
+
+// NewMerkleTreeFor creates a new empty Merkle tree hashed like the circuits
+// of family.
+func NewMerkleTreeFor(family string, depth int) (*MerkleTree, error) {
+	hashFn, err := familyHash(family)
+	if err != nil {
+		return nil, err
+	}
+	return newMerkleTree(hashFn, depth), nil
+}
+
+func newMerkleTree(hashFn func(...*big.Int) []byte, depth int) *MerkleTree {
 	t := &MerkleTree{
 		Depth:  depth,
 		Leaves: [][]byte{},
-		zeros:  zeroValues(depth),
+		zeros:  zeroValues(hashFn, depth),
+		hash:   hashFn,
 	}
 	t.Root = t.zeros[depth]
 	return t
@@ -138,7 +218,7 @@ func (t *MerkleTree) computeRoot() []byte {
 			}
 			lVal := new(big.Int).SetBytes(left)
 			rVal := new(big.Int).SetBytes(right)
-			nextLevel = append(nextLevel, MiMCHash(lVal, rVal))
+			nextLevel = append(nextLevel, t.hash(lVal, rVal))
 		}
 
 		if len(nextLevel) == 0 {
@@ -199,7 +279,7 @@ func (t *MerkleTree) GenerateProof(leafIndex int) (siblings [][]byte, pathIndice
 			}
 			lVal := new(big.Int).SetBytes(left)
 			rVal := new(big.Int).SetBytes(right)
-			nextLevel = append(nextLevel, MiMCHash(lVal, rVal))
+			nextLevel = append(nextLevel, t.hash(lVal, rVal))
 		}
 		currentLevel = nextLevel
 		idx = idx / 2
@@ -208,7 +288,7 @@ func (t *MerkleTree) GenerateProof(leafIndex int) (siblings [][]byte, pathIndice
 	return siblings, pathIndices, nil
 }
 
-// VerifyMerkleProof verifies a Merkle proof against a root.
+// VerifyMerkleProof verifies a MiMC Merkle proof against a root.
 func VerifyMerkleProof(root, leaf []byte, siblings [][]byte, pathIndices []int) bool {
 	if len(siblings) != len(pathIndices) {
 		return false
@@ -301,6 +381,70 @@ func ComputeVoteSignalV2(chainID, domainName, issueName, suggestionName string,
 	return hashToField(encodeVoteContextV2(chainID, domainName, issueName, suggestionName, rating, rewardRecipient))
 }
 
+// encodeScopedContext frames values like encodeVoteContext: a domain
+// separator followed by every value length-prefixed.
+func encodeScopedContext(separator string, values ...string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(separator)
+	for _, value := range values {
+		_ = binary.Write(&buf, binary.BigEndian, uint32(len(value)))
+		buf.WriteString(value)
+	}
+	return buf.Bytes()
+}
+
+// ComputeElectionNullifierScope returns the nullifier context of one person
+// election. The ballot is excluded, so a member holds a single nullifier per
+// election and a later proof under it replaces their ballot.
+func ComputeElectionNullifierScope(chainID, domainName, issueName string) []byte {
+	return hashToField(encodeScopedContext("TrueRepublic/election/v1", chainID, domainName, issueName))
+}
+
+// ComputeElectionBallotSignal binds a proof to the chain, election, and exact
+// ranking. An empty ranking is an abstention.
+func ComputeElectionBallotSignal(chainID, domainName, issueName string, ranking []string) []byte {
+	values := append([]string{chainID, domainName, issueName}, ranking...)
+	return hashToField(encodeScopedContext("TrueRepublic/election-ballot/v1", values...))
+}
+
+// ComputeStoneNullifierScope returns the nullifier context of one stone
+// list: the domain's issue or member list, or the suggestion list of
+// issueName. The target is excluded so the nullifier moves the stone.
+func ComputeStoneNullifierScope(chainID, domainName, list, issueName string) []byte {
+	return hashToField(encodeScopedContext("TrueRepublic/stone/v1", chainID, domainName, list, issueName))
+}
+
+// ComputeStoneSignal binds a proof to the chain, stone list, and target.
+func ComputeStoneSignal(chainID, domainName, list, issueName, target string) []byte {
+	return hashToField(encodeScopedContext("TrueRepublic/stone-target/v1", chainID, domainName, list, issueName, target))
+}
+
+// ComputeProposalNullifierScope returns the nullifier context of anonymous
+// submissions to one issue, so each member holds one anonymous suggestion
+// per issue.
+func ComputeProposalNullifierScope(chainID, domainName, issueName string) []byte {
+	return hashToField(encodeScopedContext("TrueRepublic/proposal/v1", chainID, domainName, issueName))
+}
+
+// ComputeProposalSignal binds a proof to the chain, issue, suggestion name,
+// and external link. The fee and its payer are left out, so any account can
+// fund the submission.
+func ComputeProposalSignal(chainID, domainName, issueName, suggestionName, externalLink string) []byte {
+	return hashToField(encodeScopedContext("TrueRepublic/proposal-content/v1", chainID, domainName, issueName, suggestionName, externalLink))
+}
+
+// ComputeSignalNullifierScope returns the nullifier context of an anonymous
+// signal topic: an application-defined name within one domain. Each member
+// holds one nullifier per topic, so they can post one signal to it.
+func ComputeSignalNullifierScope(chainID, domainName, topic string) []byte {
+	return hashToField(encodeScopedContext("TrueRepublic/signal/v1", chainID, domainName, topic))
+}
+
+// ComputeSignalHash binds a proof to the chain, topic, and exact signal.
+func ComputeSignalHash(chainID, domainName, topic, signal string) []byte {
+	return hashToField(encodeScopedContext("TrueRepublic/signal-content/v1", chainID, domainName, topic, signal))
+}
+
 // HexToFieldElement converts a hex string to a 32-byte big-endian
 // field element, validating it is < BN254 field modulus.
 func HexToFieldElement(hexStr string) ([]byte, error) {
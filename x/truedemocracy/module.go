@@ -7,7 +7,6 @@ import (
 
 	"cosmossdk.io/core/appmodule"
 	"cosmossdk.io/math"
-	storeprefix "cosmossdk.io/store/prefix"
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/spf13/cobra"
 
@@ -46,6 +45,12 @@ func (AppModuleBasic) RegisterInterfaces(registry codectypes.InterfaceRegistry)
 		&MsgSubmitProposal{},
 		&MsgRegisterValidator{},
 		&MsgWithdrawStake{},
+		&MsgDelegateStake{},
+		&MsgUndelegateStake{},
+		&MsgEditValidator{},
+		&MsgClaimStakingRewards{},
+		&MsgSetStakingRewardAddress{},
+		&MsgVoteValidatorSetParams{},
 		&MsgRemoveValidator{},
 		&MsgRotateValidatorKey{},
 		&MsgUnjail{},
@@ -58,16 +63,29 @@ func (AppModuleBasic) RegisterInterfaces(registry codectypes.InterfaceRegistry)
 		&MsgVoteToDelete{},
 		&MsgRateProposal{},
 		&MsgCastElectionVote{},
+		&MsgCastRankedElectionVote{},
 		&MsgAddMember{},
 		&MsgOnboardToDomain{},
 		&MsgApproveOnboarding{},
 		&MsgRejectOnboarding{},
 		&MsgRegisterIdentity{},
 		&MsgRateWithProof{},
+		&MsgCastElectionVoteWithProof{},
+		&MsgPlaceStoneWithProof{},
+		&MsgRateOpenly{},
+		&MsgDelegateVote{},
+		&MsgCreateSubDomain{},
+		&MsgVoteSubDomainBudget{},
+		&MsgDelegateIssueToSubDomain{},
+		&MsgProposeDomainOptionsChange{},
+		&MsgRotateIdentityCommitment{},
 		&MsgDepositToDomain{},
 		&MsgWithdrawFromDomain{},
 		&MsgVoteSoftwareUpgrade{},
 		&MsgVoteCancelSoftwareUpgrade{},
+		&MsgVoteVerifyingKeyRotation{},
+		&MsgSubmitProposalWithProof{},
+		&MsgSignalWithProof{},
 	)
 	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
 }
@@ -128,14 +146,33 @@ func (am AppModule) RegisterServices(cfg module.Configurator) {
 	if err := cfg.RegisterMigration(ModuleName, 1, func(sdk.Context) error { return nil }); err != nil {
 		panic(err)
 	}
+	// Version 3 splits each monolithic "domain:" blob into a header plus
+	// member, issue, suggestion, rating and register records (domain_store.go).
+	if err := cfg.RegisterMigration(ModuleName, 2, am.keeper.MigrateDomainEntities); err != nil {
+		panic(err)
+	}
+	// Version 4 persists the nodes of each domain's identity Merkle tree
+	// (identity_tree.go).
+	if err := cfg.RegisterMigration(ModuleName, 3, am.keeper.MigrateIdentityTrees); err != nil {
+		panic(err)
+	}
+	// Version 5 indexes rating nullifiers by suggestion so they are pruned
+	// with it (anonymity.go).
+	if err := cfg.RegisterMigration(ModuleName, 4, am.keeper.MigrateNullifierScopes); err != nil {
+		panic(err)
+	}
+	// Version 6 ranks validators and records which of them CometBFT knows,
+	// at the power it was last reported (validator_set.go).
+	if err := cfg.RegisterMigration(ModuleName, 5, am.keeper.MigrateValidatorSet); err != nil {
+		panic(err)
+	}
 }
 
-// ConsensusVersion is 2 since GH-209: anonymous rating handlers require the
-// recipient-bound v2 payload and pay the bound recipient directly. Chains
-// running version 1 must adopt this through the registered governed no-op
-// store migration or a fresh genesis; version 1 submissions fail closed and
-// are never dual-accepted.
-func (am AppModule) ConsensusVersion() uint64 { return 2 }
+// ConsensusVersion is the module's store version. Chains on an older version
+// adopt it through the migrations registered in RegisterServices or a fresh
+// genesis; version 1 rating submissions fail closed and are never
+// dual-accepted (GH-209).
+func (am AppModule) ConsensusVersion() uint64 { return 6 }
 
 func (am AppModule) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, data json.RawMessage) []abci.ValidatorUpdate {
 	var genesisState GenesisState
@@ -149,8 +186,7 @@ func (am AppModule) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, data json.
 	// Restore domains from genesis (full state, not just name/admin/treasury).
 	store := ctx.KVStore(am.keeper.StoreKey)
 	for _, domain := range genesisState.Domains {
-		bz := am.cdc.MustMarshalLengthPrefixed(&domain)
-		store.Set([]byte("domain:"+domain.Name), bz)
+		am.keeper.SetDomain(ctx, domain)
 		store.Set(
 			domainPayoutSnapshotKey(domain.Name),
 			am.cdc.MustMarshalLengthPrefixed(domain.TotalPayouts),
@@ -167,18 +203,67 @@ func (am AppModule) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, data json.
 		panic(err)
 	}
 	for _, record := range genesisState.UsedNullifiers {
-		am.keeper.SetNullifierUsed(ctx, record.DomainName, record.NullifierHash, record.UsedAtHeight)
+		am.keeper.setNullifierRecord(ctx, record)
+	}
+	for _, scope := range genesisState.NullifierScopes {
+		for _, nullifier := range scope.Nullifiers {
+			am.keeper.SetScopedNullifierUsed(ctx, scope.DomainName, scope.IssueName, scope.SuggestionName, nullifier, 0)
+		}
+	}
+	if len(genesisState.UsedNullifiers) > 0 {
+		// Legacy exports carry no scopes; recover them from the ratings.
+		for _, domain := range genesisState.Domains {
+			am.keeper.scopeRatingNullifiers(ctx, domain.Name)
+		}
+	}
+	for _, decision := range genesisState.IssueDecisions {
+		am.keeper.setIssueDecision(ctx, decision)
+	}
+	for _, domain := range genesisState.Domains {
+		am.keeper.restoreIssueSchedules(ctx, domain.Name)
+	}
+	for _, delegation := range genesisState.VoteDelegations {
+		am.keeper.setVoteDelegation(ctx, delegation)
+	}
+	for _, record := range genesisState.VoterModes {
+		am.keeper.setVoterMode(ctx, record.DomainName, record.Member, record.Mode)
+	}
+	for _, record := range genesisState.TreasuryPayouts {
+		am.keeper.setPayoutRecord(ctx, record)
+	}
+	for _, change := range genesisState.DomainOptionsHistory {
+		am.keeper.setOptionsHistory(ctx, change)
+	}
+	for _, owner := range genesisState.IdentityLeafOwners {
+		am.keeper.setIdentityLeafOwner(ctx, owner.DomainName, owner.Member, owner.Leaf)
+	}
+	for _, rotation := range genesisState.IdentityRotations {
+		am.keeper.setIdentityRotated(ctx, rotation.DomainName, rotation.Member)
 	}
 	for _, record := range genesisState.RevokedValidatorKeys {
 		am.keeper.restoreRevokedValidatorKey(ctx, record)
 	}
 
 	// Register genesis validators and build initial validator set. Only
-	// explicitly or legacy-derived active records receive consensus power;
-	// retained inactive claims are restored exactly as exported.
-	var updates []abci.ValidatorUpdate
+	// explicitly or legacy-derived active records receive consensus power,
+	// and only the MaxValidators best-ranked of those take a seat; retained
+	// inactive claims are restored exactly as exported.
+	if params := genesisState.ValidatorSetParams; params != nil {
+		am.keeper.setValidatorSetParams(ctx, *params)
+	}
+	for _, vote := range genesisState.ValidatorSetVotes {
+		store.Set(validatorSetVoteKey(vote.Params, vote.Voter), am.cdc.MustMarshalLengthPrefixed(&vote.Params))
+	}
+	if pool := genesisState.StakingRewardPool; pool != nil {
+		// SetValidator below adds every restored validator's stake back.
+		am.keeper.setStakingRewardPool(ctx, StakingRewardPool{
+			Index:       intOrZero(pool.Index),
+			BondedStake: math.ZeroInt(),
+			Outstanding: intOrZero(pool.Outstanding),
+		})
+	}
 	for _, gv := range genesisState.Validators {
-		domains, power, active, err := resolveGenesisValidator(gv)
+		domains, power, _, err := resolveGenesisValidator(gv)
 		if err != nil {
 			panic(err)
 		}
@@ -192,17 +277,21 @@ func (am AppModule) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, data json.
 			Jailed:       gv.Jailed,
 			JailedUntil:  gv.JailedUntil,
 			MissedBlocks: gv.MissedBlocks,
+			// Delegations are restored below; DelegatedStake is their sum.
+			DelegatedStake: gv.DelegatedStake,
+			CommissionBps:  gv.CommissionBps,
 		}
 		am.keeper.SetValidator(ctx, validator)
 		store.Set(valPubKeyKey(gv.PubKey), []byte(gv.OperatorAddr))
 		store.Set(consensusAuthorityIndexKey(consensusKeyDerivedOperator(gv.PubKey)), []byte(gv.OperatorAddr))
 		am.keeper.registerConsensusKeyRecord(ctx, gv.PubKey, gv.OperatorAddr, genesisConsensusActivationHeight(ctx))
-		if active {
-			pk := cryptoproto.PublicKey{
-				Sum: &cryptoproto.PublicKey_Ed25519{Ed25519: gv.PubKey},
-			}
-			updates = append(updates, abci.ValidatorUpdate{PubKey: pk, Power: power})
+	}
+	var updates []abci.ValidatorUpdate
+	for _, validator := range am.keeper.initValidatorSet(ctx) {
+		pk := cryptoproto.PublicKey{
+			Sum: &cryptoproto.PublicKey_Ed25519{Ed25519: validator.PubKey},
 		}
+		updates = append(updates, abci.ValidatorUpdate{PubKey: pk, Power: validator.Power})
 	}
 	for _, rotation := range genesisState.PendingValidatorRotations {
 		am.keeper.restorePendingValidatorKeyRotation(ctx, rotation)
@@ -210,6 +299,25 @@ func (am AppModule) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, data json.
 	for _, removal := range genesisState.PendingValidatorRemovals {
 		am.keeper.SetPendingValidatorRemoval(ctx, removal)
 	}
+	for _, description := range genesisState.ValidatorDescriptions {
+		am.keeper.setValidatorDescription(ctx, description)
+	}
+	for _, rs := range genesisState.ValidatorRewards {
+		rs.PoolIndex = intOrZero(rs.PoolIndex)
+		rs.DelegatorIndex = intOrZero(rs.DelegatorIndex)
+		am.keeper.setValidatorRewards(ctx, rs)
+	}
+	for _, acct := range genesisState.StakingRewardAccounts {
+		acct.Unclaimed = intOrZero(acct.Unclaimed)
+		am.keeper.setStakingRewardAccount(ctx, acct)
+	}
+	for _, delegation := range genesisState.StakeDelegations {
+		delegation.RewardIndex = intOrZero(delegation.RewardIndex)
+		am.keeper.storeStakeDelegation(ctx, delegation)
+	}
+	for _, hold := range genesisState.PendingUndelegations {
+		am.keeper.setPendingUndelegation(ctx, hold)
+	}
 	for _, record := range genesisState.ConsensusKeyHistory {
 		am.keeper.setConsensusKeyRecord(ctx, record)
 		store.Set(
@@ -237,6 +345,24 @@ func (am AppModule) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, data json.
 			panic(err)
 		}
 		am.keeper.SetVerifyingKey(ctx, vkBytes)
+		am.keeper.setMembershipCircuitID(ctx, genesisState.ZKPCircuitID)
+	}
+	if genesisState.RetiringVerifyingKey != nil {
+		am.keeper.setRetiringVerifyingKey(ctx, *genesisState.RetiringVerifyingKey)
+	}
+	for _, slot := range genesisState.CircuitVerifyingKeys {
+		vkBytes, err := hex.DecodeString(slot.VerifyingKeyHex)
+		if err != nil {
+			panic(err)
+		}
+		if _, err := ValidateMembershipVerifyingKey(vkBytes, slot.CircuitID, slot.VerifyingKeySHA256); err != nil {
+			panic(err)
+		}
+		am.keeper.setFamilyVerifyingKey(ctx, membershipCircuitNullifierFamilies[slot.CircuitID], vkBytes)
+		am.keeper.setMembershipCircuitID(ctx, slot.CircuitID)
+		if slot.Retiring != nil {
+			am.keeper.setRetiringVerifyingKey(ctx, *slot.Retiring)
+		}
 	}
 
 	// Initialize PoD reward tracking state.
@@ -255,8 +381,8 @@ func (am AppModule) BeginBlock(goCtx context.Context) error {
 	return am.keeper.ProcessConsensusSignals(sdk.UnwrapSDKContext(goCtx))
 }
 
-// EndBlock implements module.HasABCIEndBlock. It distributes staking rewards
-// and domain interest, disables ineligible validators without destroying
+// EndBlock implements module.HasABCIEndBlock. It accrues staking rewards,
+// distributes domain interest, disables ineligible validators without destroying
 // their escrow claims, and returns CometBFT validator set updates.
 func (am AppModule) EndBlock(goCtx context.Context) ([]abci.ValidatorUpdate, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
@@ -314,22 +440,37 @@ func (am AppModule) EndBlock(goCtx context.Context) ([]abci.ValidatorUpdate, err
 		am.keeper.SetValidator(ctx, validator)
 	}
 
-	// 4. Evaluate suggestion lifecycle zones (green/yellow/red → auto-delete).
+	// 4. Finalize issues whose deadline passed or whose quorum was reached,
+	// before lifecycle zones can delete any of their suggestions.
+	if err := am.keeper.ProcessIssueDecisions(ctx); err != nil {
+		return nil, err
+	}
+
+	// 5. Evaluate suggestion lifecycle zones (green/yellow/red → auto-delete).
 	am.keeper.ProcessAllLifecycles(ctx)
 
-	// 5. Governance: admin election and inactivity cleanup.
+	// 6. Pay the treasury payout tranches that steps 4 and 5 approved or that
+	// fell due.
+	if err := am.keeper.ProcessTreasuryPayouts(ctx); err != nil {
+		return nil, err
+	}
+
+	// 7. Governance: admin election and inactivity cleanup.
 	am.keeper.ProcessGovernance(ctx)
 
-	// 6. Check and execute Big Purges (WP S4: periodic permission register cleanup).
+	// 8. Check and execute Big Purges (WP S4: periodic permission register cleanup).
 	am.keeper.CheckAndExecuteBigPurges(ctx)
 
-	// 7. Release validator exit holds only after both CometBFT evidence-age
-	// boundaries have been strictly exceeded.
+	// 9. Release validator exit and undelegation holds only after both
+	// CometBFT evidence-age boundaries have been strictly exceeded.
 	if err := am.keeper.ProcessPendingValidatorRemovals(ctx); err != nil {
 		return nil, err
 	}
+	if err := am.keeper.ProcessPendingUndelegations(ctx); err != nil {
+		return nil, err
+	}
 
-	// 8. Build and return validator updates.
+	// 10. Build and return validator updates.
 	updates := am.keeper.BuildValidatorUpdates(ctx)
 	return updates, nil
 }
@@ -356,16 +497,18 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 		}
 		active := !v.Jailed && v.Power > 0
 		validators = append(validators, GenesisValidator{
-			OperatorAddr: v.OperatorAddr,
-			PubKey:       v.PubKey,
-			Stake:        v.Stake.AmountOf(PNYXDenom).Int64(),
-			Domain:       domain,
-			Domains:      domains,
-			Power:        v.Power,
-			Active:       &active,
-			Jailed:       v.Jailed,
-			JailedUntil:  v.JailedUntil,
-			MissedBlocks: v.MissedBlocks,
+			OperatorAddr:   v.OperatorAddr,
+			PubKey:         v.PubKey,
+			Stake:          v.Stake.AmountOf(PNYXDenom).Int64(),
+			Domain:         domain,
+			Domains:        domains,
+			Power:          v.Power,
+			Active:         &active,
+			Jailed:         v.Jailed,
+			JailedUntil:    v.JailedUntil,
+			MissedBlocks:   v.MissedBlocks,
+			DelegatedStake: v.DelegatedStake,
+			CommissionBps:  v.CommissionBps,
 		})
 		return false
 	})
@@ -420,15 +563,62 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 	if pendingValidatorRemovals == nil {
 		pendingValidatorRemovals = []PendingValidatorRemoval{}
 	}
+	var validatorDescriptions []ValidatorDescription
+	am.keeper.IterateValidatorDescriptions(ctx, func(description ValidatorDescription) bool {
+		validatorDescriptions = append(validatorDescriptions, description)
+		return false
+	})
+	var stakeDelegations []StakeDelegation
+	am.keeper.IterateStakeDelegations(ctx, func(delegation StakeDelegation) bool {
+		stakeDelegations = append(stakeDelegations, delegation)
+		return false
+	})
+	var pendingUndelegations []PendingUndelegation
+	am.keeper.IteratePendingUndelegations(ctx, func(hold PendingUndelegation) bool {
+		pendingUndelegations = append(pendingUndelegations, hold)
+		return false
+	})
+	rewardPool := am.keeper.getStakingRewardPool(ctx)
+	var validatorRewards []ValidatorRewards
+	am.keeper.IterateValidatorRewards(ctx, func(rs ValidatorRewards) bool {
+		validatorRewards = append(validatorRewards, rs)
+		return false
+	})
+	var rewardAccounts []StakingRewardAccount
+	am.keeper.IterateStakingRewardAccounts(ctx, func(acct StakingRewardAccount) bool {
+		rewardAccounts = append(rewardAccounts, acct)
+		return false
+	})
+	validatorSetParams := am.keeper.GetValidatorSetParams(ctx)
+	var validatorSetVotes []ValidatorSetVote
+	am.keeper.IterateValidatorSetVotes(ctx, func(vote ValidatorSetVote) bool {
+		validatorSetVotes = append(validatorSetVotes, vote)
+		return false
+	})
 	lastCommitCursor, _ := am.keeper.getLastCommitCursor(ctx)
-	usedNullifiers := make([]NullifierRecord, 0)
-	nullifierStore := storeprefix.NewStore(ctx.KVStore(am.keeper.StoreKey), []byte("nullifier:"))
-	iterator := nullifierStore.Iterator(nil, nil)
-	defer iterator.Close()
-	for ; iterator.Valid(); iterator.Next() {
-		var record NullifierRecord
-		am.keeper.cdc.MustUnmarshalLengthPrefixed(iterator.Value(), &record)
-		usedNullifiers = append(usedNullifiers, record)
+	nullifierScopes := am.keeper.exportNullifierScopes(ctx)
+
+	var issueDecisions []IssueDecision
+	am.keeper.IterateIssueDecisions(ctx, func(decision IssueDecision) bool {
+		issueDecisions = append(issueDecisions, decision)
+		return false
+	})
+
+	voteDelegations, voterModes := am.keeper.exportVoteDelegations(ctx)
+
+	var treasuryPayouts []PayoutRecord
+	am.keeper.IteratePayoutRecords(ctx, func(record PayoutRecord) bool {
+		treasuryPayouts = append(treasuryPayouts, record)
+		return false
+	})
+
+	var optionsHistory []DomainOptionsChange
+	var leafOwners []IdentityLeafOwner
+	var identityRotations []IdentityRotation
+	for _, domain := range domains {
+		optionsHistory = append(optionsHistory, am.keeper.GetDomainOptionsHistory(ctx, domain.Name)...)
+		leafOwners = append(leafOwners, am.keeper.GetIdentityLeafOwners(ctx, domain.Name)...)
+		identityRotations = append(identityRotations, am.keeper.GetIdentityRotations(ctx, domain.Name)...)
 	}
 
 	vkHex := ""
@@ -437,8 +627,13 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 	if vkBytes, found := am.keeper.GetVerifyingKey(ctx); found {
 		vkHex = hex.EncodeToString(vkBytes)
 		vkFingerprint = VerifyingKeyFingerprint(vkBytes)
-		circuitID = MembershipCircuitID
+		circuitID = am.keeper.GetMembershipCircuitID(ctx)
+	}
+	var retiringKey *RetiringVerifyingKey
+	if retiring, ok := am.keeper.activeRetiringVerifyingKey(ctx, HashFamilyMiMC); ok {
+		retiringKey = &retiring
 	}
+	circuitKeys := am.keeper.exportCircuitVerifyingKeys(ctx)
 	upgradeProposal, upgradeVotes, upgradeCancelProposal, upgradeCancelVotes :=
 		am.keeper.ExportSoftwareUpgradeGovernance(ctx)
 
@@ -451,11 +646,28 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 		ValidatorSigningInfos:     validatorSigningInfos,
 		ProcessedInfractions:      processedInfractions,
 		PendingValidatorRemovals:  pendingValidatorRemovals,
+		ValidatorDescriptions:     validatorDescriptions,
+		StakeDelegations:          stakeDelegations,
+		PendingUndelegations:      pendingUndelegations,
+		StakingRewardPool:         &rewardPool,
+		ValidatorRewards:          validatorRewards,
+		StakingRewardAccounts:     rewardAccounts,
+		ValidatorSetParams:        &validatorSetParams,
+		ValidatorSetVotes:         validatorSetVotes,
 		LastCommitCursor:          lastCommitCursor,
-		UsedNullifiers:            usedNullifiers,
+		NullifierScopes:           nullifierScopes,
+		IssueDecisions:            issueDecisions,
+		VoteDelegations:           voteDelegations,
+		VoterModes:                voterModes,
+		TreasuryPayouts:           treasuryPayouts,
+		DomainOptionsHistory:      optionsHistory,
+		IdentityLeafOwners:        leafOwners,
+		IdentityRotations:         identityRotations,
 		ZKPCircuitID:              circuitID,
 		VerifyingKeyHex:           vkHex,
 		VerifyingKeySHA256:        vkFingerprint,
+		RetiringVerifyingKey:      retiringKey,
+		CircuitVerifyingKeys:      circuitKeys,
 		SoftwareUpgradeProposal:   upgradeProposal,
 		SoftwareUpgradeVotes:      upgradeVotes,
 		UpgradeCancelProposal:     upgradeCancelProposal,
@@ -87,6 +87,12 @@ func msgTypesForDescriptor() []reflect.Type {
 		reflect.TypeOf((*MsgSubmitProposal)(nil)),
 		reflect.TypeOf((*MsgRegisterValidator)(nil)),
 		reflect.TypeOf((*MsgWithdrawStake)(nil)),
+		reflect.TypeOf((*MsgDelegateStake)(nil)),
+		reflect.TypeOf((*MsgUndelegateStake)(nil)),
+		reflect.TypeOf((*MsgEditValidator)(nil)),
+		reflect.TypeOf((*MsgClaimStakingRewards)(nil)),
+		reflect.TypeOf((*MsgSetStakingRewardAddress)(nil)),
+		reflect.TypeOf((*MsgVoteValidatorSetParams)(nil)),
 		reflect.TypeOf((*MsgRemoveValidator)(nil)),
 		reflect.TypeOf((*MsgRotateValidatorKey)(nil)),
 		reflect.TypeOf((*MsgUnjail)(nil)),
@@ -99,16 +105,29 @@ func msgTypesForDescriptor() []reflect.Type {
 		reflect.TypeOf((*MsgVoteToDelete)(nil)),
 		reflect.TypeOf((*MsgRateProposal)(nil)),
 		reflect.TypeOf((*MsgCastElectionVote)(nil)),
+		reflect.TypeOf((*MsgCastRankedElectionVote)(nil)),
 		reflect.TypeOf((*MsgAddMember)(nil)),
 		reflect.TypeOf((*MsgOnboardToDomain)(nil)),
 		reflect.TypeOf((*MsgApproveOnboarding)(nil)),
 		reflect.TypeOf((*MsgRejectOnboarding)(nil)),
 		reflect.TypeOf((*MsgRegisterIdentity)(nil)),
 		reflect.TypeOf((*MsgRateWithProof)(nil)),
+		reflect.TypeOf((*MsgCastElectionVoteWithProof)(nil)),
+		reflect.TypeOf((*MsgPlaceStoneWithProof)(nil)),
+		reflect.TypeOf((*MsgRateOpenly)(nil)),
+		reflect.TypeOf((*MsgDelegateVote)(nil)),
+		reflect.TypeOf((*MsgCreateSubDomain)(nil)),
+		reflect.TypeOf((*MsgVoteSubDomainBudget)(nil)),
+		reflect.TypeOf((*MsgDelegateIssueToSubDomain)(nil)),
+		reflect.TypeOf((*MsgProposeDomainOptionsChange)(nil)),
+		reflect.TypeOf((*MsgRotateIdentityCommitment)(nil)),
 		reflect.TypeOf((*MsgDepositToDomain)(nil)),
 		reflect.TypeOf((*MsgWithdrawFromDomain)(nil)),
 		reflect.TypeOf((*MsgVoteSoftwareUpgrade)(nil)),
 		reflect.TypeOf((*MsgVoteCancelSoftwareUpgrade)(nil)),
+		reflect.TypeOf((*MsgVoteVerifyingKeyRotation)(nil)),
+		reflect.TypeOf((*MsgSubmitProposalWithProof)(nil)),
+		reflect.TypeOf((*MsgSignalWithProof)(nil)),
 	}
 }
 
@@ -118,6 +137,12 @@ func msgResponseTypesForDescriptor() []string {
 		"MsgSubmitProposalResponse",
 		"MsgRegisterValidatorResponse",
 		"MsgWithdrawStakeResponse",
+		"MsgDelegateStakeResponse",
+		"MsgUndelegateStakeResponse",
+		"MsgEditValidatorResponse",
+		"MsgClaimStakingRewardsResponse",
+		"MsgSetStakingRewardAddressResponse",
+		"MsgVoteValidatorSetParamsResponse",
 		"MsgRemoveValidatorResponse",
 		"MsgRotateValidatorKeyResponse",
 		"MsgUnjailResponse",
@@ -130,16 +155,29 @@ func msgResponseTypesForDescriptor() []string {
 		"MsgVoteToDeleteResponse",
 		"MsgRateProposalResponse",
 		"MsgCastElectionVoteResponse",
+		"MsgCastRankedElectionVoteResponse",
 		"MsgAddMemberResponse",
 		"MsgOnboardToDomainResponse",
 		"MsgApproveOnboardingResponse",
 		"MsgRejectOnboardingResponse",
 		"MsgRegisterIdentityResponse",
 		"MsgRateWithProofResponse",
+		"MsgCastElectionVoteWithProofResponse",
+		"MsgPlaceStoneWithProofResponse",
+		"MsgRateOpenlyResponse",
+		"MsgDelegateVoteResponse",
+		"MsgCreateSubDomainResponse",
+		"MsgVoteSubDomainBudgetResponse",
+		"MsgDelegateIssueToSubDomainResponse",
+		"MsgProposeDomainOptionsChangeResponse",
+		"MsgRotateIdentityCommitmentResponse",
 		"MsgDepositToDomainResponse",
 		"MsgWithdrawFromDomainResponse",
 		"MsgVoteSoftwareUpgradeResponse",
 		"MsgVoteCancelSoftwareUpgradeResponse",
+		"MsgVoteVerifyingKeyRotationResponse",
+		"MsgSubmitProposalWithProofResponse",
+		"MsgSignalWithProofResponse",
 	}
 }
 
@@ -200,9 +238,14 @@ func descriptorTypeForGoField(fieldType reflect.Type) (descriptorpb.FieldDescrip
 	if fieldType.Kind() == reflect.Slice && fieldType.Elem().Name() == "Coin" {
 		return descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, proto2.String(".cosmos.base.v1beta1.Coin")
 	}
+	if fieldType.Kind() == reflect.Slice {
+		return descriptorTypeForGoField(fieldType.Elem())
+	}
 	switch fieldType.Kind() {
 	case reflect.String:
 		return descriptorpb.FieldDescriptorProto_TYPE_STRING, nil
+	case reflect.Bool:
+		return descriptorpb.FieldDescriptorProto_TYPE_BOOL, nil
 	case reflect.Int32:
 		return descriptorpb.FieldDescriptorProto_TYPE_INT32, nil
 	case reflect.Int64:
@@ -229,6 +272,28 @@ func (*MsgRegisterValidator) Descriptor() ([]byte, []int) {
 func (*MsgWithdrawStake) Descriptor() ([]byte, []int) {
 	return descriptorForMessage("MsgWithdrawStake")
 }
+func (*MsgDelegateStake) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgDelegateStake")
+}
+func (*MsgUndelegateStake) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgUndelegateStake")
+}
+
+func (*MsgEditValidator) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgEditValidator")
+}
+
+func (*MsgClaimStakingRewards) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgClaimStakingRewards")
+}
+
+func (*MsgSetStakingRewardAddress) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgSetStakingRewardAddress")
+}
+
+func (*MsgVoteValidatorSetParams) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgVoteValidatorSetParams")
+}
 func (*MsgRemoveValidator) Descriptor() ([]byte, []int) {
 	return descriptorForMessage("MsgRemoveValidator")
 }
@@ -259,6 +324,9 @@ func (*MsgRateProposal) Descriptor() ([]byte, []int) { return descriptorForMessa
 func (*MsgCastElectionVote) Descriptor() ([]byte, []int) {
 	return descriptorForMessage("MsgCastElectionVote")
 }
+func (*MsgCastRankedElectionVote) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgCastRankedElectionVote")
+}
 func (*MsgAddMember) Descriptor() ([]byte, []int) { return descriptorForMessage("MsgAddMember") }
 func (*MsgOnboardToDomain) Descriptor() ([]byte, []int) {
 	return descriptorForMessage("MsgOnboardToDomain")
@@ -275,6 +343,33 @@ func (*MsgRegisterIdentity) Descriptor() ([]byte, []int) {
 func (*MsgRateWithProof) Descriptor() ([]byte, []int) {
 	return descriptorForMessage("MsgRateWithProof")
 }
+func (*MsgCastElectionVoteWithProof) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgCastElectionVoteWithProof")
+}
+func (*MsgPlaceStoneWithProof) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgPlaceStoneWithProof")
+}
+func (*MsgRateOpenly) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgRateOpenly")
+}
+func (*MsgDelegateVote) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgDelegateVote")
+}
+func (*MsgCreateSubDomain) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgCreateSubDomain")
+}
+func (*MsgVoteSubDomainBudget) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgVoteSubDomainBudget")
+}
+func (*MsgDelegateIssueToSubDomain) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgDelegateIssueToSubDomain")
+}
+func (*MsgProposeDomainOptionsChange) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgProposeDomainOptionsChange")
+}
+func (*MsgRotateIdentityCommitment) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgRotateIdentityCommitment")
+}
 func (*MsgDepositToDomain) Descriptor() ([]byte, []int) {
 	return descriptorForMessage("MsgDepositToDomain")
 }
@@ -293,6 +388,28 @@ func (*MsgRegisterValidatorResponse) Descriptor() ([]byte, []int) {
 func (*MsgWithdrawStakeResponse) Descriptor() ([]byte, []int) {
 	return descriptorForMessage("MsgWithdrawStakeResponse")
 }
+func (*MsgDelegateStakeResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgDelegateStakeResponse")
+}
+func (*MsgUndelegateStakeResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgUndelegateStakeResponse")
+}
+
+func (*MsgEditValidatorResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgEditValidatorResponse")
+}
+
+func (*MsgClaimStakingRewardsResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgClaimStakingRewardsResponse")
+}
+
+func (*MsgSetStakingRewardAddressResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgSetStakingRewardAddressResponse")
+}
+
+func (*MsgVoteValidatorSetParamsResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgVoteValidatorSetParamsResponse")
+}
 func (*MsgRemoveValidatorResponse) Descriptor() ([]byte, []int) {
 	return descriptorForMessage("MsgRemoveValidatorResponse")
 }
@@ -329,6 +446,9 @@ func (*MsgRateProposalResponse) Descriptor() ([]byte, []int) {
 func (*MsgCastElectionVoteResponse) Descriptor() ([]byte, []int) {
 	return descriptorForMessage("MsgCastElectionVoteResponse")
 }
+func (*MsgCastRankedElectionVoteResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgCastRankedElectionVoteResponse")
+}
 func (*MsgAddMemberResponse) Descriptor() ([]byte, []int) {
 	return descriptorForMessage("MsgAddMemberResponse")
 }
@@ -347,6 +467,24 @@ func (*MsgRegisterIdentityResponse) Descriptor() ([]byte, []int) {
 func (*MsgRateWithProofResponse) Descriptor() ([]byte, []int) {
 	return descriptorForMessage("MsgRateWithProofResponse")
 }
+func (*MsgCastElectionVoteWithProofResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgCastElectionVoteWithProofResponse")
+}
+func (*MsgPlaceStoneWithProofResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgPlaceStoneWithProofResponse")
+}
+func (*MsgRateOpenlyResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgRateOpenlyResponse")
+}
+func (*MsgDelegateVoteResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgDelegateVoteResponse")
+}
+func (*MsgProposeDomainOptionsChangeResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgProposeDomainOptionsChangeResponse")
+}
+func (*MsgRotateIdentityCommitmentResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgRotateIdentityCommitmentResponse")
+}
 func (*MsgDepositToDomainResponse) Descriptor() ([]byte, []int) {
 	return descriptorForMessage("MsgDepositToDomainResponse")
 }
@@ -359,9 +497,27 @@ func (*MsgVoteSoftwareUpgrade) Descriptor() ([]byte, []int) {
 func (*MsgVoteCancelSoftwareUpgrade) Descriptor() ([]byte, []int) {
 	return descriptorForMessage("MsgVoteCancelSoftwareUpgrade")
 }
+func (*MsgVoteVerifyingKeyRotation) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgVoteVerifyingKeyRotation")
+}
+func (*MsgSubmitProposalWithProof) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgSubmitProposalWithProof")
+}
+func (*MsgSignalWithProof) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgSignalWithProof")
+}
 func (*MsgVoteSoftwareUpgradeResponse) Descriptor() ([]byte, []int) {
 	return descriptorForMessage("MsgVoteSoftwareUpgradeResponse")
 }
 func (*MsgVoteCancelSoftwareUpgradeResponse) Descriptor() ([]byte, []int) {
 	return descriptorForMessage("MsgVoteCancelSoftwareUpgradeResponse")
 }
+func (*MsgVoteVerifyingKeyRotationResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgVoteVerifyingKeyRotationResponse")
+}
+func (*MsgSubmitProposalWithProofResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgSubmitProposalWithProofResponse")
+}
+func (*MsgSignalWithProofResponse) Descriptor() ([]byte, []int) {
+	return descriptorForMessage("MsgSignalWithProofResponse")
+}
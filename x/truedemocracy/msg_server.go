@@ -42,6 +42,46 @@ func (*MsgWithdrawStakeResponse) ProtoMessage()  {}
 func (*MsgWithdrawStakeResponse) Reset()         {}
 func (*MsgWithdrawStakeResponse) String() string { return "MsgWithdrawStakeResponse" }
 
+type MsgDelegateStakeResponse struct{}
+
+func (*MsgDelegateStakeResponse) ProtoMessage()  {}
+func (*MsgDelegateStakeResponse) Reset()         {}
+func (*MsgDelegateStakeResponse) String() string { return "MsgDelegateStakeResponse" }
+
+type MsgUndelegateStakeResponse struct{}
+
+func (*MsgUndelegateStakeResponse) ProtoMessage()  {}
+func (*MsgUndelegateStakeResponse) Reset()         {}
+func (*MsgUndelegateStakeResponse) String() string { return "MsgUndelegateStakeResponse" }
+
+type MsgEditValidatorResponse struct{}
+
+func (*MsgEditValidatorResponse) ProtoMessage()  {}
+func (*MsgEditValidatorResponse) Reset()         {}
+func (*MsgEditValidatorResponse) String() string { return "MsgEditValidatorResponse" }
+
+type MsgVoteValidatorSetParamsResponse struct{}
+
+func (*MsgVoteValidatorSetParamsResponse) ProtoMessage() {}
+func (*MsgVoteValidatorSetParamsResponse) Reset()        {}
+func (*MsgVoteValidatorSetParamsResponse) String() string {
+	return "MsgVoteValidatorSetParamsResponse"
+}
+
+type MsgClaimStakingRewardsResponse struct{}
+
+func (*MsgClaimStakingRewardsResponse) ProtoMessage()  {}
+func (*MsgClaimStakingRewardsResponse) Reset()         {}
+func (*MsgClaimStakingRewardsResponse) String() string { return "MsgClaimStakingRewardsResponse" }
+
+type MsgSetStakingRewardAddressResponse struct{}
+
+func (*MsgSetStakingRewardAddressResponse) ProtoMessage() {}
+func (*MsgSetStakingRewardAddressResponse) Reset()        {}
+func (*MsgSetStakingRewardAddressResponse) String() string {
+	return "MsgSetStakingRewardAddressResponse"
+}
+
 type MsgRemoveValidatorResponse struct{}
 
 func (*MsgRemoveValidatorResponse) ProtoMessage()  {}
@@ -116,6 +156,68 @@ func (*MsgRateWithProofResponse) ProtoMessage()  {}
 func (*MsgRateWithProofResponse) Reset()         {}
 func (*MsgRateWithProofResponse) String() string { return "MsgRateWithProofResponse" }
 
+type MsgCastElectionVoteWithProofResponse struct{}
+
+func (*MsgCastElectionVoteWithProofResponse) ProtoMessage() {}
+func (*MsgCastElectionVoteWithProofResponse) Reset()        {}
+func (*MsgCastElectionVoteWithProofResponse) String() string {
+	return "MsgCastElectionVoteWithProofResponse"
+}
+
+type MsgPlaceStoneWithProofResponse struct{}
+
+func (*MsgPlaceStoneWithProofResponse) ProtoMessage()  {}
+func (*MsgPlaceStoneWithProofResponse) Reset()         {}
+func (*MsgPlaceStoneWithProofResponse) String() string { return "MsgPlaceStoneWithProofResponse" }
+
+type MsgRateOpenlyResponse struct{}
+
+func (*MsgRateOpenlyResponse) ProtoMessage()  {}
+func (*MsgRateOpenlyResponse) Reset()         {}
+func (*MsgRateOpenlyResponse) String() string { return "MsgRateOpenlyResponse" }
+
+type MsgDelegateVoteResponse struct{}
+
+func (*MsgDelegateVoteResponse) ProtoMessage()  {}
+func (*MsgDelegateVoteResponse) Reset()         {}
+func (*MsgDelegateVoteResponse) String() string { return "MsgDelegateVoteResponse" }
+
+type MsgCreateSubDomainResponse struct{}
+
+func (*MsgCreateSubDomainResponse) ProtoMessage()  {}
+func (*MsgCreateSubDomainResponse) Reset()         {}
+func (*MsgCreateSubDomainResponse) String() string { return "MsgCreateSubDomainResponse" }
+
+type MsgVoteSubDomainBudgetResponse struct{}
+
+func (*MsgVoteSubDomainBudgetResponse) ProtoMessage()  {}
+func (*MsgVoteSubDomainBudgetResponse) Reset()         {}
+func (*MsgVoteSubDomainBudgetResponse) String() string { return "MsgVoteSubDomainBudgetResponse" }
+
+type MsgDelegateIssueToSubDomainResponse struct{}
+
+func (*MsgDelegateIssueToSubDomainResponse) ProtoMessage() {}
+func (*MsgDelegateIssueToSubDomainResponse) Reset()        {}
+func (*MsgDelegateIssueToSubDomainResponse) String() string {
+	return "MsgDelegateIssueToSubDomainResponse"
+}
+
+type MsgProposeDomainOptionsChangeResponse struct{}
+
+func (*MsgProposeDomainOptionsChangeResponse) ProtoMessage() {}
+func (*MsgProposeDomainOptionsChangeResponse) Reset()        {}
+func (*MsgProposeDomainOptionsChangeResponse) String() string {
+	return "MsgProposeDomainOptionsChangeResponse"
+}
+
+type MsgRotateIdentityCommitmentResponse struct{}
+
+func (*MsgRotateIdentityCommitmentResponse) ProtoMessage() {}
+func (*MsgRotateIdentityCommitmentResponse) Reset()        {}
+func (*MsgRotateIdentityCommitmentResponse) String() string {
+	return "MsgRotateIdentityCommitmentResponse"
+}
+
 type MsgAddMemberResponse struct{}
 
 func (*MsgAddMemberResponse) ProtoMessage()  {}
@@ -152,6 +254,12 @@ func (*MsgCastElectionVoteResponse) ProtoMessage()  {}
 func (*MsgCastElectionVoteResponse) Reset()         {}
 func (*MsgCastElectionVoteResponse) String() string { return "MsgCastElectionVoteResponse" }
 
+type MsgCastRankedElectionVoteResponse struct{}
+
+func (*MsgCastRankedElectionVoteResponse) ProtoMessage()  {}
+func (*MsgCastRankedElectionVoteResponse) Reset()         {}
+func (*MsgCastRankedElectionVoteResponse) String() string { return "MsgCastRankedElectionVoteResponse" }
+
 type MsgDepositToDomainResponse struct{}
 
 func (*MsgDepositToDomainResponse) ProtoMessage()  {}
@@ -178,6 +286,30 @@ func (*MsgVoteCancelSoftwareUpgradeResponse) String() string {
 	return "MsgVoteCancelSoftwareUpgradeResponse"
 }
 
+type MsgVoteVerifyingKeyRotationResponse struct{}
+
+func (*MsgVoteVerifyingKeyRotationResponse) ProtoMessage() {}
+func (*MsgVoteVerifyingKeyRotationResponse) Reset()        {}
+func (*MsgVoteVerifyingKeyRotationResponse) String() string {
+	return "MsgVoteVerifyingKeyRotationResponse"
+}
+
+type MsgSubmitProposalWithProofResponse struct{}
+
+func (*MsgSubmitProposalWithProofResponse) ProtoMessage() {}
+func (*MsgSubmitProposalWithProofResponse) Reset()        {}
+func (*MsgSubmitProposalWithProofResponse) String() string {
+	return "MsgSubmitProposalWithProofResponse"
+}
+
+type MsgSignalWithProofResponse struct{}
+
+func (*MsgSignalWithProofResponse) ProtoMessage() {}
+func (*MsgSignalWithProofResponse) Reset()        {}
+func (*MsgSignalWithProofResponse) String() string {
+	return "MsgSignalWithProofResponse"
+}
+
 // ---------------------------------------------------------------------------
 // Register response types with gogoproto
 // ---------------------------------------------------------------------------
@@ -189,6 +321,12 @@ func init() {
 	gogoproto.RegisterType((*MsgSubmitProposal)(nil), "truedemocracy.MsgSubmitProposal")
 	gogoproto.RegisterType((*MsgRegisterValidator)(nil), "truedemocracy.MsgRegisterValidator")
 	gogoproto.RegisterType((*MsgWithdrawStake)(nil), "truedemocracy.MsgWithdrawStake")
+	gogoproto.RegisterType((*MsgDelegateStake)(nil), "truedemocracy.MsgDelegateStake")
+	gogoproto.RegisterType((*MsgUndelegateStake)(nil), "truedemocracy.MsgUndelegateStake")
+	gogoproto.RegisterType((*MsgEditValidator)(nil), "truedemocracy.MsgEditValidator")
+	gogoproto.RegisterType((*MsgClaimStakingRewards)(nil), "truedemocracy.MsgClaimStakingRewards")
+	gogoproto.RegisterType((*MsgVoteValidatorSetParams)(nil), "truedemocracy.MsgVoteValidatorSetParams")
+	gogoproto.RegisterType((*MsgSetStakingRewardAddress)(nil), "truedemocracy.MsgSetStakingRewardAddress")
 	gogoproto.RegisterType((*MsgRemoveValidator)(nil), "truedemocracy.MsgRemoveValidator")
 	gogoproto.RegisterType((*MsgRotateValidatorKey)(nil), "truedemocracy.MsgRotateValidatorKey")
 	gogoproto.RegisterType((*MsgUnjail)(nil), "truedemocracy.MsgUnjail")
@@ -201,22 +339,41 @@ func init() {
 	gogoproto.RegisterType((*MsgVoteToDelete)(nil), "truedemocracy.MsgVoteToDelete")
 	gogoproto.RegisterType((*MsgRateProposal)(nil), "truedemocracy.MsgRateProposal")
 	gogoproto.RegisterType((*MsgCastElectionVote)(nil), "truedemocracy.MsgCastElectionVote")
+	gogoproto.RegisterType((*MsgCastRankedElectionVote)(nil), "truedemocracy.MsgCastRankedElectionVote")
 	gogoproto.RegisterType((*MsgAddMember)(nil), "truedemocracy.MsgAddMember")
 	gogoproto.RegisterType((*MsgOnboardToDomain)(nil), "truedemocracy.MsgOnboardToDomain")
 	gogoproto.RegisterType((*MsgApproveOnboarding)(nil), "truedemocracy.MsgApproveOnboarding")
 	gogoproto.RegisterType((*MsgRejectOnboarding)(nil), "truedemocracy.MsgRejectOnboarding")
 	gogoproto.RegisterType((*MsgRegisterIdentity)(nil), "truedemocracy.MsgRegisterIdentity")
 	gogoproto.RegisterType((*MsgRateWithProof)(nil), "truedemocracy.MsgRateWithProof")
+	gogoproto.RegisterType((*MsgCastElectionVoteWithProof)(nil), "truedemocracy.MsgCastElectionVoteWithProof")
+	gogoproto.RegisterType((*MsgPlaceStoneWithProof)(nil), "truedemocracy.MsgPlaceStoneWithProof")
+	gogoproto.RegisterType((*MsgRateOpenly)(nil), "truedemocracy.MsgRateOpenly")
+	gogoproto.RegisterType((*MsgDelegateVote)(nil), "truedemocracy.MsgDelegateVote")
+	gogoproto.RegisterType((*MsgCreateSubDomain)(nil), "truedemocracy.MsgCreateSubDomain")
+	gogoproto.RegisterType((*MsgVoteSubDomainBudget)(nil), "truedemocracy.MsgVoteSubDomainBudget")
+	gogoproto.RegisterType((*MsgDelegateIssueToSubDomain)(nil), "truedemocracy.MsgDelegateIssueToSubDomain")
+	gogoproto.RegisterType((*MsgProposeDomainOptionsChange)(nil), "truedemocracy.MsgProposeDomainOptionsChange")
+	gogoproto.RegisterType((*MsgRotateIdentityCommitment)(nil), "truedemocracy.MsgRotateIdentityCommitment")
 	gogoproto.RegisterType((*MsgDepositToDomain)(nil), "truedemocracy.MsgDepositToDomain")
 	gogoproto.RegisterType((*MsgWithdrawFromDomain)(nil), "truedemocracy.MsgWithdrawFromDomain")
 	gogoproto.RegisterType((*MsgVoteSoftwareUpgrade)(nil), "truedemocracy.MsgVoteSoftwareUpgrade")
 	gogoproto.RegisterType((*MsgVoteCancelSoftwareUpgrade)(nil), "truedemocracy.MsgVoteCancelSoftwareUpgrade")
+	gogoproto.RegisterType((*MsgVoteVerifyingKeyRotation)(nil), "truedemocracy.MsgVoteVerifyingKeyRotation")
+	gogoproto.RegisterType((*MsgSubmitProposalWithProof)(nil), "truedemocracy.MsgSubmitProposalWithProof")
+	gogoproto.RegisterType((*MsgSignalWithProof)(nil), "truedemocracy.MsgSignalWithProof")
 
 	// Register response types.
 	gogoproto.RegisterType((*MsgCreateDomainResponse)(nil), "truedemocracy.MsgCreateDomainResponse")
 	gogoproto.RegisterType((*MsgSubmitProposalResponse)(nil), "truedemocracy.MsgSubmitProposalResponse")
 	gogoproto.RegisterType((*MsgRegisterValidatorResponse)(nil), "truedemocracy.MsgRegisterValidatorResponse")
 	gogoproto.RegisterType((*MsgWithdrawStakeResponse)(nil), "truedemocracy.MsgWithdrawStakeResponse")
+	gogoproto.RegisterType((*MsgDelegateStakeResponse)(nil), "truedemocracy.MsgDelegateStakeResponse")
+	gogoproto.RegisterType((*MsgUndelegateStakeResponse)(nil), "truedemocracy.MsgUndelegateStakeResponse")
+	gogoproto.RegisterType((*MsgEditValidatorResponse)(nil), "truedemocracy.MsgEditValidatorResponse")
+	gogoproto.RegisterType((*MsgClaimStakingRewardsResponse)(nil), "truedemocracy.MsgClaimStakingRewardsResponse")
+	gogoproto.RegisterType((*MsgVoteValidatorSetParamsResponse)(nil), "truedemocracy.MsgVoteValidatorSetParamsResponse")
+	gogoproto.RegisterType((*MsgSetStakingRewardAddressResponse)(nil), "truedemocracy.MsgSetStakingRewardAddressResponse")
 	gogoproto.RegisterType((*MsgRemoveValidatorResponse)(nil), "truedemocracy.MsgRemoveValidatorResponse")
 	gogoproto.RegisterType((*MsgRotateValidatorKeyResponse)(nil), "truedemocracy.MsgRotateValidatorKeyResponse")
 	gogoproto.RegisterType((*MsgUnjailResponse)(nil), "truedemocracy.MsgUnjailResponse")
@@ -229,16 +386,29 @@ func init() {
 	gogoproto.RegisterType((*MsgVoteToDeleteResponse)(nil), "truedemocracy.MsgVoteToDeleteResponse")
 	gogoproto.RegisterType((*MsgRateProposalResponse)(nil), "truedemocracy.MsgRateProposalResponse")
 	gogoproto.RegisterType((*MsgCastElectionVoteResponse)(nil), "truedemocracy.MsgCastElectionVoteResponse")
+	gogoproto.RegisterType((*MsgCastRankedElectionVoteResponse)(nil), "truedemocracy.MsgCastRankedElectionVoteResponse")
 	gogoproto.RegisterType((*MsgAddMemberResponse)(nil), "truedemocracy.MsgAddMemberResponse")
 	gogoproto.RegisterType((*MsgOnboardToDomainResponse)(nil), "truedemocracy.MsgOnboardToDomainResponse")
 	gogoproto.RegisterType((*MsgApproveOnboardingResponse)(nil), "truedemocracy.MsgApproveOnboardingResponse")
 	gogoproto.RegisterType((*MsgRejectOnboardingResponse)(nil), "truedemocracy.MsgRejectOnboardingResponse")
 	gogoproto.RegisterType((*MsgRegisterIdentityResponse)(nil), "truedemocracy.MsgRegisterIdentityResponse")
 	gogoproto.RegisterType((*MsgRateWithProofResponse)(nil), "truedemocracy.MsgRateWithProofResponse")
+	gogoproto.RegisterType((*MsgCastElectionVoteWithProofResponse)(nil), "truedemocracy.MsgCastElectionVoteWithProofResponse")
+	gogoproto.RegisterType((*MsgPlaceStoneWithProofResponse)(nil), "truedemocracy.MsgPlaceStoneWithProofResponse")
+	gogoproto.RegisterType((*MsgRateOpenlyResponse)(nil), "truedemocracy.MsgRateOpenlyResponse")
+	gogoproto.RegisterType((*MsgDelegateVoteResponse)(nil), "truedemocracy.MsgDelegateVoteResponse")
+	gogoproto.RegisterType((*MsgCreateSubDomainResponse)(nil), "truedemocracy.MsgCreateSubDomainResponse")
+	gogoproto.RegisterType((*MsgVoteSubDomainBudgetResponse)(nil), "truedemocracy.MsgVoteSubDomainBudgetResponse")
+	gogoproto.RegisterType((*MsgDelegateIssueToSubDomainResponse)(nil), "truedemocracy.MsgDelegateIssueToSubDomainResponse")
+	gogoproto.RegisterType((*MsgProposeDomainOptionsChangeResponse)(nil), "truedemocracy.MsgProposeDomainOptionsChangeResponse")
+	gogoproto.RegisterType((*MsgRotateIdentityCommitmentResponse)(nil), "truedemocracy.MsgRotateIdentityCommitmentResponse")
 	gogoproto.RegisterType((*MsgDepositToDomainResponse)(nil), "truedemocracy.MsgDepositToDomainResponse")
 	gogoproto.RegisterType((*MsgWithdrawFromDomainResponse)(nil), "truedemocracy.MsgWithdrawFromDomainResponse")
 	gogoproto.RegisterType((*MsgVoteSoftwareUpgradeResponse)(nil), "truedemocracy.MsgVoteSoftwareUpgradeResponse")
 	gogoproto.RegisterType((*MsgVoteCancelSoftwareUpgradeResponse)(nil), "truedemocracy.MsgVoteCancelSoftwareUpgradeResponse")
+	gogoproto.RegisterType((*MsgVoteVerifyingKeyRotationResponse)(nil), "truedemocracy.MsgVoteVerifyingKeyRotationResponse")
+	gogoproto.RegisterType((*MsgSubmitProposalWithProofResponse)(nil), "truedemocracy.MsgSubmitProposalWithProofResponse")
+	gogoproto.RegisterType((*MsgSignalWithProofResponse)(nil), "truedemocracy.MsgSignalWithProofResponse")
 }
 
 // ---------------------------------------------------------------------------
@@ -261,6 +431,12 @@ type MsgServer interface {
 	SubmitProposal(context.Context, *MsgSubmitProposal) (*MsgSubmitProposalResponse, error)
 	RegisterValidator(context.Context, *MsgRegisterValidator) (*MsgRegisterValidatorResponse, error)
 	WithdrawStake(context.Context, *MsgWithdrawStake) (*MsgWithdrawStakeResponse, error)
+	DelegateStake(context.Context, *MsgDelegateStake) (*MsgDelegateStakeResponse, error)
+	UndelegateStake(context.Context, *MsgUndelegateStake) (*MsgUndelegateStakeResponse, error)
+	EditValidator(context.Context, *MsgEditValidator) (*MsgEditValidatorResponse, error)
+	ClaimStakingRewards(context.Context, *MsgClaimStakingRewards) (*MsgClaimStakingRewardsResponse, error)
+	SetStakingRewardAddress(context.Context, *MsgSetStakingRewardAddress) (*MsgSetStakingRewardAddressResponse, error)
+	VoteValidatorSetParams(context.Context, *MsgVoteValidatorSetParams) (*MsgVoteValidatorSetParamsResponse, error)
 	RemoveValidator(context.Context, *MsgRemoveValidator) (*MsgRemoveValidatorResponse, error)
 	RotateValidatorKey(context.Context, *MsgRotateValidatorKey) (*MsgRotateValidatorKeyResponse, error)
 	Unjail(context.Context, *MsgUnjail) (*MsgUnjailResponse, error)
@@ -273,16 +449,29 @@ type MsgServer interface {
 	VoteToDelete(context.Context, *MsgVoteToDelete) (*MsgVoteToDeleteResponse, error)
 	RateProposal(context.Context, *MsgRateProposal) (*MsgRateProposalResponse, error)
 	CastElectionVote(context.Context, *MsgCastElectionVote) (*MsgCastElectionVoteResponse, error)
+	CastRankedElectionVote(context.Context, *MsgCastRankedElectionVote) (*MsgCastRankedElectionVoteResponse, error)
 	AddMember(context.Context, *MsgAddMember) (*MsgAddMemberResponse, error)
 	OnboardToDomain(context.Context, *MsgOnboardToDomain) (*MsgOnboardToDomainResponse, error)
 	ApproveOnboarding(context.Context, *MsgApproveOnboarding) (*MsgApproveOnboardingResponse, error)
 	RejectOnboarding(context.Context, *MsgRejectOnboarding) (*MsgRejectOnboardingResponse, error)
 	RegisterIdentity(context.Context, *MsgRegisterIdentity) (*MsgRegisterIdentityResponse, error)
 	RateWithProof(context.Context, *MsgRateWithProof) (*MsgRateWithProofResponse, error)
+	CastElectionVoteWithProof(context.Context, *MsgCastElectionVoteWithProof) (*MsgCastElectionVoteWithProofResponse, error)
+	PlaceStoneWithProof(context.Context, *MsgPlaceStoneWithProof) (*MsgPlaceStoneWithProofResponse, error)
+	RateOpenly(context.Context, *MsgRateOpenly) (*MsgRateOpenlyResponse, error)
+	DelegateVote(context.Context, *MsgDelegateVote) (*MsgDelegateVoteResponse, error)
+	CreateSubDomain(context.Context, *MsgCreateSubDomain) (*MsgCreateSubDomainResponse, error)
+	VoteSubDomainBudget(context.Context, *MsgVoteSubDomainBudget) (*MsgVoteSubDomainBudgetResponse, error)
+	DelegateIssueToSubDomain(context.Context, *MsgDelegateIssueToSubDomain) (*MsgDelegateIssueToSubDomainResponse, error)
+	ProposeDomainOptionsChange(context.Context, *MsgProposeDomainOptionsChange) (*MsgProposeDomainOptionsChangeResponse, error)
+	RotateIdentityCommitment(context.Context, *MsgRotateIdentityCommitment) (*MsgRotateIdentityCommitmentResponse, error)
 	DepositToDomain(context.Context, *MsgDepositToDomain) (*MsgDepositToDomainResponse, error)
 	WithdrawFromDomain(context.Context, *MsgWithdrawFromDomain) (*MsgWithdrawFromDomainResponse, error)
 	VoteSoftwareUpgrade(context.Context, *MsgVoteSoftwareUpgrade) (*MsgVoteSoftwareUpgradeResponse, error)
 	VoteCancelSoftwareUpgrade(context.Context, *MsgVoteCancelSoftwareUpgrade) (*MsgVoteCancelSoftwareUpgradeResponse, error)
+	VoteVerifyingKeyRotation(context.Context, *MsgVoteVerifyingKeyRotation) (*MsgVoteVerifyingKeyRotationResponse, error)
+	SubmitProposalWithProof(context.Context, *MsgSubmitProposalWithProof) (*MsgSubmitProposalWithProofResponse, error)
+	SignalWithProof(context.Context, *MsgSignalWithProof) (*MsgSignalWithProofResponse, error)
 }
 
 var _ MsgServer = msgServer{}
@@ -310,6 +499,13 @@ func (m msgServer) CreateDomain(goCtx context.Context, msg *MsgCreateDomain) (*M
 func (m msgServer) SubmitProposal(goCtx context.Context, msg *MsgSubmitProposal) (*MsgSubmitProposalResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
+	rule := msg.ClosingRule()
+	if !rule.IsZero() || msg.Seats != 0 {
+		if _, exists := m.Keeper.GetIssue(ctx, msg.DomainName, msg.IssueName); exists {
+			return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "a closing rule or seat count can only be set by the proposal that opens the issue")
+		}
+	}
+
 	err := m.Keeper.SubmitProposalWithEscrow(
 		ctx,
 		msg.Sender,
@@ -323,6 +519,17 @@ func (m msgServer) SubmitProposal(goCtx context.Context, msg *MsgSubmitProposal)
 	if err != nil {
 		return nil, err
 	}
+	if err := m.Keeper.SetIssueClosingRule(ctx, msg.DomainName, msg.IssueName, rule); err != nil {
+		return nil, err
+	}
+	if err := m.Keeper.SetElectionSeats(ctx, msg.DomainName, msg.IssueName, msg.Seats); err != nil {
+		return nil, err
+	}
+	if payout := msg.Payout(); payout != nil {
+		if err := m.Keeper.SetSuggestionPayout(ctx, msg.DomainName, msg.IssueName, msg.SuggestionName, *payout); err != nil {
+			return nil, err
+		}
+	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
 		"submit_proposal",
@@ -346,6 +553,9 @@ func (m msgServer) RegisterValidator(goCtx context.Context, msg *MsgRegisterVali
 	if err != nil {
 		return nil, err
 	}
+	if err := m.Keeper.setValidatorCommission(ctx, msg.OperatorAddr, msg.CommissionBps); err != nil {
+		return nil, err
+	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
 		"register_validator",
@@ -373,6 +583,129 @@ func (m msgServer) WithdrawStake(goCtx context.Context, msg *MsgWithdrawStake) (
 	return &MsgWithdrawStakeResponse{}, nil
 }
 
+func (m msgServer) DelegateStake(goCtx context.Context, msg *MsgDelegateStake) (*MsgDelegateStakeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	err := m.Keeper.DelegateStakeWithEscrow(ctx, msg.Sender, msg.ValidatorAddr, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"delegate_stake",
+		sdk.NewAttribute("validator", msg.ValidatorAddr),
+		sdk.NewAttribute("delegator", msg.Sender.String()),
+		sdk.NewAttribute("amount", msg.Amount.String()),
+	))
+
+	return &MsgDelegateStakeResponse{}, nil
+}
+
+func (m msgServer) UndelegateStake(goCtx context.Context, msg *MsgUndelegateStake) (*MsgUndelegateStakeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	err := m.Keeper.UndelegateStake(ctx, msg.Sender, msg.ValidatorAddr, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"undelegate_stake",
+		sdk.NewAttribute("validator", msg.ValidatorAddr),
+		sdk.NewAttribute("delegator", msg.Sender.String()),
+		sdk.NewAttribute("amount", fmt.Sprintf("%d", msg.Amount)),
+	))
+
+	return &MsgUndelegateStakeResponse{}, nil
+}
+
+func (m msgServer) EditValidator(goCtx context.Context, msg *MsgEditValidator) (*MsgEditValidatorResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	edit := ValidatorDescription{
+		Moniker:         msg.Moniker,
+		Website:         msg.Website,
+		SecurityContact: msg.SecurityContact,
+		Identity:        msg.Identity,
+	}
+	if err := m.Keeper.EditValidator(ctx, msg.Sender, edit, msg.CommissionBps, msg.UpdateCommission); err != nil {
+		return nil, err
+	}
+
+	val, _ := m.Keeper.GetValidator(ctx, msg.Sender.String())
+	description, _ := m.Keeper.GetValidatorDescription(ctx, msg.Sender.String())
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"edit_validator",
+		sdk.NewAttribute("validator", msg.Sender.String()),
+		sdk.NewAttribute("moniker", description.Moniker),
+		sdk.NewAttribute("commission_bps", fmt.Sprintf("%d", val.CommissionBps)),
+	))
+
+	return &MsgEditValidatorResponse{}, nil
+}
+
+func (m msgServer) ClaimStakingRewards(goCtx context.Context, msg *MsgClaimStakingRewards) (*MsgClaimStakingRewardsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	amount, recipient, err := m.Keeper.ClaimStakingRewards(ctx, msg.Sender, msg.ValidatorAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"claim_staking_rewards",
+		sdk.NewAttribute("claimant", msg.Sender.String()),
+		sdk.NewAttribute("recipient", recipient.String()),
+		sdk.NewAttribute("amount", amount.String()),
+	))
+
+	return &MsgClaimStakingRewardsResponse{}, nil
+}
+
+func (m msgServer) SetStakingRewardAddress(goCtx context.Context, msg *MsgSetStakingRewardAddress) (*MsgSetStakingRewardAddressResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := m.Keeper.SetStakingRewardAddress(ctx, msg.Sender, msg.WithdrawAddr); err != nil {
+		return nil, err
+	}
+
+	withdrawAddr := msg.WithdrawAddr
+	if withdrawAddr == "" {
+		withdrawAddr = msg.Sender.String()
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"set_staking_reward_address",
+		sdk.NewAttribute("owner", msg.Sender.String()),
+		sdk.NewAttribute("withdraw_addr", withdrawAddr),
+	))
+
+	return &MsgSetStakingRewardAddressResponse{}, nil
+}
+
+func (m msgServer) VoteValidatorSetParams(goCtx context.Context, msg *MsgVoteValidatorSetParams) (*MsgVoteValidatorSetParamsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	votes, members, applied, err := m.Keeper.VoteValidatorSetParams(ctx, msg.Sender, msg.params())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"vote_validator_set_params",
+		sdk.NewAttribute("voter", msg.Sender.String()),
+		sdk.NewAttribute("max_validators", fmt.Sprintf("%d", msg.MaxValidators)),
+		sdk.NewAttribute("epoch_length", fmt.Sprintf("%d", msg.EpochLength)),
+		sdk.NewAttribute("votes", fmt.Sprintf("%d", votes)),
+		sdk.NewAttribute("members", fmt.Sprintf("%d", members)),
+		sdk.NewAttribute("applied", fmt.Sprintf("%t", applied)),
+	))
+
+	return &MsgVoteValidatorSetParamsResponse{}, nil
+}
+
 func (m msgServer) RemoveValidator(goCtx context.Context, msg *MsgRemoveValidator) (*MsgRemoveValidatorResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
@@ -638,6 +971,27 @@ func (m msgServer) CastElectionVote(goCtx context.Context, msg *MsgCastElectionV
 	return &MsgCastElectionVoteResponse{}, nil
 }
 
+func (m msgServer) CastRankedElectionVote(goCtx context.Context, msg *MsgCastRankedElectionVote) (*MsgCastRankedElectionVoteResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := requireSignerClaim(msg.Sender, msg.VoterAddr, "voter address"); err != nil {
+		return nil, err
+	}
+	if err := m.Keeper.CastRankedElectionVote(ctx, msg.DomainName, msg.IssueName, msg.VoterAddr, msg.Ranking); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"cast_ranked_election_vote",
+		sdk.NewAttribute("domain", msg.DomainName),
+		sdk.NewAttribute("issue", msg.IssueName),
+		sdk.NewAttribute("voter", msg.VoterAddr),
+		sdk.NewAttribute("ranked", fmt.Sprintf("%d", len(msg.Ranking))),
+	))
+
+	return &MsgCastRankedElectionVoteResponse{}, nil
+}
+
 func (m msgServer) AddMember(goCtx context.Context, msg *MsgAddMember) (*MsgAddMemberResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
@@ -786,135 +1140,415 @@ func (m msgServer) RateWithProof(goCtx context.Context, msg *MsgRateWithProof) (
 	return &MsgRateWithProofResponse{}, nil
 }
 
-func (m msgServer) DepositToDomain(goCtx context.Context, msg *MsgDepositToDomain) (*MsgDepositToDomainResponse, error) {
+func (m msgServer) CastElectionVoteWithProof(goCtx context.Context, msg *MsgCastElectionVoteWithProof) (*MsgCastElectionVoteWithProofResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	err := m.Keeper.DepositToDomain(ctx, msg.Sender, msg.DomainName, msg.Amount)
-	if err != nil {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if err := m.Keeper.CastElectionVoteWithProof(ctx, msg.DomainName, msg.IssueName, msg.Ranking, msg.Proof, msg.NullifierHash, msg.MerkleRoot); err != nil {
 		return nil, err
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
-		"deposit_to_domain",
+		"cast_election_vote_with_proof",
 		sdk.NewAttribute("domain", msg.DomainName),
-		sdk.NewAttribute("depositor", msg.Sender.String()),
-		sdk.NewAttribute("amount", msg.Amount.String()),
+		sdk.NewAttribute("issue", msg.IssueName),
+		sdk.NewAttribute("nullifier", msg.NullifierHash),
+		sdk.NewAttribute("choice", fmt.Sprintf("%d", msg.Choice)),
 	))
 
-	return &MsgDepositToDomainResponse{}, nil
+	return &MsgCastElectionVoteWithProofResponse{}, nil
 }
 
-func (m msgServer) WithdrawFromDomain(goCtx context.Context, msg *MsgWithdrawFromDomain) (*MsgWithdrawFromDomainResponse, error) {
+func (m msgServer) PlaceStoneWithProof(goCtx context.Context, msg *MsgPlaceStoneWithProof) (*MsgPlaceStoneWithProofResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	recipientAddr, err := sdk.AccAddressFromBech32(msg.Recipient)
-	if err != nil {
-		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidAddress, "invalid recipient address")
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
 	}
-
-	err = m.Keeper.WithdrawFromDomain(ctx, msg.DomainName, recipientAddr, msg.Amount, msg.Sender)
-	if err != nil {
+	if err := m.Keeper.PlaceStoneWithProof(ctx, msg.DomainName, msg.List, msg.IssueName, msg.Target, msg.Proof, msg.NullifierHash, msg.MerkleRoot); err != nil {
 		return nil, err
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
-		"withdraw_from_domain",
+		"place_stone_with_proof",
 		sdk.NewAttribute("domain", msg.DomainName),
-		sdk.NewAttribute("recipient", msg.Recipient),
-		sdk.NewAttribute("amount", msg.Amount.String()),
-		sdk.NewAttribute("authorizer", msg.Sender.String()),
+		sdk.NewAttribute("list", msg.List),
+		sdk.NewAttribute("issue", msg.IssueName),
+		sdk.NewAttribute("target", msg.Target),
+		sdk.NewAttribute("nullifier", msg.NullifierHash),
 	))
 
-	return &MsgWithdrawFromDomainResponse{}, nil
+	return &MsgPlaceStoneWithProofResponse{}, nil
 }
 
-func (m msgServer) VoteSoftwareUpgrade(goCtx context.Context, msg *MsgVoteSoftwareUpgrade) (*MsgVoteSoftwareUpgradeResponse, error) {
+func (m msgServer) RateOpenly(goCtx context.Context, msg *MsgRateOpenly) (*MsgRateOpenlyResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
 	if err := msg.ValidateBasic(); err != nil {
 		return nil, err
 	}
-	votes, eligible, scheduled, err := m.Keeper.VoteSoftwareUpgrade(ctx, msg.Sender, msg.PlanName, msg.Height, msg.Info)
+	reward, err := m.Keeper.RateProposalOpenlyWithPayout(
+		ctx,
+		msg.Sender,
+		msg.DomainName,
+		msg.IssueName,
+		msg.SuggestionName,
+		int(msg.Rating),
+		msg.MemberAddr,
+	)
 	if err != nil {
 		return nil, err
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
-		"vote_software_upgrade",
-		sdk.NewAttribute("action", "schedule"),
-		sdk.NewAttribute("plan", msg.PlanName),
-		sdk.NewAttribute("height", fmt.Sprintf("%d", msg.Height)),
-		sdk.NewAttribute("voter", msg.Sender.String()),
-		sdk.NewAttribute("votes", fmt.Sprintf("%d", votes)),
-		sdk.NewAttribute("eligible", fmt.Sprintf("%d", eligible)),
-		sdk.NewAttribute("scheduled", fmt.Sprintf("%t", scheduled)),
+		"rate_openly",
+		sdk.NewAttribute("domain", msg.DomainName),
+		sdk.NewAttribute("issue", msg.IssueName),
+		sdk.NewAttribute("suggestion", msg.SuggestionName),
+		sdk.NewAttribute("member", msg.MemberAddr),
+		sdk.NewAttribute("rating", fmt.Sprintf("%d", msg.Rating)),
+		sdk.NewAttribute("reward", reward.String()),
 	))
 
-	return &MsgVoteSoftwareUpgradeResponse{}, nil
+	return &MsgRateOpenlyResponse{}, nil
 }
 
-func (m msgServer) VoteCancelSoftwareUpgrade(goCtx context.Context, msg *MsgVoteCancelSoftwareUpgrade) (*MsgVoteCancelSoftwareUpgradeResponse, error) {
+func (m msgServer) DelegateVote(goCtx context.Context, msg *MsgDelegateVote) (*MsgDelegateVoteResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
 	if err := msg.ValidateBasic(); err != nil {
 		return nil, err
 	}
-	votes, eligible, cancelled, err := m.Keeper.VoteCancelSoftwareUpgrade(ctx, msg.Sender, msg.PlanName)
-	if err != nil {
+	if err := m.Keeper.DelegateVote(ctx, msg.DomainName, msg.IssueName, msg.Delegator, msg.Delegate); err != nil {
 		return nil, err
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
-		"vote_cancel_software_upgrade",
-		sdk.NewAttribute("action", "cancel"),
-		sdk.NewAttribute("plan", msg.PlanName),
-		sdk.NewAttribute("voter", msg.Sender.String()),
-		sdk.NewAttribute("votes", fmt.Sprintf("%d", votes)),
-		sdk.NewAttribute("eligible", fmt.Sprintf("%d", eligible)),
-		sdk.NewAttribute("cancelled", fmt.Sprintf("%t", cancelled)),
+		"delegate_vote",
+		sdk.NewAttribute("domain", msg.DomainName),
+		sdk.NewAttribute("issue", msg.IssueName),
+		sdk.NewAttribute("delegator", msg.Delegator),
+		sdk.NewAttribute("delegate", msg.Delegate),
 	))
 
-	return &MsgVoteCancelSoftwareUpgradeResponse{}, nil
+	return &MsgDelegateVoteResponse{}, nil
 }
 
-// ---------------------------------------------------------------------------
-// gRPC method handlers
-// ---------------------------------------------------------------------------
+func (m msgServer) CreateSubDomain(goCtx context.Context, msg *MsgCreateSubDomain) (*MsgCreateSubDomainResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
 
-func _Msg_CreateDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MsgCreateDomain)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(MsgServer).CreateDomain(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/truedemocracy.Msg/CreateDomain",
+	admin := msg.Sender
+	if msg.Admin != "" {
+		var err error
+		if admin, err = sdk.AccAddressFromBech32(msg.Admin); err != nil {
+			return nil, errorsmod.Wrap(sdkerrors.ErrInvalidAddress, "invalid admin address")
+		}
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MsgServer).CreateDomain(ctx, req.(*MsgCreateDomain))
+	if err := m.Keeper.CreateSubDomain(ctx, msg.ParentDomain, msg.Name, admin, msg.Sender); err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"create_sub_domain",
+		sdk.NewAttribute("parent", msg.ParentDomain),
+		sdk.NewAttribute("domain", msg.Name),
+		sdk.NewAttribute("admin", admin.String()),
+	))
+
+	return &MsgCreateSubDomainResponse{}, nil
 }
 
-func _Msg_SubmitProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MsgSubmitProposal)
-	if err := dec(in); err != nil {
+func (m msgServer) VoteSubDomainBudget(goCtx context.Context, msg *MsgVoteSubDomainBudget) (*MsgVoteSubDomainBudgetResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := requireSignerClaim(msg.Sender, msg.VoterAddr, "voter address"); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(MsgServer).SubmitProposal(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/truedemocracy.Msg/SubmitProposal",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MsgServer).SubmitProposal(ctx, req.(*MsgSubmitProposal))
+	transferred, err := m.Keeper.VoteSubDomainBudget(ctx, msg.ParentDomain, msg.SubDomain, msg.Amount, msg.VoterAddr)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"vote_sub_domain_budget",
+		sdk.NewAttribute("parent", msg.ParentDomain),
+		sdk.NewAttribute("sub_domain", msg.SubDomain),
+		sdk.NewAttribute("amount", msg.Amount.String()),
+		sdk.NewAttribute("transferred", fmt.Sprintf("%t", transferred)),
+	))
+
+	return &MsgVoteSubDomainBudgetResponse{}, nil
+}
+
+func (m msgServer) DelegateIssueToSubDomain(goCtx context.Context, msg *MsgDelegateIssueToSubDomain) (*MsgDelegateIssueToSubDomainResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := m.Keeper.DelegateIssueToSubDomain(ctx, msg.DomainName, msg.IssueName, msg.SubDomain, msg.Sender); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"delegate_issue_to_sub_domain",
+		sdk.NewAttribute("domain", msg.DomainName),
+		sdk.NewAttribute("issue", msg.IssueName),
+		sdk.NewAttribute("sub_domain", msg.SubDomain),
+	))
+
+	return &MsgDelegateIssueToSubDomainResponse{}, nil
+}
+
+func (m msgServer) ProposeDomainOptionsChange(goCtx context.Context, msg *MsgProposeDomainOptionsChange) (*MsgProposeDomainOptionsChangeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	votes, members, applied, err := m.Keeper.ProposeDomainOptionsChange(ctx, msg.DomainName, msg.Options(), msg.VoterAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"propose_domain_options_change",
+		sdk.NewAttribute("domain", msg.DomainName),
+		sdk.NewAttribute("voter", msg.VoterAddr),
+		sdk.NewAttribute("votes", fmt.Sprintf("%d", votes)),
+		sdk.NewAttribute("members", fmt.Sprintf("%d", members)),
+		sdk.NewAttribute("applied", fmt.Sprintf("%t", applied)),
+	))
+
+	return &MsgProposeDomainOptionsChangeResponse{}, nil
+}
+
+func (m msgServer) RotateIdentityCommitment(goCtx context.Context, msg *MsgRotateIdentityCommitment) (*MsgRotateIdentityCommitmentResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	err := m.Keeper.RotateIdentityCommitment(ctx, msg.DomainName, msg.Sender.String(), msg.OldCommitment, msg.NewCommitment)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"rotate_identity",
+		sdk.NewAttribute("domain", msg.DomainName),
+		sdk.NewAttribute("member", msg.Sender.String()),
+	))
+
+	return &MsgRotateIdentityCommitmentResponse{}, nil
+}
+
+func (m msgServer) DepositToDomain(goCtx context.Context, msg *MsgDepositToDomain) (*MsgDepositToDomainResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	err := m.Keeper.DepositToDomain(ctx, msg.Sender, msg.DomainName, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"deposit_to_domain",
+		sdk.NewAttribute("domain", msg.DomainName),
+		sdk.NewAttribute("depositor", msg.Sender.String()),
+		sdk.NewAttribute("amount", msg.Amount.String()),
+	))
+
+	return &MsgDepositToDomainResponse{}, nil
+}
+
+func (m msgServer) WithdrawFromDomain(goCtx context.Context, msg *MsgWithdrawFromDomain) (*MsgWithdrawFromDomainResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	recipientAddr, err := sdk.AccAddressFromBech32(msg.Recipient)
+	if err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidAddress, "invalid recipient address")
+	}
+
+	err = m.Keeper.WithdrawFromDomain(ctx, msg.DomainName, recipientAddr, msg.Amount, msg.Sender)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"withdraw_from_domain",
+		sdk.NewAttribute("domain", msg.DomainName),
+		sdk.NewAttribute("recipient", msg.Recipient),
+		sdk.NewAttribute("amount", msg.Amount.String()),
+		sdk.NewAttribute("authorizer", msg.Sender.String()),
+	))
+
+	return &MsgWithdrawFromDomainResponse{}, nil
+}
+
+func (m msgServer) VoteSoftwareUpgrade(goCtx context.Context, msg *MsgVoteSoftwareUpgrade) (*MsgVoteSoftwareUpgradeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	votes, eligible, scheduled, err := m.Keeper.VoteSoftwareUpgrade(ctx, msg.Sender, msg.PlanName, msg.Height, msg.Info)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"vote_software_upgrade",
+		sdk.NewAttribute("action", "schedule"),
+		sdk.NewAttribute("plan", msg.PlanName),
+		sdk.NewAttribute("height", fmt.Sprintf("%d", msg.Height)),
+		sdk.NewAttribute("voter", msg.Sender.String()),
+		sdk.NewAttribute("votes", fmt.Sprintf("%d", votes)),
+		sdk.NewAttribute("eligible", fmt.Sprintf("%d", eligible)),
+		sdk.NewAttribute("scheduled", fmt.Sprintf("%t", scheduled)),
+	))
+
+	return &MsgVoteSoftwareUpgradeResponse{}, nil
+}
+
+func (m msgServer) VoteCancelSoftwareUpgrade(goCtx context.Context, msg *MsgVoteCancelSoftwareUpgrade) (*MsgVoteCancelSoftwareUpgradeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	votes, eligible, cancelled, err := m.Keeper.VoteCancelSoftwareUpgrade(ctx, msg.Sender, msg.PlanName)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"vote_cancel_software_upgrade",
+		sdk.NewAttribute("action", "cancel"),
+		sdk.NewAttribute("plan", msg.PlanName),
+		sdk.NewAttribute("voter", msg.Sender.String()),
+		sdk.NewAttribute("votes", fmt.Sprintf("%d", votes)),
+		sdk.NewAttribute("eligible", fmt.Sprintf("%d", eligible)),
+		sdk.NewAttribute("cancelled", fmt.Sprintf("%t", cancelled)),
+	))
+
+	return &MsgVoteCancelSoftwareUpgradeResponse{}, nil
+}
+
+func (m msgServer) VoteVerifyingKeyRotation(goCtx context.Context, msg *MsgVoteVerifyingKeyRotation) (*MsgVoteVerifyingKeyRotationResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	votes, eligible, rotated, err := m.Keeper.VoteVerifyingKeyRotation(ctx, msg.Sender, msg.CircuitID, msg.VerifyingKeyHex, msg.VerifyingKeySHA256, msg.TransitionBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"vote_verifying_key_rotation",
+		sdk.NewAttribute("circuit_id", msg.CircuitID),
+		sdk.NewAttribute("verifying_key_sha256", msg.VerifyingKeySHA256),
+		sdk.NewAttribute("transition_blocks", fmt.Sprintf("%d", msg.TransitionBlocks)),
+		sdk.NewAttribute("voter", msg.Sender.String()),
+		sdk.NewAttribute("votes", fmt.Sprintf("%d", votes)),
+		sdk.NewAttribute("eligible", fmt.Sprintf("%d", eligible)),
+		sdk.NewAttribute("rotated", fmt.Sprintf("%t", rotated)),
+	))
+
+	return &MsgVoteVerifyingKeyRotationResponse{}, nil
+}
+
+func (m msgServer) SubmitProposalWithProof(goCtx context.Context, msg *MsgSubmitProposalWithProof) (*MsgSubmitProposalWithProofResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	creator, err := m.Keeper.SubmitProposalWithProof(
+		ctx,
+		msg.Sender,
+		msg.DomainName,
+		msg.IssueName,
+		msg.SuggestionName,
+		msg.Fee,
+		msg.ExternalLink,
+		msg.Proof,
+		msg.NullifierHash,
+		msg.MerkleRoot,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"submit_proposal_with_proof",
+		sdk.NewAttribute("domain", msg.DomainName),
+		sdk.NewAttribute("issue", msg.IssueName),
+		sdk.NewAttribute("suggestion", msg.SuggestionName),
+		sdk.NewAttribute("creator", creator),
+	))
+
+	return &MsgSubmitProposalWithProofResponse{}, nil
+}
+
+func (m msgServer) SignalWithProof(goCtx context.Context, msg *MsgSignalWithProof) (*MsgSignalWithProofResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if err := m.Keeper.SignalWithProof(ctx, msg.DomainName, msg.Topic, msg.Signal, msg.Proof, msg.NullifierHash, msg.MerkleRoot); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"signal_with_proof",
+		sdk.NewAttribute("domain", msg.DomainName),
+		sdk.NewAttribute("topic", msg.Topic),
+		sdk.NewAttribute("nullifier", msg.NullifierHash),
+	))
+
+	return &MsgSignalWithProofResponse{}, nil
+}
+
+// ---------------------------------------------------------------------------
+// gRPC method handlers
+// ---------------------------------------------------------------------------
+
+func _Msg_CreateDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCreateDomain)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CreateDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/CreateDomain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CreateDomain(ctx, req.(*MsgCreateDomain))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_SubmitProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSubmitProposal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SubmitProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/SubmitProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SubmitProposal(ctx, req.(*MsgSubmitProposal))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
 func _Msg_RegisterValidator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
@@ -923,32 +1557,140 @@ func _Msg_RegisterValidator_Handler(srv interface{}, ctx context.Context, dec fu
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MsgServer).RegisterValidator(ctx, in)
+		return srv.(MsgServer).RegisterValidator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/RegisterValidator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RegisterValidator(ctx, req.(*MsgRegisterValidator))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_WithdrawStake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgWithdrawStake)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).WithdrawStake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/WithdrawStake",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).WithdrawStake(ctx, req.(*MsgWithdrawStake))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_DelegateStake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgDelegateStake)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).DelegateStake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/DelegateStake",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).DelegateStake(ctx, req.(*MsgDelegateStake))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_UndelegateStake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgUndelegateStake)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).UndelegateStake(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/truedemocracy.Msg/RegisterValidator",
+		FullMethod: "/truedemocracy.Msg/UndelegateStake",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MsgServer).RegisterValidator(ctx, req.(*MsgRegisterValidator))
+		return srv.(MsgServer).UndelegateStake(ctx, req.(*MsgUndelegateStake))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Msg_WithdrawStake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MsgWithdrawStake)
+func _Msg_EditValidator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgEditValidator)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MsgServer).WithdrawStake(ctx, in)
+		return srv.(MsgServer).EditValidator(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/truedemocracy.Msg/WithdrawStake",
+		FullMethod: "/truedemocracy.Msg/EditValidator",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MsgServer).WithdrawStake(ctx, req.(*MsgWithdrawStake))
+		return srv.(MsgServer).EditValidator(ctx, req.(*MsgEditValidator))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_VoteValidatorSetParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgVoteValidatorSetParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).VoteValidatorSetParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/VoteValidatorSetParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).VoteValidatorSetParams(ctx, req.(*MsgVoteValidatorSetParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_ClaimStakingRewards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgClaimStakingRewards)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).ClaimStakingRewards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/ClaimStakingRewards",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).ClaimStakingRewards(ctx, req.(*MsgClaimStakingRewards))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_SetStakingRewardAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSetStakingRewardAddress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SetStakingRewardAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/SetStakingRewardAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SetStakingRewardAddress(ctx, req.(*MsgSetStakingRewardAddress))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1169,6 +1911,24 @@ func _Msg_CastElectionVote_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Msg_CastRankedElectionVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCastRankedElectionVote)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CastRankedElectionVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/CastRankedElectionVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CastRankedElectionVote(ctx, req.(*MsgCastRankedElectionVote))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Msg_AddMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MsgAddMember)
 	if err := dec(in); err != nil {
@@ -1287,6 +2047,168 @@ func _Msg_RateWithProof_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Msg_CastElectionVoteWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCastElectionVoteWithProof)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CastElectionVoteWithProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/CastElectionVoteWithProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CastElectionVoteWithProof(ctx, req.(*MsgCastElectionVoteWithProof))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_PlaceStoneWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgPlaceStoneWithProof)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).PlaceStoneWithProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/PlaceStoneWithProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).PlaceStoneWithProof(ctx, req.(*MsgPlaceStoneWithProof))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RateOpenly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRateOpenly)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RateOpenly(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/RateOpenly",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RateOpenly(ctx, req.(*MsgRateOpenly))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_DelegateVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgDelegateVote)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).DelegateVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/DelegateVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).DelegateVote(ctx, req.(*MsgDelegateVote))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_CreateSubDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgCreateSubDomain)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).CreateSubDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/CreateSubDomain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).CreateSubDomain(ctx, req.(*MsgCreateSubDomain))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_VoteSubDomainBudget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgVoteSubDomainBudget)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).VoteSubDomainBudget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/VoteSubDomainBudget",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).VoteSubDomainBudget(ctx, req.(*MsgVoteSubDomainBudget))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_DelegateIssueToSubDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgDelegateIssueToSubDomain)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).DelegateIssueToSubDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/DelegateIssueToSubDomain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).DelegateIssueToSubDomain(ctx, req.(*MsgDelegateIssueToSubDomain))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_ProposeDomainOptionsChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgProposeDomainOptionsChange)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).ProposeDomainOptionsChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/ProposeDomainOptionsChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).ProposeDomainOptionsChange(ctx, req.(*MsgProposeDomainOptionsChange))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_RotateIdentityCommitment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgRotateIdentityCommitment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).RotateIdentityCommitment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/RotateIdentityCommitment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).RotateIdentityCommitment(ctx, req.(*MsgRotateIdentityCommitment))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Msg_DepositToDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MsgDepositToDomain)
 	if err := dec(in); err != nil {
@@ -1359,6 +2281,60 @@ func _Msg_VoteCancelSoftwareUpgrade_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Msg_VoteVerifyingKeyRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgVoteVerifyingKeyRotation)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).VoteVerifyingKeyRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/VoteVerifyingKeyRotation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).VoteVerifyingKeyRotation(ctx, req.(*MsgVoteVerifyingKeyRotation))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_SubmitProposalWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSubmitProposalWithProof)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SubmitProposalWithProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/SubmitProposalWithProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SubmitProposalWithProof(ctx, req.(*MsgSubmitProposalWithProof))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Msg_SignalWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgSignalWithProof)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).SignalWithProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/truedemocracy.Msg/SignalWithProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).SignalWithProof(ctx, req.(*MsgSignalWithProof))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Msg_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "truedemocracy.Msg",
 	HandlerType: (*MsgServer)(nil),
@@ -1379,6 +2355,30 @@ var _Msg_serviceDesc = grpc.ServiceDesc{
 			MethodName: "WithdrawStake",
 			Handler:    _Msg_WithdrawStake_Handler,
 		},
+		{
+			MethodName: "DelegateStake",
+			Handler:    _Msg_DelegateStake_Handler,
+		},
+		{
+			MethodName: "UndelegateStake",
+			Handler:    _Msg_UndelegateStake_Handler,
+		},
+		{
+			MethodName: "EditValidator",
+			Handler:    _Msg_EditValidator_Handler,
+		},
+		{
+			MethodName: "ClaimStakingRewards",
+			Handler:    _Msg_ClaimStakingRewards_Handler,
+		},
+		{
+			MethodName: "SetStakingRewardAddress",
+			Handler:    _Msg_SetStakingRewardAddress_Handler,
+		},
+		{
+			MethodName: "VoteValidatorSetParams",
+			Handler:    _Msg_VoteValidatorSetParams_Handler,
+		},
 		{
 			MethodName: "RemoveValidator",
 			Handler:    _Msg_RemoveValidator_Handler,
@@ -1427,6 +2427,10 @@ var _Msg_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CastElectionVote",
 			Handler:    _Msg_CastElectionVote_Handler,
 		},
+		{
+			MethodName: "CastRankedElectionVote",
+			Handler:    _Msg_CastRankedElectionVote_Handler,
+		},
 		{
 			MethodName: "AddMember",
 			Handler:    _Msg_AddMember_Handler,
@@ -1451,6 +2455,42 @@ var _Msg_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RateWithProof",
 			Handler:    _Msg_RateWithProof_Handler,
 		},
+		{
+			MethodName: "CastElectionVoteWithProof",
+			Handler:    _Msg_CastElectionVoteWithProof_Handler,
+		},
+		{
+			MethodName: "PlaceStoneWithProof",
+			Handler:    _Msg_PlaceStoneWithProof_Handler,
+		},
+		{
+			MethodName: "RateOpenly",
+			Handler:    _Msg_RateOpenly_Handler,
+		},
+		{
+			MethodName: "DelegateVote",
+			Handler:    _Msg_DelegateVote_Handler,
+		},
+		{
+			MethodName: "CreateSubDomain",
+			Handler:    _Msg_CreateSubDomain_Handler,
+		},
+		{
+			MethodName: "VoteSubDomainBudget",
+			Handler:    _Msg_VoteSubDomainBudget_Handler,
+		},
+		{
+			MethodName: "DelegateIssueToSubDomain",
+			Handler:    _Msg_DelegateIssueToSubDomain_Handler,
+		},
+		{
+			MethodName: "ProposeDomainOptionsChange",
+			Handler:    _Msg_ProposeDomainOptionsChange_Handler,
+		},
+		{
+			MethodName: "RotateIdentityCommitment",
+			Handler:    _Msg_RotateIdentityCommitment_Handler,
+		},
 		{
 			MethodName: "DepositToDomain",
 			Handler:    _Msg_DepositToDomain_Handler,
@@ -1467,6 +2507,18 @@ var _Msg_serviceDesc = grpc.ServiceDesc{
 			MethodName: "VoteCancelSoftwareUpgrade",
 			Handler:    _Msg_VoteCancelSoftwareUpgrade_Handler,
 		},
+		{
+			MethodName: "VoteVerifyingKeyRotation",
+			Handler:    _Msg_VoteVerifyingKeyRotation_Handler,
+		},
+		{
+			MethodName: "SubmitProposalWithProof",
+			Handler:    _Msg_SubmitProposalWithProof_Handler,
+		},
+		{
+			MethodName: "SignalWithProof",
+			Handler:    _Msg_SignalWithProof_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: msgDescriptorFile,
@@ -3,6 +3,7 @@ package truedemocracy
 import (
 	"encoding/hex"
 	"encoding/json"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -48,6 +49,17 @@ type MsgSubmitProposal struct {
 	Creator        string         `protobuf:"bytes,5,opt,name=creator,proto3" json:"creator"`
 	Fee            sdk.Coins      `protobuf:"bytes,6,rep,name=fee,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"fee"`
 	ExternalLink   string         `protobuf:"bytes,7,opt,name=external_link,json=externalLink,proto3" json:"external_link"`
+	// Optional closing rule, only accepted from the proposal that opens the issue.
+	ClosesAt     int64 `protobuf:"varint,8,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"`
+	RatingQuorum int64 `protobuf:"varint,9,opt,name=rating_quorum,json=ratingQuorum,proto3" json:"rating_quorum,omitempty"`
+	StoneQuorum  int64 `protobuf:"varint,10,opt,name=stone_quorum,json=stoneQuorum,proto3" json:"stone_quorum,omitempty"`
+	// Optional seat count of a multi-seat election, also fixed on opening.
+	Seats int64 `protobuf:"varint,11,opt,name=seats,proto3" json:"seats,omitempty"`
+	// Optional treasury payout carried by the suggestion (amount in upnyx).
+	PayoutRecipient string `protobuf:"bytes,12,opt,name=payout_recipient,json=payoutRecipient,proto3" json:"payout_recipient,omitempty"`
+	PayoutAmount    int64  `protobuf:"varint,13,opt,name=payout_amount,json=payoutAmount,proto3" json:"payout_amount,omitempty"`
+	PayoutTranches  int64  `protobuf:"varint,14,opt,name=payout_tranches,json=payoutTranches,proto3" json:"payout_tranches,omitempty"`
+	PayoutInterval  int64  `protobuf:"varint,15,opt,name=payout_interval,json=payoutInterval,proto3" json:"payout_interval,omitempty"`
 }
 
 func (m *MsgSubmitProposal) ProtoMessage()               {}
@@ -66,9 +78,33 @@ func (m MsgSubmitProposal) ValidateBasic() error {
 	if err := requireSignerClaim(m.Sender, m.Creator, "creator"); err != nil {
 		return err
 	}
+	if m.ClosesAt < 0 || m.RatingQuorum < 0 || m.StoneQuorum < 0 {
+		return sdkerrors.ErrInvalidRequest.Wrap("closing rule values cannot be negative")
+	}
+	if m.Seats < 0 || m.Seats > MaxElectionSeats {
+		return sdkerrors.ErrInvalidRequest.Wrapf("seats must be between 0 and %d", MaxElectionSeats)
+	}
+	if payout := m.Payout(); payout != nil {
+		if err := validateTreasuryPayout(*payout); err != nil {
+			return err
+		}
+	}
 	return validatePNYXCoins(m.Fee, "proposal fee")
 }
 
+// ClosingRule returns the issue closing rule carried by the message.
+func (m MsgSubmitProposal) ClosingRule() IssueClosingRule {
+	return IssueClosingRule{ClosesAt: m.ClosesAt, RatingQuorum: m.RatingQuorum, StoneQuorum: m.StoneQuorum}
+}
+
+// Payout returns the treasury payout carried by the message, or nil.
+func (m MsgSubmitProposal) Payout() *TreasuryPayout {
+	if m.PayoutRecipient == "" && m.PayoutAmount == 0 && m.PayoutTranches == 0 && m.PayoutInterval == 0 {
+		return nil
+	}
+	return &TreasuryPayout{Recipient: m.PayoutRecipient, Amount: m.PayoutAmount, Tranches: m.PayoutTranches, Interval: m.PayoutInterval}
+}
+
 // --- MsgRegisterValidator ---
 
 type MsgRegisterValidator struct {
@@ -77,6 +113,8 @@ type MsgRegisterValidator struct {
 	PubKey       string         `protobuf:"bytes,3,opt,name=pub_key,json=pubKey,proto3" json:"pub_key"` // hex-encoded 32 bytes
 	Stake        sdk.Coins      `protobuf:"bytes,4,rep,name=stake,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"stake"`
 	DomainName   string         `protobuf:"bytes,5,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	// CommissionBps is the share of delegator rewards the operator keeps.
+	CommissionBps int64 `protobuf:"varint,6,opt,name=commission_bps,json=commissionBps,proto3" json:"commission_bps,omitempty"`
 }
 
 func (m *MsgRegisterValidator) ProtoMessage()               {}
@@ -95,6 +133,9 @@ func (m MsgRegisterValidator) ValidateBasic() error {
 	if err := requireSignerClaim(m.Sender, m.OperatorAddr, "operator address"); err != nil {
 		return err
 	}
+	if err := validateCommissionBps(m.CommissionBps); err != nil {
+		return err
+	}
 	return validatePNYXCoins(m.Stake, "validator stake")
 }
 
@@ -125,6 +166,189 @@ func (m MsgWithdrawStake) ValidateBasic() error {
 	return requireSignerClaim(m.Sender, m.OperatorAddr, "operator address")
 }
 
+// --- MsgDelegateStake ---
+
+type MsgDelegateStake struct {
+	Sender        sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	ValidatorAddr string         `protobuf:"bytes,2,opt,name=validator_addr,json=validatorAddr,proto3" json:"validator_addr"`
+	Amount        sdk.Coins      `protobuf:"bytes,3,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount"`
+}
+
+func (m *MsgDelegateStake) ProtoMessage()               {}
+func (m *MsgDelegateStake) Reset()                      { *m = MsgDelegateStake{} }
+func (m *MsgDelegateStake) String() string              { b, _ := json.Marshal(m); return string(b) }
+func (m MsgDelegateStake) Route() string                { return ModuleName }
+func (m MsgDelegateStake) Type() string                 { return "delegate_stake" }
+func (m MsgDelegateStake) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.Sender} }
+func (m MsgDelegateStake) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if m.ValidatorAddr == "" {
+		return sdkerrors.ErrInvalidRequest.Wrap("validator_addr is required")
+	}
+	if m.ValidatorAddr == m.Sender.String() {
+		return sdkerrors.ErrInvalidRequest.Wrap("operators bond through their own validator stake")
+	}
+	return validatePNYXCoins(m.Amount, "delegation amount")
+}
+
+// --- MsgUndelegateStake ---
+
+type MsgUndelegateStake struct {
+	Sender        sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	ValidatorAddr string         `protobuf:"bytes,2,opt,name=validator_addr,json=validatorAddr,proto3" json:"validator_addr"`
+	Amount        int64          `protobuf:"varint,3,opt,name=amount,proto3" json:"amount"`
+}
+
+func (m *MsgUndelegateStake) ProtoMessage()               {}
+func (m *MsgUndelegateStake) Reset()                      { *m = MsgUndelegateStake{} }
+func (m *MsgUndelegateStake) String() string              { b, _ := json.Marshal(m); return string(b) }
+func (m MsgUndelegateStake) Route() string                { return ModuleName }
+func (m MsgUndelegateStake) Type() string                 { return "undelegate_stake" }
+func (m MsgUndelegateStake) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.Sender} }
+func (m MsgUndelegateStake) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if m.ValidatorAddr == "" {
+		return sdkerrors.ErrInvalidRequest.Wrap("validator_addr is required")
+	}
+	if m.Amount <= 0 {
+		return sdkerrors.ErrInvalidRequest.Wrap("amount must be positive")
+	}
+	return nil
+}
+
+// --- MsgEditValidator ---
+
+// MsgEditValidator updates the sender's validator metadata. A string field
+// set to DoNotModifyDescription keeps its value; CommissionBps applies only
+// when UpdateCommission is set.
+type MsgEditValidator struct {
+	Sender           sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	Moniker          string         `protobuf:"bytes,2,opt,name=moniker,proto3" json:"moniker"`
+	Website          string         `protobuf:"bytes,3,opt,name=website,proto3" json:"website"`
+	SecurityContact  string         `protobuf:"bytes,4,opt,name=security_contact,json=securityContact,proto3" json:"security_contact"`
+	Identity         string         `protobuf:"bytes,5,opt,name=identity,proto3" json:"identity"`
+	CommissionBps    int64          `protobuf:"varint,6,opt,name=commission_bps,json=commissionBps,proto3" json:"commission_bps,omitempty"`
+	UpdateCommission bool           `protobuf:"varint,7,opt,name=update_commission,json=updateCommission,proto3" json:"update_commission,omitempty"`
+}
+
+func (m *MsgEditValidator) ProtoMessage()               {}
+func (m *MsgEditValidator) Reset()                      { *m = MsgEditValidator{} }
+func (m *MsgEditValidator) String() string              { b, _ := json.Marshal(m); return string(b) }
+func (m MsgEditValidator) Route() string                { return ModuleName }
+func (m MsgEditValidator) Type() string                 { return "edit_validator" }
+func (m MsgEditValidator) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.Sender} }
+func (m MsgEditValidator) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if err := validateValidatorDescription(m.description()); err != nil {
+		return err
+	}
+	if m.UpdateCommission {
+		return validateCommissionBps(m.CommissionBps)
+	}
+	return nil
+}
+
+// description returns the edited fields, with kept ones left empty so they
+// pass the bounds check.
+func (m MsgEditValidator) description() ValidatorDescription {
+	keep := func(value string) string {
+		if value == DoNotModifyDescription {
+			return ""
+		}
+		return value
+	}
+	return ValidatorDescription{
+		Moniker:         keep(m.Moniker),
+		Website:         keep(m.Website),
+		SecurityContact: keep(m.SecurityContact),
+		Identity:        keep(m.Identity),
+	}
+}
+
+// --- MsgClaimStakingRewards ---
+
+// MsgClaimStakingRewards pays the sender's unclaimed staking rewards to its
+// withdraw address. ValidatorAddr, if set, first settles the sender's
+// delegation to that validator; an operator's own rewards always settle.
+type MsgClaimStakingRewards struct {
+	Sender        sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	ValidatorAddr string         `protobuf:"bytes,2,opt,name=validator_addr,json=validatorAddr,proto3" json:"validator_addr,omitempty"`
+}
+
+func (m *MsgClaimStakingRewards) ProtoMessage()               {}
+func (m *MsgClaimStakingRewards) Reset()                      { *m = MsgClaimStakingRewards{} }
+func (m *MsgClaimStakingRewards) String() string              { b, _ := json.Marshal(m); return string(b) }
+func (m MsgClaimStakingRewards) Route() string                { return ModuleName }
+func (m MsgClaimStakingRewards) Type() string                 { return "claim_staking_rewards" }
+func (m MsgClaimStakingRewards) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.Sender} }
+func (m MsgClaimStakingRewards) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	return nil
+}
+
+// --- MsgSetStakingRewardAddress ---
+
+// MsgSetStakingRewardAddress sets where the sender's staking reward claims
+// are paid. An empty WithdrawAddr restores the sender itself.
+type MsgSetStakingRewardAddress struct {
+	Sender       sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	WithdrawAddr string         `protobuf:"bytes,2,opt,name=withdraw_addr,json=withdrawAddr,proto3" json:"withdraw_addr,omitempty"`
+}
+
+func (m *MsgSetStakingRewardAddress) ProtoMessage()               {}
+func (m *MsgSetStakingRewardAddress) Reset()                      { *m = MsgSetStakingRewardAddress{} }
+func (m *MsgSetStakingRewardAddress) String() string              { b, _ := json.Marshal(m); return string(b) }
+func (m MsgSetStakingRewardAddress) Route() string                { return ModuleName }
+func (m MsgSetStakingRewardAddress) Type() string                 { return "set_staking_reward_address" }
+func (m MsgSetStakingRewardAddress) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.Sender} }
+func (m MsgSetStakingRewardAddress) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if m.WithdrawAddr != "" {
+		if _, err := sdk.AccAddressFromBech32(m.WithdrawAddr); err != nil {
+			return sdkerrors.ErrInvalidAddress.Wrapf("invalid withdraw address: %s", err)
+		}
+	}
+	return nil
+}
+
+// --- MsgVoteValidatorSetParams ---
+
+// MsgVoteValidatorSetParams votes, as a governance domain member, for
+// replacing the validator-set parameters. Two thirds of the members must
+// vote for the identical parameters.
+type MsgVoteValidatorSetParams struct {
+	Sender        sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	MaxValidators int64          `protobuf:"varint,2,opt,name=max_validators,json=maxValidators,proto3" json:"max_validators"`
+	EpochLength   int64          `protobuf:"varint,3,opt,name=epoch_length,json=epochLength,proto3" json:"epoch_length"`
+}
+
+func (m *MsgVoteValidatorSetParams) ProtoMessage()               {}
+func (m *MsgVoteValidatorSetParams) Reset()                      { *m = MsgVoteValidatorSetParams{} }
+func (m *MsgVoteValidatorSetParams) String() string              { b, _ := json.Marshal(m); return string(b) }
+func (m MsgVoteValidatorSetParams) Route() string                { return ModuleName }
+func (m MsgVoteValidatorSetParams) Type() string                 { return "vote_validator_set_params" }
+func (m MsgVoteValidatorSetParams) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.Sender} }
+func (m MsgVoteValidatorSetParams) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	return validateValidatorSetParams(m.params())
+}
+
+func (m MsgVoteValidatorSetParams) params() ValidatorSetParams {
+	return ValidatorSetParams{MaxValidators: m.MaxValidators, EpochLength: m.EpochLength}
+}
+
 // --- MsgRemoveValidator ---
 
 type MsgRemoveValidator struct {
@@ -459,29 +683,38 @@ func (m MsgRateWithProof) ValidateBasic() error {
 	if m.Rating < -5 || m.Rating > 5 {
 		return sdkerrors.ErrInvalidRequest.Wrap("rating must be between -5 and +5")
 	}
-	if m.Proof == "" {
+	if err := validateProofFields(m.Proof, m.NullifierHash, m.MerkleRoot); err != nil {
+		return err
+	}
+	if _, err := ValidateRewardRecipient(m.RewardRecipient); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateProofFields checks the hex encoding of a membership proof, its
+// nullifier, and the optional Merkle root it was generated against.
+func validateProofFields(proof, nullifierHash, merkleRoot string) error {
+	if proof == "" {
 		return sdkerrors.ErrInvalidRequest.Wrap("proof is required")
 	}
-	if _, err := hex.DecodeString(m.Proof); err != nil {
+	if _, err := hex.DecodeString(proof); err != nil {
 		return sdkerrors.ErrInvalidRequest.Wrap("proof must be valid hex")
 	}
-	if len(m.NullifierHash) != 64 {
+	if len(nullifierHash) != 64 {
 		return sdkerrors.ErrInvalidRequest.Wrap("nullifier_hash must be exactly 64 hex chars")
 	}
-	if _, err := hex.DecodeString(m.NullifierHash); err != nil {
+	if _, err := hex.DecodeString(nullifierHash); err != nil {
 		return sdkerrors.ErrInvalidRequest.Wrap("nullifier_hash must be valid hex")
 	}
-	if m.MerkleRoot != "" {
-		if len(m.MerkleRoot) != 64 {
+	if merkleRoot != "" {
+		if len(merkleRoot) != 64 {
 			return sdkerrors.ErrInvalidRequest.Wrap("merkle_root must be exactly 64 hex chars if provided")
 		}
-		if _, err := hex.DecodeString(m.MerkleRoot); err != nil {
+		if _, err := hex.DecodeString(merkleRoot); err != nil {
 			return sdkerrors.ErrInvalidRequest.Wrap("merkle_root must be valid hex")
 		}
 	}
-	if _, err := ValidateRewardRecipient(m.RewardRecipient); err != nil {
-		return err
-	}
 	return nil
 }
 
@@ -604,6 +837,39 @@ func (m MsgRegisterIdentity) ValidateBasic() error {
 	return nil
 }
 
+// --- MsgRotateIdentityCommitment ---
+
+type MsgRotateIdentityCommitment struct {
+	Sender        sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	DomainName    string         `protobuf:"bytes,2,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	OldCommitment string         `protobuf:"bytes,3,opt,name=old_commitment,json=oldCommitment,proto3" json:"old_commitment"` // commitment to retire (64 hex chars)
+	NewCommitment string         `protobuf:"bytes,4,opt,name=new_commitment,json=newCommitment,proto3" json:"new_commitment"` // replacement commitment (64 hex chars)
+}
+
+func (m *MsgRotateIdentityCommitment) ProtoMessage()               {}
+func (m *MsgRotateIdentityCommitment) Reset()                      { *m = MsgRotateIdentityCommitment{} }
+func (m *MsgRotateIdentityCommitment) String() string              { b, _ := json.Marshal(m); return string(b) }
+func (m MsgRotateIdentityCommitment) Route() string                { return ModuleName }
+func (m MsgRotateIdentityCommitment) Type() string                 { return "rotate_identity" }
+func (m MsgRotateIdentityCommitment) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.Sender} }
+func (m MsgRotateIdentityCommitment) ValidateBasic() error {
+	if m.DomainName == "" {
+		return sdkerrors.ErrInvalidRequest.Wrap("domain_name is required")
+	}
+	for _, commitment := range []string{m.OldCommitment, m.NewCommitment} {
+		if len(commitment) != 64 {
+			return sdkerrors.ErrInvalidRequest.Wrap("commitments must be 64 hex characters (32 bytes)")
+		}
+		if _, err := hex.DecodeString(commitment); err != nil {
+			return sdkerrors.ErrInvalidRequest.Wrap("commitments must be valid hex")
+		}
+	}
+	if strings.EqualFold(m.OldCommitment, m.NewCommitment) {
+		return sdkerrors.ErrInvalidRequest.Wrap("new commitment must differ from the old one")
+	}
+	return nil
+}
+
 // --- MsgCastElectionVote ---
 
 type MsgCastElectionVote struct {
@@ -637,6 +903,419 @@ func (m MsgCastElectionVote) ValidateBasic() error {
 	return requireSignerClaim(m.Sender, m.VoterAddr, "voter address")
 }
 
+// --- MsgCastRankedElectionVote ---
+
+type MsgCastRankedElectionVote struct {
+	Sender     sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	DomainName string         `protobuf:"bytes,2,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	IssueName  string         `protobuf:"bytes,3,opt,name=issue_name,json=issueName,proto3" json:"issue_name"`
+	VoterAddr  string         `protobuf:"bytes,4,opt,name=voter_addr,json=voterAddr,proto3" json:"voter_addr"`
+	Ranking    []string       `protobuf:"bytes,5,rep,name=ranking,proto3" json:"ranking"` // most preferred first
+}
+
+func (m *MsgCastRankedElectionVote) ProtoMessage()               {}
+func (m *MsgCastRankedElectionVote) Reset()                      { *m = MsgCastRankedElectionVote{} }
+func (m *MsgCastRankedElectionVote) String() string              { b, _ := json.Marshal(m); return string(b) }
+func (m MsgCastRankedElectionVote) Route() string                { return ModuleName }
+func (m MsgCastRankedElectionVote) Type() string                 { return "cast_ranked_election_vote" }
+func (m MsgCastRankedElectionVote) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.Sender} }
+func (m MsgCastRankedElectionVote) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if m.DomainName == "" || m.IssueName == "" || m.VoterAddr == "" {
+		return sdkerrors.ErrInvalidRequest.Wrap("domain_name, issue_name, and voter_addr are required")
+	}
+	if err := validateRanking(m.Ranking); err != nil {
+		return err
+	}
+	return requireSignerClaim(m.Sender, m.VoterAddr, "voter address")
+}
+
+// --- MsgCastElectionVoteWithProof ---
+
+// MsgCastElectionVoteWithProof casts an anonymous election ballot. The
+// sender only pays the fee; eligibility comes from the membership proof.
+type MsgCastElectionVoteWithProof struct {
+	Sender        sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	DomainName    string         `protobuf:"bytes,2,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	IssueName     string         `protobuf:"bytes,3,opt,name=issue_name,json=issueName,proto3" json:"issue_name"`
+	Choice        int32          `protobuf:"varint,4,opt,name=choice,proto3" json:"choice"`                                   // 0=approve, 1=abstain
+	Ranking       []string       `protobuf:"bytes,5,rep,name=ranking,proto3" json:"ranking"`                                  // most preferred first; empty for abstain
+	Proof         string         `protobuf:"bytes,6,opt,name=proof,proto3" json:"proof"`                                      // hex-encoded Groth16 proof
+	NullifierHash string         `protobuf:"bytes,7,opt,name=nullifier_hash,json=nullifierHash,proto3" json:"nullifier_hash"` // hex-encoded (64 chars)
+	MerkleRoot    string         `protobuf:"bytes,8,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root"`          // optional; empty = current root
+}
+
+func (m *MsgCastElectionVoteWithProof) ProtoMessage()  {}
+func (m *MsgCastElectionVoteWithProof) Reset()         { *m = MsgCastElectionVoteWithProof{} }
+func (m *MsgCastElectionVoteWithProof) String() string { b, _ := json.Marshal(m); return string(b) }
+func (m MsgCastElectionVoteWithProof) Route() string   { return ModuleName }
+func (m MsgCastElectionVoteWithProof) Type() string    { return "cast_election_vote_with_proof" }
+func (m MsgCastElectionVoteWithProof) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Sender}
+}
+func (m MsgCastElectionVoteWithProof) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if m.DomainName == "" || m.IssueName == "" {
+		return sdkerrors.ErrInvalidRequest.Wrap("domain_name and issue_name are required")
+	}
+	switch m.Choice {
+	case 0:
+		if err := validateRanking(m.Ranking); err != nil {
+			return err
+		}
+	case 1:
+		if len(m.Ranking) != 0 {
+			return sdkerrors.ErrInvalidRequest.Wrap("abstain vote takes no ranking")
+		}
+	default:
+		return sdkerrors.ErrInvalidRequest.Wrap("choice must be 0 (approve) or 1 (abstain)")
+	}
+	return validateProofFields(m.Proof, m.NullifierHash, m.MerkleRoot)
+}
+
+// --- MsgPlaceStoneWithProof ---
+
+// MsgPlaceStoneWithProof places or moves an anonymous stone on the issue,
+// suggestion, or member list. IssueName selects the suggestion list and is
+// empty for the other two.
+type MsgPlaceStoneWithProof struct {
+	Sender        sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	DomainName    string         `protobuf:"bytes,2,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	List          string         `protobuf:"bytes,3,opt,name=list,proto3" json:"list"` // issue, suggestion or member
+	IssueName     string         `protobuf:"bytes,4,opt,name=issue_name,json=issueName,proto3" json:"issue_name"`
+	Target        string         `protobuf:"bytes,5,opt,name=target,proto3" json:"target"`                                    // issue, suggestion or member address
+	Proof         string         `protobuf:"bytes,6,opt,name=proof,proto3" json:"proof"`                                      // hex-encoded Groth16 proof
+	NullifierHash string         `protobuf:"bytes,7,opt,name=nullifier_hash,json=nullifierHash,proto3" json:"nullifier_hash"` // hex-encoded (64 chars)
+	MerkleRoot    string         `protobuf:"bytes,8,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root"`          // optional; empty = current root
+}
+
+func (m *MsgPlaceStoneWithProof) ProtoMessage()               {}
+func (m *MsgPlaceStoneWithProof) Reset()                      { *m = MsgPlaceStoneWithProof{} }
+func (m *MsgPlaceStoneWithProof) String() string              { b, _ := json.Marshal(m); return string(b) }
+func (m MsgPlaceStoneWithProof) Route() string                { return ModuleName }
+func (m MsgPlaceStoneWithProof) Type() string                 { return "place_stone_with_proof" }
+func (m MsgPlaceStoneWithProof) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.Sender} }
+func (m MsgPlaceStoneWithProof) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if m.DomainName == "" || m.Target == "" {
+		return sdkerrors.ErrInvalidRequest.Wrap("domain_name and target are required")
+	}
+	switch m.List {
+	case StoneListSuggestion:
+		if m.IssueName == "" {
+			return sdkerrors.ErrInvalidRequest.Wrap("issue_name is required for the suggestion list")
+		}
+	case StoneListIssue, StoneListMember:
+		if m.IssueName != "" {
+			return sdkerrors.ErrInvalidRequest.Wrapf("issue_name must be empty for the %s list", m.List)
+		}
+	default:
+		return sdkerrors.ErrInvalidRequest.Wrap("list must be issue, suggestion or member")
+	}
+	return validateProofFields(m.Proof, m.NullifierHash, m.MerkleRoot)
+}
+
+// --- MsgSubmitProposalWithProof ---
+
+// MsgSubmitProposalWithProof submits a suggestion for an anonymous domain
+// member. Sender is the funding account that pays the fee.
+type MsgSubmitProposalWithProof struct {
+	Sender         sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	DomainName     string         `protobuf:"bytes,2,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	IssueName      string         `protobuf:"bytes,3,opt,name=issue_name,json=issueName,proto3" json:"issue_name"`
+	SuggestionName string         `protobuf:"bytes,4,opt,name=suggestion_name,json=suggestionName,proto3" json:"suggestion_name"`
+	Fee            sdk.Coins      `protobuf:"bytes,5,rep,name=fee,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"fee"`
+	ExternalLink   string         `protobuf:"bytes,6,opt,name=external_link,json=externalLink,proto3" json:"external_link"`
+	Proof          string         `protobuf:"bytes,7,opt,name=proof,proto3" json:"proof"`                                      // hex-encoded Groth16 proof
+	NullifierHash  string         `protobuf:"bytes,8,opt,name=nullifier_hash,json=nullifierHash,proto3" json:"nullifier_hash"` // hex-encoded (64 chars)
+	MerkleRoot     string         `protobuf:"bytes,9,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root"`          // optional; empty = current root
+}
+
+func (m *MsgSubmitProposalWithProof) ProtoMessage()  {}
+func (m *MsgSubmitProposalWithProof) Reset()         { *m = MsgSubmitProposalWithProof{} }
+func (m *MsgSubmitProposalWithProof) String() string { b, _ := json.Marshal(m); return string(b) }
+func (m MsgSubmitProposalWithProof) Route() string   { return ModuleName }
+func (m MsgSubmitProposalWithProof) Type() string    { return "submit_proposal_with_proof" }
+func (m MsgSubmitProposalWithProof) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Sender}
+}
+func (m MsgSubmitProposalWithProof) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if m.DomainName == "" || m.IssueName == "" || m.SuggestionName == "" {
+		return sdkerrors.ErrInvalidRequest.Wrap("domain, issue, and suggestion names are required")
+	}
+	if err := validatePNYXCoins(m.Fee, "proposal fee"); err != nil {
+		return err
+	}
+	return validateProofFields(m.Proof, m.NullifierHash, m.MerkleRoot)
+}
+
+// --- MsgSignalWithProof ---
+
+// MsgSignalWithProof posts an anonymous signal of a domain member to an
+// application-defined topic.
+type MsgSignalWithProof struct {
+	Sender        sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	DomainName    string         `protobuf:"bytes,2,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	Topic         string         `protobuf:"bytes,3,opt,name=topic,proto3" json:"topic"`
+	Signal        string         `protobuf:"bytes,4,opt,name=signal,proto3" json:"signal"`
+	Proof         string         `protobuf:"bytes,5,opt,name=proof,proto3" json:"proof"`                                      // hex-encoded Groth16 proof
+	NullifierHash string         `protobuf:"bytes,6,opt,name=nullifier_hash,json=nullifierHash,proto3" json:"nullifier_hash"` // hex-encoded (64 chars)
+	MerkleRoot    string         `protobuf:"bytes,7,opt,name=merkle_root,json=merkleRoot,proto3" json:"merkle_root"`          // optional; empty = current root
+}
+
+func (m *MsgSignalWithProof) ProtoMessage()               {}
+func (m *MsgSignalWithProof) Reset()                      { *m = MsgSignalWithProof{} }
+func (m *MsgSignalWithProof) String() string              { b, _ := json.Marshal(m); return string(b) }
+func (m MsgSignalWithProof) Route() string                { return ModuleName }
+func (m MsgSignalWithProof) Type() string                 { return "signal_with_proof" }
+func (m MsgSignalWithProof) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.Sender} }
+func (m MsgSignalWithProof) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if m.DomainName == "" {
+		return sdkerrors.ErrInvalidRequest.Wrap("domain_name is required")
+	}
+	if err := validateAnonymousSignal(m.Topic, m.Signal); err != nil {
+		return err
+	}
+	return validateProofFields(m.Proof, m.NullifierHash, m.MerkleRoot)
+}
+
+// --- MsgRateOpenly ---
+
+// MsgRateOpenly rates a suggestion under the member's own address so the
+// votes delegated to the member count with it.
+type MsgRateOpenly struct {
+	Sender         sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	DomainName     string         `protobuf:"bytes,2,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	IssueName      string         `protobuf:"bytes,3,opt,name=issue_name,json=issueName,proto3" json:"issue_name"`
+	SuggestionName string         `protobuf:"bytes,4,opt,name=suggestion_name,json=suggestionName,proto3" json:"suggestion_name"`
+	Rating         int32          `protobuf:"varint,5,opt,name=rating,proto3" json:"rating"`
+	MemberAddr     string         `protobuf:"bytes,6,opt,name=member_addr,json=memberAddr,proto3" json:"member_addr"`
+}
+
+func (m *MsgRateOpenly) ProtoMessage()               {}
+func (m *MsgRateOpenly) Reset()                      { *m = MsgRateOpenly{} }
+func (m *MsgRateOpenly) String() string              { b, _ := json.Marshal(m); return string(b) }
+func (m MsgRateOpenly) Route() string                { return ModuleName }
+func (m MsgRateOpenly) Type() string                 { return "rate_openly" }
+func (m MsgRateOpenly) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.Sender} }
+func (m MsgRateOpenly) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if m.DomainName == "" || m.IssueName == "" || m.SuggestionName == "" || m.MemberAddr == "" {
+		return sdkerrors.ErrInvalidRequest.Wrap("domain_name, issue_name, suggestion_name, and member_addr are required")
+	}
+	if m.Rating < -5 || m.Rating > 5 {
+		return sdkerrors.ErrInvalidRequest.Wrap("rating must be between -5 and +5")
+	}
+	return requireSignerClaim(m.Sender, m.MemberAddr, "member address")
+}
+
+// --- MsgDelegateVote ---
+
+// MsgDelegateVote delegates the sender's vote to another domain member for
+// one issue, or domain-wide when IssueName is empty. An empty Delegate
+// revokes the delegation.
+type MsgDelegateVote struct {
+	Sender     sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	DomainName string         `protobuf:"bytes,2,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	IssueName  string         `protobuf:"bytes,3,opt,name=issue_name,json=issueName,proto3" json:"issue_name"` // optional; empty = domain-wide
+	Delegator  string         `protobuf:"bytes,4,opt,name=delegator,proto3" json:"delegator"`
+	Delegate   string         `protobuf:"bytes,5,opt,name=delegate,proto3" json:"delegate"` // empty = revoke
+}
+
+func (m *MsgDelegateVote) ProtoMessage()               {}
+func (m *MsgDelegateVote) Reset()                      { *m = MsgDelegateVote{} }
+func (m *MsgDelegateVote) String() string              { b, _ := json.Marshal(m); return string(b) }
+func (m MsgDelegateVote) Route() string                { return ModuleName }
+func (m MsgDelegateVote) Type() string                 { return "delegate_vote" }
+func (m MsgDelegateVote) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.Sender} }
+func (m MsgDelegateVote) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if m.DomainName == "" || m.Delegator == "" {
+		return sdkerrors.ErrInvalidRequest.Wrap("domain_name and delegator are required")
+	}
+	if m.Delegate == m.Delegator {
+		return sdkerrors.ErrInvalidRequest.Wrap("cannot delegate to yourself")
+	}
+	return requireSignerClaim(m.Sender, m.Delegator, "delegator")
+}
+
+// --- MsgCreateSubDomain ---
+
+// MsgCreateSubDomain creates a child of ParentDomain. Admin, a parent
+// member, administers the child; empty means the sender.
+type MsgCreateSubDomain struct {
+	Sender       sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	ParentDomain string         `protobuf:"bytes,2,opt,name=parent_domain,json=parentDomain,proto3" json:"parent_domain"`
+	Name         string         `protobuf:"bytes,3,opt,name=name,proto3" json:"name"`
+	Admin        string         `protobuf:"bytes,4,opt,name=admin,proto3" json:"admin"` // bech32; empty = sender
+}
+
+func (m *MsgCreateSubDomain) ProtoMessage()               {}
+func (m *MsgCreateSubDomain) Reset()                      { *m = MsgCreateSubDomain{} }
+func (m *MsgCreateSubDomain) String() string              { b, _ := json.Marshal(m); return string(b) }
+func (m MsgCreateSubDomain) Route() string                { return ModuleName }
+func (m MsgCreateSubDomain) Type() string                 { return "create_sub_domain" }
+func (m MsgCreateSubDomain) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.Sender} }
+func (m MsgCreateSubDomain) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if m.ParentDomain == "" || m.Name == "" {
+		return sdkerrors.ErrInvalidRequest.Wrap("parent_domain and name are required")
+	}
+	if m.Name == ReservedGovernanceDomain {
+		return sdkerrors.ErrInvalidRequest.Wrap("domain governance is reserved and can only be anchored in genesis")
+	}
+	if m.Admin != "" {
+		if _, err := sdk.AccAddressFromBech32(m.Admin); err != nil {
+			return sdkerrors.ErrInvalidAddress.Wrapf("invalid admin address: %s", err)
+		}
+	}
+	return nil
+}
+
+// --- MsgVoteSubDomainBudget ---
+
+// MsgVoteSubDomainBudget votes to move Amount from the parent treasury to
+// SubDomain. The transfer runs once 2/3 of the parent's members voted for
+// the same amount.
+type MsgVoteSubDomainBudget struct {
+	Sender       sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	ParentDomain string         `protobuf:"bytes,2,opt,name=parent_domain,json=parentDomain,proto3" json:"parent_domain"`
+	SubDomain    string         `protobuf:"bytes,3,opt,name=sub_domain,json=subDomain,proto3" json:"sub_domain"`
+	Amount       sdk.Coin       `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount"`
+	VoterAddr    string         `protobuf:"bytes,5,opt,name=voter_addr,json=voterAddr,proto3" json:"voter_addr"`
+}
+
+func (m *MsgVoteSubDomainBudget) ProtoMessage()               {}
+func (m *MsgVoteSubDomainBudget) Reset()                      { *m = MsgVoteSubDomainBudget{} }
+func (m *MsgVoteSubDomainBudget) String() string              { b, _ := json.Marshal(m); return string(b) }
+func (m MsgVoteSubDomainBudget) Route() string                { return ModuleName }
+func (m MsgVoteSubDomainBudget) Type() string                 { return "vote_sub_domain_budget" }
+func (m MsgVoteSubDomainBudget) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.Sender} }
+func (m MsgVoteSubDomainBudget) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if m.ParentDomain == "" || m.SubDomain == "" || m.VoterAddr == "" {
+		return sdkerrors.ErrInvalidRequest.Wrap("parent_domain, sub_domain, and voter_addr are required")
+	}
+	if !m.Amount.IsPositive() {
+		return sdkerrors.ErrInvalidRequest.Wrap("amount must be positive")
+	}
+	if m.Amount.Denom != PNYXDenom {
+		return sdkerrors.ErrInvalidRequest.Wrap("only upnyx budgets supported")
+	}
+	return requireSignerClaim(m.Sender, m.VoterAddr, "voter address")
+}
+
+// --- MsgDelegateIssueToSubDomain ---
+
+// MsgDelegateIssueToSubDomain hands an open issue to a direct sub-domain,
+// whose decision the issue then adopts. Sender must be the domain admin.
+type MsgDelegateIssueToSubDomain struct {
+	Sender     sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	DomainName string         `protobuf:"bytes,2,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	IssueName  string         `protobuf:"bytes,3,opt,name=issue_name,json=issueName,proto3" json:"issue_name"`
+	SubDomain  string         `protobuf:"bytes,4,opt,name=sub_domain,json=subDomain,proto3" json:"sub_domain"`
+}
+
+func (m *MsgDelegateIssueToSubDomain) ProtoMessage()               {}
+func (m *MsgDelegateIssueToSubDomain) Reset()                      { *m = MsgDelegateIssueToSubDomain{} }
+func (m *MsgDelegateIssueToSubDomain) String() string              { b, _ := json.Marshal(m); return string(b) }
+func (m MsgDelegateIssueToSubDomain) Route() string                { return ModuleName }
+func (m MsgDelegateIssueToSubDomain) Type() string                 { return "delegate_issue_to_sub_domain" }
+func (m MsgDelegateIssueToSubDomain) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{m.Sender} }
+func (m MsgDelegateIssueToSubDomain) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if m.DomainName == "" || m.IssueName == "" || m.SubDomain == "" {
+		return sdkerrors.ErrInvalidRequest.Wrap("domain_name, issue_name, and sub_domain are required")
+	}
+	if m.SubDomain == m.DomainName {
+		return sdkerrors.ErrInvalidRequest.Wrap("an issue cannot be delegated to its own domain")
+	}
+	return nil
+}
+
+// --- MsgProposeDomainOptionsChange ---
+
+// MsgProposeDomainOptionsChange votes for replacing a domain's options with
+// the complete set carried by the message. Members endorse a proposal by
+// submitting the identical set; it applies once the domain's options-change
+// supermajority agrees.
+type MsgProposeDomainOptionsChange struct {
+	Sender                   sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	DomainName               string         `protobuf:"bytes,2,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	VoterAddr                string         `protobuf:"bytes,3,opt,name=voter_addr,json=voterAddr,proto3" json:"voter_addr"`
+	AdminElectable           bool           `protobuf:"varint,4,opt,name=admin_electable,json=adminElectable,proto3" json:"admin_electable"`
+	AnyoneCanJoin            bool           `protobuf:"varint,5,opt,name=anyone_can_join,json=anyoneCanJoin,proto3" json:"anyone_can_join"`
+	OnlyAdminIssues          bool           `protobuf:"varint,6,opt,name=only_admin_issues,json=onlyAdminIssues,proto3" json:"only_admin_issues"`
+	CoinBurnRequired         bool           `protobuf:"varint,7,opt,name=coin_burn_required,json=coinBurnRequired,proto3" json:"coin_burn_required"`
+	ApprovalThreshold        int64          `protobuf:"varint,8,opt,name=approval_threshold,json=approvalThreshold,proto3" json:"approval_threshold"`
+	DefaultDwellTime         int64          `protobuf:"varint,9,opt,name=default_dwell_time,json=defaultDwellTime,proto3" json:"default_dwell_time"`
+	VotingMode               int32          `protobuf:"varint,10,opt,name=voting_mode,json=votingMode,proto3" json:"voting_mode"`
+	AbstentionAllowed        bool           `protobuf:"varint,11,opt,name=abstention_allowed,json=abstentionAllowed,proto3" json:"abstention_allowed"`
+	PayoutCapPerEpoch        int64          `protobuf:"varint,12,opt,name=payout_cap_per_epoch,json=payoutCapPerEpoch,proto3" json:"payout_cap_per_epoch"`
+	OptionsChangeMajorityBps int64          `protobuf:"varint,13,opt,name=options_change_majority_bps,json=optionsChangeMajorityBps,proto3" json:"options_change_majority_bps"`
+	ZKPCircuit               string         `protobuf:"bytes,14,opt,name=zkp_circuit,json=zkpCircuit,proto3" json:"zkp_circuit,omitempty"`
+}
+
+func (m *MsgProposeDomainOptionsChange) ProtoMessage()  {}
+func (m *MsgProposeDomainOptionsChange) Reset()         { *m = MsgProposeDomainOptionsChange{} }
+func (m *MsgProposeDomainOptionsChange) String() string { b, _ := json.Marshal(m); return string(b) }
+func (m MsgProposeDomainOptionsChange) Route() string   { return ModuleName }
+func (m MsgProposeDomainOptionsChange) Type() string    { return "propose_domain_options_change" }
+func (m MsgProposeDomainOptionsChange) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Sender}
+}
+func (m MsgProposeDomainOptionsChange) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if m.DomainName == "" || m.VoterAddr == "" {
+		return sdkerrors.ErrInvalidRequest.Wrap("domain_name and voter_addr are required")
+	}
+	if err := validateDomainOptions(m.Options()); err != nil {
+		return err
+	}
+	return requireSignerClaim(m.Sender, m.VoterAddr, "voter address")
+}
+
+// Options returns the proposed options set.
+func (m MsgProposeDomainOptionsChange) Options() DomainOptions {
+	return DomainOptions{
+		AdminElectable:           m.AdminElectable,
+		AnyoneCanJoin:            m.AnyoneCanJoin,
+		OnlyAdminIssues:          m.OnlyAdminIssues,
+		CoinBurnRequired:         m.CoinBurnRequired,
+		ApprovalThreshold:        m.ApprovalThreshold,
+		DefaultDwellTime:         m.DefaultDwellTime,
+		VotingMode:               VotingMode(m.VotingMode),
+		AbstentionAllowed:        m.AbstentionAllowed,
+		PayoutCapPerEpoch:        m.PayoutCapPerEpoch,
+		OptionsChangeMajorityBps: m.OptionsChangeMajorityBps,
+		ZKPCircuit:               m.ZKPCircuit,
+	}
+}
+
 // --- MsgDepositToDomain ---
 
 type MsgDepositToDomain struct {
@@ -750,3 +1429,40 @@ func (m MsgVoteCancelSoftwareUpgrade) ValidateBasic() error {
 	}
 	return validateUpgradePlanName(m.PlanName)
 }
+
+// --- MsgVoteVerifyingKeyRotation ---
+
+type MsgVoteVerifyingKeyRotation struct {
+	Sender             sdk.AccAddress `protobuf:"bytes,1,opt,name=sender,proto3,casttype=github.com/cosmos/cosmos-sdk/types.AccAddress" json:"sender"`
+	CircuitID          string         `protobuf:"bytes,2,opt,name=circuit_id,json=circuitId,proto3" json:"circuit_id"`
+	VerifyingKeyHex    string         `protobuf:"bytes,3,opt,name=verifying_key_hex,json=verifyingKeyHex,proto3" json:"verifying_key_hex"`
+	VerifyingKeySHA256 string         `protobuf:"bytes,4,opt,name=verifying_key_sha256,json=verifyingKeySha256,proto3" json:"verifying_key_sha256"`
+	TransitionBlocks   int64          `protobuf:"varint,5,opt,name=transition_blocks,json=transitionBlocks,proto3" json:"transition_blocks"`
+}
+
+func (m *MsgVoteVerifyingKeyRotation) ProtoMessage()  {}
+func (m *MsgVoteVerifyingKeyRotation) Reset()         { *m = MsgVoteVerifyingKeyRotation{} }
+func (m *MsgVoteVerifyingKeyRotation) String() string { b, _ := json.Marshal(m); return string(b) }
+func (m MsgVoteVerifyingKeyRotation) Route() string   { return ModuleName }
+func (m MsgVoteVerifyingKeyRotation) Type() string    { return "vote_verifying_key_rotation" }
+func (m MsgVoteVerifyingKeyRotation) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Sender}
+}
+func (m MsgVoteVerifyingKeyRotation) ValidateBasic() error {
+	if m.Sender.Empty() {
+		return sdkerrors.ErrInvalidAddress.Wrap("sender address is required")
+	}
+	if _, supported := membershipCircuitNullifierFamilies[m.CircuitID]; !supported {
+		return sdkerrors.ErrInvalidRequest.Wrapf("unsupported ZKP circuit id %q", m.CircuitID)
+	}
+	if m.VerifyingKeyHex == "" || len(m.VerifyingKeyHex) > verifyingKeyMaxHexLength {
+		return sdkerrors.ErrInvalidRequest.Wrapf("verifying key must be 1..%d hex characters", verifyingKeyMaxHexLength)
+	}
+	if len(m.VerifyingKeySHA256) != 64 {
+		return sdkerrors.ErrInvalidRequest.Wrap("verifying key SHA-256 must be 64 hex characters")
+	}
+	if m.TransitionBlocks < 0 || m.TransitionBlocks > VerifyingKeyMaxTransitionBlocks {
+		return sdkerrors.ErrInvalidRequest.Wrapf("transition must be 0..%d blocks", VerifyingKeyMaxTransitionBlocks)
+	}
+	return nil
+}
@@ -47,9 +47,7 @@ func setupDomainWithCommitments(t *testing.T, k Keeper, ctx sdk.Context, name st
 // tests can craft malformed state that must fail closed.
 func storeDomain(t *testing.T, k Keeper, ctx sdk.Context, domain Domain) {
 	t.Helper()
-	store := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domain.Name), bz)
+	k.SetDomain(ctx, domain)
 }
 
 // ---------- QueryMerkleProof Tests ----------
@@ -0,0 +1,345 @@
+package truedemocracy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+// ---------- Test helpers ----------
+
+// setupQueryDomain stores a domain whose issues and suggestions carry
+// distinct stones, activity, colors and scores for the list queries.
+func setupQueryDomain(t *testing.T, k Keeper, ctx sdk.Context) {
+	t.Helper()
+	rating := func(v int) Rating { return Rating{DomainPubKeyHex: fmt.Sprintf("%064x", v+10), Value: v} }
+	k.SetDomain(ctx, Domain{
+		Name:    "Query",
+		Admin:   sdk.AccAddress("admin1"),
+		Members: []string{"m0", "m1", "m2", "m3", "m4"},
+		Issues: []Issue{
+			{Name: "old", Stones: 1, LastActivityAt: 300},
+			{Name: "popular", Stones: 7, LastActivityAt: 100, Suggestions: []Suggestion{
+				{Name: "a", Color: "green", Stones: 1, Ratings: []Rating{rating(5), rating(3)}},
+				{Name: "b", Color: "yellow", Stones: 2, Ratings: []Rating{rating(-4)}},
+				{Name: "c", Color: "green", Stones: 3, Ratings: []Rating{rating(1)}},
+				{Name: "d", Color: "red"},
+			}},
+			{Name: "recent", Stones: 3, LastActivityAt: 500},
+		},
+	})
+}
+
+func decodeResult[T any](t *testing.T, bz []byte) T {
+	t.Helper()
+	var out T
+	if err := json.Unmarshal(bz, &out); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	return out
+}
+
+func issueNames(issues []IssueSummary) []string {
+	names := make([]string, len(issues))
+	for i, issue := range issues {
+		names[i] = issue.Name
+	}
+	return names
+}
+
+func suggestionNames(suggestions []SuggestionSummary) []string {
+	names := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// ---------- Domains / Validators ----------
+
+func TestQueryDomainsPaginatesSummaries(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupQueryDomain(t, k, ctx)
+	for _, name := range []string{"A", "B", "C"} {
+		k.CreateDomain(ctx, name, sdk.AccAddress("admin1"), sdk.NewCoins())
+	}
+
+	resp, err := k.Domains(ctx, &QueryDomainsRequest{Pagination: &query.PageRequest{Limit: 2, CountTotal: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := decodeResult[[]DomainSummary](t, resp.Result)
+	if len(first) != 2 || first[0].Name != "A" || first[1].Name != "B" {
+		t.Fatalf("first page = %+v", first)
+	}
+	if resp.Pagination.Total != 4 || resp.Pagination.NextKey == nil {
+		t.Fatalf("first page pagination = %+v", resp.Pagination)
+	}
+
+	resp, err = k.Domains(ctx, &QueryDomainsRequest{Pagination: &query.PageRequest{Key: resp.Pagination.NextKey, Limit: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second := decodeResult[[]DomainSummary](t, resp.Result)
+	if len(second) != 2 || second[1].Name != "Query" || resp.Pagination.NextKey != nil {
+		t.Fatalf("second page = %+v, pagination %+v", second, resp.Pagination)
+	}
+	if second[1].MemberCount != 5 || second[1].IssueCount != 3 || second[1].Members != nil || second[1].Issues != nil {
+		t.Fatalf("summary must carry counts instead of lists: %+v", second[1])
+	}
+}
+
+func TestQueryValidatorsPaginates(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	for i := 0; i < 3; i++ {
+		k.SetValidator(ctx, Validator{OperatorAddr: fmt.Sprintf("val-%d", i), Power: int64(i + 1)})
+	}
+
+	resp, err := k.Validators(ctx, &QueryValidatorsRequest{Pagination: &query.PageRequest{Offset: 1, Limit: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vals := decodeResult[[]Validator](t, resp.Result)
+	if len(vals) != 1 || vals[0].OperatorAddr != "val-1" {
+		t.Fatalf("page = %+v", vals)
+	}
+
+	resp, err = k.Validators(ctx, &QueryValidatorsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vals := decodeResult[[]Validator](t, resp.Result); len(vals) != 3 {
+		t.Fatalf("default page returned %d validators", len(vals))
+	}
+}
+
+// ---------- DomainIssues ----------
+
+func TestQueryDomainIssuesSortOrders(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupQueryDomain(t, k, ctx)
+
+	cases := map[string][]string{
+		"":         {"old", "popular", "recent"},
+		"stones":   {"popular", "recent", "old"},
+		"activity": {"recent", "old", "popular"},
+	}
+	for sortBy, want := range cases {
+		resp, err := k.DomainIssues(ctx, &QueryDomainIssuesRequest{DomainName: "Query", SortBy: sortBy})
+		if err != nil {
+			t.Fatalf("sort %q: %v", sortBy, err)
+		}
+		issues := decodeResult[[]IssueSummary](t, resp.Result)
+		if got := issueNames(issues); !reflect.DeepEqual(got, want) {
+			t.Fatalf("sort %q = %v, want %v", sortBy, got, want)
+		}
+	}
+
+	resp, _ := k.DomainIssues(ctx, &QueryDomainIssuesRequest{DomainName: "Query", SortBy: "stones"})
+	issues := decodeResult[[]IssueSummary](t, resp.Result)
+	if issues[0].SuggestionCount != 4 || issues[0].Suggestions != nil {
+		t.Fatalf("issue summary = %+v", issues[0])
+	}
+
+	if _, err := k.DomainIssues(ctx, &QueryDomainIssuesRequest{DomainName: "Query", SortBy: "name"}); !errors.Is(err, sdkerrors.ErrInvalidRequest) {
+		t.Fatalf("unknown sort order: got %v", err)
+	}
+	if _, err := k.DomainIssues(ctx, &QueryDomainIssuesRequest{DomainName: "Missing"}); !errors.Is(err, sdkerrors.ErrKeyNotFound) {
+		t.Fatalf("missing domain: got %v", err)
+	}
+}
+
+func TestQueryDomainIssuesPagesSortedView(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupQueryDomain(t, k, ctx)
+
+	var got []string
+	var key []byte
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatal("pagination did not terminate")
+		}
+		resp, err := k.DomainIssues(ctx, &QueryDomainIssuesRequest{
+			DomainName: "Query",
+			SortBy:     "stones",
+			Pagination: &query.PageRequest{Key: key, Limit: 2},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, issueNames(decodeResult[[]IssueSummary](t, resp.Result))...)
+		if resp.Pagination.NextKey == nil {
+			break
+		}
+		key = resp.Pagination.NextKey
+	}
+	if want := []string{"popular", "recent", "old"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("paged issues = %v, want %v", got, want)
+	}
+
+	_, err := k.DomainIssues(ctx, &QueryDomainIssuesRequest{
+		DomainName: "Query",
+		Pagination: &query.PageRequest{Key: []byte{0, 0, 0, 0, 0, 0, 0, 1}, Offset: 1},
+	})
+	if !errors.Is(err, sdkerrors.ErrInvalidRequest) {
+		t.Fatalf("key and offset together: got %v", err)
+	}
+}
+
+// ---------- IssueSuggestions ----------
+
+func TestQueryIssueSuggestionsFilters(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupQueryDomain(t, k, ctx)
+
+	cases := []struct {
+		name string
+		req  QueryIssueSuggestionsRequest
+		want []string
+	}{
+		{"all", QueryIssueSuggestionsRequest{}, []string{"a", "b", "c", "d"}},
+		{"color", QueryIssueSuggestionsRequest{Color: "green"}, []string{"a", "c"}},
+		{"min score", QueryIssueSuggestionsRequest{MinScore: "1"}, []string{"a", "c"}},
+		{"negative max", QueryIssueSuggestionsRequest{MaxScore: "-1"}, []string{"b"}},
+		{"range", QueryIssueSuggestionsRequest{MinScore: "0", MaxScore: "1"}, []string{"c", "d"}},
+		{"color and score", QueryIssueSuggestionsRequest{Color: "green", MaxScore: "2"}, []string{"c"}},
+		{"by score", QueryIssueSuggestionsRequest{SortBy: "score"}, []string{"a", "c", "d", "b"}},
+	}
+	for _, tc := range cases {
+		req := tc.req
+		req.DomainName, req.IssueName = "Query", "popular"
+		resp, err := k.IssueSuggestions(ctx, &req)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if got := suggestionNames(decodeResult[[]SuggestionSummary](t, resp.Result)); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("%s = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	resp, _ := k.IssueSuggestions(ctx, &QueryIssueSuggestionsRequest{DomainName: "Query", IssueName: "popular", Color: "green"})
+	first := decodeResult[[]SuggestionSummary](t, resp.Result)[0]
+	if first.Score != 8 || first.RatingCount != 2 || first.Ratings != nil {
+		t.Fatalf("suggestion summary = %+v", first)
+	}
+
+	if _, err := k.IssueSuggestions(ctx, &QueryIssueSuggestionsRequest{DomainName: "Query", IssueName: "popular", MinScore: "high"}); !errors.Is(err, sdkerrors.ErrInvalidRequest) {
+		t.Fatalf("bad score bound: got %v", err)
+	}
+	if _, err := k.IssueSuggestions(ctx, &QueryIssueSuggestionsRequest{DomainName: "Query", IssueName: "missing"}); !errors.Is(err, sdkerrors.ErrKeyNotFound) {
+		t.Fatalf("missing issue: got %v", err)
+	}
+}
+
+func TestQueryListsPageStoreOrderAndCapSortedReads(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupQueryDomain(t, k, ctx)
+
+	// Filtered submission order pages over the records: the cursor resumes
+	// after the last matching suggestion.
+	resp, err := k.IssueSuggestions(ctx, &QueryIssueSuggestionsRequest{
+		DomainName: "Query", IssueName: "popular", Color: "green",
+		Pagination: &query.PageRequest{Limit: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := suggestionNames(decodeResult[[]SuggestionSummary](t, resp.Result)); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Fatalf("first page = %v", got)
+	}
+	resp, err = k.IssueSuggestions(ctx, &QueryIssueSuggestionsRequest{
+		DomainName: "Query", IssueName: "popular", Color: "green",
+		Pagination: &query.PageRequest{Key: resp.Pagination.NextKey, Limit: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := suggestionNames(decodeResult[[]SuggestionSummary](t, resp.Result)); !reflect.DeepEqual(got, []string{"c"}) {
+		t.Fatalf("second page = %v", got)
+	}
+
+	issues := make([]Issue, MaxSortedListEntries+1)
+	for i := range issues {
+		issues[i] = Issue{Name: fmt.Sprintf("issue-%04d", i)}
+	}
+	k.SetDomain(ctx, Domain{Name: "Large", Admin: sdk.AccAddress("admin1"), Issues: issues})
+	if _, err := k.DomainIssues(ctx, &QueryDomainIssuesRequest{DomainName: "Large", SortBy: "stones"}); !errors.Is(err, sdkerrors.ErrInvalidRequest) {
+		t.Fatalf("sorted listing past the cap: got %v", err)
+	}
+	resp2, err := k.DomainIssues(ctx, &QueryDomainIssuesRequest{
+		DomainName: "Large",
+		Pagination: &query.PageRequest{Limit: 2, CountTotal: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := issueNames(decodeResult[[]IssueSummary](t, resp2.Result)); !reflect.DeepEqual(got, []string{"issue-0000", "issue-0001"}) {
+		t.Fatalf("creation order page = %v", got)
+	}
+	if resp2.Pagination.Total != uint64(MaxSortedListEntries+1) {
+		t.Fatalf("total = %d", resp2.Pagination.Total)
+	}
+}
+
+func TestQueryIssueSuggestionsWeighsRatings(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	k.SetDomain(ctx, Domain{
+		Name:    "Weighted",
+		Admin:   sdk.AccAddress("admin1"),
+		Members: []string{"m0", "m1"},
+		Issues: []Issue{{Name: "budget", Suggestions: []Suggestion{
+			{Name: "direct", Ratings: []Rating{{DomainPubKeyHex: fmt.Sprintf("%064x", 1), Value: 5}}},
+			{Name: "delegated", Ratings: []Rating{{DomainPubKeyHex: fmt.Sprintf("%064x", 2), Value: 2, Weight: 3}}},
+		}}},
+	})
+
+	resp, err := k.IssueSuggestions(ctx, &QueryIssueSuggestionsRequest{DomainName: "Weighted", IssueName: "budget", SortBy: "score", MinScore: "6"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := decodeResult[[]SuggestionSummary](t, resp.Result)
+	if len(got) != 1 || got[0].Name != "delegated" || got[0].Score != 6 {
+		t.Fatalf("weighted suggestions = %+v", got)
+	}
+}
+
+// ---------- DomainMembers ----------
+
+func TestQueryDomainMembersPaginates(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupQueryDomain(t, k, ctx)
+
+	resp, err := k.DomainMembers(ctx, &QueryDomainMembersRequest{
+		DomainName: "Query",
+		Pagination: &query.PageRequest{Limit: 3, CountTotal: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := decodeResult[[]string](t, resp.Result); !reflect.DeepEqual(got, []string{"m0", "m1", "m2"}) {
+		t.Fatalf("first page = %v", got)
+	}
+	if resp.Pagination.Total != 5 {
+		t.Fatalf("total = %d", resp.Pagination.Total)
+	}
+
+	resp, err = k.DomainMembers(ctx, &QueryDomainMembersRequest{
+		DomainName: "Query",
+		Pagination: &query.PageRequest{Key: resp.Pagination.NextKey, Limit: 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := decodeResult[[]string](t, resp.Result); !reflect.DeepEqual(got, []string{"m3", "m4"}) {
+		t.Fatalf("second page = %v", got)
+	}
+	if resp.Pagination.NextKey != nil {
+		t.Fatalf("last page has next key %x", resp.Pagination.NextKey)
+	}
+}
@@ -2,17 +2,22 @@ package truedemocracy
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
 	gogogrpc "github.com/cosmos/gogoproto/grpc"
 	gogoproto "github.com/cosmos/gogoproto/proto"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"google.golang.org/grpc"
 
 	rewards "truerepublic/treasury/keeper"
@@ -38,14 +43,17 @@ func (*QueryDomainResponse) ProtoMessage()  {}
 func (*QueryDomainResponse) Reset()         {}
 func (*QueryDomainResponse) String() string { return "QueryDomainResponse" }
 
-type QueryDomainsRequest struct{}
+type QueryDomainsRequest struct {
+	Pagination *query.PageRequest `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
 
 func (*QueryDomainsRequest) ProtoMessage()  {}
 func (*QueryDomainsRequest) Reset()         {}
 func (*QueryDomainsRequest) String() string { return "QueryDomainsRequest" }
 
 type QueryDomainsResponse struct {
-	Result []byte `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+	Result     []byte              `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
 }
 
 func (*QueryDomainsResponse) ProtoMessage()  {}
@@ -68,14 +76,17 @@ func (*QueryValidatorResponse) ProtoMessage()  {}
 func (*QueryValidatorResponse) Reset()         {}
 func (*QueryValidatorResponse) String() string { return "QueryValidatorResponse" }
 
-type QueryValidatorsRequest struct{}
+type QueryValidatorsRequest struct {
+	Pagination *query.PageRequest `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
 
 func (*QueryValidatorsRequest) ProtoMessage()  {}
 func (*QueryValidatorsRequest) Reset()         {}
 func (*QueryValidatorsRequest) String() string { return "QueryValidatorsRequest" }
 
 type QueryValidatorsResponse struct {
-	Result []byte `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+	Result     []byte              `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
 }
 
 func (*QueryValidatorsResponse) ProtoMessage()  {}
@@ -164,6 +175,279 @@ func (*QueryPayToPutResponse) ProtoMessage()  {}
 func (*QueryPayToPutResponse) Reset()         {}
 func (*QueryPayToPutResponse) String() string { return "QueryPayToPutResponse" }
 
+type QueryDomainIssuesRequest struct {
+	DomainName string             `protobuf:"bytes,1,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	SortBy     string             `protobuf:"bytes,2,opt,name=sort_by,json=sortBy,proto3" json:"sort_by"`
+	Pagination *query.PageRequest `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryDomainIssuesRequest) ProtoMessage()  {}
+func (*QueryDomainIssuesRequest) Reset()         {}
+func (*QueryDomainIssuesRequest) String() string { return "QueryDomainIssuesRequest" }
+
+type QueryDomainIssuesResponse struct {
+	Result     []byte              `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryDomainIssuesResponse) ProtoMessage()  {}
+func (*QueryDomainIssuesResponse) Reset()         {}
+func (*QueryDomainIssuesResponse) String() string { return "QueryDomainIssuesResponse" }
+
+// QueryIssueSuggestionsRequest filters an issue's suggestions. MinScore and
+// MaxScore are decimal strings because scores can be negative; an empty
+// string disables the bound.
+type QueryIssueSuggestionsRequest struct {
+	DomainName string             `protobuf:"bytes,1,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	IssueName  string             `protobuf:"bytes,2,opt,name=issue_name,json=issueName,proto3" json:"issue_name"`
+	Color      string             `protobuf:"bytes,3,opt,name=color,proto3" json:"color"`
+	MinScore   string             `protobuf:"bytes,4,opt,name=min_score,json=minScore,proto3" json:"min_score"`
+	MaxScore   string             `protobuf:"bytes,5,opt,name=max_score,json=maxScore,proto3" json:"max_score"`
+	SortBy     string             `protobuf:"bytes,6,opt,name=sort_by,json=sortBy,proto3" json:"sort_by"`
+	Pagination *query.PageRequest `protobuf:"bytes,7,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryIssueSuggestionsRequest) ProtoMessage()  {}
+func (*QueryIssueSuggestionsRequest) Reset()         {}
+func (*QueryIssueSuggestionsRequest) String() string { return "QueryIssueSuggestionsRequest" }
+
+type QueryIssueSuggestionsResponse struct {
+	Result     []byte              `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryIssueSuggestionsResponse) ProtoMessage()  {}
+func (*QueryIssueSuggestionsResponse) Reset()         {}
+func (*QueryIssueSuggestionsResponse) String() string { return "QueryIssueSuggestionsResponse" }
+
+type QueryDomainMembersRequest struct {
+	DomainName string             `protobuf:"bytes,1,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	Pagination *query.PageRequest `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryDomainMembersRequest) ProtoMessage()  {}
+func (*QueryDomainMembersRequest) Reset()         {}
+func (*QueryDomainMembersRequest) String() string { return "QueryDomainMembersRequest" }
+
+type QueryDomainMembersResponse struct {
+	Result     []byte              `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryDomainMembersResponse) ProtoMessage()  {}
+func (*QueryDomainMembersResponse) Reset()         {}
+func (*QueryDomainMembersResponse) String() string { return "QueryDomainMembersResponse" }
+
+type QueryIssueDecisionRequest struct {
+	DomainName string `protobuf:"bytes,1,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	IssueName  string `protobuf:"bytes,2,opt,name=issue_name,json=issueName,proto3" json:"issue_name"`
+}
+
+func (*QueryIssueDecisionRequest) ProtoMessage()  {}
+func (*QueryIssueDecisionRequest) Reset()         {}
+func (*QueryIssueDecisionRequest) String() string { return "QueryIssueDecisionRequest" }
+
+type QueryIssueDecisionResponse struct {
+	Result []byte `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+}
+
+func (*QueryIssueDecisionResponse) ProtoMessage()  {}
+func (*QueryIssueDecisionResponse) Reset()         {}
+func (*QueryIssueDecisionResponse) String() string { return "QueryIssueDecisionResponse" }
+
+type QueryIssueDecisionsRequest struct {
+	DomainName string             `protobuf:"bytes,1,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	Pagination *query.PageRequest `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryIssueDecisionsRequest) ProtoMessage()  {}
+func (*QueryIssueDecisionsRequest) Reset()         {}
+func (*QueryIssueDecisionsRequest) String() string { return "QueryIssueDecisionsRequest" }
+
+type QueryIssueDecisionsResponse struct {
+	Result     []byte              `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryIssueDecisionsResponse) ProtoMessage()  {}
+func (*QueryIssueDecisionsResponse) Reset()         {}
+func (*QueryIssueDecisionsResponse) String() string { return "QueryIssueDecisionsResponse" }
+
+type QueryVotingWeightRequest struct {
+	DomainName string `protobuf:"bytes,1,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	IssueName  string `protobuf:"bytes,2,opt,name=issue_name,json=issueName,proto3" json:"issue_name"` // optional; empty = issue and member lists
+	Member     string `protobuf:"bytes,3,opt,name=member,proto3" json:"member"`
+}
+
+func (*QueryVotingWeightRequest) ProtoMessage()  {}
+func (*QueryVotingWeightRequest) Reset()         {}
+func (*QueryVotingWeightRequest) String() string { return "QueryVotingWeightRequest" }
+
+type QueryVotingWeightResponse struct {
+	Result []byte `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+}
+
+func (*QueryVotingWeightResponse) ProtoMessage()  {}
+func (*QueryVotingWeightResponse) Reset()         {}
+func (*QueryVotingWeightResponse) String() string { return "QueryVotingWeightResponse" }
+
+type QuerySubDomainsRequest struct {
+	DomainName string             `protobuf:"bytes,1,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	Pagination *query.PageRequest `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QuerySubDomainsRequest) ProtoMessage()  {}
+func (*QuerySubDomainsRequest) Reset()         {}
+func (*QuerySubDomainsRequest) String() string { return "QuerySubDomainsRequest" }
+
+type QuerySubDomainsResponse struct {
+	Result     []byte              `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QuerySubDomainsResponse) ProtoMessage()  {}
+func (*QuerySubDomainsResponse) Reset()         {}
+func (*QuerySubDomainsResponse) String() string { return "QuerySubDomainsResponse" }
+
+type QueryTreasuryPayoutsRequest struct {
+	DomainName string             `protobuf:"bytes,1,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	Pagination *query.PageRequest `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryTreasuryPayoutsRequest) ProtoMessage()  {}
+func (*QueryTreasuryPayoutsRequest) Reset()         {}
+func (*QueryTreasuryPayoutsRequest) String() string { return "QueryTreasuryPayoutsRequest" }
+
+type QueryTreasuryPayoutsResponse struct {
+	Result     []byte              `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryTreasuryPayoutsResponse) ProtoMessage()  {}
+func (*QueryTreasuryPayoutsResponse) Reset()         {}
+func (*QueryTreasuryPayoutsResponse) String() string { return "QueryTreasuryPayoutsResponse" }
+
+type QueryDomainOptionsHistoryRequest struct {
+	DomainName string             `protobuf:"bytes,1,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	Pagination *query.PageRequest `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryDomainOptionsHistoryRequest) ProtoMessage()  {}
+func (*QueryDomainOptionsHistoryRequest) Reset()         {}
+func (*QueryDomainOptionsHistoryRequest) String() string { return "QueryDomainOptionsHistoryRequest" }
+
+type QueryDomainOptionsHistoryResponse struct {
+	Result     []byte              `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryDomainOptionsHistoryResponse) ProtoMessage()  {}
+func (*QueryDomainOptionsHistoryResponse) Reset()         {}
+func (*QueryDomainOptionsHistoryResponse) String() string { return "QueryDomainOptionsHistoryResponse" }
+
+type QueryAnonymousSignalsRequest struct {
+	DomainName string             `protobuf:"bytes,1,opt,name=domain_name,json=domainName,proto3" json:"domain_name"`
+	Topic      string             `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic"`
+	Pagination *query.PageRequest `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryAnonymousSignalsRequest) ProtoMessage()  {}
+func (*QueryAnonymousSignalsRequest) Reset()         {}
+func (*QueryAnonymousSignalsRequest) String() string { return "QueryAnonymousSignalsRequest" }
+
+type QueryAnonymousSignalsResponse struct {
+	Result     []byte              `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryAnonymousSignalsResponse) ProtoMessage()  {}
+func (*QueryAnonymousSignalsResponse) Reset()         {}
+func (*QueryAnonymousSignalsResponse) String() string { return "QueryAnonymousSignalsResponse" }
+
+type QueryStakeDelegationsRequest struct {
+	ValidatorAddr string             `protobuf:"bytes,1,opt,name=validator_addr,json=validatorAddr,proto3" json:"validator_addr"`
+	Pagination    *query.PageRequest `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryStakeDelegationsRequest) ProtoMessage()  {}
+func (*QueryStakeDelegationsRequest) Reset()         {}
+func (*QueryStakeDelegationsRequest) String() string { return "QueryStakeDelegationsRequest" }
+
+type QueryStakeDelegationsResponse struct {
+	Result     []byte              `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryStakeDelegationsResponse) ProtoMessage()  {}
+func (*QueryStakeDelegationsResponse) Reset()         {}
+func (*QueryStakeDelegationsResponse) String() string { return "QueryStakeDelegationsResponse" }
+
+type QueryPendingUndelegationsRequest struct {
+	ValidatorAddr string             `protobuf:"bytes,1,opt,name=validator_addr,json=validatorAddr,proto3" json:"validator_addr"`
+	Pagination    *query.PageRequest `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryPendingUndelegationsRequest) ProtoMessage()  {}
+func (*QueryPendingUndelegationsRequest) Reset()         {}
+func (*QueryPendingUndelegationsRequest) String() string { return "QueryPendingUndelegationsRequest" }
+
+type QueryPendingUndelegationsResponse struct {
+	Result     []byte              `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryPendingUndelegationsResponse) ProtoMessage()  {}
+func (*QueryPendingUndelegationsResponse) Reset()         {}
+func (*QueryPendingUndelegationsResponse) String() string { return "QueryPendingUndelegationsResponse" }
+
+type QueryStakingRewardsRequest struct {
+	Address       string `protobuf:"bytes,1,opt,name=address,proto3" json:"address"`
+	ValidatorAddr string `protobuf:"bytes,2,opt,name=validator_addr,json=validatorAddr,proto3" json:"validator_addr,omitempty"`
+}
+
+func (*QueryStakingRewardsRequest) ProtoMessage()  {}
+func (*QueryStakingRewardsRequest) Reset()         {}
+func (*QueryStakingRewardsRequest) String() string { return "QueryStakingRewardsRequest" }
+
+type QueryStakingRewardsResponse struct {
+	Result []byte `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+}
+
+func (*QueryStakingRewardsResponse) ProtoMessage()  {}
+func (*QueryStakingRewardsResponse) Reset()         {}
+func (*QueryStakingRewardsResponse) String() string { return "QueryStakingRewardsResponse" }
+
+type QueryValidatorSetRequest struct {
+	Pagination *query.PageRequest `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryValidatorSetRequest) ProtoMessage()  {}
+func (*QueryValidatorSetRequest) Reset()         {}
+func (*QueryValidatorSetRequest) String() string { return "QueryValidatorSetRequest" }
+
+type QueryValidatorSetResponse struct {
+	Result     []byte              `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+	Pagination *query.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (*QueryValidatorSetResponse) ProtoMessage()  {}
+func (*QueryValidatorSetResponse) Reset()         {}
+func (*QueryValidatorSetResponse) String() string { return "QueryValidatorSetResponse" }
+
+type QueryEpochInfoRequest struct{}
+
+func (*QueryEpochInfoRequest) ProtoMessage()  {}
+func (*QueryEpochInfoRequest) Reset()         {}
+func (*QueryEpochInfoRequest) String() string { return "QueryEpochInfoRequest" }
+
+type QueryEpochInfoResponse struct {
+	Result []byte `protobuf:"bytes,1,opt,name=result,proto3" json:"result"`
+}
+
+func (*QueryEpochInfoResponse) ProtoMessage()  {}
+func (*QueryEpochInfoResponse) Reset()         {}
+func (*QueryEpochInfoResponse) String() string { return "QueryEpochInfoResponse" }
+
 // ---------------------------------------------------------------------------
 // Register query types with gogoproto
 // ---------------------------------------------------------------------------
@@ -188,6 +472,36 @@ func init() {
 	gogoproto.RegisterType((*QueryMerkleProofResponse)(nil), "truedemocracy.QueryMerkleProofResponse")
 	gogoproto.RegisterType((*QueryPayToPutRequest)(nil), "truedemocracy.QueryPayToPutRequest")
 	gogoproto.RegisterType((*QueryPayToPutResponse)(nil), "truedemocracy.QueryPayToPutResponse")
+	gogoproto.RegisterType((*QueryDomainIssuesRequest)(nil), "truedemocracy.QueryDomainIssuesRequest")
+	gogoproto.RegisterType((*QueryDomainIssuesResponse)(nil), "truedemocracy.QueryDomainIssuesResponse")
+	gogoproto.RegisterType((*QueryIssueSuggestionsRequest)(nil), "truedemocracy.QueryIssueSuggestionsRequest")
+	gogoproto.RegisterType((*QueryIssueSuggestionsResponse)(nil), "truedemocracy.QueryIssueSuggestionsResponse")
+	gogoproto.RegisterType((*QueryDomainMembersRequest)(nil), "truedemocracy.QueryDomainMembersRequest")
+	gogoproto.RegisterType((*QueryDomainMembersResponse)(nil), "truedemocracy.QueryDomainMembersResponse")
+	gogoproto.RegisterType((*QueryIssueDecisionRequest)(nil), "truedemocracy.QueryIssueDecisionRequest")
+	gogoproto.RegisterType((*QueryIssueDecisionResponse)(nil), "truedemocracy.QueryIssueDecisionResponse")
+	gogoproto.RegisterType((*QueryIssueDecisionsRequest)(nil), "truedemocracy.QueryIssueDecisionsRequest")
+	gogoproto.RegisterType((*QueryIssueDecisionsResponse)(nil), "truedemocracy.QueryIssueDecisionsResponse")
+	gogoproto.RegisterType((*QueryVotingWeightRequest)(nil), "truedemocracy.QueryVotingWeightRequest")
+	gogoproto.RegisterType((*QueryVotingWeightResponse)(nil), "truedemocracy.QueryVotingWeightResponse")
+	gogoproto.RegisterType((*QuerySubDomainsRequest)(nil), "truedemocracy.QuerySubDomainsRequest")
+	gogoproto.RegisterType((*QuerySubDomainsResponse)(nil), "truedemocracy.QuerySubDomainsResponse")
+	gogoproto.RegisterType((*QueryTreasuryPayoutsRequest)(nil), "truedemocracy.QueryTreasuryPayoutsRequest")
+	gogoproto.RegisterType((*QueryTreasuryPayoutsResponse)(nil), "truedemocracy.QueryTreasuryPayoutsResponse")
+	gogoproto.RegisterType((*QueryDomainOptionsHistoryRequest)(nil), "truedemocracy.QueryDomainOptionsHistoryRequest")
+	gogoproto.RegisterType((*QueryDomainOptionsHistoryResponse)(nil), "truedemocracy.QueryDomainOptionsHistoryResponse")
+	gogoproto.RegisterType((*QueryAnonymousSignalsRequest)(nil), "truedemocracy.QueryAnonymousSignalsRequest")
+	gogoproto.RegisterType((*QueryAnonymousSignalsResponse)(nil), "truedemocracy.QueryAnonymousSignalsResponse")
+	gogoproto.RegisterType((*QueryStakeDelegationsRequest)(nil), "truedemocracy.QueryStakeDelegationsRequest")
+	gogoproto.RegisterType((*QueryStakeDelegationsResponse)(nil), "truedemocracy.QueryStakeDelegationsResponse")
+	gogoproto.RegisterType((*QueryPendingUndelegationsRequest)(nil), "truedemocracy.QueryPendingUndelegationsRequest")
+	gogoproto.RegisterType((*QueryPendingUndelegationsResponse)(nil), "truedemocracy.QueryPendingUndelegationsResponse")
+	gogoproto.RegisterType((*QueryStakingRewardsRequest)(nil), "truedemocracy.QueryStakingRewardsRequest")
+	gogoproto.RegisterType((*QueryStakingRewardsResponse)(nil), "truedemocracy.QueryStakingRewardsResponse")
+	gogoproto.RegisterType((*QueryValidatorSetRequest)(nil), "truedemocracy.QueryValidatorSetRequest")
+	gogoproto.RegisterType((*QueryValidatorSetResponse)(nil), "truedemocracy.QueryValidatorSetResponse")
+	gogoproto.RegisterType((*QueryEpochInfoRequest)(nil), "truedemocracy.QueryEpochInfoRequest")
+	gogoproto.RegisterType((*QueryEpochInfoResponse)(nil), "truedemocracy.QueryEpochInfoResponse")
 }
 
 // ---------------------------------------------------------------------------
@@ -204,6 +518,21 @@ type QueryServer interface {
 	ZKPState(context.Context, *QueryZKPStateRequest) (*QueryZKPStateResponse, error)
 	MerkleProof(context.Context, *QueryMerkleProofRequest) (*QueryMerkleProofResponse, error)
 	PayToPut(context.Context, *QueryPayToPutRequest) (*QueryPayToPutResponse, error)
+	DomainIssues(context.Context, *QueryDomainIssuesRequest) (*QueryDomainIssuesResponse, error)
+	IssueSuggestions(context.Context, *QueryIssueSuggestionsRequest) (*QueryIssueSuggestionsResponse, error)
+	DomainMembers(context.Context, *QueryDomainMembersRequest) (*QueryDomainMembersResponse, error)
+	IssueDecision(context.Context, *QueryIssueDecisionRequest) (*QueryIssueDecisionResponse, error)
+	IssueDecisions(context.Context, *QueryIssueDecisionsRequest) (*QueryIssueDecisionsResponse, error)
+	VotingWeight(context.Context, *QueryVotingWeightRequest) (*QueryVotingWeightResponse, error)
+	SubDomains(context.Context, *QuerySubDomainsRequest) (*QuerySubDomainsResponse, error)
+	TreasuryPayouts(context.Context, *QueryTreasuryPayoutsRequest) (*QueryTreasuryPayoutsResponse, error)
+	DomainOptionsHistory(context.Context, *QueryDomainOptionsHistoryRequest) (*QueryDomainOptionsHistoryResponse, error)
+	AnonymousSignals(context.Context, *QueryAnonymousSignalsRequest) (*QueryAnonymousSignalsResponse, error)
+	StakeDelegations(context.Context, *QueryStakeDelegationsRequest) (*QueryStakeDelegationsResponse, error)
+	PendingUndelegations(context.Context, *QueryPendingUndelegationsRequest) (*QueryPendingUndelegationsResponse, error)
+	StakingRewards(context.Context, *QueryStakingRewardsRequest) (*QueryStakingRewardsResponse, error)
+	ValidatorSet(context.Context, *QueryValidatorSetRequest) (*QueryValidatorSetResponse, error)
+	EpochInfo(context.Context, *QueryEpochInfoRequest) (*QueryEpochInfoResponse, error)
 }
 
 var _ QueryServer = Keeper{}
@@ -228,21 +557,58 @@ func (k Keeper) Domain(goCtx context.Context, req *QueryDomainRequest) (*QueryDo
 	return &QueryDomainResponse{Result: bz}, nil
 }
 
+// DomainSummary is one entry of the Domains query: the domain header with
+// entity counts in place of the member and issue lists, which are served by
+// the DomainMembers and DomainIssues queries.
+type DomainSummary struct {
+	Domain
+	MemberCount int `json:"member_count"`
+	IssueCount  int `json:"issue_count"`
+}
+
 func (k Keeper) Domains(goCtx context.Context, req *QueryDomainsRequest) (*QueryDomainsResponse, error) {
+	if req == nil {
+		req = &QueryDomainsRequest{}
+	}
 	ctx := sdk.UnwrapSDKContext(goCtx)
-	var domains []Domain
-	k.IterateDomains(ctx, func(d Domain) bool {
-		domains = append(domains, d)
-		return false
+	kv := ctx.KVStore(k.StoreKey)
+	domains := []DomainSummary{}
+	pageRes, err := query.Paginate(prefix.NewStore(kv, []byte("domain:")), req.Pagination, func(_, value []byte) error {
+		var header Domain
+		if err := k.cdc.UnmarshalLengthPrefixed(value, &header); err != nil {
+			return err
+		}
+		scope := domainScope(header.Name)
+		domains = append(domains, DomainSummary{
+			Domain:      header,
+			MemberCount: domainMembers.count(kv, scope),
+			IssueCount:  domainIssues.count(kv, scope),
+		})
+		return nil
 	})
-	if domains == nil {
-		domains = []Domain{}
+	if err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
 	}
 	bz, err := json.Marshal(domains)
 	if err != nil {
 		return nil, err
 	}
-	return &QueryDomainsResponse{Result: bz}, nil
+	return &QueryDomainsResponse{Result: bz, Pagination: pageRes}, nil
+}
+
+// ValidatorSummary is one entry of the Validator and Validators queries: the
+// validator record with the operator's description, if it set one.
+type ValidatorSummary struct {
+	Validator
+	Description *ValidatorDescription `json:"description,omitempty"`
+}
+
+func (k Keeper) validatorSummary(ctx sdk.Context, val Validator) ValidatorSummary {
+	summary := ValidatorSummary{Validator: val}
+	if description, found := k.GetValidatorDescription(ctx, val.OperatorAddr); found {
+		summary.Description = &description
+	}
+	return summary
 }
 
 func (k Keeper) Validator(goCtx context.Context, req *QueryValidatorRequest) (*QueryValidatorResponse, error) {
@@ -254,7 +620,7 @@ func (k Keeper) Validator(goCtx context.Context, req *QueryValidatorRequest) (*Q
 	if !found {
 		return nil, errorsmod.Wrapf(sdkerrors.ErrKeyNotFound, "validator %s not found", req.OperatorAddr)
 	}
-	bz, err := json.Marshal(val)
+	bz, err := json.Marshal(k.validatorSummary(ctx, val))
 	if err != nil {
 		return nil, err
 	}
@@ -262,20 +628,27 @@ func (k Keeper) Validator(goCtx context.Context, req *QueryValidatorRequest) (*Q
 }
 
 func (k Keeper) Validators(goCtx context.Context, req *QueryValidatorsRequest) (*QueryValidatorsResponse, error) {
+	if req == nil {
+		req = &QueryValidatorsRequest{}
+	}
 	ctx := sdk.UnwrapSDKContext(goCtx)
-	var validators []Validator
-	k.IterateValidators(ctx, func(v Validator) bool {
-		validators = append(validators, v)
-		return false
+	validators := []ValidatorSummary{}
+	pageRes, err := query.Paginate(prefix.NewStore(ctx.KVStore(k.StoreKey), []byte("validator:")), req.Pagination, func(_, value []byte) error {
+		var val Validator
+		if err := k.cdc.UnmarshalLengthPrefixed(value, &val); err != nil {
+			return err
+		}
+		validators = append(validators, k.validatorSummary(ctx, val))
+		return nil
 	})
-	if validators == nil {
-		validators = []Validator{}
+	if err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
 	}
 	bz, err := json.Marshal(validators)
 	if err != nil {
 		return nil, err
 	}
-	return &QueryValidatorsResponse{Result: bz}, nil
+	return &QueryValidatorsResponse{Result: bz, Pagination: pageRes}, nil
 }
 
 func (k Keeper) Nullifier(goCtx context.Context, req *QueryNullifierRequest) (*QueryNullifierResponse, error) {
@@ -320,7 +693,8 @@ func (k Keeper) ZKPState(goCtx context.Context, req *QueryZKPStateRequest) (*Que
 	if !found {
 		return nil, errorsmod.Wrapf(sdkerrors.ErrKeyNotFound, "domain %s not found", req.DomainName)
 	}
-	_, vkFound := k.GetVerifyingKey(ctx)
+	family := domain.Options.hashFamily()
+	vkBytes, vkFound := k.GetFamilyVerifyingKey(ctx, family)
 	rootHistory := domain.MerkleRootHistory
 	if rootHistory == nil {
 		rootHistory = []string{}
@@ -332,6 +706,14 @@ func (k Keeper) ZKPState(goCtx context.Context, req *QueryZKPStateRequest) (*Que
 		CommitmentCount:   len(domain.IdentityCommits),
 		MemberCount:       len(domain.Members),
 		VKInitialized:     vkFound,
+		ZKPCircuitID:      k.GetFamilyCircuitID(ctx, family),
+	}
+	if vkFound {
+		state.VerifyingKeySHA256 = VerifyingKeyFingerprint(vkBytes)
+	}
+	if retiring, ok := k.activeRetiringVerifyingKey(ctx, family); ok {
+		state.RetiringVerifyingKeySHA256 = retiring.VerifyingKeySHA256
+		state.RetiringKeyEndHeight = retiring.EndHeight
 	}
 	bz, err := json.Marshal(state)
 	if err != nil {
@@ -363,55 +745,31 @@ func (k Keeper) MerkleProof(goCtx context.Context, req *QueryMerkleProofRequest)
 	}
 	requestedCommitment := strings.ToLower(req.Commitment)
 	ctx := sdk.UnwrapSDKContext(goCtx)
-	domain, found := k.GetDomain(ctx, req.DomainName)
+	domain, found := k.GetDomainHeader(ctx, req.DomainName)
 	if !found {
 		return nil, errorsmod.Wrapf(sdkerrors.ErrKeyNotFound, "domain %s not found", req.DomainName)
 	}
 	if domain.MerkleRoot == "" {
 		return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "domain %s has no Merkle root", req.DomainName)
 	}
-	leafIndex := -1
-	for i, commitment := range domain.IdentityCommits {
-		if commitment != requestedCommitment {
-			continue
-		}
-		if leafIndex >= 0 {
-			return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "domain %s contains duplicate identity commitment", req.DomainName)
-		}
-		leafIndex = i
-	}
-	if leafIndex < 0 {
+	leafIndex, ok := domainCommits.lookup(ctx.KVStore(k.StoreKey), domainScope(req.DomainName), requestedCommitment)
+	if !ok || requestedCommitment == RevokedIdentityLeaf {
 		return nil, errorsmod.Wrapf(sdkerrors.ErrKeyNotFound, "commitment not found in domain %s", req.DomainName)
 	}
-	leaves := make([][]byte, len(domain.IdentityCommits))
-	for i, commitment := range domain.IdentityCommits {
-		if len(commitment) != 64 {
-			return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "domain %s contains malformed identity commitment at index %d", req.DomainName, i)
-		}
-		b, err := hex.DecodeString(commitment)
-		if err != nil {
-			return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "domain %s contains malformed identity commitment at index %d", req.DomainName, i)
-		}
-		leaves[i] = b
-	}
-	tree := NewMerkleTree(MerkleTreeDepth)
-	if err := tree.BuildFromLeaves(leaves); err != nil {
-		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "failed to rebuild identity Merkle tree: "+err.Error())
+	siblings, pathIndices, root, err := k.merkleProof(ctx, domainIdentityTree(domain), leafIndex)
+	if err != nil {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "domain %s identity tree: %s", req.DomainName, err.Error())
 	}
-	if tree.GetRoot() != domain.MerkleRoot {
+	if hex.EncodeToString(root) != domain.MerkleRoot {
 		return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "domain %s stored Merkle root does not match identity commitments", req.DomainName)
 	}
-	siblings, pathIndices, err := tree.GenerateProof(leafIndex)
-	if err != nil {
-		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "failed to generate Merkle proof: "+err.Error())
-	}
 	pathElements := make([]string, len(siblings))
 	for i, sibling := range siblings {
 		pathElements[i] = hex.EncodeToString(sibling)
 	}
 	result := MerkleProofResult{
 		DomainName:   domain.Name,
-		Commitment:   domain.IdentityCommits[leafIndex],
+		Commitment:   requestedCommitment,
 		Root:         domain.MerkleRoot,
 		PathIndices:  pathIndices,
 		PathElements: pathElements,
@@ -469,116 +827,706 @@ func (k Keeper) PayToPut(goCtx context.Context, req *QueryPayToPutRequest) (*Que
 	return &QueryPayToPutResponse{Result: bz}, nil
 }
 
-// ---------------------------------------------------------------------------
-// gRPC method handlers
-// ---------------------------------------------------------------------------
+// MaxSortedListEntries bounds how many issues or suggestions a sorted list
+// query reads. Sorting needs the whole list in memory, so longer lists are
+// only served in store order, which pages directly over the records.
+const MaxSortedListEntries = 1_000
 
-func _Query_Domain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QueryDomainRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+// IssueSummary is one entry of the DomainIssues query. Suggestions are left
+// out; they are listed per issue by the IssueSuggestions query.
+type IssueSummary struct {
+	Issue
+	SuggestionCount int `json:"suggestion_count"`
+}
+
+// DomainIssues lists a domain's issues without their suggestions. SortBy is
+// empty for creation order, "stones" for most stones first or "activity" for
+// most recent activity first; ties keep creation order. Creation order pages
+// directly over the issue records; the sorted orders are limited to domains
+// with at most MaxSortedListEntries issues.
+func (k Keeper) DomainIssues(goCtx context.Context, req *QueryDomainIssuesRequest) (*QueryDomainIssuesResponse, error) {
+	if req == nil || req.DomainName == "" {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain name is required")
 	}
-	if interceptor == nil {
-		return srv.(QueryServer).Domain(ctx, in)
+	var less func(a, b IssueSummary) bool
+	switch req.SortBy {
+	case "":
+	case "stones":
+		less = func(a, b IssueSummary) bool { return a.Stones > b.Stones }
+	case "activity":
+		less = func(a, b IssueSummary) bool { return a.LastActivityAt > b.LastActivityAt }
+	default:
+		return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "unknown sort order %q (want stones or activity)", req.SortBy)
 	}
-	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/Domain"}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QueryServer).Domain(ctx, req.(*QueryDomainRequest))
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if _, found := k.GetDomainHeader(ctx, req.DomainName); !found {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrKeyNotFound, "domain %s not found", req.DomainName)
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Query_Domains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QueryDomainsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	kv := ctx.KVStore(k.StoreKey)
+	scope := domainScope(req.DomainName)
+	summarize := func(seq uint64, value []byte) IssueSummary {
+		var issue Issue
+		k.cdc.MustUnmarshalLengthPrefixed(value, &issue)
+		return IssueSummary{
+			Issue:           issue,
+			SuggestionCount: domainSuggestions.count(kv, appendSeq(scope, seq)),
+		}
 	}
-	if interceptor == nil {
-		return srv.(QueryServer).Domains(ctx, in)
+
+	issues := []IssueSummary{}
+	var pageRes *query.PageResponse
+	var err error
+	if less == nil {
+		recordPrefix := append([]byte(domainIssues.prefix+":"), scope...)
+		pageRes, err = query.Paginate(prefix.NewStore(kv, recordPrefix), req.Pagination, func(key, value []byte) error {
+			issues = append(issues, summarize(binary.BigEndian.Uint64(key), value))
+			return nil
+		})
+		if err != nil {
+			return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+		}
+	} else {
+		if count := domainIssues.count(kv, scope); count > MaxSortedListEntries {
+			return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest,
+				"domain has %d issues; sorted listing is limited to %d, list in creation order instead", count, MaxSortedListEntries)
+		}
+		domainIssues.iterate(kv, scope, func(seq uint64, value []byte) bool {
+			issues = append(issues, summarize(seq, value))
+			return false
+		})
+		sort.SliceStable(issues, func(i, j int) bool { return less(issues[i], issues[j]) })
+		issues, pageRes, err = paginateSlice(issues, req.Pagination)
+		if err != nil {
+			return nil, err
+		}
 	}
-	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/Domains"}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QueryServer).Domains(ctx, req.(*QueryDomainsRequest))
+	bz, err := json.Marshal(issues)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return &QueryDomainIssuesResponse{Result: bz, Pagination: pageRes}, nil
 }
 
-func _Query_Validator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QueryValidatorRequest)
-	if err := dec(in); err != nil {
+// SuggestionSummary is one entry of the IssueSuggestions query. Ratings are
+// reduced to their count and the consensus score of ComputeSuggestionScore.
+type SuggestionSummary struct {
+	Suggestion
+	Score       int `json:"score"`
+	RatingCount int `json:"rating_count"`
+}
+
+// IssueSuggestions lists an issue's suggestions, optionally filtered by zone
+// color and an inclusive score range. SortBy is empty for submission order or
+// "score" for the RankSuggestionsByScore order. Submission order pages
+// directly over the suggestion records; score order is limited to issues with
+// at most MaxSortedListEntries suggestions.
+func (k Keeper) IssueSuggestions(goCtx context.Context, req *QueryIssueSuggestionsRequest) (*QueryIssueSuggestionsResponse, error) {
+	if req == nil || req.DomainName == "" || req.IssueName == "" {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain name and issue name are required")
+	}
+	minScore, hasMin, err := parseScoreBound("min_score", req.MinScore)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(QueryServer).Validator(ctx, in)
+	maxScore, hasMax, err := parseScoreBound("max_score", req.MaxScore)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/Validator"}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QueryServer).Validator(ctx, req.(*QueryValidatorRequest))
+	if req.SortBy != "" && req.SortBy != "score" {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "unknown sort order %q (want score)", req.SortBy)
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	scope, found := k.issueScope(ctx, req.DomainName, req.IssueName)
+	if !found {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrKeyNotFound, "issue %s not found in domain %s", req.IssueName, req.DomainName)
 	}
-	return interceptor(ctx, in, info, handler)
-}
 
-func _Query_Validators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QueryValidatorsRequest)
-	if err := dec(in); err != nil {
+	kv := ctx.KVStore(k.StoreKey)
+	// summarize reports whether the suggestion passes the filters.
+	summarize := func(seq uint64, value []byte) (SuggestionSummary, bool) {
+		var suggestion Suggestion
+		k.cdc.MustUnmarshalLengthPrefixed(value, &suggestion)
+		if req.Color != "" && suggestion.Color != req.Color {
+			return SuggestionSummary{}, false
+		}
+		summary := SuggestionSummary{Suggestion: suggestion}
+		domainRatings.iterate(kv, appendSeq(scope, seq), func(_ uint64, value []byte) bool {
+			var rating Rating
+			k.cdc.MustUnmarshalLengthPrefixed(value, &rating)
+			summary.Score += weightedRatingValue(rating)
+			summary.RatingCount++
+			return false
+		})
+		if (hasMin && int64(summary.Score) < minScore) || (hasMax && int64(summary.Score) > maxScore) {
+			return SuggestionSummary{}, false
+		}
+		return summary, true
+	}
+
+	suggestions := []SuggestionSummary{}
+	var pageRes *query.PageResponse
+	if req.SortBy == "" {
+		recordPrefix := append([]byte(domainSuggestions.prefix+":"), scope...)
+		pageRes, err = query.FilteredPaginate(prefix.NewStore(kv, recordPrefix), req.Pagination, func(key, value []byte, accumulate bool) (bool, error) {
+			if len(key) != 8 {
+				return false, nil // record of a nested scope sharing this prefix
+			}
+			summary, ok := summarize(binary.BigEndian.Uint64(key), value)
+			if ok && accumulate {
+				suggestions = append(suggestions, summary)
+			}
+			return ok, nil
+		})
+		if err != nil {
+			return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+		}
+	} else {
+		if count := domainSuggestions.count(kv, scope); count > MaxSortedListEntries {
+			return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest,
+				"issue has %d suggestions; sorted listing is limited to %d, list in submission order instead", count, MaxSortedListEntries)
+		}
+		domainSuggestions.iterate(kv, scope, func(seq uint64, value []byte) bool {
+			if summary, ok := summarize(seq, value); ok {
+				suggestions = append(suggestions, summary)
+			}
+			return false
+		})
+		sort.SliceStable(suggestions, func(i, j int) bool {
+			if suggestions[i].Score != suggestions[j].Score {
+				return suggestions[i].Score > suggestions[j].Score
+			}
+			return suggestions[i].Stones > suggestions[j].Stones
+		})
+		suggestions, pageRes, err = paginateSlice(suggestions, req.Pagination)
+		if err != nil {
+			return nil, err
+		}
+	}
+	bz, err := json.Marshal(suggestions)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(QueryServer).Validators(ctx, in)
+	return &QueryIssueSuggestionsResponse{Result: bz, Pagination: pageRes}, nil
+}
+
+func parseScoreBound(field, value string) (int64, bool, error) {
+	if value == "" {
+		return 0, false, nil
 	}
-	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/Validators"}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QueryServer).Validators(ctx, req.(*QueryValidatorsRequest))
+	bound, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "%s must be an integer: %s", field, value)
 	}
-	return interceptor(ctx, in, info, handler)
+	return bound, true, nil
 }
 
-func _Query_Nullifier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QueryNullifierRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+// DomainMembers lists a domain's member addresses in join order, paging
+// directly over the member records.
+func (k Keeper) DomainMembers(goCtx context.Context, req *QueryDomainMembersRequest) (*QueryDomainMembersResponse, error) {
+	if req == nil || req.DomainName == "" {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain name is required")
 	}
-	if interceptor == nil {
-		return srv.(QueryServer).Nullifier(ctx, in)
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if _, found := k.GetDomainHeader(ctx, req.DomainName); !found {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrKeyNotFound, "domain %s not found", req.DomainName)
 	}
-	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/Nullifier"}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QueryServer).Nullifier(ctx, req.(*QueryNullifierRequest))
+	recordPrefix := append([]byte(domainMembers.prefix+":"), domainScope(req.DomainName)...)
+	members := []string{}
+	pageRes, err := query.Paginate(prefix.NewStore(ctx.KVStore(k.StoreKey), recordPrefix), req.Pagination, func(_, value []byte) error {
+		members = append(members, string(value))
+		return nil
+	})
+	if err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
 	}
-	return interceptor(ctx, in, info, handler)
+	bz, err := json.Marshal(members)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryDomainMembersResponse{Result: bz, Pagination: pageRes}, nil
 }
 
-func _Query_PurgeSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QueryPurgeScheduleRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (k Keeper) IssueDecision(goCtx context.Context, req *QueryIssueDecisionRequest) (*QueryIssueDecisionResponse, error) {
+	if req == nil || req.DomainName == "" || req.IssueName == "" {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain name and issue name are required")
 	}
-	if interceptor == nil {
-		return srv.(QueryServer).PurgeSchedule(ctx, in)
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	decision, found := k.GetIssueDecision(ctx, req.DomainName, req.IssueName)
+	if !found {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrKeyNotFound, "issue %s in domain %s has not been decided", req.IssueName, req.DomainName)
 	}
-	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/PurgeSchedule"}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QueryServer).PurgeSchedule(ctx, req.(*QueryPurgeScheduleRequest))
+	bz, err := json.Marshal(decision)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return &QueryIssueDecisionResponse{Result: bz}, nil
 }
 
-func _Query_ZKPState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QueryZKPStateRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+// IssueDecisions lists a domain's recorded decisions in issue name order.
+func (k Keeper) IssueDecisions(goCtx context.Context, req *QueryIssueDecisionsRequest) (*QueryIssueDecisionsResponse, error) {
+	if req == nil || req.DomainName == "" {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain name is required")
 	}
-	if interceptor == nil {
-		return srv.(QueryServer).ZKPState(ctx, in)
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	decisions := []IssueDecision{}
+	decisionStore := prefix.NewStore(ctx.KVStore(k.StoreKey), issueDecisionKey(req.DomainName, ""))
+	pageRes, err := query.Paginate(decisionStore, req.Pagination, func(_, value []byte) error {
+		var decision IssueDecision
+		if err := k.cdc.UnmarshalLengthPrefixed(value, &decision); err != nil {
+			return err
+		}
+		decisions = append(decisions, decision)
+		return nil
+	})
+	if err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
 	}
-	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/ZKPState"}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QueryServer).ZKPState(ctx, req.(*QueryZKPStateRequest))
+	bz, err := json.Marshal(decisions)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return &QueryIssueDecisionsResponse{Result: bz, Pagination: pageRes}, nil
 }
 
-func _Query_MerkleProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+// VotingWeight reports a member's effective voting weight including the
+// votes delegated to them.
+func (k Keeper) VotingWeight(goCtx context.Context, req *QueryVotingWeightRequest) (*QueryVotingWeightResponse, error) {
+	if req == nil || req.DomainName == "" || req.Member == "" {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain name and member are required")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	weight, err := k.GetVotingWeight(ctx, req.DomainName, req.IssueName, req.Member)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := json.Marshal(weight)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryVotingWeightResponse{Result: bz}, nil
+}
+
+// SubDomains lists a domain's direct sub-domains in name order, summarized
+// like Domains.
+func (k Keeper) SubDomains(goCtx context.Context, req *QuerySubDomainsRequest) (*QuerySubDomainsResponse, error) {
+	if req == nil || req.DomainName == "" {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain name is required")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if _, found := k.GetDomainHeader(ctx, req.DomainName); !found {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrKeyNotFound, "domain %s not found", req.DomainName)
+	}
+	kv := ctx.KVStore(k.StoreKey)
+	domains := []DomainSummary{}
+	pageRes, err := query.Paginate(prefix.NewStore(kv, subDomainPrefix(req.DomainName)), req.Pagination, func(_, value []byte) error {
+		header, found := k.GetDomainHeader(ctx, string(value))
+		if !found {
+			return fmt.Errorf("sub-domain %s not found", value)
+		}
+		scope := domainScope(header.Name)
+		domains = append(domains, DomainSummary{
+			Domain:      header,
+			MemberCount: domainMembers.count(kv, scope),
+			IssueCount:  domainIssues.count(kv, scope),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	bz, err := json.Marshal(domains)
+	if err != nil {
+		return nil, err
+	}
+	return &QuerySubDomainsResponse{Result: bz, Pagination: pageRes}, nil
+}
+
+// TreasuryPayouts lists a domain's approved treasury payouts with their
+// payment progress, in issue and suggestion order.
+func (k Keeper) TreasuryPayouts(goCtx context.Context, req *QueryTreasuryPayoutsRequest) (*QueryTreasuryPayoutsResponse, error) {
+	if req == nil || req.DomainName == "" {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain name is required")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if _, found := k.GetDomainHeader(ctx, req.DomainName); !found {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrKeyNotFound, "domain %s not found", req.DomainName)
+	}
+	records := []PayoutRecord{}
+	store := prefix.NewStore(ctx.KVStore(k.StoreKey), payoutPrefix(req.DomainName))
+	pageRes, err := query.Paginate(store, req.Pagination, func(_, value []byte) error {
+		var record PayoutRecord
+		if err := k.cdc.UnmarshalLengthPrefixed(value, &record); err != nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	bz, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryTreasuryPayoutsResponse{Result: bz, Pagination: pageRes}, nil
+}
+
+// DomainOptionsHistory lists a domain's applied options changes, oldest
+// first.
+func (k Keeper) DomainOptionsHistory(goCtx context.Context, req *QueryDomainOptionsHistoryRequest) (*QueryDomainOptionsHistoryResponse, error) {
+	if req == nil || req.DomainName == "" {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain name is required")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if _, found := k.GetDomainHeader(ctx, req.DomainName); !found {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrKeyNotFound, "domain %s not found", req.DomainName)
+	}
+	changes := []DomainOptionsChange{}
+	store := prefix.NewStore(ctx.KVStore(k.StoreKey), optionsHistoryPrefix(req.DomainName))
+	pageRes, err := query.Paginate(store, req.Pagination, func(_, value []byte) error {
+		var change DomainOptionsChange
+		if err := k.cdc.UnmarshalLengthPrefixed(value, &change); err != nil {
+			return err
+		}
+		changes = append(changes, change)
+		return nil
+	})
+	if err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	bz, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryDomainOptionsHistoryResponse{Result: bz, Pagination: pageRes}, nil
+}
+
+// AnonymousSignals lists the signals posted to a topic of a domain, in
+// nullifier order.
+func (k Keeper) AnonymousSignals(goCtx context.Context, req *QueryAnonymousSignalsRequest) (*QueryAnonymousSignalsResponse, error) {
+	if req == nil || req.DomainName == "" || req.Topic == "" {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain name and topic are required")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if _, found := k.GetDomainHeader(ctx, req.DomainName); !found {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrKeyNotFound, "domain %s not found", req.DomainName)
+	}
+	signals := []AnonymousSignal{}
+	store := prefix.NewStore(ctx.KVStore(k.StoreKey), anonSignalPrefix(req.DomainName, req.Topic))
+	pageRes, err := query.Paginate(store, req.Pagination, func(_, value []byte) error {
+		var signal AnonymousSignal
+		if err := k.cdc.UnmarshalLengthPrefixed(value, &signal); err != nil {
+			return err
+		}
+		signals = append(signals, signal)
+		return nil
+	})
+	if err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	bz, err := json.Marshal(signals)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryAnonymousSignalsResponse{Result: bz, Pagination: pageRes}, nil
+}
+
+// StakeDelegations lists the delegations to a validator in delegator order.
+func (k Keeper) StakeDelegations(goCtx context.Context, req *QueryStakeDelegationsRequest) (*QueryStakeDelegationsResponse, error) {
+	if req == nil || req.ValidatorAddr == "" {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "validator address is required")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if _, found := k.GetValidator(ctx, req.ValidatorAddr); !found {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrKeyNotFound, "validator %s not found", req.ValidatorAddr)
+	}
+	delegations := []StakeDelegation{}
+	store := prefix.NewStore(ctx.KVStore(k.StoreKey), validatorDelegationsPrefix(req.ValidatorAddr))
+	pageRes, err := query.Paginate(store, req.Pagination, func(_, value []byte) error {
+		var delegation StakeDelegation
+		if err := k.cdc.UnmarshalLengthPrefixed(value, &delegation); err != nil {
+			return err
+		}
+		delegations = append(delegations, delegation)
+		return nil
+	})
+	if err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	bz, err := json.Marshal(delegations)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryStakeDelegationsResponse{Result: bz, Pagination: pageRes}, nil
+}
+
+// PendingUndelegations lists the undelegation holds of a validator in
+// delegator and creation order. Holds outlive a removed validator, so the
+// validator need not exist.
+func (k Keeper) PendingUndelegations(goCtx context.Context, req *QueryPendingUndelegationsRequest) (*QueryPendingUndelegationsResponse, error) {
+	if req == nil || req.ValidatorAddr == "" {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "validator address is required")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	holds := []PendingUndelegation{}
+	store := prefix.NewStore(ctx.KVStore(k.StoreKey), validatorUndelegationsPrefix(req.ValidatorAddr))
+	pageRes, err := query.Paginate(store, req.Pagination, func(_, value []byte) error {
+		var hold PendingUndelegation
+		if err := k.cdc.UnmarshalLengthPrefixed(value, &hold); err != nil {
+			return err
+		}
+		holds = append(holds, hold)
+		return nil
+	})
+	if err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	bz, err := json.Marshal(holds)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryPendingUndelegationsResponse{Result: bz, Pagination: pageRes}, nil
+}
+
+// StakingRewardsResult is the JSON payload returned by the StakingRewards
+// query. Unclaimed is already settled; Claimable adds what a claim would
+// settle first. Amounts are decimal upnyx.
+type StakingRewardsResult struct {
+	Address      string `json:"address"`
+	WithdrawAddr string `json:"withdraw_addr"`
+	Unclaimed    string `json:"unclaimed"`
+	Claimable    string `json:"claimable"`
+}
+
+// StakingRewards reports an address's staking rewards as operator and, if
+// ValidatorAddr is set, as a delegator of that validator.
+func (k Keeper) StakingRewards(goCtx context.Context, req *QueryStakingRewardsRequest) (*QueryStakingRewardsResponse, error) {
+	if req == nil || req.Address == "" {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "address is required")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	acct := k.GetStakingRewardAccount(ctx, req.Address)
+	result := StakingRewardsResult{
+		Address:      req.Address,
+		WithdrawAddr: acct.WithdrawAddr,
+		Unclaimed:    intOrZero(acct.Unclaimed).String(),
+		Claimable:    k.ClaimableStakingRewards(ctx, req.Address, req.ValidatorAddr).String(),
+	}
+	if result.WithdrawAddr == "" {
+		result.WithdrawAddr = req.Address
+	}
+	bz, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryStakingRewardsResponse{Result: bz}, nil
+}
+
+// ValidatorSet reports the validator-set parameters, the next re-ranking
+// height and the ranked validators, best first, each marked active if it
+// holds a seat. Validators beyond the seated ones form the waiting queue.
+func (k Keeper) ValidatorSet(goCtx context.Context, req *QueryValidatorSetRequest) (*QueryValidatorSetResponse, error) {
+	if req == nil {
+		req = &QueryValidatorSetRequest{}
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	params := k.GetValidatorSetParams(ctx)
+	result := ValidatorSetResult{
+		Params:          params,
+		ActiveCount:     int64(len(k.validatorSetMembers(ctx))),
+		NextEpochHeight: nextValidatorSetEpoch(ctx.BlockHeight(), params.EpochLength),
+		Validators:      []ValidatorSetEntry{},
+	}
+	pageRes, err := query.Paginate(prefix.NewStore(ctx.KVStore(k.StoreKey), []byte(validatorRankPrefix)), req.Pagination, func(_, value []byte) error {
+		val, found := k.GetValidator(ctx, string(value))
+		if !found {
+			return nil
+		}
+		result.Validators = append(result.Validators, ValidatorSetEntry{
+			OperatorAddr: val.OperatorAddr,
+			Power:        val.Power,
+			BondedStake:  validatorBondedStake(val).Int64(),
+			Active:       k.IsValidatorSetMember(ctx, val.OperatorAddr),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	bz, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryValidatorSetResponse{Result: bz, Pagination: pageRes}, nil
+}
+
+// EpochInfo reports the current validator-set epoch, its boundaries, and the
+// member power changes CometBFT receives at the next boundary.
+func (k Keeper) EpochInfo(goCtx context.Context, _ *QueryEpochInfoRequest) (*QueryEpochInfoResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	bz, err := json.Marshal(k.ValidatorSetEpochInfo(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &QueryEpochInfoResponse{Result: bz}, nil
+}
+
+// paginateSlice applies a PageRequest to a list that had to be filtered or
+// sorted in memory. It follows query.Paginate semantics (default limit,
+// offset, count_total, reverse); NextKey carries the next offset as 8
+// big-endian bytes so clients can page with keys as on KV-backed queries.
+func paginateSlice[T any](items []T, req *query.PageRequest) ([]T, *query.PageResponse, error) {
+	if req == nil {
+		req = &query.PageRequest{}
+	}
+	if len(req.Key) > 0 && req.Offset > 0 {
+		return nil, nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "invalid request, either offset or key is expected, got both")
+	}
+	offset := req.Offset
+	if len(req.Key) > 0 {
+		if len(req.Key) != 8 {
+			return nil, nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "invalid pagination key")
+		}
+		offset = binary.BigEndian.Uint64(req.Key)
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = query.DefaultLimit
+	}
+	if req.Reverse {
+		reversed := make([]T, len(items))
+		for i, item := range items {
+			reversed[len(items)-1-i] = item
+		}
+		items = reversed
+	}
+
+	total := uint64(len(items))
+	res := &query.PageResponse{}
+	if req.CountTotal && len(req.Key) == 0 {
+		res.Total = total
+	}
+	if offset >= total {
+		return []T{}, res, nil
+	}
+	end := total
+	if limit < total-offset {
+		end = offset + limit
+		res.NextKey = binary.BigEndian.AppendUint64(nil, end)
+	}
+	return items[offset:end], res, nil
+}
+
+// ---------------------------------------------------------------------------
+// gRPC method handlers
+// ---------------------------------------------------------------------------
+
+func _Query_Domain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Domain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/Domain"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Domain(ctx, req.(*QueryDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Domains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDomainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Domains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/Domains"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Domains(ctx, req.(*QueryDomainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Validator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryValidatorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Validator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/Validator"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Validator(ctx, req.(*QueryValidatorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Validators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryValidatorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Validators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/Validators"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Validators(ctx, req.(*QueryValidatorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Nullifier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryNullifierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Nullifier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/Nullifier"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Nullifier(ctx, req.(*QueryNullifierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_PurgeSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryPurgeScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).PurgeSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/PurgeSchedule"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).PurgeSchedule(ctx, req.(*QueryPurgeScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_ZKPState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryZKPStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).ZKPState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/ZKPState"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).ZKPState(ctx, req.(*QueryZKPStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_MerkleProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(QueryMerkleProofRequest)
 	if err := dec(in); err != nil {
 		return nil, err
@@ -608,6 +1556,231 @@ func _Query_PayToPut_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Query_DomainIssues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDomainIssuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).DomainIssues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/DomainIssues"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).DomainIssues(ctx, req.(*QueryDomainIssuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_IssueSuggestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryIssueSuggestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).IssueSuggestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/IssueSuggestions"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).IssueSuggestions(ctx, req.(*QueryIssueSuggestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_DomainMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDomainMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).DomainMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/DomainMembers"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).DomainMembers(ctx, req.(*QueryDomainMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_IssueDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryIssueDecisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).IssueDecision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/IssueDecision"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).IssueDecision(ctx, req.(*QueryIssueDecisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_IssueDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryIssueDecisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).IssueDecisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/IssueDecisions"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).IssueDecisions(ctx, req.(*QueryIssueDecisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_VotingWeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryVotingWeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).VotingWeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/VotingWeight"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).VotingWeight(ctx, req.(*QueryVotingWeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_SubDomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuerySubDomainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).SubDomains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/SubDomains"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).SubDomains(ctx, req.(*QuerySubDomainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_TreasuryPayouts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryTreasuryPayoutsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).TreasuryPayouts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/TreasuryPayouts"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).TreasuryPayouts(ctx, req.(*QueryTreasuryPayoutsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_DomainOptionsHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDomainOptionsHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).DomainOptionsHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/DomainOptionsHistory"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).DomainOptionsHistory(ctx, req.(*QueryDomainOptionsHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_AnonymousSignals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAnonymousSignalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).AnonymousSignals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/AnonymousSignals"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).AnonymousSignals(ctx, req.(*QueryAnonymousSignalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_StakeDelegations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryStakeDelegationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).StakeDelegations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/StakeDelegations"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).StakeDelegations(ctx, req.(*QueryStakeDelegationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_PendingUndelegations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryPendingUndelegationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).PendingUndelegations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/PendingUndelegations"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).PendingUndelegations(ctx, req.(*QueryPendingUndelegationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_ValidatorSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryValidatorSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).ValidatorSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/ValidatorSet"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).ValidatorSet(ctx, req.(*QueryValidatorSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_EpochInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryEpochInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).EpochInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/EpochInfo"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).EpochInfo(ctx, req.(*QueryEpochInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_StakingRewards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryStakingRewardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).StakingRewards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/truedemocracy.Query/StakingRewards"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).StakingRewards(ctx, req.(*QueryStakingRewardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ---------------------------------------------------------------------------
 // gRPC service registration
 // ---------------------------------------------------------------------------
@@ -629,6 +1802,21 @@ var _Query_serviceDesc = grpc.ServiceDesc{
 		{MethodName: "ZKPState", Handler: _Query_ZKPState_Handler},
 		{MethodName: "MerkleProof", Handler: _Query_MerkleProof_Handler},
 		{MethodName: "PayToPut", Handler: _Query_PayToPut_Handler},
+		{MethodName: "DomainIssues", Handler: _Query_DomainIssues_Handler},
+		{MethodName: "IssueSuggestions", Handler: _Query_IssueSuggestions_Handler},
+		{MethodName: "DomainMembers", Handler: _Query_DomainMembers_Handler},
+		{MethodName: "IssueDecision", Handler: _Query_IssueDecision_Handler},
+		{MethodName: "IssueDecisions", Handler: _Query_IssueDecisions_Handler},
+		{MethodName: "VotingWeight", Handler: _Query_VotingWeight_Handler},
+		{MethodName: "SubDomains", Handler: _Query_SubDomains_Handler},
+		{MethodName: "TreasuryPayouts", Handler: _Query_TreasuryPayouts_Handler},
+		{MethodName: "DomainOptionsHistory", Handler: _Query_DomainOptionsHistory_Handler},
+		{MethodName: "AnonymousSignals", Handler: _Query_AnonymousSignals_Handler},
+		{MethodName: "StakeDelegations", Handler: _Query_StakeDelegations_Handler},
+		{MethodName: "PendingUndelegations", Handler: _Query_PendingUndelegations_Handler},
+		{MethodName: "StakingRewards", Handler: _Query_StakingRewards_Handler},
+		{MethodName: "ValidatorSet", Handler: _Query_ValidatorSet_Handler},
+		{MethodName: "EpochInfo", Handler: _Query_EpochInfo_Handler},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: queryDescriptorFile,
@@ -726,3 +1914,138 @@ func (c *queryClient) PayToPut(ctx context.Context, in *QueryPayToPutRequest) (*
 	}
 	return out, nil
 }
+
+func (c *queryClient) DomainIssues(ctx context.Context, in *QueryDomainIssuesRequest) (*QueryDomainIssuesResponse, error) {
+	out := new(QueryDomainIssuesResponse)
+	err := c.cc.Invoke(ctx, "/truedemocracy.Query/DomainIssues", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) IssueSuggestions(ctx context.Context, in *QueryIssueSuggestionsRequest) (*QueryIssueSuggestionsResponse, error) {
+	out := new(QueryIssueSuggestionsResponse)
+	err := c.cc.Invoke(ctx, "/truedemocracy.Query/IssueSuggestions", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) DomainMembers(ctx context.Context, in *QueryDomainMembersRequest) (*QueryDomainMembersResponse, error) {
+	out := new(QueryDomainMembersResponse)
+	err := c.cc.Invoke(ctx, "/truedemocracy.Query/DomainMembers", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) IssueDecision(ctx context.Context, in *QueryIssueDecisionRequest) (*QueryIssueDecisionResponse, error) {
+	out := new(QueryIssueDecisionResponse)
+	err := c.cc.Invoke(ctx, "/truedemocracy.Query/IssueDecision", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) IssueDecisions(ctx context.Context, in *QueryIssueDecisionsRequest) (*QueryIssueDecisionsResponse, error) {
+	out := new(QueryIssueDecisionsResponse)
+	err := c.cc.Invoke(ctx, "/truedemocracy.Query/IssueDecisions", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) VotingWeight(ctx context.Context, in *QueryVotingWeightRequest) (*QueryVotingWeightResponse, error) {
+	out := new(QueryVotingWeightResponse)
+	err := c.cc.Invoke(ctx, "/truedemocracy.Query/VotingWeight", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) SubDomains(ctx context.Context, in *QuerySubDomainsRequest) (*QuerySubDomainsResponse, error) {
+	out := new(QuerySubDomainsResponse)
+	err := c.cc.Invoke(ctx, "/truedemocracy.Query/SubDomains", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) TreasuryPayouts(ctx context.Context, in *QueryTreasuryPayoutsRequest) (*QueryTreasuryPayoutsResponse, error) {
+	out := new(QueryTreasuryPayoutsResponse)
+	err := c.cc.Invoke(ctx, "/truedemocracy.Query/TreasuryPayouts", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) DomainOptionsHistory(ctx context.Context, in *QueryDomainOptionsHistoryRequest) (*QueryDomainOptionsHistoryResponse, error) {
+	out := new(QueryDomainOptionsHistoryResponse)
+	err := c.cc.Invoke(ctx, "/truedemocracy.Query/DomainOptionsHistory", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) AnonymousSignals(ctx context.Context, in *QueryAnonymousSignalsRequest) (*QueryAnonymousSignalsResponse, error) {
+	out := new(QueryAnonymousSignalsResponse)
+	err := c.cc.Invoke(ctx, "/truedemocracy.Query/AnonymousSignals", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) StakeDelegations(ctx context.Context, in *QueryStakeDelegationsRequest) (*QueryStakeDelegationsResponse, error) {
+	out := new(QueryStakeDelegationsResponse)
+	err := c.cc.Invoke(ctx, "/truedemocracy.Query/StakeDelegations", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) PendingUndelegations(ctx context.Context, in *QueryPendingUndelegationsRequest) (*QueryPendingUndelegationsResponse, error) {
+	out := new(QueryPendingUndelegationsResponse)
+	err := c.cc.Invoke(ctx, "/truedemocracy.Query/PendingUndelegations", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) ValidatorSet(ctx context.Context, in *QueryValidatorSetRequest) (*QueryValidatorSetResponse, error) {
+	out := new(QueryValidatorSetResponse)
+	err := c.cc.Invoke(ctx, "/truedemocracy.Query/ValidatorSet", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) EpochInfo(ctx context.Context, in *QueryEpochInfoRequest) (*QueryEpochInfoResponse, error) {
+	out := new(QueryEpochInfoResponse)
+	err := c.cc.Invoke(ctx, "/truedemocracy.Query/EpochInfo", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) StakingRewards(ctx context.Context, in *QueryStakingRewardsRequest) (*QueryStakingRewardsResponse, error) {
+	out := new(QueryStakingRewardsResponse)
+	err := c.cc.Invoke(ctx, "/truedemocracy.Query/StakingRewards", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -179,11 +179,29 @@ func TestQueryZKPStateEmpty(t *testing.T) {
 	if state.MerkleRoot != "" {
 		t.Fatal("expected empty Merkle root")
 	}
-	if state.VKInitialized {
+	if state.VKInitialized || state.VerifyingKeySHA256 != "" {
 		t.Fatal("VK should not be initialized")
 	}
 }
 
+func TestQueryZKPStateReportsVerifyingKeyFingerprint(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupDomainWithZKPIdentity(t, k, ctx, "VKDomain", 1)
+	vkBytes, _ := k.GetVerifyingKey(ctx)
+
+	resp, err := k.ZKPState(ctx, &QueryZKPStateRequest{DomainName: "VKDomain"})
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	var state ZKPDomainState
+	if err := json.Unmarshal(resp.Result, &state); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if !state.VKInitialized || state.VerifyingKeySHA256 != VerifyingKeyFingerprint(vkBytes) {
+		t.Fatalf("fingerprint = %q, want %q", state.VerifyingKeySHA256, VerifyingKeyFingerprint(vkBytes))
+	}
+}
+
 func TestQueryZKPStateMissingDomain(t *testing.T) {
 	k, ctx := setupKeeper(t)
 
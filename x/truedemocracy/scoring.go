@@ -5,19 +5,26 @@ import "sort"
 // Systemic Consensing scoring (Whitepaper §3.2).
 //
 // Each suggestion has a list of Ratings with values from -5 to +5.
-// The score is the sum of all ratings. The suggestion with the highest
+// The score is the sum of all ratings, open ratings counted once for the
+// rater and once for every member whose delegation they carry. The suggestion with the highest
 // score is the "least resisted" option — the systemic consensus winner.
 
-// ComputeSuggestionScore sums all rating values for a suggestion.
-// Returns 0 if there are no ratings.
+// ComputeSuggestionScore sums all rating values for a suggestion, each
+// multiplied by its weight. Returns 0 if there are no ratings.
 func ComputeSuggestionScore(s Suggestion) int {
 	total := 0
 	for _, r := range s.Ratings {
-		total += r.Value
+		total += weightedRatingValue(r)
 	}
 	return total
 }
 
+// weightedRatingValue is a rating's contribution to a suggestion's score: its
+// value times the delegated weight it was cast with, at least one.
+func weightedRatingValue(r Rating) int {
+	return r.Value * max(r.Weight, 1)
+}
+
 // ScoredSuggestion pairs a suggestion with its computed consensus score.
 type ScoredSuggestion struct {
 	Name   string `json:"name"`
@@ -73,17 +73,20 @@ func (k Keeper) Unjail(ctx sdk.Context, operatorAddr string) error {
 	}
 	val.Jailed = false
 	val.JailedUntil = 0
-	val.Power = val.Stake.AmountOf(PNYXDenom).Int64() / rewards.StakeMin
+	val.Power = validatorPowerFromStake(val)
 	k.SetValidator(ctx, val)
 	k.deleteValidatorSigningInfo(ctx, operatorAddr)
 	return nil
 }
 
+// validatorPowerFromStake derives consensus power from the bonded stake. An
+// operator whose own stake is below StakeMin has no power however much is
+// delegated to it.
 func validatorPowerFromStake(val Validator) int64 {
 	if val.Stake.AmountOf(PNYXDenom).LT(math.NewInt(rewards.StakeMin)) {
 		return 0
 	}
-	return val.Stake.AmountOf(PNYXDenom).Int64() / rewards.StakeMin
+	return validatorBondedStake(val).Int64() / rewards.StakeMin
 }
 
 // handleDoubleSignForRecord applies the economic penalty to either an active
@@ -91,8 +94,7 @@ func validatorPowerFromStake(val Validator) int64 {
 // replay marker so the full ABCI++ batch remains atomic.
 func (k Keeper) handleDoubleSignForRecord(ctx sdk.Context, record ConsensusKeyRecord) (int64, error) {
 	if val, found := k.GetValidator(ctx, record.OperatorAddr); found {
-		before := val.Stake.AmountOf(PNYXDenom)
-		slashed, err := k.slashValidatorStake(ctx, val, SlashFractionDoubleSign)
+		slashed, burned, err := k.slashValidatorStake(ctx, val, SlashFractionDoubleSign)
 		if err != nil {
 			return 0, err
 		}
@@ -101,7 +103,7 @@ func (k Keeper) handleDoubleSignForRecord(ctx sdk.Context, record ConsensusKeyRe
 		slashed.JailedUntil = ctx.BlockTime().Unix() + DowntimeJailDuration*10
 		slashed.Power = validatorPowerFromStake(slashed)
 		k.SetValidator(ctx, slashed)
-		return before.Sub(slashed.Stake.AmountOf(PNYXDenom)).Int64(), nil
+		return burned.Int64(), nil
 	}
 
 	removal, found := k.GetPendingValidatorRemoval(ctx, record.OperatorAddr)
@@ -109,7 +111,7 @@ func (k Keeper) handleDoubleSignForRecord(ctx sdk.Context, record ConsensusKeyRe
 		return 0, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "validator claim not found")
 	}
 	before := removal.Validator.Stake.AmountOf(PNYXDenom)
-	slashed, err := k.slashValidatorStake(ctx, removal.Validator, SlashFractionDoubleSign)
+	slashed, burned, err := k.slashValidatorStake(ctx, removal.Validator, SlashFractionDoubleSign)
 	if err != nil {
 		return 0, err
 	}
@@ -122,7 +124,7 @@ func (k Keeper) handleDoubleSignForRecord(ctx sdk.Context, record ConsensusKeyRe
 		return 0, err
 	}
 	k.SetPendingValidatorRemoval(ctx, removal)
-	return penalty, nil
+	return burned.Int64(), nil
 }
 
 // recordValidatorSignature advances the operator-scoped 100-block rolling
@@ -192,7 +194,7 @@ func (k Keeper) recordValidatorSignature(ctx sdk.Context, operatorAddr string, c
 	}
 
 	if active {
-		slashed, err := k.slashValidatorStake(ctx, val, SlashFractionDowntime)
+		slashed, _, err := k.slashValidatorStake(ctx, val, SlashFractionDowntime)
 		if err != nil {
 			return err
 		}
@@ -204,7 +206,7 @@ func (k Keeper) recordValidatorSignature(ctx sdk.Context, operatorAddr string, c
 		k.SetValidator(ctx, slashed)
 	} else {
 		before := removal.Validator.Stake.AmountOf(PNYXDenom)
-		slashed, err := k.slashValidatorStake(ctx, removal.Validator, SlashFractionDowntime)
+		slashed, _, err := k.slashValidatorStake(ctx, removal.Validator, SlashFractionDowntime)
 		if err != nil {
 			return err
 		}
@@ -228,7 +230,7 @@ func (k Keeper) reducePendingTransferAccounting(ctx sdk.Context, removal Pending
 		return nil
 	}
 	domainName := removal.Validator.Domains[0]
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "pending validator domain %s not found", domainName)
 	}
@@ -236,7 +238,7 @@ func (k Keeper) reducePendingTransferAccounting(ctx sdk.Context, removal Pending
 		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "pending validator transfer accounting underflows")
 	}
 	domain.TransferredStake -= penalty
-	ctx.KVStore(k.StoreKey).Set([]byte("domain:"+domainName), k.cdc.MustMarshalLengthPrefixed(&domain))
+	k.SetDomainHeader(ctx, domain)
 	return nil
 }
 
@@ -255,22 +257,25 @@ func slashStake(val Validator, pct int64) Validator {
 	return val
 }
 
-// slashValidatorStake removes the slashed claim from validator stake and burns
-// the same amount from module escrow. The whitepaper requires slashed PNYX to
-// leave circulation; crediting it to an admin-withdrawable domain treasury
-// would let a colluding validator recover the penalty.
-func (k Keeper) slashValidatorStake(ctx sdk.Context, val Validator, pct int64) (Validator, error) {
+// slashValidatorStake removes the slashed claim from validator stake, slashes
+// its delegations and held undelegations pro rata, and burns the total from
+// module escrow. The whitepaper requires slashed PNYX to leave circulation;
+// crediting it to an admin-withdrawable domain treasury would let a colluding
+// validator recover the penalty.
+func (k Keeper) slashValidatorStake(ctx sdk.Context, val Validator, pct int64) (Validator, math.Int, error) {
 	if err := requireBankKeeper(k.bankKeeper); err != nil {
-		return Validator{}, err
+		return Validator{}, math.Int{}, err
 	}
 
 	before := val.Stake.AmountOf(PNYXDenom)
 	val = slashStake(val, pct)
 	penalty := before.Sub(val.Stake.AmountOf(PNYXDenom))
+	val, delegated := k.slashDelegations(ctx, val, pct)
+	penalty = penalty.Add(delegated)
 	if penalty.IsPositive() {
 		if err := k.issuer.Burn(ctx, penalty); err != nil {
-			return Validator{}, errorsmod.Wrap(err, "validator slash burn failed")
+			return Validator{}, math.Int{}, errorsmod.Wrap(err, "validator slash burn failed")
 		}
 	}
-	return val, nil
+	return val, penalty, nil
 }
@@ -44,9 +44,7 @@ func TestHandleDoubleSignBelowMinimum(t *testing.T) {
 	k.CreateDomain(ctx, "D", sdk.AccAddress("a"), sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 500_000*PNYXUnit)))
 	domain, _ := k.GetDomain(ctx, "D")
 	domain.Members = append(domain.Members, "lowval")
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:D"), bz)
+	k.SetDomain(ctx, domain)
 
 	// Register with exactly minimum stake.
 	pk := testPubKey("lowval")
@@ -212,9 +210,7 @@ func TestUnjailFailsNotDomainMember(t *testing.T) {
 	// Remove oper1 from domain.
 	domain, _ := k.GetDomain(ctx, "TestDomain")
 	domain.Members = []string{domain.Admin.String()} // only admin
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:TestDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	err := k.Unjail(ctx, "oper1")
 	if err == nil {
@@ -0,0 +1,464 @@
+package truedemocracy
+
+import (
+	"encoding/binary"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Third-party stake delegation. Delegated upnyx is escrowed in the module
+// account like the operator's own stake:
+//   "stake-delegation:{validator}:{delegator}"          → StakeDelegation
+//   "stake-unbonding:{validator}:{delegator}:{height}"  → PendingUndelegation
+//   "stake-unbonding-due:{due}{hold key}"               → empty (schedule)
+//
+// {due} is the 8-byte big-endian height at which a hold next needs
+// EndBlock's attention: its retirement height until the retirement is
+// observed, then the first height past its release height. EndBlock walks
+// only the holds that are due; one whose release time has not passed yet
+// stays due until it has.
+//
+// A validator's DelegatedStake caches the sum of its delegations, so power
+// and escrow parity never scan them. Delegating never makes the delegator a
+// validator: power still belongs to an operator that is a domain member, and
+// the operator's own stake must meet StakeMin on its own.
+
+const (
+	stakeDelegationPrefix        = "stake-delegation:"
+	pendingUndelegationPrefix    = "stake-unbonding:"
+	pendingUndelegationDuePrefix = "stake-unbonding-due:"
+)
+
+func validatorDelegationsPrefix(validatorAddr string) []byte {
+	return []byte(stakeDelegationPrefix + validatorAddr + ":")
+}
+
+func stakeDelegationKey(validatorAddr, delegatorAddr string) []byte {
+	return append(validatorDelegationsPrefix(validatorAddr), delegatorAddr...)
+}
+
+func validatorUndelegationsPrefix(validatorAddr string) []byte {
+	return []byte(pendingUndelegationPrefix + validatorAddr + ":")
+}
+
+func pendingUndelegationKey(validatorAddr, delegatorAddr string, createdHeight int64) []byte {
+	key := append(validatorUndelegationsPrefix(validatorAddr), delegatorAddr+":"...)
+	return binary.BigEndian.AppendUint64(key, uint64(createdHeight))
+}
+
+func pendingUndelegationDueKey(hold PendingUndelegation) []byte {
+	key := binary.BigEndian.AppendUint64([]byte(pendingUndelegationDuePrefix), uint64(undelegationDueHeight(hold)))
+	return append(key, pendingUndelegationKey(hold.ValidatorAddr, hold.DelegatorAddr, hold.CreatedHeight)...)
+}
+
+// undelegationDueHeight is the height from which ProcessPendingUndelegations
+// has work on a hold.
+func undelegationDueHeight(hold PendingUndelegation) int64 {
+	if hold.ConsensusRetiredAtNanos == 0 {
+		return hold.ConsensusRetiredHeight
+	}
+	return hold.ReleaseAfterHeight + 1
+}
+
+// validatorBondedStake is the stake behind a validator's power: its own bond
+// plus everything delegated to it.
+func validatorBondedStake(val Validator) math.Int {
+	return val.Stake.AmountOf(PNYXDenom).AddRaw(val.DelegatedStake)
+}
+
+// validateCommissionBps checks a commission rate in basis points.
+func validateCommissionBps(bps int64) error {
+	if bps < 0 || bps > MaxCommissionBps {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "commission must be 0..%d basis points", MaxCommissionBps)
+	}
+	return nil
+}
+
+// setValidatorCommission sets the share of delegator rewards the operator
+// keeps.
+func (k Keeper) setValidatorCommission(ctx sdk.Context, operatorAddr string, bps int64) error {
+	if err := validateCommissionBps(bps); err != nil {
+		return err
+	}
+	val, found := k.GetValidator(ctx, operatorAddr)
+	if !found {
+		return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "validator not found")
+	}
+	val.CommissionBps = bps
+	k.SetValidator(ctx, val)
+	return nil
+}
+
+// GetStakeDelegation returns a delegator's bond to a validator.
+func (k Keeper) GetStakeDelegation(ctx sdk.Context, validatorAddr, delegatorAddr string) (StakeDelegation, bool) {
+	bz := ctx.KVStore(k.StoreKey).Get(stakeDelegationKey(validatorAddr, delegatorAddr))
+	if bz == nil {
+		return StakeDelegation{}, false
+	}
+	var delegation StakeDelegation
+	k.cdc.MustUnmarshalLengthPrefixed(bz, &delegation)
+	return delegation, true
+}
+
+// setStakeDelegation credits what the stored delegation earned and stores
+// the new one from the validator's current reward index.
+func (k Keeper) setStakeDelegation(ctx sdk.Context, delegation StakeDelegation) {
+	rs := k.settleDelegationRewards(ctx, delegation.ValidatorAddr, delegation.DelegatorAddr)
+	delegation.RewardIndex = rs.DelegatorIndex
+	k.storeStakeDelegation(ctx, delegation)
+}
+
+// storeStakeDelegation writes a delegation as is, deleting it once it is
+// empty.
+func (k Keeper) storeStakeDelegation(ctx sdk.Context, delegation StakeDelegation) {
+	store := ctx.KVStore(k.StoreKey)
+	key := stakeDelegationKey(delegation.ValidatorAddr, delegation.DelegatorAddr)
+	if delegation.Amount <= 0 {
+		store.Delete(key)
+		return
+	}
+	store.Set(key, k.cdc.MustMarshalLengthPrefixed(&delegation))
+}
+
+func (k Keeper) iterateStakeDelegations(ctx sdk.Context, prefix []byte, fn func(StakeDelegation) bool) {
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var delegation StakeDelegation
+		k.cdc.MustUnmarshalLengthPrefixed(iter.Value(), &delegation)
+		if fn(delegation) {
+			return
+		}
+	}
+}
+
+// IterateStakeDelegations visits every delegation in validator, then
+// delegator order. Returning true stops iteration.
+func (k Keeper) IterateStakeDelegations(ctx sdk.Context, fn func(StakeDelegation) bool) {
+	k.iterateStakeDelegations(ctx, []byte(stakeDelegationPrefix), fn)
+}
+
+// validatorDelegations returns the delegations to one validator.
+func (k Keeper) validatorDelegations(ctx sdk.Context, validatorAddr string) []StakeDelegation {
+	var delegations []StakeDelegation
+	k.iterateStakeDelegations(ctx, validatorDelegationsPrefix(validatorAddr), func(delegation StakeDelegation) bool {
+		delegations = append(delegations, delegation)
+		return false
+	})
+	return delegations
+}
+
+func (k Keeper) setPendingUndelegation(ctx sdk.Context, hold PendingUndelegation) {
+	k.deletePendingUndelegation(ctx, hold)
+	store := ctx.KVStore(k.StoreKey)
+	store.Set(
+		pendingUndelegationKey(hold.ValidatorAddr, hold.DelegatorAddr, hold.CreatedHeight),
+		k.cdc.MustMarshalLengthPrefixed(&hold),
+	)
+	store.Set(pendingUndelegationDueKey(hold), []byte{})
+}
+
+// deletePendingUndelegation removes the stored hold with hold's key and its
+// schedule entry.
+func (k Keeper) deletePendingUndelegation(ctx sdk.Context, hold PendingUndelegation) {
+	store := ctx.KVStore(k.StoreKey)
+	key := pendingUndelegationKey(hold.ValidatorAddr, hold.DelegatorAddr, hold.CreatedHeight)
+	bz := store.Get(key)
+	if bz == nil {
+		return
+	}
+	var stored PendingUndelegation
+	k.cdc.MustUnmarshalLengthPrefixed(bz, &stored)
+	store.Delete(pendingUndelegationDueKey(stored))
+	store.Delete(key)
+}
+
+func (k Keeper) iteratePendingUndelegations(ctx sdk.Context, prefix []byte, fn func(PendingUndelegation) bool) {
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var hold PendingUndelegation
+		k.cdc.MustUnmarshalLengthPrefixed(iter.Value(), &hold)
+		if fn(hold) {
+			return
+		}
+	}
+}
+
+// IteratePendingUndelegations visits every undelegation hold in validator,
+// delegator and creation order. Returning true stops iteration.
+func (k Keeper) IteratePendingUndelegations(ctx sdk.Context, fn func(PendingUndelegation) bool) {
+	k.iteratePendingUndelegations(ctx, []byte(pendingUndelegationPrefix), fn)
+}
+
+// validatorHasDomainMembership reports whether the operator is still a member
+// of at least one of the validator's domains.
+func (k Keeper) validatorHasDomainMembership(ctx sdk.Context, val Validator) bool {
+	for _, domainName := range val.Domains {
+		if k.IsDomainMember(ctx, domainName, val.OperatorAddr) {
+			return true
+		}
+	}
+	return false
+}
+
+// DelegateStakeWithEscrow bonds the sender's upnyx to a validator and moves
+// the coins into module escrow. The validator must be unjailed and its
+// operator still a domain member; operators bond through their own stake.
+func (k Keeper) DelegateStakeWithEscrow(ctx sdk.Context, sender sdk.AccAddress, validatorAddr string, amount sdk.Coins) error {
+	if err := requireBankKeeper(k.bankKeeper); err != nil {
+		return err
+	}
+	if sender.Empty() {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidAddress, "delegator address is required")
+	}
+	if err := validatePNYXCoins(amount, "delegation amount"); err != nil {
+		return err
+	}
+	delegatorAddr := sender.String()
+	if delegatorAddr == validatorAddr {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "operators bond through their own validator stake")
+	}
+	val, found := k.GetValidator(ctx, validatorAddr)
+	if !found {
+		return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "validator not found")
+	}
+	if val.Jailed {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "cannot delegate to a jailed validator")
+	}
+	if !k.validatorHasDomainMembership(ctx, val) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "validator operator is no longer a domain member")
+	}
+	bonded := validatorBondedStake(val).Add(amount.AmountOf(PNYXDenom))
+	if !bonded.IsInt64() {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "validator stake exceeds supported range")
+	}
+	added := amount.AmountOf(PNYXDenom).Int64()
+
+	cacheCtx, write := ctx.CacheContext()
+	delegation, _ := k.GetStakeDelegation(cacheCtx, validatorAddr, delegatorAddr)
+	delegation.DelegatorAddr = delegatorAddr
+	delegation.ValidatorAddr = validatorAddr
+	delegation.Amount += added
+	k.setStakeDelegation(cacheCtx, delegation)
+	val.DelegatedStake += added
+	val.Power = validatorPowerFromStake(val)
+	k.SetValidator(cacheCtx, val)
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(cacheCtx, sender, ModuleName, amount); err != nil {
+		return errorsmod.Wrap(err, "delegation escrow transfer failed")
+	}
+	write()
+	return nil
+}
+
+// stakeReportHeight returns the block whose validator updates drop stake
+// removed from a validator now. Set members report stake changes only at
+// epoch boundaries, so their stake keeps its consensus power until the next
+// one; other validators have no power left to report.
+func (k Keeper) stakeReportHeight(ctx sdk.Context, validatorAddr string) int64 {
+	if !k.IsValidatorSetMember(ctx, validatorAddr) || k.IsValidatorSetEpochBoundary(ctx) {
+		return ctx.BlockHeight()
+	}
+	return nextValidatorSetEpoch(ctx.BlockHeight(), k.GetValidatorSetParams(ctx).EpochLength)
+}
+
+// newPendingUndelegation opens an evidence-window hold for stake that stops
+// backing the validator at removalHeight.
+func newPendingUndelegation(ctx sdk.Context, delegatorAddr, validatorAddr string, amount, removalHeight int64) (PendingUndelegation, error) {
+	retiredHeight, releaseHeight, err := evidenceHoldHeights(ctx, removalHeight)
+	if err != nil {
+		return PendingUndelegation{}, err
+	}
+	return PendingUndelegation{
+		DelegatorAddr:          delegatorAddr,
+		ValidatorAddr:          validatorAddr,
+		Amount:                 amount,
+		CreatedHeight:          ctx.BlockHeight(),
+		ConsensusRetiredHeight: retiredHeight,
+		ReleaseAfterHeight:     releaseHeight,
+	}, nil
+}
+
+// holdUndelegation adds stake to the delegator's hold for the current block.
+// A merged hold retires with the later of its parts.
+func (k Keeper) holdUndelegation(ctx sdk.Context, delegatorAddr, validatorAddr string, amount, removalHeight int64) error {
+	hold, err := newPendingUndelegation(ctx, delegatorAddr, validatorAddr, amount, removalHeight)
+	if err != nil {
+		return err
+	}
+	if bz := ctx.KVStore(k.StoreKey).Get(pendingUndelegationKey(validatorAddr, delegatorAddr, ctx.BlockHeight())); bz != nil {
+		var existing PendingUndelegation
+		k.cdc.MustUnmarshalLengthPrefixed(bz, &existing)
+		hold.Amount += existing.Amount
+		hold.ConsensusRetiredHeight = max(hold.ConsensusRetiredHeight, existing.ConsensusRetiredHeight)
+		hold.ReleaseAfterHeight = max(hold.ReleaseAfterHeight, existing.ReleaseAfterHeight)
+	}
+	k.setPendingUndelegation(ctx, hold)
+	return nil
+}
+
+// UndelegateStake removes stake from a validator at once and holds it in
+// escrow, still slashable, until the consensus evidence window has expired.
+// For a set member the window starts at the next epoch boundary, when
+// CometBFT learns of the lower power.
+func (k Keeper) UndelegateStake(ctx sdk.Context, sender sdk.AccAddress, validatorAddr string, amount int64) error {
+	if sender.Empty() {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidAddress, "delegator address is required")
+	}
+	if amount <= 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "undelegation amount must be positive")
+	}
+	delegatorAddr := sender.String()
+	delegation, found := k.GetStakeDelegation(ctx, validatorAddr, delegatorAddr)
+	if !found {
+		return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "delegation not found")
+	}
+	if amount > delegation.Amount {
+		return errorsmod.Wrapf(sdkerrors.ErrInsufficientFunds, "undelegate %d exceeds delegation %d", amount, delegation.Amount)
+	}
+	val, found := k.GetValidator(ctx, validatorAddr)
+	if !found {
+		return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "validator not found")
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	if err := k.holdUndelegation(cacheCtx, delegatorAddr, validatorAddr, amount, k.stakeReportHeight(ctx, validatorAddr)); err != nil {
+		return err
+	}
+	delegation.Amount -= amount
+	k.setStakeDelegation(cacheCtx, delegation)
+	val.DelegatedStake -= amount
+	if !val.Jailed {
+		val.Power = validatorPowerFromStake(val)
+	}
+	k.SetValidator(cacheCtx, val)
+	write()
+	return nil
+}
+
+// unbondValidatorDelegations moves every delegation of an exiting validator
+// into an evidence-window hold and clears its DelegatedStake. The validator
+// leaves the set in the current block.
+func (k Keeper) unbondValidatorDelegations(ctx sdk.Context, val Validator) (Validator, error) {
+	for _, delegation := range k.validatorDelegations(ctx, val.OperatorAddr) {
+		if err := k.holdUndelegation(ctx, delegation.DelegatorAddr, delegation.ValidatorAddr, delegation.Amount, ctx.BlockHeight()); err != nil {
+			return Validator{}, err
+		}
+		delegation.Amount = 0
+		k.setStakeDelegation(ctx, delegation)
+	}
+	val.DelegatedStake = 0
+	return val, nil
+}
+
+// slashDelegations cuts every delegation to the validator and every
+// undelegation still held for it by pct percent, rounding each penalty down.
+// It returns the validator with its reduced DelegatedStake and the total
+// penalty, which the caller burns.
+func (k Keeper) slashDelegations(ctx sdk.Context, val Validator, pct int64) (Validator, math.Int) {
+	penalty := math.ZeroInt()
+	cut := func(amount int64) int64 {
+		return math.NewInt(amount).MulRaw(pct).QuoRaw(100).Int64()
+	}
+	for _, delegation := range k.validatorDelegations(ctx, val.OperatorAddr) {
+		slashed := cut(delegation.Amount)
+		delegation.Amount -= slashed
+		k.setStakeDelegation(ctx, delegation)
+		val.DelegatedStake -= slashed
+		penalty = penalty.AddRaw(slashed)
+	}
+	var holds []PendingUndelegation
+	k.iteratePendingUndelegations(ctx, validatorUndelegationsPrefix(val.OperatorAddr), func(hold PendingUndelegation) bool {
+		holds = append(holds, hold)
+		return false
+	})
+	for _, hold := range holds {
+		slashed := cut(hold.Amount)
+		hold.Amount -= slashed
+		if hold.Amount > 0 {
+			k.setPendingUndelegation(ctx, hold)
+		} else {
+			k.deletePendingUndelegation(ctx, hold)
+		}
+		penalty = penalty.AddRaw(slashed)
+	}
+	return val, penalty
+}
+
+func observeUndelegationRetirement(ctx sdk.Context, hold PendingUndelegation) (PendingUndelegation, error) {
+	if hold.ConsensusRetiredAtNanos != 0 || ctx.BlockHeight() < hold.ConsensusRetiredHeight {
+		return hold, nil
+	}
+	retiredAt, releaseAt, err := evidenceHoldRelease(ctx)
+	if err != nil {
+		return PendingUndelegation{}, err
+	}
+	hold.ConsensusRetiredAtNanos = retiredAt
+	hold.ReleaseAfterTimeNanos = releaseAt
+	return hold, nil
+}
+
+// ProcessPendingUndelegations observes the retirement block of each due
+// undelegation hold and pays out mature holds to their delegators, under the
+// same rule as ProcessPendingValidatorRemovals.
+func (k Keeper) ProcessPendingUndelegations(ctx sdk.Context) error {
+	if err := requireBankKeeper(k.bankKeeper); err != nil {
+		return err
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	holds := k.dueUndelegations(cacheCtx)
+
+	for _, stored := range holds {
+		hold, err := observeUndelegationRetirement(cacheCtx, stored)
+		if err != nil {
+			return err
+		}
+		if hold.ConsensusRetiredAtNanos == 0 ||
+			cacheCtx.BlockHeight() <= hold.ReleaseAfterHeight ||
+			cacheCtx.BlockTime().UnixNano() <= hold.ReleaseAfterTimeNanos {
+			if hold.ConsensusRetiredAtNanos != stored.ConsensusRetiredAtNanos {
+				k.setPendingUndelegation(cacheCtx, hold)
+			}
+			continue
+		}
+
+		delegator, err := sdk.AccAddressFromBech32(hold.DelegatorAddr)
+		if err != nil {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidAddress, "pending undelegation delegator is invalid")
+		}
+		coins := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, hold.Amount))
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(cacheCtx, ModuleName, delegator, coins); err != nil {
+			return errorsmod.Wrap(err, "pending undelegation payout failed")
+		}
+		k.deletePendingUndelegation(cacheCtx, hold)
+	}
+
+	write()
+	return nil
+}
+
+// dueUndelegations returns the holds scheduled at or before the current
+// height, in schedule order.
+func (k Keeper) dueUndelegations(ctx sdk.Context) []PendingUndelegation {
+	store := ctx.KVStore(k.StoreKey)
+	prefix := []byte(pendingUndelegationDuePrefix)
+	end := binary.BigEndian.AppendUint64(append([]byte{}, prefix...), uint64(ctx.BlockHeight())+1)
+	var keys [][]byte
+	iter := store.Iterator(prefix, end)
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, append([]byte{}, iter.Key()[len(prefix)+8:]...))
+	}
+	iter.Close()
+
+	holds := make([]PendingUndelegation, 0, len(keys))
+	for _, key := range keys {
+		var hold PendingUndelegation
+		k.cdc.MustUnmarshalLengthPrefixed(store.Get(key), &hold)
+		holds = append(holds, hold)
+	}
+	return holds
+}
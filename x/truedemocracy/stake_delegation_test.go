@@ -0,0 +1,545 @@
+package truedemocracy
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	rewards "truerepublic/treasury/keeper"
+)
+
+// setupDelegationValidator registers an escrowed validator with exactly
+// StakeMin and funds a delegator with five times that.
+func setupDelegationValidator(t *testing.T) (Keeper, sdk.Context, *mockBankKeeper, sdk.AccAddress, sdk.AccAddress) {
+	t.Helper()
+	k, ctx, bank := setupKeeperWithBank(t)
+	ctx = withEvidenceWindow(ctx.WithBlockHeight(100), 5, 10*time.Minute)
+	operator := sdk.AccAddress("delegation-operator")
+	delegator := sdk.AccAddress("delegation-holder")
+	initial := int64(1_000 * PNYXUnit)
+	bank.fundAccount(operator, sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, initial+rewards.StakeMin)))
+	bank.fundAccount(delegator, sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 5*rewards.StakeMin)))
+
+	if err := k.CreateDomainWithEscrow(ctx, "Delegated", operator, sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, initial))); err != nil {
+		t.Fatal(err)
+	}
+	stake := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, rewards.StakeMin))
+	if err := k.RegisterValidatorWithEscrow(ctx, operator, operator.String(), testPubKey("delegation-operator"), stake, "Delegated"); err != nil {
+		t.Fatal(err)
+	}
+	return k, ctx, bank, operator, delegator
+}
+
+func delegate(t *testing.T, k Keeper, ctx sdk.Context, delegator sdk.AccAddress, validatorAddr string, amount int64) {
+	t.Helper()
+	if err := k.DelegateStakeWithEscrow(ctx, delegator, validatorAddr, sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, amount))); err != nil {
+		t.Fatalf("delegate: %v", err)
+	}
+}
+
+func TestDelegateStakeBacksValidatorPower(t *testing.T) {
+	k, ctx, bank, operator, delegator := setupDelegationValidator(t)
+	moduleBefore := moduleBalance(bank)
+
+	delegate(t, k, ctx, delegator, operator.String(), 2*rewards.StakeMin)
+	val, _ := k.GetValidator(ctx, operator.String())
+	if val.DelegatedStake != 2*rewards.StakeMin || val.Power != 3 {
+		t.Fatalf("validator after delegation = delegated %d power %d, want %d and 3", val.DelegatedStake, val.Power, 2*rewards.StakeMin)
+	}
+	if val.Stake.AmountOf(PNYXDenom).Int64() != rewards.StakeMin {
+		t.Fatal("delegation changed the operator's own stake")
+	}
+	delegation, found := k.GetStakeDelegation(ctx, operator.String(), delegator.String())
+	if !found || delegation.Amount != 2*rewards.StakeMin {
+		t.Fatalf("delegation = %+v, found %v", delegation, found)
+	}
+	if got := moduleBalance(bank); got != moduleBefore+2*rewards.StakeMin {
+		t.Fatalf("module balance = %d, want %d", got, moduleBefore+2*rewards.StakeMin)
+	}
+	if err := k.ValidateEscrowParity(ctx); err != nil {
+		t.Fatalf("parity after delegation: %v", err)
+	}
+
+	one := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, rewards.StakeMin))
+	if err := k.DelegateStakeWithEscrow(ctx, operator, operator.String(), one); err == nil {
+		t.Fatal("operator delegated to its own validator")
+	}
+	if err := k.DelegateStakeWithEscrow(ctx, delegator, sdk.AccAddress("nobody").String(), one); err == nil {
+		t.Fatal("delegation to an unknown validator accepted")
+	}
+
+	jailed := val
+	jailed.Jailed = true
+	k.SetValidator(ctx, jailed)
+	if err := k.DelegateStakeWithEscrow(ctx, delegator, operator.String(), one); err == nil {
+		t.Fatal("delegation to a jailed validator accepted")
+	}
+	k.SetValidator(ctx, val)
+
+	domain, _ := k.GetDomain(ctx, "Delegated")
+	domain.Members = nil
+	k.SetDomain(ctx, domain)
+	balance := accountBalance(bank, delegator)
+	if err := k.DelegateStakeWithEscrow(ctx, delegator, operator.String(), one); err == nil {
+		t.Fatal("delegation to a non-member operator accepted")
+	}
+	if got := accountBalance(bank, delegator); got != balance {
+		t.Fatalf("rejected delegation debited the delegator: %d, want %d", got, balance)
+	}
+}
+
+func TestUndelegateStakeHoldsUntilEvidenceWindowExpires(t *testing.T) {
+	k, ctx, bank, operator, delegator := setupDelegationValidator(t)
+	delegate(t, k, ctx, delegator, operator.String(), 2*rewards.StakeMin)
+	balance := accountBalance(bank, delegator)
+
+	if err := k.UndelegateStake(ctx, delegator, operator.String(), 3*rewards.StakeMin); err == nil {
+		t.Fatal("undelegation beyond the delegation accepted")
+	}
+	if err := k.UndelegateStake(ctx, delegator, operator.String(), rewards.StakeMin); err != nil {
+		t.Fatalf("undelegate: %v", err)
+	}
+	val, _ := k.GetValidator(ctx, operator.String())
+	if val.DelegatedStake != rewards.StakeMin || val.Power != 2 {
+		t.Fatalf("validator after undelegation = delegated %d power %d", val.DelegatedStake, val.Power)
+	}
+	if got := accountBalance(bank, delegator); got != balance {
+		t.Fatal("undelegation paid out before the evidence window")
+	}
+	if err := k.ValidateEscrowParity(ctx); err != nil {
+		t.Fatalf("parity during undelegation hold: %v", err)
+	}
+
+	var holds []PendingUndelegation
+	k.IteratePendingUndelegations(ctx, func(hold PendingUndelegation) bool {
+		holds = append(holds, hold)
+		return false
+	})
+	if len(holds) != 1 || holds[0].Amount != rewards.StakeMin || holds[0].CreatedHeight != 100 {
+		t.Fatalf("holds = %+v", holds)
+	}
+	hold := holds[0]
+
+	retirementCtx := ctx.WithBlockHeight(hold.ConsensusRetiredHeight).WithBlockTime(ctx.BlockTime().Add(time.Minute))
+	if err := k.ProcessPendingUndelegations(retirementCtx); err != nil {
+		t.Fatal(err)
+	}
+	k.IteratePendingUndelegations(retirementCtx, func(observed PendingUndelegation) bool {
+		hold = observed
+		return true
+	})
+	if hold.ConsensusRetiredAtNanos == 0 || hold.ReleaseAfterTimeNanos <= hold.ConsensusRetiredAtNanos {
+		t.Fatalf("retirement not observed: %+v", hold)
+	}
+
+	early := retirementCtx.WithBlockHeight(hold.ReleaseAfterHeight + 1)
+	if err := k.ProcessPendingUndelegations(early); err != nil {
+		t.Fatal(err)
+	}
+	if got := accountBalance(bank, delegator); got != balance {
+		t.Fatal("undelegation released before the evidence duration expired")
+	}
+
+	release := early.WithBlockTime(time.Unix(0, hold.ReleaseAfterTimeNanos+1))
+	if err := k.ProcessPendingUndelegations(release); err != nil {
+		t.Fatal(err)
+	}
+	if got := accountBalance(bank, delegator); got != balance+rewards.StakeMin {
+		t.Fatalf("delegator balance after release = %d, want %d", got, balance+rewards.StakeMin)
+	}
+	k.IteratePendingUndelegations(release, func(PendingUndelegation) bool {
+		t.Fatal("released hold remained")
+		return true
+	})
+	if err := k.ValidateEscrowParity(release); err != nil {
+		t.Fatalf("parity after release: %v", err)
+	}
+
+	if err := k.UndelegateStake(release, delegator, operator.String(), rewards.StakeMin); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := k.GetStakeDelegation(release, operator.String(), delegator.String()); found {
+		t.Fatal("empty delegation was kept")
+	}
+}
+
+func TestUndelegationFromMemberRetiresAtNextEpoch(t *testing.T) {
+	k, ctx, bank, operator, delegator := setupDelegationValidator(t)
+	seatValidator(k, ctx, operator.String())
+	delegate(t, k, ctx, delegator, operator.String(), 2*rewards.StakeMin)
+	balance := accountBalance(bank, delegator)
+	epochLength := k.GetValidatorSetParams(ctx).EpochLength
+
+	// Mid-epoch the member keeps reporting its old power until the boundary.
+	midEpoch := ctx.WithBlockHeight(ctx.BlockHeight() + epochLength/2)
+	if err := k.UndelegateStake(midEpoch, delegator, operator.String(), rewards.StakeMin); err != nil {
+		t.Fatal(err)
+	}
+	boundary := nextValidatorSetEpoch(midEpoch.BlockHeight(), epochLength)
+	var hold PendingUndelegation
+	k.IteratePendingUndelegations(midEpoch, func(stored PendingUndelegation) bool {
+		hold = stored
+		return true
+	})
+	wantRetired, wantRelease, err := validatorRetirementHeight(boundary, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hold.ConsensusRetiredHeight != wantRetired || hold.ReleaseAfterHeight != wantRelease {
+		t.Fatalf("hold retires at %d, releases after %d; want %d, %d", hold.ConsensusRetiredHeight, hold.ReleaseAfterHeight, wantRetired, wantRelease)
+	}
+
+	// Blocks before the boundary's update takes effect do not start the window.
+	beforeBoundary := midEpoch.WithBlockHeight(boundary).WithBlockTime(midEpoch.BlockTime().Add(time.Hour))
+	if err := k.ProcessPendingUndelegations(beforeBoundary); err != nil {
+		t.Fatal(err)
+	}
+	k.IteratePendingUndelegations(beforeBoundary, func(stored PendingUndelegation) bool {
+		hold = stored
+		return true
+	})
+	if hold.ConsensusRetiredAtNanos != 0 {
+		t.Fatal("retirement observed before the epoch boundary reached CometBFT")
+	}
+
+	// After the boundary the evidence window runs as usual.
+	retired := beforeBoundary.WithBlockHeight(wantRetired)
+	if err := k.ProcessPendingUndelegations(retired); err != nil {
+		t.Fatal(err)
+	}
+	release := retired.WithBlockHeight(wantRelease + 1).WithBlockTime(retired.BlockTime().Add(11 * time.Minute))
+	if err := k.ProcessPendingUndelegations(release); err != nil {
+		t.Fatal(err)
+	}
+	if got := accountBalance(bank, delegator); got != balance+rewards.StakeMin {
+		t.Fatalf("delegator balance after release = %d, want %d", got, balance+rewards.StakeMin)
+	}
+
+	// At a boundary the lower power is reported in the same block.
+	if err := k.UndelegateStake(release.WithBlockHeight(2*boundary), delegator, operator.String(), rewards.StakeMin); err != nil {
+		t.Fatal(err)
+	}
+	k.IteratePendingUndelegations(release, func(stored PendingUndelegation) bool {
+		hold = stored
+		return true
+	})
+	if want, _, _ := validatorRetirementHeight(2*boundary, 5); hold.ConsensusRetiredHeight != want {
+		t.Fatalf("boundary hold retires at %d, want %d", hold.ConsensusRetiredHeight, want)
+	}
+}
+
+func TestUndelegationScheduleWalksOnlyDueHolds(t *testing.T) {
+	k, ctx, _, operator, delegator := setupDelegationValidator(t)
+	delegate(t, k, ctx, delegator, operator.String(), 2*rewards.StakeMin)
+	if err := k.UndelegateStake(ctx, delegator, operator.String(), rewards.StakeMin); err != nil {
+		t.Fatal(err)
+	}
+	var hold PendingUndelegation
+	k.IteratePendingUndelegations(ctx, func(stored PendingUndelegation) bool {
+		hold = stored
+		return true
+	})
+	due := func(height int64) int {
+		return len(k.dueUndelegations(ctx.WithBlockHeight(height)))
+	}
+	if due(hold.ConsensusRetiredHeight-1) != 0 || due(hold.ConsensusRetiredHeight) != 1 {
+		t.Fatal("hold not scheduled at its retirement height")
+	}
+
+	retired := ctx.WithBlockHeight(hold.ConsensusRetiredHeight).WithBlockTime(ctx.BlockTime().Add(time.Minute))
+	if err := k.ProcessPendingUndelegations(retired); err != nil {
+		t.Fatal(err)
+	}
+	if due(hold.ReleaseAfterHeight) != 0 || due(hold.ReleaseAfterHeight+1) != 1 {
+		t.Fatal("observed hold not rescheduled past its release height")
+	}
+}
+
+func TestDoubleSignSlashesDelegationsAndHoldsProRata(t *testing.T) {
+	k, ctx, bank, operator, delegator := setupDelegationValidator(t)
+	other := sdk.AccAddress("delegation-other")
+	bank.fundAccount(other, sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, rewards.StakeMin)))
+	delegate(t, k, ctx, delegator, operator.String(), 2*rewards.StakeMin)
+	delegate(t, k, ctx, other, operator.String(), rewards.StakeMin)
+	if err := k.UndelegateStake(ctx, delegator, operator.String(), rewards.StakeMin); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := k.HandleDoubleSign(ctx, testPubKey("delegation-operator")); err != nil {
+		t.Fatal(err)
+	}
+	cut := func(amount int64) int64 { return amount - amount*SlashFractionDoubleSign/100 }
+	val, _ := k.GetValidator(ctx, operator.String())
+	if got := val.Stake.AmountOf(PNYXDenom).Int64(); got != cut(rewards.StakeMin) {
+		t.Fatalf("operator stake = %d, want %d", got, cut(rewards.StakeMin))
+	}
+	for _, address := range []sdk.AccAddress{delegator, other} {
+		delegation, _ := k.GetStakeDelegation(ctx, operator.String(), address.String())
+		if delegation.Amount != cut(rewards.StakeMin) {
+			t.Fatalf("delegation of %s = %d, want %d", address, delegation.Amount, cut(rewards.StakeMin))
+		}
+	}
+	if val.DelegatedStake != 2*cut(rewards.StakeMin) || !val.Jailed || val.Power != 0 {
+		t.Fatalf("slashed validator = %+v", val)
+	}
+	k.IteratePendingUndelegations(ctx, func(hold PendingUndelegation) bool {
+		if hold.Amount != cut(rewards.StakeMin) {
+			t.Fatalf("held undelegation = %d, want %d", hold.Amount, cut(rewards.StakeMin))
+		}
+		return false
+	})
+	if got, want := bank.burned.AmountOf(PNYXDenom).Int64(), 4*(rewards.StakeMin-cut(rewards.StakeMin)); got != want {
+		t.Fatalf("burned = %d, want %d", got, want)
+	}
+	if err := k.ValidateEscrowParity(ctx); err != nil {
+		t.Fatalf("parity after slash: %v", err)
+	}
+
+	// A jailed validator keeps its delegations, which can still leave.
+	if err := k.UndelegateStake(ctx, other, operator.String(), cut(rewards.StakeMin)); err != nil {
+		t.Fatal(err)
+	}
+	if val, _ := k.GetValidator(ctx, operator.String()); val.Power != 0 {
+		t.Fatal("undelegation restored power to a jailed validator")
+	}
+}
+
+func TestStakingRewardsAreSharedAfterCommission(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	first := sdk.AccAddress("reward-delegator-a").String()
+	second := sdk.AccAddress("reward-delegator-b").String()
+	val := Validator{
+		OperatorAddr:   "reward-operator",
+		Stake:          sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 1_000)),
+		DelegatedStake: 3_000,
+		CommissionBps:  1_000,
+	}
+	k.SetValidator(ctx, val)
+	seatValidator(k, ctx, val.OperatorAddr)
+	k.setStakeDelegation(ctx, StakeDelegation{DelegatorAddr: first, ValidatorAddr: val.OperatorAddr, Amount: 1_000})
+	k.setStakeDelegation(ctx, StakeDelegation{DelegatorAddr: second, ValidatorAddr: val.OperatorAddr, Amount: 2_000})
+
+	// Delegators back 3/4 of 400: 300, less 10% commission, leaves 270.
+	k.addStakingRewards(ctx, math.NewInt(400))
+	if got := k.ClaimableStakingRewards(ctx, first, val.OperatorAddr); got.Int64() != 90 {
+		t.Fatalf("first delegator reward = %s, want 90", got)
+	}
+	if got := k.ClaimableStakingRewards(ctx, second, val.OperatorAddr); got.Int64() != 180 {
+		t.Fatalf("second delegator reward = %s, want 180", got)
+	}
+	if got := k.ClaimableStakingRewards(ctx, val.OperatorAddr, ""); got.Int64() != 130 {
+		t.Fatalf("operator reward = %s, want 130", got)
+	}
+	a, _ := k.GetStakeDelegation(ctx, val.OperatorAddr, first)
+	stored, _ := k.GetValidator(ctx, val.OperatorAddr)
+	if a.Amount != 1_000 || stored.DelegatedStake != 3_000 || stored.Stake.AmountOf(PNYXDenom).Int64() != 1_000 {
+		t.Fatal("rewards compounded into stake")
+	}
+}
+
+func TestValidatorExitMovesDelegationsToHolds(t *testing.T) {
+	k, ctx, bank, operator, delegator := setupDelegationValidator(t)
+	delegate(t, k, ctx, delegator, operator.String(), 2*rewards.StakeMin)
+	balance := accountBalance(bank, delegator)
+	domain, _ := k.GetDomain(ctx, "Delegated")
+	domain.TotalPayouts = rewards.StakeMin * 10
+	k.SetDomain(ctx, domain)
+
+	if err := k.RemoveValidatorWithEscrow(ctx, operator, operator.String()); err != nil {
+		t.Fatal(err)
+	}
+	removal, _ := k.GetPendingValidatorRemoval(ctx, operator.String())
+	if removal.Validator.DelegatedStake != 0 {
+		t.Fatal("removal snapshot kept the delegations")
+	}
+	if _, found := k.GetStakeDelegation(ctx, operator.String(), delegator.String()); found {
+		t.Fatal("delegation survived the validator exit")
+	}
+	var held int64
+	k.IteratePendingUndelegations(ctx, func(hold PendingUndelegation) bool {
+		held += hold.Amount
+		return false
+	})
+	if held != 2*rewards.StakeMin {
+		t.Fatalf("held = %d, want %d", held, 2*rewards.StakeMin)
+	}
+	if err := k.ValidateEscrowParity(ctx); err != nil {
+		t.Fatalf("parity after exit: %v", err)
+	}
+
+	// Double signing inside the evidence window still reaches the holds.
+	if err := k.HandleDoubleSign(ctx, testPubKey("delegation-operator")); err != nil {
+		t.Fatal(err)
+	}
+	k.IteratePendingUndelegations(ctx, func(hold PendingUndelegation) bool {
+		held = hold.Amount
+		return true
+	})
+	if want := 2*rewards.StakeMin - 2*rewards.StakeMin*SlashFractionDoubleSign/100; held != want {
+		t.Fatalf("held after slash = %d, want %d", held, want)
+	}
+	if err := k.ValidateEscrowParity(ctx); err != nil {
+		t.Fatalf("parity after slashing the exit: %v", err)
+	}
+
+	release := ctx.WithBlockHeight(ctx.BlockHeight() + 100).WithBlockTime(ctx.BlockTime().Add(time.Hour))
+	if err := k.ProcessPendingUndelegations(ctx.WithBlockHeight(ctx.BlockHeight() + 10)); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.ProcessPendingUndelegations(release); err != nil {
+		t.Fatal(err)
+	}
+	if got := accountBalance(bank, delegator); got != balance+held {
+		t.Fatalf("delegator balance = %d, want %d", got, balance+held)
+	}
+}
+
+func TestStakeDelegationGenesisRoundTrip(t *testing.T) {
+	am1, k1, ctx1 := setupModuleForGenesis(t)
+	admin := sdk.AccAddress("genesis-delegation-admin")
+	k1.CreateDomain(ctx1, "Backed", admin, sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 500_000*PNYXUnit)))
+	operator := sdk.AccAddress("genesis-delegation-operator").String()
+	if err := k1.AddMember(ctx1, "Backed", operator, admin); err != nil {
+		t.Fatal(err)
+	}
+	stake := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, rewards.StakeMin))
+	if err := k1.RegisterValidator(ctx1, operator, testPubKey("genesis-delegation"), stake, "Backed"); err != nil {
+		t.Fatal(err)
+	}
+	if err := k1.setValidatorCommission(ctx1, operator, 500); err != nil {
+		t.Fatal(err)
+	}
+	delegator := sdk.AccAddress("genesis-delegator").String()
+	val, _ := k1.GetValidator(ctx1, operator)
+	val.DelegatedStake = rewards.StakeMin
+	val.Power = validatorPowerFromStake(val)
+	k1.SetValidator(ctx1, val)
+	k1.setStakeDelegation(ctx1, StakeDelegation{DelegatorAddr: delegator, ValidatorAddr: operator, Amount: rewards.StakeMin})
+	hold := PendingUndelegation{
+		DelegatorAddr: delegator, ValidatorAddr: operator, Amount: 7,
+		CreatedHeight: 3, ConsensusRetiredHeight: 5, ReleaseAfterHeight: 9,
+	}
+	k1.setPendingUndelegation(ctx1, hold)
+
+	exported := am1.ExportGenesis(ctx1, nil)
+	var genesis GenesisState
+	if err := json.Unmarshal(exported, &genesis); err != nil {
+		t.Fatal(err)
+	}
+	if len(genesis.StakeDelegations) != 1 || len(genesis.PendingUndelegations) != 1 ||
+		genesis.Validators[0].DelegatedStake != rewards.StakeMin || genesis.Validators[0].CommissionBps != 500 ||
+		genesis.Validators[0].Power != 2 {
+		t.Fatalf("exported delegation state = %+v", genesis)
+	}
+	claims, err := GenesisEscrowClaims(genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := math.NewInt(500_000*PNYXUnit + 2*rewards.StakeMin + 7); !claims.Equal(want) {
+		t.Fatalf("claims = %s, want %s", claims, want)
+	}
+
+	am2, k2, ctx2 := setupModuleForGenesis(t)
+	updates := am2.InitGenesis(ctx2, nil, exported)
+	if len(updates) != 1 || updates[0].Power != 2 {
+		t.Fatalf("updates = %v", updates)
+	}
+	restored, _ := k2.GetValidator(ctx2, operator)
+	if restored.DelegatedStake != rewards.StakeMin || restored.CommissionBps != 500 {
+		t.Fatalf("restored validator = %+v", restored)
+	}
+	if got, found := k2.GetStakeDelegation(ctx2, operator, delegator); !found || got.Amount != rewards.StakeMin {
+		t.Fatalf("restored delegation = %+v", got)
+	}
+	var holds []PendingUndelegation
+	k2.IteratePendingUndelegations(ctx2, func(h PendingUndelegation) bool {
+		holds = append(holds, h)
+		return false
+	})
+	if len(holds) != 1 || holds[0] != hold {
+		t.Fatalf("restored holds = %+v", holds)
+	}
+}
+
+func TestValidateGenesisStateRejectsMalformedStakeDelegations(t *testing.T) {
+	delegator := sdk.AccAddress("genesis-delegator").String()
+	valid := func() GenesisState {
+		genesis := validActiveInactiveGenesis()
+		genesis.Validators[0].DelegatedStake = rewards.StakeMin
+		genesis.Validators[0].Power = 3
+		genesis.StakeDelegations = []StakeDelegation{{
+			DelegatorAddr: delegator, ValidatorAddr: genesis.Validators[0].OperatorAddr, Amount: rewards.StakeMin,
+		}}
+		genesis.PendingUndelegations = []PendingUndelegation{{
+			DelegatorAddr: delegator, ValidatorAddr: genesis.Validators[1].OperatorAddr, Amount: 5,
+			CreatedHeight: 1, ConsensusRetiredHeight: 2, ReleaseAfterHeight: 3,
+		}}
+		return genesis
+	}
+	if err := ValidateGenesisState(valid()); err != nil {
+		t.Fatalf("valid delegation genesis rejected: %v", err)
+	}
+
+	for name, mutate := range map[string]func(*GenesisState){
+		"power ignores delegation": func(g *GenesisState) { g.Validators[0].Power = 2 },
+		"sum mismatch":             func(g *GenesisState) { g.Validators[0].DelegatedStake++ },
+		"negative delegated":       func(g *GenesisState) { g.Validators[1].DelegatedStake = -1 },
+		"commission too high":      func(g *GenesisState) { g.Validators[0].CommissionBps = MaxCommissionBps + 1 },
+		"unknown validator": func(g *GenesisState) {
+			g.StakeDelegations[0].ValidatorAddr = sdk.AccAddress("nobody").String()
+		},
+		"self delegation": func(g *GenesisState) {
+			g.StakeDelegations[0].DelegatorAddr = g.StakeDelegations[0].ValidatorAddr
+		},
+		"duplicate delegation": func(g *GenesisState) {
+			half := g.StakeDelegations[0]
+			half.Amount /= 2
+			g.StakeDelegations = []StakeDelegation{half, half}
+		},
+		"zero hold":          func(g *GenesisState) { g.PendingUndelegations[0].Amount = 0 },
+		"hold heights":       func(g *GenesisState) { g.PendingUndelegations[0].ConsensusRetiredHeight = 1 },
+		"hold times":         func(g *GenesisState) { g.PendingUndelegations[0].ConsensusRetiredAtNanos = 1 },
+		"invalid hold owner": func(g *GenesisState) { g.PendingUndelegations[0].DelegatorAddr = "delegator" },
+		"duplicate hold": func(g *GenesisState) {
+			g.PendingUndelegations = append(g.PendingUndelegations, g.PendingUndelegations[0])
+		},
+	} {
+		genesis := valid()
+		mutate(&genesis)
+		if err := ValidateGenesisState(genesis); err == nil {
+			t.Errorf("%s accepted", name)
+		}
+	}
+}
+
+func TestMsgDelegateStakeValidateBasic(t *testing.T) {
+	delegator := sdk.AccAddress("msg-delegator")
+	validatorAddr := sdk.AccAddress("msg-validator").String()
+	amount := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 10))
+	if err := (MsgDelegateStake{Sender: delegator, ValidatorAddr: validatorAddr, Amount: amount}).ValidateBasic(); err != nil {
+		t.Fatalf("valid delegation rejected: %v", err)
+	}
+	for name, msg := range map[string]MsgDelegateStake{
+		"no sender":    {ValidatorAddr: validatorAddr, Amount: amount},
+		"no validator": {Sender: delegator, Amount: amount},
+		"self":         {Sender: delegator, ValidatorAddr: delegator.String(), Amount: amount},
+		"wrong denom":  {Sender: delegator, ValidatorAddr: validatorAddr, Amount: sdk.NewCoins(sdk.NewInt64Coin("uatom", 10))},
+	} {
+		if err := msg.ValidateBasic(); err == nil {
+			t.Errorf("%s accepted", name)
+		}
+	}
+	if err := (MsgUndelegateStake{Sender: delegator, ValidatorAddr: validatorAddr}).ValidateBasic(); err == nil {
+		t.Error("zero undelegation accepted")
+	}
+	register := MsgRegisterValidator{
+		Sender: delegator, OperatorAddr: delegator.String(), PubKey: "ab", DomainName: "D",
+		Stake: amount, CommissionBps: MaxCommissionBps + 1,
+	}
+	if err := register.ValidateBasic(); err == nil {
+		t.Error("commission above 100% accepted")
+	}
+}
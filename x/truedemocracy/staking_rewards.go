@@ -0,0 +1,370 @@
+package truedemocracy
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Staking rewards accrue lazily through an F1-style cumulative index:
+//   "pod:reward-pool"           → StakingRewardPool
+//   "pod-rewards:{operator}"    → ValidatorRewards
+//   "staking-reward:{address}"  → StakingRewardAccount
+//
+// Each reward interval mints the reward for all bonded stake at once and
+// raises the pool index by reward/bonded, so EndBlock writes nothing per
+// validator. Only unjailed members of the validator set earn: a validator
+// waiting for a seat secures nothing. A validator is settled against the
+// pool whenever SetValidator replaces it or it joins or leaves the set: the
+// reward on its own stake and its commission are credited to the operator,
+// and the rest raises the validator's delegator index. A
+// delegation is settled against that index whenever its amount changes or it
+// is claimed. Every settlement rounds down and the dust stays in Outstanding,
+// so escrow parity stays exact.
+
+// RewardIndexScale is the fixed-point scale of the reward indexes.
+var RewardIndexScale = math.NewIntWithDecimal(1, 18)
+
+const (
+	stakingRewardPoolKey       = "pod:reward-pool"
+	validatorRewardsPrefix     = "pod-rewards:"
+	stakingRewardAccountPrefix = "staking-reward:"
+)
+
+func validatorRewardsKey(operatorAddr string) []byte {
+	return []byte(validatorRewardsPrefix + operatorAddr)
+}
+
+func stakingRewardAccountKey(address string) []byte {
+	return []byte(stakingRewardAccountPrefix + address)
+}
+
+// intOrZero reads a math.Int that may be missing from JSON genesis.
+func intOrZero(i math.Int) math.Int {
+	if i.IsNil() {
+		return math.ZeroInt()
+	}
+	return i
+}
+
+// validatorEarningStake is the stake a validator contributes to the pool's
+// BondedStake: its bonded stake while it is an unjailed set member, nothing
+// otherwise.
+func validatorEarningStake(val Validator, member bool) math.Int {
+	if val.Jailed || !member {
+		return math.ZeroInt()
+	}
+	return validatorBondedStake(val)
+}
+
+// earningStake is validatorEarningStake at the validator's stored set
+// membership.
+func (k Keeper) earningStake(ctx sdk.Context, val Validator) math.Int {
+	return validatorEarningStake(val, k.IsValidatorSetMember(ctx, val.OperatorAddr))
+}
+
+// getStakingRewardPool returns the reward pool. A store that predates the
+// pool derives its BondedStake from the stored validators once.
+func (k Keeper) getStakingRewardPool(ctx sdk.Context) StakingRewardPool {
+	bz := ctx.KVStore(k.StoreKey).Get([]byte(stakingRewardPoolKey))
+	if bz == nil {
+		bonded := math.ZeroInt()
+		k.IterateValidators(ctx, func(val Validator) bool {
+			bonded = bonded.Add(k.earningStake(ctx, val))
+			return false
+		})
+		return StakingRewardPool{Index: math.ZeroInt(), BondedStake: bonded, Outstanding: math.ZeroInt()}
+	}
+	var pool StakingRewardPool
+	k.cdc.MustUnmarshalLengthPrefixed(bz, &pool)
+	return pool
+}
+
+func (k Keeper) setStakingRewardPool(ctx sdk.Context, pool StakingRewardPool) {
+	ctx.KVStore(k.StoreKey).Set([]byte(stakingRewardPoolKey), k.cdc.MustMarshalLengthPrefixed(&pool))
+}
+
+// GetValidatorRewards returns how far a validator has been settled.
+func (k Keeper) GetValidatorRewards(ctx sdk.Context, operatorAddr string) (ValidatorRewards, bool) {
+	bz := ctx.KVStore(k.StoreKey).Get(validatorRewardsKey(operatorAddr))
+	if bz == nil {
+		return ValidatorRewards{}, false
+	}
+	var rs ValidatorRewards
+	k.cdc.MustUnmarshalLengthPrefixed(bz, &rs)
+	return rs, true
+}
+
+func (k Keeper) setValidatorRewards(ctx sdk.Context, rs ValidatorRewards) {
+	ctx.KVStore(k.StoreKey).Set(validatorRewardsKey(rs.OperatorAddr), k.cdc.MustMarshalLengthPrefixed(&rs))
+}
+
+// IterateValidatorRewards visits every validator reward record in operator
+// order. Returning true stops iteration.
+func (k Keeper) IterateValidatorRewards(ctx sdk.Context, fn func(ValidatorRewards) bool) {
+	prefix := []byte(validatorRewardsPrefix)
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var rs ValidatorRewards
+		k.cdc.MustUnmarshalLengthPrefixed(iter.Value(), &rs)
+		if fn(rs) {
+			return
+		}
+	}
+}
+
+// validatorRewardsOrNew returns a validator's reward record, starting a
+// missing one at the current pool index.
+func (k Keeper) validatorRewardsOrNew(ctx sdk.Context, pool StakingRewardPool, operatorAddr string) ValidatorRewards {
+	if rs, found := k.GetValidatorRewards(ctx, operatorAddr); found {
+		return rs
+	}
+	return ValidatorRewards{OperatorAddr: operatorAddr, PoolIndex: pool.Index, DelegatorIndex: math.ZeroInt()}
+}
+
+// GetStakingRewardAccount returns an address's settled rewards; an address
+// that never earned any has an empty account.
+func (k Keeper) GetStakingRewardAccount(ctx sdk.Context, address string) StakingRewardAccount {
+	bz := ctx.KVStore(k.StoreKey).Get(stakingRewardAccountKey(address))
+	if bz == nil {
+		return StakingRewardAccount{Address: address, Unclaimed: math.ZeroInt()}
+	}
+	var acct StakingRewardAccount
+	k.cdc.MustUnmarshalLengthPrefixed(bz, &acct)
+	return acct
+}
+
+// setStakingRewardAccount stores an account, deleting it once it holds
+// nothing and pays the address itself.
+func (k Keeper) setStakingRewardAccount(ctx sdk.Context, acct StakingRewardAccount) {
+	store := ctx.KVStore(k.StoreKey)
+	key := stakingRewardAccountKey(acct.Address)
+	if !intOrZero(acct.Unclaimed).IsPositive() && acct.WithdrawAddr == "" {
+		store.Delete(key)
+		return
+	}
+	store.Set(key, k.cdc.MustMarshalLengthPrefixed(&acct))
+}
+
+// IterateStakingRewardAccounts visits every reward account in address order.
+// Returning true stops iteration.
+func (k Keeper) IterateStakingRewardAccounts(ctx sdk.Context, fn func(StakingRewardAccount) bool) {
+	prefix := []byte(stakingRewardAccountPrefix)
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var acct StakingRewardAccount
+		k.cdc.MustUnmarshalLengthPrefixed(iter.Value(), &acct)
+		if fn(acct) {
+			return
+		}
+	}
+}
+
+func (k Keeper) creditStakingReward(ctx sdk.Context, address string, amount math.Int) {
+	if !amount.IsPositive() {
+		return
+	}
+	acct := k.GetStakingRewardAccount(ctx, address)
+	acct.Unclaimed = intOrZero(acct.Unclaimed).Add(amount)
+	k.setStakingRewardAccount(ctx, acct)
+}
+
+// accrueValidatorRewards advances a validator's record to the pool index. It
+// returns the advanced record and the operator's share: the reward on its own
+// stake plus its commission on the delegators' reward. A jailed validator,
+// or one outside the set, earns nothing for the period.
+func accrueValidatorRewards(pool StakingRewardPool, val Validator, member bool, rs ValidatorRewards) (ValidatorRewards, math.Int) {
+	delta := pool.Index.Sub(intOrZero(rs.PoolIndex))
+	rs.PoolIndex = pool.Index
+	rs.DelegatorIndex = intOrZero(rs.DelegatorIndex)
+	if val.Jailed || !member || !delta.IsPositive() {
+		return rs, math.ZeroInt()
+	}
+	delegatorDelta := delta.MulRaw(MaxCommissionBps - val.CommissionBps).QuoRaw(MaxCommissionBps)
+	rs.DelegatorIndex = rs.DelegatorIndex.Add(delegatorDelta)
+	operator := val.Stake.AmountOf(PNYXDenom).Mul(delta).
+		Add(math.NewInt(val.DelegatedStake).Mul(delta.Sub(delegatorDelta))).
+		Quo(RewardIndexScale)
+	return rs, operator
+}
+
+// delegationReward is what a delegation earned since it was last settled.
+func delegationReward(rs ValidatorRewards, delegation StakeDelegation) math.Int {
+	return math.NewInt(delegation.Amount).
+		Mul(intOrZero(rs.DelegatorIndex).Sub(intOrZero(delegation.RewardIndex))).
+		Quo(RewardIndexScale)
+}
+
+// settleValidatorRewards brings a stored validator up to the pool index and
+// credits the operator's share.
+func (k Keeper) settleValidatorRewards(ctx sdk.Context, val Validator) ValidatorRewards {
+	pool := k.getStakingRewardPool(ctx)
+	member := k.IsValidatorSetMember(ctx, val.OperatorAddr)
+	rs, reward := accrueValidatorRewards(pool, val, member, k.validatorRewardsOrNew(ctx, pool, val.OperatorAddr))
+	k.creditStakingReward(ctx, val.OperatorAddr, reward)
+	k.setValidatorRewards(ctx, rs)
+	return rs
+}
+
+// trackValidatorRewards runs before SetValidator replaces a validator: it
+// settles the stored record at the old stake and moves the pool's
+// BondedStake to the new one.
+func (k Keeper) trackValidatorRewards(ctx sdk.Context, val Validator) {
+	pool := k.getStakingRewardPool(ctx)
+	if old, found := k.GetValidator(ctx, val.OperatorAddr); found {
+		k.settleValidatorRewards(ctx, old)
+		pool.BondedStake = pool.BondedStake.Sub(k.earningStake(ctx, old))
+	} else {
+		k.setValidatorRewards(ctx, k.validatorRewardsOrNew(ctx, pool, val.OperatorAddr))
+	}
+	pool.BondedStake = pool.BondedStake.Add(k.earningStake(ctx, val))
+	k.setStakingRewardPool(ctx, pool)
+}
+
+// trackValidatorSetRewards wraps a change of a validator's set membership:
+// it settles the stored validator at its old membership and moves the pool's
+// BondedStake to the new one.
+func (k Keeper) trackValidatorSetRewards(ctx sdk.Context, operatorAddr string, change func()) {
+	val, found := k.GetValidator(ctx, operatorAddr)
+	if !found {
+		change()
+		return
+	}
+	k.settleValidatorRewards(ctx, val)
+	pool := k.getStakingRewardPool(ctx)
+	pool.BondedStake = pool.BondedStake.Sub(k.earningStake(ctx, val))
+	change()
+	pool.BondedStake = pool.BondedStake.Add(k.earningStake(ctx, val))
+	k.setStakingRewardPool(ctx, pool)
+}
+
+// untrackValidatorRewards runs before RemoveValidator deletes a validator: it
+// pays the operator's last share and drops the validator from the pool.
+func (k Keeper) untrackValidatorRewards(ctx sdk.Context, val Validator) {
+	k.settleValidatorRewards(ctx, val)
+	pool := k.getStakingRewardPool(ctx)
+	pool.BondedStake = pool.BondedStake.Sub(k.earningStake(ctx, val))
+	k.setStakingRewardPool(ctx, pool)
+	ctx.KVStore(k.StoreKey).Delete(validatorRewardsKey(val.OperatorAddr))
+}
+
+// settleDelegationRewards credits what a stored delegation earned and
+// returns the validator record its new RewardIndex is taken from.
+func (k Keeper) settleDelegationRewards(ctx sdk.Context, validatorAddr, delegatorAddr string) ValidatorRewards {
+	rs, found := k.GetValidatorRewards(ctx, validatorAddr)
+	if val, ok := k.GetValidator(ctx, validatorAddr); ok {
+		rs = k.settleValidatorRewards(ctx, val)
+	} else if !found {
+		rs = ValidatorRewards{OperatorAddr: validatorAddr, PoolIndex: math.ZeroInt(), DelegatorIndex: math.ZeroInt()}
+	}
+	if delegation, ok := k.GetStakeDelegation(ctx, validatorAddr, delegatorAddr); ok {
+		k.creditStakingReward(ctx, delegatorAddr, delegationReward(rs, delegation))
+	}
+	return rs
+}
+
+// addStakingRewards accrues minted staking rewards to every bonded upnyx.
+func (k Keeper) addStakingRewards(ctx sdk.Context, minted math.Int) {
+	if !minted.IsPositive() {
+		return
+	}
+	pool := k.getStakingRewardPool(ctx)
+	if pool.BondedStake.IsPositive() {
+		pool.Index = pool.Index.Add(minted.Mul(RewardIndexScale).Quo(pool.BondedStake))
+	}
+	pool.Outstanding = pool.Outstanding.Add(minted)
+	k.setStakingRewardPool(ctx, pool)
+}
+
+// ClaimableStakingRewards returns an address's unclaimed rewards plus what
+// it would receive by settling its own validator and, if validatorAddr is
+// set, its delegation to that validator. It writes nothing.
+func (k Keeper) ClaimableStakingRewards(ctx sdk.Context, address, validatorAddr string) math.Int {
+	pool := k.getStakingRewardPool(ctx)
+	total := intOrZero(k.GetStakingRewardAccount(ctx, address).Unclaimed)
+	if val, found := k.GetValidator(ctx, address); found {
+		_, reward := accrueValidatorRewards(pool, val, k.IsValidatorSetMember(ctx, address), k.validatorRewardsOrNew(ctx, pool, address))
+		total = total.Add(reward)
+	}
+	if validatorAddr == "" {
+		return total
+	}
+	delegation, found := k.GetStakeDelegation(ctx, validatorAddr, address)
+	if !found {
+		return total
+	}
+	rs, _ := k.GetValidatorRewards(ctx, validatorAddr)
+	if val, ok := k.GetValidator(ctx, validatorAddr); ok {
+		rs, _ = accrueValidatorRewards(pool, val, k.IsValidatorSetMember(ctx, validatorAddr), k.validatorRewardsOrNew(ctx, pool, validatorAddr))
+	}
+	return total.Add(delegationReward(rs, delegation))
+}
+
+// SetStakingRewardAddress sets where the sender's claims are paid. An empty
+// address, or the sender's own, restores the default.
+func (k Keeper) SetStakingRewardAddress(ctx sdk.Context, sender sdk.AccAddress, withdrawAddr string) error {
+	if withdrawAddr != "" {
+		if _, err := sdk.AccAddressFromBech32(withdrawAddr); err != nil {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidAddress, "invalid withdraw address: %s", err)
+		}
+	}
+	if withdrawAddr == sender.String() {
+		withdrawAddr = ""
+	}
+	acct := k.GetStakingRewardAccount(ctx, sender.String())
+	acct.WithdrawAddr = withdrawAddr
+	k.setStakingRewardAccount(ctx, acct)
+	return nil
+}
+
+// ClaimStakingRewards settles the sender's own validator and, if
+// validatorAddr is set, the sender's delegation to it, then pays every
+// unclaimed reward from module escrow to the sender's withdraw address.
+func (k Keeper) ClaimStakingRewards(ctx sdk.Context, sender sdk.AccAddress, validatorAddr string) (math.Int, sdk.AccAddress, error) {
+	if err := requireBankKeeper(k.bankKeeper); err != nil {
+		return math.Int{}, nil, err
+	}
+	address := sender.String()
+	cacheCtx, write := ctx.CacheContext()
+	if validatorAddr != "" {
+		delegation, found := k.GetStakeDelegation(cacheCtx, validatorAddr, address)
+		if !found {
+			return math.Int{}, nil, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "delegation not found")
+		}
+		k.setStakeDelegation(cacheCtx, delegation)
+	}
+	if val, found := k.GetValidator(cacheCtx, address); found {
+		k.settleValidatorRewards(cacheCtx, val)
+	}
+
+	acct := k.GetStakingRewardAccount(cacheCtx, address)
+	amount := intOrZero(acct.Unclaimed)
+	if !amount.IsPositive() {
+		return math.Int{}, nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "no staking rewards to claim")
+	}
+	recipient := sender
+	if acct.WithdrawAddr != "" {
+		addr, err := sdk.AccAddressFromBech32(acct.WithdrawAddr)
+		if err != nil {
+			return math.Int{}, nil, errorsmod.Wrapf(sdkerrors.ErrInvalidAddress, "invalid withdraw address: %s", err)
+		}
+		recipient = addr
+	}
+	pool := k.getStakingRewardPool(cacheCtx)
+	if pool.Outstanding.LT(amount) {
+		return math.Int{}, nil, errorsmod.Wrapf(sdkerrors.ErrLogic, "staking reward claim %s exceeds outstanding %s", amount, pool.Outstanding)
+	}
+	pool.Outstanding = pool.Outstanding.Sub(amount)
+	k.setStakingRewardPool(cacheCtx, pool)
+	acct.Unclaimed = math.ZeroInt()
+	k.setStakingRewardAccount(cacheCtx, acct)
+
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(cacheCtx, ModuleName, recipient, sdk.NewCoins(sdk.NewCoin(PNYXDenom, amount))); err != nil {
+		return math.Int{}, nil, errorsmod.Wrap(err, "staking reward payout failed")
+	}
+	write()
+	return amount, recipient, nil
+}
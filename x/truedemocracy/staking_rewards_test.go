@@ -0,0 +1,330 @@
+package truedemocracy
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	rewards "truerepublic/treasury/keeper"
+)
+
+// accrueRewardInterval mints one reward interval through EndBlock's staking
+// reward path and returns the context after it.
+func accrueRewardInterval(t *testing.T, k Keeper, ctx sdk.Context) sdk.Context {
+	t.Helper()
+	store := ctx.KVStore(k.StoreKey)
+	store.Set([]byte("pod:last-reward-time"), k.cdc.MustMarshalLengthPrefixed(ctx.BlockTime().Unix()))
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(time.Duration(RewardInterval) * time.Second))
+	if err := k.DistributeStakingRewards(ctx); err != nil {
+		t.Fatal(err)
+	}
+	return ctx
+}
+
+// seatValidator makes a stored validator a member of the validator set, the
+// only validators that earn staking rewards.
+func seatValidator(k Keeper, ctx sdk.Context, operatorAddr string) {
+	val, _ := k.GetValidator(ctx, operatorAddr)
+	k.joinValidatorSet(ctx, val, "vacancy")
+}
+
+func TestClaimStakingRewardsPaysOperatorAndDelegator(t *testing.T) {
+	k, ctx, bank, operator, delegator := setupDelegationValidator(t)
+	seatValidator(k, ctx, operator.String())
+	if err := k.setValidatorCommission(ctx, operator.String(), 2_000); err != nil {
+		t.Fatal(err)
+	}
+	delegate(t, k, ctx, delegator, operator.String(), 2*rewards.StakeMin)
+
+	ctx = accrueRewardInterval(t, k, ctx)
+	minted := k.getStakingRewardPool(ctx).Outstanding
+	if !minted.IsPositive() {
+		t.Fatal("no staking reward accrued")
+	}
+	val, _ := k.GetValidator(ctx, operator.String())
+	if val.Stake.AmountOf(PNYXDenom).Int64() != rewards.StakeMin || val.DelegatedStake != 2*rewards.StakeMin {
+		t.Fatal("rewards compounded into stake")
+	}
+	if err := k.ValidateEscrowParity(ctx); err != nil {
+		t.Fatalf("parity after accrual: %v", err)
+	}
+
+	delegatorShare := k.ClaimableStakingRewards(ctx, delegator.String(), operator.String())
+	operatorShare := k.ClaimableStakingRewards(ctx, operator.String(), "")
+	// Delegators back 2/3 of the reward and keep 80% of it after commission.
+	if want := minted.MulRaw(2 * 8).QuoRaw(3 * 10); delegatorShare.GT(want) || delegatorShare.LT(want.SubRaw(2)) {
+		t.Fatalf("delegator share = %s, want about %s", delegatorShare, want)
+	}
+	if sum := delegatorShare.Add(operatorShare); sum.GT(minted) || sum.LT(minted.SubRaw(2)) {
+		t.Fatalf("shares %s + %s do not add up to %s", delegatorShare, operatorShare, minted)
+	}
+
+	balance := accountBalance(bank, delegator)
+	amount, recipient, err := k.ClaimStakingRewards(ctx, delegator, operator.String())
+	if err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	if !amount.Equal(delegatorShare) || !recipient.Equals(delegator) {
+		t.Fatalf("claimed %s to %s, want %s to the delegator", amount, recipient, delegatorShare)
+	}
+	if got := accountBalance(bank, delegator); got != balance+delegatorShare.Int64() {
+		t.Fatalf("delegator balance = %d, want %d", got, balance+delegatorShare.Int64())
+	}
+	if _, _, err := k.ClaimStakingRewards(ctx, delegator, operator.String()); err == nil {
+		t.Fatal("second claim paid again")
+	}
+	if _, _, err := k.ClaimStakingRewards(ctx, delegator, sdk.AccAddress("nobody").String()); err == nil {
+		t.Fatal("claim for a missing delegation accepted")
+	}
+
+	payee := sdk.AccAddress("reward-payee")
+	if err := k.SetStakingRewardAddress(ctx, operator, payee.String()); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.SetStakingRewardAddress(ctx, operator, "payee"); err == nil {
+		t.Fatal("invalid withdraw address accepted")
+	}
+	amount, recipient, err = k.ClaimStakingRewards(ctx, operator, "")
+	if err != nil {
+		t.Fatalf("operator claim: %v", err)
+	}
+	if !amount.Equal(operatorShare) || !recipient.Equals(payee) || accountBalance(bank, payee) != operatorShare.Int64() {
+		t.Fatalf("operator claimed %s to %s, want %s to the payee", amount, recipient, operatorShare)
+	}
+	if dust := k.getStakingRewardPool(ctx).Outstanding; !dust.Equal(minted.Sub(delegatorShare).Sub(operatorShare)) {
+		t.Fatalf("outstanding after claims = %s", dust)
+	}
+	if err := k.ValidateEscrowParity(ctx); err != nil {
+		t.Fatalf("parity after claims: %v", err)
+	}
+}
+
+func TestStakingRewardsSkipJailedValidators(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	active := Validator{OperatorAddr: "active-operator", Stake: sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 1_000))}
+	jailed := Validator{OperatorAddr: "jailed-operator", Stake: sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 3_000)), Jailed: true}
+	k.SetValidator(ctx, active)
+	k.SetValidator(ctx, jailed)
+	seatValidator(k, ctx, active.OperatorAddr)
+	seatValidator(k, ctx, jailed.OperatorAddr)
+	if bonded := k.getStakingRewardPool(ctx).BondedStake; bonded.Int64() != 1_000 {
+		t.Fatalf("bonded stake = %s, want 1000", bonded)
+	}
+
+	k.addStakingRewards(ctx, math.NewInt(100))
+	if got := k.ClaimableStakingRewards(ctx, active.OperatorAddr, ""); got.Int64() != 100 {
+		t.Fatalf("active reward = %s, want 100", got)
+	}
+	if got := k.ClaimableStakingRewards(ctx, jailed.OperatorAddr, ""); !got.IsZero() {
+		t.Fatalf("jailed validator earned %s", got)
+	}
+
+	// Unjailing starts accrual from the current index only.
+	jailed.Jailed = false
+	k.SetValidator(ctx, jailed)
+	k.addStakingRewards(ctx, math.NewInt(400))
+	if got := k.ClaimableStakingRewards(ctx, active.OperatorAddr, ""); got.Int64() != 200 {
+		t.Fatalf("active reward = %s, want 200", got)
+	}
+	if got := k.ClaimableStakingRewards(ctx, jailed.OperatorAddr, ""); got.Int64() != 300 {
+		t.Fatalf("unjailed reward = %s, want 300", got)
+	}
+}
+
+func TestStakingRewardsOnlyPaySetMembers(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	seated := Validator{OperatorAddr: "seated-operator", Stake: sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 1_000))}
+	waiting := Validator{OperatorAddr: "waiting-operator", Stake: sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 3_000))}
+	k.SetValidator(ctx, seated)
+	k.SetValidator(ctx, waiting)
+	seatValidator(k, ctx, seated.OperatorAddr)
+	if bonded := k.getStakingRewardPool(ctx).BondedStake; bonded.Int64() != 1_000 {
+		t.Fatalf("bonded stake = %s, want 1000", bonded)
+	}
+
+	k.addStakingRewards(ctx, math.NewInt(100))
+	if got := k.ClaimableStakingRewards(ctx, waiting.OperatorAddr, ""); !got.IsZero() {
+		t.Fatalf("waiting validator earned %s", got)
+	}
+
+	// Promotion starts accrual from the current index only.
+	seatValidator(k, ctx, waiting.OperatorAddr)
+	k.addStakingRewards(ctx, math.NewInt(400))
+	if got := k.ClaimableStakingRewards(ctx, waiting.OperatorAddr, ""); got.Int64() != 300 {
+		t.Fatalf("promoted reward = %s, want 300", got)
+	}
+
+	// Demotion settles what was earned while seated, then accrual stops.
+	if !k.leaveValidatorSet(ctx, seated, "epoch") {
+		t.Fatal("seated validator was not a member")
+	}
+	if got := k.GetStakingRewardAccount(ctx, seated.OperatorAddr).Unclaimed; got.Int64() != 200 {
+		t.Fatalf("settled on demotion = %s, want 200", got)
+	}
+	k.addStakingRewards(ctx, math.NewInt(300))
+	if got := k.ClaimableStakingRewards(ctx, seated.OperatorAddr, ""); got.Int64() != 200 {
+		t.Fatalf("demoted reward = %s, want 200", got)
+	}
+	if bonded := k.getStakingRewardPool(ctx).BondedStake; bonded.Int64() != 3_000 {
+		t.Fatalf("bonded stake after demotion = %s, want 3000", bonded)
+	}
+}
+
+func TestRemoveValidatorSettlesStakingRewards(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupDomainWithValidator(t, k, ctx)
+	seatValidator(k, ctx, "oper1")
+	k.addStakingRewards(ctx, math.NewInt(50))
+
+	if err := k.RemoveValidator(ctx, "oper1"); err != nil {
+		t.Fatal(err)
+	}
+	if got := k.GetStakingRewardAccount(ctx, "oper1").Unclaimed; got.Int64() != 50 {
+		t.Fatalf("settled reward = %s, want 50", got)
+	}
+	if _, found := k.GetValidatorRewards(ctx, "oper1"); found {
+		t.Fatal("reward record outlived the validator")
+	}
+	if bonded := k.getStakingRewardPool(ctx).BondedStake; !bonded.IsZero() {
+		t.Fatalf("bonded stake after removal = %s", bonded)
+	}
+}
+
+func TestStakingRewardsGenesisRoundTrip(t *testing.T) {
+	am1, k1, ctx1 := setupModuleForGenesis(t)
+	admin := sdk.AccAddress("genesis-reward-admin")
+	k1.CreateDomain(ctx1, "Rewarded", admin, sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 500_000*PNYXUnit)))
+	operator := sdk.AccAddress("genesis-reward-operator").String()
+	if err := k1.AddMember(ctx1, "Rewarded", operator, admin); err != nil {
+		t.Fatal(err)
+	}
+	stake := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, rewards.StakeMin))
+	if err := k1.RegisterValidator(ctx1, operator, testPubKey("genesis-reward"), stake, "Rewarded"); err != nil {
+		t.Fatal(err)
+	}
+	if err := k1.setValidatorCommission(ctx1, operator, 1_000); err != nil {
+		t.Fatal(err)
+	}
+	delegator := sdk.AccAddress("genesis-reward-delegator").String()
+	val, _ := k1.GetValidator(ctx1, operator)
+	val.DelegatedStake = rewards.StakeMin
+	val.Power = validatorPowerFromStake(val)
+	k1.SetValidator(ctx1, val)
+	seatValidator(k1, ctx1, operator)
+	k1.setStakeDelegation(ctx1, StakeDelegation{DelegatorAddr: delegator, ValidatorAddr: operator, Amount: rewards.StakeMin})
+	k1.addStakingRewards(ctx1, math.NewInt(1_000_000))
+	// Settle the operator once so both a record and an account are exported.
+	k1.SetValidator(ctx1, val)
+	k1.addStakingRewards(ctx1, math.NewInt(333))
+	if err := k1.SetStakingRewardAddress(ctx1, sdk.MustAccAddressFromBech32(delegator), admin.String()); err != nil {
+		t.Fatal(err)
+	}
+
+	exported := am1.ExportGenesis(ctx1, nil)
+	var genesis GenesisState
+	if err := json.Unmarshal(exported, &genesis); err != nil {
+		t.Fatal(err)
+	}
+	if genesis.StakingRewardPool == nil || len(genesis.ValidatorRewards) != 1 || len(genesis.StakingRewardAccounts) != 2 {
+		t.Fatalf("exported reward state = %+v %+v %+v", genesis.StakingRewardPool, genesis.ValidatorRewards, genesis.StakingRewardAccounts)
+	}
+	claims, err := GenesisEscrowClaims(genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := math.NewInt(500_000*PNYXUnit + 2*rewards.StakeMin + 1_000_333); !claims.Equal(want) {
+		t.Fatalf("claims = %s, want %s", claims, want)
+	}
+
+	am2, k2, ctx2 := setupModuleForGenesis(t)
+	am2.InitGenesis(ctx2, nil, exported)
+	for _, query := range [][2]string{{operator, ""}, {delegator, operator}} {
+		want := k1.ClaimableStakingRewards(ctx1, query[0], query[1])
+		if got := k2.ClaimableStakingRewards(ctx2, query[0], query[1]); !got.Equal(want) || !want.IsPositive() {
+			t.Fatalf("claimable for %s = %s, want %s", query[0], got, want)
+		}
+	}
+	pool1, pool2 := k1.getStakingRewardPool(ctx1), k2.getStakingRewardPool(ctx2)
+	if !pool1.Index.Equal(pool2.Index) || !pool1.BondedStake.Equal(pool2.BondedStake) || !pool1.Outstanding.Equal(pool2.Outstanding) {
+		t.Fatalf("restored pool = %+v, want %+v", pool2, pool1)
+	}
+	if acct := k2.GetStakingRewardAccount(ctx2, delegator); acct.WithdrawAddr != admin.String() {
+		t.Fatalf("restored withdraw address = %q", acct.WithdrawAddr)
+	}
+}
+
+func TestValidateGenesisStateRejectsMalformedStakingRewards(t *testing.T) {
+	delegator := sdk.AccAddress("genesis-reward-delegator").String()
+	valid := func() GenesisState {
+		genesis := validActiveInactiveGenesis()
+		operator := genesis.Validators[0].OperatorAddr
+		genesis.Validators[0].DelegatedStake = rewards.StakeMin
+		genesis.Validators[0].Power = 3
+		genesis.StakingRewardPool = &StakingRewardPool{
+			Index:       math.NewInt(1_000),
+			BondedStake: math.NewInt(3 * rewards.StakeMin),
+			Outstanding: math.NewInt(10),
+		}
+		genesis.ValidatorRewards = []ValidatorRewards{{
+			OperatorAddr: operator, PoolIndex: math.NewInt(900), DelegatorIndex: math.NewInt(800),
+		}}
+		genesis.StakeDelegations = []StakeDelegation{{
+			DelegatorAddr: delegator, ValidatorAddr: operator, Amount: rewards.StakeMin, RewardIndex: math.NewInt(700),
+		}}
+		genesis.StakingRewardAccounts = []StakingRewardAccount{{Address: delegator, Unclaimed: math.NewInt(10)}}
+		return genesis
+	}
+	if err := ValidateGenesisState(valid()); err != nil {
+		t.Fatalf("valid reward genesis rejected: %v", err)
+	}
+
+	for name, mutate := range map[string]func(*GenesisState){
+		"bonded mismatch":    func(g *GenesisState) { g.StakingRewardPool.BondedStake = math.NewInt(4 * rewards.StakeMin) },
+		"negative index":     func(g *GenesisState) { g.StakingRewardPool.Index = math.NewInt(-1) },
+		"record ahead":       func(g *GenesisState) { g.ValidatorRewards[0].PoolIndex = math.NewInt(1_001) },
+		"delegation ahead":   func(g *GenesisState) { g.StakeDelegations[0].RewardIndex = math.NewInt(801) },
+		"unclaimed too high": func(g *GenesisState) { g.StakingRewardAccounts[0].Unclaimed = math.NewInt(11) },
+		"negative unclaimed": func(g *GenesisState) { g.StakingRewardAccounts[0].Unclaimed = math.NewInt(-1) },
+		"invalid account":    func(g *GenesisState) { g.StakingRewardAccounts[0].Address = "delegator" },
+		"invalid payee":      func(g *GenesisState) { g.StakingRewardAccounts[0].WithdrawAddr = "payee" },
+		"unknown validator": func(g *GenesisState) {
+			g.ValidatorRewards[0].OperatorAddr = sdk.AccAddress("nobody").String()
+		},
+		"duplicate record": func(g *GenesisState) {
+			g.ValidatorRewards = append(g.ValidatorRewards, g.ValidatorRewards[0])
+		},
+		"duplicate account": func(g *GenesisState) {
+			g.StakingRewardAccounts = append(g.StakingRewardAccounts, StakingRewardAccount{Address: delegator})
+		},
+	} {
+		genesis := valid()
+		mutate(&genesis)
+		if err := ValidateGenesisState(genesis); err == nil {
+			t.Errorf("%s accepted", name)
+		}
+	}
+}
+
+func TestMsgStakingRewardsValidateBasic(t *testing.T) {
+	sender := sdk.AccAddress("reward-claimant")
+	if err := (MsgClaimStakingRewards{Sender: sender}).ValidateBasic(); err != nil {
+		t.Fatalf("valid claim rejected: %v", err)
+	}
+	if err := (MsgClaimStakingRewards{}).ValidateBasic(); err == nil {
+		t.Fatal("claim without sender accepted")
+	}
+	if err := (MsgSetStakingRewardAddress{Sender: sender}).ValidateBasic(); err != nil {
+		t.Fatalf("reset to self rejected: %v", err)
+	}
+	if err := (MsgSetStakingRewardAddress{Sender: sender, WithdrawAddr: sdk.AccAddress("payee").String()}).ValidateBasic(); err != nil {
+		t.Fatalf("valid withdraw address rejected: %v", err)
+	}
+	err := (MsgSetStakingRewardAddress{Sender: sender, WithdrawAddr: "payee"}).ValidateBasic()
+	if err == nil || !strings.Contains(err.Error(), "withdraw address") {
+		t.Fatalf("invalid withdraw address: %v", err)
+	}
+}
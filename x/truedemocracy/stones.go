@@ -32,48 +32,58 @@ func suggestionStoneKey(domainName, issueName, memberAddr string) []byte {
 // it is moved automatically (old issue -1, new issue +1). A VoteToEarn reward
 // is paid from the domain treasury (whitepaper eq.2).
 func (k Keeper) PlaceStoneOnIssue(ctx sdk.Context, domainName, issueName, memberAddr string) (sdk.Coins, error) {
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return sdk.Coins{}, errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
 
-	if !isMember(domain, memberAddr) {
+	if !k.IsDomainMember(ctx, domainName, memberAddr) {
 		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only domain members can place stones")
 	}
 
-	targetIdx := findIssueIndex(domain, issueName)
-	if targetIdx == -1 {
+	target, found := k.GetIssue(ctx, domainName, issueName)
+	if !found {
 		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue not found")
 	}
+	if err := k.requireIssueOpen(ctx, domainName, issueName); err != nil {
+		return sdk.Coins{}, err
+	}
 
 	store := ctx.KVStore(k.StoreKey)
 	key := issueStoneKey(domainName, memberAddr)
 
 	// Check if member already has a stone placed.
-	if existing := store.Get(key); existing != nil {
-		oldIssue := string(existing)
-		if oldIssue == issueName {
-			return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "stone already placed on this issue")
-		}
+	existing := store.Get(key)
+	if string(existing) == issueName {
+		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "stone already placed on this issue")
+	}
+
+	place := func() {
 		// Move: decrement old issue.
-		for i, issue := range domain.Issues {
-			if issue.Name == oldIssue {
-				domain.Issues[i].Stones--
-				break
+		if existing != nil {
+			if old, ok := k.GetIssue(ctx, domainName, string(existing)); ok {
+				old.Stones--
+				k.SetIssue(ctx, domainName, old)
 			}
 		}
-	}
 
-	// Increment target issue and update activity.
-	domain.Issues[targetIdx].Stones++
-	domain.Issues[targetIdx].LastActivityAt = ctx.BlockTime().Unix()
-	store.Set(key, []byte(issueName))
+		// Increment target issue and update activity.
+		target.Stones++
+		target.LastActivityAt = ctx.BlockTime().Unix()
+		k.SetIssue(ctx, domainName, target)
+		store.Set(key, []byte(issueName))
+	}
+	if k.hasVoteDelegations(ctx, domainName) {
+		// Stones delegated to the member move with theirs.
+		k.trackDelegatedWeight(ctx, domainName, []string{memberAddr}, []delegatedTally{k.issueListTally(ctx, domainName)}, place)
+	} else {
+		place()
+	}
 
 	// VoteToEarn reward (eq.2).
 	reward := k.payStoneReward(&domain)
 
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	k.SetDomainHeader(ctx, domain)
 	return reward, nil
 }
 
@@ -81,22 +91,25 @@ func (k Keeper) PlaceStoneOnIssue(ctx sdk.Context, domainName, issueName, member
 // within an issue's suggestion list. Each issue has its own independent
 // suggestion list, so a member can have one stone per issue's suggestion list.
 func (k Keeper) PlaceStoneOnSuggestion(ctx sdk.Context, domainName, issueName, suggestionName, memberAddr string) (sdk.Coins, error) {
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return sdk.Coins{}, errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
 
-	if !isMember(domain, memberAddr) {
+	if !k.IsDomainMember(ctx, domainName, memberAddr) {
 		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only domain members can place stones")
 	}
 
-	issueIdx := findIssueIndex(domain, issueName)
-	if issueIdx == -1 {
+	issue, found := k.GetIssue(ctx, domainName, issueName)
+	if !found {
 		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue not found")
 	}
+	if err := k.requireIssueOpen(ctx, domainName, issueName); err != nil {
+		return sdk.Coins{}, err
+	}
 
-	targetIdx := findSuggestionIndex(domain.Issues[issueIdx], suggestionName)
-	if targetIdx == -1 {
+	target, found := k.GetSuggestion(ctx, domainName, issueName, suggestionName)
+	if !found {
 		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "suggestion not found")
 	}
 
@@ -104,30 +117,40 @@ func (k Keeper) PlaceStoneOnSuggestion(ctx sdk.Context, domainName, issueName, s
 	key := suggestionStoneKey(domainName, issueName, memberAddr)
 
 	// Check if member already has a stone in this suggestion list.
-	if existing := store.Get(key); existing != nil {
-		oldSugg := string(existing)
-		if oldSugg == suggestionName {
-			return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "stone already placed on this suggestion")
-		}
+	existing := store.Get(key)
+	if string(existing) == suggestionName {
+		return sdk.Coins{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "stone already placed on this suggestion")
+	}
+
+	place := func() {
 		// Move: decrement old suggestion.
-		for j, s := range domain.Issues[issueIdx].Suggestions {
-			if s.Name == oldSugg {
-				domain.Issues[issueIdx].Suggestions[j].Stones--
-				break
+		if existing != nil {
+			if old, ok := k.GetSuggestion(ctx, domainName, issueName, string(existing)); ok {
+				old.Stones--
+				k.SetSuggestion(ctx, domainName, issueName, old)
 			}
 		}
-	}
 
-	// Increment target suggestion and update issue activity.
-	domain.Issues[issueIdx].Suggestions[targetIdx].Stones++
-	domain.Issues[issueIdx].LastActivityAt = ctx.BlockTime().Unix()
-	store.Set(key, []byte(suggestionName))
+		// Increment target suggestion and update issue activity.
+		target.Stones++
+		k.SetSuggestion(ctx, domainName, issueName, target)
+		issue.LastActivityAt = ctx.BlockTime().Unix()
+		k.SetIssue(ctx, domainName, issue)
+		store.Set(key, []byte(suggestionName))
+	}
+	if k.hasVoteDelegations(ctx, domainName) {
+		// Stones delegated to the member move with theirs.
+		k.trackDelegatedWeight(ctx, domainName, []string{memberAddr}, []delegatedTally{k.suggestionListTally(ctx, domainName, issueName)}, place)
+		issue, _ = k.GetIssue(ctx, domainName, issueName)
+	} else {
+		place()
+	}
+	k.checkIssueQuorum(ctx, domainName, issue)
 
 	// VoteToEarn reward (eq.2).
 	reward := k.payStoneReward(&domain)
 
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	k.SetDomainHeader(ctx, domain)
 	return reward, nil
 }
 
@@ -192,24 +215,6 @@ func isMember(domain Domain, addr string) bool {
 	return false
 }
 
-func findIssueIndex(domain Domain, issueName string) int {
-	for i, issue := range domain.Issues {
-		if issue.Name == issueName {
-			return i
-		}
-	}
-	return -1
-}
-
-func findSuggestionIndex(issue Issue, suggestionName string) int {
-	for j, s := range issue.Suggestions {
-		if s.Name == suggestionName {
-			return j
-		}
-	}
-	return -1
-}
-
 // payStoneReward calculates and deducts the VoteToEarn reward (eq.2) from the
 // domain treasury. Returns the reward coins (may be empty if treasury is low).
 func (k Keeper) payStoneReward(domain *Domain) sdk.Coins {
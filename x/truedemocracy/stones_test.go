@@ -37,9 +37,7 @@ func setupDomainWithIssues(t *testing.T, k Keeper, ctx sdk.Context) {
 		},
 	}
 
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:StonesDomain"), bz)
+	k.SetDomain(ctx, domain)
 }
 
 // ---------- PlaceStoneOnIssue ----------
@@ -0,0 +1,291 @@
+package truedemocracy
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Sub-domains nest domains into a tree, e.g. a federation with one child
+// domain per region:
+//   "subdomain:{p}{child}"                       → child name (child index)
+//   "budgetvote:{p}{child scope}{amount}{voter}" → []byte{1}
+//
+// Domain.Parent is fixed at creation. A child's members are always a subset
+// of its parent's: only parent members can be added, and a member removed
+// from the parent is removed from every descendant. Children start with an
+// empty treasury and receive budgets from their parent once 2/3 of the
+// parent's members vote for the same amount. A parent issue can be handed to
+// a child, which then decides it; the parent adopts the child's decision.
+
+// BudgetMajorityBps is the share of parent members, in basis points, that
+// must vote for a sub-domain budget before it is transferred.
+const BudgetMajorityBps int64 = 6667 // 2/3 ≈ 66.67%
+
+func subDomainPrefix(parentName string) []byte {
+	return append([]byte("subdomain:"), domainScope(parentName)...)
+}
+
+func subDomainKey(parentName, childName string) []byte {
+	return append(subDomainPrefix(parentName), childName...)
+}
+
+func budgetVotePrefix(parentName, childName string) []byte {
+	return append(append([]byte("budgetvote:"), domainScope(parentName)...), domainScope(childName)...)
+}
+
+func budgetVoteKey(parentName, childName string, amount sdk.Coin, voter string) []byte {
+	return append(append(budgetVotePrefix(parentName, childName), domainScope(amount.String())...), voter...)
+}
+
+// CreateSubDomain creates a child of an existing domain. Only the parent's
+// admin can create children, and the child's admin must be a parent member.
+// The child copies the parent's options and starts with an empty treasury.
+func (k Keeper) CreateSubDomain(ctx sdk.Context, parentName, name string, admin, caller sdk.AccAddress) error {
+	if parentName == ReservedGovernanceDomain || name == ReservedGovernanceDomain {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "domain %s cannot have sub-domains or be one", ReservedGovernanceDomain)
+	}
+	parent, found := k.GetDomainHeader(ctx, parentName)
+	if !found {
+		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", parentName)
+	}
+	if !caller.Equals(parent.Admin) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only the parent domain admin can create sub-domains")
+	}
+	if name == "" {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "domain name is required")
+	}
+	if _, found := k.GetDomainHeader(ctx, name); found {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "domain %s already exists", name)
+	}
+	if admin.Empty() || !k.IsDomainMember(ctx, parentName, admin.String()) {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "sub-domain admin must be a member of the parent domain")
+	}
+
+	k.SetDomain(ctx, Domain{
+		Name:          name,
+		Parent:        parentName,
+		Admin:         admin,
+		Members:       []string{admin.String()},
+		Treasury:      sdk.NewCoins(),
+		Issues:        []Issue{},
+		Options:       parent.Options,
+		PermissionReg: []string{},
+	})
+	k.InitializeBigPurgeSchedule(ctx, name)
+	return nil
+}
+
+// GetSubDomains returns the names of a domain's direct children in name
+// order.
+func (k Keeper) GetSubDomains(ctx sdk.Context, parentName string) []string {
+	prefix := subDomainPrefix(parentName)
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	var children []string
+	for ; iter.Valid(); iter.Next() {
+		children = append(children, string(iter.Value()))
+	}
+	return children
+}
+
+// removeFromSubDomains removes a member who left a domain from all of its
+// descendants.
+func (k Keeper) removeFromSubDomains(ctx sdk.Context, domainName, memberAddr string) error {
+	for _, child := range k.GetSubDomains(ctx, domainName) {
+		if k.IsDomainMember(ctx, child, memberAddr) {
+			if err := k.removeMember(ctx, child, memberAddr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// requireParentMembership rejects new members of a sub-domain who do not
+// belong to its parent.
+func (k Keeper) requireParentMembership(ctx sdk.Context, domain Domain, memberAddr string) error {
+	if domain.Parent != "" && !k.IsDomainMember(ctx, domain.Parent, memberAddr) {
+		return errorsmod.Wrapf(sdkerrors.ErrUnauthorized, "sub-domain members must belong to the parent domain %s", domain.Parent)
+	}
+	return nil
+}
+
+// ---------- Budgets ----------
+
+// VoteSubDomainBudget records a parent member's vote to move amount from the
+// parent treasury to a child. Votes count per exact amount; when 2/3 of the
+// parent's members agree, the budget is transferred and every open budget
+// vote for that child is cleared. Returns whether the transfer happened.
+func (k Keeper) VoteSubDomainBudget(ctx sdk.Context, parentName, childName string, amount sdk.Coin, voterAddr string) (bool, error) {
+	parent, found := k.GetDomainHeader(ctx, parentName)
+	if !found {
+		return false, errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", parentName)
+	}
+	child, found := k.GetDomainHeader(ctx, childName)
+	if !found || child.Parent != parentName {
+		return false, errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "%s is not a sub-domain of %s", childName, parentName)
+	}
+	if !k.IsDomainMember(ctx, parentName, voterAddr) {
+		return false, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only parent domain members can vote on budgets")
+	}
+	if !amount.IsPositive() || amount.Denom != PNYXDenom {
+		return false, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "budget must be a positive %s amount", PNYXDenom)
+	}
+	if parent.Treasury.AmountOf(PNYXDenom).LT(amount.Amount) {
+		return false, errorsmod.Wrap(sdkerrors.ErrInsufficientFunds, "parent treasury cannot cover the budget")
+	}
+
+	store := ctx.KVStore(k.StoreKey)
+	voteKey := budgetVoteKey(parentName, childName, amount, voterAddr)
+	if store.Has(voteKey) {
+		return false, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "already voted for this budget")
+	}
+	store.Set(voteKey, []byte{1})
+
+	members := k.GetDomainMembers(ctx, parentName)
+	votes := 0
+	for _, member := range members {
+		if store.Has(budgetVoteKey(parentName, childName, amount, member)) {
+			votes++
+		}
+	}
+	if int64(votes)*10000 < int64(len(members))*BudgetMajorityBps {
+		return false, nil
+	}
+
+	parent.Treasury = parent.Treasury.Sub(amount)
+	child.Treasury = child.Treasury.Add(amount)
+	k.SetDomainHeader(ctx, parent)
+	k.SetDomainHeader(ctx, child)
+	k.clearBudgetVotes(ctx, parentName, childName)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"sub_domain_budget",
+		sdk.NewAttribute("parent", parentName),
+		sdk.NewAttribute("sub_domain", childName),
+		sdk.NewAttribute("amount", amount.String()),
+	))
+	return true, nil
+}
+
+func (k Keeper) clearBudgetVotes(ctx sdk.Context, parentName, childName string) {
+	store := ctx.KVStore(k.StoreKey)
+	prefix := budgetVotePrefix(parentName, childName)
+	iter := store.Iterator(prefix, prefixEnd(prefix))
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, append([]byte{}, iter.Key()...))
+	}
+	iter.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// ---------- Delegated issues ----------
+
+// DelegateIssueToSubDomain hands an open parent issue to a direct child. The
+// child receives an issue of the same name with the parent's suggestions,
+// closing rule and seat count, and the parent issue stops accepting votes
+// until the child's decision is adopted. Treasury payouts of the suggestions
+// are then paid from the child's treasury. Only the parent admin can delegate.
+func (k Keeper) DelegateIssueToSubDomain(ctx sdk.Context, domainName, issueName, childName string, caller sdk.AccAddress) error {
+	domain, found := k.GetDomainHeader(ctx, domainName)
+	if !found {
+		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
+	}
+	if !caller.Equals(domain.Admin) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only domain admin can delegate issues")
+	}
+	issue, found := k.GetIssue(ctx, domainName, issueName)
+	if !found {
+		return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "issue not found")
+	}
+	if err := k.requireIssueOpen(ctx, domainName, issueName); err != nil {
+		return err
+	}
+	child, found := k.GetDomainHeader(ctx, childName)
+	if !found || child.Parent != domainName {
+		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "%s is not a sub-domain of %s", childName, domainName)
+	}
+	if _, exists := k.GetIssue(ctx, childName, issueName); exists {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "sub-domain %s already has an issue %s", childName, issueName)
+	}
+
+	var suggestions []Suggestion
+	k.IterateSuggestions(ctx, domainName, issueName, func(s Suggestion) bool {
+		suggestions = append(suggestions, s)
+		return false
+	})
+	now := ctx.BlockTime().Unix()
+	k.SetIssue(ctx, childName, Issue{
+		Name:           issue.Name,
+		CreationDate:   now,
+		LastActivityAt: now,
+		ExternalLink:   issue.ExternalLink,
+		Closing:        issue.Closing,
+		Seats:          issue.Seats,
+	})
+	for _, s := range suggestions {
+		k.SetSuggestion(ctx, childName, issueName, Suggestion{
+			Name:         s.Name,
+			Creator:      s.Creator,
+			Color:        s.Color,
+			DwellTime:    s.DwellTime,
+			CreationDate: now,
+			ExternalLink: s.ExternalLink,
+			Payout:       s.Payout,
+		})
+	}
+	childIssue, _ := k.GetIssue(ctx, childName, issueName)
+	k.scheduleIssueDecision(ctx, childName, childIssue)
+
+	k.unscheduleIssueDecision(ctx, domainName, issue)
+	issue.SubDomain = childName
+	k.SetIssue(ctx, domainName, issue)
+	return nil
+}
+
+// adoptSubDomainDecision records a child's decision for the parent issue
+// delegated to it, and so on up the tree.
+func (k Keeper) adoptSubDomainDecision(ctx sdk.Context, decision IssueDecision) {
+	child, found := k.GetDomainHeader(ctx, decision.DomainName)
+	if !found || child.Parent == "" {
+		return
+	}
+	issue, found := k.GetIssue(ctx, child.Parent, decision.IssueName)
+	if !found || issue.SubDomain != child.Name || k.IsIssueDecided(ctx, child.Parent, issue.Name) {
+		return
+	}
+	adopted := decision
+	adopted.DomainName = child.Parent
+	adopted.SubDomain = child.Name
+	k.setIssueDecision(ctx, adopted)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"issue_decided",
+		sdk.NewAttribute("domain", adopted.DomainName),
+		sdk.NewAttribute("issue", adopted.IssueName),
+		sdk.NewAttribute("winner", adopted.Winner),
+		sdk.NewAttribute("reason", adopted.Reason),
+		sdk.NewAttribute("sub_domain", adopted.SubDomain),
+	))
+	k.adoptSubDomainDecision(ctx, adopted)
+}
+
+// releaseSubDomainIssue returns a delegated issue to the parent when the
+// child's copy is deleted before it was decided.
+func (k Keeper) releaseSubDomainIssue(ctx sdk.Context, childName, issueName string) {
+	child, found := k.GetDomainHeader(ctx, childName)
+	if !found || child.Parent == "" {
+		return
+	}
+	issue, found := k.GetIssue(ctx, child.Parent, issueName)
+	if !found || issue.SubDomain != childName || k.IsIssueDecided(ctx, child.Parent, issueName) {
+		return
+	}
+	issue.SubDomain = ""
+	k.SetIssue(ctx, child.Parent, issue)
+	k.scheduleIssueDecision(ctx, child.Parent, issue)
+}
@@ -0,0 +1,342 @@
+package truedemocracy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+)
+
+// setupFederation builds the "Vote" decision domain with a 900 PNYX treasury
+// and a child "North" administered by admin1.
+func setupFederation(t *testing.T, k Keeper, ctx sdk.Context) {
+	t.Helper()
+	setupDecisionDomain(t, k, ctx)
+	parent, _ := k.GetDomainHeader(ctx, "Vote")
+	parent.Treasury = sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 900))
+	k.SetDomainHeader(ctx, parent)
+	if err := k.CreateSubDomain(ctx, "Vote", "North", sdk.AccAddress("admin1"), sdk.AccAddress("admin1")); err != nil {
+		t.Fatalf("CreateSubDomain: %v", err)
+	}
+}
+
+func TestCreateSubDomain(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupFederation(t, k, ctx)
+	admin := sdk.AccAddress("admin1")
+
+	child, found := k.GetDomain(ctx, "North")
+	if !found {
+		t.Fatal("sub-domain not stored")
+	}
+	if child.Parent != "Vote" || !child.Treasury.IsZero() || !reflect.DeepEqual(child.Members, []string{admin.String()}) {
+		t.Fatalf("sub-domain = %+v", child)
+	}
+	if children := k.GetSubDomains(ctx, "Vote"); !reflect.DeepEqual(children, []string{"North"}) {
+		t.Fatalf("sub-domains = %v", children)
+	}
+
+	if err := k.CreateSubDomain(ctx, "Vote", "South", admin, sdk.AccAddress(decisionAlice)); !errors.Is(err, sdkerrors.ErrUnauthorized) {
+		t.Fatalf("non-admin creation: got %v", err)
+	}
+	if err := k.CreateSubDomain(ctx, "Vote", "South", sdk.AccAddress("outsider"), admin); err == nil {
+		t.Fatal("sub-domain admin outside the parent accepted")
+	}
+	if err := k.CreateSubDomain(ctx, "Vote", "North", admin, admin); err == nil {
+		t.Fatal("duplicate domain name accepted")
+	}
+	if err := k.CreateSubDomain(ctx, ReservedGovernanceDomain, "South", admin, admin); err == nil {
+		t.Fatal("governance sub-domain accepted")
+	}
+
+	// Sub-domain members must belong to the parent.
+	if err := k.AddMember(ctx, "North", "outsider", admin); !errors.Is(err, sdkerrors.ErrUnauthorized) {
+		t.Fatalf("adding a non-parent member: got %v", err)
+	}
+	if err := k.AddMember(ctx, "North", decisionAlice, admin); err != nil {
+		t.Fatalf("adding a parent member: %v", err)
+	}
+}
+
+func TestParentExclusionCascadesToSubDomains(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupFederation(t, k, ctx)
+	admin := sdk.AccAddress("admin1")
+	if err := k.CreateSubDomain(ctx, "North", "Harbor", admin, admin); err != nil {
+		t.Fatal(err)
+	}
+	for _, domainName := range []string{"North", "Harbor"} {
+		if err := k.AddMember(ctx, domainName, decisionAlice, admin); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := k.VoteToExclude(ctx, "Vote", decisionAlice, admin.String()); err != nil {
+		t.Fatal(err)
+	}
+	excluded, err := k.VoteToExclude(ctx, "Vote", decisionAlice, decisionBob)
+	if err != nil || !excluded {
+		t.Fatalf("exclusion = %v, %v", excluded, err)
+	}
+	for _, domainName := range []string{"Vote", "North", "Harbor"} {
+		if k.IsDomainMember(ctx, domainName, decisionAlice) {
+			t.Fatalf("%s still lists the excluded member", domainName)
+		}
+	}
+}
+
+func TestSubDomainBudget(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupFederation(t, k, ctx)
+	admin := sdk.AccAddress("admin1").String()
+	budget := sdk.NewInt64Coin(PNYXDenom, 600)
+
+	if _, err := k.VoteSubDomainBudget(ctx, "Vote", "North", sdk.NewInt64Coin(PNYXDenom, 1000), admin); !errors.Is(err, sdkerrors.ErrInsufficientFunds) {
+		t.Fatalf("budget above treasury: got %v", err)
+	}
+	if _, err := k.VoteSubDomainBudget(ctx, "Vote", "North", budget, "outsider"); !errors.Is(err, sdkerrors.ErrUnauthorized) {
+		t.Fatalf("non-member vote: got %v", err)
+	}
+	if _, err := k.VoteSubDomainBudget(ctx, "Vote", "Vote", budget, admin); err == nil {
+		t.Fatal("budget for a non-child accepted")
+	}
+
+	for _, vote := range []struct {
+		voter  string
+		amount sdk.Coin
+	}{{admin, budget}, {decisionBob, sdk.NewInt64Coin(PNYXDenom, 300)}, {decisionAlice, budget}} {
+		transferred, err := k.VoteSubDomainBudget(ctx, "Vote", "North", vote.amount, vote.voter)
+		if err != nil || transferred {
+			t.Fatalf("vote by %s = %v, %v", vote.voter, transferred, err)
+		}
+	}
+	if _, err := k.VoteSubDomainBudget(ctx, "Vote", "North", budget, admin); err == nil {
+		t.Fatal("duplicate budget vote accepted")
+	}
+
+	transferred, err := k.VoteSubDomainBudget(ctx, "Vote", "North", budget, decisionBob)
+	if err != nil || !transferred {
+		t.Fatalf("final vote = %v, %v", transferred, err)
+	}
+	parent, _ := k.GetDomainHeader(ctx, "Vote")
+	child, _ := k.GetDomainHeader(ctx, "North")
+	if !parent.Treasury.AmountOf(PNYXDenom).Equal(sdk.NewInt64Coin(PNYXDenom, 300).Amount) ||
+		!child.Treasury.AmountOf(PNYXDenom).Equal(budget.Amount) {
+		t.Fatalf("treasuries = %s / %s", parent.Treasury, child.Treasury)
+	}
+	// Every vote for the child, including bob's other amount, is cleared.
+	if _, err := k.VoteSubDomainBudget(ctx, "Vote", "North", sdk.NewInt64Coin(PNYXDenom, 300), decisionBob); err != nil {
+		t.Fatalf("vote after transfer: %v", err)
+	}
+}
+
+func TestDelegatedIssueDecidedBySubDomain(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupFederation(t, k, ctx)
+	admin := sdk.AccAddress("admin1")
+	closesAt := ctx.BlockTime().Unix() + 3600
+	if err := k.SetIssueClosingRule(ctx, "Vote", "Budget", IssueClosingRule{ClosesAt: closesAt}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := k.DelegateIssueToSubDomain(ctx, "Vote", "Budget", "North", sdk.AccAddress(decisionAlice)); !errors.Is(err, sdkerrors.ErrUnauthorized) {
+		t.Fatalf("non-admin delegation: got %v", err)
+	}
+	if err := k.DelegateIssueToSubDomain(ctx, "Vote", "Missing", "North", admin); err == nil {
+		t.Fatal("delegation of a missing issue accepted")
+	}
+	if err := k.DelegateIssueToSubDomain(ctx, "Vote", "Budget", "North", admin); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.DelegateIssueToSubDomain(ctx, "Vote", "Budget", "North", admin); err == nil {
+		t.Fatal("issue delegated twice")
+	}
+
+	childIssue, found := k.GetIssue(ctx, "North", "Budget")
+	if !found || childIssue.Closing.ClosesAt != closesAt {
+		t.Fatalf("child issue = %+v (found %v)", childIssue, found)
+	}
+	for _, name := range []string{"A", "B"} {
+		if _, found := k.GetSuggestion(ctx, "North", "Budget", name); !found {
+			t.Fatalf("suggestion %s not copied", name)
+		}
+	}
+	if parentIssue, _ := k.GetIssue(ctx, "Vote", "Budget"); parentIssue.SubDomain != "North" {
+		t.Fatalf("parent issue = %+v", parentIssue)
+	}
+	if err := k.SubmitProposal(ctx, "Vote", "Budget", "C", decisionAlice, sdk.NewCoins(), ""); !errors.Is(err, sdkerrors.ErrInvalidRequest) {
+		t.Fatalf("suggestion on delegated issue: got %v", err)
+	}
+
+	for i, value := range []int{-2, 4} {
+		if !k.recordRating(ctx, "North", "Budget", "B", Rating{DomainPubKeyHex: fmt.Sprintf("%064x", i), Value: value}) {
+			t.Fatal("rating not recorded in the sub-domain")
+		}
+	}
+	at := ctx.WithBlockTime(time.Unix(closesAt, 0)).WithBlockHeight(10)
+	if err := k.ProcessIssueDecisions(at); err != nil {
+		t.Fatal(err)
+	}
+	childDecision, found := k.GetIssueDecision(at, "North", "Budget")
+	if !found || childDecision.Winner != "B" {
+		t.Fatalf("child decision = %+v (found %v)", childDecision, found)
+	}
+	adopted, found := k.GetIssueDecision(at, "Vote", "Budget")
+	if !found || adopted.Winner != "B" || adopted.SubDomain != "North" || adopted.DecidedAtHeight != 10 {
+		t.Fatalf("adopted decision = %+v (found %v)", adopted, found)
+	}
+}
+
+func TestDeletedSubDomainIssueReturnsToParent(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupFederation(t, k, ctx)
+	if err := k.DelegateIssueToSubDomain(ctx, "Vote", "Budget", "North", sdk.AccAddress("admin1")); err != nil {
+		t.Fatal(err)
+	}
+	k.deleteIssue(ctx, "North", "Budget")
+
+	if issue, _ := k.GetIssue(ctx, "Vote", "Budget"); issue.SubDomain != "" {
+		t.Fatalf("parent issue still delegated: %+v", issue)
+	}
+	if err := k.SubmitProposal(ctx, "Vote", "Budget", "C", decisionAlice, sdk.NewCoins(), ""); err != nil {
+		t.Fatalf("released issue rejects suggestions: %v", err)
+	}
+}
+
+func TestQuerySubDomains(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	setupFederation(t, k, ctx)
+	admin := sdk.AccAddress("admin1")
+	if err := k.CreateSubDomain(ctx, "Vote", "East", admin, admin); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := k.SubDomains(ctx, &QuerySubDomainsRequest{DomainName: "Vote"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var children []DomainSummary
+	if err := json.Unmarshal(resp.Result, &children); err != nil {
+		t.Fatal(err)
+	}
+	if len(children) != 2 || children[0].Name != "East" || children[1].Name != "North" || children[1].MemberCount != 1 {
+		t.Fatalf("sub-domains = %+v", children)
+	}
+	if _, err := k.SubDomains(ctx, &QuerySubDomainsRequest{DomainName: "Missing"}); err == nil {
+		t.Fatal("sub-domains of a missing domain returned")
+	}
+}
+
+func TestMsgSubDomainValidationAndEncoding(t *testing.T) {
+	sender := sdk.AccAddress("admin1")
+	budget := MsgVoteSubDomainBudget{
+		Sender:       sender,
+		ParentDomain: "Vote",
+		SubDomain:    "North",
+		Amount:       sdk.NewInt64Coin(PNYXDenom, 600),
+		VoterAddr:    sender.String(),
+	}
+	if err := budget.ValidateBasic(); err != nil {
+		t.Fatalf("valid budget vote rejected: %v", err)
+	}
+	bz, err := gogoproto.Marshal(&budget)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded MsgVoteSubDomainBudget
+	if err := gogoproto.Unmarshal(bz, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, budget) {
+		t.Fatalf("decoded = %+v", decoded)
+	}
+	budget.VoterAddr = sdk.AccAddress("someone-else").String()
+	if err := budget.ValidateBasic(); err == nil {
+		t.Fatal("budget vote for another member accepted")
+	}
+
+	create := MsgCreateSubDomain{Sender: sender, ParentDomain: "Vote", Name: "North"}
+	if err := create.ValidateBasic(); err != nil {
+		t.Fatalf("valid creation rejected: %v", err)
+	}
+	if bz, indexes := create.Descriptor(); len(bz) == 0 || len(indexes) == 0 {
+		t.Fatal("message descriptor missing")
+	}
+	create.Admin = "not-an-address"
+	if err := create.ValidateBasic(); err == nil {
+		t.Fatal("invalid admin accepted")
+	}
+
+	delegate := MsgDelegateIssueToSubDomain{Sender: sender, DomainName: "Vote", IssueName: "Budget", SubDomain: "Vote"}
+	if err := delegate.ValidateBasic(); err == nil {
+		t.Fatal("delegation to the domain itself accepted")
+	}
+}
+
+func TestGenesisSubDomains(t *testing.T) {
+	genesis := validDemocracyGenesis()
+	parent := genesis.Domains[0]
+	parent.Issues = []Issue{{Name: "Budget", SubDomain: "Child"}}
+	child := Domain{
+		Name:          "Child",
+		Parent:        "Test",
+		Admin:         parent.Admin,
+		Members:       []string{parent.Admin.String()},
+		Treasury:      sdk.NewCoins(),
+		Issues:        []Issue{{Name: "Budget"}},
+		Options:       parent.Options,
+		PermissionReg: []string{},
+	}
+	genesis.Domains = []Domain{parent, child}
+	if err := ValidateGenesisState(genesis); err != nil {
+		t.Fatalf("valid sub-domain rejected: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func([]Domain)
+	}{
+		{"missing parent", func(d []Domain) { d[1].Parent = "Missing" }},
+		{"own parent", func(d []Domain) { d[1].Parent = "Child" }},
+		{"cycle", func(d []Domain) { d[0].Parent = "Child" }},
+		{"non-parent member", func(d []Domain) { d[1].Members = append(d[1].Members, sdk.AccAddress("outsider").String()) }},
+		{"issue delegated to a non-child", func(d []Domain) { d[0].Issues = []Issue{{Name: "Budget", SubDomain: "Test"}} }},
+		{"issue missing from the child", func(d []Domain) { d[1].Issues = []Issue{} }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := genesis
+			g.Domains = append([]Domain(nil), genesis.Domains...)
+			tc.mutate(g.Domains)
+			if err := ValidateGenesisState(g); err == nil {
+				t.Fatal("invalid genesis accepted")
+			}
+		})
+	}
+}
+
+func TestGenesisRoundTripPreservesSubDomains(t *testing.T) {
+	am1, k1, ctx1 := setupModuleForGenesis(t)
+	admin := sdk.AccAddress("admin1")
+	k1.CreateDomain(ctx1, "Federation", admin, sdk.NewCoins())
+	if err := k1.CreateSubDomain(ctx1, "Federation", "Region", admin, admin); err != nil {
+		t.Fatal(err)
+	}
+
+	exported := am1.ExportGenesis(ctx1, nil)
+	am2, k2, ctx2 := setupModuleForGenesis(t)
+	am2.InitGenesis(ctx2, nil, exported)
+
+	if children := k2.GetSubDomains(ctx2, "Federation"); !reflect.DeepEqual(children, []string{"Region"}) {
+		t.Fatalf("sub-domains after import = %v", children)
+	}
+	if err := k2.AddMember(ctx2, "Region", sdk.AccAddress("outsider").String(), admin); err == nil {
+		t.Fatal("parent membership not enforced after import")
+	}
+}
@@ -3,7 +3,9 @@ package truedemocracy
 // Treasury bridge: connects x/bank user accounts with Domain.Treasury
 // (custom accounting). Deposits move PNYX from a user's bank balance into
 // the truedemocracy module account and increment Domain.Treasury. Withdrawals
-// do the reverse (admin authorization required).
+// do the reverse (admin authorization required) and count toward the
+// domain's PayoutCapPerEpoch like voted payouts; without a cap they are held
+// to AdminWithdrawalCapBps of the treasury per epoch (treasury_payout.go).
 
 import (
 	errorsmod "cosmossdk.io/errors"
@@ -19,7 +21,7 @@ func (k Keeper) DepositToDomain(ctx sdk.Context, depositor sdk.AccAddress, domai
 		return errorsmod.Wrap(sdkerrors.ErrLogic, "bank keeper not available")
 	}
 
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return errorsmod.Wrapf(sdkerrors.ErrNotFound, "domain %s not found", domainName)
 	}
@@ -42,9 +44,7 @@ func (k Keeper) DepositToDomain(ctx sdk.Context, depositor sdk.AccAddress, domai
 	// Credit domain treasury.
 	domain.Treasury = domain.Treasury.Add(amount)
 
-	store := cacheCtx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	k.SetDomainHeader(cacheCtx, domain)
 
 	cacheCtx.EventManager().EmitEvent(sdk.NewEvent(
 		"domain_deposit",
@@ -58,7 +58,9 @@ func (k Keeper) DepositToDomain(ctx sdk.Context, depositor sdk.AccAddress, domai
 }
 
 // WithdrawFromDomain transfers PNYX from a domain's treasury to a recipient's
-// bank account. Only the domain admin may authorize withdrawals.
+// bank account. Only the domain admin may authorize withdrawals, and only
+// within what is left of the domain's payout cap for the epoch, or of
+// AdminWithdrawalCapBps of the treasury when the domain sets no cap.
 // The coins move: truedemocracy module account → recipient (via x/bank),
 // and Domain.Treasury is decremented.
 func (k Keeper) WithdrawFromDomain(ctx sdk.Context, domainName string, recipient sdk.AccAddress, amount sdk.Coin, authorizer sdk.AccAddress) error {
@@ -66,7 +68,7 @@ func (k Keeper) WithdrawFromDomain(ctx sdk.Context, domainName string, recipient
 		return errorsmod.Wrap(sdkerrors.ErrLogic, "bank keeper not available")
 	}
 
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return errorsmod.Wrapf(sdkerrors.ErrNotFound, "domain %s not found", domainName)
 	}
@@ -89,6 +91,18 @@ func (k Keeper) WithdrawFromDomain(ctx sdk.Context, domainName string, recipient
 			"domain treasury has %s upnyx, requested %s",
 			domain.Treasury.AmountOf(PNYXDenom), amount.Amount)
 	}
+	rollPayoutEpoch(&domain, ctx.BlockTime().Unix())
+	if !amount.Amount.IsInt64() || !adminWithdrawalAllows(domain, amount.Amount.Int64()) {
+		if domain.Options.PayoutCapPerEpoch == 0 {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest,
+				"withdrawal exceeds the admin limit of %d bps of the treasury per epoch (%d upnyx spent); larger sums need a member-voted payout",
+				AdminWithdrawalCapBps, domain.PayoutEpochSpent)
+		}
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest,
+			"withdrawal exceeds the domain's payout cap of %d upnyx per epoch (%d spent)",
+			domain.Options.PayoutCapPerEpoch, domain.PayoutEpochSpent)
+	}
+	domain.PayoutEpochSpent += amount.Amount.Int64()
 
 	cacheCtx, write := ctx.CacheContext()
 
@@ -101,9 +115,7 @@ func (k Keeper) WithdrawFromDomain(ctx sdk.Context, domainName string, recipient
 		return errorsmod.Wrap(err, "bank transfer failed")
 	}
 
-	store := cacheCtx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	k.SetDomainHeader(cacheCtx, domain)
 
 	cacheCtx.EventManager().EmitEvent(sdk.NewEvent(
 		"domain_withdrawal",
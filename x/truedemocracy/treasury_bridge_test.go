@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"cosmossdk.io/math"
 	storetypes "cosmossdk.io/store/types"
@@ -271,27 +272,27 @@ func TestWithdrawFromDomain(t *testing.T) {
 	bk.fundModule(ModuleName, sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 5000)))
 
 	t.Run("success", func(t *testing.T) {
-		err := k.WithdrawFromDomain(ctx, "WithdrawDomain", recipient, sdk.NewInt64Coin(PNYXDenom, 1000), admin)
+		err := k.WithdrawFromDomain(ctx, "WithdrawDomain", recipient, sdk.NewInt64Coin(PNYXDenom, 500), admin)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 
 		// Recipient received coins.
 		recipientBal := bk.accounts[recipient.String()]
-		if recipientBal.AmountOf(PNYXDenom).Int64() != 1000 {
-			t.Errorf("recipient balance = %d, want 1000", recipientBal.AmountOf(PNYXDenom).Int64())
+		if recipientBal.AmountOf(PNYXDenom).Int64() != 500 {
+			t.Errorf("recipient balance = %d, want 500", recipientBal.AmountOf(PNYXDenom).Int64())
 		}
 
 		// Domain treasury decreased.
 		domain, _ := k.GetDomain(ctx, "WithdrawDomain")
-		if domain.Treasury.AmountOf(PNYXDenom).Int64() != 4000 {
-			t.Errorf("treasury = %d, want 4000", domain.Treasury.AmountOf(PNYXDenom).Int64())
+		if domain.Treasury.AmountOf(PNYXDenom).Int64() != 4500 {
+			t.Errorf("treasury = %d, want 4500", domain.Treasury.AmountOf(PNYXDenom).Int64())
 		}
 
 		// Module account debited.
 		modBal := bk.modules[ModuleName]
-		if modBal.AmountOf(PNYXDenom).Int64() != 4000 {
-			t.Errorf("module balance = %d, want 4000", modBal.AmountOf(PNYXDenom).Int64())
+		if modBal.AmountOf(PNYXDenom).Int64() != 4500 {
+			t.Errorf("module balance = %d, want 4500", modBal.AmountOf(PNYXDenom).Int64())
 		}
 	})
 
@@ -310,6 +311,46 @@ func TestWithdrawFromDomain(t *testing.T) {
 		}
 	})
 
+	t.Run("payout cap", func(t *testing.T) {
+		domain, _ := k.GetDomainHeader(ctx, "WithdrawDomain")
+		domain.Options.PayoutCapPerEpoch = 1500
+		k.SetDomainHeader(ctx, domain)
+		defer func() {
+			domain, _ := k.GetDomainHeader(ctx, "WithdrawDomain")
+			domain.Options.PayoutCapPerEpoch = 0
+			k.SetDomainHeader(ctx, domain)
+		}()
+
+		// Fresh epoch: the earlier withdrawal no longer counts.
+		next := ctx.WithBlockTime(ctx.BlockTime().Add(time.Duration(PayoutEpochSecs) * time.Second))
+		if err := k.WithdrawFromDomain(next, "WithdrawDomain", recipient, sdk.NewInt64Coin(PNYXDenom, 1000), admin); err != nil {
+			t.Fatalf("withdrawal within the cap: %v", err)
+		}
+		if err := k.WithdrawFromDomain(next, "WithdrawDomain", recipient, sdk.NewInt64Coin(PNYXDenom, 600), admin); err == nil {
+			t.Fatal("expected error for withdrawal beyond the payout cap")
+		}
+		if domain, _ := k.GetDomainHeader(next, "WithdrawDomain"); domain.PayoutEpochSpent != 1000 {
+			t.Errorf("epoch spent = %d, want 1000", domain.PayoutEpochSpent)
+		}
+	})
+
+	t.Run("default admin limit", func(t *testing.T) {
+		// Without a cap the admin may take a tenth of the 3500 left per epoch.
+		later := ctx.WithBlockTime(ctx.BlockTime().Add(2 * time.Duration(PayoutEpochSecs) * time.Second))
+		if err := k.WithdrawFromDomain(later, "WithdrawDomain", recipient, sdk.NewInt64Coin(PNYXDenom, 351), admin); err == nil {
+			t.Fatal("expected error for withdrawal beyond the admin limit")
+		}
+		if err := k.WithdrawFromDomain(later, "WithdrawDomain", recipient, sdk.NewInt64Coin(PNYXDenom, 300), admin); err != nil {
+			t.Fatalf("withdrawal within the admin limit: %v", err)
+		}
+		if err := k.WithdrawFromDomain(later, "WithdrawDomain", recipient, sdk.NewInt64Coin(PNYXDenom, 51), admin); err == nil {
+			t.Fatal("expected error once the epoch's admin limit is spent")
+		}
+		if err := k.WithdrawFromDomain(later, "WithdrawDomain", recipient, sdk.NewInt64Coin(PNYXDenom, 50), admin); err != nil {
+			t.Fatalf("withdrawal up to the admin limit: %v", err)
+		}
+	})
+
 	t.Run("domain not found", func(t *testing.T) {
 		err := k.WithdrawFromDomain(ctx, "NoSuchDomain", recipient, sdk.NewInt64Coin(PNYXDenom, 100), admin)
 		if err == nil {
@@ -360,20 +401,20 @@ func TestDepositWithdrawRoundTrip(t *testing.T) {
 		t.Errorf("treasury after deposit = %d, want 500", domain.Treasury.AmountOf(PNYXDenom).Int64())
 	}
 
-	// Admin withdraws 200 back to user.
-	err = k.WithdrawFromDomain(ctx, "RoundTrip", user, sdk.NewInt64Coin(PNYXDenom, 200), admin)
+	// Admin withdraws 50, the most the default admin limit allows, back to user.
+	err = k.WithdrawFromDomain(ctx, "RoundTrip", user, sdk.NewInt64Coin(PNYXDenom, 50), admin)
 	if err != nil {
 		t.Fatalf("withdraw: %v", err)
 	}
 
 	// Verify final state.
 	userBal = bk.accounts[user.String()]
-	if userBal.AmountOf(PNYXDenom).Int64() != 700 {
-		t.Errorf("user after withdraw = %d, want 700 (500+200)", userBal.AmountOf(PNYXDenom).Int64())
+	if userBal.AmountOf(PNYXDenom).Int64() != 550 {
+		t.Errorf("user after withdraw = %d, want 550 (500+50)", userBal.AmountOf(PNYXDenom).Int64())
 	}
 	domain, _ = k.GetDomain(ctx, "RoundTrip")
-	if domain.Treasury.AmountOf(PNYXDenom).Int64() != 300 {
-		t.Errorf("treasury after withdraw = %d, want 300 (500-200)", domain.Treasury.AmountOf(PNYXDenom).Int64())
+	if domain.Treasury.AmountOf(PNYXDenom).Int64() != 450 {
+		t.Errorf("treasury after withdraw = %d, want 450 (500-50)", domain.Treasury.AmountOf(PNYXDenom).Int64())
 	}
 }
 
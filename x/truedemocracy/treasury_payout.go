@@ -0,0 +1,341 @@
+package truedemocracy
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Treasury payouts let a domain spend its treasury by consensus instead of
+// admin withdrawal. A suggestion may carry a TreasuryPayout; the payout is
+// approved once, either when the suggestion has stayed green for its dwell
+// time or when it wins its issue, and is then paid from Domain.Treasury
+// through the bank keeper, in equal tranches when it vests. KV keys:
+//   "payout:{d}{i}{suggestion}"             → PayoutRecord
+//   "payout-due:{dueAt}{d}{i}{suggestion}"  → empty (tranche schedule)
+//
+// {d} and {i} are length-prefixed domain and issue scopes and dueAt is an
+// 8-byte big-endian unix time. Each domain pays at most
+// DomainOptions.PayoutCapPerEpoch per PayoutEpochSecs, admin withdrawals
+// included. Without a cap, admin withdrawals are still held to
+// AdminWithdrawalCapBps of the treasury per epoch. A tranche the cap cannot cover is rescheduled for the next epoch,
+// one the treasury cannot cover for PayoutRetrySecs later; later tranches
+// keep their vesting times.
+
+const (
+	PayoutTriggerGreen    = "green"
+	PayoutTriggerDecision = "decision"
+
+	PayoutEpochSecs   int64 = 604_800 // 7 days
+	PayoutRetrySecs   int64 = 3_600   // 1 hour
+	MaxPayoutTranches int64 = 120
+
+	// AdminWithdrawalCapBps bounds the admin withdrawals of a domain without
+	// a PayoutCapPerEpoch, in basis points of its treasury per epoch. Larger
+	// sums go through member-voted payouts.
+	AdminWithdrawalCapBps int64 = 1_000 // 10%
+)
+
+func payoutScope(domainName, issueName, suggestionName string) []byte {
+	return append(append(domainScope(domainName), domainScope(issueName)...), suggestionName...)
+}
+
+func payoutPrefix(domainName string) []byte {
+	return append([]byte("payout:"), domainScope(domainName)...)
+}
+
+func payoutKey(domainName, issueName, suggestionName string) []byte {
+	return append([]byte("payout:"), payoutScope(domainName, issueName, suggestionName)...)
+}
+
+func payoutDueKey(dueAt int64, domainName, issueName, suggestionName string) []byte {
+	key := binary.BigEndian.AppendUint64([]byte("payout-due:"), uint64(dueAt))
+	return append(key, payoutScope(domainName, issueName, suggestionName)...)
+}
+
+// splitPayoutScope reverses payoutScope.
+func splitPayoutScope(bz []byte) (string, string, string, error) {
+	domainName, rest, err := splitDecisionScope(bz)
+	if err != nil {
+		return "", "", "", err
+	}
+	issueName, suggestionName, err := splitDecisionScope([]byte(rest))
+	if err != nil {
+		return "", "", "", fmt.Errorf("malformed payout key")
+	}
+	return domainName, issueName, suggestionName, nil
+}
+
+// validateTreasuryPayout checks a payout's recipient, amount and vesting
+// schedule.
+func validateTreasuryPayout(payout TreasuryPayout) error {
+	if _, err := sdk.AccAddressFromBech32(payout.Recipient); err != nil {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidAddress, "invalid payout recipient: %s", err)
+	}
+	if payout.Amount <= 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "payout amount must be positive")
+	}
+	if payout.Tranches < 0 || payout.Tranches > MaxPayoutTranches {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "payout tranches must be between 0 and %d", MaxPayoutTranches)
+	}
+	if payout.Tranches > 1 {
+		if payout.Interval <= 0 {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "vesting payouts need a positive interval")
+		}
+		if payout.Amount < payout.Tranches {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "payout amount is smaller than its tranche count")
+		}
+	} else if payout.Interval != 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "a single payment takes no interval")
+	}
+	return nil
+}
+
+// trancheCount returns the number of payments of a payout.
+func (p TreasuryPayout) trancheCount() int64 {
+	return max(p.Tranches, 1)
+}
+
+// trancheAmount returns the amount of the given zero-based tranche. The last
+// tranche carries the rounding remainder.
+func (p TreasuryPayout) trancheAmount(index int64) int64 {
+	n := p.trancheCount()
+	share := p.Amount / n
+	if index == n-1 {
+		return p.Amount - share*(n-1)
+	}
+	return share
+}
+
+// SetSuggestionPayout attaches a payout to a suggestion of an open issue. Like
+// the issue's closing rule it is fixed once set.
+func (k Keeper) SetSuggestionPayout(ctx sdk.Context, domainName, issueName, suggestionName string, payout TreasuryPayout) error {
+	if err := validateTreasuryPayout(payout); err != nil {
+		return err
+	}
+	suggestion, found := k.GetSuggestion(ctx, domainName, issueName, suggestionName)
+	if !found {
+		return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "suggestion not found")
+	}
+	if err := k.requireIssueOpen(ctx, domainName, issueName); err != nil {
+		return err
+	}
+	if suggestion.Payout != nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "suggestion already carries a payout")
+	}
+	suggestion.Payout = &payout
+	k.SetSuggestion(ctx, domainName, issueName, suggestion)
+	return nil
+}
+
+// GetPayoutRecord returns the approved payout of a suggestion.
+func (k Keeper) GetPayoutRecord(ctx sdk.Context, domainName, issueName, suggestionName string) (PayoutRecord, bool) {
+	bz := ctx.KVStore(k.StoreKey).Get(payoutKey(domainName, issueName, suggestionName))
+	if bz == nil {
+		return PayoutRecord{}, false
+	}
+	var record PayoutRecord
+	k.cdc.MustUnmarshalLengthPrefixed(bz, &record)
+	return record, true
+}
+
+// setPayoutRecord stores a payout record and indexes its next tranche.
+func (k Keeper) setPayoutRecord(ctx sdk.Context, record PayoutRecord) {
+	store := ctx.KVStore(k.StoreKey)
+	store.Set(payoutKey(record.DomainName, record.IssueName, record.SuggestionName), k.cdc.MustMarshalLengthPrefixed(&record))
+	if record.NextPaymentAt > 0 {
+		store.Set(payoutDueKey(record.NextPaymentAt, record.DomainName, record.IssueName, record.SuggestionName), []byte{})
+	}
+}
+
+// IteratePayoutRecords walks all payout records in domain, issue and
+// suggestion order.
+func (k Keeper) IteratePayoutRecords(ctx sdk.Context, fn func(PayoutRecord) bool) {
+	prefix := []byte("payout:")
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var record PayoutRecord
+		k.cdc.MustUnmarshalLengthPrefixed(iter.Value(), &record)
+		if fn(record) {
+			return
+		}
+	}
+}
+
+// approvePayout records a suggestion's payout as approved and schedules its
+// first tranche for the current block. A payout is approved at most once.
+func (k Keeper) approvePayout(ctx sdk.Context, domainName, issueName string, suggestion Suggestion, trigger string) {
+	if suggestion.Payout == nil {
+		return
+	}
+	if _, exists := k.GetPayoutRecord(ctx, domainName, issueName, suggestion.Name); exists {
+		return
+	}
+	now := ctx.BlockTime().Unix()
+	record := PayoutRecord{
+		DomainName:       domainName,
+		IssueName:        issueName,
+		SuggestionName:   suggestion.Name,
+		Payout:           *suggestion.Payout,
+		Trigger:          trigger,
+		ApprovedAtHeight: ctx.BlockHeight(),
+		ApprovedAt:       now,
+		NextPaymentAt:    now,
+	}
+	k.setPayoutRecord(ctx, record)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"treasury_payout_approved",
+		sdk.NewAttribute("domain", domainName),
+		sdk.NewAttribute("issue", issueName),
+		sdk.NewAttribute("suggestion", suggestion.Name),
+		sdk.NewAttribute("recipient", record.Payout.Recipient),
+		sdk.NewAttribute("amount", sdk.NewInt64Coin(PNYXDenom, record.Payout.Amount).String()),
+		sdk.NewAttribute("trigger", trigger),
+	))
+}
+
+// approveGreenPayout approves the payout of a suggestion that has stayed
+// green for its dwell time, as long as its issue still takes votes.
+func (k Keeper) approveGreenPayout(ctx sdk.Context, domain Domain, issueName string, suggestion Suggestion) {
+	if suggestion.Payout == nil || suggestion.Color != "green" || suggestion.EnteredGreenAt == 0 {
+		return
+	}
+	if ctx.BlockTime().Unix() < suggestion.EnteredGreenAt+effectiveDwellTime(suggestion, domain.Options) {
+		return
+	}
+	if k.requireIssueOpen(ctx, domain.Name, issueName) != nil {
+		return
+	}
+	k.approvePayout(ctx, domain.Name, issueName, suggestion, PayoutTriggerGreen)
+}
+
+// rollPayoutEpoch starts a new payout epoch for the domain once the current
+// one has ended.
+func rollPayoutEpoch(domain *Domain, now int64) {
+	if epoch := now / PayoutEpochSecs; domain.PayoutEpoch != epoch {
+		domain.PayoutEpoch = epoch
+		domain.PayoutEpochSpent = 0
+	}
+}
+
+// payoutCapAllows reports whether amount fits in what is left of the
+// domain's PayoutCapPerEpoch.
+func payoutCapAllows(domain Domain, amount int64) bool {
+	limit := domain.Options.PayoutCapPerEpoch
+	return limit <= 0 || domain.PayoutEpochSpent+amount <= limit
+}
+
+// adminWithdrawalAllows reports whether an admin withdrawal of amount fits in
+// the domain's PayoutCapPerEpoch or, without one, in AdminWithdrawalCapBps of
+// the treasury before this epoch's payouts.
+func adminWithdrawalAllows(domain Domain, amount int64) bool {
+	if domain.Options.PayoutCapPerEpoch > 0 {
+		return payoutCapAllows(domain, amount)
+	}
+	spent := math.NewInt(domain.PayoutEpochSpent)
+	limit := domain.Treasury.AmountOf(PNYXDenom).Add(spent).MulRaw(AdminWithdrawalCapBps).QuoRaw(10_000)
+	return spent.AddRaw(amount).LTE(limit)
+}
+
+// ProcessTreasuryPayouts pays every tranche that has fallen due. Called from
+// EndBlock. Tranches the domain treasury or epoch cap cannot cover are
+// rescheduled.
+func (k Keeper) ProcessTreasuryPayouts(ctx sdk.Context) error {
+	if k.bankKeeper == nil {
+		return nil
+	}
+	store := ctx.KVStore(k.StoreKey)
+	now := ctx.BlockTime().Unix()
+
+	type duePayout struct {
+		domainName, issueName, suggestionName string
+		dueAt                                 int64
+	}
+	var due []duePayout
+	duePrefix := []byte("payout-due:")
+	end := binary.BigEndian.AppendUint64(append([]byte{}, duePrefix...), uint64(now)+1)
+	iter := store.Iterator(duePrefix, end)
+	for ; iter.Valid(); iter.Next() {
+		rest := iter.Key()[len(duePrefix):]
+		domainName, issueName, suggestionName, err := splitPayoutScope(rest[8:])
+		if err != nil {
+			iter.Close()
+			return err
+		}
+		due = append(due, duePayout{domainName, issueName, suggestionName, int64(binary.BigEndian.Uint64(rest[:8]))})
+	}
+	iter.Close()
+
+	for _, d := range due {
+		record, found := k.GetPayoutRecord(ctx, d.domainName, d.issueName, d.suggestionName)
+		if !found || record.NextPaymentAt != d.dueAt {
+			store.Delete(payoutDueKey(d.dueAt, d.domainName, d.issueName, d.suggestionName))
+			continue
+		}
+		store.Delete(payoutDueKey(d.dueAt, d.domainName, d.issueName, d.suggestionName))
+		if retryAt := k.payTranche(ctx, record); retryAt > 0 {
+			record.NextPaymentAt = retryAt
+			k.setPayoutRecord(ctx, record)
+		}
+	}
+	return nil
+}
+
+// payTranche pays the record's next tranche if the treasury and the epoch cap
+// allow it. It returns zero once the tranche is settled, or when to retry a
+// tranche that could not be paid: the next epoch if the cap is spent,
+// PayoutRetrySecs from now otherwise.
+func (k Keeper) payTranche(ctx sdk.Context, record PayoutRecord) int64 {
+	domain, found := k.GetDomainHeader(ctx, record.DomainName)
+	if !found {
+		return 0 // the domain is gone; nothing left to pay from
+	}
+	recipient, err := sdk.AccAddressFromBech32(record.Payout.Recipient)
+	if err != nil {
+		return 0
+	}
+	now := ctx.BlockTime().Unix()
+	amount := record.Payout.trancheAmount(record.TranchesPaid)
+	if domain.Treasury.AmountOf(PNYXDenom).LT(math.NewInt(amount)) {
+		return now + PayoutRetrySecs
+	}
+	rollPayoutEpoch(&domain, now)
+	if !payoutCapAllows(domain, amount) {
+		return (domain.PayoutEpoch + 1) * PayoutEpochSecs
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	coin := sdk.NewInt64Coin(PNYXDenom, amount)
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(cacheCtx, ModuleName, recipient, sdk.NewCoins(coin)); err != nil {
+		return now + PayoutRetrySecs
+	}
+	domain.Treasury = domain.Treasury.Sub(coin)
+	domain.PayoutEpochSpent += amount
+	k.SetDomainHeader(cacheCtx, domain)
+
+	record.TranchesPaid++
+	record.Paid += amount
+	record.NextPaymentAt = 0
+	if record.TranchesPaid < record.Payout.trancheCount() {
+		record.NextPaymentAt = record.ApprovedAt + record.TranchesPaid*record.Payout.Interval
+	}
+	k.setPayoutRecord(cacheCtx, record)
+
+	cacheCtx.EventManager().EmitEvent(sdk.NewEvent(
+		"treasury_payout",
+		sdk.NewAttribute("domain", record.DomainName),
+		sdk.NewAttribute("issue", record.IssueName),
+		sdk.NewAttribute("suggestion", record.SuggestionName),
+		sdk.NewAttribute("recipient", record.Payout.Recipient),
+		sdk.NewAttribute("amount", coin.String()),
+		sdk.NewAttribute("tranche", fmt.Sprintf("%d/%d", record.TranchesPaid, record.Payout.trancheCount())),
+	))
+	write()
+	return 0
+}
@@ -0,0 +1,364 @@
+package truedemocracy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+)
+
+var payoutRecipient = sdk.AccAddress("payout-recipient")
+
+// setupPayoutDomain creates "PayDomain" with ten members, a 10,000 upnyx
+// treasury backed by the module account, and issue "Grants" whose suggestion
+// "Build" carries the given payout.
+func setupPayoutDomain(t *testing.T, payout TreasuryPayout) (Keeper, sdk.Context, *mockBankKeeper) {
+	t.Helper()
+	k, ctx, bk := setupKeeperWithBank(t)
+	treasury := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 10_000))
+	k.CreateDomain(ctx, "PayDomain", sdk.AccAddress("admin1"), treasury)
+	bk.fundModule(ModuleName, treasury)
+
+	domain, _ := k.GetDomain(ctx, "PayDomain")
+	domain.Members = []string{"m1", "m2", "m3", "m4", "m5", "m6", "m7", "m8", "m9", "m10"}
+	now := ctx.BlockTime().Unix()
+	domain.Issues = []Issue{{
+		Name: "Grants", CreationDate: now,
+		Suggestions: []Suggestion{
+			{Name: "Build", Creator: "m1", CreationDate: now},
+			{Name: "Repair", Creator: "m2", CreationDate: now},
+		},
+	}}
+	k.SetDomain(ctx, domain)
+	if err := k.SetSuggestionPayout(ctx, "PayDomain", "Grants", "Build", payout); err != nil {
+		t.Fatalf("SetSuggestionPayout: %v", err)
+	}
+	return k, ctx, bk
+}
+
+func recipientBalance(bk *mockBankKeeper) int64 {
+	return bk.accounts[payoutRecipient.String()].AmountOf(PNYXDenom).Int64()
+}
+
+func TestGreenSuggestionPayoutAfterDwellTime(t *testing.T) {
+	k, ctx, bk := setupPayoutDomain(t, TreasuryPayout{Recipient: payoutRecipient.String(), Amount: 2_500})
+	setSuggestionStones(t, k, ctx, "PayDomain", 0, 0, 1)
+	start := ctx.BlockTime().Unix()
+
+	if err := k.EvaluateSuggestionZones(ctx, "PayDomain"); err != nil {
+		t.Fatal(err)
+	}
+	suggestion, _ := k.GetSuggestion(ctx, "PayDomain", "Grants", "Build")
+	if suggestion.Color != "green" || suggestion.EnteredGreenAt != start {
+		t.Fatalf("suggestion = %+v", suggestion)
+	}
+
+	before := ctx.WithBlockTime(time.Unix(start+DefaultDwellTimeSecs-1, 0))
+	k.EvaluateSuggestionZones(before, "PayDomain")
+	if _, found := k.GetPayoutRecord(before, "PayDomain", "Grants", "Build"); found {
+		t.Fatal("payout approved before the dwell time")
+	}
+
+	at := ctx.WithBlockTime(time.Unix(start+DefaultDwellTimeSecs, 0)).WithBlockHeight(7)
+	k.EvaluateSuggestionZones(at, "PayDomain")
+	if err := k.ProcessTreasuryPayouts(at); err != nil {
+		t.Fatal(err)
+	}
+	record, found := k.GetPayoutRecord(at, "PayDomain", "Grants", "Build")
+	if !found || record.Trigger != PayoutTriggerGreen || record.ApprovedAtHeight != 7 || record.Paid != 2_500 || record.NextPaymentAt != 0 {
+		t.Fatalf("record = %+v (found %v)", record, found)
+	}
+	if got := recipientBalance(bk); got != 2_500 {
+		t.Fatalf("recipient balance = %d", got)
+	}
+	domain, _ := k.GetDomainHeader(at, "PayDomain")
+	if domain.Treasury.AmountOf(PNYXDenom).Int64() != 7_500 {
+		t.Fatalf("treasury = %s", domain.Treasury)
+	}
+
+	// The payout is approved and paid only once.
+	later := at.WithBlockTime(time.Unix(start+2*DefaultDwellTimeSecs, 0))
+	k.EvaluateSuggestionZones(later, "PayDomain")
+	k.ProcessTreasuryPayouts(later)
+	if got := recipientBalance(bk); got != 2_500 {
+		t.Fatalf("recipient paid twice: %d", got)
+	}
+}
+
+func TestGreenPayoutTimerRestartsAfterLeavingGreen(t *testing.T) {
+	k, ctx, _ := setupPayoutDomain(t, TreasuryPayout{Recipient: payoutRecipient.String(), Amount: 100})
+	start := ctx.BlockTime().Unix()
+	setSuggestionStones(t, k, ctx, "PayDomain", 0, 0, 1)
+	k.EvaluateSuggestionZones(ctx, "PayDomain")
+
+	setSuggestionStones(t, k, ctx, "PayDomain", 0, 0, 0)
+	dropped := ctx.WithBlockTime(time.Unix(start+60, 0))
+	k.EvaluateSuggestionZones(dropped, "PayDomain")
+	setSuggestionStones(t, k, ctx, "PayDomain", 0, 0, 1)
+	regained := ctx.WithBlockTime(time.Unix(start+120, 0))
+	k.EvaluateSuggestionZones(regained, "PayDomain")
+
+	at := ctx.WithBlockTime(time.Unix(start+DefaultDwellTimeSecs, 0))
+	k.EvaluateSuggestionZones(at, "PayDomain")
+	if _, found := k.GetPayoutRecord(at, "PayDomain", "Grants", "Build"); found {
+		t.Fatal("payout approved although the suggestion left the green zone")
+	}
+	at = ctx.WithBlockTime(time.Unix(start+120+DefaultDwellTimeSecs, 0))
+	k.EvaluateSuggestionZones(at, "PayDomain")
+	if _, found := k.GetPayoutRecord(at, "PayDomain", "Grants", "Build"); !found {
+		t.Fatal("payout not approved after a full green dwell time")
+	}
+}
+
+func TestWinningSuggestionPayoutVests(t *testing.T) {
+	k, ctx, bk := setupPayoutDomain(t, TreasuryPayout{Recipient: payoutRecipient.String(), Amount: 1_000, Tranches: 3, Interval: 100})
+	closesAt := ctx.BlockTime().Unix() + 3600
+	if err := k.SetIssueClosingRule(ctx, "PayDomain", "Grants", IssueClosingRule{ClosesAt: closesAt}); err != nil {
+		t.Fatal(err)
+	}
+	for i, value := range []int{4, 5} {
+		if !k.recordRating(ctx, "PayDomain", "Grants", "Build", Rating{DomainPubKeyHex: fmt.Sprintf("%064x", i), Value: value}) {
+			t.Fatal("rating not recorded")
+		}
+	}
+
+	at := ctx.WithBlockTime(time.Unix(closesAt, 0))
+	if err := k.ProcessIssueDecisions(at); err != nil {
+		t.Fatal(err)
+	}
+	for _, step := range []struct {
+		offset int64
+		paid   int64
+	}{{0, 333}, {50, 333}, {100, 666}, {200, 1_000}, {300, 1_000}} {
+		now := at.WithBlockTime(time.Unix(closesAt+step.offset, 0))
+		if err := k.ProcessTreasuryPayouts(now); err != nil {
+			t.Fatal(err)
+		}
+		if got := recipientBalance(bk); got != step.paid {
+			t.Fatalf("paid after %ds = %d, want %d", step.offset, got, step.paid)
+		}
+	}
+	record, _ := k.GetPayoutRecord(at, "PayDomain", "Grants", "Build")
+	if record.Trigger != PayoutTriggerDecision || record.TranchesPaid != 3 || record.NextPaymentAt != 0 {
+		t.Fatalf("record = %+v", record)
+	}
+	if _, found := k.GetPayoutRecord(at, "PayDomain", "Grants", "Repair"); found {
+		t.Fatal("losing suggestion approved")
+	}
+}
+
+func TestPayoutEpochCapAndTreasuryDeferPayments(t *testing.T) {
+	k, ctx, bk := setupPayoutDomain(t, TreasuryPayout{Recipient: payoutRecipient.String(), Amount: 400})
+	domain, _ := k.GetDomainHeader(ctx, "PayDomain")
+	domain.Options.PayoutCapPerEpoch = 500
+	k.SetDomainHeader(ctx, domain)
+	if err := k.SetSuggestionPayout(ctx, "PayDomain", "Grants", "Repair", TreasuryPayout{Recipient: payoutRecipient.String(), Amount: 400}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Build", "Repair"} {
+		s, _ := k.GetSuggestion(ctx, "PayDomain", "Grants", name)
+		k.approvePayout(ctx, "PayDomain", "Grants", s, PayoutTriggerGreen)
+	}
+
+	if err := k.ProcessTreasuryPayouts(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := recipientBalance(bk); got != 400 {
+		t.Fatalf("paid in the first epoch = %d, want 400", got)
+	}
+	next := ctx.WithBlockTime(ctx.BlockTime().Add(time.Duration(PayoutEpochSecs) * time.Second))
+	if err := k.ProcessTreasuryPayouts(next); err != nil {
+		t.Fatal(err)
+	}
+	if got := recipientBalance(bk); got != 800 {
+		t.Fatalf("paid after the next epoch began = %d, want 800", got)
+	}
+
+	// A payout the treasury cannot cover waits for deposits, retried after
+	// PayoutRetrySecs rather than every block.
+	domain, _ = k.GetDomainHeader(next, "PayDomain")
+	domain.Treasury = sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 100))
+	domain.Options.PayoutCapPerEpoch = 0
+	k.SetDomainHeader(next, domain)
+	k.approvePayout(next, "PayDomain", "Grants", Suggestion{Name: "Extra", Payout: &TreasuryPayout{Recipient: payoutRecipient.String(), Amount: 300}}, PayoutTriggerDecision)
+	k.ProcessTreasuryPayouts(next)
+	if record, _ := k.GetPayoutRecord(next, "PayDomain", "Grants", "Extra"); record.Paid != 0 {
+		t.Fatalf("payout beyond the treasury was paid: %+v", record)
+	}
+	if record, _ := k.GetPayoutRecord(next, "PayDomain", "Grants", "Extra"); record.NextPaymentAt != next.BlockTime().Unix()+PayoutRetrySecs {
+		t.Fatalf("unpaid tranche not backed off: %+v", record)
+	}
+	domain.Treasury = sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 300))
+	k.SetDomainHeader(next, domain)
+	k.ProcessTreasuryPayouts(next)
+	if record, _ := k.GetPayoutRecord(next, "PayDomain", "Grants", "Extra"); record.Paid != 0 {
+		t.Fatalf("payout retried before its backoff: %+v", record)
+	}
+	retry := next.WithBlockTime(next.BlockTime().Add(time.Duration(PayoutRetrySecs) * time.Second))
+	k.ProcessTreasuryPayouts(retry)
+	if record, _ := k.GetPayoutRecord(retry, "PayDomain", "Grants", "Extra"); record.Paid != 300 {
+		t.Fatalf("deferred payout = %+v", record)
+	}
+}
+
+func TestSetSuggestionPayoutValidation(t *testing.T) {
+	k, ctx, _ := setupPayoutDomain(t, TreasuryPayout{Recipient: payoutRecipient.String(), Amount: 100})
+	recipient := payoutRecipient.String()
+
+	tests := []struct {
+		name   string
+		payout TreasuryPayout
+	}{
+		{"invalid recipient", TreasuryPayout{Recipient: "nobody", Amount: 100}},
+		{"zero amount", TreasuryPayout{Recipient: recipient}},
+		{"vesting without interval", TreasuryPayout{Recipient: recipient, Amount: 100, Tranches: 2}},
+		{"interval without vesting", TreasuryPayout{Recipient: recipient, Amount: 100, Interval: 60}},
+		{"too many tranches", TreasuryPayout{Recipient: recipient, Amount: 1_000, Tranches: MaxPayoutTranches + 1, Interval: 60}},
+		{"tranches above amount", TreasuryPayout{Recipient: recipient, Amount: 2, Tranches: 3, Interval: 60}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := k.SetSuggestionPayout(ctx, "PayDomain", "Grants", "Repair", tc.payout); err == nil {
+				t.Fatal("invalid payout accepted")
+			}
+		})
+	}
+
+	payout := TreasuryPayout{Recipient: recipient, Amount: 100}
+	if err := k.SetSuggestionPayout(ctx, "PayDomain", "Grants", "Build", payout); !errors.Is(err, sdkerrors.ErrInvalidRequest) {
+		t.Fatalf("second payout on a suggestion: got %v", err)
+	}
+	if err := k.SetSuggestionPayout(ctx, "PayDomain", "Grants", "Missing", payout); err == nil {
+		t.Fatal("payout on a missing suggestion accepted")
+	}
+}
+
+func TestMsgSubmitProposalPayout(t *testing.T) {
+	sender := sdk.AccAddress("creator")
+	msg := MsgSubmitProposal{
+		Sender:          sender,
+		DomainName:      "PayDomain",
+		IssueName:       "Grants",
+		SuggestionName:  "Build",
+		Creator:         sender.String(),
+		Fee:             sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 10)),
+		PayoutRecipient: payoutRecipient.String(),
+		PayoutAmount:    1_000,
+		PayoutTranches:  4,
+		PayoutInterval:  86_400,
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		t.Fatalf("valid payout proposal rejected: %v", err)
+	}
+	want := TreasuryPayout{Recipient: payoutRecipient.String(), Amount: 1_000, Tranches: 4, Interval: 86_400}
+	if payout := msg.Payout(); payout == nil || *payout != want {
+		t.Fatalf("payout = %+v", payout)
+	}
+	bz, err := gogoproto.Marshal(&msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded MsgSubmitProposal
+	if err := gogoproto.Unmarshal(bz, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, msg) {
+		t.Fatalf("decoded = %+v", decoded)
+	}
+
+	msg.PayoutInterval = 0
+	if err := msg.ValidateBasic(); err == nil {
+		t.Fatal("vesting payout without interval accepted")
+	}
+	plain := MsgSubmitProposal{Sender: sender, DomainName: "D", IssueName: "I", SuggestionName: "S", Creator: sender.String()}
+	if plain.Payout() != nil {
+		t.Fatal("proposal without payout fields carries a payout")
+	}
+}
+
+func TestQueryTreasuryPayouts(t *testing.T) {
+	k, ctx, _ := setupPayoutDomain(t, TreasuryPayout{Recipient: payoutRecipient.String(), Amount: 100})
+	s, _ := k.GetSuggestion(ctx, "PayDomain", "Grants", "Build")
+	k.approvePayout(ctx, "PayDomain", "Grants", s, PayoutTriggerDecision)
+	if err := k.ProcessTreasuryPayouts(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := k.TreasuryPayouts(ctx, &QueryTreasuryPayoutsRequest{DomainName: "PayDomain"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []PayoutRecord
+	if err := json.Unmarshal(resp.Result, &records); err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].SuggestionName != "Build" || records[0].Paid != 100 {
+		t.Fatalf("records = %+v", records)
+	}
+	if _, err := k.TreasuryPayouts(ctx, &QueryTreasuryPayoutsRequest{DomainName: "Missing"}); err == nil {
+		t.Fatal("payouts of a missing domain returned")
+	}
+}
+
+func TestGenesisTreasuryPayouts(t *testing.T) {
+	genesis := validDemocracyGenesis()
+	payout := TreasuryPayout{Recipient: payoutRecipient.String(), Amount: 300, Tranches: 3, Interval: 60}
+	genesis.TreasuryPayouts = []PayoutRecord{{
+		DomainName: "Test", IssueName: "Grants", SuggestionName: "Build", Payout: payout,
+		Trigger: PayoutTriggerDecision, ApprovedAt: 1_000, TranchesPaid: 1, Paid: 100, NextPaymentAt: 1_060,
+	}}
+	if err := ValidateGenesisState(genesis); err != nil {
+		t.Fatalf("valid payout record rejected: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*PayoutRecord)
+	}{
+		{"missing domain", func(r *PayoutRecord) { r.DomainName = "Missing" }},
+		{"invalid payout", func(r *PayoutRecord) { r.Payout.Interval = 0 }},
+		{"unknown trigger", func(r *PayoutRecord) { r.Trigger = "admin" }},
+		{"overpaid", func(r *PayoutRecord) { r.Paid = 400 }},
+		{"complete but scheduled", func(r *PayoutRecord) { r.TranchesPaid = 3 }},
+		{"incomplete but unscheduled", func(r *PayoutRecord) { r.NextPaymentAt = 0 }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := genesis
+			g.TreasuryPayouts = append([]PayoutRecord(nil), genesis.TreasuryPayouts...)
+			tc.mutate(&g.TreasuryPayouts[0])
+			if err := ValidateGenesisState(g); err == nil {
+				t.Fatal("invalid genesis accepted")
+			}
+		})
+	}
+}
+
+func TestGenesisRoundTripResumesPayouts(t *testing.T) {
+	am1, k1, ctx1 := setupModuleForGenesis(t)
+	k1.CreateDomain(ctx1, "PayDomain", sdk.AccAddress("admin1"), sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 1_000)))
+	k1.approvePayout(ctx1, "PayDomain", "Grants", Suggestion{
+		Name:   "Build",
+		Payout: &TreasuryPayout{Recipient: payoutRecipient.String(), Amount: 600, Tranches: 2, Interval: 60},
+	}, PayoutTriggerDecision)
+
+	exported := am1.ExportGenesis(ctx1, nil)
+	am2, k2, ctx2 := setupModuleForGenesis(t)
+	am2.InitGenesis(ctx2, nil, exported)
+
+	bk := newMockBankKeeper()
+	bk.fundModule(ModuleName, sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 1_000)))
+	k2.bankKeeper = bk
+	if err := k2.ProcessTreasuryPayouts(ctx2); err != nil {
+		t.Fatal(err)
+	}
+	if record, _ := k2.GetPayoutRecord(ctx2, "PayDomain", "Grants", "Build"); record.Paid != 300 || record.NextPaymentAt != record.ApprovedAt+60 {
+		t.Fatalf("record after import = %+v", record)
+	}
+}
@@ -1,6 +1,7 @@
 package truedemocracy
 
 import (
+	"cosmossdk.io/math"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -19,6 +20,7 @@ const (
 	SignedBlocksWindow      int64 = 100  // blocks tracked for liveness
 	MinSignedPerWindow      int64 = 50   // must sign ≥50% of blocks in window
 	RewardInterval          int64 = 3600 // distribute rewards every hour (seconds)
+	MaxCommissionBps        int64 = 10_000
 )
 
 // Suggestion lifecycle parameters (whitepaper §3.1.2).
@@ -46,8 +48,25 @@ const (
 	VotingModeSimpleMajority     VotingMode = 0 // >50% of votes cast (excl. abstentions)
 	VotingModeAbsoluteMajority   VotingMode = 1 // >50% of all eligible members
 	VotingModeSystemicConsensing VotingMode = 2 // -5 to +5 rating scale (WP §3.2)
+	VotingModeRankedChoice       VotingMode = 3 // instant-runoff over ranked ballots
+	VotingModeSchulze            VotingMode = 4 // Condorcet winner by Schulze strongest paths
+	VotingModeSTV                VotingMode = 5 // multi-seat single transferable vote (Droop quota)
+	VotingModeDHondt             VotingMode = 6 // multi-seat D'Hondt highest averages over first preferences
 )
 
+// MaxElectionSeats bounds the seats of a multi-seat election.
+const MaxElectionSeats = 100
+
+// Ranked reports whether the mode tallies full preference orders.
+func (m VotingMode) Ranked() bool {
+	return m == VotingModeRankedChoice || m == VotingModeSchulze || m == VotingModeSTV
+}
+
+// MultiSeat reports whether the mode can fill more than one seat.
+func (m VotingMode) MultiSeat() bool {
+	return m == VotingModeSTV || m == VotingModeDHondt
+}
+
 // VoteChoice represents a member's vote in a person election (WP §3.7).
 type VoteChoice int32
 
@@ -70,6 +89,12 @@ type Domain struct {
 	IdentityCommits   []string `json:"identity_commits"`    // MiMC commitments (hex)
 	MerkleRoot        string   `json:"merkle_root"`         // current Merkle root (hex)
 	MerkleRootHistory []string `json:"merkle_root_history"` // recent past Merkle roots
+	// Parent is the domain this one is a sub-domain of; empty for top-level
+	// domains. Fixed at creation (see subdomain.go).
+	Parent string `json:"parent,omitempty"`
+	// Treasury payouts paid in the current payout epoch (see treasury_payout.go).
+	PayoutEpoch      int64 `json:"payout_epoch,omitempty"`       // blocktime / PayoutEpochSecs
+	PayoutEpochSpent int64 `json:"payout_epoch_spent,omitempty"` // upnyx paid in PayoutEpoch
 }
 
 type DomainOptions struct {
@@ -77,10 +102,18 @@ type DomainOptions struct {
 	AnyoneCanJoin     bool       `json:"anyone_can_join"`
 	OnlyAdminIssues   bool       `json:"only_admin_issues"`
 	CoinBurnRequired  bool       `json:"coin_burn_required"`
-	ApprovalThreshold int64      `json:"approval_threshold"` // basis points; 0 = use default (500 = 5%)
-	DefaultDwellTime  int64      `json:"default_dwell_time"` // seconds; 0 = use default (86400 = 1 day)
-	VotingMode        VotingMode `json:"voting_mode"`        // person election mode (WP §3.7); 0 = simple majority
-	AbstentionAllowed bool       `json:"abstention_allowed"` // allow explicit abstention in elections (WP §3.7); default true
+	ApprovalThreshold int64      `json:"approval_threshold"`             // basis points; 0 = use default (500 = 5%)
+	DefaultDwellTime  int64      `json:"default_dwell_time"`             // seconds; 0 = use default (86400 = 1 day)
+	VotingMode        VotingMode `json:"voting_mode"`                    // person election mode (WP §3.7); 0 = simple majority
+	AbstentionAllowed bool       `json:"abstention_allowed"`             // allow explicit abstention in elections (WP §3.7); default true
+	PayoutCapPerEpoch int64      `json:"payout_cap_per_epoch,omitempty"` // upnyx of treasury payouts per epoch; 0 = voted payouts uncapped, admin withdrawals held to AdminWithdrawalCapBps
+	// OptionsChangeMajorityBps is the share of members, in basis points,
+	// that must agree on new options; 0 = default (6667 = 2/3).
+	OptionsChangeMajorityBps int64 `json:"options_change_majority_bps,omitempty"`
+	// ZKPCircuit is the membership circuit of the domain's anonymous proofs;
+	// "" = the MiMC circuit. Its hash family hashes the identity tree and
+	// picks the verifying key the proofs are checked against.
+	ZKPCircuit string `json:"zkp_circuit,omitempty"`
 }
 
 type Issue struct {
@@ -90,6 +123,48 @@ type Issue struct {
 	CreationDate   int64        `json:"creation_date"`    // unix timestamp
 	LastActivityAt int64        `json:"last_activity_at"` // updated on any interaction
 	ExternalLink   string       `json:"external_link"`    // optional URL to forum/discussion
+	// Closing is fixed by the proposal that opens the issue; zero never closes.
+	Closing IssueClosingRule `json:"closing"`
+	// Seats is the number of candidates a person election on this issue
+	// elects; 0 and 1 both mean a single winner.
+	Seats int64 `json:"seats"`
+	// DelegatedStones is the part of Stones lent by members through their
+	// domain-wide delegation (see delegation.go).
+	DelegatedStones int `json:"delegated_stones,omitempty"`
+	// SubDomain is the child domain this issue was handed to; the issue
+	// takes no more votes and adopts the child's decision.
+	SubDomain string `json:"sub_domain,omitempty"`
+}
+
+// IssueClosingRule decides when an issue is finalized into an IssueDecision.
+// The issue closes at the first EndBlock where any enabled condition holds.
+type IssueClosingRule struct {
+	ClosesAt     int64 `json:"closes_at"`     // unix deadline; 0 = none
+	RatingQuorum int64 `json:"rating_quorum"` // ratings across all suggestions; 0 = none
+	StoneQuorum  int64 `json:"stone_quorum"`  // stones on the issue's suggestions; 0 = none
+}
+
+// IsZero reports whether the rule has no closing condition.
+func (r IssueClosingRule) IsZero() bool {
+	return r.ClosesAt == 0 && r.RatingQuorum == 0 && r.StoneQuorum == 0
+}
+
+// IssueDecision is the permanent outcome of a closed issue. It is written
+// once and never changed; the issue rejects ratings and new suggestions
+// afterwards. KV key: "decision:{d}{issueName}".
+type IssueDecision struct {
+	DomainName      string             `json:"domain_name"`
+	IssueName       string             `json:"issue_name"`
+	Winner          string             `json:"winner"` // empty when no suggestion was rated
+	WinnerScore     int                `json:"winner_score"`
+	Ranking         []ScoredSuggestion `json:"ranking"` // full RankSuggestionsByScore table
+	Reason          string             `json:"reason"`  // "deadline" or "quorum"
+	RatingCount     int64              `json:"rating_count"`
+	StoneCount      int64              `json:"stone_count"` // stones on the issue's suggestions
+	MemberCount     int64              `json:"member_count"`
+	DecidedAtHeight int64              `json:"decided_at_height"`
+	DecidedAt       int64              `json:"decided_at"`           // unix timestamp
+	SubDomain       string             `json:"sub_domain,omitempty"` // child domain whose decision was adopted
 }
 
 type Suggestion struct {
@@ -99,17 +174,76 @@ type Suggestion struct {
 	Ratings         []Rating `json:"ratings"`
 	Color           string   `json:"color"`
 	DwellTime       int64    `json:"dwell_time"`
-	CreationDate    int64    `json:"creation_date"`     // unix timestamp
-	ExternalLink    string   `json:"external_link"`     // optional URL to details/arguments
-	EnteredYellowAt int64    `json:"entered_yellow_at"` // when suggestion entered yellow zone
-	EnteredRedAt    int64    `json:"entered_red_at"`    // when suggestion entered red zone
-	DeleteVotes     int      `json:"delete_votes"`      // fast-delete vote counter
+	CreationDate    int64    `json:"creation_date"`              // unix timestamp
+	ExternalLink    string   `json:"external_link"`              // optional URL to details/arguments
+	EnteredYellowAt int64    `json:"entered_yellow_at"`          // when suggestion entered yellow zone
+	EnteredRedAt    int64    `json:"entered_red_at"`             // when suggestion entered red zone
+	DeleteVotes     int      `json:"delete_votes"`               // fast-delete vote counter
+	DelegatedStones int      `json:"delegated_stones,omitempty"` // part of Stones lent through delegation
+	EnteredGreenAt  int64    `json:"entered_green_at,omitempty"` // when suggestion entered green zone
+	// Payout is paid from the domain treasury once the suggestion stays
+	// green for its dwell time or wins its issue (see treasury_payout.go).
+	Payout *TreasuryPayout `json:"payout,omitempty"`
+}
+
+// TreasuryPayout is a treasury spend carried by a suggestion. With more than
+// one tranche it vests: equal tranches are paid Interval seconds apart,
+// starting when the payout is approved.
+type TreasuryPayout struct {
+	Recipient string `json:"recipient"`
+	Amount    int64  `json:"amount"`             // upnyx, all tranches together
+	Tranches  int64  `json:"tranches,omitempty"` // 0 or 1 = single payment
+	Interval  int64  `json:"interval,omitempty"` // seconds between tranches
+}
+
+// AnonymousSignal is a signal a domain member posted to a topic with a
+// membership proof. KV key: "signal-zk:{d}{topic}{nullifier}".
+type AnonymousSignal struct {
+	DomainName    string `json:"domain_name"`
+	Topic         string `json:"topic"`
+	NullifierHash string `json:"nullifier_hash"`
+	Signal        string `json:"signal"`
+	Height        int64  `json:"height"`
+}
+
+// PayoutRecord is an approved treasury payout and its payment progress.
+// KV key: "payout:{d}{i}{suggestion}".
+type PayoutRecord struct {
+	DomainName       string         `json:"domain_name"`
+	IssueName        string         `json:"issue_name"`
+	SuggestionName   string         `json:"suggestion_name"`
+	Payout           TreasuryPayout `json:"payout"`
+	Trigger          string         `json:"trigger"` // "green" or "decision"
+	ApprovedAtHeight int64          `json:"approved_at_height"`
+	ApprovedAt       int64          `json:"approved_at"`               // unix timestamp
+	TranchesPaid     int64          `json:"tranches_paid"`             // tranches paid so far
+	Paid             int64          `json:"paid"`                      // upnyx paid so far
+	NextPaymentAt    int64          `json:"next_payment_at,omitempty"` // unix time of the next tranche; 0 when complete
 }
 
 type Rating struct {
 	DomainPubKeyHex string `json:"domain_pub_key_hex"` // legacy ed25519 domain key (hex), empty for ZKP
 	NullifierHex    string `json:"nullifier_hex"`      // ZKP nullifier (hex), empty for legacy
 	Value           int    `json:"value"`
+	MemberAddr      string `json:"member_addr,omitempty"` // open rating by this member, empty for anonymous ratings
+	Weight          int    `json:"weight,omitempty"`      // open ratings: one plus the delegated votes carried; 0 counts as 1
+}
+
+// VoteDelegation hands a member's vote to another member of the domain, for
+// one issue or, with an empty IssueName, for the whole domain.
+// KV key: "delegation:{d}{issueName}{delegator}".
+type VoteDelegation struct {
+	DomainName string `json:"domain_name"`
+	IssueName  string `json:"issue_name,omitempty"`
+	Delegator  string `json:"delegator"`
+	Delegate   string `json:"delegate"`
+}
+
+// VoterModeRecord is a member's voter mode in genesis.
+type VoterModeRecord struct {
+	DomainName string `json:"domain_name"`
+	Member     string `json:"member"`
+	Mode       string `json:"mode"` // VoterModeOpen or VoterModeAnonymous
 }
 
 // VoteCommitment records a domain-key-signed vote without revealing voter identity.
@@ -118,16 +252,88 @@ type VoteCommitment struct {
 	Signature    string `json:"signature"`      // hex-encoded signature over vote payload
 }
 
-// Validator represents an active Proof of Domain validator node.
+// Validator represents an active Proof of Domain validator node. Stake is the
+// operator's own bond; DelegatedStake is the sum of its StakeDelegations, and
+// power is derived from both.
 type Validator struct {
-	OperatorAddr string    `json:"operator_addr"`
-	PubKey       []byte    `json:"pub_key"`
-	Stake        sdk.Coins `json:"stake"`
-	Domains      []string  `json:"domains"`
-	Power        int64     `json:"power"`
-	Jailed       bool      `json:"jailed"`
-	JailedUntil  int64     `json:"jailed_until"`
-	MissedBlocks int64     `json:"missed_blocks"`
+	OperatorAddr   string    `json:"operator_addr"`
+	PubKey         []byte    `json:"pub_key"`
+	Stake          sdk.Coins `json:"stake"`
+	Domains        []string  `json:"domains"`
+	Power          int64     `json:"power"`
+	Jailed         bool      `json:"jailed"`
+	JailedUntil    int64     `json:"jailed_until"`
+	MissedBlocks   int64     `json:"missed_blocks"`
+	DelegatedStake int64     `json:"delegated_stake,omitempty"`
+	CommissionBps  int64     `json:"commission_bps,omitempty"` // operator's cut of delegator rewards
+}
+
+// ValidatorDescription is operator-supplied metadata shown by explorers,
+// stored under "validator-description:{operator}". CommissionUpdatedAt is the
+// unix time of the last commission change through MsgEditValidator.
+type ValidatorDescription struct {
+	OperatorAddr        string `json:"operator_addr"`
+	Moniker             string `json:"moniker,omitempty"`
+	Website             string `json:"website,omitempty"`
+	SecurityContact     string `json:"security_contact,omitempty"`
+	Identity            string `json:"identity,omitempty"`
+	CommissionUpdatedAt int64  `json:"commission_updated_at,omitempty"`
+}
+
+// StakeDelegation is upnyx a third-party account has bonded to a validator,
+// stored under "stake-delegation:{validator}:{delegator}". The coins sit in
+// module escrow, add to the validator's power, share its rewards after
+// commission and are slashed pro rata with the operator stake. RewardIndex is
+// the validator's delegator reward index when the delegation was last settled.
+type StakeDelegation struct {
+	DelegatorAddr string   `json:"delegator_addr"`
+	ValidatorAddr string   `json:"validator_addr"`
+	Amount        int64    `json:"amount"`
+	RewardIndex   math.Int `json:"reward_index"`
+}
+
+// StakingRewardPool is the module-wide staking reward accumulator, stored
+// under "pod:reward-pool". Index is the cumulative reward per bonded upnyx,
+// scaled by RewardIndexScale; BondedStake is the stake of every unjailed
+// validator, which earns rewards; Outstanding is minted reward not yet paid
+// out, including rounding dust.
+type StakingRewardPool struct {
+	Index       math.Int `json:"index"`
+	BondedStake math.Int `json:"bonded_stake"`
+	Outstanding math.Int `json:"outstanding"`
+}
+
+// ValidatorRewards tracks how far a validator has been settled against the
+// pool, stored under "pod-rewards:{operator}". DelegatorIndex is the
+// cumulative reward per delegated upnyx after commission.
+type ValidatorRewards struct {
+	OperatorAddr   string   `json:"operator_addr"`
+	PoolIndex      math.Int `json:"pool_index"`
+	DelegatorIndex math.Int `json:"delegator_index"`
+}
+
+// StakingRewardAccount holds settled, unclaimed staking rewards of an
+// operator or delegator, stored under "staking-reward:{address}". Claims pay
+// WithdrawAddr, or the account itself when it is empty.
+type StakingRewardAccount struct {
+	Address      string   `json:"address"`
+	Unclaimed    math.Int `json:"unclaimed"`
+	WithdrawAddr string   `json:"withdraw_addr,omitempty"`
+}
+
+// PendingUndelegation holds undelegated stake in module escrow, still
+// slashable, until both CometBFT evidence-age limits have expired, exactly
+// like a PendingValidatorRemoval. A validator exit moves every delegation to
+// it into such a hold.
+type PendingUndelegation struct {
+	DelegatorAddr           string `json:"delegator_addr"`
+	ValidatorAddr           string `json:"validator_addr"`
+	Amount                  int64  `json:"amount"`
+	CreatedHeight           int64  `json:"created_height"`
+	ConsensusRetiredHeight  int64  `json:"consensus_retired_height"`
+	ConsensusRetiredAtNanos int64  `json:"consensus_retired_at_nanos,omitempty"`
+	ReleaseAfterHeight      int64  `json:"release_after_height"`
+	ReleaseAfterTimeNanos   int64  `json:"release_after_time_nanos,omitempty"`
 }
 
 // BigPurgeSchedule tracks automated purge timing for a domain (WP S4).
@@ -158,14 +364,61 @@ type ZKPDomainState struct {
 	CommitmentCount   int      `json:"commitment_count"`
 	MemberCount       int      `json:"member_count"`
 	VKInitialized     bool     `json:"vk_initialized"`
+	// ZKPCircuitID is the circuit the domain's proofs are made for: the
+	// circuit of the current key of the domain's hash family. Provers hash
+	// their commitment with that family.
+	ZKPCircuitID string `json:"zkp_circuit_id,omitempty"`
+	// VerifyingKeySHA256 lets provers check their local ceremony keys
+	// against the consensus verifying key before proving.
+	VerifyingKeySHA256 string `json:"verifying_key_sha256,omitempty"`
+	// RetiringVerifyingKeySHA256 is the key replaced by the last rotation,
+	// still accepted below RetiringKeyEndHeight.
+	RetiringVerifyingKeySHA256 string `json:"retiring_verifying_key_sha256,omitempty"`
+	RetiringKeyEndHeight       int64  `json:"retiring_key_end_height,omitempty"`
+}
+
+// RetiringVerifyingKey is the membership verifying key replaced by the last
+// rotation. Proofs made for it are accepted below EndHeight so members can
+// switch prover keys without a coordinated cutover.
+// KV key: "zkp:retiring-verifying-key[/{family}]"
+type RetiringVerifyingKey struct {
+	CircuitID          string `json:"circuit_id"`
+	VerifyingKeyHex    string `json:"verifying_key_hex"`
+	VerifyingKeySHA256 string `json:"verifying_key_sha256"`
+	EndHeight          int64  `json:"end_height"`
+}
+
+// CircuitVerifyingKey is the genesis form of the verifying-key slot of a hash
+// family other than MiMC, whose slot uses the top-level genesis fields.
+// Retiring is the key its last rotation replaced, while still accepted.
+type CircuitVerifyingKey struct {
+	CircuitID          string                `json:"circuit_id"`
+	VerifyingKeyHex    string                `json:"verifying_key_hex"`
+	VerifyingKeySHA256 string                `json:"verifying_key_sha256"`
+	Retiring           *RetiringVerifyingKey `json:"retiring,omitempty"`
 }
 
 // NullifierRecord tracks a used nullifier to prevent double-voting with ZKP.
 // KV key: "nullifier:{domain}:{nullifierHex}"
+//
+// IssueName, and SuggestionName within it, scope the nullifier: deleting that
+// issue or suggestion prunes it. Unscoped nullifiers are domain-wide.
 type NullifierRecord struct {
-	DomainName    string `json:"domain_name"`
-	NullifierHash string `json:"nullifier_hash"` // hex-encoded
-	UsedAtHeight  int64  `json:"used_at_height"` // block height when consumed
+	DomainName     string `json:"domain_name"`
+	IssueName      string `json:"issue_name,omitempty"`
+	SuggestionName string `json:"suggestion_name,omitempty"`
+	NullifierHash  string `json:"nullifier_hash"` // hex-encoded
+	UsedAtHeight   int64  `json:"used_at_height"` // block height when consumed
+}
+
+// NullifierScopeGenesis is the compact genesis form of the used nullifiers of
+// one scope: a domain, one of its issues, or one suggestion of an issue.
+// Consumption heights are informational and not exported.
+type NullifierScopeGenesis struct {
+	DomainName     string   `json:"domain_name"`
+	IssueName      string   `json:"issue_name,omitempty"`
+	SuggestionName string   `json:"suggestion_name,omitempty"`
+	Nullifiers     []string `json:"nullifiers"` // hex-encoded, sorted
 }
 
 // GenesisValidator is the genesis-file representation of a validator.
@@ -195,6 +448,15 @@ type GenesisValidator struct {
 	Jailed       bool  `json:"jailed,omitempty"`
 	JailedUntil  int64 `json:"jailed_until,omitempty"`
 	MissedBlocks int64 `json:"missed_blocks,omitempty"`
+	// DelegatedStake is the sum of the validator's StakeDelegations.
+	DelegatedStake int64 `json:"delegated_stake,omitempty"`
+	CommissionBps  int64 `json:"commission_bps,omitempty"`
+}
+
+// BondedStake is the stake behind the validator's power: its own bond plus
+// everything delegated to it.
+func (v GenesisValidator) BondedStake() int64 {
+	return v.Stake + v.DelegatedStake
 }
 
 // RevokedValidatorKey permanently retires a consensus key. Retired keys can
@@ -287,12 +549,30 @@ type GenesisState struct {
 	ValidatorSigningInfos      []ValidatorSigningInfo         `json:"validator_signing_infos,omitempty"`
 	ProcessedInfractions       []ProcessedInfraction          `json:"processed_infractions,omitempty"`
 	PendingValidatorRemovals   []PendingValidatorRemoval      `json:"pending_validator_removals,omitempty"`
+	ValidatorDescriptions      []ValidatorDescription         `json:"validator_descriptions,omitempty"`
+	StakeDelegations           []StakeDelegation              `json:"stake_delegations,omitempty"`
+	PendingUndelegations       []PendingUndelegation          `json:"pending_undelegations,omitempty"`
+	StakingRewardPool          *StakingRewardPool             `json:"staking_reward_pool,omitempty"`
+	ValidatorRewards           []ValidatorRewards             `json:"validator_rewards,omitempty"`
+	StakingRewardAccounts      []StakingRewardAccount         `json:"staking_reward_accounts,omitempty"`
+	ValidatorSetParams         *ValidatorSetParams            `json:"validator_set_params,omitempty"`
+	ValidatorSetVotes          []ValidatorSetVote             `json:"validator_set_votes,omitempty"`
 	LastCommitCursor           LastCommitCursor               `json:"last_commit_cursor,omitempty"`
 	BootstrapOperatorAddresses []string                       `json:"bootstrap_operator_addresses,omitempty"`
-	UsedNullifiers             []NullifierRecord              `json:"used_nullifiers"`
+	UsedNullifiers             []NullifierRecord              `json:"used_nullifiers,omitempty"` // legacy per-record form, import only
+	NullifierScopes            []NullifierScopeGenesis        `json:"nullifier_scopes,omitempty"`
+	IssueDecisions             []IssueDecision                `json:"issue_decisions,omitempty"`
+	VoteDelegations            []VoteDelegation               `json:"vote_delegations,omitempty"`
+	VoterModes                 []VoterModeRecord              `json:"voter_modes,omitempty"`
+	TreasuryPayouts            []PayoutRecord                 `json:"treasury_payouts,omitempty"`
+	DomainOptionsHistory       []DomainOptionsChange          `json:"domain_options_history,omitempty"`
+	IdentityLeafOwners         []IdentityLeafOwner            `json:"identity_leaf_owners,omitempty"`
+	IdentityRotations          []IdentityRotation             `json:"identity_rotations,omitempty"`
 	ZKPCircuitID               string                         `json:"zkp_circuit_id,omitempty"`
 	VerifyingKeyHex            string                         `json:"verifying_key_hex,omitempty"`
 	VerifyingKeySHA256         string                         `json:"verifying_key_sha256,omitempty"`
+	RetiringVerifyingKey       *RetiringVerifyingKey          `json:"retiring_verifying_key,omitempty"`
+	CircuitVerifyingKeys       []CircuitVerifyingKey          `json:"circuit_verifying_keys,omitempty"`
 	SoftwareUpgradeProposal    *SoftwareUpgradeProposal       `json:"software_upgrade_proposal,omitempty"`
 	SoftwareUpgradeVotes       []string                       `json:"software_upgrade_votes,omitempty"`
 	UpgradeCancelProposal      *SoftwareUpgradeCancelProposal `json:"software_upgrade_cancel_proposal,omitempty"`
@@ -305,11 +585,22 @@ func RegisterCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(Issue{}, "truedemocracy/Issue", nil)
 	cdc.RegisterConcrete(Suggestion{}, "truedemocracy/Suggestion", nil)
 	cdc.RegisterConcrete(Rating{}, "truedemocracy/Rating", nil)
+	cdc.RegisterConcrete(IssueClosingRule{}, "truedemocracy/IssueClosingRule", nil)
+	cdc.RegisterConcrete(IssueDecision{}, "truedemocracy/IssueDecision", nil)
+	cdc.RegisterConcrete(ScoredSuggestion{}, "truedemocracy/ScoredSuggestion", nil)
 	cdc.RegisterConcrete(VoteCommitment{}, "truedemocracy/VoteCommitment", nil)
+	cdc.RegisterConcrete(RankedBallot{}, "truedemocracy/RankedBallot", nil)
+	cdc.RegisterConcrete(VoteDelegation{}, "truedemocracy/VoteDelegation", nil)
+	cdc.RegisterConcrete(VoterModeRecord{}, "truedemocracy/VoterModeRecord", nil)
+	cdc.RegisterConcrete(TreasuryPayout{}, "truedemocracy/TreasuryPayout", nil)
+	cdc.RegisterConcrete(PayoutRecord{}, "truedemocracy/PayoutRecord", nil)
+	cdc.RegisterConcrete(AnonymousSignal{}, "truedemocracy/AnonymousSignal", nil)
+	cdc.RegisterConcrete(DomainOptionsChange{}, "truedemocracy/DomainOptionsChange", nil)
 	cdc.RegisterConcrete(GenesisState{}, "truedemocracy/GenesisState", nil)
 	cdc.RegisterConcrete(BigPurgeSchedule{}, "truedemocracy/BigPurgeSchedule", nil)
 	cdc.RegisterConcrete(OnboardingRequest{}, "truedemocracy/OnboardingRequest", nil)
 	cdc.RegisterConcrete(NullifierRecord{}, "truedemocracy/NullifierRecord", nil)
+	cdc.RegisterConcrete(NullifierScopeGenesis{}, "truedemocracy/NullifierScopeGenesis", nil)
 	cdc.RegisterConcrete(Validator{}, "truedemocracy/Validator", nil)
 	cdc.RegisterConcrete(GenesisValidator{}, "truedemocracy/GenesisValidator", nil)
 	cdc.RegisterConcrete(RevokedValidatorKey{}, "truedemocracy/RevokedValidatorKey", nil)
@@ -319,8 +610,18 @@ func RegisterCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(ProcessedInfraction{}, "truedemocracy/ProcessedInfraction", nil)
 	cdc.RegisterConcrete(LastCommitCursor{}, "truedemocracy/LastCommitCursor", nil)
 	cdc.RegisterConcrete(PendingValidatorRemoval{}, "truedemocracy/PendingValidatorRemoval", nil)
+	cdc.RegisterConcrete(ValidatorDescription{}, "truedemocracy/ValidatorDescription", nil)
+	cdc.RegisterConcrete(StakeDelegation{}, "truedemocracy/StakeDelegation", nil)
+	cdc.RegisterConcrete(PendingUndelegation{}, "truedemocracy/PendingUndelegation", nil)
+	cdc.RegisterConcrete(StakingRewardPool{}, "truedemocracy/StakingRewardPool", nil)
+	cdc.RegisterConcrete(ValidatorRewards{}, "truedemocracy/ValidatorRewards", nil)
+	cdc.RegisterConcrete(StakingRewardAccount{}, "truedemocracy/StakingRewardAccount", nil)
+	cdc.RegisterConcrete(ValidatorSetParams{}, "truedemocracy/ValidatorSetParams", nil)
 	cdc.RegisterConcrete(SoftwareUpgradeProposal{}, "truedemocracy/SoftwareUpgradeProposal", nil)
 	cdc.RegisterConcrete(SoftwareUpgradeCancelProposal{}, "truedemocracy/SoftwareUpgradeCancelProposal", nil)
+	cdc.RegisterConcrete(VerifyingKeyRotationProposal{}, "truedemocracy/VerifyingKeyRotationProposal", nil)
+	cdc.RegisterConcrete(RetiringVerifyingKey{}, "truedemocracy/RetiringVerifyingKey", nil)
+	cdc.RegisterConcrete(CircuitVerifyingKey{}, "truedemocracy/CircuitVerifyingKey", nil)
 
 	// Message types for CLI transactions.
 	cdc.RegisterConcrete(MsgCreateDomain{}, "truedemocracy/MsgCreateDomain", nil)
@@ -339,16 +640,35 @@ func RegisterCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(MsgVoteToDelete{}, "truedemocracy/MsgVoteToDelete", nil)
 	cdc.RegisterConcrete(MsgRateProposal{}, "truedemocracy/MsgRateProposal", nil)
 	cdc.RegisterConcrete(MsgCastElectionVote{}, "truedemocracy/MsgCastElectionVote", nil)
+	cdc.RegisterConcrete(MsgCastRankedElectionVote{}, "truedemocracy/MsgCastRankedElectionVote", nil)
 	cdc.RegisterConcrete(MsgAddMember{}, "truedemocracy/MsgAddMember", nil)
 	cdc.RegisterConcrete(MsgOnboardToDomain{}, "truedemocracy/MsgOnboardToDomain", nil)
 	cdc.RegisterConcrete(MsgApproveOnboarding{}, "truedemocracy/MsgApproveOnboarding", nil)
 	cdc.RegisterConcrete(MsgRejectOnboarding{}, "truedemocracy/MsgRejectOnboarding", nil)
 	cdc.RegisterConcrete(MsgRegisterIdentity{}, "truedemocracy/MsgRegisterIdentity", nil)
 	cdc.RegisterConcrete(MsgRateWithProof{}, "truedemocracy/MsgRateWithProof", nil)
+	cdc.RegisterConcrete(MsgCastElectionVoteWithProof{}, "truedemocracy/MsgCastElectionVoteWithProof", nil)
+	cdc.RegisterConcrete(MsgPlaceStoneWithProof{}, "truedemocracy/MsgPlaceStoneWithProof", nil)
+	cdc.RegisterConcrete(MsgRateOpenly{}, "truedemocracy/MsgRateOpenly", nil)
+	cdc.RegisterConcrete(MsgDelegateVote{}, "truedemocracy/MsgDelegateVote", nil)
+	cdc.RegisterConcrete(MsgCreateSubDomain{}, "truedemocracy/MsgCreateSubDomain", nil)
+	cdc.RegisterConcrete(MsgVoteSubDomainBudget{}, "truedemocracy/MsgVoteSubDomainBudget", nil)
+	cdc.RegisterConcrete(MsgDelegateIssueToSubDomain{}, "truedemocracy/MsgDelegateIssueToSubDomain", nil)
+	cdc.RegisterConcrete(MsgProposeDomainOptionsChange{}, "truedemocracy/MsgProposeDomainOptionsChange", nil)
+	cdc.RegisterConcrete(MsgRotateIdentityCommitment{}, "truedemocracy/MsgRotateIdentityCommitment", nil)
 	cdc.RegisterConcrete(MsgDepositToDomain{}, "truedemocracy/MsgDepositToDomain", nil)
 	cdc.RegisterConcrete(MsgWithdrawFromDomain{}, "truedemocracy/MsgWithdrawFromDomain", nil)
 	cdc.RegisterConcrete(MsgVoteSoftwareUpgrade{}, "truedemocracy/MsgVoteSoftwareUpgrade", nil)
 	cdc.RegisterConcrete(MsgVoteCancelSoftwareUpgrade{}, "truedemocracy/MsgVoteCancelSoftwareUpgrade", nil)
+	cdc.RegisterConcrete(MsgVoteVerifyingKeyRotation{}, "truedemocracy/MsgVoteVerifyingKeyRotation", nil)
+	cdc.RegisterConcrete(MsgSubmitProposalWithProof{}, "truedemocracy/MsgSubmitProposalWithProof", nil)
+	cdc.RegisterConcrete(MsgSignalWithProof{}, "truedemocracy/MsgSignalWithProof", nil)
+	cdc.RegisterConcrete(MsgDelegateStake{}, "truedemocracy/MsgDelegateStake", nil)
+	cdc.RegisterConcrete(MsgUndelegateStake{}, "truedemocracy/MsgUndelegateStake", nil)
+	cdc.RegisterConcrete(MsgEditValidator{}, "truedemocracy/MsgEditValidator", nil)
+	cdc.RegisterConcrete(MsgClaimStakingRewards{}, "truedemocracy/MsgClaimStakingRewards", nil)
+	cdc.RegisterConcrete(MsgSetStakingRewardAddress{}, "truedemocracy/MsgSetStakingRewardAddress", nil)
+	cdc.RegisterConcrete(MsgVoteValidatorSetParams{}, "truedemocracy/MsgVoteValidatorSetParams", nil)
 }
 
 func DefaultGenesisState() GenesisState {
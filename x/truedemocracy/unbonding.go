@@ -80,18 +80,42 @@ func validatorRetirementHeight(removalHeight, maxAgeNumBlocks int64) (int64, int
 	return retirementHeight, retirementHeight + releaseOffset, nil
 }
 
-// newPendingValidatorRemoval snapshots the complete validator claim and the
-// consensus evidence limits at exit time. The time-based boundary is
-// deliberately unset until the retirement height is actually observed.
-func newPendingValidatorRemoval(ctx sdk.Context, validator Validator, recipientAddr string) (PendingValidatorRemoval, error) {
+// evidenceHoldHeights returns the retirement and release heights of stake
+// whose removal is reported to CometBFT at removalHeight, checking that both
+// evidence-age limits are configured.
+func evidenceHoldHeights(ctx sdk.Context, removalHeight int64) (int64, int64, error) {
 	evidence := ctx.ConsensusParams().Evidence
 	if evidence == nil {
-		return PendingValidatorRemoval{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "consensus evidence parameters are unavailable")
+		return 0, 0, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "consensus evidence parameters are unavailable")
 	}
 	if evidence.MaxAgeDuration <= 0 {
-		return PendingValidatorRemoval{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "consensus evidence max-age duration must be positive")
+		return 0, 0, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "consensus evidence max-age duration must be positive")
 	}
-	retiredHeight, releaseHeight, err := validatorRetirementHeight(ctx.BlockHeight(), evidence.MaxAgeNumBlocks)
+	return validatorRetirementHeight(removalHeight, evidence.MaxAgeNumBlocks)
+}
+
+// evidenceHoldRelease stamps the current block as the observed retirement
+// time of a hold and returns it with the time-based release boundary.
+func evidenceHoldRelease(ctx sdk.Context) (int64, int64, error) {
+	evidence := ctx.ConsensusParams().Evidence
+	if evidence == nil {
+		return 0, 0, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "consensus evidence parameters are unavailable")
+	}
+	if evidence.MaxAgeDuration <= 0 {
+		return 0, 0, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "consensus evidence max-age duration must be positive")
+	}
+	retiredAt := ctx.BlockTime().UnixNano()
+	if retiredAt > math.MaxInt64-int64(evidence.MaxAgeDuration) {
+		return 0, 0, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "validator release time overflows")
+	}
+	return retiredAt, retiredAt + int64(evidence.MaxAgeDuration), nil
+}
+
+// newPendingValidatorRemoval snapshots the complete validator claim and the
+// consensus evidence limits at exit time. The time-based boundary is
+// deliberately unset until the retirement height is actually observed.
+func newPendingValidatorRemoval(ctx sdk.Context, validator Validator, recipientAddr string) (PendingValidatorRemoval, error) {
+	retiredHeight, releaseHeight, err := evidenceHoldHeights(ctx, ctx.BlockHeight())
 	if err != nil {
 		return PendingValidatorRemoval{}, err
 	}
@@ -109,19 +133,12 @@ func observeValidatorRetirement(ctx sdk.Context, removal PendingValidatorRemoval
 	if removal.ConsensusRetiredAtNanos != 0 || ctx.BlockHeight() < removal.ConsensusRetiredHeight {
 		return removal, nil
 	}
-	evidence := ctx.ConsensusParams().Evidence
-	if evidence == nil {
-		return PendingValidatorRemoval{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "consensus evidence parameters are unavailable")
-	}
-	if evidence.MaxAgeDuration <= 0 {
-		return PendingValidatorRemoval{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "consensus evidence max-age duration must be positive")
-	}
-	retiredAt := ctx.BlockTime().UnixNano()
-	if retiredAt > math.MaxInt64-int64(evidence.MaxAgeDuration) {
-		return PendingValidatorRemoval{}, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "validator release time overflows")
+	retiredAt, releaseAt, err := evidenceHoldRelease(ctx)
+	if err != nil {
+		return PendingValidatorRemoval{}, err
 	}
 	removal.ConsensusRetiredAtNanos = retiredAt
-	removal.ReleaseAfterTimeNanos = retiredAt + int64(evidence.MaxAgeDuration)
+	removal.ReleaseAfterTimeNanos = releaseAt
 	return removal, nil
 }
 
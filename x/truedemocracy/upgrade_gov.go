@@ -1,6 +1,7 @@
 package truedemocracy
 
 import (
+	"encoding/hex"
 	"fmt"
 	"sort"
 
@@ -494,3 +495,254 @@ func (k Keeper) VoteCancelSoftwareUpgrade(
 	write()
 	return votes, eligibleCount, cancelled, nil
 }
+
+// ---------- Verifying-key rotation ----------
+
+// Verifying-key rotation bounds.
+const (
+	// VerifyingKeyRotationVotingBlocks is how long a rotation proposal
+	// collects votes before a vote for a different key may replace it.
+	VerifyingKeyRotationVotingBlocks int64 = 100_800
+	// VerifyingKeyMaxTransitionBlocks bounds how long the replaced key keeps
+	// verifying proofs after a rotation.
+	VerifyingKeyMaxTransitionBlocks int64 = 403_200
+	// verifyingKeyMaxHexLength bounds the submitted key encoding; a BN254
+	// membership key is well under 1 KiB.
+	verifyingKeyMaxHexLength = 8192
+)
+
+// VerifyingKeyRotationProposal is a proposal to replace the membership
+// verifying key. Like a software-upgrade proposal, it snapshots the
+// governance domain on its first vote; unlike one, it is applied directly by
+// the module when votes reach two thirds, and it lapses after
+// VerifyingKeyRotationVotingBlocks. Several proposals may collect votes at
+// once, so no member can hold rotations up with a proposal of their own; each
+// member backs at most one, and voting for another moves their vote.
+type VerifyingKeyRotationProposal struct {
+	CircuitID          string   `json:"circuit_id"`
+	VerifyingKeySHA256 string   `json:"verifying_key_sha256"`
+	TransitionBlocks   int64    `json:"transition_blocks"`
+	VotingEndHeight    int64    `json:"voting_end_height"`
+	Eligible           []string `json:"eligible"` // sorted, deduplicated, non-empty snapshot
+}
+
+// id names the proposal in the store: its circuit, key and transition.
+func (p VerifyingKeyRotationProposal) id() string {
+	return fmt.Sprintf("%s/%s/%d", p.CircuitID, p.VerifyingKeySHA256, p.TransitionBlocks)
+}
+
+// KV layout:
+//
+//	"zkpgov:proposal:{id}"  → VerifyingKeyRotationProposal
+//	"zkpgov:vote:{voter}"   → id of the proposal the voter backs
+
+var verifyingKeyRotationProposalPrefix = []byte("zkpgov:proposal:")
+
+func verifyingKeyRotationProposalKey(id string) []byte {
+	return append(append([]byte{}, verifyingKeyRotationProposalPrefix...), id...)
+}
+
+func verifyingKeyRotationVoteKey(voter string) []byte {
+	return []byte("zkpgov:vote:" + voter)
+}
+
+// GetVerifyingKeyRotationProposals returns the open rotation proposals in id
+// order.
+func (k Keeper) GetVerifyingKeyRotationProposals(ctx sdk.Context) []VerifyingKeyRotationProposal {
+	iter := ctx.KVStore(k.StoreKey).Iterator(verifyingKeyRotationProposalPrefix, prefixEnd(verifyingKeyRotationProposalPrefix))
+	defer iter.Close()
+	var proposals []VerifyingKeyRotationProposal
+	for ; iter.Valid(); iter.Next() {
+		var proposal VerifyingKeyRotationProposal
+		k.cdc.MustUnmarshalLengthPrefixed(iter.Value(), &proposal)
+		proposals = append(proposals, proposal)
+	}
+	return proposals
+}
+
+func (k Keeper) getVerifyingKeyRotationProposal(ctx sdk.Context, id string) (VerifyingKeyRotationProposal, bool) {
+	bz := ctx.KVStore(k.StoreKey).Get(verifyingKeyRotationProposalKey(id))
+	if bz == nil {
+		return VerifyingKeyRotationProposal{}, false
+	}
+	var proposal VerifyingKeyRotationProposal
+	k.cdc.MustUnmarshalLengthPrefixed(bz, &proposal)
+	return proposal, true
+}
+
+// HasVerifyingKeyRotationVote reports whether the voter backs an open
+// rotation proposal.
+func (k Keeper) HasVerifyingKeyRotationVote(ctx sdk.Context, voter string) bool {
+	return ctx.KVStore(k.StoreKey).Has(verifyingKeyRotationVoteKey(voter))
+}
+
+// countVerifyingKeyRotationVotes counts the snapshot members backing the
+// proposal.
+func (k Keeper) countVerifyingKeyRotationVotes(ctx sdk.Context, proposal VerifyingKeyRotationProposal) int {
+	store := ctx.KVStore(k.StoreKey)
+	id := proposal.id()
+	votes := 0
+	for _, member := range proposal.Eligible {
+		if string(store.Get(verifyingKeyRotationVoteKey(member))) == id {
+			votes++
+		}
+	}
+	return votes
+}
+
+// deleteVerifyingKeyRotationProposal removes a proposal and the votes that
+// back it.
+func (k Keeper) deleteVerifyingKeyRotationProposal(ctx sdk.Context, proposal VerifyingKeyRotationProposal) {
+	store := ctx.KVStore(k.StoreKey)
+	id := proposal.id()
+	store.Delete(verifyingKeyRotationProposalKey(id))
+	for _, member := range proposal.Eligible {
+		if string(store.Get(verifyingKeyRotationVoteKey(member))) == id {
+			store.Delete(verifyingKeyRotationVoteKey(member))
+		}
+	}
+}
+
+// clearVerifyingKeyRotationGovernance removes every proposal and vote once a
+// rotation is applied; the open proposals were made against the replaced key.
+func (k Keeper) clearVerifyingKeyRotationGovernance(ctx sdk.Context) {
+	for _, proposal := range k.GetVerifyingKeyRotationProposals(ctx) {
+		k.deleteVerifyingKeyRotationProposal(ctx, proposal)
+	}
+}
+
+// validateVerifyingKeyRotation checks a proposed key against the current key
+// of its circuit's hash family: it must be a valid key for a supported
+// circuit and differ from the current key. The rotation replaces only that
+// family's key, so nullifiers spent under the current key stay spent under
+// the new one; a family without a key receives its first one. A rotation
+// cannot start while the family's previous transition window is still open.
+func (k Keeper) validateVerifyingKeyRotation(ctx sdk.Context, circuitID string, vkBytes []byte, vkSHA256 string, transitionBlocks int64) error {
+	if transitionBlocks < 0 || transitionBlocks > VerifyingKeyMaxTransitionBlocks {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "transition must be 0..%d blocks", VerifyingKeyMaxTransitionBlocks)
+	}
+	if _, err := ValidateMembershipVerifyingKey(vkBytes, circuitID, vkSHA256); err != nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	family := membershipCircuitNullifierFamilies[circuitID]
+	current, found := k.GetFamilyVerifyingKey(ctx, family)
+	if !found {
+		if family == HashFamilyMiMC {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "no verifying key to rotate; the first key is set in genesis")
+		}
+		return nil
+	}
+	if VerifyingKeyFingerprint(current) == vkSHA256 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "verifying key is already active")
+	}
+	if retiring, ok := k.activeRetiringVerifyingKey(ctx, family); ok {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "previous verifying key transition runs until height %d", retiring.EndHeight)
+	}
+	return nil
+}
+
+// VoteVerifyingKeyRotation records a governance member's vote to replace the
+// verifying key of circuitID's hash family with vkHex. The electorate is the
+// software-upgrade electorate: the first vote for a circuit, key and
+// transition opens a proposal that snapshots the reserved governance domain,
+// and later votes for it must come from that snapshot. A member who backs
+// another proposal moves their vote; a proposal left without votes is
+// dropped. At two thirds the key is rotated in place: the new key becomes
+// current, the replaced key keeps verifying proofs for transitionBlocks
+// blocks so members can fetch new prover keys, and every open proposal is
+// cleared. No genesis export is needed.
+func (k Keeper) VoteVerifyingKeyRotation(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	circuitID, vkHex, vkSHA256 string,
+	transitionBlocks int64,
+) (votes, eligibleCount int, rotated bool, err error) {
+	if sender.Empty() {
+		return 0, 0, false, errorsmod.Wrap(sdkerrors.ErrInvalidAddress, "sender address is required")
+	}
+	vkBytes, err := hex.DecodeString(vkHex)
+	if err != nil || hex.EncodeToString(vkBytes) != vkHex {
+		return 0, 0, false, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "verifying key must be lowercase hex")
+	}
+	if err := k.validateVerifyingKeyRotation(ctx, circuitID, vkBytes, vkSHA256, transitionBlocks); err != nil {
+		return 0, 0, false, err
+	}
+
+	domain, found := k.GetDomain(ctx, ReservedGovernanceDomain)
+	if !found {
+		return 0, 0, false, errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "reserved domain %s not found", ReservedGovernanceDomain)
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+
+	id := VerifyingKeyRotationProposal{CircuitID: circuitID, VerifyingKeySHA256: vkSHA256, TransitionBlocks: transitionBlocks}.id()
+	proposal, exists := k.getVerifyingKeyRotationProposal(cacheCtx, id)
+	if exists && ctx.BlockHeight() > proposal.VotingEndHeight {
+		// A lapsed proposal starts over.
+		k.deleteVerifyingKeyRotationProposal(cacheCtx, proposal)
+		exists = false
+	}
+	if !exists {
+		if !isMember(domain, sender.String()) {
+			return 0, 0, false, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only governance domain members can vote for verifying-key rotations")
+		}
+		eligible := snapshotEligibleMembers(domain)
+		if len(eligible) == 0 {
+			return 0, 0, false, errorsmod.Wrap(sdkerrors.ErrLogic, "governance domain has no members")
+		}
+		proposal = VerifyingKeyRotationProposal{
+			CircuitID:          circuitID,
+			VerifyingKeySHA256: vkSHA256,
+			TransitionBlocks:   transitionBlocks,
+			VotingEndHeight:    ctx.BlockHeight() + VerifyingKeyRotationVotingBlocks,
+			Eligible:           eligible,
+		}
+	} else if !isEligibleSnapshotMember(proposal.Eligible, sender.String()) {
+		return 0, 0, false, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "sender is not in the rotation eligibility snapshot")
+	}
+
+	store := cacheCtx.KVStore(k.StoreKey)
+	voteKey := verifyingKeyRotationVoteKey(sender.String())
+	if backed := store.Get(voteKey); backed != nil {
+		if string(backed) == id {
+			return 0, 0, false, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "sender already voted for this verifying-key rotation")
+		}
+		// Move the vote; a proposal nobody backs any more is dropped.
+		store.Delete(voteKey)
+		if previous, found := k.getVerifyingKeyRotationProposal(cacheCtx, string(backed)); found && k.countVerifyingKeyRotationVotes(cacheCtx, previous) == 0 {
+			k.deleteVerifyingKeyRotationProposal(cacheCtx, previous)
+		}
+	}
+	store.Set(voteKey, []byte(id))
+
+	votes = k.countVerifyingKeyRotationVotes(cacheCtx, proposal)
+	eligibleCount = len(proposal.Eligible)
+
+	if upgradeThresholdReached(votes, eligibleCount) {
+		k.rotateVerifyingKey(cacheCtx, circuitID, vkBytes, transitionBlocks)
+		k.clearVerifyingKeyRotationGovernance(cacheCtx)
+		rotated = true
+	} else {
+		store.Set(verifyingKeyRotationProposalKey(id), k.cdc.MustMarshalLengthPrefixed(&proposal))
+	}
+	write()
+	return votes, eligibleCount, rotated, nil
+}
+
+// rotateVerifyingKey makes vkBytes the current key of its circuit's family
+// and retires the replaced one for transitionBlocks blocks; with no
+// transition the old key stops verifying at once.
+func (k Keeper) rotateVerifyingKey(ctx sdk.Context, circuitID string, vkBytes []byte, transitionBlocks int64) {
+	family := membershipCircuitNullifierFamilies[circuitID]
+	k.deleteRetiringVerifyingKey(ctx, family)
+	if current, found := k.GetFamilyVerifyingKey(ctx, family); found && transitionBlocks > 0 {
+		k.setRetiringVerifyingKey(ctx, RetiringVerifyingKey{
+			CircuitID:          k.GetFamilyCircuitID(ctx, family),
+			VerifyingKeyHex:    hex.EncodeToString(current),
+			VerifyingKeySHA256: VerifyingKeyFingerprint(current),
+			EndHeight:          ctx.BlockHeight() + transitionBlocks,
+		})
+	}
+	k.setFamilyVerifyingKey(ctx, family, vkBytes)
+	k.setMembershipCircuitID(ctx, circuitID)
+}
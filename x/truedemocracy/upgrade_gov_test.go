@@ -1,12 +1,16 @@
 package truedemocracy
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
 
 	upgradetypes "cosmossdk.io/x/upgrade/types"
+	"github.com/consensys/gnark/backend/groth16"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -56,7 +60,7 @@ func createUpgradeDomain(t *testing.T, k Keeper, ctx sdk.Context, members []sdk.
 	for _, member := range members[1:] {
 		domain.Members = append(domain.Members, member.String())
 	}
-	ctx.KVStore(k.StoreKey).Set([]byte("domain:"+ReservedGovernanceDomain), k.cdc.MustMarshalLengthPrefixed(&domain))
+	k.SetDomain(ctx, domain)
 }
 
 func TestSoftwareUpgradeGovernanceFailsClosedAndValidatesPlan(t *testing.T) {
@@ -312,3 +316,217 @@ func TestSoftwareUpgradeUnscheduledProposalCanBeCancelledAfterLeadWindow(t *test
 		t.Fatal("two-thirds cancellation retained an expired unscheduled proposal")
 	}
 }
+
+// rotatedTestKeys runs a second trusted setup for the membership circuit, as
+// a new ceremony would.
+func rotatedTestKeys(t *testing.T) (*ZKPKeys, []byte) {
+	t.Helper()
+	current := getTestZKPKeys(t)
+	pk, vk, err := groth16.Setup(current.CS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vkBytes, err := SerializeVerifyingKey(vk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &ZKPKeys{ProvingKey: pk, VerifyingKey: vk, CS: current.CS}, vkBytes
+}
+
+func TestVerifyingKeyRotationAcceptsBothKeysDuringTransition(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	ctx = ctx.WithBlockHeight(10)
+	members := upgradeMembers()[:3]
+	createUpgradeDomain(t, k, ctx, members)
+	secrets := setupDomainWithZKPIdentity(t, k, ctx, "RotateDomain", 2)
+	oldVK, _ := k.GetVerifyingKey(ctx)
+	addProposal(t, k, ctx, "RotateDomain", "Climate", "GreenDeal")
+	addProposal(t, k, ctx, "RotateDomain", "Climate", "Solar")
+	oldProof, oldNullifier := generateZKPRating(t, k, ctx, "RotateDomain", secrets, 0, "Climate", "GreenDeal", 3)
+
+	newKeys, newVK := rotatedTestKeys(t)
+	newHex, newSHA := hex.EncodeToString(newVK), VerifyingKeyFingerprint(newVK)
+	votes, eligible, rotated, err := k.VoteVerifyingKeyRotation(ctx, members[0], MembershipCircuitID, newHex, newSHA, 100)
+	if err != nil || votes != 1 || eligible != 3 || rotated {
+		t.Fatalf("first vote = %d/%d rotated=%v err=%v", votes, eligible, rotated, err)
+	}
+	if current, _ := k.GetVerifyingKey(ctx); !bytes.Equal(current, oldVK) {
+		t.Fatal("key rotated before two thirds")
+	}
+	if _, _, rotated, err := k.VoteVerifyingKeyRotation(ctx, members[1], MembershipCircuitID, newHex, newSHA, 100); err != nil || !rotated {
+		t.Fatalf("threshold vote rotated=%v err=%v", rotated, err)
+	}
+	if current, _ := k.GetVerifyingKey(ctx); !bytes.Equal(current, newVK) {
+		t.Fatal("new key is not current")
+	}
+	if len(k.GetVerifyingKeyRotationProposals(ctx)) != 0 || k.HasVerifyingKeyRotationVote(ctx, members[0].String()) {
+		t.Fatal("rotation governance state left behind")
+	}
+	retiring, found := k.GetRetiringVerifyingKey(ctx)
+	if !found || retiring.VerifyingKeySHA256 != VerifyingKeyFingerprint(oldVK) || retiring.EndHeight != 110 || retiring.CircuitID != MembershipCircuitID {
+		t.Fatalf("retiring key = %+v", retiring)
+	}
+
+	// A proof made for the old key before the rotation still counts, and
+	// spends the same nullifier a new-key proof for that member would.
+	mid := ctx.WithBlockHeight(109)
+	if _, err := k.RateProposalWithZKP(mid, "RotateDomain", "Climate", "GreenDeal", 3, oldProof, oldNullifier, "", testRewardRecipient()); err != nil {
+		t.Fatalf("old-key proof rejected during transition: %v", err)
+	}
+	newProof, newNullifier := generateZKPRatingWithKeys(t, newKeys, k, mid, "RotateDomain", secrets, 0, "Climate", "GreenDeal", -2, testRewardRecipient())
+	if newNullifier != oldNullifier {
+		t.Fatal("nullifier depends on the verifying key")
+	}
+	if _, err := k.RateProposalWithZKP(mid, "RotateDomain", "Climate", "GreenDeal", -2, newProof, newNullifier, "", testRewardRecipient()); err == nil {
+		t.Fatal("member rated twice by switching keys")
+	}
+
+	// After the window only the new key verifies.
+	end := ctx.WithBlockHeight(110)
+	staleProof, staleNullifier := generateZKPRating(t, k, end, "RotateDomain", secrets, 1, "Climate", "Solar", 1)
+	if _, err := k.RateProposalWithZKP(end, "RotateDomain", "Climate", "Solar", 1, staleProof, staleNullifier, "", testRewardRecipient()); err == nil {
+		t.Fatal("old-key proof accepted after the transition window")
+	}
+	freshProof, freshNullifier := generateZKPRatingWithKeys(t, newKeys, k, end, "RotateDomain", secrets, 1, "Climate", "Solar", 1, testRewardRecipient())
+	if _, err := k.RateProposalWithZKP(end, "RotateDomain", "Climate", "Solar", 1, freshProof, freshNullifier, "", testRewardRecipient()); err != nil {
+		t.Fatalf("new-key proof rejected: %v", err)
+	}
+}
+
+func TestVerifyingKeyRotationValidatesProposalAndVoters(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	ctx = ctx.WithBlockHeight(10)
+	members := upgradeMembers()
+	createUpgradeDomain(t, k, ctx, members)
+	currentVK := setTestVerifyingKey(t, k, ctx)
+	_, newVK := rotatedTestKeys(t)
+	newHex, newSHA := hex.EncodeToString(newVK), VerifyingKeyFingerprint(newVK)
+
+	rejects := []struct {
+		name       string
+		sender     sdk.AccAddress
+		circuitID  string
+		vkHex, sha string
+		transition int64
+	}{
+		{"non-member", sdk.AccAddress("outsider"), MembershipCircuitID, newHex, newSHA, 10},
+		{"unsupported circuit", members[0], "v3-bn254-unknown", newHex, newSHA, 10},
+		{"fingerprint mismatch", members[0], MembershipCircuitID, newHex, VerifyingKeyFingerprint(currentVK), 10},
+		{"uppercase hex", members[0], MembershipCircuitID, strings.ToUpper(newHex), newSHA, 10},
+		{"current key", members[0], MembershipCircuitID, hex.EncodeToString(currentVK), VerifyingKeyFingerprint(currentVK), 10},
+		{"transition too long", members[0], MembershipCircuitID, newHex, newSHA, VerifyingKeyMaxTransitionBlocks + 1},
+	}
+	for _, tc := range rejects {
+		if _, _, _, err := k.VoteVerifyingKeyRotation(ctx, tc.sender, tc.circuitID, tc.vkHex, tc.sha, tc.transition); err == nil {
+			t.Fatalf("%s: vote accepted", tc.name)
+		}
+	}
+	if len(k.GetVerifyingKeyRotationProposals(ctx)) != 0 {
+		t.Fatal("rejected votes created a proposal")
+	}
+
+	if _, _, _, err := k.VoteVerifyingKeyRotation(ctx, members[0], MembershipCircuitID, newHex, newSHA, 10); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := k.VoteVerifyingKeyRotation(ctx, members[0], MembershipCircuitID, newHex, newSHA, 10); err == nil {
+		t.Fatal("duplicate vote accepted")
+	}
+
+	// A competing proposal runs alongside the first, so one member cannot
+	// hold rotations up.
+	votes, _, _, err := k.VoteVerifyingKeyRotation(ctx, members[1], MembershipCircuitID, newHex, newSHA, 20)
+	if err != nil || votes != 1 || len(k.GetVerifyingKeyRotationProposals(ctx)) != 2 {
+		t.Fatalf("competing vote = %d, err %v", votes, err)
+	}
+	// Backing the competitor moves the vote and drops the emptied proposal.
+	votes, _, _, err = k.VoteVerifyingKeyRotation(ctx, members[0], MembershipCircuitID, newHex, newSHA, 20)
+	proposals := k.GetVerifyingKeyRotationProposals(ctx)
+	if err != nil || votes != 2 || len(proposals) != 1 || proposals[0].TransitionBlocks != 20 {
+		t.Fatalf("moved vote = %d, proposals %+v, err %v", votes, proposals, err)
+	}
+
+	// Once the voting window lapses, the proposal starts over.
+	lapsed := ctx.WithBlockHeight(10 + VerifyingKeyRotationVotingBlocks + 1)
+	votes, _, _, err = k.VoteVerifyingKeyRotation(lapsed, members[2], MembershipCircuitID, newHex, newSHA, 20)
+	if err != nil || votes != 1 || k.HasVerifyingKeyRotationVote(lapsed, members[0].String()) {
+		t.Fatalf("restarted vote = %d, err %v", votes, err)
+	}
+	for _, member := range []sdk.AccAddress{members[3], members[0]} {
+		if _, _, _, err := k.VoteVerifyingKeyRotation(lapsed, member, MembershipCircuitID, newHex, newSHA, 20); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if current, _ := k.GetVerifyingKey(lapsed); !bytes.Equal(current, newVK) {
+		t.Fatal("rotation not applied")
+	}
+
+	// A further rotation waits for the open transition to end.
+	if _, _, _, err := k.VoteVerifyingKeyRotation(lapsed, members[0], MembershipCircuitID, hex.EncodeToString(currentVK), VerifyingKeyFingerprint(currentVK), 0); err == nil {
+		t.Fatal("rotation accepted during an open transition")
+	}
+}
+
+func TestVerifyingKeyRotationGenesisRoundTrip(t *testing.T) {
+	am, k, ctx := setupModuleForGenesis(t)
+	ctx = ctx.WithBlockHeight(10)
+	members := upgradeMembers()[:1]
+	createUpgradeDomain(t, k, ctx, members)
+	oldVK := setTestVerifyingKey(t, k, ctx)
+	_, newVK := rotatedTestKeys(t)
+	if _, _, rotated, err := k.VoteVerifyingKeyRotation(ctx, members[0], MembershipCircuitID, hex.EncodeToString(newVK), VerifyingKeyFingerprint(newVK), 50); err != nil || !rotated {
+		t.Fatalf("rotation rotated=%v err=%v", rotated, err)
+	}
+
+	var genesis GenesisState
+	if err := json.Unmarshal(am.ExportGenesis(ctx, nil), &genesis); err != nil {
+		t.Fatal(err)
+	}
+	if genesis.VerifyingKeySHA256 != VerifyingKeyFingerprint(newVK) || genesis.RetiringVerifyingKey == nil ||
+		genesis.RetiringVerifyingKey.VerifyingKeySHA256 != VerifyingKeyFingerprint(oldVK) || genesis.RetiringVerifyingKey.EndHeight != 60 {
+		t.Fatalf("exported keys = %s, retiring %+v", genesis.VerifyingKeySHA256, genesis.RetiringVerifyingKey)
+	}
+	if err := ValidateGenesisState(genesis); err != nil {
+		t.Fatalf("exported genesis invalid: %v", err)
+	}
+
+	am2, k2, ctx2 := setupModuleForGenesis(t)
+	bz, _ := json.Marshal(genesis)
+	am2.InitGenesis(ctx2, nil, bz)
+	if retiring, found := k2.GetRetiringVerifyingKey(ctx2); !found || *genesis.RetiringVerifyingKey != retiring {
+		t.Fatalf("restored retiring key = %+v", retiring)
+	}
+	if k2.GetMembershipCircuitID(ctx2) != MembershipCircuitID {
+		t.Fatal("circuit id not restored")
+	}
+
+	// Once the window closes the retiring key is no longer exported.
+	var later GenesisState
+	if err := json.Unmarshal(am.ExportGenesis(ctx.WithBlockHeight(60), nil), &later); err != nil {
+		t.Fatal(err)
+	}
+	if later.RetiringVerifyingKey != nil {
+		t.Fatal("expired retiring key exported")
+	}
+
+	invalid := []func(*GenesisState){
+		func(g *GenesisState) { g.VerifyingKeyHex, g.VerifyingKeySHA256, g.ZKPCircuitID = "", "", "" },
+		func(g *GenesisState) { g.RetiringVerifyingKey.VerifyingKeySHA256 = g.VerifyingKeySHA256 },
+		func(g *GenesisState) { g.RetiringVerifyingKey.CircuitID = "v3-bn254-unknown" },
+		func(g *GenesisState) { g.RetiringVerifyingKey.EndHeight = 0 },
+	}
+	for i, mutate := range invalid {
+		g := validDemocracyGenesis()
+		g.ZKPCircuitID, g.VerifyingKeyHex, g.VerifyingKeySHA256 = MembershipCircuitID, hex.EncodeToString(newVK), VerifyingKeyFingerprint(newVK)
+		g.RetiringVerifyingKey = &RetiringVerifyingKey{
+			CircuitID: MembershipCircuitID, VerifyingKeyHex: hex.EncodeToString(oldVK),
+			VerifyingKeySHA256: VerifyingKeyFingerprint(oldVK), EndHeight: 60,
+		}
+		if err := ValidateGenesisState(g); err != nil {
+			t.Fatalf("baseline genesis invalid: %v", err)
+		}
+		mutate(&g)
+		if err := ValidateGenesisState(g); err == nil {
+			t.Fatalf("invalid retiring key %d accepted", i)
+		}
+	}
+}
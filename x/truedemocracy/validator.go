@@ -78,18 +78,10 @@ func (k Keeper) RegisterValidator(ctx sdk.Context, operatorAddr string, pubKeyBy
 	}
 
 	// Verify the operator is a member of the domain.
-	domain, found := k.GetDomain(ctx, domainName)
-	if !found {
+	if _, found := k.GetDomainHeader(ctx, domainName); !found {
 		return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "domain not found")
 	}
-	isMember := false
-	for _, m := range domain.Members {
-		if m == operatorAddr {
-			isMember = true
-			break
-		}
-	}
-	if !isMember {
+	if !k.IsDomainMember(ctx, domainName, operatorAddr) {
 		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "operator is not a member of the domain")
 	}
 
@@ -124,8 +116,7 @@ func (k Keeper) RegisterValidator(ctx sdk.Context, operatorAddr string, pubKeyBy
 		MissedBlocks: 0,
 	}
 
-	valBz := k.cdc.MustMarshalLengthPrefixed(&val)
-	store.Set(validatorKey(operatorAddr), valBz)
+	k.SetValidator(ctx, val)
 	store.Set(valPubKeyKey(pubKeyBytes), []byte(operatorAddr))
 	store.Set(consensusAuthorityIndexKey(consensusKeyDerivedOperator(pubKeyBytes)), []byte(operatorAddr))
 	k.registerConsensusKeyRecord(ctx, pubKeyBytes, operatorAddr, validatorUpdateActivationHeight(ctx))
@@ -145,21 +136,32 @@ func (k Keeper) GetValidator(ctx sdk.Context, operatorAddr string) (Validator, b
 	return val, true
 }
 
-// SetValidator persists a validator to the store.
+// SetValidator persists a validator to the store, settling its staking
+// rewards at the stake it is replacing and moving its validator-set rank.
 func (k Keeper) SetValidator(ctx sdk.Context, val Validator) {
+	k.trackValidatorRewards(ctx, val)
+	k.trackValidatorRank(ctx, val)
 	store := ctx.KVStore(k.StoreKey)
 	bz := k.cdc.MustMarshalLengthPrefixed(&val)
 	store.Set(validatorKey(val.OperatorAddr), bz)
 }
 
 // QueueValidatorPowerZero records a one-shot removal only for a key that may
-// already exist in CometBFT's validator set. A just-rotated replacement key is
-// not active until H+2; queuing power zero for it in the rotation block would
-// make CometBFT reject an attempt to remove a validator it has never seen.
+// already exist in CometBFT's validator set. A validator waiting for a seat
+// was never sent to CometBFT. A just-rotated replacement key is not active
+// until H+2; queuing power zero for it in the rotation block would make
+// CometBFT reject an attempt to remove a validator it has never seen.
 func (k Keeper) QueueValidatorPowerZero(ctx sdk.Context, val Validator) {
+	k.queueValidatorPowerZero(ctx, val, "jailed")
+}
+
+func (k Keeper) queueValidatorPowerZero(ctx sdk.Context, val Validator, reason string) {
 	if val.Jailed || val.Power <= 0 {
 		return
 	}
+	if !k.leaveValidatorSet(ctx, val, reason) {
+		return
+	}
 	store := ctx.KVStore(k.StoreKey)
 	if bz := store.Get(pendingValidatorRotationKey(val.OperatorAddr)); bz != nil {
 		var pending PendingValidatorKeyRotation
@@ -264,7 +266,9 @@ func (k Keeper) RotateValidatorKey(ctx sdk.Context, sender sdk.AccAddress, opera
 	}
 
 	store.Delete(valPubKeyKey(oldPubKey))
-	store.Set(removedValidatorKey(oldPubKey), oldPubKey)
+	if k.IsValidatorSetMember(cacheCtx, operatorAddr) {
+		store.Set(removedValidatorKey(oldPubKey), oldPubKey)
+	}
 	store.Set(revokedValidatorKey(oldPubKey), k.cdc.MustMarshalLengthPrefixed(&revoked))
 	store.Set(consensusAuthorityIndexKey(consensusKeyDerivedOperator(oldPubKey)), []byte(operatorAddr))
 	store.Set(consensusAuthorityIndexKey(consensusKeyDerivedOperator(newPubKey)), []byte(operatorAddr))
@@ -386,27 +390,26 @@ func (k Keeper) WithdrawStake(ctx sdk.Context, operatorAddr string, amount int64
 	}
 
 	// Update domain accounting only after every withdrawal precondition passed.
-	domain, found := k.GetDomain(ctx, domainName)
+	domain, found := k.GetDomainHeader(ctx, domainName)
 	if !found {
 		return errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "domain %s not found", domainName)
 	}
 	domain.TransferredStake += amount
-	store := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	store.Set([]byte("domain:"+domainName), bz)
+	k.SetDomainHeader(ctx, domain)
 
 	if newStake == 0 {
 		return k.RemoveValidator(ctx, operatorAddr)
 	}
 
 	val.Stake = sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, newStake))
-	val.Power = newStake / rewards.StakeMin
+	val.Power = validatorPowerFromStake(val)
 	k.SetValidator(ctx, val)
 	return nil
 }
 
 // RemoveValidator deletes a validator, its reverse index, and records a
-// one-shot CometBFT power-zero update for the removed consensus key.
+// one-shot CometBFT power-zero update for the removed consensus key if it
+// held a seat. Its delegations move into evidence-window undelegation holds.
 func (k Keeper) RemoveValidator(ctx sdk.Context, operatorAddr string) error {
 	val, found := k.GetValidator(ctx, operatorAddr)
 	if !found {
@@ -416,9 +419,18 @@ func (k Keeper) RemoveValidator(ctx sdk.Context, operatorAddr string) error {
 	if store.Has(pendingValidatorRotationKey(operatorAddr)) {
 		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "validator key rotation is pending")
 	}
+	if _, err := k.unbondValidatorDelegations(ctx, val); err != nil {
+		return err
+	}
+	member := k.leaveValidatorSet(ctx, val, "removed")
+	k.untrackValidatorRewards(ctx, val)
+	k.untrackValidatorRank(ctx, val)
+	if member {
+		store.Set(removedValidatorKey(val.PubKey), append([]byte(nil), val.PubKey...))
+	}
+	store.Delete(validatorDescriptionKey(operatorAddr))
 	store.Delete(validatorKey(operatorAddr))
 	store.Delete(valPubKeyKey(val.PubKey))
-	store.Set(removedValidatorKey(val.PubKey), append([]byte(nil), val.PubKey...))
 	k.retireConsensusKeyRecord(ctx, val.PubKey, ctx.BlockHeight()+sdk.ValidatorUpdateDelay+1)
 	return nil
 }
@@ -467,15 +479,8 @@ func (k Keeper) EnforceDomainMembership(ctx sdk.Context, operatorAddr string) bo
 
 	var active []string
 	for _, domName := range val.Domains {
-		domain, ok := k.GetDomain(ctx, domName)
-		if !ok {
-			continue
-		}
-		for _, m := range domain.Members {
-			if m == operatorAddr {
-				active = append(active, domName)
-				break
-			}
+		if k.IsDomainMember(ctx, domName, operatorAddr) {
+			active = append(active, domName)
 		}
 	}
 
@@ -488,8 +493,11 @@ func (k Keeper) EnforceDomainMembership(ctx sdk.Context, operatorAddr string) bo
 	return true
 }
 
-// DistributeStakingRewards distributes node staking rewards (eq.5) to all
-// bonded validators if at least RewardInterval seconds have elapsed.
+// DistributeStakingRewards mints node staking rewards (eq.5) on the total
+// bonded stake if at least RewardInterval seconds have elapsed and accrues
+// them to the reward index. Validators and delegators are settled lazily and
+// claim with MsgClaimStakingRewards, so the cost does not grow with the
+// number of validators.
 func (k Keeper) DistributeStakingRewards(ctx sdk.Context) error {
 	cacheCtx, write := ctx.CacheContext()
 	store := cacheCtx.KVStore(k.StoreKey)
@@ -517,43 +525,12 @@ func (k Keeper) DistributeStakingRewards(ctx sdk.Context) error {
 		return errorsmod.Wrap(err, "read canonical supply for staking rewards")
 	}
 
-	type allocation struct {
-		validator Validator
-		requested math.Int
-	}
-	var allocations []allocation
-	totalRequested := math.ZeroInt()
-	k.IterateValidators(cacheCtx, func(val Validator) bool {
-		if val.Jailed {
-			return false
-		}
-		stakeAmt := val.Stake.AmountOf(PNYXDenom)
-		reward := rewards.CalcNodeReward(stakeAmt, supply, elapsed)
-		if reward.IsPositive() {
-			allocations = append(allocations, allocation{validator: val, requested: reward})
-			totalRequested = totalRequested.Add(reward)
-		}
-		return false
-	})
-
-	minted, err := k.issuer.MintUpToCap(cacheCtx, totalRequested)
+	requested := rewards.CalcNodeReward(k.getStakingRewardPool(cacheCtx).BondedStake, supply, elapsed)
+	minted, err := k.issuer.MintUpToCap(cacheCtx, requested)
 	if err != nil {
 		return errorsmod.Wrap(err, "mint staking rewards")
 	}
-	remaining := minted
-	for _, allocation := range allocations {
-		grant := allocation.requested
-		if grant.GT(remaining) {
-			grant = remaining
-		}
-		if !grant.IsPositive() {
-			break
-		}
-		allocation.validator.Stake = allocation.validator.Stake.Add(sdk.NewCoin(PNYXDenom, grant))
-		allocation.validator.Power = allocation.validator.Stake.AmountOf(PNYXDenom).Int64() / rewards.StakeMin
-		k.SetValidator(cacheCtx, allocation.validator)
-		remaining = remaining.Sub(grant)
-	}
+	k.addStakingRewards(cacheCtx, minted)
 	store.Set([]byte("pod:last-reward-time"), k.cdc.MustMarshalLengthPrefixed(blockTime))
 
 	write()
@@ -576,7 +553,7 @@ func (k Keeper) DistributeDomainInterest(ctx sdk.Context) error {
 	} else {
 		bz := k.cdc.MustMarshalLengthPrefixed(blockTime)
 		store.Set([]byte("dom:last-interest-time"), bz)
-		k.IterateDomains(cacheCtx, func(domain Domain) bool {
+		k.IterateDomainHeaders(cacheCtx, func(domain Domain) bool {
 			store.Set(domainPayoutSnapshotKey(domain.Name), k.cdc.MustMarshalLengthPrefixed(domain.TotalPayouts))
 			return false
 		})
@@ -600,7 +577,7 @@ func (k Keeper) DistributeDomainInterest(ctx sdk.Context) error {
 	}
 	var allocations []allocation
 	totalRequested := math.ZeroInt()
-	k.IterateDomains(cacheCtx, func(domain Domain) bool {
+	k.IterateDomainHeaders(cacheCtx, func(domain Domain) bool {
 		treasure := domain.Treasury.AmountOf(PNYXDenom)
 		snapshotKey := domainPayoutSnapshotKey(domain.Name)
 		previousPayouts := domain.TotalPayouts
@@ -636,7 +613,7 @@ func (k Keeper) DistributeDomainInterest(ctx sdk.Context) error {
 			break
 		}
 		allocation.domain.Treasury = allocation.domain.Treasury.Add(sdk.NewCoin(PNYXDenom, grant))
-		store.Set([]byte("domain:"+allocation.domain.Name), k.cdc.MustMarshalLengthPrefixed(&allocation.domain))
+		k.SetDomainHeader(cacheCtx, allocation.domain)
 		remaining = remaining.Sub(grant)
 	}
 	store.Set([]byte("dom:last-interest-time"), k.cdc.MustMarshalLengthPrefixed(blockTime))
@@ -649,22 +626,31 @@ func domainPayoutSnapshotKey(domainName string) []byte {
 }
 
 // BuildValidatorUpdates constructs the CometBFT ValidatorUpdate slice for the
-// current validator set. Jailed validators are reported with Power 0. Validators
-// removed since the last call are emitted once with Power 0 so CometBFT can
-// evict them from the consensus set.
+// current validator set. It first applies this block's promotions and
+// demotions (validator_set.go), then reports promoted members, rotated keys
+// and, at an epoch boundary, changed powers. Validators that were jailed,
+// demoted or removed since the last call are emitted once with Power 0 so
+// CometBFT can evict them from the consensus set without waiting for the
+// epoch to end.
 func (k Keeper) BuildValidatorUpdates(ctx sdk.Context) []abci.ValidatorUpdate {
 	var updates []abci.ValidatorUpdate
 
-	k.IterateValidators(ctx, func(val Validator) bool {
-		if val.Jailed || val.Power <= 0 {
-			return false
+	boundary := k.IsValidatorSetEpochBoundary(ctx)
+	k.updateValidatorSet(ctx, boundary)
+	for _, operatorAddr := range k.validatorSetMembers(ctx) {
+		val, found := k.GetValidator(ctx, operatorAddr)
+		if !found || !rankedValidator(val) {
+			continue
+		}
+		power, changed := k.reportValidatorPower(ctx, val, boundary)
+		if !changed {
+			continue
 		}
 		pk := cryptoproto.PublicKey{
 			Sum: &cryptoproto.PublicKey_Ed25519{Ed25519: val.PubKey},
 		}
-		updates = append(updates, abci.ValidatorUpdate{PubKey: pk, Power: val.Power})
-		return false
-	})
+		updates = append(updates, abci.ValidatorUpdate{PubKey: pk, Power: power})
+	}
 
 	store := ctx.KVStore(k.StoreKey)
 	removedPrefix := []byte("validator-removed:")
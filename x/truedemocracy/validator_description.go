@@ -0,0 +1,134 @@
+package truedemocracy
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Validator metadata lives next to the validator record and goes with it:
+//   "validator-description:{operator}" → ValidatorDescription
+//
+// MsgEditValidator replaces individual fields; DoNotModifyDescription keeps
+// one as it is. Commission changes are rate limited so delegators can react
+// before a rate drifts far from the one they delegated at.
+
+// Validator metadata bounds, in bytes.
+const (
+	MaxMonikerLength         = 70
+	MaxWebsiteLength         = 140
+	MaxSecurityContactLength = 140
+	MaxIdentityLength        = 64
+)
+
+// Commission change limits of MsgEditValidator.
+const (
+	MaxCommissionChangeBps   int64 = 100   // 1 percentage point per change
+	CommissionChangeInterval int64 = 86400 // seconds between changes (1 day)
+)
+
+// DoNotModifyDescription marks a MsgEditValidator field to keep.
+const DoNotModifyDescription = "[do-not-modify]"
+
+func validatorDescriptionKey(operatorAddr string) []byte {
+	return []byte("validator-description:" + operatorAddr)
+}
+
+// validateValidatorDescription checks the metadata bounds.
+func validateValidatorDescription(description ValidatorDescription) error {
+	for _, field := range []struct {
+		name, value string
+		max         int
+	}{
+		{"moniker", description.Moniker, MaxMonikerLength},
+		{"website", description.Website, MaxWebsiteLength},
+		{"security contact", description.SecurityContact, MaxSecurityContactLength},
+		{"identity", description.Identity, MaxIdentityLength},
+	} {
+		if len(field.value) > field.max {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "%s exceeds %d bytes", field.name, field.max)
+		}
+	}
+	return nil
+}
+
+// GetValidatorDescription returns a validator's metadata; a validator that
+// never set any has an empty description.
+func (k Keeper) GetValidatorDescription(ctx sdk.Context, operatorAddr string) (ValidatorDescription, bool) {
+	bz := ctx.KVStore(k.StoreKey).Get(validatorDescriptionKey(operatorAddr))
+	if bz == nil {
+		return ValidatorDescription{OperatorAddr: operatorAddr}, false
+	}
+	var description ValidatorDescription
+	k.cdc.MustUnmarshalLengthPrefixed(bz, &description)
+	return description, true
+}
+
+func (k Keeper) setValidatorDescription(ctx sdk.Context, description ValidatorDescription) {
+	ctx.KVStore(k.StoreKey).Set(validatorDescriptionKey(description.OperatorAddr), k.cdc.MustMarshalLengthPrefixed(&description))
+}
+
+// IterateValidatorDescriptions visits every validator description in
+// operator order. Returning true stops iteration.
+func (k Keeper) IterateValidatorDescriptions(ctx sdk.Context, fn func(ValidatorDescription) bool) {
+	prefix := []byte("validator-description:")
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var description ValidatorDescription
+		k.cdc.MustUnmarshalLengthPrefixed(iter.Value(), &description)
+		if fn(description) {
+			return
+		}
+	}
+}
+
+// EditValidator updates the metadata of the sender's validator and, if
+// updateCommission is set, its commission. Fields equal to
+// DoNotModifyDescription keep their value. A commission change may move the
+// rate by at most MaxCommissionChangeBps, once per CommissionChangeInterval.
+func (k Keeper) EditValidator(ctx sdk.Context, sender sdk.AccAddress, edit ValidatorDescription, commissionBps int64, updateCommission bool) error {
+	operatorAddr := sender.String()
+	val, found := k.GetValidator(ctx, operatorAddr)
+	if !found {
+		return errorsmod.Wrap(sdkerrors.ErrUnknownRequest, "validator not found")
+	}
+	description, _ := k.GetValidatorDescription(ctx, operatorAddr)
+	if edit.Moniker != DoNotModifyDescription {
+		description.Moniker = edit.Moniker
+	}
+	if edit.Website != DoNotModifyDescription {
+		description.Website = edit.Website
+	}
+	if edit.SecurityContact != DoNotModifyDescription {
+		description.SecurityContact = edit.SecurityContact
+	}
+	if edit.Identity != DoNotModifyDescription {
+		description.Identity = edit.Identity
+	}
+	if err := validateValidatorDescription(description); err != nil {
+		return err
+	}
+
+	if updateCommission && commissionBps != val.CommissionBps {
+		if err := validateCommissionBps(commissionBps); err != nil {
+			return err
+		}
+		change := commissionBps - val.CommissionBps
+		if change > MaxCommissionChangeBps || -change > MaxCommissionChangeBps {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest,
+				"commission may change by at most %d basis points at a time", MaxCommissionChangeBps)
+		}
+		now := ctx.BlockTime().Unix()
+		if description.CommissionUpdatedAt != 0 && now-description.CommissionUpdatedAt < CommissionChangeInterval {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest,
+				"commission was changed less than %d seconds ago", CommissionChangeInterval)
+		}
+		description.CommissionUpdatedAt = now
+		// SetValidator settles accrued rewards at the old rate first.
+		val.CommissionBps = commissionBps
+		k.SetValidator(ctx, val)
+	}
+	k.setValidatorDescription(ctx, description)
+	return nil
+}
@@ -0,0 +1,205 @@
+package truedemocracy
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	rewards "truerepublic/treasury/keeper"
+)
+
+func keepDescription() ValidatorDescription {
+	return ValidatorDescription{
+		Moniker:         DoNotModifyDescription,
+		Website:         DoNotModifyDescription,
+		SecurityContact: DoNotModifyDescription,
+		Identity:        DoNotModifyDescription,
+	}
+}
+
+func TestEditValidatorDescription(t *testing.T) {
+	k, ctx, _, operator, delegator := setupDelegationValidator(t)
+
+	edit := keepDescription()
+	edit.Moniker = "Alpine Node"
+	edit.Website = "https://alpine.example"
+	if err := k.EditValidator(ctx, operator, edit, 0, false); err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	edit = keepDescription()
+	edit.SecurityContact = "security@alpine.example"
+	if err := k.EditValidator(ctx, operator, edit, 0, false); err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	description, found := k.GetValidatorDescription(ctx, operator.String())
+	if !found || description.Moniker != "Alpine Node" || description.Website != "https://alpine.example" ||
+		description.SecurityContact != "security@alpine.example" || description.Identity != "" {
+		t.Fatalf("description = %+v, found %v", description, found)
+	}
+
+	edit = keepDescription()
+	edit.Moniker = strings.Repeat("m", MaxMonikerLength+1)
+	if err := k.EditValidator(ctx, operator, edit, 0, false); err == nil {
+		t.Fatal("oversized moniker accepted")
+	}
+	if err := k.EditValidator(ctx, delegator, keepDescription(), 0, false); err == nil {
+		t.Fatal("non-validator edited a description")
+	}
+
+	resp, err := k.Validator(ctx, &QueryValidatorRequest{OperatorAddr: operator.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary ValidatorSummary
+	if err := json.Unmarshal(resp.Result, &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.OperatorAddr != operator.String() || summary.Description == nil || summary.Description.Moniker != "Alpine Node" {
+		t.Fatalf("validator query = %+v", summary)
+	}
+	list, err := k.Validators(ctx, &QueryValidatorsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summaries []ValidatorSummary
+	if err := json.Unmarshal(list.Result, &summaries); err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 1 || summaries[0].Description == nil || summaries[0].Description.Website != "https://alpine.example" {
+		t.Fatalf("validators query = %+v", summaries)
+	}
+
+	domain, _ := k.GetDomain(ctx, "Delegated")
+	domain.TotalPayouts = rewards.StakeMin * 10
+	k.SetDomain(ctx, domain)
+	if err := k.WithdrawStake(ctx, operator.String(), rewards.StakeMin); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := k.GetValidatorDescription(ctx, operator.String()); found {
+		t.Fatal("description outlived the validator")
+	}
+}
+
+func TestEditValidatorCommissionIsRateLimited(t *testing.T) {
+	k, ctx, _, operator, _ := setupDelegationValidator(t)
+
+	if err := k.EditValidator(ctx, operator, keepDescription(), MaxCommissionChangeBps+1, true); err == nil {
+		t.Fatal("commission jump beyond the change limit accepted")
+	}
+	if err := k.EditValidator(ctx, operator, keepDescription(), MaxCommissionChangeBps, true); err != nil {
+		t.Fatalf("commission change: %v", err)
+	}
+	val, _ := k.GetValidator(ctx, operator.String())
+	description, _ := k.GetValidatorDescription(ctx, operator.String())
+	if val.CommissionBps != MaxCommissionChangeBps || description.CommissionUpdatedAt != ctx.BlockTime().Unix() {
+		t.Fatalf("commission = %d updated at %d", val.CommissionBps, description.CommissionUpdatedAt)
+	}
+
+	soon := ctx.WithBlockTime(ctx.BlockTime().Add(time.Hour))
+	if err := k.EditValidator(soon, operator, keepDescription(), 2*MaxCommissionChangeBps, true); err == nil {
+		t.Fatal("second commission change within the interval accepted")
+	}
+	// Metadata edits and an unchanged rate are not rate limited.
+	edit := keepDescription()
+	edit.Identity = "0123456789ABCDEF"
+	if err := k.EditValidator(soon, operator, edit, MaxCommissionChangeBps, true); err != nil {
+		t.Fatalf("edit with unchanged commission: %v", err)
+	}
+
+	later := ctx.WithBlockTime(ctx.BlockTime().Add(time.Duration(CommissionChangeInterval) * time.Second))
+	if err := k.EditValidator(later, operator, keepDescription(), 0, true); err != nil {
+		t.Fatalf("commission change after the interval: %v", err)
+	}
+	if val, _ := k.GetValidator(later, operator.String()); val.CommissionBps != 0 {
+		t.Fatalf("commission = %d, want 0", val.CommissionBps)
+	}
+}
+
+func TestValidatorDescriptionGenesis(t *testing.T) {
+	am1, k1, ctx1 := setupModuleForGenesis(t)
+	admin := sdk.AccAddress("genesis-description-admin")
+	k1.CreateDomain(ctx1, "Described", admin, sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 500_000*PNYXUnit)))
+	operator := sdk.AccAddress("genesis-described-operator")
+	if err := k1.AddMember(ctx1, "Described", operator.String(), admin); err != nil {
+		t.Fatal(err)
+	}
+	stake := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, rewards.StakeMin))
+	if err := k1.RegisterValidator(ctx1, operator.String(), testPubKey("genesis-described"), stake, "Described"); err != nil {
+		t.Fatal(err)
+	}
+	edit := keepDescription()
+	edit.Moniker = "Described"
+	if err := k1.EditValidator(ctx1, operator, edit, 50, true); err != nil {
+		t.Fatal(err)
+	}
+
+	exported := am1.ExportGenesis(ctx1, nil)
+	var genesis GenesisState
+	if err := json.Unmarshal(exported, &genesis); err != nil {
+		t.Fatal(err)
+	}
+	if len(genesis.ValidatorDescriptions) != 1 || genesis.ValidatorDescriptions[0].Moniker != "Described" {
+		t.Fatalf("exported descriptions = %+v", genesis.ValidatorDescriptions)
+	}
+	am2, k2, ctx2 := setupModuleForGenesis(t)
+	am2.InitGenesis(ctx2, nil, exported)
+	want, _ := k1.GetValidatorDescription(ctx1, operator.String())
+	if got, found := k2.GetValidatorDescription(ctx2, operator.String()); !found || got != want {
+		t.Fatalf("restored description = %+v, want %+v", got, want)
+	}
+
+	valid := func() GenesisState {
+		genesis := validActiveInactiveGenesis()
+		genesis.ValidatorDescriptions = []ValidatorDescription{{
+			OperatorAddr: genesis.Validators[0].OperatorAddr, Moniker: "Active", CommissionUpdatedAt: 1,
+		}}
+		return genesis
+	}
+	if err := ValidateGenesisState(valid()); err != nil {
+		t.Fatalf("valid description genesis rejected: %v", err)
+	}
+	for name, mutate := range map[string]func(*GenesisState){
+		"unknown validator": func(g *GenesisState) {
+			g.ValidatorDescriptions[0].OperatorAddr = sdk.AccAddress("nobody").String()
+		},
+		"duplicate": func(g *GenesisState) {
+			g.ValidatorDescriptions = append(g.ValidatorDescriptions, g.ValidatorDescriptions[0])
+		},
+		"long website":  func(g *GenesisState) { g.ValidatorDescriptions[0].Website = strings.Repeat("w", MaxWebsiteLength+1) },
+		"negative time": func(g *GenesisState) { g.ValidatorDescriptions[0].CommissionUpdatedAt = -1 },
+	} {
+		genesis := valid()
+		mutate(&genesis)
+		if err := ValidateGenesisState(genesis); err == nil {
+			t.Errorf("%s accepted", name)
+		}
+	}
+}
+
+func TestMsgEditValidatorValidateBasic(t *testing.T) {
+	msg := MsgEditValidator{
+		Sender:          sdk.AccAddress("editor"),
+		Moniker:         "Alpine Node",
+		Website:         DoNotModifyDescription,
+		SecurityContact: DoNotModifyDescription,
+		Identity:        DoNotModifyDescription,
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		t.Fatalf("valid edit rejected: %v", err)
+	}
+	for name, mutate := range map[string]func(*MsgEditValidator){
+		"no sender":       func(m *MsgEditValidator) { m.Sender = nil },
+		"long identity":   func(m *MsgEditValidator) { m.Identity = strings.Repeat("i", MaxIdentityLength+1) },
+		"long contact":    func(m *MsgEditValidator) { m.SecurityContact = strings.Repeat("c", MaxSecurityContactLength+1) },
+		"commission high": func(m *MsgEditValidator) { m.CommissionBps, m.UpdateCommission = MaxCommissionBps+1, true },
+	} {
+		bad := msg
+		mutate(&bad)
+		if err := bad.ValidateBasic(); err == nil {
+			t.Errorf("%s accepted", name)
+		}
+	}
+}
@@ -29,6 +29,8 @@ func setupRotationValidator(t *testing.T, k Keeper, ctx sdk.Context) (sdk.AccAdd
 	if err := k.RegisterValidator(ctx, operator.String(), testPubKey("rotation-old"), stake, "Rotation"); err != nil {
 		t.Fatal(err)
 	}
+	// Seat the validator so CometBFT knows the key being rotated.
+	k.BuildValidatorUpdates(ctx.WithBlockHeight(ctx.BlockHeight() - 1).WithEventManager(sdk.NewEventManager()))
 	validator, found := k.GetValidator(ctx, operator.String())
 	if !found {
 		t.Fatal("validator missing")
@@ -104,7 +106,7 @@ func TestRotateValidatorKeyFailsClosedAndAtomically(t *testing.T) {
 	other := rotationTestAddress(2)
 	domain, _ := k.GetDomain(ctx, "Rotation")
 	domain.Members = append(domain.Members, other.String())
-	ctx.KVStore(k.StoreKey).Set([]byte("domain:Rotation"), k.cdc.MustMarshalLengthPrefixed(&domain))
+	k.SetDomain(ctx, domain)
 	duplicate := testPubKey("rotation-duplicate")
 	if err := k.RegisterValidator(ctx, other.String(), duplicate, before.Stake, "Rotation"); err != nil {
 		t.Fatal(err)
@@ -150,7 +152,7 @@ func TestRotateValidatorKeyFailsClosedAndAtomically(t *testing.T) {
 	third := rotationTestAddress(3)
 	domain, _ = k.GetDomain(ctx, "Rotation")
 	domain.Members = append(domain.Members, third.String())
-	ctx.KVStore(k.StoreKey).Set([]byte("domain:Rotation"), k.cdc.MustMarshalLengthPrefixed(&domain))
+	k.SetDomain(ctx, domain)
 	if err := k.RegisterValidator(ctx, third.String(), oldKey, before.Stake, "Rotation"); err == nil {
 		t.Fatal("revoked key registration succeeded")
 	}
@@ -256,7 +258,7 @@ func TestRuntimeValidatorAuthoritySeparation(t *testing.T) {
 	collisionOperator := sdk.AccAddress((&ed25519.PubKey{Key: collisionKey}).Address())
 	domain, _ := k.GetDomain(ctx, "Rotation")
 	domain.Members = append(domain.Members, collisionOperator.String())
-	ctx.KVStore(k.StoreKey).Set([]byte("domain:Rotation"), k.cdc.MustMarshalLengthPrefixed(&domain))
+	k.SetDomain(ctx, domain)
 	if err := k.RegisterValidator(ctx, collisionOperator.String(), testPubKey("safe-other-consensus"), stake, "Rotation"); err != nil {
 		t.Fatal(err)
 	}
@@ -272,7 +274,7 @@ func TestRuntimeValidatorAuthoritySeparation(t *testing.T) {
 	revokedDerivedOperator := sdk.AccAddress((&ed25519.PubKey{Key: before.PubKey}).Address())
 	domain, _ = k.GetDomain(ctx, "Rotation")
 	domain.Members = append(domain.Members, revokedDerivedOperator.String())
-	ctx.KVStore(k.StoreKey).Set([]byte("domain:Rotation"), k.cdc.MustMarshalLengthPrefixed(&domain))
+	k.SetDomain(ctx, domain)
 	if err := k.RegisterValidator(ctx, revokedDerivedOperator.String(), testPubKey("post-revocation-safe-key"), stake, "Rotation"); err == nil {
 		t.Fatal("operator derived from a revoked consensus key was registered")
 	}
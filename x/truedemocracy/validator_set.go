@@ -0,0 +1,501 @@
+package truedemocracy
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// CometBFT only receives the MaxValidators best-ranked validators:
+//   "valset:params"                   → ValidatorSetParams
+//   "valset-rank:{rank key}"          → operator address
+//   "valset-member:{operator}"        → reported power and consensus key
+//   "valset-vote:{params hash}{voter}" → ValidatorSetParams
+//
+// Every unjailed validator with power has a rank entry ordered by power, then
+// bonded stake (both descending), then operator address, so the best
+// validators are found without reading or sorting the whole validator set.
+// Members are the validators CometBFT currently knows, each stored with the
+// power and key it was last reported with. A member that is jailed or removed
+// leaves the set at once. At every epoch boundary the set is re-ranked:
+// better-ranked waiting validators are promoted, the members they displace are
+// demoted, and members whose power changed report their new power. Between
+// boundaries only vacant seats are filled, from the head of the waiting queue,
+// and stake changes of members wait for the next boundary, so CometBFT (and
+// every light client following it) sees at most one power change per member
+// and epoch.
+//
+// The parameters are changed by members of the governance domain: a change
+// applies once two thirds of the current members voted for the identical
+// parameter set.
+
+// Validator-set defaults and bounds.
+const (
+	DefaultMaxValidators int64 = 100
+	// MaxValidatorsLimit bounds the governed cap so the per-block update
+	// stays small enough for CometBFT.
+	MaxValidatorsLimit int64 = 1000
+	// DefaultValidatorSetEpochLength is the number of blocks between
+	// re-rankings.
+	DefaultValidatorSetEpochLength int64 = 100
+	// MaxValidatorSetEpochLength bounds how long withdrawn or slashed stake
+	// keeps its consensus power.
+	MaxValidatorSetEpochLength int64 = 10000
+)
+
+// ValidatorSetParams are the governed validator-set parameters.
+type ValidatorSetParams struct {
+	MaxValidators int64 `json:"max_validators"`
+	EpochLength   int64 `json:"epoch_length"`
+}
+
+// ValidatorSetVote is a governance member's vote for a parameter set.
+type ValidatorSetVote struct {
+	Voter  string             `json:"voter"`
+	Params ValidatorSetParams `json:"params"`
+}
+
+// ValidatorSetEntry is one ranked validator in the validator-set query.
+type ValidatorSetEntry struct {
+	OperatorAddr string `json:"operator_addr"`
+	Power        int64  `json:"power"`
+	BondedStake  int64  `json:"bonded_stake"`
+	Active       bool   `json:"active"`
+}
+
+// ValidatorPowerChange is a member's stake change that CometBFT receives at
+// the next epoch boundary.
+type ValidatorPowerChange struct {
+	OperatorAddr  string `json:"operator_addr"`
+	ReportedPower int64  `json:"reported_power"`
+	Power         int64  `json:"power"`
+}
+
+// ValidatorSetEpochInfo is the epoch-info query result. Epoch numbers count
+// epoch lengths from genesis and jump when the length is changed.
+type ValidatorSetEpochInfo struct {
+	EpochLength         int64                  `json:"epoch_length"`
+	Epoch               int64                  `json:"epoch"`
+	EpochStartHeight    int64                  `json:"epoch_start_height"`
+	NextEpochHeight     int64                  `json:"next_epoch_height"`
+	PendingPowerChanges []ValidatorPowerChange `json:"pending_power_changes"`
+}
+
+// ValidatorSetResult is the validator-set query result: the parameters, the
+// next re-ranking height, and the ranked validators, members first.
+type ValidatorSetResult struct {
+	Params          ValidatorSetParams  `json:"params"`
+	ActiveCount     int64               `json:"active_count"`
+	NextEpochHeight int64               `json:"next_epoch_height"`
+	Validators      []ValidatorSetEntry `json:"validators"`
+}
+
+var validatorSetParamsKey = []byte("valset:params")
+
+const (
+	validatorRankPrefix    = "valset-rank:"
+	validatorMemberPrefix  = "valset-member:"
+	validatorSetVotePrefix = "valset-vote:"
+)
+
+// validatorRankKey orders validators best first: descending power and bonded
+// stake are stored bit-inverted, ties fall back to the operator address.
+func validatorRankKey(val Validator) []byte {
+	key := []byte(validatorRankPrefix)
+	key = binary.BigEndian.AppendUint64(key, ^uint64(val.Power))
+	key = binary.BigEndian.AppendUint64(key, ^validatorBondedStake(val).Uint64())
+	return append(key, val.OperatorAddr...)
+}
+
+func validatorMemberKey(operatorAddr string) []byte {
+	return []byte(validatorMemberPrefix + operatorAddr)
+}
+
+func validatorSetVoteKey(params ValidatorSetParams, voter string) []byte {
+	hash := validatorSetParamsHash(params)
+	return append(append([]byte(validatorSetVotePrefix), hash[:]...), voter...)
+}
+
+func validatorSetParamsHash(params ValidatorSetParams) [32]byte {
+	return sha256.Sum256([]byte(fmt.Sprintf("%d/%d", params.MaxValidators, params.EpochLength)))
+}
+
+// validatorSetMember is what CometBFT was last sent for a member. A zero
+// power means the member was promoted but not reported yet.
+type validatorSetMember struct {
+	Power  int64
+	PubKey []byte
+}
+
+func encodeValidatorSetMember(member validatorSetMember) []byte {
+	return append(binary.BigEndian.AppendUint64(nil, uint64(member.Power)), member.PubKey...)
+}
+
+func decodeValidatorSetMember(bz []byte) validatorSetMember {
+	if len(bz) < 8 {
+		return validatorSetMember{}
+	}
+	return validatorSetMember{
+		Power:  int64(binary.BigEndian.Uint64(bz)),
+		PubKey: append([]byte(nil), bz[8:]...),
+	}
+}
+
+// rankedValidator reports whether a validator competes for a seat.
+func rankedValidator(val Validator) bool {
+	return !val.Jailed && val.Power > 0
+}
+
+// validateValidatorSetParams checks the parameter bounds.
+func validateValidatorSetParams(params ValidatorSetParams) error {
+	if params.MaxValidators < 1 || params.MaxValidators > MaxValidatorsLimit {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "max validators must be 1..%d", MaxValidatorsLimit)
+	}
+	if params.EpochLength < 1 || params.EpochLength > MaxValidatorSetEpochLength {
+		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "epoch length must be 1..%d blocks", MaxValidatorSetEpochLength)
+	}
+	return nil
+}
+
+// DefaultValidatorSetParams returns the parameters of a chain that never
+// voted on them.
+func DefaultValidatorSetParams() ValidatorSetParams {
+	return ValidatorSetParams{MaxValidators: DefaultMaxValidators, EpochLength: DefaultValidatorSetEpochLength}
+}
+
+// GetValidatorSetParams returns the governed validator-set parameters.
+func (k Keeper) GetValidatorSetParams(ctx sdk.Context) ValidatorSetParams {
+	bz := ctx.KVStore(k.StoreKey).Get(validatorSetParamsKey)
+	if bz == nil {
+		return DefaultValidatorSetParams()
+	}
+	var params ValidatorSetParams
+	k.cdc.MustUnmarshalLengthPrefixed(bz, &params)
+	return params
+}
+
+func (k Keeper) setValidatorSetParams(ctx sdk.Context, params ValidatorSetParams) {
+	ctx.KVStore(k.StoreKey).Set(validatorSetParamsKey, k.cdc.MustMarshalLengthPrefixed(&params))
+}
+
+// IsValidatorSetMember reports whether CometBFT currently knows the
+// validator's consensus key.
+func (k Keeper) IsValidatorSetMember(ctx sdk.Context, operatorAddr string) bool {
+	return ctx.KVStore(k.StoreKey).Has(validatorMemberKey(operatorAddr))
+}
+
+func (k Keeper) getValidatorSetMember(ctx sdk.Context, operatorAddr string) validatorSetMember {
+	return decodeValidatorSetMember(ctx.KVStore(k.StoreKey).Get(validatorMemberKey(operatorAddr)))
+}
+
+func (k Keeper) setValidatorSetMember(ctx sdk.Context, operatorAddr string, member validatorSetMember) {
+	ctx.KVStore(k.StoreKey).Set(validatorMemberKey(operatorAddr), encodeValidatorSetMember(member))
+}
+
+// trackValidatorRank moves a validator's rank entry from its stored record to
+// val. SetValidator calls it before overwriting the record.
+func (k Keeper) trackValidatorRank(ctx sdk.Context, val Validator) {
+	store := ctx.KVStore(k.StoreKey)
+	if old, found := k.GetValidator(ctx, val.OperatorAddr); found && rankedValidator(old) {
+		store.Delete(validatorRankKey(old))
+	}
+	if rankedValidator(val) {
+		store.Set(validatorRankKey(val), []byte(val.OperatorAddr))
+	}
+}
+
+// untrackValidatorRank drops a removed validator's rank entry.
+func (k Keeper) untrackValidatorRank(ctx sdk.Context, val Validator) {
+	if rankedValidator(val) {
+		ctx.KVStore(k.StoreKey).Delete(validatorRankKey(val))
+	}
+}
+
+// iterateRankedValidators visits ranked operators best first. Returning true
+// stops iteration.
+func (k Keeper) iterateRankedValidators(ctx sdk.Context, fn func(operatorAddr string) bool) {
+	prefix := []byte(validatorRankPrefix)
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if fn(string(iter.Value())) {
+			return
+		}
+	}
+}
+
+// validatorSetMembers returns the members in operator order.
+func (k Keeper) validatorSetMembers(ctx sdk.Context) []string {
+	prefix := []byte(validatorMemberPrefix)
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	var members []string
+	for ; iter.Valid(); iter.Next() {
+		members = append(members, string(iter.Key()[len(prefix):]))
+	}
+	return members
+}
+
+// joinValidatorSet makes a validator an unreported member; its key is sent
+// to CometBFT by the BuildValidatorUpdates call that promoted it.
+func (k Keeper) joinValidatorSet(ctx sdk.Context, val Validator, reason string) {
+	k.trackValidatorSetRewards(ctx, val.OperatorAddr, func() {
+		k.setValidatorSetMember(ctx, val.OperatorAddr, validatorSetMember{})
+	})
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"validator_promoted",
+		sdk.NewAttribute("operator", val.OperatorAddr),
+		sdk.NewAttribute("power", fmt.Sprintf("%d", val.Power)),
+		sdk.NewAttribute("reason", reason),
+	))
+}
+
+// leaveValidatorSet drops a member and reports whether the validator was
+// one; the caller queues its power-zero update.
+func (k Keeper) leaveValidatorSet(ctx sdk.Context, val Validator, reason string) bool {
+	store := ctx.KVStore(k.StoreKey)
+	if !store.Has(validatorMemberKey(val.OperatorAddr)) {
+		return false
+	}
+	k.trackValidatorSetRewards(ctx, val.OperatorAddr, func() {
+		store.Delete(validatorMemberKey(val.OperatorAddr))
+	})
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"validator_demoted",
+		sdk.NewAttribute("operator", val.OperatorAddr),
+		sdk.NewAttribute("power", fmt.Sprintf("%d", val.Power)),
+		sdk.NewAttribute("reason", reason),
+	))
+	return true
+}
+
+// IsValidatorSetEpochBoundary reports whether the validator set is re-ranked
+// and stake changes are reported in the current block.
+func (k Keeper) IsValidatorSetEpochBoundary(ctx sdk.Context) bool {
+	return ctx.BlockHeight()%k.GetValidatorSetParams(ctx).EpochLength == 0
+}
+
+// nextValidatorSetEpoch returns the first re-ranking height after height.
+func nextValidatorSetEpoch(height, epochLength int64) int64 {
+	return (height/epochLength + 1) * epochLength
+}
+
+// reportValidatorPower returns the update CometBFT needs for a member, if
+// any, and records it as reported. Outside an epoch boundary a reported
+// member keeps its reported power and is only re-sent after a key rotation.
+func (k Keeper) reportValidatorPower(ctx sdk.Context, val Validator, boundary bool) (int64, bool) {
+	reported := k.getValidatorSetMember(ctx, val.OperatorAddr)
+	power := reported.Power
+	if power == 0 || boundary {
+		power = val.Power
+	}
+	if power == reported.Power && bytes.Equal(val.PubKey, reported.PubKey) {
+		return 0, false
+	}
+	k.setValidatorSetMember(ctx, val.OperatorAddr, validatorSetMember{Power: power, PubKey: val.PubKey})
+	return power, true
+}
+
+// ValidatorSetEpochInfo reports the current epoch and the member power
+// changes that CometBFT receives at its end.
+func (k Keeper) ValidatorSetEpochInfo(ctx sdk.Context) ValidatorSetEpochInfo {
+	epochLength := k.GetValidatorSetParams(ctx).EpochLength
+	height := ctx.BlockHeight()
+	info := ValidatorSetEpochInfo{
+		EpochLength:         epochLength,
+		Epoch:               height / epochLength,
+		EpochStartHeight:    height / epochLength * epochLength,
+		NextEpochHeight:     nextValidatorSetEpoch(height, epochLength),
+		PendingPowerChanges: []ValidatorPowerChange{},
+	}
+	for _, operatorAddr := range k.validatorSetMembers(ctx) {
+		val, found := k.GetValidator(ctx, operatorAddr)
+		if !found || !rankedValidator(val) {
+			continue
+		}
+		reported := k.getValidatorSetMember(ctx, operatorAddr)
+		if reported.Power != 0 && reported.Power != val.Power {
+			info.PendingPowerChanges = append(info.PendingPowerChanges, ValidatorPowerChange{
+				OperatorAddr:  operatorAddr,
+				ReportedPower: reported.Power,
+				Power:         val.Power,
+			})
+		}
+	}
+	return info
+}
+
+// updateValidatorSet applies promotions and demotions for this block. At an
+// epoch boundary the members become exactly the MaxValidators best-ranked
+// validators; otherwise vacant seats go to the best waiting validators. It
+// reads at most MaxValidators rank entries beyond the members it skips.
+func (k Keeper) updateValidatorSet(ctx sdk.Context, boundary bool) {
+	maxValidators := k.GetValidatorSetParams(ctx).MaxValidators
+	members := k.validatorSetMembers(ctx)
+
+	if !boundary {
+		seats := maxValidators - int64(len(members))
+		if seats <= 0 {
+			return
+		}
+		var promoted []string
+		k.iterateRankedValidators(ctx, func(operatorAddr string) bool {
+			if !k.IsValidatorSetMember(ctx, operatorAddr) {
+				promoted = append(promoted, operatorAddr)
+			}
+			return int64(len(promoted)) >= seats
+		})
+		for _, operatorAddr := range promoted {
+			val, _ := k.GetValidator(ctx, operatorAddr)
+			k.joinValidatorSet(ctx, val, "vacancy")
+		}
+		return
+	}
+
+	selected := make(map[string]bool, maxValidators)
+	var ranked []string
+	k.iterateRankedValidators(ctx, func(operatorAddr string) bool {
+		selected[operatorAddr] = true
+		ranked = append(ranked, operatorAddr)
+		return int64(len(ranked)) >= maxValidators
+	})
+	for _, operatorAddr := range members {
+		if selected[operatorAddr] {
+			continue
+		}
+		val, found := k.GetValidator(ctx, operatorAddr)
+		if !found {
+			ctx.KVStore(k.StoreKey).Delete(validatorMemberKey(operatorAddr))
+			continue
+		}
+		k.queueValidatorPowerZero(ctx, val, "epoch")
+	}
+	for _, operatorAddr := range ranked {
+		if k.IsValidatorSetMember(ctx, operatorAddr) {
+			continue
+		}
+		val, _ := k.GetValidator(ctx, operatorAddr)
+		k.joinValidatorSet(ctx, val, "epoch")
+	}
+}
+
+// initValidatorSet seats and reports the MaxValidators best-ranked
+// validators at genesis.
+func (k Keeper) initValidatorSet(ctx sdk.Context) []Validator {
+	maxValidators := k.GetValidatorSetParams(ctx).MaxValidators
+	var ranked []string
+	k.iterateRankedValidators(ctx, func(operatorAddr string) bool {
+		ranked = append(ranked, operatorAddr)
+		return int64(len(ranked)) >= maxValidators
+	})
+	seated := make([]Validator, 0, len(ranked))
+	for _, operatorAddr := range ranked {
+		val, _ := k.GetValidator(ctx, operatorAddr)
+		k.trackValidatorSetRewards(ctx, operatorAddr, func() {
+			k.setValidatorSetMember(ctx, operatorAddr, validatorSetMember{Power: val.Power, PubKey: val.PubKey})
+		})
+		seated = append(seated, val)
+	}
+	return seated
+}
+
+// VoteValidatorSetParams records a governance domain member's vote for
+// replacing the validator-set parameters with params. Once two thirds of the
+// current members voted for the identical set it applies and every open vote
+// is cleared. A lower cap takes effect at the next boundary of the new epoch
+// length. Returns
+// the votes for the set, the member count, and whether it was applied.
+func (k Keeper) VoteValidatorSetParams(ctx sdk.Context, sender sdk.AccAddress, params ValidatorSetParams) (int, int, bool, error) {
+	if err := validateValidatorSetParams(params); err != nil {
+		return 0, 0, false, err
+	}
+	if _, found := k.GetDomainHeader(ctx, ReservedGovernanceDomain); !found {
+		return 0, 0, false, errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "reserved domain %s not found", ReservedGovernanceDomain)
+	}
+	voter := sender.String()
+	if !k.IsDomainMember(ctx, ReservedGovernanceDomain, voter) {
+		return 0, 0, false, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "only governance domain members can vote on validator-set parameters")
+	}
+	if validatorSetParamsHash(params) == validatorSetParamsHash(k.GetValidatorSetParams(ctx)) {
+		return 0, 0, false, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "validator-set parameters are unchanged")
+	}
+
+	store := ctx.KVStore(k.StoreKey)
+	if store.Has(validatorSetVoteKey(params, voter)) {
+		return 0, 0, false, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "already voted for these validator-set parameters")
+	}
+	store.Set(validatorSetVoteKey(params, voter), k.cdc.MustMarshalLengthPrefixed(&params))
+
+	members := k.GetDomainMembers(ctx, ReservedGovernanceDomain)
+	votes := 0
+	for _, member := range members {
+		if store.Has(validatorSetVoteKey(params, member)) {
+			votes++
+		}
+	}
+	if !upgradeThresholdReached(votes, len(members)) {
+		return votes, len(members), false, nil
+	}
+
+	k.setValidatorSetParams(ctx, params)
+	k.clearValidatorSetVotes(ctx)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		"validator_set_params_changed",
+		sdk.NewAttribute("max_validators", fmt.Sprintf("%d", params.MaxValidators)),
+		sdk.NewAttribute("epoch_length", fmt.Sprintf("%d", params.EpochLength)),
+		sdk.NewAttribute("votes", fmt.Sprintf("%d", votes)),
+		sdk.NewAttribute("members", fmt.Sprintf("%d", len(members))),
+	))
+	return votes, len(members), true, nil
+}
+
+// IterateValidatorSetVotes visits every open vote. Returning true stops
+// iteration.
+func (k Keeper) IterateValidatorSetVotes(ctx sdk.Context, fn func(ValidatorSetVote) bool) {
+	prefix := []byte(validatorSetVotePrefix)
+	iter := ctx.KVStore(k.StoreKey).Iterator(prefix, prefixEnd(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var params ValidatorSetParams
+		k.cdc.MustUnmarshalLengthPrefixed(iter.Value(), &params)
+		voter := string(iter.Key()[len(prefix)+sha256.Size:])
+		if fn(ValidatorSetVote{Voter: voter, Params: params}) {
+			return
+		}
+	}
+}
+
+func (k Keeper) clearValidatorSetVotes(ctx sdk.Context) {
+	store := ctx.KVStore(k.StoreKey)
+	prefix := []byte(validatorSetVotePrefix)
+	iter := store.Iterator(prefix, prefixEnd(prefix))
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, append([]byte{}, iter.Key()...))
+	}
+	iter.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// MigrateValidatorSet indexes the stored validators by rank and records every
+// validator CometBFT already knows, i.e. every unjailed validator with power,
+// as a member reported at its current power. A set above the cap shrinks at
+// the next epoch boundary.
+func (k Keeper) MigrateValidatorSet(ctx sdk.Context) error {
+	store := ctx.KVStore(k.StoreKey)
+	k.IterateValidators(ctx, func(val Validator) bool {
+		if rankedValidator(val) {
+			store.Set(validatorRankKey(val), []byte(val.OperatorAddr))
+			store.Set(validatorMemberKey(val.OperatorAddr), encodeValidatorSetMember(validatorSetMember{Power: val.Power, PubKey: val.PubKey}))
+		}
+		return false
+	})
+	return nil
+}
@@ -0,0 +1,325 @@
+package truedemocracy
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	rewards "truerepublic/treasury/keeper"
+)
+
+func countEvents(ctx sdk.Context, eventType, reason string) int {
+	count := 0
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type != eventType {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key == "reason" && attr.Value == reason {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestValidatorSetCapsActiveSetAndQueuesTheRest(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	ctx = ctx.WithBlockHeight(101)
+	k.setValidatorSetParams(ctx, ValidatorSetParams{MaxValidators: 2, EpochLength: DefaultValidatorSetEpochLength})
+	k.CreateDomain(ctx, "Capped", sdk.AccAddress("admin1"), sdk.NewCoins())
+	registerVal(t, k, ctx, "Capped", "val-a", "capped-a", rewards.StakeMin)
+	registerVal(t, k, ctx, "Capped", "val-b", "capped-b", 2*rewards.StakeMin)
+	registerVal(t, k, ctx, "Capped", "val-c", "capped-c", 3*rewards.StakeMin)
+	pubKey := func(operator string) []byte {
+		val, _ := k.GetValidator(ctx, operator)
+		return val.PubKey
+	}
+
+	// Vacant seats fill from the head of the queue at once.
+	updates := k.BuildValidatorUpdates(ctx)
+	if len(updates) != 2 {
+		t.Fatalf("got %d updates, want 2", len(updates))
+	}
+	assertValidatorUpdatePower(t, updates, pubKey("val-c"), 3)
+	assertValidatorUpdatePower(t, updates, pubKey("val-b"), 2)
+	assertNoValidatorUpdate(t, updates, pubKey("val-a"))
+	if got := countEvents(ctx, "validator_promoted", "vacancy"); got != 2 {
+		t.Fatalf("vacancy promotions = %d, want 2", got)
+	}
+
+	// A better validator waits for the epoch boundary instead of displacing
+	// a member mid-epoch.
+	ctx = ctx.WithBlockHeight(102)
+	registerVal(t, k, ctx, "Capped", "val-d", "capped-d", 4*rewards.StakeMin)
+	updates = k.BuildValidatorUpdates(ctx)
+	assertNoValidatorUpdate(t, updates, pubKey("val-d"))
+	if k.IsValidatorSetMember(ctx, "val-d") {
+		t.Fatal("waiting validator took a seat mid-epoch")
+	}
+
+	ctx = ctx.WithBlockHeight(DefaultValidatorSetEpochLength * 2).WithEventManager(sdk.NewEventManager())
+	updates = k.BuildValidatorUpdates(ctx)
+	assertValidatorUpdatePower(t, updates, pubKey("val-d"), 4)
+	assertNoValidatorUpdate(t, updates, pubKey("val-c"))
+	assertValidatorUpdatePower(t, updates, pubKey("val-b"), 0)
+	assertNoValidatorUpdate(t, updates, pubKey("val-a"))
+	if countEvents(ctx, "validator_promoted", "epoch") != 1 || countEvents(ctx, "validator_demoted", "epoch") != 1 {
+		t.Fatalf("epoch events = %v", ctx.EventManager().Events())
+	}
+
+	// Jailing frees a seat at once; the best waiting validator takes it.
+	ctx = ctx.WithBlockHeight(DefaultValidatorSetEpochLength*2 + 1)
+	jailed, _ := k.GetValidator(ctx, "val-c")
+	k.QueueValidatorPowerZero(ctx, jailed)
+	jailed.Jailed = true
+	k.SetValidator(ctx, jailed)
+	updates = k.BuildValidatorUpdates(ctx)
+	assertValidatorUpdatePower(t, updates, pubKey("val-c"), 0)
+	assertValidatorUpdatePower(t, updates, pubKey("val-b"), 2)
+	assertNoValidatorUpdate(t, updates, pubKey("val-a"))
+
+	// A waiting validator never reached CometBFT, so removing it queues no
+	// power-zero update.
+	if err := k.RemoveValidator(ctx, "val-a"); err != nil {
+		t.Fatal(err)
+	}
+	assertNoValidatorUpdate(t, k.BuildValidatorUpdates(ctx), pubKey("val-a"))
+
+	resp, err := k.ValidatorSet(ctx, &QueryValidatorSetRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result ValidatorSetResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Params.MaxValidators != 2 || result.ActiveCount != 2 || result.NextEpochHeight != DefaultValidatorSetEpochLength*3 {
+		t.Fatalf("validator set = %+v", result)
+	}
+	if len(result.Validators) != 2 || result.Validators[0].OperatorAddr != "val-d" || result.Validators[1].OperatorAddr != "val-b" ||
+		!result.Validators[0].Active || !result.Validators[1].Active {
+		t.Fatalf("ranked validators = %+v", result.Validators)
+	}
+}
+
+func TestVoteValidatorSetParams(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	members := upgradeMembers()
+	if _, _, _, err := k.VoteValidatorSetParams(ctx, members[0], ValidatorSetParams{MaxValidators: 50, EpochLength: DefaultValidatorSetEpochLength}); err == nil {
+		t.Fatal("vote accepted without a governance domain")
+	}
+	createUpgradeDomain(t, k, ctx, members)
+
+	if _, _, _, err := k.VoteValidatorSetParams(ctx, sdk.AccAddress("outsider"), ValidatorSetParams{MaxValidators: 50, EpochLength: DefaultValidatorSetEpochLength}); err == nil {
+		t.Fatal("non-member vote accepted")
+	}
+	for _, bad := range []int64{0, MaxValidatorsLimit + 1, DefaultMaxValidators} {
+		if _, _, _, err := k.VoteValidatorSetParams(ctx, members[0], ValidatorSetParams{MaxValidators: bad, EpochLength: DefaultValidatorSetEpochLength}); err == nil {
+			t.Errorf("max validators %d accepted", bad)
+		}
+	}
+
+	target := ValidatorSetParams{MaxValidators: 50, EpochLength: DefaultValidatorSetEpochLength}
+	for i, member := range members[:2] {
+		votes, eligible, applied, err := k.VoteValidatorSetParams(ctx, member, target)
+		if err != nil || votes != i+1 || eligible != 4 || applied {
+			t.Fatalf("vote %d = %d/%d applied %v, err %v", i, votes, eligible, applied, err)
+		}
+	}
+	if _, _, _, err := k.VoteValidatorSetParams(ctx, members[0], target); err == nil {
+		t.Fatal("duplicate vote accepted")
+	}
+	if k.GetValidatorSetParams(ctx) != DefaultValidatorSetParams() {
+		t.Fatal("params changed below the threshold")
+	}
+	if _, _, applied, err := k.VoteValidatorSetParams(ctx, members[2], target); err != nil || !applied {
+		t.Fatalf("third vote applied %v, err %v", applied, err)
+	}
+	if k.GetValidatorSetParams(ctx) != target {
+		t.Fatalf("params = %+v, want %+v", k.GetValidatorSetParams(ctx), target)
+	}
+	k.IterateValidatorSetVotes(ctx, func(vote ValidatorSetVote) bool {
+		t.Fatalf("vote %+v survived the change", vote)
+		return true
+	})
+
+	msg := MsgVoteValidatorSetParams{Sender: members[0], MaxValidators: 50, EpochLength: 20}
+	if err := msg.ValidateBasic(); err != nil {
+		t.Fatalf("valid message rejected: %v", err)
+	}
+	msg.MaxValidators = 0
+	if err := msg.ValidateBasic(); err == nil {
+		t.Fatal("zero cap message accepted")
+	}
+	msg.MaxValidators, msg.EpochLength = 50, MaxValidatorSetEpochLength+1
+	if err := msg.ValidateBasic(); err == nil {
+		t.Fatal("overlong epoch message accepted")
+	}
+}
+
+func TestValidatorSetGenesis(t *testing.T) {
+	am1, k1, ctx1 := setupModuleForGenesis(t)
+	admin := sdk.AccAddress("genesis-valset-admin")
+	k1.CreateDomain(ctx1, "Seats", admin, sdk.NewCoins())
+	for i, seed := range []string{"genesis-valset-small", "genesis-valset-large"} {
+		operator := sdk.AccAddress(seed)
+		if err := k1.AddMember(ctx1, "Seats", operator.String(), admin); err != nil {
+			t.Fatal(err)
+		}
+		stake := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, int64(i+1)*rewards.StakeMin))
+		if err := k1.RegisterValidator(ctx1, operator.String(), testPubKey(seed), stake, "Seats"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	members := upgradeMembers()
+	createUpgradeDomain(t, k1, ctx1, members)
+	k1.setValidatorSetParams(ctx1, ValidatorSetParams{MaxValidators: 1, EpochLength: DefaultValidatorSetEpochLength})
+	if _, _, _, err := k1.VoteValidatorSetParams(ctx1, members[0], ValidatorSetParams{MaxValidators: 7, EpochLength: DefaultValidatorSetEpochLength}); err != nil {
+		t.Fatal(err)
+	}
+
+	exported := am1.ExportGenesis(ctx1, nil)
+	var genesis GenesisState
+	if err := json.Unmarshal(exported, &genesis); err != nil {
+		t.Fatal(err)
+	}
+	if genesis.ValidatorSetParams == nil || genesis.ValidatorSetParams.MaxValidators != 1 || len(genesis.ValidatorSetVotes) != 1 {
+		t.Fatalf("exported params %+v, votes %+v", genesis.ValidatorSetParams, genesis.ValidatorSetVotes)
+	}
+	am2, k2, ctx2 := setupModuleForGenesis(t)
+	updates := am2.InitGenesis(ctx2, nil, exported)
+	if len(updates) != 1 {
+		t.Fatalf("genesis seated %d validators, want 1", len(updates))
+	}
+	assertValidatorUpdatePower(t, updates, testPubKey("genesis-valset-large"), 2)
+	if !k2.IsValidatorSetMember(ctx2, sdk.AccAddress("genesis-valset-large").String()) ||
+		k2.IsValidatorSetMember(ctx2, sdk.AccAddress("genesis-valset-small").String()) {
+		t.Fatal("restored membership does not follow the ranking")
+	}
+	if votes, _, _, err := k2.VoteValidatorSetParams(ctx2, members[1], ValidatorSetParams{MaxValidators: 7, EpochLength: DefaultValidatorSetEpochLength}); err != nil || votes != 2 {
+		t.Fatalf("restored vote count = %d, err %v", votes, err)
+	}
+
+	for name, mutate := range map[string]func(*GenesisState){
+		"zero cap":          func(g *GenesisState) { g.ValidatorSetParams.MaxValidators = 0 },
+		"zero epoch length": func(g *GenesisState) { g.ValidatorSetParams.EpochLength = 0 },
+		"non-member vote": func(g *GenesisState) {
+			g.ValidatorSetVotes[0].Voter = sdk.AccAddress("outsider").String()
+		},
+		"duplicate vote": func(g *GenesisState) {
+			g.ValidatorSetVotes = append(g.ValidatorSetVotes, g.ValidatorSetVotes[0])
+		},
+		"vote for current params": func(g *GenesisState) { g.ValidatorSetVotes[0].Params.MaxValidators = 1 },
+	} {
+		var bad GenesisState
+		if err := json.Unmarshal(exported, &bad); err != nil {
+			t.Fatal(err)
+		}
+		mutate(&bad)
+		if err := ValidateGenesisState(bad); err == nil {
+			t.Errorf("%s accepted", name)
+		}
+	}
+}
+
+func TestMigrateValidatorSetSeatsKnownValidators(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	k.CreateDomain(ctx, "Legacy", sdk.AccAddress("admin1"), sdk.NewCoins())
+	registerVal(t, k, ctx, "Legacy", "val-a", "legacy-a", rewards.StakeMin)
+	registerVal(t, k, ctx, "Legacy", "val-b", "legacy-b", rewards.StakeMin)
+	// Simulate a store that predates the index.
+	store := ctx.KVStore(k.StoreKey)
+	for _, prefix := range []string{validatorRankPrefix, validatorMemberPrefix} {
+		iter := store.Iterator([]byte(prefix), prefixEnd([]byte(prefix)))
+		var keys [][]byte
+		for ; iter.Valid(); iter.Next() {
+			keys = append(keys, iter.Key())
+		}
+		iter.Close()
+		for _, key := range keys {
+			store.Delete(key)
+		}
+	}
+
+	if err := k.MigrateValidatorSet(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !k.IsValidatorSetMember(ctx, "val-a") || !k.IsValidatorSetMember(ctx, "val-b") {
+		t.Fatal("migration did not record the validators CometBFT knows")
+	}
+	// Members are recorded as reported at their current power, so the
+	// next boundary has nothing to report.
+	val, _ := k.GetValidator(ctx, "val-a")
+	if member := k.getValidatorSetMember(ctx, "val-a"); member.Power != val.Power || !bytes.Equal(member.PubKey, val.PubKey) {
+		t.Fatalf("migrated member = %+v", member)
+	}
+	// A set above a lowered cap shrinks at the next boundary.
+	k.setValidatorSetParams(ctx, ValidatorSetParams{MaxValidators: 1, EpochLength: DefaultValidatorSetEpochLength})
+	updates := k.BuildValidatorUpdates(ctx.WithBlockHeight(DefaultValidatorSetEpochLength))
+	if len(updates) != 1 || k.IsValidatorSetMember(ctx, "val-b") {
+		t.Fatalf("updates after migration = %v", updates)
+	}
+}
+
+func TestValidatorSetEpochDefersPowerChanges(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	ctx = ctx.WithBlockHeight(21)
+	k.setValidatorSetParams(ctx, ValidatorSetParams{MaxValidators: DefaultMaxValidators, EpochLength: 10})
+	k.CreateDomain(ctx, "Epochs", sdk.AccAddress("admin1"), sdk.NewCoins())
+	registerVal(t, k, ctx, "Epochs", "val-a", "epochs-a", 2*rewards.StakeMin)
+	registerVal(t, k, ctx, "Epochs", "val-b", "epochs-b", rewards.StakeMin)
+	initial := k.BuildValidatorUpdates(ctx)
+	if len(initial) != 2 {
+		t.Fatalf("got %d initial updates, want 2", len(initial))
+	}
+
+	// A stake change waits for the boundary and is listed as pending.
+	ctx = ctx.WithBlockHeight(22)
+	val, _ := k.GetValidator(ctx, "val-a")
+	val.Stake = sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 5*rewards.StakeMin))
+	val.Power = validatorPowerFromStake(val)
+	k.SetValidator(ctx, val)
+	if updates := k.BuildValidatorUpdates(ctx); len(updates) != 0 {
+		t.Fatalf("mid-epoch updates = %v, want none", updates)
+	}
+	resp, err := k.EpochInfo(ctx, &QueryEpochInfoRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var info ValidatorSetEpochInfo
+	if err := json.Unmarshal(resp.Result, &info); err != nil {
+		t.Fatal(err)
+	}
+	if info.EpochLength != 10 || info.Epoch != 2 || info.EpochStartHeight != 20 || info.NextEpochHeight != 30 {
+		t.Fatalf("epoch info = %+v", info)
+	}
+	if len(info.PendingPowerChanges) != 1 || info.PendingPowerChanges[0] != (ValidatorPowerChange{OperatorAddr: "val-a", ReportedPower: 2, Power: 5}) {
+		t.Fatalf("pending changes = %+v", info.PendingPowerChanges)
+	}
+
+	// Jailing still takes effect at once.
+	ctx = ctx.WithBlockHeight(23)
+	jailed, _ := k.GetValidator(ctx, "val-b")
+	k.QueueValidatorPowerZero(ctx, jailed)
+	jailed.Jailed = true
+	k.SetValidator(ctx, jailed)
+	updates := k.BuildValidatorUpdates(ctx)
+	if len(updates) != 1 {
+		t.Fatalf("got %d jail updates, want 1", len(updates))
+	}
+	assertValidatorUpdatePower(t, updates, jailed.PubKey, 0)
+
+	// The boundary reports the new power once.
+	ctx = ctx.WithBlockHeight(30)
+	updates = k.BuildValidatorUpdates(ctx)
+	if len(updates) != 1 {
+		t.Fatalf("got %d boundary updates, want 1", len(updates))
+	}
+	assertValidatorUpdatePower(t, updates, val.PubKey, 5)
+	if updates := k.BuildValidatorUpdates(ctx.WithBlockHeight(31)); len(updates) != 0 {
+		t.Fatalf("updates after the boundary = %v, want none", updates)
+	}
+}
@@ -73,9 +73,7 @@ func setupDomainWithValidator(t *testing.T, k Keeper, ctx sdk.Context) (string,
 	// Re-save domain with the validator operator as a member.
 	domain, _ := k.GetDomain(ctx, "TestDomain")
 	domain.Members = append(domain.Members, "oper1")
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:TestDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	pk := testPubKey("oper1")
 	stake := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 100_000*PNYXUnit))
@@ -92,9 +90,7 @@ func TestRegisterValidator(t *testing.T) {
 	// Add member to domain.
 	domain, _ := k.GetDomain(ctx, "Party")
 	domain.Members = append(domain.Members, "val1")
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:Party"), bz)
+	k.SetDomain(ctx, domain)
 
 	pk := testPubKey("val1")
 
@@ -128,8 +124,7 @@ func TestRegisterValidator(t *testing.T) {
 		pk2 := testPubKey("val2-low")
 		domain, _ := k.GetDomain(ctx, "Party")
 		domain.Members = append(domain.Members, "val2")
-		bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-		st.Set([]byte("domain:Party"), bz)
+		k.SetDomain(ctx, domain)
 
 		stake := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 99_999*PNYXUnit))
 		err := k.RegisterValidator(ctx, "val2", pk2, stake, "Party")
@@ -150,8 +145,7 @@ func TestRegisterValidator(t *testing.T) {
 	t.Run("bad pubkey length", func(t *testing.T) {
 		domain, _ := k.GetDomain(ctx, "Party")
 		domain.Members = append(domain.Members, "val3")
-		bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-		st.Set([]byte("domain:Party"), bz)
+		k.SetDomain(ctx, domain)
 
 		stake := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 100_000*PNYXUnit))
 		err := k.RegisterValidator(ctx, "val3", []byte("short"), stake, "Party")
@@ -200,9 +194,7 @@ func TestIterateValidators(t *testing.T) {
 	// Register a second validator.
 	domain, _ := k.GetDomain(ctx, "TestDomain")
 	domain.Members = append(domain.Members, "oper2")
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:TestDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	pk2 := testPubKey("oper2")
 	stake := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 200_000*PNYXUnit))
@@ -241,9 +233,7 @@ func TestEnforceDomainMembership(t *testing.T) {
 			}
 		}
 		domain.Members = newMembers
-		st := ctx.KVStore(k.StoreKey)
-		bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-		st.Set([]byte("domain:TestDomain"), bz)
+		k.SetDomain(ctx, domain)
 
 		ok := k.EnforceDomainMembership(ctx, "oper1")
 		if ok {
@@ -255,6 +245,7 @@ func TestEnforceDomainMembership(t *testing.T) {
 func TestDistributeStakingRewards(t *testing.T) {
 	k, ctx := setupKeeper(t)
 	setupDomainWithValidator(t, k, ctx)
+	seatValidator(k, ctx, "oper1")
 
 	// Initialize reward tracking.
 	st := ctx.KVStore(k.StoreKey)
@@ -282,13 +273,23 @@ func TestDistributeStakingRewards(t *testing.T) {
 			t.Fatal(err)
 		}
 		val, _ := k.GetValidator(ctx2, "oper1")
-		stakeAmt := val.Stake.AmountOf(PNYXDenom)
+		if !val.Stake.AmountOf(PNYXDenom).Equal(math.NewInt(100_000 * PNYXUnit)) {
+			t.Errorf("reward compounded into stake: %s", val.Stake)
+		}
 
 		// Reward decay uses canonical bank supply at the interval boundary.
+		// The reward accrues to the index; the operator's share loses at most
+		// one upnyx of rounding, which stays outstanding.
 		expected := rewards.CalcNodeReward(math.NewInt(100_000*PNYXUnit), initialSupply, RewardInterval)
-		wantStake := math.NewInt(100_000 * PNYXUnit).Add(expected)
-		if !stakeAmt.Equal(wantStake) {
-			t.Errorf("stake = %s, want %s (reward = %s)", stakeAmt, wantStake, expected)
+		if pool := k.getStakingRewardPool(ctx2); !pool.Outstanding.Equal(expected) {
+			t.Errorf("outstanding = %s, want %s", pool.Outstanding, expected)
+		}
+		claimable := k.ClaimableStakingRewards(ctx2, "oper1", "")
+		if claimable.GT(expected) || claimable.LT(expected.SubRaw(1)) {
+			t.Errorf("claimable = %s, want %s", claimable, expected)
+		}
+		if err := k.ValidateEscrowParity(ctx2); err != nil {
+			t.Errorf("accrued rewards broke parity: %v", err)
 		}
 	})
 }
@@ -349,8 +350,9 @@ func TestValidatorJoinLeaveReplacementUpdates(t *testing.T) {
 	if !found {
 		t.Fatal("validator-2 not registered")
 	}
+	// Members already reported at their power are not re-sent.
 	joinUpdates := k.BuildValidatorUpdates(ctx)
-	assertValidatorUpdatePower(t, joinUpdates, validator1.PubKey, 1)
+	assertNoValidatorUpdate(t, joinUpdates, validator1.PubKey)
 	assertValidatorUpdatePower(t, joinUpdates, validator2.PubKey, 1)
 
 	if err := k.RemoveValidator(ctx, "validator-1"); err != nil {
@@ -358,14 +360,14 @@ func TestValidatorJoinLeaveReplacementUpdates(t *testing.T) {
 	}
 	leaveUpdates := k.BuildValidatorUpdates(ctx)
 	assertValidatorUpdatePower(t, leaveUpdates, validator1.PubKey, 0)
-	assertValidatorUpdatePower(t, leaveUpdates, validator2.PubKey, 1)
+	assertNoValidatorUpdate(t, leaveUpdates, validator2.PubKey)
 	if _, found := k.GetValidatorByPubKey(ctx, validator1.PubKey); found {
 		t.Fatal("removed validator pubkey index remained")
 	}
 
 	replayedUpdates := k.BuildValidatorUpdates(ctx)
 	assertNoValidatorUpdate(t, replayedUpdates, validator1.PubKey)
-	assertValidatorUpdatePower(t, replayedUpdates, validator2.PubKey, 1)
+	assertNoValidatorUpdate(t, replayedUpdates, validator2.PubKey)
 
 	registerVal(t, k, ctx, "Lifecycle", "validator-3", "lifecycle-validator-3", 100_000*PNYXUnit)
 	replacement, found := k.GetValidator(ctx, "validator-3")
@@ -374,7 +376,7 @@ func TestValidatorJoinLeaveReplacementUpdates(t *testing.T) {
 	}
 	replacementUpdates := k.BuildValidatorUpdates(ctx)
 	assertNoValidatorUpdate(t, replacementUpdates, validator1.PubKey)
-	assertValidatorUpdatePower(t, replacementUpdates, validator2.PubKey, 1)
+	assertNoValidatorUpdate(t, replacementUpdates, validator2.PubKey)
 	assertValidatorUpdatePower(t, replacementUpdates, replacement.PubKey, 1)
 }
 
@@ -407,9 +409,7 @@ func registerVal(t *testing.T, k Keeper, ctx sdk.Context, domainName, operAddr,
 	t.Helper()
 	domain, _ := k.GetDomain(ctx, domainName)
 	domain.Members = append(domain.Members, operAddr)
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:"+domainName), bz)
+	k.SetDomain(ctx, domain)
 
 	pk := testPubKey(seed)
 	stake := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, stakeAmt))
@@ -427,9 +427,7 @@ func TestTransferLimitEnforcement(t *testing.T) {
 	// Set domain payouts to 1,000,000 → 10% limit = 100,000.
 	domain, _ := k.GetDomain(ctx, "LimitDomain")
 	domain.TotalPayouts = 1_000_000 * PNYXUnit
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:LimitDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	// Try to withdraw 150,000 → exceeds 10% limit (100,000). Should fail.
 	err := k.WithdrawStake(ctx, "val1", 150_000*PNYXUnit)
@@ -447,9 +445,7 @@ func TestTransferWithinLimit(t *testing.T) {
 	// Set domain payouts to 2,000,000 → 10% limit = 200,000.
 	domain, _ := k.GetDomain(ctx, "OkDomain")
 	domain.TotalPayouts = 2_000_000 * PNYXUnit
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:OkDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	// Withdraw 100,000 → within limit. Should succeed.
 	err := k.WithdrawStake(ctx, "val1", 100_000*PNYXUnit)
@@ -482,9 +478,7 @@ func TestPayoutIncreasesLimit(t *testing.T) {
 	// Initial payouts = 1,000,000 → limit = 100,000.
 	domain, _ := k.GetDomain(ctx, "GrowDomain")
 	domain.TotalPayouts = 1_000_000 * PNYXUnit
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:GrowDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	// Withdraw 100,000 → exactly at limit, should succeed.
 	err := k.WithdrawStake(ctx, "val1", 100_000*PNYXUnit)
@@ -501,8 +495,7 @@ func TestPayoutIncreasesLimit(t *testing.T) {
 	// Increase payouts → limit increases.
 	domain, _ = k.GetDomain(ctx, "GrowDomain")
 	domain.TotalPayouts = 3_000_000 * PNYXUnit // new limit = 300,000; already transferred 100,000 → 200,000 left
-	bz = k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:GrowDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	// Now withdraw 100,000 more — should succeed (200,000 remaining capacity).
 	err = k.WithdrawStake(ctx, "val1", 100_000*PNYXUnit)
@@ -521,9 +514,7 @@ func TestMultipleValidators(t *testing.T) {
 	// Set payouts = 2,000,000 → 10% limit = 200,000 total across all validators.
 	domain, _ := k.GetDomain(ctx, "MultiDomain")
 	domain.TotalPayouts = 2_000_000 * PNYXUnit
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:MultiDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	// val1 withdraws 100,000 → success (100,000 of 200,000 used).
 	err := k.WithdrawStake(ctx, "val1", 100_000*PNYXUnit)
@@ -553,9 +544,7 @@ func TestWithdrawBelowMinRejectsDustStake(t *testing.T) {
 	// Set payouts high enough that limit is not an issue.
 	domain, _ := k.GetDomain(ctx, "RemDomain")
 	domain.TotalPayouts = 10_000_000 * PNYXUnit // limit = 1,000,000
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:RemDomain"), bz)
+	k.SetDomain(ctx, domain)
 
 	// Withdraw 60,000 → remaining 90,000 < StakeMin (100,000) → rejected.
 	err := k.WithdrawStake(ctx, "val1", 60_000*PNYXUnit)
@@ -605,9 +594,7 @@ func TestPowerScalesWithStake(t *testing.T) {
 	k.CreateDomain(ctx, "Big", sdk.AccAddress("admin1"), sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 500_000*PNYXUnit)))
 	domain, _ := k.GetDomain(ctx, "Big")
 	domain.Members = append(domain.Members, "whale")
-	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:Big"), bz)
+	k.SetDomain(ctx, domain)
 
 	pk := testPubKey("whale")
 	stake := sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 300_000*PNYXUnit))
@@ -630,8 +617,7 @@ func TestDistributeDomainInterest(t *testing.T) {
 	domain, _ := k.GetDomain(ctx, "Active")
 	domain.TotalPayouts = 100_000 * PNYXUnit // active domain
 	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:Active"), bz)
+	k.SetDomain(ctx, domain)
 
 	// Initialize tracking state (mimics InitGenesis).
 	initTime := ctx.BlockTime().Unix()
@@ -699,8 +685,7 @@ func TestDomainInterestCappedByPayout(t *testing.T) {
 	domain, _ := k.GetDomain(ctx, "Big")
 	domain.TotalPayouts = 1 // tiny payout → caps interest at 1
 	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:Big"), bz)
+	k.SetDomain(ctx, domain)
 
 	initTime := ctx.BlockTime().Unix()
 	st.Set([]byte("dom:last-interest-time"), k.cdc.MustMarshalLengthPrefixed(initTime))
@@ -727,8 +712,7 @@ func TestDomainInterestDecaysWithRelease(t *testing.T) {
 	domain, _ := k.GetDomain(ctx, "Decay")
 	domain.TotalPayouts = 1_000_000 * PNYXUnit // high cap so it doesn't constrain
 	st := ctx.KVStore(k.StoreKey)
-	bz := k.cdc.MustMarshalLengthPrefixed(&domain)
-	st.Set([]byte("domain:Decay"), bz)
+	k.SetDomain(ctx, domain)
 
 	initTime := ctx.BlockTime().Unix()
 	st.Set([]byte("dom:last-interest-time"), k.cdc.MustMarshalLengthPrefixed(initTime))
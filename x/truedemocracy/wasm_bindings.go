@@ -15,13 +15,14 @@ import (
 
 // WasmCustomQuery is the top-level query envelope sent by contracts.
 type WasmCustomQuery struct {
-	Domain         *WasmQueryDomain         `json:"domain,omitempty"`
-	DomainMembers  *WasmQueryDomainMembers  `json:"domain_members,omitempty"`
-	Issue          *WasmQueryIssue          `json:"issue,omitempty"`
-	Suggestion     *WasmQuerySuggestion     `json:"suggestion,omitempty"`
-	PurgeSchedule  *WasmQueryPurgeSchedule  `json:"purge_schedule,omitempty"`
-	Nullifier      *WasmQueryNullifier      `json:"nullifier,omitempty"`
-	DomainTreasury *WasmQueryDomainTreasury `json:"domain_treasury,omitempty"`
+	Domain           *WasmQueryDomain           `json:"domain,omitempty"`
+	DomainMembers    *WasmQueryDomainMembers    `json:"domain_members,omitempty"`
+	Issue            *WasmQueryIssue            `json:"issue,omitempty"`
+	Suggestion       *WasmQuerySuggestion       `json:"suggestion,omitempty"`
+	PurgeSchedule    *WasmQueryPurgeSchedule    `json:"purge_schedule,omitempty"`
+	Nullifier        *WasmQueryNullifier        `json:"nullifier,omitempty"`
+	DomainTreasury   *WasmQueryDomainTreasury   `json:"domain_treasury,omitempty"`
+	AnonymousSignals *WasmQueryAnonymousSignals `json:"anonymous_signals,omitempty"`
 }
 
 type WasmQueryDomain struct {
@@ -56,6 +57,15 @@ type WasmQueryDomainTreasury struct {
 	DomainName string `json:"domain_name"`
 }
 
+// WasmQueryAnonymousSignals pages through the signals of a topic in
+// nullifier order. Limit defaults to 30 and is capped at 100.
+type WasmQueryAnonymousSignals struct {
+	DomainName string `json:"domain_name"`
+	Topic      string `json:"topic"`
+	StartAfter string `json:"start_after,omitempty"` // nullifier hex of the last signal seen
+	Limit      uint32 `json:"limit,omitempty"`
+}
+
 // --- Custom Query Response Types ---
 
 type WasmDomainResponse struct {
@@ -128,6 +138,16 @@ type WasmDomainTreasuryResponse struct {
 	Amount     string `json:"amount"` // e.g. "500000upnyx"
 }
 
+type WasmAnonymousSignal struct {
+	NullifierHash string `json:"nullifier_hash"`
+	Signal        string `json:"signal"`
+	Height        int64  `json:"height"`
+}
+
+type WasmAnonymousSignalsResponse struct {
+	Signals []WasmAnonymousSignal `json:"signals"`
+}
+
 // --- Custom Query Handler ---
 
 // CustomQueryHandler returns a query handler function for CosmWasm contracts
@@ -155,6 +175,8 @@ func CustomQueryHandler(keeper Keeper) func(ctx sdk.Context, request json.RawMes
 			return handleQueryNullifier(ctx, keeper, query.Nullifier)
 		case query.DomainTreasury != nil:
 			return handleQueryDomainTreasury(ctx, keeper, query.DomainTreasury)
+		case query.AnonymousSignals != nil:
+			return handleQueryAnonymousSignals(ctx, keeper, query.AnonymousSignals)
 		default:
 			return nil, fmt.Errorf("unknown truedemocracy query")
 		}
@@ -298,6 +320,25 @@ func handleQueryDomainTreasury(ctx sdk.Context, keeper Keeper, req *WasmQueryDom
 	return json.Marshal(resp)
 }
 
+func handleQueryAnonymousSignals(ctx sdk.Context, keeper Keeper, req *WasmQueryAnonymousSignals) ([]byte, error) {
+	if _, found := keeper.GetDomainHeader(ctx, req.DomainName); !found {
+		return nil, fmt.Errorf("domain not found: %s", req.DomainName)
+	}
+	limit := 30
+	if req.Limit > 0 {
+		limit = min(int(req.Limit), 100)
+	}
+	resp := WasmAnonymousSignalsResponse{Signals: []WasmAnonymousSignal{}}
+	for _, signal := range keeper.anonymousSignals(ctx, req.DomainName, req.Topic, req.StartAfter, limit) {
+		resp.Signals = append(resp.Signals, WasmAnonymousSignal{
+			NullifierHash: signal.NullifierHash,
+			Signal:        signal.Signal,
+			Height:        signal.Height,
+		})
+	}
+	return json.Marshal(resp)
+}
+
 // --- Custom Message Types ---
 
 // WasmCustomMsg is the top-level message envelope sent by contracts.
@@ -307,6 +348,7 @@ type WasmCustomMsg struct {
 	CastElectionVote       *WasmMsgCastElectionVote       `json:"cast_election_vote,omitempty"`
 	DepositToDomain        *WasmMsgDepositToDomain        `json:"deposit_to_domain,omitempty"`
 	WithdrawFromDomain     *WasmMsgWithdrawFromDomain     `json:"withdraw_from_domain,omitempty"`
+	SignalWithProof        *WasmMsgSignalWithProof        `json:"signal_with_proof,omitempty"`
 }
 
 type WasmMsgPlaceStoneOnIssue struct {
@@ -338,6 +380,17 @@ type WasmMsgWithdrawFromDomain struct {
 	Amount     string `json:"amount"`    // e.g. "100upnyx"
 }
 
+// WasmMsgSignalWithProof relays a member's anonymous signal; the contract
+// only submits the proof and learns nothing about the member.
+type WasmMsgSignalWithProof struct {
+	DomainName    string `json:"domain_name"`
+	Topic         string `json:"topic"`
+	Signal        string `json:"signal"`
+	Proof         string `json:"proof"`
+	NullifierHash string `json:"nullifier_hash"`
+	MerkleRoot    string `json:"merkle_root,omitempty"`
+}
+
 // --- Custom Message Encoder ---
 
 // CustomMessageEncoder returns a message encoder function for CosmWasm contracts
@@ -406,6 +459,18 @@ func CustomMessageEncoder() func(sender sdk.AccAddress, msg json.RawMessage) ([]
 				Amount:     coin,
 			}}, nil
 
+		case customMsg.SignalWithProof != nil:
+			m := customMsg.SignalWithProof
+			return []sdk.Msg{&MsgSignalWithProof{
+				Sender:        sender,
+				DomainName:    m.DomainName,
+				Topic:         m.Topic,
+				Signal:        m.Signal,
+				Proof:         m.Proof,
+				NullifierHash: m.NullifierHash,
+				MerkleRoot:    m.MerkleRoot,
+			}}, nil
+
 		default:
 			return nil, fmt.Errorf("unknown truedemocracy message")
 		}
@@ -56,8 +56,7 @@ func TestWasmQueryDomainMembers(t *testing.T) {
 	// Add extra members.
 	domain, _ := k.GetDomain(ctx, "MembersDomain")
 	domain.Members = append(domain.Members, "alice", "bob")
-	st := ctx.KVStore(k.StoreKey)
-	st.Set([]byte("domain:MembersDomain"), k.cdc.MustMarshalLengthPrefixed(&domain))
+	k.SetDomain(ctx, domain)
 
 	handler := CustomQueryHandler(k)
 
@@ -106,8 +105,7 @@ func TestWasmQueryIssue(t *testing.T) {
 			},
 		},
 	}
-	st := ctx.KVStore(k.StoreKey)
-	st.Set([]byte("domain:IssueDomain"), k.cdc.MustMarshalLengthPrefixed(&domain))
+	k.SetDomain(ctx, domain)
 
 	handler := CustomQueryHandler(k)
 
@@ -184,8 +182,7 @@ func TestWasmQuerySuggestion(t *testing.T) {
 			},
 		},
 	}
-	st := ctx.KVStore(k.StoreKey)
-	st.Set([]byte("domain:SugDomain"), k.cdc.MustMarshalLengthPrefixed(&domain))
+	k.SetDomain(ctx, domain)
 
 	handler := CustomQueryHandler(k)
 
@@ -335,6 +332,47 @@ func TestWasmQueryNullifier(t *testing.T) {
 	})
 }
 
+func TestWasmQueryAnonymousSignals(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	k.CreateDomain(ctx, "SignalDomain", sdk.AccAddress("admin1"), sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 100)))
+	store := ctx.KVStore(k.StoreKey)
+	for _, nullifier := range []string{"aa", "bb", "cc"} {
+		record := AnonymousSignal{DomainName: "SignalDomain", Topic: "poll", NullifierHash: nullifier, Signal: "yes-" + nullifier, Height: 7}
+		store.Set(anonSignalKey("SignalDomain", "poll", nullifier), k.cdc.MustMarshalLengthPrefixed(&record))
+	}
+
+	handler := CustomQueryHandler(k)
+	signals := func(req WasmQueryAnonymousSignals) []WasmAnonymousSignal {
+		t.Helper()
+		reqBytes, _ := json.Marshal(WasmCustomQuery{AnonymousSignals: &req})
+		respBytes, err := handler(ctx, reqBytes)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var resp WasmAnonymousSignalsResponse
+		if err := json.Unmarshal(respBytes, &resp); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		return resp.Signals
+	}
+
+	if got := signals(WasmQueryAnonymousSignals{DomainName: "SignalDomain", Topic: "poll"}); len(got) != 3 || got[0].Signal != "yes-aa" || got[0].Height != 7 {
+		t.Fatalf("signals = %+v", got)
+	}
+	got := signals(WasmQueryAnonymousSignals{DomainName: "SignalDomain", Topic: "poll", StartAfter: "aa", Limit: 1})
+	if len(got) != 1 || got[0].NullifierHash != "bb" {
+		t.Fatalf("page = %+v", got)
+	}
+	if got := signals(WasmQueryAnonymousSignals{DomainName: "SignalDomain", Topic: "other"}); len(got) != 0 {
+		t.Fatalf("other topic signals = %+v", got)
+	}
+
+	reqBytes, _ := json.Marshal(WasmCustomQuery{AnonymousSignals: &WasmQueryAnonymousSignals{DomainName: "Nowhere", Topic: "poll"}})
+	if _, err := handler(ctx, reqBytes); err == nil {
+		t.Fatal("expected error for unknown domain")
+	}
+}
+
 func TestWasmQueryInvalidJSON(t *testing.T) {
 	k, ctx := setupKeeper(t)
 	handler := CustomQueryHandler(k)
@@ -634,3 +672,34 @@ func TestWasmMsgWithdrawFromDomain(t *testing.T) {
 		}
 	})
 }
+
+func TestWasmMsgSignalWithProof(t *testing.T) {
+	encoder := CustomMessageEncoder()
+	sender := sdk.AccAddress("contract1")
+	msgBytes, _ := json.Marshal(WasmCustomMsg{
+		SignalWithProof: &WasmMsgSignalWithProof{
+			DomainName:    "TestDomain",
+			Topic:         "poll",
+			Signal:        "yes",
+			Proof:         "abcd",
+			NullifierHash: "ef01",
+		},
+	})
+	msgs, err := encoder(sender, msgBytes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(msgs) != 1 {
+		t.Fatalf("msgs len = %d, want 1", len(msgs))
+	}
+	m, ok := msgs[0].(*MsgSignalWithProof)
+	if !ok {
+		t.Fatalf("wrong msg type: %T", msgs[0])
+	}
+	if m.DomainName != "TestDomain" || m.Topic != "poll" || m.Signal != "yes" || m.Proof != "abcd" || m.NullifierHash != "ef01" {
+		t.Errorf("msg = %+v", m)
+	}
+	if !m.Sender.Equals(sender) {
+		t.Errorf("sender = %s, want %s", m.Sender, sender)
+	}
+}
@@ -19,44 +19,97 @@ import (
 
 const (
 	MembershipCircuitID          = zkpcircuit.ID
+	Poseidon2MembershipCircuitID = zkpcircuit.Poseidon2ID
 	membershipPublicWitnessCount = zkpcircuit.PublicWitnessCount
 )
 
+// membershipCircuitNullifierFamilies lists the membership circuits a verifying
+// key may be bound to, by the hashing they share with the identity tree.
+// Circuits of one family hash commitments, tree nodes and nullifiers the same
+// way, so a member proving under either circuit reveals the same nullifier for
+// a scope and the spent-nullifier set stays sound across a key rotation.
+var membershipCircuitNullifierFamilies = map[string]string{
+	MembershipCircuitID:          HashFamilyMiMC,
+	Poseidon2MembershipCircuitID: HashFamilyPoseidon2,
+}
+
+// hashFamilyCircuits names the first circuit of every family: the circuit a
+// family's key slot runs before a rotation records another.
+var hashFamilyCircuits = map[string]string{
+	HashFamilyMiMC:      MembershipCircuitID,
+	HashFamilyPoseidon2: Poseidon2MembershipCircuitID,
+}
+
 // MembershipCircuit is the shared frozen Groth16 membership-vote circuit.
 type MembershipCircuit = zkpcircuit.MembershipCircuit
 
-// ZKPKeys holds the compiled circuit artifacts for Groth16.
+// Poseidon2MembershipCircuit is its Poseidon2 variant.
+type Poseidon2MembershipCircuit = zkpcircuit.Poseidon2MembershipCircuit
+
+// membershipCircuitAssignment returns the circuit of circuitID holding
+// assignment; every membership circuit shares its witness layout.
+func membershipCircuitAssignment(circuitID string, assignment MembershipCircuit) (frontend.Circuit, error) {
+	switch circuitID {
+	case MembershipCircuitID:
+		return &assignment, nil
+	case Poseidon2MembershipCircuitID:
+		variant := Poseidon2MembershipCircuit(assignment)
+		return &variant, nil
+	}
+	return nil, fmt.Errorf("unsupported ZKP circuit id %q", circuitID)
+}
+
+// ZKPKeys holds the compiled circuit artifacts for Groth16. An empty
+// CircuitID is the MiMC circuit.
 type ZKPKeys struct {
 	ProvingKey   groth16.ProvingKey
 	VerifyingKey groth16.VerifyingKey
 	CS           constraint.ConstraintSystem
+	CircuitID    string
+}
+
+func (k *ZKPKeys) circuitID() string {
+	if k.CircuitID == "" {
+		return MembershipCircuitID
+	}
+	return k.CircuitID
 }
 
-// Global cached keys (setup is expensive, ~seconds).
+// Global cached keys per circuit (setup is expensive, ~seconds).
 var (
-	cachedKeys     *ZKPKeys
-	cachedKeysOnce sync.Once
-	cachedKeysErr  error
+	cachedKeysMu sync.Mutex
+	cachedKeys   = make(map[string]*ZKPKeys)
 )
 
-// SetupMembershipCircuit compiles the circuit and runs the Groth16
+// SetupMembershipCircuit compiles the MiMC circuit and runs the Groth16
 // trusted setup. This is expensive and results are cached globally.
 func SetupMembershipCircuit() (*ZKPKeys, error) {
-	cachedKeysOnce.Do(func() {
-		var circuit MembershipCircuit
-		cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
-		if err != nil {
-			cachedKeysErr = fmt.Errorf("circuit compilation failed: %w", err)
-			return
-		}
-		pk, vk, err := groth16.Setup(cs)
-		if err != nil {
-			cachedKeysErr = fmt.Errorf("groth16 setup failed: %w", err)
-			return
-		}
-		cachedKeys = &ZKPKeys{ProvingKey: pk, VerifyingKey: vk, CS: cs}
-	})
-	return cachedKeys, cachedKeysErr
+	return SetupMembershipCircuitFor(MembershipCircuitID)
+}
+
+// SetupMembershipCircuitFor is SetupMembershipCircuit for any supported
+// circuit.
+func SetupMembershipCircuitFor(circuitID string) (*ZKPKeys, error) {
+	cachedKeysMu.Lock()
+	defer cachedKeysMu.Unlock()
+	if keys, ok := cachedKeys[circuitID]; ok {
+		return keys, nil
+	}
+	circuit, err := membershipCircuitAssignment(circuitID, MembershipCircuit{})
+	if err != nil {
+		return nil, err
+	}
+	cs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+	if err != nil {
+		return nil, fmt.Errorf("circuit compilation failed: %w", err)
+	}
+	pk, vk, err := groth16.Setup(cs)
+	if err != nil {
+		return nil, fmt.Errorf("groth16 setup failed: %w", err)
+	}
+	keys := &ZKPKeys{ProvingKey: pk, VerifyingKey: vk, CS: cs, CircuitID: circuitID}
+	cachedKeys[circuitID] = keys
+	return keys, nil
 }
 
 // GenerateMembershipProof creates a Groth16 proof that the prover
@@ -91,7 +144,8 @@ func GenerateMembershipProofForSignal(
 	}
 
 	// Compute nullifier off-chain for return value.
-	nullifierHash, err = ComputeNullifier(identitySecret, externalNullifier)
+	circuitID := keys.circuitID()
+	nullifierHash, err = ComputeNullifierFor(membershipCircuitNullifierFamilies[circuitID], identitySecret, externalNullifier)
 	if err != nil {
 		return nil, nil, fmt.Errorf("nullifier computation failed: %w", err)
 	}
@@ -110,7 +164,11 @@ func GenerateMembershipProofForSignal(
 	}
 
 	// Create witness.
-	witness, err := frontend.NewWitness(&assignment, ecc.BN254.ScalarField())
+	circuit, err := membershipCircuitAssignment(circuitID, assignment)
+	if err != nil {
+		return nil, nil, err
+	}
+	witness, err := frontend.NewWitness(circuit, ecc.BN254.ScalarField())
 	if err != nil {
 		return nil, nil, fmt.Errorf("witness creation failed: %w", err)
 	}
@@ -251,11 +309,12 @@ func VerifyingKeyFingerprint(data []byte) string {
 	return hex.EncodeToString(digest[:])
 }
 
-// ValidateMembershipVerifyingKey pins the trusted genesis artifact to the
-// expected circuit version, exact bytes, fingerprint, curve, and public-input
-// shape. The genesis file remains the trust anchor for the ceremony output.
+// ValidateMembershipVerifyingKey pins the trusted genesis artifact to a
+// supported circuit version, exact bytes, fingerprint, curve, and public-input
+// shape. The genesis file remains the trust anchor for the ceremony output;
+// later keys are admitted only by verifying-key rotation governance.
 func ValidateMembershipVerifyingKey(data []byte, circuitID, fingerprint string) (groth16.VerifyingKey, error) {
-	if circuitID != MembershipCircuitID {
+	if _, supported := membershipCircuitNullifierFamilies[circuitID]; !supported {
 		return nil, fmt.Errorf("unsupported ZKP circuit id %q", circuitID)
 	}
 	if fingerprint != VerifyingKeyFingerprint(data) {
@@ -266,7 +325,7 @@ func ValidateMembershipVerifyingKey(data []byte, circuitID, fingerprint string)
 		return nil, err
 	}
 	if vk.CurveID() != ecc.BN254 || vk.NbPublicWitness() != membershipPublicWitnessCount {
-		return nil, fmt.Errorf("verifying key does not match %s public-input shape", MembershipCircuitID)
+		return nil, fmt.Errorf("verifying key does not match %s public-input shape", circuitID)
 	}
 	canonical, err := SerializeVerifyingKey(vk)
 	if err != nil {
@@ -0,0 +1,333 @@
+package truedemocracy
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MembershipStatement is one serialized membership proof together with the
+// public inputs it claims, in circuit order.
+type MembershipStatement struct {
+	Proof             []byte
+	MerkleRoot        []byte
+	NullifierHash     []byte
+	ExternalNullifier []byte
+	SignalHash        []byte
+}
+
+func (s MembershipStatement) publicInputs() [][]byte {
+	return [][]byte{s.MerkleRoot, s.NullifierHash, s.ExternalNullifier, s.SignalHash}
+}
+
+// encode frames the statement unambiguously: the length-prefixed proof
+// followed by the four 32-byte public inputs.
+func (s MembershipStatement) encode() []byte {
+	out := binary.BigEndian.AppendUint32(nil, uint32(len(s.Proof)))
+	out = append(out, s.Proof...)
+	for _, input := range s.publicInputs() {
+		out = append(out, input...)
+	}
+	return out
+}
+
+// BatchVerifyMembershipProofs checks every statement under vk and returns one
+// error per statement, nil for a valid proof. Well-formed proofs are first
+// checked together: each Groth16 equation
+//
+//	e(A, B) · e(C, -δ) · e(L, -γ) = e(α, β)
+//
+// is raised to a coefficient r derived by Fiat-Shamir from the key and all
+// statements, and the product is checked in one multi-pairing. If that check
+// fails, every proof is verified on its own so a single bad proof cannot
+// reject the others. Keys with Pedersen commitments are always verified one by
+// one.
+func BatchVerifyMembershipProofs(vk groth16.VerifyingKey, statements []MembershipStatement) []error {
+	return batchVerify(vk, statements, true)
+}
+
+// errLeftToDelivery marks a statement the combined check did not accept when
+// no per-proof fallback was asked for; delivery verifies it on its own.
+var errLeftToDelivery = errors.New("proof left to per-proof verification")
+
+// batchVerify is BatchVerifyMembershipProofs with the per-proof fallback made
+// optional. Without it, a statement is either accepted by the one combined
+// check or marked errLeftToDelivery, and no single pairing check runs.
+func batchVerify(vk groth16.VerifyingKey, statements []MembershipStatement, fallback bool) []error {
+	errs := make([]error, len(statements))
+	bnVK, batchable := vk.(*groth16bn254.VerifyingKey)
+	batchable = batchable && len(bnVK.CommitmentKeys) == 0 && len(bnVK.PublicAndCommitmentCommitted) == 0 &&
+		len(bnVK.G1.K) == membershipPublicWitnessCount+1
+
+	var pending []int
+	proofs := make([]*groth16bn254.Proof, len(statements))
+	for i, statement := range statements {
+		if err := validateProofPublicInputs(statement.MerkleRoot, statement.NullifierHash, statement.ExternalNullifier, statement.SignalHash); err != nil {
+			errs[i] = err
+			continue
+		}
+		proof, err := DeserializeProof(statement.Proof)
+		if err != nil {
+			errs[i] = fmt.Errorf("proof deserialization failed: %w", err)
+			continue
+		}
+		bnProof, ok := proof.(*groth16bn254.Proof)
+		if !ok || len(bnProof.Commitments) != 0 || !bnProof.Ar.IsInSubGroup() || !bnProof.Krs.IsInSubGroup() || !bnProof.Bs.IsInSubGroup() {
+			if fallback {
+				errs[i] = verifySingle(vk, statement)
+			} else {
+				errs[i] = errLeftToDelivery
+			}
+			continue
+		}
+		proofs[i] = bnProof
+		pending = append(pending, i)
+	}
+
+	if batchable && len(pending) > 1 && batchPairingCheck(bnVK, statements, proofs, pending) {
+		return errs
+	}
+	for _, i := range pending {
+		if fallback {
+			errs[i] = verifySingle(vk, statements[i])
+		} else {
+			errs[i] = errLeftToDelivery
+		}
+	}
+	return errs
+}
+
+func verifySingle(vk groth16.VerifyingKey, s MembershipStatement) error {
+	return VerifyMembershipProofForSignal(vk, s.Proof, s.MerkleRoot, s.NullifierHash, s.ExternalNullifier, s.SignalHash)
+}
+
+// batchPairingCheck reports whether the random linear combination of the
+// pending proofs' verification equations holds.
+func batchPairingCheck(vk *groth16bn254.VerifyingKey, statements []MembershipStatement, proofs []*groth16bn254.Proof, pending []int) bool {
+	coefficients := batchCoefficients(vk, statements, pending)
+
+	// Σr and Σr·x_i scale α and the public-input bases K_0..K_4.
+	var rSum fr.Element
+	inputSums := make([]fr.Element, membershipPublicWitnessCount)
+	krs := make([]curve.G1Affine, len(pending))
+	g1 := make([]curve.G1Affine, 0, len(pending)+3)
+	g2 := make([]curve.G2Affine, 0, len(pending)+3)
+	for n, i := range pending {
+		r := coefficients[n]
+		rSum.Add(&rSum, &r)
+		for j, input := range statements[i].publicInputs() {
+			var x fr.Element
+			x.SetBytes(input)
+			x.Mul(&x, &r)
+			inputSums[j].Add(&inputSums[j], &x)
+		}
+		krs[n] = proofs[i].Krs
+		var a curve.G1Affine
+		a.ScalarMultiplication(&proofs[i].Ar, r.BigInt(new(big.Int)))
+		g1 = append(g1, a)
+		g2 = append(g2, proofs[i].Bs)
+	}
+
+	var c curve.G1Affine
+	if _, err := c.MultiExp(krs, coefficients, ecc.MultiExpConfig{}); err != nil {
+		return false
+	}
+	var l curve.G1Affine
+	if _, err := l.MultiExp(vk.G1.K, append([]fr.Element{rSum}, inputSums...), ecc.MultiExpConfig{}); err != nil {
+		return false
+	}
+	var alpha curve.G1Affine
+	alpha.ScalarMultiplication(&vk.G1.Alpha, rSum.BigInt(new(big.Int)))
+
+	var deltaNeg, gammaNeg, betaNeg curve.G2Affine
+	deltaNeg.Neg(&vk.G2.Delta)
+	gammaNeg.Neg(&vk.G2.Gamma)
+	betaNeg.Neg(&vk.G2.Beta)
+	g1 = append(g1, c, l, alpha)
+	g2 = append(g2, deltaNeg, gammaNeg, betaNeg)
+	ok, err := curve.PairingCheck(g1, g2)
+	return err == nil && ok
+}
+
+// batchCoefficients derives one non-zero 128-bit coefficient per pending
+// statement from a hash of the key and every pending statement, so the
+// combination is fixed only after all proofs are and every node derives the
+// same one.
+func batchCoefficients(vk *groth16bn254.VerifyingKey, statements []MembershipStatement, pending []int) []fr.Element {
+	transcript := sha256.New()
+	transcript.Write([]byte("TrueRepublic/zkp-batch/v1"))
+	_, _ = vk.WriteRawTo(transcript)
+	for _, i := range pending {
+		transcript.Write(statements[i].encode())
+	}
+	seed := transcript.Sum(nil)
+
+	coefficients := make([]fr.Element, len(pending))
+	for n := range pending {
+		digest := sha256.Sum256(binary.BigEndian.AppendUint32(append([]byte(nil), seed...), uint32(n)))
+		coefficients[n].SetBytes(digest[:16])
+		if coefficients[n].IsZero() {
+			coefficients[n].SetOne()
+		}
+	}
+	return coefficients
+}
+
+// ---------- Block-level batching ----------
+
+// verifiedProofCache remembers the statements of the current block that the
+// pre-block batch check accepted. Entries are tied to one block height, so a
+// later block never trusts them.
+type verifiedProofCache struct {
+	mu     sync.Mutex
+	height int64
+	keys   map[[32]byte]struct{}
+}
+
+func newVerifiedProofCache() *verifiedProofCache {
+	return &verifiedProofCache{keys: make(map[[32]byte]struct{})}
+}
+
+func verifiedProofKey(vkBytes []byte, statement MembershipStatement) [32]byte {
+	digest := sha256.New()
+	digest.Write([]byte(VerifyingKeyFingerprint(vkBytes)))
+	digest.Write(statement.encode())
+	var key [32]byte
+	copy(key[:], digest.Sum(nil))
+	return key
+}
+
+func (c *verifiedProofCache) reset(height int64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.height = height
+	c.keys = make(map[[32]byte]struct{})
+}
+
+func (c *verifiedProofCache) add(height int64, key [32]byte) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.height == height {
+		c.keys[key] = struct{}{}
+	}
+}
+
+func (c *verifiedProofCache) contains(height int64, key [32]byte) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.keys[key]
+	return ok && c.height == height
+}
+
+// MaxBlockProofBatch bounds how many rating proofs of one block the pre-block
+// check combines. It runs before the ante handler, so the work it does for a
+// block is bounded here rather than by fees; later proofs are verified on
+// delivery like any other.
+const MaxBlockProofBatch = 64
+
+// PrepareProofBatch batch-verifies the anonymous rating proofs of a block
+// before its transactions run. Accepted statements skip the per-proof
+// pairing check when their message is delivered; anything else, including
+// statements whose context changes earlier in the block, is verified as
+// before. Undecodable transactions and malformed messages are left to normal
+// delivery.
+func (k Keeper) PrepareProofBatch(ctx sdk.Context, txs [][]byte, decode sdk.TxDecoder) {
+	var msgs []*MsgRateWithProof
+	for _, raw := range txs {
+		tx, err := decode(raw)
+		if err != nil {
+			continue
+		}
+		for _, msg := range tx.GetMsgs() {
+			if rating, ok := msg.(*MsgRateWithProof); ok {
+				msgs = append(msgs, rating)
+			}
+		}
+	}
+	k.batchVerifyRatings(ctx, msgs)
+}
+
+// batchVerifyRatings caches the statements of msgs that one combined check per
+// hash family accepts. At most MaxBlockProofBatch statements are combined, and
+// a failed check caches nothing: delivery verifies every proof anyway, so
+// checking them one by one here as well would only repeat that work.
+
+func (k Keeper) batchVerifyRatings(ctx sdk.Context, msgs []*MsgRateWithProof) {
+	k.verifiedProofs.reset(ctx.BlockHeight())
+	if len(msgs) < 2 {
+		return
+	}
+	// Proofs verify under the key of their domain's hash family, so each
+	// family is batched on its own.
+	byFamily := make(map[string][]MembershipStatement)
+	var families []string
+	batched := 0
+	for _, msg := range msgs {
+		if batched == MaxBlockProofBatch {
+			break
+		}
+		statement, ok := k.ratingStatement(ctx, msg)
+		if !ok {
+			continue
+		}
+		domain, _ := k.GetDomainHeader(ctx, msg.DomainName)
+		family := domain.Options.hashFamily()
+		if _, seen := byFamily[family]; !seen {
+			families = append(families, family)
+		}
+		byFamily[family] = append(byFamily[family], statement)
+		batched++
+	}
+	for _, family := range families {
+		statements := byFamily[family]
+		vkBytes, found := k.GetFamilyVerifyingKey(ctx, family)
+		if !found {
+			continue
+		}
+		vk, err := DeserializeVerifyingKey(vkBytes)
+		if err != nil {
+			continue
+		}
+		for i, err := range batchVerify(vk, statements, false) {
+			if err == nil {
+				k.verifiedProofs.add(ctx.BlockHeight(), verifiedProofKey(vkBytes, statements[i]))
+			}
+		}
+	}
+}
+
+// ratingStatement resolves the statement RateProposalWithZKP will verify for
+// msg against the current state.
+func (k Keeper) ratingStatement(ctx sdk.Context, msg *MsgRateWithProof) (MembershipStatement, bool) {
+	if msg.ValidateBasic() != nil {
+		return MembershipStatement{}, false
+	}
+	domain, found := k.GetDomainHeader(ctx, msg.DomainName)
+	if !found {
+		return MembershipStatement{}, false
+	}
+	externalNullifier := ComputeVoteNullifierScope(ctx.ChainID(), msg.DomainName, msg.IssueName, msg.SuggestionName)
+	signalHash := ComputeVoteSignalV2(ctx.ChainID(), msg.DomainName, msg.IssueName, msg.SuggestionName, int(msg.Rating), msg.RewardRecipient)
+	statement, err := membershipStatement(domain, msg.Proof, msg.NullifierHash, msg.MerkleRoot, externalNullifier, signalHash)
+	return statement, err == nil
+}
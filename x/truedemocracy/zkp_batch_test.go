@@ -0,0 +1,238 @@
+package truedemocracy
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	groth16bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// batchTestStatements proves n ratings of distinct members of one tree.
+func batchTestStatements(tb testing.TB, keys *ZKPKeys, n int) []MembershipStatement {
+	tb.Helper()
+	secrets := make([][]byte, n)
+	leaves := make([][]byte, n)
+	for i := range secrets {
+		secrets[i] = []byte{byte(i + 1), 0x5a}
+		commitment, err := ComputeCommitment(secrets[i])
+		if err != nil {
+			tb.Fatal(err)
+		}
+		leaves[i] = commitment
+	}
+	tree := NewMerkleTree(MerkleTreeDepth)
+	if err := tree.BuildFromLeaves(leaves); err != nil {
+		tb.Fatal(err)
+	}
+	statements := make([]MembershipStatement, n)
+	for i := range statements {
+		siblings, pathIndices, err := tree.GenerateProof(i)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		scope := ComputeVoteNullifierScope("batch-chain", "Batch", "Issue", fmt.Sprintf("Suggestion%d", i%3))
+		signal := ComputeVoteSignalV2("batch-chain", "Batch", "Issue", fmt.Sprintf("Suggestion%d", i%3), i%11-5, testRewardRecipient())
+		proof, nullifier, err := GenerateMembershipProofForSignal(keys, secrets[i], tree.Root, siblings, pathIndices, scope, signal)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		statements[i] = MembershipStatement{Proof: proof, MerkleRoot: tree.Root, NullifierHash: nullifier, ExternalNullifier: scope, SignalHash: signal}
+	}
+	return statements
+}
+
+func TestBatchVerifyMembershipProofs(t *testing.T) {
+	keys := getTestZKPKeys(t)
+	statements := batchTestStatements(t, keys, 4)
+
+	for i, err := range BatchVerifyMembershipProofs(keys.VerifyingKey, statements) {
+		if err != nil {
+			t.Fatalf("valid statement %d rejected: %v", i, err)
+		}
+	}
+	if got := BatchVerifyMembershipProofs(keys.VerifyingKey, nil); len(got) != 0 {
+		t.Fatalf("empty batch returned %d results", len(got))
+	}
+
+	// A wrong signal, a swapped proof and malformed bytes fail alone; the
+	// valid proofs in the same batch still pass.
+	mixed := append([]MembershipStatement(nil), statements...)
+	mixed[1].SignalHash = statements[2].SignalHash
+	mixed[2].Proof = statements[3].Proof
+	mixed = append(mixed, MembershipStatement{Proof: []byte{1, 2, 3}, MerkleRoot: statements[0].MerkleRoot,
+		NullifierHash: statements[0].NullifierHash, ExternalNullifier: statements[0].ExternalNullifier, SignalHash: statements[0].SignalHash})
+	errs := BatchVerifyMembershipProofs(keys.VerifyingKey, mixed)
+	for i, err := range errs {
+		if wantErr := i == 1 || i == 2 || i == 4; (err != nil) != wantErr {
+			t.Fatalf("statement %d: err = %v, want error %v", i, err, wantErr)
+		}
+	}
+}
+
+func TestBatchPairingCheck(t *testing.T) {
+	keys := getTestZKPKeys(t)
+	statements := batchTestStatements(t, keys, 3)
+	vk := keys.VerifyingKey.(*groth16bn254.VerifyingKey)
+	proofs := make([]*groth16bn254.Proof, len(statements))
+	for i, statement := range statements {
+		proof, err := DeserializeProof(statement.Proof)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proofs[i] = proof.(*groth16bn254.Proof)
+	}
+	all := []int{0, 1, 2}
+	if !batchPairingCheck(vk, statements, proofs, all) {
+		t.Fatal("combined equation rejected valid proofs")
+	}
+
+	// One wrong public input breaks the combined equation.
+	tampered := append([]MembershipStatement(nil), statements...)
+	tampered[2].SignalHash = statements[0].SignalHash
+	if batchPairingCheck(vk, tampered, proofs, all) {
+		t.Fatal("combined equation accepted a proof for another signal")
+	}
+	// Two proofs swapped between statements do too.
+	swapped := []*groth16bn254.Proof{proofs[1], proofs[0], proofs[2]}
+	if batchPairingCheck(vk, statements, swapped, all) {
+		t.Fatal("combined equation accepted swapped proofs")
+	}
+}
+
+func TestPrepareProofBatchCachesVerifiedRatings(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	ctx = ctx.WithBlockHeight(10)
+	secrets := setupDomainWithZKPIdentity(t, k, ctx, "BatchDomain", 3)
+	addProposal(t, k, ctx, "BatchDomain", "Climate", "GreenDeal")
+	vkBytes, _ := k.GetVerifyingKey(ctx)
+
+	var msgs []*MsgRateWithProof
+	for leaf, rating := range []int{2, -1, 5} {
+		proof, nullifier := generateZKPRating(t, k, ctx, "BatchDomain", secrets, leaf, "Climate", "GreenDeal", rating)
+		msgs = append(msgs, &MsgRateWithProof{
+			Sender: sdk.AccAddress("relayer"), DomainName: "BatchDomain", IssueName: "Climate", SuggestionName: "GreenDeal",
+			Rating: int32(rating), Proof: proof, NullifierHash: nullifier, RewardRecipient: testRewardRecipient(),
+		})
+	}
+	k.batchVerifyRatings(ctx, msgs)
+	for i, msg := range msgs {
+		statement, ok := k.ratingStatement(ctx, msg)
+		if !ok {
+			t.Fatalf("message %d has no statement", i)
+		}
+		if !k.verifiedProofs.contains(ctx.BlockHeight(), verifiedProofKey(vkBytes, statement)) {
+			t.Fatalf("message %d not cached", i)
+		}
+		if k.verifiedProofs.contains(ctx.BlockHeight()+1, verifiedProofKey(vkBytes, statement)) {
+			t.Fatalf("message %d trusted at the next height", i)
+		}
+	}
+
+	// One forged proof fails the combined check, and the block caches nothing
+	// rather than verifying each proof a second time before delivery does.
+	forged := *msgs[2]
+	forged.Rating = -5
+	msgs = append(msgs, &forged)
+	k.batchVerifyRatings(ctx, msgs)
+	if len(k.verifiedProofs.keys) != 0 {
+		t.Fatal("failed batch cached statements")
+	}
+
+	for i, msg := range msgs {
+		_, err := k.RateProposalWithZKP(ctx, msg.DomainName, msg.IssueName, msg.SuggestionName, int(msg.Rating), msg.Proof, msg.NullifierHash, msg.MerkleRoot, msg.RewardRecipient)
+		if (err != nil) != (i == 3) {
+			t.Fatalf("message %d delivery error = %v", i, err)
+		}
+	}
+}
+
+func TestBatchVerifyWithoutFallbackLeavesProofsToDelivery(t *testing.T) {
+	keys := getTestZKPKeys(t)
+	statements := batchTestStatements(t, keys, 3)
+	statements[1].SignalHash = statements[0].SignalHash
+	// The wrong signal fails the combined check, and no proof is then checked
+	// on its own, valid or not.
+	for i, err := range batchVerify(keys.VerifyingKey, statements, false) {
+		if !errors.Is(err, errLeftToDelivery) {
+			t.Fatalf("statement %d: err = %v, want errLeftToDelivery", i, err)
+		}
+	}
+}
+
+func TestBatchVerifyRatingsCapsBlockWork(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	ctx = ctx.WithBlockHeight(10)
+	secrets := setupDomainWithZKPIdentity(t, k, ctx, "CapDomain", 1)
+	addProposal(t, k, ctx, "CapDomain", "Climate", "GreenDeal")
+	proof, nullifier := generateZKPRating(t, k, ctx, "CapDomain", secrets, 0, "Climate", "GreenDeal", 3)
+	valid := &MsgRateWithProof{
+		Sender: sdk.AccAddress("relayer"), DomainName: "CapDomain", IssueName: "Climate", SuggestionName: "GreenDeal",
+		Rating: 3, Proof: proof, NullifierHash: nullifier, RewardRecipient: testRewardRecipient(),
+	}
+	// A forged proof behind a full batch never reaches the combined check, so
+	// it cannot fail it; it is left to delivery.
+	msgs := make([]*MsgRateWithProof, 0, MaxBlockProofBatch+1)
+	for i := 0; i < MaxBlockProofBatch; i++ {
+		msgs = append(msgs, valid)
+	}
+	forged := *valid
+	forged.Rating = -5
+	msgs = append(msgs, &forged)
+	k.batchVerifyRatings(ctx, msgs)
+	vkBytes, _ := k.GetVerifyingKey(ctx)
+	statement, _ := k.ratingStatement(ctx, valid)
+	if !k.verifiedProofs.contains(ctx.BlockHeight(), verifiedProofKey(vkBytes, statement)) {
+		t.Fatal("full batch not cached")
+	}
+	statement, _ = k.ratingStatement(ctx, &forged)
+	if k.verifiedProofs.contains(ctx.BlockHeight(), verifiedProofKey(vkBytes, statement)) {
+		t.Fatal("statement beyond MaxBlockProofBatch was cached")
+	}
+}
+
+func TestPrepareProofBatchIgnoresUndecodableTxs(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	decode := func([]byte) (sdk.Tx, error) { return nil, fmt.Errorf("not a tx") }
+	k.PrepareProofBatch(ctx, [][]byte{[]byte("garbage"), nil}, decode)
+	if len(k.verifiedProofs.keys) != 0 {
+		t.Fatal("undecodable transactions produced cached statements")
+	}
+}
+
+// BenchmarkMembershipProofVerification compares per-proof verification with
+// one batched multi-pairing for a block's worth of rating proofs:
+//
+//	go test ./x/truedemocracy -run '^$' -bench MembershipProofVerification
+func BenchmarkMembershipProofVerification(b *testing.B) {
+	keys, err := SetupMembershipCircuit()
+	if err != nil {
+		b.Fatal(err)
+	}
+	all := batchTestStatements(b, keys, 64)
+	for _, size := range []int{1, 8, 32, 64} {
+		statements := all[:size]
+		b.Run(fmt.Sprintf("individual/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, statement := range statements {
+					if err := verifySingle(keys.VerifyingKey, statement); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+			b.ReportMetric(float64(size*b.N)/b.Elapsed().Seconds(), "proofs/s")
+		})
+		b.Run(fmt.Sprintf("batch/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, err := range BatchVerifyMembershipProofs(keys.VerifyingKey, statements) {
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+			b.ReportMetric(float64(size*b.N)/b.Elapsed().Seconds(), "proofs/s")
+		})
+	}
+}
@@ -3,6 +3,7 @@ package truedemocracy
 import (
 	"encoding/hex"
 	"encoding/json"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -141,8 +142,11 @@ func TestInitGenesisRestoresUsedZKPNullifiers(t *testing.T) {
 	if err := ValidateGenesisState(roundTrip); err != nil {
 		t.Fatalf("round-trip genesis rejected: %v", err)
 	}
-	if len(roundTrip.UsedNullifiers) != 1 || roundTrip.UsedNullifiers[0].UsedAtHeight != 42 {
-		t.Fatalf("active nullifier set not preserved: %+v", roundTrip.UsedNullifiers)
+	// The legacy record is scoped to its rating's suggestion and exported in
+	// the compact form.
+	want := []NullifierScopeGenesis{{DomainName: "Restored", IssueName: "Issue", SuggestionName: "Suggestion", Nullifiers: []string{nullifier}}}
+	if len(roundTrip.UsedNullifiers) != 0 || !reflect.DeepEqual(roundTrip.NullifierScopes, want) {
+		t.Fatalf("active nullifier set not preserved: %+v %+v", roundTrip.UsedNullifiers, roundTrip.NullifierScopes)
 	}
 }
 
@@ -170,9 +174,15 @@ func TestInitGenesisDoesNotResurrectPurgedRatingNullifier(t *testing.T) {
 
 func TestValidateGenesisRejectsMalformedUsedNullifiers(t *testing.T) {
 	genesis := validDemocracyGenesis()
+	admin := genesis.Domains[0].Admin.String()
+	genesis.Domains[0].Issues = []Issue{{Name: "Issue", Suggestions: []Suggestion{{Name: "Suggestion", Creator: admin}}}}
 	genesis.UsedNullifiers = []NullifierRecord{{
 		DomainName: "Test", NullifierHash: strings.Repeat("0", 63) + "1", UsedAtHeight: 1,
 	}}
+	genesis.NullifierScopes = []NullifierScopeGenesis{
+		{DomainName: "Test", IssueName: "Issue", Nullifiers: []string{strings.Repeat("0", 63) + "2"}},
+		{DomainName: "Test", IssueName: "Issue", SuggestionName: "Suggestion", Nullifiers: []string{strings.Repeat("0", 63) + "3"}},
+	}
 	if err := ValidateGenesisState(genesis); err != nil {
 		t.Fatalf("valid used nullifier rejected: %v", err)
 	}
@@ -185,11 +195,18 @@ func TestValidateGenesisRejectsMalformedUsedNullifiers(t *testing.T) {
 		{"short hash", func(g *GenesisState) { g.UsedNullifiers[0].NullifierHash = "01" }},
 		{"negative height", func(g *GenesisState) { g.UsedNullifiers[0].UsedAtHeight = -1 }},
 		{"duplicate", func(g *GenesisState) { g.UsedNullifiers = append(g.UsedNullifiers, g.UsedNullifiers[0]) }},
+		{"missing issue", func(g *GenesisState) { g.NullifierScopes[0].IssueName = "Gone" }},
+		{"missing suggestion", func(g *GenesisState) { g.NullifierScopes[1].SuggestionName = "Gone" }},
+		{"suggestion without issue", func(g *GenesisState) { g.NullifierScopes[1].IssueName = "" }},
+		{"empty scope", func(g *GenesisState) { g.NullifierScopes[0].Nullifiers = nil }},
+		{"malformed scoped hash", func(g *GenesisState) { g.NullifierScopes[0].Nullifiers = []string{"01"} }},
+		{"duplicate across forms", func(g *GenesisState) { g.NullifierScopes[0].Nullifiers = []string{g.UsedNullifiers[0].NullifierHash} }},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			candidate := genesis
 			candidate.UsedNullifiers = append([]NullifierRecord(nil), genesis.UsedNullifiers...)
+			candidate.NullifierScopes = append([]NullifierScopeGenesis(nil), genesis.NullifierScopes...)
 			tc.mutate(&candidate)
 			if err := ValidateGenesisState(candidate); err == nil {
 				t.Fatal("malformed used nullifier accepted")
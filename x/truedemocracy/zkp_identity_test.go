@@ -2,7 +2,9 @@ package truedemocracy
 
 import (
 	"encoding/hex"
+	"fmt"
 	"math/big"
+	"reflect"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -195,6 +197,59 @@ func TestPurgeNullifiers(t *testing.T) {
 	}
 }
 
+func TestScopedNullifiersArePrunedWithTheirScope(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	k.CreateDomain(ctx, "Scoped", sdk.AccAddress("admin1"), sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 500_000)))
+	addProposal(t, k, ctx, "Scoped", "Parks", "Plant")
+	addProposal(t, k, ctx, "Scoped", "Parks", "Pave")
+	addProposal(t, k, ctx, "Scoped", "Roads", "Repair")
+	nullifier := func(n int) string { return fmt.Sprintf("%064x", n) }
+
+	k.SetScopedNullifierUsed(ctx, "Scoped", "Parks", "Plant", nullifier(1), 10)
+	k.SetScopedNullifierUsed(ctx, "Scoped", "Parks", "Pave", nullifier(2), 10)
+	k.SetScopedNullifierUsed(ctx, "Scoped", "Parks", "", nullifier(3), 10)
+	k.SetScopedNullifierUsed(ctx, "Scoped", "Roads", "Repair", nullifier(4), 10)
+	k.SetNullifierUsed(ctx, "Scoped", nullifier(5), 10)
+	// A rating nullifier recorded before scopes existed is scoped by the
+	// migration through its rating.
+	k.recordRating(ctx, "Scoped", "Roads", "Repair", Rating{NullifierHex: nullifier(6), Value: 1})
+	k.SetNullifierUsed(ctx, "Scoped", nullifier(6), 10)
+	if err := k.MigrateNullifierScopes(ctx); err != nil {
+		t.Fatal(err)
+	}
+	used := func() []bool {
+		var out []bool
+		for n := 1; n <= 6; n++ {
+			out = append(out, k.IsNullifierUsed(ctx, "Scoped", nullifier(n)))
+		}
+		return out
+	}
+
+	k.deleteSuggestion(ctx, "Scoped", "Parks", "Plant")
+	if got := used(); !reflect.DeepEqual(got, []bool{false, true, true, true, true, true}) {
+		t.Fatalf("after suggestion deletion used = %v", got)
+	}
+	k.deleteIssue(ctx, "Scoped", "Parks")
+	if got := used(); !reflect.DeepEqual(got, []bool{false, false, false, true, true, true}) {
+		t.Fatalf("after issue deletion used = %v", got)
+	}
+	k.deleteIssue(ctx, "Scoped", "Roads")
+	if got := used(); !reflect.DeepEqual(got, []bool{false, false, false, false, true, false}) {
+		t.Fatalf("after second issue deletion used = %v", got)
+	}
+
+	k.SetScopedNullifierUsed(ctx, "Scoped", "Parks", "", nullifier(7), 11)
+	k.PurgeNullifiers(ctx, "Scoped")
+	if k.IsNullifierUsed(ctx, "Scoped", nullifier(5)) || k.IsNullifierUsed(ctx, "Scoped", nullifier(7)) {
+		t.Fatal("Big Purge left nullifiers behind")
+	}
+	iter := ctx.KVStore(k.StoreKey).Iterator(nullifierScopePrefix("Scoped"), prefixEnd(nullifierScopePrefix("Scoped")))
+	defer iter.Close()
+	if iter.Valid() {
+		t.Fatal("Big Purge left scope index entries behind")
+	}
+}
+
 // ---------- Big Purge Integration Tests ----------
 
 func TestBigPurgeClearsIdentityCommits(t *testing.T) {
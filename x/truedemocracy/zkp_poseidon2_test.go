@@ -0,0 +1,279 @@
+package truedemocracy
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	testPoseidon2Keys     *ZKPKeys
+	testPoseidon2KeysOnce sync.Once
+	testPoseidon2KeysErr  error
+)
+
+func getTestPoseidon2Keys(t *testing.T) *ZKPKeys {
+	t.Helper()
+	testPoseidon2KeysOnce.Do(func() {
+		testPoseidon2Keys, testPoseidon2KeysErr = SetupMembershipCircuitFor(Poseidon2MembershipCircuitID)
+	})
+	if testPoseidon2KeysErr != nil {
+		t.Fatalf("Poseidon2 ZKP setup failed: %v", testPoseidon2KeysErr)
+	}
+	return testPoseidon2Keys
+}
+
+// setTestPoseidon2VerifyingKey installs the Poseidon2 test key in its slot.
+func setTestPoseidon2VerifyingKey(t *testing.T, k Keeper, ctx sdk.Context) []byte {
+	t.Helper()
+	vkBytes, err := SerializeVerifyingKey(getTestPoseidon2Keys(t).VerifyingKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k.setFamilyVerifyingKey(ctx, HashFamilyPoseidon2, vkBytes)
+	k.setMembershipCircuitID(ctx, Poseidon2MembershipCircuitID)
+	return vkBytes
+}
+
+func TestPoseidon2NativeHashingMatchesCircuit(t *testing.T) {
+	keys := getTestPoseidon2Keys(t)
+	mimcKeys := getTestZKPKeys(t)
+	if got, mimc := keys.CS.GetNbConstraints(), mimcKeys.CS.GetNbConstraints(); got >= mimc {
+		t.Fatalf("Poseidon2 circuit has %d constraints, MiMC %d", got, mimc)
+	}
+
+	secrets := [][]byte{big.NewInt(700).Bytes(), big.NewInt(701).Bytes(), big.NewInt(702).Bytes()}
+	leaves := make([][]byte, len(secrets))
+	for i, secret := range secrets {
+		var err error
+		if leaves[i], err = ComputeCommitmentFor(HashFamilyPoseidon2, secret); err != nil {
+			t.Fatal(err)
+		}
+		mimcLeaf, _ := ComputeCommitment(secret)
+		if bytes.Equal(leaves[i], mimcLeaf) {
+			t.Fatal("Poseidon2 commitment equals the MiMC one")
+		}
+	}
+	tree, err := NewMerkleTreeFor(HashFamilyPoseidon2, MerkleTreeDepth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tree.BuildFromLeaves(leaves); err != nil {
+		t.Fatal(err)
+	}
+	siblings, pathIndices, err := tree.GenerateProof(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scope := ComputeVoteNullifierScope("poseidon-chain", "Domain", "Issue", "Suggestion")
+	signal := ComputeVoteSignalV2("poseidon-chain", "Domain", "Issue", "Suggestion", 4, testRewardRecipient())
+
+	// The proof only exists if the native commitment, tree and nullifier
+	// hashing agree with the circuit's.
+	proof, nullifier, err := GenerateMembershipProofForSignal(keys, secrets[2], tree.Root, siblings, pathIndices, scope, signal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := ComputeNullifierFor(HashFamilyPoseidon2, secrets[2], scope)
+	if !bytes.Equal(nullifier, want) {
+		t.Fatal("returned nullifier is not the Poseidon2 nullifier")
+	}
+	if err := VerifyMembershipProofForSignal(keys.VerifyingKey, proof, tree.Root, nullifier, scope, signal); err != nil {
+		t.Fatalf("Poseidon2 proof rejected: %v", err)
+	}
+	if err := VerifyMembershipProofForSignal(mimcKeys.VerifyingKey, proof, tree.Root, nullifier, scope, signal); err == nil {
+		t.Fatal("Poseidon2 proof verified under the MiMC key")
+	}
+
+	if _, err := ComputeCommitmentFor("sha256", secrets[0]); err == nil {
+		t.Fatal("unknown hash family accepted")
+	}
+}
+
+func TestDomainSelectsPoseidon2Circuit(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	ctx = ctx.WithBlockHeight(5)
+	admin := sdk.AccAddress("admin1")
+	mimcSecrets := setupDomainWithZKPIdentity(t, k, ctx, "MiMCDomain", 2)
+	k.CreateDomain(ctx, "PoseidonDomain", admin, sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 500_000)))
+
+	options := DomainOptions{AdminElectable: true, AbstentionAllowed: true, ZKPCircuit: Poseidon2MembershipCircuitID}
+	if _, _, _, err := k.ProposeDomainOptionsChange(ctx, "PoseidonDomain", options, admin.String()); err == nil {
+		t.Fatal("circuit without a verifying key selected")
+	}
+	poseidonVK := setTestPoseidon2VerifyingKey(t, k, ctx)
+	if _, _, applied, err := k.ProposeDomainOptionsChange(ctx, "PoseidonDomain", options, admin.String()); err != nil || !applied {
+		t.Fatalf("circuit selection applied=%v err=%v", applied, err)
+	}
+	invalid := options
+	invalid.ZKPCircuit = "truerepublic/membership-vote/v9-unknown"
+	if err := validateDomainOptions(invalid); err == nil {
+		t.Fatal("unsupported circuit accepted")
+	}
+
+	secrets := make([][]byte, 3)
+	var leaves [][]byte
+	for i := range secrets {
+		member := sdk.AccAddress("poseidon-member" + string(rune('A'+i))).String()
+		k.AddMember(ctx, "PoseidonDomain", member, admin)
+		secrets[i] = big.NewInt(int64(i + 900)).Bytes()
+		commitment, err := ComputeCommitmentFor(HashFamilyPoseidon2, secrets[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := k.RegisterIdentityCommitment(ctx, "PoseidonDomain", member, hex.EncodeToString(commitment)); err != nil {
+			t.Fatal(err)
+		}
+		leaves = append(leaves, commitment)
+	}
+	tree, _ := NewMerkleTreeFor(HashFamilyPoseidon2, MerkleTreeDepth)
+	if err := tree.BuildFromLeaves(leaves); err != nil {
+		t.Fatal(err)
+	}
+	domain, _ := k.GetDomainHeader(ctx, "PoseidonDomain")
+	if domain.MerkleRoot != tree.GetRoot() {
+		t.Fatal("stored identity tree is not hashed with Poseidon2")
+	}
+	if _, _, root, err := k.merkleProof(ctx, domainIdentityTree(domain), 1); err != nil || !bytes.Equal(root, tree.Root) {
+		t.Fatalf("Merkle proof root = %x, err %v", root, err)
+	}
+
+	// Once commitments exist the hash family is fixed.
+	back := options
+	back.ZKPCircuit = ""
+	if _, _, _, err := k.ProposeDomainOptionsChange(ctx, "PoseidonDomain", back, admin.String()); err == nil {
+		t.Fatal("hash family changed with registered commitments")
+	}
+
+	// Each domain verifies under the key of its own circuit.
+	addProposal(t, k, ctx, "PoseidonDomain", "Climate", "GreenDeal")
+	addProposal(t, k, ctx, "MiMCDomain", "Climate", "GreenDeal")
+	proof, nullifier := generateZKPRatingWithKeys(t, getTestPoseidon2Keys(t), k, ctx, "PoseidonDomain", secrets, 1, "Climate", "GreenDeal", 2, testRewardRecipient())
+	if _, err := k.RateProposalWithZKP(ctx, "PoseidonDomain", "Climate", "GreenDeal", 2, proof, nullifier, "", testRewardRecipient()); err != nil {
+		t.Fatalf("Poseidon2 rating rejected: %v", err)
+	}
+	mimcProof, mimcNullifier := generateZKPRating(t, k, ctx, "MiMCDomain", mimcSecrets, 0, "Climate", "GreenDeal", 2)
+	if _, err := k.RateProposalWithZKP(ctx, "MiMCDomain", "Climate", "GreenDeal", 2, mimcProof, mimcNullifier, "", testRewardRecipient()); err != nil {
+		t.Fatalf("MiMC rating rejected next to a Poseidon2 domain: %v", err)
+	}
+
+	resp, err := k.ZKPState(ctx, &QueryZKPStateRequest{DomainName: "PoseidonDomain"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state ZKPDomainState
+	if err := json.Unmarshal(resp.Result, &state); err != nil {
+		t.Fatal(err)
+	}
+	if state.ZKPCircuitID != Poseidon2MembershipCircuitID || state.VerifyingKeySHA256 != VerifyingKeyFingerprint(poseidonVK) {
+		t.Fatalf("ZKP state = %+v", state)
+	}
+}
+
+func TestPoseidon2BatchVerificationUsesDomainKey(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	ctx = ctx.WithBlockHeight(5)
+	admin := sdk.AccAddress("admin1")
+	setTestVerifyingKey(t, k, ctx)
+	poseidonVK := setTestPoseidon2VerifyingKey(t, k, ctx)
+	k.CreateDomain(ctx, "BatchPoseidon", admin, sdk.NewCoins(sdk.NewInt64Coin(PNYXDenom, 500_000)))
+	options := DomainOptions{AdminElectable: true, ZKPCircuit: Poseidon2MembershipCircuitID}
+	if _, _, applied, err := k.ProposeDomainOptionsChange(ctx, "BatchPoseidon", options, admin.String()); err != nil || !applied {
+		t.Fatalf("circuit selection applied=%v err=%v", applied, err)
+	}
+	secrets := make([][]byte, 2)
+	for i := range secrets {
+		member := sdk.AccAddress("batch-member" + string(rune('A'+i))).String()
+		k.AddMember(ctx, "BatchPoseidon", member, admin)
+		secrets[i] = big.NewInt(int64(i + 40)).Bytes()
+		commitment, _ := ComputeCommitmentFor(HashFamilyPoseidon2, secrets[i])
+		if err := k.RegisterIdentityCommitment(ctx, "BatchPoseidon", member, hex.EncodeToString(commitment)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	addProposal(t, k, ctx, "BatchPoseidon", "Climate", "GreenDeal")
+
+	var msgs []*MsgRateWithProof
+	for leaf, rating := range []int{1, -3} {
+		proof, nullifier := generateZKPRatingWithKeys(t, getTestPoseidon2Keys(t), k, ctx, "BatchPoseidon", secrets, leaf, "Climate", "GreenDeal", rating, testRewardRecipient())
+		msgs = append(msgs, &MsgRateWithProof{
+			Sender: sdk.AccAddress("relayer"), DomainName: "BatchPoseidon", IssueName: "Climate", SuggestionName: "GreenDeal",
+			Rating: int32(rating), Proof: proof, NullifierHash: nullifier, RewardRecipient: testRewardRecipient(),
+		})
+	}
+	k.batchVerifyRatings(ctx, msgs)
+	for i, msg := range msgs {
+		statement, _ := k.ratingStatement(ctx, msg)
+		if !k.verifiedProofs.contains(ctx.BlockHeight(), verifiedProofKey(poseidonVK, statement)) {
+			t.Fatalf("Poseidon2 rating %d not batch-verified under its domain's key", i)
+		}
+	}
+}
+
+func TestCircuitVerifyingKeysGenesis(t *testing.T) {
+	am, k, ctx := setupModuleForGenesis(t)
+	ctx = ctx.WithBlockHeight(10)
+	mimcVK := setTestVerifyingKey(t, k, ctx)
+	poseidonVK := setTestPoseidon2VerifyingKey(t, k, ctx)
+
+	var genesis GenesisState
+	if err := json.Unmarshal(am.ExportGenesis(ctx, nil), &genesis); err != nil {
+		t.Fatal(err)
+	}
+	if genesis.VerifyingKeySHA256 != VerifyingKeyFingerprint(mimcVK) || len(genesis.CircuitVerifyingKeys) != 1 ||
+		genesis.CircuitVerifyingKeys[0].CircuitID != Poseidon2MembershipCircuitID ||
+		genesis.CircuitVerifyingKeys[0].VerifyingKeySHA256 != VerifyingKeyFingerprint(poseidonVK) {
+		t.Fatalf("exported keys = %s, %+v", genesis.VerifyingKeySHA256, genesis.CircuitVerifyingKeys)
+	}
+	if err := ValidateGenesisState(genesis); err != nil {
+		t.Fatalf("exported genesis invalid: %v", err)
+	}
+	am2, k2, ctx2 := setupModuleForGenesis(t)
+	bz, _ := json.Marshal(genesis)
+	am2.InitGenesis(ctx2, nil, bz)
+	if restored, found := k2.GetFamilyVerifyingKey(ctx2, HashFamilyPoseidon2); !found || !bytes.Equal(restored, poseidonVK) {
+		t.Fatal("Poseidon2 key not restored")
+	}
+	if current, _ := k2.GetVerifyingKey(ctx2); !bytes.Equal(current, mimcVK) {
+		t.Fatal("MiMC key overwritten by the Poseidon2 slot")
+	}
+
+	slot := CircuitVerifyingKey{
+		CircuitID: Poseidon2MembershipCircuitID, VerifyingKeyHex: hex.EncodeToString(poseidonVK),
+		VerifyingKeySHA256: VerifyingKeyFingerprint(poseidonVK),
+	}
+	invalid := map[string]func(*GenesisState){
+		"top-level Poseidon2 key": func(g *GenesisState) {
+			g.ZKPCircuitID, g.VerifyingKeyHex, g.VerifyingKeySHA256 = slot.CircuitID, slot.VerifyingKeyHex, slot.VerifyingKeySHA256
+		},
+		"MiMC circuit in slots": func(g *GenesisState) {
+			g.CircuitVerifyingKeys[0].CircuitID = MembershipCircuitID
+		},
+		"duplicate family": func(g *GenesisState) { g.CircuitVerifyingKeys = append(g.CircuitVerifyingKeys, slot) },
+		"fingerprint mismatch": func(g *GenesisState) {
+			g.CircuitVerifyingKeys[0].VerifyingKeySHA256 = VerifyingKeyFingerprint(mimcVK)
+		},
+		"retiring key of another family": func(g *GenesisState) {
+			g.CircuitVerifyingKeys[0].Retiring = &RetiringVerifyingKey{
+				CircuitID: MembershipCircuitID, VerifyingKeyHex: hex.EncodeToString(mimcVK),
+				VerifyingKeySHA256: VerifyingKeyFingerprint(mimcVK), EndHeight: 20,
+			}
+		},
+	}
+	for name, mutate := range invalid {
+		g := validDemocracyGenesis()
+		g.CircuitVerifyingKeys = []CircuitVerifyingKey{slot}
+		if err := ValidateGenesisState(g); err != nil {
+			t.Fatalf("baseline genesis invalid: %v", err)
+		}
+		mutate(&g)
+		if err := ValidateGenesisState(g); err == nil {
+			t.Fatalf("%s accepted", name)
+		}
+	}
+}
@@ -186,7 +186,12 @@ func generateZKPRating(t *testing.T, k Keeper, ctx sdk.Context, domainName strin
 // covers the given canonical reward recipient.
 func generateZKPRatingForRecipient(t *testing.T, k Keeper, ctx sdk.Context, domainName string, secrets [][]byte, memberIndex int, issueName, suggestionName string, rating int, rewardRecipient string) (string, string) {
 	t.Helper()
-	keys := getTestZKPKeys(t)
+	return generateZKPRatingWithKeys(t, getTestZKPKeys(t), k, ctx, domainName, secrets, memberIndex, issueName, suggestionName, rating, rewardRecipient)
+}
+
+// generateZKPRatingWithKeys proves a v2 rating with the given proving key.
+func generateZKPRatingWithKeys(t *testing.T, keys *ZKPKeys, k Keeper, ctx sdk.Context, domainName string, secrets [][]byte, memberIndex int, issueName, suggestionName string, rating int, rewardRecipient string) (string, string) {
+	t.Helper()
 
 	// Rebuild tree from domain's commitments.
 	domain, _ := k.GetDomain(ctx, domainName)
@@ -195,7 +200,10 @@ func generateZKPRatingForRecipient(t *testing.T, k Keeper, ctx sdk.Context, doma
 		b, _ := hex.DecodeString(h)
 		commitments[i] = b
 	}
-	tree := NewMerkleTree(MerkleTreeDepth)
+	tree, err := NewMerkleTreeFor(domain.Options.hashFamily(), MerkleTreeDepth)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if err := tree.BuildFromLeaves(commitments); err != nil {
 		t.Fatalf("BuildFromLeaves failed: %v", err)
 	}
@@ -1,4 +1,4 @@
-// Package zkpcircuit defines the versioned membership-vote circuit shared by
+// Package zkpcircuit defines the versioned membership-vote circuits shared by
 // the chain verifier and the isolated test-only maintained-client prover.
 package zkpcircuit
 
@@ -0,0 +1,67 @@
+package zkpcircuit
+
+import (
+	"fmt"
+
+	bn254poseidon2 "github.com/consensys/gnark-crypto/ecc/bn254/fr/poseidon2"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/permutation/poseidon2"
+)
+
+// Poseidon2ID identifies the Poseidon2 variant of the membership-vote
+// circuit. It has the same witness layout, depth and public inputs as ID but
+// hashes commitments, tree nodes and nullifiers with the Poseidon2
+// Merkle-Damgård hasher, which needs far fewer constraints than MiMC.
+const Poseidon2ID = "truerepublic/membership-vote/v3-bn254-poseidon2-depth20"
+
+// Poseidon2MembershipCircuit is MembershipCircuit with Poseidon2 hashing.
+// Assignments convert directly between the two types.
+type Poseidon2MembershipCircuit MembershipCircuit
+
+// newPoseidon2Hasher returns the in-circuit Merkle-Damgård hasher matching the
+// native BN254 Poseidon2 hasher: its default width and round counts and a zero
+// IV. gnark ships defaults only for BLS12-377, so the parameters are taken
+// from gnark-crypto explicitly.
+func newPoseidon2Hasher(api frontend.API) (hash.FieldHasher, error) {
+	params := bn254poseidon2.GetDefaultParameters()
+	permutation, err := poseidon2.NewPoseidon2FromParameters(api, params.Width, params.NbFullRounds, params.NbPartialRounds)
+	if err != nil {
+		return nil, err
+	}
+	return hash.NewMerkleDamgardHasher(api, permutation, 0), nil
+}
+
+// Define implements frontend.Circuit.
+func (c *Poseidon2MembershipCircuit) Define(api frontend.API) error {
+	commitHasher, err := newPoseidon2Hasher(api)
+	if err != nil {
+		return fmt.Errorf("poseidon2 init for commitment: %w", err)
+	}
+	commitHasher.Write(c.IdentitySecret)
+	commitment := commitHasher.Sum()
+
+	currentHash := commitment
+	for i := 0; i < MerkleDepth; i++ {
+		api.AssertIsBoolean(c.PathIndices[i])
+		left := api.Select(c.PathIndices[i], c.Siblings[i], currentHash)
+		right := api.Select(c.PathIndices[i], currentHash, c.Siblings[i])
+
+		levelHasher, err := newPoseidon2Hasher(api)
+		if err != nil {
+			return fmt.Errorf("poseidon2 init for level %d: %w", i, err)
+		}
+		levelHasher.Write(left, right)
+		currentHash = levelHasher.Sum()
+	}
+	api.AssertIsEqual(currentHash, c.MerkleRoot)
+
+	nullifierHasher, err := newPoseidon2Hasher(api)
+	if err != nil {
+		return fmt.Errorf("poseidon2 init for nullifier: %w", err)
+	}
+	nullifierHasher.Write(c.IdentitySecret, c.ExternalNullifier)
+	api.AssertIsEqual(nullifierHasher.Sum(), c.NullifierHash)
+	api.AssertIsDifferent(c.SignalHash, 0)
+	return nil
+}
@@ -0,0 +1,273 @@
+// Package zkpceremony runs the multi-party Groth16 trusted setup of the
+// membership circuit on top of gnark's BN254 MPC setup. Phase 1 (powers of
+// tau) depends only on the circuit's FFT domain size; Phase 2 specialises the
+// sealed Phase 1 parameters to the circuit. Every contribution proves that it
+// extends the previous one, and each phase is sealed with a public random
+// beacon, so the keys are sound as long as one contributor discarded their
+// randomness.
+package zkpceremony
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+
+	"truerepublic/x/truedemocracy"
+	"truerepublic/x/truedemocracy/zkpcircuit"
+)
+
+// MinBeaconBytes is the least public beacon entropy accepted when sealing a
+// phase.
+const MinBeaconBytes = 32
+
+// Artifacts are the finalized ceremony outputs. VerifyingKeyHex and
+// VerifyingKeySHA256 go into the truedemocracy genesis; provers use the
+// constraint system and proving key.
+type Artifacts struct {
+	ConstraintSystem   []byte
+	ProvingKey         []byte
+	VerifyingKey       []byte
+	VerifyingKeySHA256 string
+}
+
+// GenesisFields is the truedemocracy genesis excerpt that pins the ceremony
+// output. Its JSON names match GenesisState.
+type GenesisFields struct {
+	ZKPCircuitID       string `json:"zkp_circuit_id"`
+	VerifyingKeyHex    string `json:"verifying_key_hex"`
+	VerifyingKeySHA256 string `json:"verifying_key_sha256"`
+}
+
+// GenesisFields returns the genesis excerpt for the artifacts.
+func (a Artifacts) GenesisFields() GenesisFields {
+	return GenesisFields{
+		ZKPCircuitID:       zkpcircuit.ID,
+		VerifyingKeyHex:    hex.EncodeToString(a.VerifyingKey),
+		VerifyingKeySHA256: a.VerifyingKeySHA256,
+	}
+}
+
+// CompileMembershipCircuit compiles the frozen membership circuit.
+func CompileMembershipCircuit() (*cs.R1CS, error) {
+	var circuit zkpcircuit.MembershipCircuit
+	compiled, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		return nil, fmt.Errorf("compile membership circuit: %w", err)
+	}
+	return compiled.(*cs.R1CS), nil
+}
+
+// DomainSize is the Phase 1 size a circuit needs.
+func DomainSize(r1cs *cs.R1CS) uint64 {
+	return ecc.NextPowerOfTwo(uint64(r1cs.GetNbConstraints()))
+}
+
+// Digest is the SHA-256 of a contribution or artifact, published by each
+// participant so the transcript can be matched against what they signed off.
+func Digest(data []byte) string {
+	digest := sha256.Sum256(data)
+	return hex.EncodeToString(digest[:])
+}
+
+// ContributePhase1 adds fresh randomness on top of previous, or starts the
+// Phase 1 transcript for domainSize when previous is empty.
+func ContributePhase1(previous []byte, domainSize uint64) ([]byte, error) {
+	var phase1 *mpcsetup.Phase1
+	if len(previous) == 0 {
+		if domainSize == 0 || ecc.NextPowerOfTwo(domainSize) != domainSize {
+			return nil, fmt.Errorf("phase 1 domain size %d is not a power of two", domainSize)
+		}
+		phase1 = mpcsetup.NewPhase1(domainSize)
+	} else {
+		phase1 = new(mpcsetup.Phase1)
+		if err := readExact("phase 1 contribution", previous, phase1); err != nil {
+			return nil, err
+		}
+	}
+	phase1.Contribute()
+	return encode(phase1)
+}
+
+// SealPhase1 verifies the ordered Phase 1 contributions from the initial
+// parameters of domainSize and seals them with the beacon. The result is the
+// SRS Phase 2 starts from.
+func SealPhase1(domainSize uint64, contributions [][]byte, beacon []byte) ([]byte, error) {
+	if err := checkBeacon(beacon); err != nil {
+		return nil, err
+	}
+	if len(contributions) == 0 {
+		return nil, fmt.Errorf("phase 1 needs at least one contribution")
+	}
+	if domainSize == 0 || ecc.NextPowerOfTwo(domainSize) != domainSize {
+		return nil, fmt.Errorf("phase 1 domain size %d is not a power of two", domainSize)
+	}
+	chain := make([]*mpcsetup.Phase1, len(contributions))
+	for i, contribution := range contributions {
+		chain[i] = new(mpcsetup.Phase1)
+		if err := readExact(fmt.Sprintf("phase 1 contribution %d", i+1), contribution, chain[i]); err != nil {
+			return nil, err
+		}
+	}
+	commons, err := mpcsetup.VerifyPhase1(domainSize, beacon, chain...)
+	if err != nil {
+		return nil, fmt.Errorf("phase 1 transcript: %w", err)
+	}
+	return encode(&commons)
+}
+
+// ContributePhase2 adds fresh randomness on top of previous, or starts the
+// Phase 2 transcript of r1cs from the sealed SRS when previous is empty.
+func ContributePhase2(r1cs *cs.R1CS, srs, previous []byte) ([]byte, error) {
+	phase2 := new(mpcsetup.Phase2)
+	if len(previous) == 0 {
+		commons, err := decodeSRS(r1cs, srs)
+		if err != nil {
+			return nil, err
+		}
+		phase2.Initialize(r1cs, commons)
+	} else if err := readExact("phase 2 contribution", previous, phase2); err != nil {
+		return nil, err
+	}
+	phase2.Contribute()
+	return encode(phase2)
+}
+
+// FinalizePhase2 verifies the ordered Phase 2 contributions against the SRS,
+// seals them with the beacon and exports the keys. The verifying key must
+// pass truedemocracy.ValidateMembershipVerifyingKey, and a proof made with
+// the proving key must verify under it.
+func FinalizePhase2(r1cs *cs.R1CS, srs []byte, contributions [][]byte, beacon []byte) (Artifacts, error) {
+	pk, vk, err := sealPhase2(r1cs, srs, contributions, beacon)
+	if err != nil {
+		return Artifacts{}, err
+	}
+
+	var artifacts Artifacts
+	if artifacts.ConstraintSystem, err = encode(r1cs); err != nil {
+		return Artifacts{}, err
+	}
+	if artifacts.ProvingKey, err = encode(pk); err != nil {
+		return Artifacts{}, err
+	}
+	if artifacts.VerifyingKey, err = truedemocracy.SerializeVerifyingKey(vk); err != nil {
+		return Artifacts{}, err
+	}
+	artifacts.VerifyingKeySHA256 = truedemocracy.VerifyingKeyFingerprint(artifacts.VerifyingKey)
+	if _, err := truedemocracy.ValidateMembershipVerifyingKey(artifacts.VerifyingKey, zkpcircuit.ID, artifacts.VerifyingKeySHA256); err != nil {
+		return Artifacts{}, fmt.Errorf("ceremony verifying key: %w", err)
+	}
+	if err := selfTest(r1cs, pk, vk); err != nil {
+		return Artifacts{}, err
+	}
+	return artifacts, nil
+}
+
+// sealPhase2 verifies the Phase 2 transcript of any circuit and seals it.
+func sealPhase2(r1cs *cs.R1CS, srs []byte, contributions [][]byte, beacon []byte) (groth16.ProvingKey, groth16.VerifyingKey, error) {
+	if err := checkBeacon(beacon); err != nil {
+		return nil, nil, err
+	}
+	if len(contributions) == 0 {
+		return nil, nil, fmt.Errorf("phase 2 needs at least one contribution")
+	}
+	commons, err := decodeSRS(r1cs, srs)
+	if err != nil {
+		return nil, nil, err
+	}
+	chain := make([]*mpcsetup.Phase2, len(contributions))
+	for i, contribution := range contributions {
+		chain[i] = new(mpcsetup.Phase2)
+		if err := readExact(fmt.Sprintf("phase 2 contribution %d", i+1), contribution, chain[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	pk, vk, err := mpcsetup.VerifyPhase2(r1cs, commons, beacon, chain...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("phase 2 transcript: %w", err)
+	}
+	return pk, vk, nil
+}
+
+// selfTest proves one synthetic membership and verifies it, so keys from a
+// transcript for another circuit are never exported.
+func selfTest(r1cs *cs.R1CS, pk groth16.ProvingKey, vk groth16.VerifyingKey) error {
+	secret := big.NewInt(1).Bytes()
+	leaf, err := truedemocracy.ComputeCommitment(secret)
+	if err != nil {
+		return err
+	}
+	tree := truedemocracy.NewMerkleTree(truedemocracy.MerkleTreeDepth)
+	if err := tree.BuildFromLeaves([][]byte{leaf}); err != nil {
+		return err
+	}
+	siblings, pathIndices, err := tree.GenerateProof(0)
+	if err != nil {
+		return err
+	}
+	scope, err := truedemocracy.ComputeExternalNullifier("truerepublic/zkp-ceremony/self-test")
+	if err != nil {
+		return err
+	}
+	keys := &truedemocracy.ZKPKeys{ProvingKey: pk, VerifyingKey: vk, CS: r1cs}
+	proof, nullifier, err := truedemocracy.GenerateMembershipProof(keys, secret, tree.Root, siblings, pathIndices, scope)
+	if err != nil {
+		return fmt.Errorf("ceremony self-test proof: %w", err)
+	}
+	if err := truedemocracy.VerifyMembershipProof(vk, proof, tree.Root, nullifier, scope); err != nil {
+		return fmt.Errorf("ceremony self-test verification: %w", err)
+	}
+	return nil
+}
+
+func decodeSRS(r1cs *cs.R1CS, srs []byte) (*mpcsetup.SrsCommons, error) {
+	commons := new(mpcsetup.SrsCommons)
+	if err := readExact("phase 1 SRS", srs, commons); err != nil {
+		return nil, err
+	}
+	if got, want := uint64(len(commons.G1.AlphaTau)), DomainSize(r1cs); got != want {
+		return nil, fmt.Errorf("phase 1 SRS domain size %d, circuit needs %d", got, want)
+	}
+	return commons, nil
+}
+
+func checkBeacon(beacon []byte) error {
+	if len(beacon) < MinBeaconBytes {
+		return fmt.Errorf("beacon must contain at least %d bytes", MinBeaconBytes)
+	}
+	return nil
+}
+
+type codec interface {
+	io.WriterTo
+	io.ReaderFrom
+}
+
+func encode(value io.WriterTo) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := value.WriteTo(&buf); err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// readExact decodes data into target and rejects trailing bytes.
+func readExact(label string, data []byte, target codec) error {
+	reader := bytes.NewReader(data)
+	if _, err := target.ReadFrom(reader); err != nil {
+		return fmt.Errorf("decode %s: %w", label, err)
+	}
+	if reader.Len() != 0 {
+		return fmt.Errorf("decode %s: %d trailing bytes", label, reader.Len())
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package zkpceremony
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+
+	"truerepublic/x/truedemocracy"
+)
+
+const fullCeremonyEnv = "TRUEREPUBLIC_ZKP_CEREMONY_FULL"
+
+var testBeacon = bytes.Repeat([]byte{0xab}, MinBeaconBytes)
+
+// squareCircuit keeps transcript tests fast; the membership circuit needs a
+// 2^14 domain.
+type squareCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *squareCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+func compileSquare(t *testing.T) *cs.R1CS {
+	t.Helper()
+	compiled, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &squareCircuit{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return compiled.(*cs.R1CS)
+}
+
+// runTranscript contributes n times to each phase and returns the SRS and the
+// Phase 2 contributions.
+func runTranscript(t *testing.T, circuit *cs.R1CS, n int) ([][]byte, []byte, [][]byte) {
+	t.Helper()
+	var phase1 [][]byte
+	var previous []byte
+	for i := 0; i < n; i++ {
+		next, err := ContributePhase1(previous, DomainSize(circuit))
+		if err != nil {
+			t.Fatal(err)
+		}
+		phase1, previous = append(phase1, next), next
+	}
+	srs, err := SealPhase1(DomainSize(circuit), phase1, testBeacon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var phase2 [][]byte
+	previous = nil
+	for i := 0; i < n; i++ {
+		next, err := ContributePhase2(circuit, srs, previous)
+		if err != nil {
+			t.Fatal(err)
+		}
+		phase2, previous = append(phase2, next), next
+	}
+	return phase1, srs, phase2
+}
+
+func TestCeremonyKeysProveAndVerify(t *testing.T) {
+	circuit := compileSquare(t)
+	_, srs, phase2 := runTranscript(t, circuit, 3)
+	pk, vk, err := sealPhase2(circuit, srs, phase2, testBeacon)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	witness, err := frontend.NewWitness(&squareCircuit{X: 3, Y: 9}, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := groth16.Prove(circuit, pk, witness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	public, _ := witness.Public()
+	if err := groth16.Verify(proof, vk, public); err != nil {
+		t.Fatalf("ceremony keys do not verify their own proof: %v", err)
+	}
+	wrong, _ := frontend.NewWitness(&squareCircuit{Y: 10}, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	if err := groth16.Verify(proof, vk, wrong); err == nil {
+		t.Fatal("proof verified for a different public input")
+	}
+}
+
+func TestCeremonySealIsReproducible(t *testing.T) {
+	circuit := compileSquare(t)
+	phase1, srs, phase2 := runTranscript(t, circuit, 2)
+	again, err := SealPhase1(DomainSize(circuit), phase1, testBeacon)
+	if err != nil || !bytes.Equal(again, srs) {
+		t.Fatalf("re-sealing phase 1 gave a different SRS (err=%v)", err)
+	}
+	_, first, err := sealPhase2(circuit, srs, phase2, testBeacon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, second, err := sealPhase2(circuit, srs, phase2, testBeacon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ := truedemocracy.SerializeVerifyingKey(first)
+	b, _ := truedemocracy.SerializeVerifyingKey(second)
+	if !bytes.Equal(a, b) {
+		t.Fatal("independent verifiers derived different verifying keys")
+	}
+	otherBeacon := bytes.Repeat([]byte{0xcd}, MinBeaconBytes)
+	_, third, err := sealPhase2(circuit, srs, phase2, otherBeacon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := truedemocracy.SerializeVerifyingKey(third); bytes.Equal(a, c) {
+		t.Fatal("beacon did not affect the verifying key")
+	}
+}
+
+func TestCeremonyRejectsBrokenTranscript(t *testing.T) {
+	circuit := compileSquare(t)
+	size := DomainSize(circuit)
+	phase1, srs, phase2 := runTranscript(t, circuit, 3)
+	fork, err := ContributePhase2(circuit, srs, phase2[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	phase1Cases := []struct {
+		name          string
+		contributions [][]byte
+		beacon        []byte
+	}{
+		{"reordered", [][]byte{phase1[1], phase1[0], phase1[2]}, testBeacon},
+		{"missing first", phase1[1:], testBeacon},
+		{"dropped middle", [][]byte{phase1[0], phase1[2]}, testBeacon},
+		{"trailing bytes", [][]byte{phase1[0], append(append([]byte{}, phase1[1]...), 0)}, testBeacon},
+		{"empty", nil, testBeacon},
+		{"short beacon", phase1, testBeacon[:MinBeaconBytes-1]},
+	}
+	for _, tc := range phase1Cases {
+		t.Run("phase1 "+tc.name, func(t *testing.T) {
+			if _, err := SealPhase1(size, tc.contributions, tc.beacon); err == nil {
+				t.Fatal("broken phase 1 transcript sealed")
+			}
+		})
+	}
+
+	phase2Cases := []struct {
+		name          string
+		contributions [][]byte
+		beacon        []byte
+	}{
+		{"reordered", [][]byte{phase2[1], phase2[0], phase2[2]}, testBeacon},
+		{"missing first", phase2[1:], testBeacon},
+		{"forked branch", [][]byte{phase2[0], phase2[1], fork}, testBeacon},
+		{"empty", nil, testBeacon},
+		{"short beacon", phase2, nil},
+	}
+	for _, tc := range phase2Cases {
+		t.Run("phase2 "+tc.name, func(t *testing.T) {
+			if _, _, err := sealPhase2(circuit, srs, tc.contributions, tc.beacon); err == nil {
+				t.Fatal("broken phase 2 transcript sealed")
+			}
+		})
+	}
+
+	// Phase 2 must start from an SRS of the circuit's domain size.
+	small, _, _ := runTranscript(t, circuit, 1)
+	bigger, err := ContributePhase1(nil, size*2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrongSRS, err := SealPhase1(size*2, [][]byte{bigger}, testBeacon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ContributePhase2(circuit, wrongSRS, nil); err == nil {
+		t.Fatal("phase 2 started from an SRS of the wrong domain size")
+	}
+	if _, err := SealPhase1(size, small, testBeacon); err != nil {
+		t.Fatalf("valid single-contribution transcript rejected: %v", err)
+	}
+}
+
+// TestMembershipCeremonyGenesis runs the whole ceremony on the membership
+// circuit through the command, which takes minutes.
+func TestMembershipCeremonyGenesis(t *testing.T) {
+	if testing.Short() || strings.TrimSpace(os.Getenv(fullCeremonyEnv)) != "1" {
+		t.Skipf("set %s=1 to run the membership-circuit ceremony", fullCeremonyEnv)
+	}
+	dir := t.TempDir()
+	path := func(name string) string { return filepath.Join(dir, name) }
+	beacon := strings.Repeat("ab", MinBeaconBytes)
+	for _, args := range [][]string{
+		{"phase1", "contribute", "--out", path("p1a")},
+		{"phase1", "contribute", "--in", path("p1a"), "--out", path("p1b")},
+		{"phase1", "seal", "--contribution", path("p1a"), "--contribution", path("p1b"), "--beacon", beacon, "--out", path("srs")},
+		{"phase2", "contribute", "--srs", path("srs"), "--out", path("p2a")},
+		{"phase2", "contribute", "--in", path("p2a"), "--out", path("p2b")},
+		{"phase2", "finalize", "--srs", path("srs"), "--contribution", path("p2a") + "," + path("p2b"), "--beacon", beacon, "--out-dir", path("out")},
+	} {
+		cmd := NewCommand()
+		cmd.SetArgs(args)
+		cmd.SetOut(&bytes.Buffer{})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(path("out"), GenesisFieldsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var genesis truedemocracy.GenesisState
+	if err := json.Unmarshal(data, &genesis); err != nil {
+		t.Fatal(err)
+	}
+	if err := truedemocracy.ValidateGenesisState(genesis); err != nil {
+		t.Fatalf("ceremony output rejected by genesis validation: %v", err)
+	}
+	vk, _ := os.ReadFile(filepath.Join(path("out"), VerifyingKeyFile))
+	if Digest(vk) != genesis.VerifyingKeySHA256 {
+		t.Fatal("exported verifying key differs from the genesis fingerprint")
+	}
+}
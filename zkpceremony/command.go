@@ -0,0 +1,281 @@
+package zkpceremony
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// Output file names written by `phase2 finalize`.
+const (
+	ConstraintSystemFile = "membership.r1cs"
+	ProvingKeyFile       = "membership.pk"
+	VerifyingKeyFile     = "membership.vk"
+	GenesisFieldsFile    = "zkp_genesis.json"
+)
+
+// NewCommand builds the `zkp-ceremony` command group. Participants run
+// `contribute` in turn, each passing the previous participant's file; the
+// coordinator seals Phase 1 and finalizes Phase 2 with a public beacon that
+// was unknown when the last contribution was made. Anyone can re-run `seal`
+// and `verify` on the published transcript and must obtain the same SRS and
+// verifying-key fingerprint.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "zkp-ceremony",
+		Short: "Multi-party Groth16 trusted setup for the membership circuit",
+	}
+	phase1 := &cobra.Command{Use: "phase1", Short: "Circuit-independent powers of tau"}
+	phase1.AddCommand(newPhase1ContributeCommand(), newPhase1SealCommand())
+	phase2 := &cobra.Command{Use: "phase2", Short: "Membership-circuit specific parameters"}
+	phase2.AddCommand(newPhase2ContributeCommand(), newPhase2VerifyCommand(), newPhase2FinalizeCommand())
+	cmd.AddCommand(phase1, phase2)
+	return cmd
+}
+
+func newPhase1ContributeCommand() *cobra.Command {
+	var in, out string
+	cmd := &cobra.Command{
+		Use:          "contribute",
+		Short:        "Add a Phase 1 contribution (omit --in to start the transcript)",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if out == "" {
+				return fmt.Errorf("--out is required")
+			}
+			previous, err := readOptional(in)
+			if err != nil {
+				return err
+			}
+			r1cs, err := CompileMembershipCircuit()
+			if err != nil {
+				return err
+			}
+			next, err := ContributePhase1(previous, DomainSize(r1cs))
+			if err != nil {
+				return err
+			}
+			return writeContribution(cmd, out, next)
+		},
+	}
+	cmd.Flags().StringVar(&in, "in", "", "previous Phase 1 contribution")
+	cmd.Flags().StringVar(&out, "out", "", "file for the new contribution")
+	return cmd
+}
+
+func newPhase1SealCommand() *cobra.Command {
+	var contributions []string
+	var beacon, out string
+	cmd := &cobra.Command{
+		Use:          "seal",
+		Short:        "Verify the Phase 1 transcript and seal it into the SRS",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if out == "" {
+				return fmt.Errorf("--out is required")
+			}
+			chain, beaconBytes, err := readTranscript(contributions, beacon)
+			if err != nil {
+				return err
+			}
+			r1cs, err := CompileMembershipCircuit()
+			if err != nil {
+				return err
+			}
+			srs, err := SealPhase1(DomainSize(r1cs), chain, beaconBytes)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(out, srs, 0o644); err != nil {
+				return fmt.Errorf("write SRS: %w", err)
+			}
+			cmd.Printf("phase 1 SRS %s sha256 %s\n", out, Digest(srs))
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVar(&contributions, "contribution", nil, "Phase 1 contributions in order (repeatable)")
+	cmd.Flags().StringVar(&beacon, "beacon", "", "public random beacon (hex, at least 32 bytes)")
+	cmd.Flags().StringVar(&out, "out", "", "file for the sealed SRS")
+	return cmd
+}
+
+func newPhase2ContributeCommand() *cobra.Command {
+	var srsPath, in, out string
+	cmd := &cobra.Command{
+		Use:          "contribute",
+		Short:        "Add a Phase 2 contribution (omit --in to start the transcript)",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if out == "" {
+				return fmt.Errorf("--out is required")
+			}
+			previous, err := readOptional(in)
+			if err != nil {
+				return err
+			}
+			var srs []byte
+			if len(previous) == 0 {
+				if srsPath == "" {
+					return fmt.Errorf("--srs is required for the first Phase 2 contribution")
+				}
+				if srs, err = os.ReadFile(srsPath); err != nil {
+					return fmt.Errorf("read SRS: %w", err)
+				}
+			}
+			r1cs, err := CompileMembershipCircuit()
+			if err != nil {
+				return err
+			}
+			next, err := ContributePhase2(r1cs, srs, previous)
+			if err != nil {
+				return err
+			}
+			return writeContribution(cmd, out, next)
+		},
+	}
+	cmd.Flags().StringVar(&srsPath, "srs", "", "sealed Phase 1 SRS (first contribution only)")
+	cmd.Flags().StringVar(&in, "in", "", "previous Phase 2 contribution")
+	cmd.Flags().StringVar(&out, "out", "", "file for the new contribution")
+	return cmd
+}
+
+// phase2Flags are shared by verify and finalize, which run the same checks.
+type phase2Flags struct {
+	srs           string
+	contributions []string
+	beacon        string
+}
+
+func (f *phase2Flags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.srs, "srs", "", "sealed Phase 1 SRS")
+	cmd.Flags().StringSliceVar(&f.contributions, "contribution", nil, "Phase 2 contributions in order (repeatable)")
+	cmd.Flags().StringVar(&f.beacon, "beacon", "", "public random beacon (hex, at least 32 bytes)")
+}
+
+func (f *phase2Flags) finalize() (Artifacts, error) {
+	if f.srs == "" {
+		return Artifacts{}, fmt.Errorf("--srs is required")
+	}
+	srs, err := os.ReadFile(f.srs)
+	if err != nil {
+		return Artifacts{}, fmt.Errorf("read SRS: %w", err)
+	}
+	chain, beacon, err := readTranscript(f.contributions, f.beacon)
+	if err != nil {
+		return Artifacts{}, err
+	}
+	r1cs, err := CompileMembershipCircuit()
+	if err != nil {
+		return Artifacts{}, err
+	}
+	return FinalizePhase2(r1cs, srs, chain, beacon)
+}
+
+func newPhase2VerifyCommand() *cobra.Command {
+	var flags phase2Flags
+	cmd := &cobra.Command{
+		Use:          "verify",
+		Short:        "Verify the Phase 2 transcript and print the verifying-key fingerprint",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			artifacts, err := flags.finalize()
+			if err != nil {
+				return err
+			}
+			cmd.Printf("verifying key sha256 %s\n", artifacts.VerifyingKeySHA256)
+			return nil
+		},
+	}
+	flags.register(cmd)
+	return cmd
+}
+
+func newPhase2FinalizeCommand() *cobra.Command {
+	var flags phase2Flags
+	var outDir string
+	cmd := &cobra.Command{
+		Use:          "finalize",
+		Short:        "Verify and seal Phase 2, then export the keys and genesis fields",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if outDir == "" {
+				return fmt.Errorf("--out-dir is required")
+			}
+			artifacts, err := flags.finalize()
+			if err != nil {
+				return err
+			}
+			genesis, err := json.MarshalIndent(artifacts.GenesisFields(), "", "  ")
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(outDir, 0o755); err != nil {
+				return fmt.Errorf("create output directory: %w", err)
+			}
+			for name, data := range map[string][]byte{
+				ConstraintSystemFile: artifacts.ConstraintSystem,
+				ProvingKeyFile:       artifacts.ProvingKey,
+				VerifyingKeyFile:     artifacts.VerifyingKey,
+				GenesisFieldsFile:    append(genesis, '\n'),
+			} {
+				if err := os.WriteFile(filepath.Join(outDir, name), data, 0o644); err != nil {
+					return fmt.Errorf("write %s: %w", name, err)
+				}
+			}
+			cmd.Printf("verifying key sha256 %s\n", artifacts.VerifyingKeySHA256)
+			cmd.Printf("proving key sha256 %s\n", Digest(artifacts.ProvingKey))
+			return nil
+		},
+	}
+	flags.register(cmd)
+	cmd.Flags().StringVar(&outDir, "out-dir", "", "directory for the keys and genesis fields")
+	return cmd
+}
+
+func readOptional(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read previous contribution: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("previous contribution %s is empty", path)
+	}
+	return data, nil
+}
+
+func readTranscript(paths []string, beaconHex string) ([][]byte, []byte, error) {
+	beacon, err := hex.DecodeString(beaconHex)
+	if err != nil {
+		return nil, nil, fmt.Errorf("--beacon must be hex: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, nil, fmt.Errorf("at least one --contribution is required")
+	}
+	chain := make([][]byte, len(paths))
+	for i, path := range paths {
+		if chain[i], err = os.ReadFile(path); err != nil {
+			return nil, nil, fmt.Errorf("read contribution %d: %w", i+1, err)
+		}
+	}
+	return chain, beacon, nil
+}
+
+func writeContribution(cmd *cobra.Command, path string, data []byte) error {
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write contribution: %w", err)
+	}
+	cmd.Printf("contribution %s sha256 %s\n", path, Digest(data))
+	return nil
+}
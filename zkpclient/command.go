@@ -0,0 +1,220 @@
+// Package zkpclient is the member-side prover for anonymous ratings. It reads
+// the identity secret from a file or stdin, fetches the domain Merkle path
+// from a node, proves membership with the ceremony keys through
+// internal/zkpprover and emits a MsgRateWithProof for the relaying account to
+// sign.
+package zkpclient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/spf13/cobra"
+
+	"truerepublic/x/truedemocracy"
+	"truerepublic/zkpceremony"
+)
+
+const (
+	flagSecretFile      = "secret-file"
+	flagKeysDir         = "keys-dir"
+	flagRewardRecipient = "reward-recipient"
+
+	// maxSecretFileBytes bounds the secret input: 64 hex characters and a
+	// line ending.
+	maxSecretFileBytes = 128
+)
+
+// NewCommand builds the `zkp` command group.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "zkp",
+		Short: "Anonymous membership proofs",
+	}
+	cmd.AddCommand(newProveCommand())
+	return cmd
+}
+
+func newProveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prove [domain] [issue] [suggestion] [rating]",
+		Short: "Prove membership and rate a suggestion anonymously",
+		Long: `Prove membership in a domain and emit a MsgRateWithProof for the rating.
+
+The identity secret is read as lowercase hex from --secret-file, or from stdin
+when the file is "-"; it is never accepted as an argument. The Merkle path
+comes from the node's MerkleProof query, and the proof is made with the
+ceremony keys in --keys-dir, which must match the chain's verifying key.
+Sign with an account that is not linked to your membership, or pass
+--generate-only to hand the unsigned transaction to a relayer.`,
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			rating, err := strconv.ParseInt(args[3], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid rating: %w", err)
+			}
+			secretPath, _ := cmd.Flags().GetString(flagSecretFile)
+			keysDir, _ := cmd.Flags().GetString(flagKeysDir)
+			recipient, _ := cmd.Flags().GetString(flagRewardRecipient)
+			if keysDir == "" {
+				return fmt.Errorf("--%s is required", flagKeysDir)
+			}
+			keys, err := readKeys(keysDir)
+			if err != nil {
+				return err
+			}
+			secret, err := readIdentitySecret(cmd.InOrStdin(), secretPath)
+			if err != nil {
+				return err
+			}
+			defer clear(secret)
+
+			vote := RatingVote{
+				ChainID:         clientCtx.ChainID,
+				DomainName:      args[0],
+				IssueName:       args[1],
+				SuggestionName:  args[2],
+				Rating:          int(rating),
+				RewardRecipient: recipient,
+			}
+			if err := vote.validate(); err != nil {
+				return err
+			}
+			path, vkSHA256, err := fetchMembership(cmd, clientCtx, vote.DomainName, secret, keys.VerifyingKey)
+			if err != nil {
+				return err
+			}
+			msg, err := ProveRating(keys, vkSHA256, secret, path, vote)
+			if err != nil {
+				return err
+			}
+			msg.Sender = clientCtx.GetFromAddress()
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	cmd.Flags().String(flagSecretFile, "", `file holding the identity secret as hex, or "-" for stdin`)
+	cmd.Flags().String(flagKeysDir, "", "directory with the membership.r1cs, membership.pk and membership.vk ceremony outputs")
+	cmd.Flags().String(flagRewardRecipient, "", "bech32 account bound into the proof that receives the rating reward")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// fetchMembership looks up the Merkle path of the secret's commitment and the
+// fingerprint of the chain verifying key to prove for: the current key, or the
+// retiring key during a rotation when the local keys are still the old ones.
+func fetchMembership(cmd *cobra.Command, clientCtx client.Context, domainName string, secret, localVK []byte) (truedemocracy.MerkleProofResult, string, error) {
+	commitment, err := truedemocracy.ComputeCommitment(secret)
+	if err != nil {
+		return truedemocracy.MerkleProofResult{}, "", err
+	}
+	queryClient := truedemocracy.NewQueryClient(clientCtx)
+	stateResp, err := queryClient.ZKPState(cmd.Context(), &truedemocracy.QueryZKPStateRequest{DomainName: domainName})
+	if err != nil {
+		return truedemocracy.MerkleProofResult{}, "", err
+	}
+	var state truedemocracy.ZKPDomainState
+	if err := json.Unmarshal(stateResp.Result, &state); err != nil {
+		return truedemocracy.MerkleProofResult{}, "", fmt.Errorf("decode ZKP state: %w", err)
+	}
+	if !state.VKInitialized || state.VerifyingKeySHA256 == "" {
+		return truedemocracy.MerkleProofResult{}, "", fmt.Errorf("chain has no membership verifying key")
+	}
+	if state.ZKPCircuitID != "" && state.ZKPCircuitID != truedemocracy.MembershipCircuitID {
+		return truedemocracy.MerkleProofResult{}, "", fmt.Errorf("domain %s uses circuit %s, which this prover does not support", domainName, state.ZKPCircuitID)
+	}
+	proofResp, err := queryClient.MerkleProof(cmd.Context(), &truedemocracy.QueryMerkleProofRequest{
+		DomainName: domainName,
+		Commitment: hex.EncodeToString(commitment),
+	})
+	if err != nil {
+		return truedemocracy.MerkleProofResult{}, "", fmt.Errorf("identity commitment lookup: %w", err)
+	}
+	var path truedemocracy.MerkleProofResult
+	if err := json.Unmarshal(proofResp.Result, &path); err != nil {
+		return truedemocracy.MerkleProofResult{}, "", fmt.Errorf("decode Merkle proof: %w", err)
+	}
+	vkSHA256 := state.VerifyingKeySHA256
+	if local := truedemocracy.VerifyingKeyFingerprint(localVK); state.RetiringVerifyingKeySHA256 != "" && local == state.RetiringVerifyingKeySHA256 {
+		vkSHA256 = local
+	}
+	return path, vkSHA256, nil
+}
+
+// Keys are the ceremony outputs a member proves with.
+type Keys struct {
+	ConstraintSystem []byte
+	ProvingKey       []byte
+	VerifyingKey     []byte
+}
+
+func readKeys(dir string) (Keys, error) {
+	var keys Keys
+	for name, target := range map[string]*[]byte{
+		zkpceremony.ConstraintSystemFile: &keys.ConstraintSystem,
+		zkpceremony.ProvingKeyFile:       &keys.ProvingKey,
+		zkpceremony.VerifyingKeyFile:     &keys.VerifyingKey,
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return Keys{}, fmt.Errorf("read %s: %w", name, err)
+		}
+		*target = data
+	}
+	return keys, nil
+}
+
+// readIdentitySecret reads a hex identity secret from path, or from in when
+// path is "-". Surrounding whitespace is ignored; the decoded secret must hold
+// 1-32 bytes.
+func readIdentitySecret(in io.Reader, path string) ([]byte, error) {
+	if path == "" {
+		return nil, fmt.Errorf(`--%s is required (use "-" for stdin)`, flagSecretFile)
+	}
+	var source io.Reader = in
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("open identity secret: %w", err)
+		}
+		defer file.Close()
+		source = file
+	}
+	raw, err := io.ReadAll(io.LimitReader(source, maxSecretFileBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read identity secret: %w", err)
+	}
+	defer clear(raw)
+	if len(raw) > maxSecretFileBytes {
+		return nil, fmt.Errorf("identity secret input exceeds %d bytes", maxSecretFileBytes)
+	}
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || len(trimmed)%2 != 0 {
+		return nil, fmt.Errorf("identity secret must be non-empty hex")
+	}
+	secret := make([]byte, len(trimmed)/2)
+	if _, err := hex.Decode(secret, trimmed); err != nil {
+		clear(secret)
+		return nil, fmt.Errorf("identity secret must be non-empty hex")
+	}
+	if len(secret) > 32 {
+		clear(secret)
+		return nil, fmt.Errorf("identity secret must contain 1-32 bytes")
+	}
+	return secret, nil
+}
@@ -0,0 +1,176 @@
+package zkpclient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"truerepublic/x/truedemocracy"
+)
+
+func TestReadIdentitySecret(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "secret")
+	if err := os.WriteFile(file, []byte("00c8\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fromFile, err := readIdentitySecret(strings.NewReader("ignored"), file)
+	if err != nil || !bytes.Equal(fromFile, []byte{0x00, 0xc8}) {
+		t.Fatalf("file secret = %x, %v", fromFile, err)
+	}
+	fromStdin, err := readIdentitySecret(strings.NewReader("  00C8\r\n"), "-")
+	if err != nil || !bytes.Equal(fromStdin, fromFile) {
+		t.Fatalf("stdin secret = %x, %v", fromStdin, err)
+	}
+
+	tests := []struct {
+		name, path, input string
+	}{
+		{"no source", "", "00c8"},
+		{"missing file", filepath.Join(dir, "missing"), ""},
+		{"empty", "-", " \n"},
+		{"odd length", "-", "0c8"},
+		{"not hex", "-", "zz"},
+		{"over 32 bytes", "-", strings.Repeat("01", 33)},
+		{"oversized input", "-", strings.Repeat(" ", maxSecretFileBytes) + "01"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := readIdentitySecret(strings.NewReader(tc.input), tc.path); err == nil {
+				t.Fatal("invalid secret accepted")
+			}
+		})
+	}
+}
+
+func TestProveCommandNeverTakesSecretFromArgs(t *testing.T) {
+	cmd := NewCommand()
+	cmd.SetArgs([]string{"prove", "Domain", "Issue", "Suggestion", "3", "00c8"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "accepts 4 arg(s)") {
+		t.Fatalf("extra positional argument result = %v", err)
+	}
+	prove, _, err := NewCommand().Find([]string{"prove"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{flagSecretFile, flagKeysDir, flagRewardRecipient, "from", "generate-only", "chain-id"} {
+		if prove.Flags().Lookup(name) == nil {
+			t.Fatalf("prove is missing --%s", name)
+		}
+	}
+}
+
+func TestProveRatingIsAcceptedByVerifier(t *testing.T) {
+	zkpKeys, err := truedemocracy.SetupMembershipCircuit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cs, pk bytes.Buffer
+	if _, err := zkpKeys.CS.WriteTo(&cs); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := zkpKeys.ProvingKey.WriteTo(&pk); err != nil {
+		t.Fatal(err)
+	}
+	vk, err := truedemocracy.SerializeVerifyingKey(zkpKeys.VerifyingKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := Keys{ConstraintSystem: cs.Bytes(), ProvingKey: pk.Bytes(), VerifyingKey: vk}
+	vkSHA256 := truedemocracy.VerifyingKeyFingerprint(vk)
+
+	secrets := [][]byte{big.NewInt(300).Bytes(), big.NewInt(301).Bytes(), big.NewInt(302).Bytes()}
+	leaves := make([][]byte, len(secrets))
+	for i, secret := range secrets {
+		if leaves[i], err = truedemocracy.ComputeCommitment(secret); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tree := truedemocracy.NewMerkleTree(truedemocracy.MerkleTreeDepth)
+	if err := tree.BuildFromLeaves(leaves); err != nil {
+		t.Fatal(err)
+	}
+	siblings, pathIndices, err := tree.GenerateProof(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := truedemocracy.MerkleProofResult{
+		DomainName:  "ProveDomain",
+		Commitment:  hex.EncodeToString(leaves[1]),
+		Root:        tree.GetRoot(),
+		PathIndices: pathIndices,
+	}
+	for _, sibling := range siblings {
+		path.PathElements = append(path.PathElements, hex.EncodeToString(sibling))
+	}
+	vote := RatingVote{
+		ChainID:         "truerepublic-test",
+		DomainName:      "ProveDomain",
+		IssueName:       "Climate",
+		SuggestionName:  "GreenDeal",
+		Rating:          -2,
+		RewardRecipient: sdk.AccAddress("zkp-reward-recipient").String(),
+	}
+
+	msg, err := ProveRating(keys, vkSHA256, secrets[1], path, vote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg.Sender = sdk.AccAddress("relayer")
+	if err := msg.ValidateBasic(); err != nil {
+		t.Fatalf("emitted message invalid: %v", err)
+	}
+	if msg.Rating != -2 || msg.MerkleRoot != tree.GetRoot() || msg.RewardRecipient != vote.RewardRecipient {
+		t.Fatalf("emitted message = %+v", msg)
+	}
+	scope := truedemocracy.ComputeVoteNullifierScope(vote.ChainID, vote.DomainName, vote.IssueName, vote.SuggestionName)
+	verify := func(rating int, recipient string) error {
+		signal := truedemocracy.ComputeVoteSignalV2(vote.ChainID, vote.DomainName, vote.IssueName, vote.SuggestionName, rating, recipient)
+		proof, _ := hex.DecodeString(msg.Proof)
+		nullifier, _ := hex.DecodeString(msg.NullifierHash)
+		return truedemocracy.VerifyMembershipProofForSignal(zkpKeys.VerifyingKey, proof, tree.Root, nullifier, scope, signal)
+	}
+	if err := verify(vote.Rating, vote.RewardRecipient); err != nil {
+		t.Fatalf("chain verifier rejected the proof: %v", err)
+	}
+	if err := verify(3, vote.RewardRecipient); err == nil {
+		t.Fatal("proof verified for another rating")
+	}
+	if err := verify(vote.Rating, sdk.AccAddress("someone-else").String()); err == nil {
+		t.Fatal("proof verified for another reward recipient")
+	}
+
+	t.Run("rejects", func(t *testing.T) {
+		otherDomain := path
+		otherDomain.DomainName = "Elsewhere"
+		tests := []struct {
+			name     string
+			vkSHA256 string
+			secret   []byte
+			path     truedemocracy.MerkleProofResult
+			vote     RatingVote
+		}{
+			{"chain key mismatch", strings.Repeat("00", 32), secrets[1], path, vote},
+			{"another member's path", vkSHA256, secrets[0], path, vote},
+			{"another domain's path", vkSHA256, secrets[1], otherDomain, vote},
+			{"missing chain id", vkSHA256, secrets[1], path, RatingVote{DomainName: "ProveDomain", IssueName: "Climate", SuggestionName: "GreenDeal", RewardRecipient: vote.RewardRecipient}},
+			{"rating out of range", vkSHA256, secrets[1], path, RatingVote{ChainID: "c", DomainName: "ProveDomain", IssueName: "Climate", SuggestionName: "GreenDeal", Rating: 6, RewardRecipient: vote.RewardRecipient}},
+			{"missing recipient", vkSHA256, secrets[1], path, RatingVote{ChainID: "c", DomainName: "ProveDomain", IssueName: "Climate", SuggestionName: "GreenDeal"}},
+		}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				if _, err := ProveRating(keys, tc.vkSHA256, tc.secret, tc.path, tc.vote); err == nil {
+					t.Fatal("rating proved")
+				}
+			})
+		}
+	})
+}
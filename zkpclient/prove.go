@@ -0,0 +1,83 @@
+package zkpclient
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"truerepublic/internal/zkpprover"
+	"truerepublic/x/truedemocracy"
+)
+
+// RatingVote is the public context a rating proof is bound to. The chain ID,
+// domain, issue and suggestion fix the nullifier scope; the rating and reward
+// recipient also enter the v2 signal.
+type RatingVote struct {
+	ChainID         string
+	DomainName      string
+	IssueName       string
+	SuggestionName  string
+	Rating          int
+	RewardRecipient string
+}
+
+func (v RatingVote) validate() error {
+	if v.ChainID == "" {
+		return fmt.Errorf("chain ID is required: the proof is bound to it")
+	}
+	if v.DomainName == "" || v.IssueName == "" || v.SuggestionName == "" {
+		return fmt.Errorf("domain, issue and suggestion are required")
+	}
+	if v.Rating < -5 || v.Rating > 5 {
+		return fmt.Errorf("rating must be between -5 and +5")
+	}
+	if _, err := truedemocracy.ValidateRewardRecipient(v.RewardRecipient); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ProveRating proves that secret's commitment sits at path in the domain tree
+// and returns the MsgRateWithProof for vote without a sender; the relaying
+// account fills it in and signs. vkSHA256 is the fingerprint of the chain's
+// verifying key, which keys.VerifyingKey must match.
+func ProveRating(keys Keys, vkSHA256 string, secret []byte, path truedemocracy.MerkleProofResult, vote RatingVote) (truedemocracy.MsgRateWithProof, error) {
+	if err := vote.validate(); err != nil {
+		return truedemocracy.MsgRateWithProof{}, err
+	}
+	if path.DomainName != vote.DomainName {
+		return truedemocracy.MsgRateWithProof{}, fmt.Errorf("merkle proof is for domain %q, not %q", path.DomainName, vote.DomainName)
+	}
+	commitment, err := truedemocracy.ComputeCommitment(secret)
+	if err != nil {
+		return truedemocracy.MsgRateWithProof{}, err
+	}
+	if path.Commitment != hex.EncodeToString(commitment) {
+		return truedemocracy.MsgRateWithProof{}, fmt.Errorf("merkle proof is for another identity commitment")
+	}
+
+	scope := truedemocracy.ComputeVoteNullifierScope(vote.ChainID, vote.DomainName, vote.IssueName, vote.SuggestionName)
+	signal := truedemocracy.ComputeVoteSignalV2(vote.ChainID, vote.DomainName, vote.IssueName, vote.SuggestionName, vote.Rating, vote.RewardRecipient)
+	result, err := zkpprover.ProveMember(keys.ConstraintSystem, keys.ProvingKey, keys.VerifyingKey, vkSHA256, zkpprover.Request{
+		Schema:               zkpprover.RequestSchema,
+		CircuitID:            truedemocracy.MembershipCircuitID,
+		IdentitySecretHex:    hex.EncodeToString(secret),
+		MerkleRootHex:        path.Root,
+		SiblingsHex:          path.PathElements,
+		PathIndices:          path.PathIndices,
+		ExternalNullifierHex: hex.EncodeToString(scope),
+		SignalHashHex:        hex.EncodeToString(signal),
+	})
+	if err != nil {
+		return truedemocracy.MsgRateWithProof{}, err
+	}
+	return truedemocracy.MsgRateWithProof{
+		DomainName:      vote.DomainName,
+		IssueName:       vote.IssueName,
+		SuggestionName:  vote.SuggestionName,
+		Rating:          int32(vote.Rating),
+		Proof:           result.ProofHex,
+		NullifierHash:   result.NullifierHashHex,
+		MerkleRoot:      result.MerkleRootHex,
+		RewardRecipient: vote.RewardRecipient,
+	}, nil
+}